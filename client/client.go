@@ -0,0 +1,171 @@
+// Package client calls the BookMyShow REST API from Go. The request and response types and one method per
+// endpoint, in client_gen.go, are generated from the server's OpenAPI document, which is also written to
+// openapi.json; run go generate ./client after changing a route.
+package client
+
+//go:generate go run ./gen -o client_gen.go -spec openapi.json
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client calls the API at one base URL. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+
+	mutex sync.RWMutex
+	token string
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithToken makes requests act as the session's user
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient sends requests through httpClient instead of http.DefaultClient, e.g. to set a timeout
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// New creates a client for the API at baseURL, e.g. http://localhost:8080
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// SetToken makes later requests act as the session's user, e.g. with the token Login returns;
+// an empty token makes them anonymous
+func (c *Client) SetToken(token string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.token = token
+}
+
+// Error is an answer outside 2xx, carrying the API's error message and code
+type Error struct {
+	StatusCode int
+	Message    string
+	Code       string         // Stable machine-readable code, e.g. SEAT_NOT_AVAILABLE
+	Retryable  bool           // Sending the same request again may succeed
+	Details    map[string]any // Facts about the failure, e.g. retry_after_seconds
+	RetryAfter time.Duration  // When to try again, from the Retry-After header of 429 and 503 answers
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bookmyshow api: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// do sends the request and decodes the JSON answer into out, when out is not nil
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	resp, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("bookmyshow api: decoding %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// bytes sends the request and returns the whole answer, for endpoints that serve files
+func (c *Client) bytes(ctx context.Context, method, path string, query url.Values) ([]byte, error) {
+	resp, err := c.send(ctx, method, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// stream sends the request and hands back the open answer, for endpoints that keep writing; the caller closes it
+func (c *Client) stream(ctx context.Context, method, path string, query url.Values) (io.ReadCloser, error) {
+	resp, err := c.send(ctx, method, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// send makes the request, turning answers outside 2xx into an *Error
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body any) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("bookmyshow api: encoding %s %s: %w", method, path, err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.mutex.RLock()
+	token := c.token
+	c.mutex.RUnlock()
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	var errBody struct {
+		Error     string         `json:"error"`
+		Code      string         `json:"code"`
+		Retryable bool           `json:"retryable"`
+		Details   map[string]any `json:"details"`
+	}
+	if json.NewDecoder(resp.Body).Decode(&errBody) == nil {
+		apiErr.Message, apiErr.Code, apiErr.Retryable, apiErr.Details = errBody.Error, errBody.Code, errBody.Retryable, errBody.Details
+	}
+	return nil, apiErr
+}
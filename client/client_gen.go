@@ -0,0 +1,3083 @@
+// Code generated by go run ./gen from the OpenAPI document; DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// AddCityRequest is the AddCityRequest schema
+type AddCityRequest struct {
+	Name   string `json:"name"`
+	Region string `json:"region,omitempty"`
+}
+
+// AddInstrumentRequest is the AddInstrumentRequest schema
+type AddInstrumentRequest struct {
+	CardNumber string `json:"card_number,omitempty"`
+	Expiry     string `json:"expiry,omitempty"`
+	Handle     string `json:"handle,omitempty"`
+	Type       string `json:"type"`
+}
+
+// AddOnOffer is the AddOnOffer schema
+type AddOnOffer struct {
+	Available   *int64  `json:"available,omitempty"`
+	Description string  `json:"description"`
+	MaxPerSeat  bool    `json:"max_per_seat,omitempty"`
+	PerBooking  bool    `json:"per_booking,omitempty"`
+	Percent     float64 `json:"percent,omitempty"`
+	Type        string  `json:"type"`
+	UnitPrice   *Money  `json:"unit_price,omitempty"`
+}
+
+// AddOnRequest is the AddOnRequest schema
+type AddOnRequest struct {
+	Quantity int64  `json:"quantity,omitempty"`
+	Type     string `json:"type"`
+}
+
+// AddScreenRequest is the AddScreenRequest schema
+type AddScreenRequest struct {
+	BasePrice float64 `json:"base_price"`
+	Currency  string  `json:"currency,omitempty"`
+	Name      string  `json:"name"`
+}
+
+// AdminScreenRequest is the AdminScreenRequest schema
+type AdminScreenRequest struct {
+	BasePrice float64          `json:"base_price"`
+	Currency  string           `json:"currency,omitempty"`
+	Name      string           `json:"name"`
+	Rows      []*RowConfig     `json:"rows,omitempty"`
+	Sections  []*SectionConfig `json:"sections,omitempty"`
+}
+
+// AppliedProfileDiscount is the AppliedProfileDiscount schema
+type AppliedProfileDiscount struct {
+	Amount   *Money `json:"amount"`
+	Category string `json:"category"`
+}
+
+// AuditEntry is the AuditEntry schema
+type AuditEntry struct {
+	Action     string            `json:"action"`
+	ActorID    string            `json:"actor_id"`
+	At         time.Time         `json:"at"`
+	Details    map[string]string `json:"details,omitempty"`
+	EntityID   string            `json:"entity_id"`
+	EntityType string            `json:"entity_type"`
+	ID         string            `json:"id"`
+}
+
+// AuthSession is the AuthSession schema
+type AuthSession struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Token     string    `json:"token"`
+	User      *User     `json:"user"`
+}
+
+// AvailabilitySummary is the AvailabilitySummary schema
+type AvailabilitySummary struct {
+	Available int64                  `json:"available"`
+	Badge     string                 `json:"badge"`
+	Blocked   int64                  `json:"blocked"`
+	Booked    int64                  `json:"booked"`
+	ByType    map[string]*SeatCounts `json:"by_type"`
+	CountedAt time.Time              `json:"counted_at"`
+	ShowID    string                 `json:"show_id"`
+	Total     int64                  `json:"total"`
+	Withheld  int64                  `json:"withheld"`
+}
+
+// BlockUserRequest is the BlockUserRequest schema
+type BlockUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Booking is the Booking schema
+type Booking struct {
+	AddOns          []*BookingAddOn         `json:"add_ons,omitempty"`
+	BookingTime     time.Time               `json:"booking_time"`
+	BulkBookingID   string                  `json:"bulk_booking_id,omitempty"`
+	CouponCode      string                  `json:"coupon_code,omitempty"`
+	CreatedAt       time.Time               `json:"created_at"`
+	DiscountAmount  *Money                  `json:"discount_amount"`
+	ExpiryTime      time.Time               `json:"expiry_time"`
+	ExtraPaymentIDs []string                `json:"extra_payment_ids,omitempty"`
+	HoldExtendedAt  time.Time               `json:"hold_extended_at"`
+	HoldID          string                  `json:"hold_id,omitempty"`
+	ID              string                  `json:"id"`
+	PaymentAttempts []string                `json:"payment_attempts,omitempty"`
+	PaymentID       string                  `json:"payment_id,omitempty"`
+	PriceBreakdown  *PriceBreakdown         `json:"price_breakdown"`
+	ProfileDiscount *AppliedProfileDiscount `json:"profile_discount,omitempty"`
+	Reference       string                  `json:"reference"`
+	RemindedAt      *time.Time              `json:"reminded_at,omitempty"`
+	SeatIDs         []string                `json:"seat_ids"`
+	ShowID          string                  `json:"show_id"`
+	SoldPaymentIDs  []string                `json:"sold_payment_ids,omitempty"`
+	Status          string                  `json:"status"`
+	StatusHistory   []*BookingStatusChange  `json:"status_history,omitempty"`
+	SubtotalAmount  *Money                  `json:"subtotal_amount"`
+	TotalAmount     *Money                  `json:"total_amount"`
+	Transfers       []*BookingTransfer      `json:"transfers,omitempty"`
+	UpdatedAt       time.Time               `json:"updated_at"`
+	UserID          string                  `json:"user_id"`
+	WithGuardian    bool                    `json:"with_guardian,omitempty"`
+}
+
+// BookingAddOn is the BookingAddOn schema
+type BookingAddOn struct {
+	Amount      *Money     `json:"amount"`
+	Description string     `json:"description"`
+	Error       string     `json:"error,omitempty"`
+	FulfilledAt *time.Time `json:"fulfilled_at,omitempty"`
+	Quantity    int64      `json:"quantity"`
+	Reference   string     `json:"reference,omitempty"`
+	Status      string     `json:"status"`
+	Type        string     `json:"type"`
+	UnitPrice   *Money     `json:"unit_price"`
+}
+
+// BookingDetails is the BookingDetails schema
+type BookingDetails struct {
+	Booking        *Booking        `json:"booking"`
+	Event          *Event          `json:"event,omitempty"`
+	LineItems      []*LineItem     `json:"line_items"`
+	Movie          *Movie          `json:"movie,omitempty"`
+	Payment        *Payment        `json:"payment,omitempty"`
+	PriceBreakdown *PriceBreakdown `json:"price_breakdown"`
+	Screen         *Screen         `json:"screen"`
+	Seats          []*Seat         `json:"seats"`
+	Show           *Show           `json:"show"`
+	Theatre        *Theatre        `json:"theatre"`
+}
+
+// BookingStatusChange is the BookingStatusChange schema
+type BookingStatusChange struct {
+	At    time.Time `json:"at"`
+	Event string    `json:"event"`
+	From  string    `json:"from"`
+	To    string    `json:"to"`
+}
+
+// BookingSummary is the BookingSummary schema
+type BookingSummary struct {
+	BookedAt    time.Time `json:"booked_at"`
+	BookingID   string    `json:"booking_id"`
+	Category    string    `json:"category"`
+	City        string    `json:"city"`
+	EventID     string    `json:"event_id,omitempty"`
+	EventType   string    `json:"event_type"`
+	MovieID     string    `json:"movie_id,omitempty"`
+	Reference   string    `json:"reference"`
+	ScreenName  string    `json:"screen_name"`
+	Seats       []string  `json:"seats"`
+	ShowID      string    `json:"show_id"`
+	ShowStart   time.Time `json:"show_start"`
+	Status      string    `json:"status"`
+	TheatreName string    `json:"theatre_name"`
+	Title       string    `json:"title"`
+	TotalAmount *Money    `json:"total_amount"`
+}
+
+// BookingTimeoutRequest is the BookingTimeoutRequest schema
+type BookingTimeoutRequest struct {
+	Minutes int64 `json:"minutes"`
+}
+
+// BookingTransfer is the BookingTransfer schema
+type BookingTransfer struct {
+	FromUserID  string     `json:"from_user_id"`
+	ID          string     `json:"id"`
+	OfferedAt   time.Time  `json:"offered_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+	Status      string     `json:"status"`
+	ToUserID    string     `json:"to_user_id"`
+}
+
+// BulkBookingDetails is the BulkBookingDetails schema
+type BulkBookingDetails struct {
+	AccountID       string      `json:"account_id"`
+	Booking         *Booking    `json:"booking"`
+	BookingID       string      `json:"booking_id"`
+	Codes           []*BulkCode `json:"codes"`
+	CreatedAt       time.Time   `json:"created_at"`
+	ID              string      `json:"id"`
+	Issued          int64       `json:"issued"`
+	Organization    string      `json:"organization"`
+	Redeemed        int64       `json:"redeemed"`
+	ReleaseDeadline time.Time   `json:"release_deadline"`
+	Released        int64       `json:"released"`
+	ShowID          string      `json:"show_id"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+}
+
+// BulkCode is the BulkCode schema
+type BulkCode struct {
+	Code       string     `json:"code"`
+	RedeemedAt *time.Time `json:"redeemed_at,omitempty"`
+	RedeemedBy string     `json:"redeemed_by,omitempty"`
+	ReleasedAt *time.Time `json:"released_at,omitempty"`
+	Seat       string     `json:"seat"`
+	SeatID     string     `json:"seat_id"`
+	Status     string     `json:"status"`
+}
+
+// BulkPass is the BulkPass schema
+type BulkPass struct {
+	BookingReference string    `json:"booking_reference"`
+	Code             *BulkCode `json:"code"`
+	Organization     string    `json:"organization"`
+	Show             *Show     `json:"show"`
+}
+
+// BulkRelease is the BulkRelease schema
+type BulkRelease struct {
+	BulkBooking     *BulkBookingDetails `json:"bulk_booking"`
+	PriceDifference *Money              `json:"price_difference"`
+	Refunds         []*Refund           `json:"refunds,omitempty"`
+	Released        []*BulkCode         `json:"released"`
+}
+
+// BulkShowsRequest is the BulkShowsRequest schema
+type BulkShowsRequest struct {
+	BasePrice float64            `json:"base_price"`
+	Currency  string             `json:"currency,omitempty"`
+	Format    string             `json:"format,omitempty"`
+	Language  string             `json:"language,omitempty"`
+	MovieID   string             `json:"movie_id"`
+	ScreenID  string             `json:"screen_id"`
+	Slots     []*ShowSlotRequest `json:"slots"`
+	TheatreID string             `json:"theatre_id"`
+	WeekStart time.Time          `json:"week_start"`
+	Weeks     int64              `json:"weeks"`
+}
+
+// BulkShowsResponse is the BulkShowsResponse schema
+type BulkShowsResponse struct {
+	Errors []string `json:"errors,omitempty"`
+	Shows  []*Show  `json:"shows"`
+}
+
+// BuyResaleRequest is the BuyResaleRequest schema
+type BuyResaleRequest struct {
+	InstrumentID string `json:"instrument_id,omitempty"`
+	Method       string `json:"method"`
+	TenureMonths int64  `json:"tenure_months,omitempty"`
+}
+
+// CalendarDay is the CalendarDay schema
+type CalendarDay struct {
+	Date       time.Time `json:"date"`
+	Kind       string    `json:"kind"`
+	Multiplier float64   `json:"multiplier"`
+	Name       string    `json:"name"`
+}
+
+// CancelShowRequest is the CancelShowRequest schema
+type CancelShowRequest struct {
+	Reason string `json:"reason"`
+}
+
+// CancellationPolicy is the CancellationPolicy schema
+type CancellationPolicy struct {
+	Tiers []*CancellationTier `json:"tiers"`
+}
+
+// CancellationPolicyRequest is the CancellationPolicyRequest schema
+type CancellationPolicyRequest struct {
+	Tiers []*CancellationTier `json:"tiers"`
+}
+
+// CancellationTier is the CancellationTier schema
+type CancellationTier struct {
+	HoursBefore   int64   `json:"hours_before"`
+	RefundPercent float64 `json:"refund_percent"`
+}
+
+// CancelledShowBooking is the CancelledShowBooking schema
+type CancelledShowBooking struct {
+	BookingID string    `json:"booking_id"`
+	Refunds   []*Refund `json:"refunds,omitempty"`
+	UserID    string    `json:"user_id"`
+}
+
+// CatalogImport is the CatalogImport schema
+type CatalogImport struct {
+	Created int64    `json:"created"`
+	Skipped []string `json:"skipped,omitempty"`
+	Source  string   `json:"source"`
+	Updated int64    `json:"updated"`
+}
+
+// CheckInRequest is the CheckInRequest schema
+type CheckInRequest struct {
+	Gate    string `json:"gate,omitempty"`
+	Payload string `json:"payload"`
+}
+
+// City is the City schema
+type City struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Region    string    `json:"region,omitempty"`
+}
+
+// CloneScreenRequest is the CloneScreenRequest schema
+type CloneScreenRequest struct {
+	Name      string `json:"name"`
+	TheatreID string `json:"theatre_id,omitempty"`
+}
+
+// CompleteChallengeRequest is the CompleteChallengeRequest schema
+type CompleteChallengeRequest struct {
+	Otp string `json:"otp"`
+}
+
+// ConfirmBookingRequest is the ConfirmBookingRequest schema
+type ConfirmBookingRequest struct {
+	PaymentID string `json:"payment_id"`
+}
+
+// Coupon is the Coupon schema
+type Coupon struct {
+	Code         string    `json:"code"`
+	CreatedAt    time.Time `json:"created_at"`
+	DiscountType string    `json:"discount_type"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	ID           string    `json:"id"`
+	MinAmount    *Money    `json:"min_amount"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	UsageLimit   int64     `json:"usage_limit"`
+	UsedCount    int64     `json:"used_count"`
+	Value        float64   `json:"value"`
+}
+
+// CreateBookingRequest is the CreateBookingRequest schema
+type CreateBookingRequest struct {
+	AddOns       []*AddOnRequest `json:"add_ons,omitempty"`
+	CouponCode   string          `json:"coupon_code,omitempty"`
+	HoldID       string          `json:"hold_id,omitempty"`
+	SeatIDs      []string        `json:"seat_ids"`
+	ShowID       string          `json:"show_id"`
+	WithGuardian bool            `json:"with_guardian,omitempty"`
+}
+
+// CreateCouponRequest is the CreateCouponRequest schema
+type CreateCouponRequest struct {
+	Code         string    `json:"code"`
+	Currency     string    `json:"currency,omitempty"`
+	DiscountType string    `json:"discount_type"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	MinAmount    float64   `json:"min_amount"`
+	UsageLimit   int64     `json:"usage_limit"`
+	Value        float64   `json:"value"`
+}
+
+// CreateEventRequest is the CreateEventRequest schema
+type CreateEventRequest struct {
+	Description     string   `json:"description"`
+	DurationMinutes int64    `json:"duration_minutes"`
+	Language        string   `json:"language"`
+	Performers      []string `json:"performers,omitempty"`
+	Title           string   `json:"title"`
+	Type            string   `json:"type"`
+}
+
+// CreateHoldRequest is the CreateHoldRequest schema
+type CreateHoldRequest struct {
+	SeatIDs []string `json:"seat_ids"`
+	ShowID  string   `json:"show_id"`
+}
+
+// CreateMovieRequest is the CreateMovieRequest schema
+type CreateMovieRequest struct {
+	Certificate     string    `json:"certificate,omitempty"`
+	Description     string    `json:"description"`
+	DurationMinutes int64     `json:"duration_minutes"`
+	Genre           string    `json:"genre"`
+	Language        string    `json:"language"`
+	Rating          float32   `json:"rating"`
+	ReleaseDate     time.Time `json:"release_date"`
+	Title           string    `json:"title"`
+}
+
+// CreateShowRequest is the CreateShowRequest schema
+type CreateShowRequest struct {
+	BasePrice             float64   `json:"base_price"`
+	BookingTimeoutMinutes int64     `json:"booking_timeout_minutes,omitempty"`
+	Currency              string    `json:"currency,omitempty"`
+	EventID               string    `json:"event_id,omitempty"`
+	Format                string    `json:"format,omitempty"`
+	Language              string    `json:"language,omitempty"`
+	MovieID               string    `json:"movie_id,omitempty"`
+	ScreenID              string    `json:"screen_id"`
+	StartTime             time.Time `json:"start_time"`
+	TheatreID             string    `json:"theatre_id"`
+}
+
+// CreateTheatreRequest is the CreateTheatreRequest schema
+type CreateTheatreRequest struct {
+	Address   string   `json:"address"`
+	City      string   `json:"city"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	Name      string   `json:"name"`
+}
+
+// DailyRevenue is the DailyRevenue schema
+type DailyRevenue struct {
+	Date     string `json:"date"`
+	Gross    *Money `json:"gross"`
+	Net      *Money `json:"net"`
+	Payments int64  `json:"payments"`
+	Refunded *Money `json:"refunded"`
+}
+
+// DateOfBirthRequest is the DateOfBirthRequest schema
+type DateOfBirthRequest struct {
+	DateOfBirth string `json:"date_of_birth"`
+}
+
+// Deal is the Deal schema
+type Deal struct {
+	City      string    `json:"city"`
+	EndsAt    time.Time `json:"ends_at"`
+	EventID   string    `json:"event_id,omitempty"`
+	ID        string    `json:"id"`
+	MovieID   string    `json:"movie_id,omitempty"`
+	Occupancy float64   `json:"occupancy"`
+	Percent   float64   `json:"percent"`
+	ShowID    string    `json:"show_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	TheatreID string    `json:"theatre_id"`
+}
+
+// DiscountProfile is the DiscountProfile schema
+type DiscountProfile struct {
+	Category     string     `json:"category"`
+	DocumentID   string     `json:"document_id"`
+	RejectReason string     `json:"reject_reason,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	ReviewedBy   string     `json:"reviewed_by,omitempty"`
+	Status       string     `json:"status"`
+	SubmittedAt  time.Time  `json:"submitted_at"`
+	ValidUntil   *time.Time `json:"valid_until,omitempty"`
+}
+
+// DiscountProfileRequest is the DiscountProfileRequest schema
+type DiscountProfileRequest struct {
+	Category   string `json:"category"`
+	DocumentID string `json:"document_id"`
+	ValidUntil string `json:"valid_until,omitempty"`
+}
+
+// ErrorResponse is the ErrorResponse schema
+type ErrorResponse struct {
+	Code      string                     `json:"code"`
+	Details   map[string]json.RawMessage `json:"details,omitempty"`
+	Error     string                     `json:"error"`
+	Retryable bool                       `json:"retryable,omitempty"`
+}
+
+// Event is the Event schema
+type Event struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Description string    `json:"description"`
+	Duration    int64     `json:"duration"` // Nanoseconds
+	ID          string    `json:"id"`
+	Language    string    `json:"language"`
+	Performers  []string  `json:"performers,omitempty"`
+	Title       string    `json:"title"`
+	Type        string    `json:"type"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// FeeConfig is the FeeConfig schema
+type FeeConfig struct {
+	ConvenienceFeePercent float64 `json:"convenience_fee_percent"`
+	GstPercent            float64 `json:"gst_percent"`
+}
+
+// GatewayCallback is the GatewayCallback schema
+type GatewayCallback struct {
+	Amount        *Money `json:"amount"`
+	ID            string `json:"id"`
+	PaymentID     string `json:"payment_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+	RefundID      string `json:"refund_id,omitempty"`
+	TransactionID string `json:"transaction_id,omitempty"`
+	Type          string `json:"type"`
+}
+
+// GatewayCallbackResponse is the GatewayCallbackResponse schema
+type GatewayCallbackResponse struct {
+	Payment *Payment `json:"payment,omitempty"`
+	Status  string   `json:"status"`
+}
+
+// GenerateSettlementRequest is the GenerateSettlementRequest schema
+type GenerateSettlementRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// GeoPoint is the GeoPoint schema
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// GrantRoleRequest is the GrantRoleRequest schema
+type GrantRoleRequest struct {
+	Role       string   `json:"role"`
+	TheatreIDs []string `json:"theatre_ids,omitempty"`
+}
+
+// HouseSeat is the HouseSeat schema
+type HouseSeat struct {
+	BlockedAt time.Time `json:"blocked_at"`
+	BlockedBy string    `json:"blocked_by"`
+	Reason    string    `json:"reason"`
+	SeatID    string    `json:"seat_id"`
+	Status    string    `json:"status"`
+}
+
+// Installment is the Installment schema
+type Installment struct {
+	Amount  *Money    `json:"amount"`
+	DueDate time.Time `json:"due_date"`
+	Number  int64     `json:"number"`
+}
+
+// InstallmentPlan is the InstallmentPlan schema
+type InstallmentPlan struct {
+	AnnualInterestRate float64        `json:"annual_interest_rate"`
+	Installments       []*Installment `json:"installments"`
+	Interest           *Money         `json:"interest"`
+	Principal          *Money         `json:"principal"`
+	TenureMonths       int64          `json:"tenure_months"`
+	TotalPayable       *Money         `json:"total_payable"`
+}
+
+// LineItem is the LineItem schema
+type LineItem struct {
+	Amount      *Money `json:"amount"`
+	Description string `json:"description"`
+}
+
+// ListForResaleRequest is the ListForResaleRequest schema
+type ListForResaleRequest struct {
+	Currency string  `json:"currency,omitempty"`
+	Price    float64 `json:"price"`
+}
+
+// LoginRequest is the LoginRequest schema
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoyaltyAccount is the LoyaltyAccount schema
+type LoyaltyAccount struct {
+	LifetimePoints int64     `json:"lifetime_points"`
+	Points         int64     `json:"points"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	UserID         string    `json:"user_id"`
+}
+
+// MaintenanceRequest is the MaintenanceRequest schema
+type MaintenanceRequest struct {
+	Offline bool `json:"offline"`
+}
+
+// MarkSettlementPaidRequest is the MarkSettlementPaidRequest schema
+type MarkSettlementPaidRequest struct {
+	Reference string `json:"reference"`
+}
+
+// ModerateReviewRequest is the ModerateReviewRequest schema
+type ModerateReviewRequest struct {
+	Approve bool   `json:"approve"`
+	Note    string `json:"note,omitempty"`
+}
+
+// ModifySeatsRequest is the ModifySeatsRequest schema
+type ModifySeatsRequest struct {
+	SeatIDs []string `json:"seat_ids"`
+}
+
+// Money is the Money schema
+type Money struct {
+	Currency   string `json:"currency"`
+	MinorUnits int64  `json:"minor_units"`
+}
+
+// Movie is the Movie schema
+type Movie struct {
+	BaseRating  float32     `json:"base_rating"`
+	Certificate string      `json:"certificate,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	Description string      `json:"description"`
+	Duration    int64       `json:"duration"` // Nanoseconds
+	ExternalID  string      `json:"external_id,omitempty"`
+	Genre       string      `json:"genre"`
+	Genres      []string    `json:"genres,omitempty"`
+	ID          string      `json:"id"`
+	Language    string      `json:"language"`
+	PosterURL   string      `json:"poster_url,omitempty"`
+	Rating      float32     `json:"rating"`
+	ReleaseDate time.Time   `json:"release_date"`
+	ReviewCount int64       `json:"review_count"`
+	SaleWindow  *SaleWindow `json:"sale_window"`
+	Title       string      `json:"title"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// MovieRecommendation is the MovieRecommendation schema
+type MovieRecommendation struct {
+	Movie   *Movie             `json:"movie"`
+	Reasons []string           `json:"reasons,omitempty"`
+	Score   float64            `json:"score"`
+	Scores  map[string]float64 `json:"scores"`
+}
+
+// MovieReviews is the MovieReviews schema
+type MovieReviews struct {
+	Limit   int64     `json:"limit"`
+	Offset  int64     `json:"offset"`
+	Reviews []*Review `json:"reviews"`
+	Total   int64     `json:"total"`
+}
+
+// NearbyTheatre is the NearbyTheatre schema
+type NearbyTheatre struct {
+	DistanceKm float64  `json:"distance_km"`
+	Theatre    *Theatre `json:"theatre"`
+}
+
+// Notification is the Notification schema
+type Notification struct {
+	Attachments   []*NotificationAttachment `json:"attachments,omitempty"`
+	Attempts      int64                     `json:"attempts"`
+	Channel       string                    `json:"channel"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	Fields        map[string]string         `json:"fields,omitempty"`
+	ID            string                    `json:"id"`
+	Kind          string                    `json:"kind"`
+	LastError     string                    `json:"last_error,omitempty"`
+	NextAttemptAt time.Time                 `json:"next_attempt_at"`
+	SentAt        *time.Time                `json:"sent_at,omitempty"`
+	Status        string                    `json:"status"`
+	Subject       string                    `json:"subject"`
+	UserID        string                    `json:"user_id"`
+}
+
+// NotificationAttachment is the NotificationAttachment schema
+type NotificationAttachment struct {
+	Content     []byte `json:"content"`
+	ContentType string `json:"content_type"`
+	Filename    string `json:"filename"`
+}
+
+// NowShowingMovie is the NowShowingMovie schema
+type NowShowingMovie struct {
+	FirstShow time.Time `json:"first_show"`
+	Formats   []string  `json:"formats"`
+	Languages []string  `json:"languages"`
+	Movie     *Movie    `json:"movie"`
+	Shows     int64     `json:"shows"`
+	Theatres  int64     `json:"theatres"`
+}
+
+// OTPChallenge is the OTPChallenge schema
+type OTPChallenge struct {
+	Attempts  int64     `json:"attempts,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Token     string    `json:"token"`
+}
+
+// OutboxMessage is the OutboxMessage schema
+type OutboxMessage struct {
+	Attempts      int64           `json:"attempts"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+	EventType     string          `json:"event_type"`
+	ID            string          `json:"id"`
+	LastError     string          `json:"last_error,omitempty"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+}
+
+// ParkingAvailability is the ParkingAvailability schema
+type ParkingAvailability struct {
+	Available int64  `json:"available"`
+	Capacity  int64  `json:"capacity"`
+	Reserved  int64  `json:"reserved"`
+	ShowID    string `json:"show_id"`
+	TheatreID string `json:"theatre_id"`
+}
+
+// ParkingCapacityRequest is the ParkingCapacityRequest schema
+type ParkingCapacityRequest struct {
+	Slots int64 `json:"slots"`
+}
+
+// ParkingReservation is the ParkingReservation schema
+type ParkingReservation struct {
+	BookingID string    `json:"booking_id"`
+	CreatedAt time.Time `json:"created_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	ID        string    `json:"id"`
+	ShowID    string    `json:"show_id"`
+	Slots     []string  `json:"slots"`
+	StartsAt  time.Time `json:"starts_at"`
+	Status    string    `json:"status"`
+	TheatreID string    `json:"theatre_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UserID    string    `json:"user_id"`
+}
+
+// Payment is the Payment schema
+type Payment struct {
+	Amount          *Money           `json:"amount"`
+	Attempt         int64            `json:"attempt,omitempty"`
+	BookingID       string           `json:"booking_id"`
+	Challenge       *OTPChallenge    `json:"challenge,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	FailureReason   string           `json:"failure_reason,omitempty"`
+	GatewayResponse string           `json:"gateway_response,omitempty"`
+	ID              string           `json:"id"`
+	Installments    *InstallmentPlan `json:"installments,omitempty"`
+	Method          string           `json:"method"`
+	ProcessedAt     *time.Time       `json:"processed_at,omitempty"`
+	RefundAmount    *Money           `json:"refund_amount"`
+	RefundReason    string           `json:"refund_reason,omitempty"`
+	RefundedAt      *time.Time       `json:"refunded_at,omitempty"`
+	Risk            *RiskAssessment  `json:"risk,omitempty"`
+	Status          string           `json:"status"`
+	TransactionID   string           `json:"transaction_id,omitempty"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	UserID          string           `json:"user_id"`
+}
+
+// PaymentInstrument is the PaymentInstrument schema
+type PaymentInstrument struct {
+	Brand     string    `json:"brand,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Expiry    string    `json:"expiry,omitempty"`
+	Handle    string    `json:"handle,omitempty"`
+	ID        string    `json:"id"`
+	Last4     string    `json:"last4,omitempty"`
+	Token     string    `json:"token,omitempty"`
+	Type      string    `json:"type"`
+	UserID    string    `json:"user_id"`
+}
+
+// PriceBreakdown is the PriceBreakdown schema
+type PriceBreakdown struct {
+	AddOns         *Money     `json:"add_ons"`
+	Cgst           *Money     `json:"cgst"`
+	ConvenienceFee *Money     `json:"convenience_fee"`
+	Discount       *Money     `json:"discount"`
+	Fees           *FeeConfig `json:"fees"`
+	LoyaltyPoints  int64      `json:"loyalty_points,omitempty"`
+	LoyaltyValue   *Money     `json:"loyalty_value"`
+	Sgst           *Money     `json:"sgst"`
+	Subtotal       *Money     `json:"subtotal"`
+	Total          *Money     `json:"total"`
+}
+
+// PricingDayRequest is the PricingDayRequest schema
+type PricingDayRequest struct {
+	Kind       string  `json:"kind"`
+	Multiplier float64 `json:"multiplier"`
+	Name       string  `json:"name,omitempty"`
+}
+
+// ProcessPaymentRequest is the ProcessPaymentRequest schema
+type ProcessPaymentRequest struct {
+	BookingID    string `json:"booking_id"`
+	InstrumentID string `json:"instrument_id,omitempty"`
+	Method       string `json:"method"`
+	TenureMonths int64  `json:"tenure_months,omitempty"`
+}
+
+// RatingSummary is the RatingSummary schema
+type RatingSummary struct {
+	AverageStars float64          `json:"average_stars"`
+	Distribution map[string]int64 `json:"distribution"`
+	MovieID      string           `json:"movie_id"`
+	Rating       float32          `json:"rating"`
+	ReviewCount  int64            `json:"review_count"`
+}
+
+// RedeemPointsRequest is the RedeemPointsRequest schema
+type RedeemPointsRequest struct {
+	Points int64 `json:"points"`
+}
+
+// Refund is the Refund schema
+type Refund struct {
+	Amount           *Money     `json:"amount"`
+	BookingID        string     `json:"booking_id"`
+	CreatedAt        time.Time  `json:"created_at"`
+	Destination      string     `json:"destination"`
+	FailureReason    string     `json:"failure_reason,omitempty"`
+	GatewayReference string     `json:"gateway_reference,omitempty"`
+	ID               string     `json:"id"`
+	PaymentID        string     `json:"payment_id"`
+	ProcessedAt      *time.Time `json:"processed_at,omitempty"`
+	Reason           string     `json:"reason"`
+	Status           string     `json:"status"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	UserID           string     `json:"user_id"`
+}
+
+// RefundPaymentRequest is the RefundPaymentRequest schema
+type RefundPaymentRequest struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency,omitempty"`
+	Reason   string  `json:"reason"`
+	ToWallet bool    `json:"to_wallet,omitempty"`
+}
+
+// RegisterScreenTemplateRequest is the RegisterScreenTemplateRequest schema
+type RegisterScreenTemplateRequest struct {
+	Description string           `json:"description,omitempty"`
+	Name        string           `json:"name"`
+	Rows        []*RowConfig     `json:"rows,omitempty"`
+	Sections    []*SectionConfig `json:"sections,omitempty"`
+}
+
+// RegisterSeatTypeRequest is the RegisterSeatTypeRequest schema
+type RegisterSeatTypeRequest struct {
+	Description string  `json:"description,omitempty"`
+	Multiplier  float64 `json:"multiplier"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+}
+
+// RegisterWebhookRequest is the RegisterWebhookRequest schema
+type RegisterWebhookRequest struct {
+	Events []string `json:"events,omitempty"`
+	URL    string   `json:"url"`
+}
+
+// RejectDiscountProfileRequest is the RejectDiscountProfileRequest schema
+type RejectDiscountProfileRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ReleaseBulkSeatsRequest is the ReleaseBulkSeatsRequest schema
+type ReleaseBulkSeatsRequest struct {
+	Codes []string `json:"codes,omitempty"`
+}
+
+// ReleaseHouseSeatsRequest is the ReleaseHouseSeatsRequest schema
+type ReleaseHouseSeatsRequest struct {
+	SeatIDs []string `json:"seat_ids,omitempty"`
+}
+
+// ResaleListing is the ResaleListing schema
+type ResaleListing struct {
+	BookingID    string     `json:"booking_id"`
+	BuyerID      string     `json:"buyer_id,omitempty"`
+	FaceValue    *Money     `json:"face_value"`
+	Fee          *Money     `json:"fee"`
+	ID           string     `json:"id"`
+	ListedAt     time.Time  `json:"listed_at"`
+	PaymentID    string     `json:"payment_id,omitempty"`
+	Price        *Money     `json:"price"`
+	Reason       string     `json:"reason,omitempty"`
+	RefundIDs    []string   `json:"refund_ids,omitempty"`
+	Seats        int64      `json:"seats"`
+	SellerID     string     `json:"seller_id"`
+	SellerPayout *Money     `json:"seller_payout"`
+	ShowID       string     `json:"show_id"`
+	SoldAt       *time.Time `json:"sold_at,omitempty"`
+	Status       string     `json:"status"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// ResalePurchase is the ResalePurchase schema
+type ResalePurchase struct {
+	Booking *Booking       `json:"booking"`
+	Listing *ResaleListing `json:"listing"`
+	Payment *Payment       `json:"payment"`
+	Refunds []*Refund      `json:"refunds"`
+}
+
+// RescheduleShowRequest is the RescheduleShowRequest schema
+type RescheduleShowRequest struct {
+	StartTime time.Time `json:"start_time"`
+}
+
+// ReserveBulkBlockRequest is the ReserveBulkBlockRequest schema
+type ReserveBulkBlockRequest struct {
+	Organization    string    `json:"organization"`
+	ReleaseDeadline time.Time `json:"release_deadline"`
+	Rows            []string  `json:"rows,omitempty"`
+	SeatIDs         []string  `json:"seat_ids,omitempty"`
+	Seats           int64     `json:"seats,omitempty"`
+	ShowID          string    `json:"show_id"`
+}
+
+// RetryPaymentRequest is the RetryPaymentRequest schema
+type RetryPaymentRequest struct {
+	InstrumentID string `json:"instrument_id,omitempty"`
+	Method       string `json:"method"`
+	TenureMonths int64  `json:"tenure_months,omitempty"`
+}
+
+// RevenueTotals is the RevenueTotals schema
+type RevenueTotals struct {
+	Gross    *Money `json:"gross"`
+	Net      *Money `json:"net"`
+	Payments int64  `json:"payments"`
+	Refunded *Money `json:"refunded"`
+}
+
+// Review is the Review schema
+type Review struct {
+	CreatedAt      time.Time `json:"created_at"`
+	ID             string    `json:"id"`
+	ModeratedBy    string    `json:"moderated_by,omitempty"`
+	ModerationNote string    `json:"moderation_note,omitempty"`
+	MovieID        string    `json:"movie_id"`
+	Stars          int64     `json:"stars"`
+	Status         string    `json:"status"`
+	Text           string    `json:"text,omitempty"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	UserID         string    `json:"user_id"`
+}
+
+// ReviewRequest is the ReviewRequest schema
+type ReviewRequest struct {
+	Stars int64  `json:"stars"`
+	Text  string `json:"text,omitempty"`
+}
+
+// RiskAssessment is the RiskAssessment schema
+type RiskAssessment struct {
+	AssessedAt time.Time     `json:"assessed_at"`
+	Decision   string        `json:"decision"`
+	Score      int64         `json:"score"`
+	Signals    []*RiskSignal `json:"signals,omitempty"`
+}
+
+// RiskSignal is the RiskSignal schema
+type RiskSignal struct {
+	Reason string `json:"reason"`
+	Rule   string `json:"rule"`
+	Score  int64  `json:"score"`
+}
+
+// RowConfig is the RowConfig schema
+type RowConfig struct {
+	AisleAfter []int64 `json:"aisle_after,omitempty"`
+	Companion  []int64 `json:"companion,omitempty"`
+	Count      int64   `json:"count"`
+	GroupSize  int64   `json:"group_size,omitempty"`
+	Missing    []int64 `json:"missing,omitempty"`
+	Name       string  `json:"name"`
+	Offset     int64   `json:"offset,omitempty"`
+	Type       string  `json:"type"`
+	Wheelchair []int64 `json:"wheelchair,omitempty"`
+}
+
+// SaleWindow is the SaleWindow schema
+type SaleWindow struct {
+	OpensAt     time.Time `json:"opens_at"`
+	OpensBefore int64     `json:"opens_before,omitempty"` // Nanoseconds
+}
+
+// SaleWindowRequest is the SaleWindowRequest schema
+type SaleWindowRequest struct {
+	OpensAt          time.Time `json:"opens_at"`
+	OpensBeforeHours int64     `json:"opens_before_hours,omitempty"`
+}
+
+// Screen is the Screen schema
+type Screen struct {
+	Aisles    map[string][]int64 `json:"aisles,omitempty"`
+	Capacity  int64              `json:"capacity"`
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	Seats     map[string]*Seat   `json:"seats"`
+	Status    string             `json:"status"`
+	TheatreID string             `json:"theatre_id"`
+}
+
+// ScreenConfig is the ScreenConfig schema
+type ScreenConfig struct {
+	Rows     []*RowConfig     `json:"rows,omitempty"`
+	Sections []*SectionConfig `json:"sections,omitempty"`
+}
+
+// ScreenTemplate is the ScreenTemplate schema
+type ScreenTemplate struct {
+	Capacity    int64         `json:"capacity"`
+	Description string        `json:"description"`
+	Layout      *ScreenConfig `json:"layout"`
+	Name        string        `json:"name"`
+}
+
+// Seat is the Seat schema
+type Seat struct {
+	Attributes []string `json:"attributes,omitempty"`
+	GroupID    string   `json:"group_id,omitempty"`
+	ID         string   `json:"id"`
+	Number     int64    `json:"number"`
+	Price      *Money   `json:"price"`
+	RowName    string   `json:"row_name"`
+	Section    string   `json:"section,omitempty"`
+	Type       string   `json:"type"`
+	X          int64    `json:"x,omitempty"`
+	Y          int64    `json:"y,omitempty"`
+}
+
+// SeatCounts is the SeatCounts schema
+type SeatCounts struct {
+	Available int64 `json:"available"`
+	Blocked   int64 `json:"blocked"`
+	Booked    int64 `json:"booked"`
+	Total     int64 `json:"total"`
+	Withheld  int64 `json:"withheld"`
+}
+
+// SeatHold is the SeatHold schema
+type SeatHold struct {
+	BookingID  string    `json:"booking_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Extensions int64     `json:"extensions"`
+	ID         string    `json:"id"`
+	SeatIDs    []string  `json:"seat_ids"`
+	ShowID     string    `json:"show_id"`
+	Status     string    `json:"status"`
+	Ttl        int64     `json:"ttl,omitempty"` // Nanoseconds
+	UpdatedAt  time.Time `json:"updated_at"`
+	UserID     string    `json:"user_id"`
+}
+
+// SeatMap is the SeatMap schema
+type SeatMap struct {
+	Available int64         `json:"available"`
+	Capacity  int64         `json:"capacity"`
+	Rows      []*SeatMapRow `json:"rows"`
+	ScreenID  string        `json:"screen_id"`
+	ShowID    string        `json:"show_id,omitempty"`
+}
+
+// SeatMapCell is the SeatMapCell schema
+type SeatMapCell struct {
+	Aisle      bool     `json:"aisle,omitempty"`
+	Attributes []string `json:"attributes,omitempty"`
+	Gap        bool     `json:"gap,omitempty"`
+	Group      string   `json:"group,omitempty"`
+	Label      string   `json:"label,omitempty"`
+	Number     int64    `json:"number,omitempty"`
+	Price      *Money   `json:"price"`
+	SeatID     string   `json:"seat_id,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Type       string   `json:"type,omitempty"`
+	X          int64    `json:"x,omitempty"`
+}
+
+// SeatMapRow is the SeatMapRow schema
+type SeatMapRow struct {
+	Cells   []*SeatMapCell `json:"cells"`
+	Name    string         `json:"name"`
+	Section string         `json:"section,omitempty"`
+	Y       int64          `json:"y,omitempty"`
+}
+
+// SeatModification is the SeatModification schema
+type SeatModification struct {
+	Booking         *Booking  `json:"booking"`
+	Payment         *Payment  `json:"payment,omitempty"`
+	PriceDifference *Money    `json:"price_difference"`
+	Refunds         []*Refund `json:"refunds,omitempty"`
+}
+
+// SeatSuggestion is the SeatSuggestion schema
+type SeatSuggestion struct {
+	Labels  []string `json:"labels"`
+	Row     string   `json:"row"`
+	SeatIDs []string `json:"seat_ids"`
+	ShowID  string   `json:"show_id"`
+	Total   *Money   `json:"total"`
+	Type    string   `json:"type"`
+}
+
+// SeatTypeInfo is the SeatTypeInfo schema
+type SeatTypeInfo struct {
+	Description string  `json:"description"`
+	Multiplier  float64 `json:"multiplier"`
+	Name        string  `json:"name"`
+}
+
+// SeatTypeSales is the SeatTypeSales schema
+type SeatTypeSales struct {
+	Capacity         int64   `json:"capacity"`
+	OccupancyPercent float64 `json:"occupancy_percent"`
+	SeatsSold        int64   `json:"seats_sold"`
+	TicketSales      *Money  `json:"ticket_sales"`
+	Type             string  `json:"type"`
+}
+
+// SectionConfig is the SectionConfig schema
+type SectionConfig struct {
+	Name            string       `json:"name"`
+	PriceMultiplier float64      `json:"price_multiplier,omitempty"`
+	Rows            []*RowConfig `json:"rows"`
+}
+
+// Settlement is the Settlement schema
+type Settlement struct {
+	BookingIDs        []string   `json:"booking_ids"`
+	Commission        *Money     `json:"commission"`
+	CommissionPercent float64    `json:"commission_percent"`
+	CreatedAt         time.Time  `json:"created_at"`
+	ID                string     `json:"id"`
+	PaidAt            *time.Time `json:"paid_at,omitempty"`
+	Payable           *Money     `json:"payable"`
+	PayoutReference   string     `json:"payout_reference,omitempty"`
+	PeriodEnd         time.Time  `json:"period_end"`
+	PeriodStart       time.Time  `json:"period_start"`
+	Seats             int64      `json:"seats"`
+	Status            string     `json:"status"`
+	TheatreID         string     `json:"theatre_id"`
+	TicketSales       *Money     `json:"ticket_sales"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// SettlementStatement is the SettlementStatement schema
+type SettlementStatement struct {
+	Outstanding *Money        `json:"outstanding"`
+	Paid        *Money        `json:"paid"`
+	Settlements []*Settlement `json:"settlements"`
+	TheatreID   string        `json:"theatre_id"`
+}
+
+// Show is the Show schema
+type Show struct {
+	BasePrice       *Money                `json:"base_price"`
+	BookingTimeout  int64                 `json:"booking_timeout,omitempty"` // Nanoseconds
+	CancelReason    string                `json:"cancel_reason,omitempty"`
+	CreatedAt       time.Time             `json:"created_at"`
+	EndTime         time.Time             `json:"end_time"`
+	EventID         string                `json:"event_id,omitempty"`
+	EventType       string                `json:"event_type"`
+	Format          string                `json:"format,omitempty"`
+	FormatSurcharge *Money                `json:"format_surcharge"`
+	HouseSeats      map[string]*HouseSeat `json:"house_seats,omitempty"`
+	ID              string                `json:"id"`
+	Language        string                `json:"language,omitempty"`
+	MovieID         string                `json:"movie_id,omitempty"`
+	SaleWindow      *SaleWindow           `json:"sale_window"`
+	ScreenID        string                `json:"screen_id"`
+	Seats           map[string]string     `json:"seats,omitempty"`
+	StartTime       time.Time             `json:"start_time"`
+	Status          string                `json:"status"`
+	Summary         *ShowSummary          `json:"summary,omitempty"`
+	TheatreID       string                `json:"theatre_id"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+// ShowCancellation is the ShowCancellation schema
+type ShowCancellation struct {
+	Bookings []*CancelledShowBooking `json:"bookings"`
+	Failures []string                `json:"failures,omitempty"`
+	Show     *Show                   `json:"show"`
+}
+
+// ShowReport is the ShowReport schema
+type ShowReport struct {
+	Attended         int64            `json:"attended,omitempty"`
+	Capacity         int64            `json:"capacity"`
+	Final            bool             `json:"final,omitempty"`
+	NoShows          int64            `json:"no_shows,omitempty"`
+	OccupancyPercent float64          `json:"occupancy_percent"`
+	Revenue          *RevenueTotals   `json:"revenue"`
+	ScreenID         string           `json:"screen_id"`
+	SeatTypes        []*SeatTypeSales `json:"seat_types"`
+	SeatsSold        int64            `json:"seats_sold"`
+	ShowID           string           `json:"show_id"`
+	StartTime        time.Time        `json:"start_time"`
+	TheatreID        string           `json:"theatre_id"`
+}
+
+// ShowReschedule is the ShowReschedule schema
+type ShowReschedule struct {
+	Failures      []string  `json:"failures,omitempty"`
+	NotifiedUsers int64     `json:"notified_users"`
+	PreviousStart time.Time `json:"previous_start"`
+	Show          *Show     `json:"show"`
+}
+
+// ShowSlotRequest is the ShowSlotRequest schema
+type ShowSlotRequest struct {
+	StartTime string `json:"start_time"`
+	Weekday   string `json:"weekday"`
+}
+
+// ShowSummary is the ShowSummary schema
+type ShowSummary struct {
+	AttendancePercent float64   `json:"attendance_percent"`
+	Attended          int64     `json:"attended"`
+	Capacity          int64     `json:"capacity"`
+	ClosedAt          time.Time `json:"closed_at"`
+	Gross             *Money    `json:"gross"`
+	Net               *Money    `json:"net"`
+	NoShowBookings    int64     `json:"no_show_bookings"`
+	NoShows           int64     `json:"no_shows"`
+	OccupancyPercent  float64   `json:"occupancy_percent"`
+	Payments          int64     `json:"payments"`
+	Refunded          *Money    `json:"refunded"`
+	SeatsSold         int64     `json:"seats_sold"`
+}
+
+// SignupRequest is the SignupRequest schema
+type SignupRequest struct {
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	Password    string `json:"password"`
+	PhoneNumber string `json:"phone_number"`
+}
+
+// SlotSuggestion is the SlotSuggestion schema
+type SlotSuggestion struct {
+	Duration   int64       `json:"duration"` // Nanoseconds
+	MovieID    string      `json:"movie_id"`
+	ScreenID   string      `json:"screen_id"`
+	StartTimes []time.Time `json:"start_times"`
+	Turnaround int64       `json:"turnaround"` // Nanoseconds
+}
+
+// TemplateScreensRequest is the TemplateScreensRequest schema
+type TemplateScreensRequest struct {
+	BasePrice float64  `json:"base_price"`
+	Currency  string   `json:"currency,omitempty"`
+	Names     []string `json:"names"`
+	Template  string   `json:"template"`
+}
+
+// Theatre is the Theatre schema
+type Theatre struct {
+	Address            string              `json:"address"`
+	BookingTimeout     int64               `json:"booking_timeout,omitempty"` // Nanoseconds
+	CancellationPolicy *CancellationPolicy `json:"cancellation_policy,omitempty"`
+	City               string              `json:"city"`
+	CreatedAt          time.Time           `json:"created_at"`
+	ID                 string              `json:"id"`
+	Location           *GeoPoint           `json:"location,omitempty"`
+	Name               string              `json:"name"`
+	ParkingSlots       int64               `json:"parking_slots,omitempty"`
+	SaleWindow         *SaleWindow         `json:"sale_window"`
+	Screens            map[string]*Screen  `json:"screens"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+}
+
+// TheatreRevenueReport is the TheatreRevenueReport schema
+type TheatreRevenueReport struct {
+	Days      []*DailyRevenue `json:"days"`
+	TheatreID string          `json:"theatre_id"`
+	Total     *RevenueTotals  `json:"total"`
+}
+
+// Ticket is the Ticket schema
+type Ticket struct {
+	BookingID   string    `json:"booking_id"`
+	CheckedInAt time.Time `json:"checked_in_at"`
+	CheckedInBy string    `json:"checked_in_by,omitempty"`
+	ID          string    `json:"id"`
+	IssuedAt    time.Time `json:"issued_at"`
+	Payload     string    `json:"payload"`
+	Reissues    int64     `json:"reissues,omitempty"`
+	ShowID      string    `json:"show_id"`
+	Status      string    `json:"status"`
+	TheatreID   string    `json:"theatre_id"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	UserID      string    `json:"user_id"`
+}
+
+// TicketValidation is the TicketValidation schema
+type TicketValidation struct {
+	ScreenName string    `json:"screen_name"`
+	Seats      []string  `json:"seats"`
+	ShowStart  time.Time `json:"show_start"`
+	Ticket     *Ticket   `json:"ticket"`
+	Title      string    `json:"title"`
+}
+
+// TopUpRequest is the TopUpRequest schema
+type TopUpRequest struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+// TransferBookingRequest is the TransferBookingRequest schema
+type TransferBookingRequest struct {
+	Email string `json:"email"`
+}
+
+// TrendingMovie is the TrendingMovie schema
+type TrendingMovie struct {
+	Bookings    int64  `json:"bookings"`
+	Movie       *Movie `json:"movie"`
+	Rank        int64  `json:"rank"`
+	SeatsBooked int64  `json:"seats_booked"`
+}
+
+// User is the User schema
+type User struct {
+	BlockReason     string           `json:"block_reason,omitempty"`
+	Blocked         bool             `json:"blocked,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	DateOfBirth     *time.Time       `json:"date_of_birth,omitempty"`
+	DiscountProfile *DiscountProfile `json:"discount_profile,omitempty"`
+	Email           string           `json:"email"`
+	ID              string           `json:"id"`
+	Name            string           `json:"name"`
+	PhoneNumber     string           `json:"phone_number"`
+	Preferences     *UserPreferences `json:"preferences"`
+	Role            string           `json:"role"`
+	TheatreIDs      []string         `json:"theatre_ids,omitempty"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	WhatsappOptIn   *WhatsAppOptIn   `json:"whatsapp_opt_in,omitempty"`
+}
+
+// UserBookings is the UserBookings schema
+type UserBookings struct {
+	Bookings []*BookingSummary `json:"bookings"`
+	Limit    int64             `json:"limit"`
+	Offset   int64             `json:"offset"`
+	Total    int64             `json:"total"`
+}
+
+// UserPreferences is the UserPreferences schema
+type UserPreferences struct {
+	Channels           []string `json:"channels,omitempty"`
+	FavoriteGenres     []string `json:"favorite_genres,omitempty"`
+	FavoriteTheatreIDs []string `json:"favorite_theatre_ids,omitempty"`
+	HomeCity           string   `json:"home_city,omitempty"`
+	PreferredLanguages []string `json:"preferred_languages,omitempty"`
+}
+
+// Wallet is the Wallet schema
+type Wallet struct {
+	Balance   *Money    `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UserID    string    `json:"user_id"`
+}
+
+// WalletStatement is the WalletStatement schema
+type WalletStatement struct {
+	Limit        int64                `json:"limit"`
+	Offset       int64                `json:"offset"`
+	Total        int64                `json:"total"`
+	Transactions []*WalletTransaction `json:"transactions"`
+	Wallet       *Wallet              `json:"wallet"`
+}
+
+// WalletTransaction is the WalletTransaction schema
+type WalletTransaction struct {
+	Amount       *Money    `json:"amount"`
+	BalanceAfter *Money    `json:"balance_after"`
+	CreatedAt    time.Time `json:"created_at"`
+	Description  string    `json:"description"`
+	ID           string    `json:"id"`
+	Reference    string    `json:"reference,omitempty"`
+	Type         string    `json:"type"`
+	UserID       string    `json:"user_id"`
+	WalletID     string    `json:"wallet_id"`
+}
+
+// WatchlistEntry is the WatchlistEntry schema
+type WatchlistEntry struct {
+	AddedAt        time.Time  `json:"added_at"`
+	ID             string     `json:"id"`
+	MovieID        string     `json:"movie_id"`
+	RemindedAt     *time.Time `json:"reminded_at,omitempty"`
+	RemindedCity   string     `json:"reminded_city,omitempty"`
+	RemindedShowID string     `json:"reminded_show_id,omitempty"`
+	UserID         string     `json:"user_id"`
+}
+
+// WatchlistItem is the WatchlistItem schema
+type WatchlistItem struct {
+	AddedAt        time.Time  `json:"added_at"`
+	ID             string     `json:"id"`
+	Movie          *Movie     `json:"movie"`
+	MovieID        string     `json:"movie_id"`
+	RemindedAt     *time.Time `json:"reminded_at,omitempty"`
+	RemindedCity   string     `json:"reminded_city,omitempty"`
+	RemindedShowID string     `json:"reminded_show_id,omitempty"`
+	UserID         string     `json:"user_id"`
+}
+
+// WatchlistRequest is the WatchlistRequest schema
+type WatchlistRequest struct {
+	MovieID string `json:"movie_id"`
+}
+
+// Webhook is the Webhook schema
+type Webhook struct {
+	CreatedAt  time.Time `json:"created_at"`
+	CreatedBy  string    `json:"created_by,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	ID         string    `json:"id"`
+	Secret     string    `json:"secret,omitempty"`
+	TheatreID  string    `json:"theatre_id"`
+	URL        string    `json:"url"`
+}
+
+// WebhookDelivery is the WebhookDelivery schema
+type WebhookDelivery struct {
+	Attempts       int64           `json:"attempts"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	EventType      string          `json:"event_type"`
+	ID             string          `json:"id"`
+	LastError      string          `json:"last_error,omitempty"`
+	LastStatusCode int64           `json:"last_status_code,omitempty"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	WebhookID      string          `json:"webhook_id"`
+}
+
+// WhatsAppOptIn is the WhatsAppOptIn schema
+type WhatsAppOptIn struct {
+	OptedIn    bool       `json:"opted_in"`
+	OptedInAt  *time.Time `json:"opted_in_at,omitempty"`
+	OptedOutAt *time.Time `json:"opted_out_at,omitempty"`
+}
+
+// WhatsAppOptInRequest is the WhatsAppOptInRequest schema
+type WhatsAppOptInRequest struct {
+	OptedIn bool `json:"opted_in"`
+}
+
+// WhatsAppTemplate is the WhatsAppTemplate schema
+type WhatsAppTemplate struct {
+	Body      string    `json:"body"`
+	Header    string    `json:"header,omitempty"`
+	Kind      string    `json:"kind"`
+	Language  string    `json:"language"`
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WhatsAppTemplateRequest is the WhatsAppTemplateRequest schema
+type WhatsAppTemplateRequest struct {
+	Body     string `json:"body"`
+	Header   string `json:"header,omitempty"`
+	Language string `json:"language"`
+	Name     string `json:"name"`
+}
+
+// WithholdSeatsRequest is the WithholdSeatsRequest schema
+type WithholdSeatsRequest struct {
+	Reason  string   `json:"reason"`
+	SeatIDs []string `json:"seat_ids"`
+	Status  string   `json:"status"`
+}
+
+// AcceptTransfer calls POST /bookings/{id}/transfer/accept - accept a booking offered to you; its ticket is signed again for you
+func (c *Client) AcceptTransfer(ctx context.Context, id string) (*Booking, error) {
+	var out Booking
+	if err := c.do(ctx, "POST", "/bookings/"+url.PathEscape(id)+"/transfer/accept", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AddCity calls POST /admin/cities - add a city
+func (c *Client) AddCity(ctx context.Context, req AddCityRequest) (*City, error) {
+	var out City
+	if err := c.do(ctx, "POST", "/admin/cities", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AddInstrument calls POST /users/{id}/instruments - save a card, UPI handle or wallet link to pay with later
+func (c *Client) AddInstrument(ctx context.Context, id string, req AddInstrumentRequest) (*PaymentInstrument, error) {
+	var out PaymentInstrument
+	if err := c.do(ctx, "POST", "/users/"+url.PathEscape(id)+"/instruments", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AddScreen calls POST /theatres/{id}/screens - add a screen with the default seat layout
+func (c *Client) AddScreen(ctx context.Context, id string, req AddScreenRequest) (*Screen, error) {
+	var out Screen
+	if err := c.do(ctx, "POST", "/theatres/"+url.PathEscape(id)+"/screens", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AddScreensFromTemplate calls POST /admin/theatres/{id}/screens/from-template - add one screen per name, laid out by a screen template
+func (c *Client) AddScreensFromTemplate(ctx context.Context, id string, req TemplateScreensRequest) ([]*Screen, error) {
+	var out []*Screen
+	if err := c.do(ctx, "POST", "/admin/theatres/"+url.PathEscape(id)+"/screens/from-template", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AddToWatchlist calls POST /users/{id}/watchlist - watchlist a movie; adding it again returns the existing entry
+func (c *Client) AddToWatchlist(ctx context.Context, id string, req WatchlistRequest) (*WatchlistEntry, error) {
+	var out WatchlistEntry
+	if err := c.do(ctx, "POST", "/users/"+url.PathEscape(id)+"/watchlist", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminAddScreen calls POST /admin/theatres/{id}/screens - add a screen with a custom layout: rows or priced sections, aisles and missing seats
+func (c *Client) AdminAddScreen(ctx context.Context, id string, req AdminScreenRequest) (*Screen, error) {
+	var out Screen
+	if err := c.do(ctx, "POST", "/admin/theatres/"+url.PathEscape(id)+"/screens", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BlockUser calls POST /admin/users/{id}/block - stop a user holding seats or booking
+func (c *Client) BlockUser(ctx context.Context, id string, req BlockUserRequest) (*User, error) {
+	var out User
+	if err := c.do(ctx, "POST", "/admin/users/"+url.PathEscape(id)+"/block", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BuyResaleListing calls POST /resale/{id}/buy - buy a listed booking; the seller is refunded the price less the resale fee
+func (c *Client) BuyResaleListing(ctx context.Context, id string, req BuyResaleRequest) (*ResalePurchase, error) {
+	var out ResalePurchase
+	if err := c.do(ctx, "POST", "/resale/"+url.PathEscape(id)+"/buy", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CancelBooking calls POST /bookings/{id}/cancel - cancel a booking, refunding it if it was confirmed
+func (c *Client) CancelBooking(ctx context.Context, id string) (*Booking, error) {
+	var out Booking
+	if err := c.do(ctx, "POST", "/bookings/"+url.PathEscape(id)+"/cancel", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CancelShow calls POST /admin/shows/{id}/cancel - cancel a show, refunding its bookings in full
+func (c *Client) CancelShow(ctx context.Context, id string, req CancelShowRequest) (*ShowCancellation, error) {
+	var out ShowCancellation
+	if err := c.do(ctx, "POST", "/admin/shows/"+url.PathEscape(id)+"/cancel", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CheckIn calls POST /theatres/{id}/checkin - admit a scanned ticket once
+func (c *Client) CheckIn(ctx context.Context, id string, req CheckInRequest) (*TicketValidation, error) {
+	var out TicketValidation
+	if err := c.do(ctx, "POST", "/theatres/"+url.PathEscape(id)+"/checkin", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ClearPricingDay calls DELETE /admin/pricing-calendar/{date} - take a date off the pricing calendar
+func (c *Client) ClearPricingDay(ctx context.Context, date string) error {
+	return c.do(ctx, "DELETE", "/admin/pricing-calendar/"+url.PathEscape(date), nil, nil, nil)
+}
+
+// CloneScreen calls POST /admin/screens/{id}/clone - copy a screen's layout to a new screen, in any theatre the caller manages
+func (c *Client) CloneScreen(ctx context.Context, id string, req CloneScreenRequest) (*Screen, error) {
+	var out Screen
+	if err := c.do(ctx, "POST", "/admin/screens/"+url.PathEscape(id)+"/clone", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CloseOutShow calls POST /admin/shows/{id}/close-out - close out a show that has ended: unscanned tickets become no-shows and its figures are final
+func (c *Client) CloseOutShow(ctx context.Context, id string) (*Show, error) {
+	var out Show
+	if err := c.do(ctx, "POST", "/admin/shows/"+url.PathEscape(id)+"/close-out", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CompleteChallenge calls POST /payments/{id}/challenge - enter the card issuer's OTP for a payment that requires action
+func (c *Client) CompleteChallenge(ctx context.Context, id string, req CompleteChallengeRequest) (*Payment, error) {
+	var out Payment
+	if err := c.do(ctx, "POST", "/payments/"+url.PathEscape(id)+"/challenge", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConfirmBooking calls POST /bookings/{id}/confirm - confirm a booking with its successful payment
+func (c *Client) ConfirmBooking(ctx context.Context, id string, req ConfirmBookingRequest) (*Booking, error) {
+	var out Booking
+	if err := c.do(ctx, "POST", "/bookings/"+url.PathEscape(id)+"/confirm", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateBooking calls POST /bookings - book seats; the booking stays pending until paid
+func (c *Client) CreateBooking(ctx context.Context, req CreateBookingRequest) (*Booking, error) {
+	var out Booking
+	if err := c.do(ctx, "POST", "/bookings", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateCoupon calls POST /coupons - create a coupon
+func (c *Client) CreateCoupon(ctx context.Context, req CreateCouponRequest) (*Coupon, error) {
+	var out Coupon
+	if err := c.do(ctx, "POST", "/coupons", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateEvent calls POST /events - add a concert, play or stand-up event
+func (c *Client) CreateEvent(ctx context.Context, req CreateEventRequest) (*Event, error) {
+	var out Event
+	if err := c.do(ctx, "POST", "/events", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateHold calls POST /holds - hold seats for a few minutes before booking
+func (c *Client) CreateHold(ctx context.Context, req CreateHoldRequest) (*SeatHold, error) {
+	var out SeatHold
+	if err := c.do(ctx, "POST", "/holds", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateMovie calls POST /movies - add a movie to the catalog
+func (c *Client) CreateMovie(ctx context.Context, req CreateMovieRequest) (*Movie, error) {
+	var out Movie
+	if err := c.do(ctx, "POST", "/movies", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateShow calls POST /shows - schedule a movie show, or a live event show with event_id
+func (c *Client) CreateShow(ctx context.Context, req CreateShowRequest) (*Show, error) {
+	var out Show
+	if err := c.do(ctx, "POST", "/shows", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateShowsFromTemplate calls POST /admin/shows/bulk - schedule shows from a weekly template
+func (c *Client) CreateShowsFromTemplate(ctx context.Context, req BulkShowsRequest) (*BulkShowsResponse, error) {
+	var out BulkShowsResponse
+	if err := c.do(ctx, "POST", "/admin/shows/bulk", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateTheatre calls POST /theatres - add a theatre
+func (c *Client) CreateTheatre(ctx context.Context, req CreateTheatreRequest) (*Theatre, error) {
+	var out Theatre
+	if err := c.do(ctx, "POST", "/theatres", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateUser calls POST /users - create a customer and sign them in, like /auth/signup
+func (c *Client) CreateUser(ctx context.Context, req SignupRequest) (*AuthSession, error) {
+	var out AuthSession
+	if err := c.do(ctx, "POST", "/users", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeclineTransfer calls POST /bookings/{id}/transfer/decline - decline a booking offered to you
+func (c *Client) DeclineTransfer(ctx context.Context, id string) (*Booking, error) {
+	var out Booking
+	if err := c.do(ctx, "POST", "/bookings/"+url.PathEscape(id)+"/transfer/decline", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteWebhook calls DELETE /admin/webhooks/{id} - delete a webhook
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/admin/webhooks/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// EditReview calls PUT /reviews/{id} - edit your review; it goes back to moderation
+func (c *Client) EditReview(ctx context.Context, id string, req ReviewRequest) (*Review, error) {
+	var out Review
+	if err := c.do(ctx, "PUT", "/reviews/"+url.PathEscape(id), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExtendBookingHold calls POST /bookings/{id}/extend - extend a pending booking's payment window, once per booking and a few times a day
+func (c *Client) ExtendBookingHold(ctx context.Context, id string) (*Booking, error) {
+	var out Booking
+	if err := c.do(ctx, "POST", "/bookings/"+url.PathEscape(id)+"/extend", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExtendHold calls POST /holds/{id}/extend - extend a hold's expiry
+func (c *Client) ExtendHold(ctx context.Context, id string) (*SeatHold, error) {
+	var out SeatHold
+	if err := c.do(ctx, "POST", "/holds/"+url.PathEscape(id)+"/extend", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GenerateSettlement calls POST /admin/theatres/{id}/settlements - settle a period that is already over
+func (c *Client) GenerateSettlement(ctx context.Context, id string, req GenerateSettlementRequest) (*Settlement, error) {
+	var out Settlement
+	if err := c.do(ctx, "POST", "/admin/theatres/"+url.PathEscape(id)+"/settlements", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetActiveDealsParams are the query parameters of GetActiveDeals
+type GetActiveDealsParams struct {
+	City string // The signed-in user's home city by default, else every city
+}
+
+func (p GetActiveDealsParams) values() url.Values {
+	query := url.Values{}
+	if p.City != "" {
+		query.Set("city", p.City)
+	}
+	return query
+}
+
+// GetActiveDeals calls GET /shows/deals - last-minute deals running now, soonest show first
+func (c *Client) GetActiveDeals(ctx context.Context, params GetActiveDealsParams) ([]*Deal, error) {
+	var out []*Deal
+	if err := c.do(ctx, "GET", "/shows/deals", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAddOnOffers calls GET /shows/{id}/add-ons - add-ons a booking for the show can buy, e.g. parking
+func (c *Client) GetAddOnOffers(ctx context.Context, id string) ([]*AddOnOffer, error) {
+	var out []*AddOnOffer
+	if err := c.do(ctx, "GET", "/shows/"+url.PathEscape(id)+"/add-ons", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAuditTrail calls GET /admin/audit/{entityID} - every recorded change to an entity, oldest first
+func (c *Client) GetAuditTrail(ctx context.Context, entityID string) ([]*AuditEntry, error) {
+	var out []*AuditEntry
+	if err := c.do(ctx, "GET", "/admin/audit/"+url.PathEscape(entityID), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAvailabilitySummary calls GET /shows/{id}/availability - seat counts and a badge such as FILLING_FAST
+func (c *Client) GetAvailabilitySummary(ctx context.Context, id string) (*AvailabilitySummary, error) {
+	var out AvailabilitySummary
+	if err := c.do(ctx, "GET", "/shows/"+url.PathEscape(id)+"/availability", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBooking calls GET /bookings/{id} - a booking
+func (c *Client) GetBooking(ctx context.Context, id string) (*Booking, error) {
+	var out Booking
+	if err := c.do(ctx, "GET", "/bookings/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBookingByReferenceParams are the query parameters of GetBookingByReference
+type GetBookingByReferenceParams struct {
+	Reference string // Required. e.g. BMS-7F3K9Q
+}
+
+func (p GetBookingByReferenceParams) values() url.Values {
+	query := url.Values{}
+	query.Set("reference", p.Reference)
+	return query
+}
+
+// GetBookingByReference calls GET /bookings - a booking by its reference code
+func (c *Client) GetBookingByReference(ctx context.Context, params GetBookingByReferenceParams) (*Booking, error) {
+	var out Booking
+	if err := c.do(ctx, "GET", "/bookings", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBookingCalendar calls GET /bookings/{id}/calendar.ics - calendar event with the show times, theatre address, seats and reference
+func (c *Client) GetBookingCalendar(ctx context.Context, id string) ([]byte, error) {
+	return c.bytes(ctx, "GET", "/bookings/"+url.PathEscape(id)+"/calendar.ics", nil)
+}
+
+// GetBookingDetails calls GET /bookings/{id}/details - a booking with its show, theatre, seats, price breakdown and payment
+func (c *Client) GetBookingDetails(ctx context.Context, id string) (*BookingDetails, error) {
+	var out BookingDetails
+	if err := c.do(ctx, "GET", "/bookings/"+url.PathEscape(id)+"/details", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBookingTicket calls GET /bookings/{id}/ticket - a booking's e-ticket
+func (c *Client) GetBookingTicket(ctx context.Context, id string) (*Ticket, error) {
+	var out Ticket
+	if err := c.do(ctx, "GET", "/bookings/"+url.PathEscape(id)+"/ticket", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBulkBooking calls GET /bulk-bookings/{id} - a block with its codes and counts
+func (c *Client) GetBulkBooking(ctx context.Context, id string) (*BulkBookingDetails, error) {
+	var out BulkBookingDetails
+	if err := c.do(ctx, "GET", "/bulk-bookings/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCoupon calls GET /coupons/{code} - a coupon
+func (c *Client) GetCoupon(ctx context.Context, code string) (*Coupon, error) {
+	var out Coupon
+	if err := c.do(ctx, "GET", "/coupons/"+url.PathEscape(code), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDeadLetters calls GET /admin/outbox/dead-letters - events that ran out of delivery attempts
+func (c *Client) GetDeadLetters(ctx context.Context) ([]*OutboxMessage, error) {
+	var out []*OutboxMessage
+	if err := c.do(ctx, "GET", "/admin/outbox/dead-letters", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetEvent calls GET /events/{id} - a live event
+func (c *Client) GetEvent(ctx context.Context, id string) (*Event, error) {
+	var out Event
+	if err := c.do(ctx, "GET", "/events/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetHold calls GET /holds/{id} - a seat hold
+func (c *Client) GetHold(ctx context.Context, id string) (*SeatHold, error) {
+	var out SeatHold
+	if err := c.do(ctx, "GET", "/holds/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetHouseSeats calls GET /admin/shows/{id}/house-seats - seats held back from sale
+func (c *Client) GetHouseSeats(ctx context.Context, id string) ([]*HouseSeat, error) {
+	var out []*HouseSeat
+	if err := c.do(ctx, "GET", "/admin/shows/"+url.PathEscape(id)+"/house-seats", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetIncomingTransfers calls GET /users/{id}/transfers - bookings offered to the user, waiting for them to accept or decline
+func (c *Client) GetIncomingTransfers(ctx context.Context, id string) ([]*Booking, error) {
+	var out []*Booking
+	if err := c.do(ctx, "GET", "/users/"+url.PathEscape(id)+"/transfers", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetInstruments calls GET /users/{id}/instruments - saved cards, UPI handles and wallet links, oldest first
+func (c *Client) GetInstruments(ctx context.Context, id string) ([]*PaymentInstrument, error) {
+	var out []*PaymentInstrument
+	if err := c.do(ctx, "GET", "/users/"+url.PathEscape(id)+"/instruments", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetLoyaltyAccount calls GET /users/{id}/loyalty - loyalty points balance
+func (c *Client) GetLoyaltyAccount(ctx context.Context, id string) (*LoyaltyAccount, error) {
+	var out LoyaltyAccount
+	if err := c.do(ctx, "GET", "/users/"+url.PathEscape(id)+"/loyalty", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMovie calls GET /movies/{id} - a movie
+func (c *Client) GetMovie(ctx context.Context, id string) (*Movie, error) {
+	var out Movie
+	if err := c.do(ctx, "GET", "/movies/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMovieRating calls GET /movies/{id}/rating - aggregate rating and star distribution
+func (c *Client) GetMovieRating(ctx context.Context, id string) (*RatingSummary, error) {
+	var out RatingSummary
+	if err := c.do(ctx, "GET", "/movies/"+url.PathEscape(id)+"/rating", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetNearbyTheatresParams are the query parameters of GetNearbyTheatres
+type GetNearbyTheatresParams struct {
+	Lat      float64 // Required
+	Lng      float64 // Required
+	RadiusKm float64 // 10 by default
+}
+
+func (p GetNearbyTheatresParams) values() url.Values {
+	query := url.Values{}
+	query.Set("lat", strconv.FormatFloat(p.Lat, 'f', -1, 64))
+	query.Set("lng", strconv.FormatFloat(p.Lng, 'f', -1, 64))
+	if p.RadiusKm != 0 {
+		query.Set("radius_km", strconv.FormatFloat(p.RadiusKm, 'f', -1, 64))
+	}
+	return query
+}
+
+// GetNearbyTheatres calls GET /theatres/nearby - theatres near a location, nearest first
+func (c *Client) GetNearbyTheatres(ctx context.Context, params GetNearbyTheatresParams) ([]*NearbyTheatre, error) {
+	var out []*NearbyTheatre
+	if err := c.do(ctx, "GET", "/theatres/nearby", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetNotificationDeadLetters calls GET /admin/notifications/dead-letters - notifications that ran out of delivery attempts or could never be delivered
+func (c *Client) GetNotificationDeadLetters(ctx context.Context) ([]*Notification, error) {
+	var out []*Notification
+	if err := c.do(ctx, "GET", "/admin/notifications/dead-letters", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetNowShowingParams are the query parameters of GetNowShowing
+type GetNowShowingParams struct {
+	City string    // The signed-in user's home city by default, else every city
+	Date time.Time // Today by default
+}
+
+func (p GetNowShowingParams) values() url.Values {
+	query := url.Values{}
+	if p.City != "" {
+		query.Set("city", p.City)
+	}
+	if !p.Date.IsZero() {
+		query.Set("date", p.Date.Format(time.DateOnly))
+	}
+	return query
+}
+
+// GetNowShowing calls GET /shows/now-showing - movies bookable in a city that day, most shows first
+func (c *Client) GetNowShowing(ctx context.Context, params GetNowShowingParams) ([]*NowShowingMovie, error) {
+	var out []*NowShowingMovie
+	if err := c.do(ctx, "GET", "/shows/now-showing", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetParkingAvailability calls GET /shows/{id}/parking - parking slots left at the theatre for the length of the show
+func (c *Client) GetParkingAvailability(ctx context.Context, id string) (*ParkingAvailability, error) {
+	var out ParkingAvailability
+	if err := c.do(ctx, "GET", "/shows/"+url.PathEscape(id)+"/parking", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetParkingReservation calls GET /bookings/{id}/parking - the parking slots held or booked with a booking
+func (c *Client) GetParkingReservation(ctx context.Context, id string) (*ParkingReservation, error) {
+	var out ParkingReservation
+	if err := c.do(ctx, "GET", "/bookings/"+url.PathEscape(id)+"/parking", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPayment calls GET /payments/{id} - a payment
+func (c *Client) GetPayment(ctx context.Context, id string) (*Payment, error) {
+	var out Payment
+	if err := c.do(ctx, "GET", "/payments/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPaymentAttempts calls GET /bookings/{id}/payments - every payment attempt with its failure reason
+func (c *Client) GetPaymentAttempts(ctx context.Context, id string) ([]*Payment, error) {
+	var out []*Payment
+	if err := c.do(ctx, "GET", "/bookings/"+url.PathEscape(id)+"/payments", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetPreferences calls GET /users/{id}/preferences - home city, languages, genres and favourite theatres
+func (c *Client) GetPreferences(ctx context.Context, id string) (*UserPreferences, error) {
+	var out UserPreferences
+	if err := c.do(ctx, "GET", "/users/"+url.PathEscape(id)+"/preferences", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPricingCalendar calls GET /admin/pricing-calendar - holiday and peak days, earliest first
+func (c *Client) GetPricingCalendar(ctx context.Context) ([]*CalendarDay, error) {
+	var out []*CalendarDay
+	if err := c.do(ctx, "GET", "/admin/pricing-calendar", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRecommendationsParams are the query parameters of GetRecommendations
+type GetRecommendationsParams struct {
+	Limit int // Movies to return
+}
+
+func (p GetRecommendationsParams) values() url.Values {
+	query := url.Values{}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return query
+}
+
+// GetRecommendations calls GET /users/{id}/recommendations - movies the user hasn't booked, best first
+func (c *Client) GetRecommendations(ctx context.Context, id string, params GetRecommendationsParams) ([]*MovieRecommendation, error) {
+	var out []*MovieRecommendation
+	if err := c.do(ctx, "GET", "/users/"+url.PathEscape(id)+"/recommendations", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetResaleListing calls GET /resale/{id} - a resale listing
+func (c *Client) GetResaleListing(ctx context.Context, id string) (*ResaleListing, error) {
+	var out ResaleListing
+	if err := c.do(ctx, "GET", "/resale/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSeatAvailability calls GET /shows/{id}/seats - seat map with this show's seat status and prices
+func (c *Client) GetSeatAvailability(ctx context.Context, id string) (*SeatMap, error) {
+	var out SeatMap
+	if err := c.do(ctx, "GET", "/shows/"+url.PathEscape(id)+"/seats", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSellerResaleListings calls GET /users/{id}/resale - bookings the user has put up for resale, newest first
+func (c *Client) GetSellerResaleListings(ctx context.Context, id string) ([]*ResaleListing, error) {
+	var out []*ResaleListing
+	if err := c.do(ctx, "GET", "/users/"+url.PathEscape(id)+"/resale", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetShow calls GET /shows/{id} - a show
+func (c *Client) GetShow(ctx context.Context, id string) (*Show, error) {
+	var out Show
+	if err := c.do(ctx, "GET", "/shows/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetShowReport calls GET /admin/shows/{id}/report - occupancy and revenue of a show
+func (c *Client) GetShowReport(ctx context.Context, id string) (*ShowReport, error) {
+	var out ShowReport
+	if err := c.do(ctx, "GET", "/admin/shows/"+url.PathEscape(id)+"/report", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetShowResaleListings calls GET /shows/{id}/resale - bookings for the show on resale, cheapest first
+func (c *Client) GetShowResaleListings(ctx context.Context, id string) ([]*ResaleListing, error) {
+	var out []*ResaleListing
+	if err := c.do(ctx, "GET", "/shows/"+url.PathEscape(id)+"/resale", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetShowsByEvent calls GET /events/{id}/shows - every show of a live event
+func (c *Client) GetShowsByEvent(ctx context.Context, id string) ([]*Show, error) {
+	var out []*Show
+	if err := c.do(ctx, "GET", "/events/"+url.PathEscape(id)+"/shows", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetShowsByMovie calls GET /movies/{id}/shows - every show of a movie
+func (c *Client) GetShowsByMovie(ctx context.Context, id string) ([]*Show, error) {
+	var out []*Show
+	if err := c.do(ctx, "GET", "/movies/"+url.PathEscape(id)+"/shows", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetTheatre calls GET /theatres/{id} - a theatre with its screens
+func (c *Client) GetTheatre(ctx context.Context, id string) (*Theatre, error) {
+	var out Theatre
+	if err := c.do(ctx, "GET", "/theatres/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTheatreRevenueParams are the query parameters of GetTheatreRevenue
+type GetTheatreRevenueParams struct {
+	From time.Time // Inclusive UTC day; a week before to by default
+	To   time.Time // Inclusive UTC day; today by default
+}
+
+func (p GetTheatreRevenueParams) values() url.Values {
+	query := url.Values{}
+	if !p.From.IsZero() {
+		query.Set("from", p.From.Format(time.DateOnly))
+	}
+	if !p.To.IsZero() {
+		query.Set("to", p.To.Format(time.DateOnly))
+	}
+	return query
+}
+
+// GetTheatreRevenue calls GET /admin/theatres/{id}/revenue - daily revenue of a theatre
+func (c *Client) GetTheatreRevenue(ctx context.Context, id string, params GetTheatreRevenueParams) (*TheatreRevenueReport, error) {
+	var out TheatreRevenueReport
+	if err := c.do(ctx, "GET", "/admin/theatres/"+url.PathEscape(id)+"/revenue", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTheatreSettlements calls GET /admin/theatres/{id}/settlements - a theatre's settlements and what is still unsettled
+func (c *Client) GetTheatreSettlements(ctx context.Context, id string) (*SettlementStatement, error) {
+	var out SettlementStatement
+	if err := c.do(ctx, "GET", "/admin/theatres/"+url.PathEscape(id)+"/settlements", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTheatresByCity calls GET /cities/{id}/theatres - theatres in a city, by name
+func (c *Client) GetTheatresByCity(ctx context.Context, id string) ([]*Theatre, error) {
+	var out []*Theatre
+	if err := c.do(ctx, "GET", "/cities/"+url.PathEscape(id)+"/theatres", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetTicketQRCodeParams are the query parameters of GetTicketQRCode
+type GetTicketQRCodeParams struct {
+	Size int // Edge length in pixels
+}
+
+func (p GetTicketQRCodeParams) values() url.Values {
+	query := url.Values{}
+	if p.Size != 0 {
+		query.Set("size", strconv.Itoa(p.Size))
+	}
+	return query
+}
+
+// GetTicketQRCode calls GET /tickets/{id}/qr - qR code of the signed ticket payload
+func (c *Client) GetTicketQRCode(ctx context.Context, id string, params GetTicketQRCodeParams) ([]byte, error) {
+	return c.bytes(ctx, "GET", "/tickets/"+url.PathEscape(id)+"/qr", params.values())
+}
+
+// GetTrendingParams are the query parameters of GetTrending
+type GetTrendingParams struct {
+	City string // The signed-in user's home city by default, else every city
+}
+
+func (p GetTrendingParams) values() url.Values {
+	query := url.Values{}
+	if p.City != "" {
+		query.Set("city", p.City)
+	}
+	return query
+}
+
+// GetTrending calls GET /movies/trending - movies with the most seats booked lately
+func (c *Client) GetTrending(ctx context.Context, params GetTrendingParams) ([]*TrendingMovie, error) {
+	var out []*TrendingMovie
+	if err := c.do(ctx, "GET", "/movies/trending", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetUser calls GET /users/{id} - a user's profile
+func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
+	var out User
+	if err := c.do(ctx, "GET", "/users/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUserBookingsParams are the query parameters of GetUserBookings
+type GetUserBookingsParams struct {
+	Category string // UPCOMING, PAST or CANCELLED
+	Offset   int    // Items to skip
+	Limit    int    // Items to return; 20 by default
+}
+
+func (p GetUserBookingsParams) values() url.Values {
+	query := url.Values{}
+	if p.Category != "" {
+		query.Set("category", p.Category)
+	}
+	if p.Offset != 0 {
+		query.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return query
+}
+
+// GetUserBookings calls GET /users/{id}/bookings - my Bookings: upcoming, past or cancelled
+func (c *Client) GetUserBookings(ctx context.Context, id string, params GetUserBookingsParams) (*UserBookings, error) {
+	var out UserBookings
+	if err := c.do(ctx, "GET", "/users/"+url.PathEscape(id)+"/bookings", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWalletParams are the query parameters of GetWallet
+type GetWalletParams struct {
+	Offset int // Items to skip
+	Limit  int // Items to return; 20 by default
+}
+
+func (p GetWalletParams) values() url.Values {
+	query := url.Values{}
+	if p.Offset != 0 {
+		query.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return query
+}
+
+// GetWallet calls GET /users/{id}/wallet - wallet balance and transactions, newest first
+func (c *Client) GetWallet(ctx context.Context, id string, params GetWalletParams) (*WalletStatement, error) {
+	var out WalletStatement
+	if err := c.do(ctx, "GET", "/users/"+url.PathEscape(id)+"/wallet", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWatchlist calls GET /users/{id}/watchlist - watchlisted movies, newest first
+func (c *Client) GetWatchlist(ctx context.Context, id string) ([]*WatchlistItem, error) {
+	var out []*WatchlistItem
+	if err := c.do(ctx, "GET", "/users/"+url.PathEscape(id)+"/watchlist", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetWhatsAppTemplates calls GET /admin/whatsapp/templates - the template each notification kind is sent with on WhatsApp
+func (c *Client) GetWhatsAppTemplates(ctx context.Context) ([]*WhatsAppTemplate, error) {
+	var out []*WhatsAppTemplate
+	if err := c.do(ctx, "GET", "/admin/whatsapp/templates", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GrantRole calls POST /admin/users/{id}/role - grant a user a role
+func (c *Client) GrantRole(ctx context.Context, id string, req GrantRoleRequest) (*User, error) {
+	var out User
+	if err := c.do(ctx, "POST", "/admin/users/"+url.PathEscape(id)+"/role", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ImportCatalog calls POST /admin/catalog/import - import movies from the configured catalog source
+func (c *Client) ImportCatalog(ctx context.Context) (*CatalogImport, error) {
+	var out CatalogImport
+	if err := c.do(ctx, "POST", "/admin/catalog/import", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// IssueTicket calls POST /bookings/{id}/ticket - issue the e-ticket of a confirmed booking
+func (c *Client) IssueTicket(ctx context.Context, id string) (*Ticket, error) {
+	var out Ticket
+	if err := c.do(ctx, "POST", "/bookings/"+url.PathEscape(id)+"/ticket", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListBulkBookings calls GET /bulk-bookings - the account's blocks, newest first
+func (c *Client) ListBulkBookings(ctx context.Context) ([]*BulkBookingDetails, error) {
+	var out []*BulkBookingDetails
+	if err := c.do(ctx, "GET", "/bulk-bookings", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCities calls GET /cities - cities with theatres
+func (c *Client) ListCities(ctx context.Context) ([]*City, error) {
+	var out []*City
+	if err := c.do(ctx, "GET", "/cities", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListEventsParams are the query parameters of ListEvents
+type ListEventsParams struct {
+	Type string // e.g. CONCERT; every type when empty
+}
+
+func (p ListEventsParams) values() url.Values {
+	query := url.Values{}
+	if p.Type != "" {
+		query.Set("type", p.Type)
+	}
+	return query
+}
+
+// ListEvents calls GET /events - live events
+func (c *Client) ListEvents(ctx context.Context, params ListEventsParams) ([]*Event, error) {
+	var out []*Event
+	if err := c.do(ctx, "GET", "/events", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListForResale calls POST /bookings/{id}/resale - put a confirmed booking up for resale at no more than it cost
+func (c *Client) ListForResale(ctx context.Context, id string, req ListForResaleRequest) (*ResaleListing, error) {
+	var out ResaleListing
+	if err := c.do(ctx, "POST", "/bookings/"+url.PathEscape(id)+"/resale", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListMovieReviewsParams are the query parameters of ListMovieReviews
+type ListMovieReviewsParams struct {
+	Offset int // Items to skip
+	Limit  int // Items to return; 20 by default
+}
+
+func (p ListMovieReviewsParams) values() url.Values {
+	query := url.Values{}
+	if p.Offset != 0 {
+		query.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return query
+}
+
+// ListMovieReviews calls GET /movies/{id}/reviews - approved reviews, newest first
+func (c *Client) ListMovieReviews(ctx context.Context, id string, params ListMovieReviewsParams) (*MovieReviews, error) {
+	var out MovieReviews
+	if err := c.do(ctx, "GET", "/movies/"+url.PathEscape(id)+"/reviews", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListPendingDiscountProfiles calls GET /admin/discount-profiles - users whose discount profiles wait for review, oldest first
+func (c *Client) ListPendingDiscountProfiles(ctx context.Context) ([]*User, error) {
+	var out []*User
+	if err := c.do(ctx, "GET", "/admin/discount-profiles", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListPendingReviewsParams are the query parameters of ListPendingReviews
+type ListPendingReviewsParams struct {
+	Offset int // Items to skip
+	Limit  int // Items to return; 20 by default
+}
+
+func (p ListPendingReviewsParams) values() url.Values {
+	query := url.Values{}
+	if p.Offset != 0 {
+		query.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return query
+}
+
+// ListPendingReviews calls GET /admin/movies/{id}/reviews/pending - reviews waiting for moderation
+func (c *Client) ListPendingReviews(ctx context.Context, id string, params ListPendingReviewsParams) (*MovieReviews, error) {
+	var out MovieReviews
+	if err := c.do(ctx, "GET", "/admin/movies/"+url.PathEscape(id)+"/reviews/pending", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListReleasedMovies calls GET /movies - released movies
+func (c *Client) ListReleasedMovies(ctx context.Context) ([]*Movie, error) {
+	var out []*Movie
+	if err := c.do(ctx, "GET", "/movies", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListScreenTemplates calls GET /screen-templates - named screen layouts, fewest seats first
+func (c *Client) ListScreenTemplates(ctx context.Context) ([]*ScreenTemplate, error) {
+	var out []*ScreenTemplate
+	if err := c.do(ctx, "GET", "/screen-templates", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSeatTypes calls GET /seat-types - every seat type and its price multiplier
+func (c *Client) ListSeatTypes(ctx context.Context) (map[string]*SeatTypeInfo, error) {
+	var out map[string]*SeatTypeInfo
+	if err := c.do(ctx, "GET", "/seat-types", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListWebhookDeliveries calls GET /admin/webhooks/{id}/deliveries - a webhook's deliveries, newest first
+func (c *Client) ListWebhookDeliveries(ctx context.Context, id string) ([]*WebhookDelivery, error) {
+	var out []*WebhookDelivery
+	if err := c.do(ctx, "GET", "/admin/webhooks/"+url.PathEscape(id)+"/deliveries", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListWebhooks calls GET /admin/theatres/{id}/webhooks - a theatre's webhooks, secrets left out
+func (c *Client) ListWebhooks(ctx context.Context, id string) ([]*Webhook, error) {
+	var out []*Webhook
+	if err := c.do(ctx, "GET", "/admin/theatres/"+url.PathEscape(id)+"/webhooks", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Login calls POST /auth/login - sign in with email and password
+func (c *Client) Login(ctx context.Context, req LoginRequest) (*AuthSession, error) {
+	var out AuthSession
+	if err := c.do(ctx, "POST", "/auth/login", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Logout calls POST /auth/logout - end the session of the bearer token
+func (c *Client) Logout(ctx context.Context) error {
+	return c.do(ctx, "POST", "/auth/logout", nil, nil, nil)
+}
+
+// MarkSettlementPaid calls POST /admin/settlements/{id}/paid - record the transfer that paid a settlement
+func (c *Client) MarkSettlementPaid(ctx context.Context, id string, req MarkSettlementPaidRequest) (*Settlement, error) {
+	var out Settlement
+	if err := c.do(ctx, "POST", "/admin/settlements/"+url.PathEscape(id)+"/paid", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Me calls GET /auth/me - the signed-in user
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	var out User
+	if err := c.do(ctx, "GET", "/auth/me", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ModerateReview calls POST /admin/reviews/{id}/moderate - approve or reject a review
+func (c *Client) ModerateReview(ctx context.Context, id string, req ModerateReviewRequest) (*Review, error) {
+	var out Review
+	if err := c.do(ctx, "POST", "/admin/reviews/"+url.PathEscape(id)+"/moderate", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ModifySeats calls POST /bookings/{id}/seats - move a booking to other seats; upgrades are charged, downgrades refunded
+func (c *Client) ModifySeats(ctx context.Context, id string, req ModifySeatsRequest) (*SeatModification, error) {
+	var out SeatModification
+	if err := c.do(ctx, "POST", "/bookings/"+url.PathEscape(id)+"/seats", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// OnboardTheatre calls POST /admin/theatres - onboard a theatre
+func (c *Client) OnboardTheatre(ctx context.Context, req CreateTheatreRequest) (*Theatre, error) {
+	var out Theatre
+	if err := c.do(ctx, "POST", "/admin/theatres", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PrintTicketParams are the query parameters of PrintTicket
+type PrintTicketParams struct {
+	Download bool // Save instead of opening
+}
+
+func (p PrintTicketParams) values() url.Values {
+	query := url.Values{}
+	if p.Download {
+		query.Set("download", strconv.FormatBool(p.Download))
+	}
+	return query
+}
+
+// PrintTicket calls GET /bookings/{id}/ticket/print - printable ticket and invoice
+func (c *Client) PrintTicket(ctx context.Context, id string, params PrintTicketParams) ([]byte, error) {
+	return c.bytes(ctx, "GET", "/bookings/"+url.PathEscape(id)+"/ticket/print", params.values())
+}
+
+// ProcessPayment calls POST /payments - pay for a pending booking
+func (c *Client) ProcessPayment(ctx context.Context, req ProcessPaymentRequest) (*Payment, error) {
+	var out Payment
+	if err := c.do(ctx, "POST", "/payments", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReceiveGatewayCallback calls POST /payments/callbacks - asynchronous payment gateway callback, authenticated by the provider's signature header
+func (c *Client) ReceiveGatewayCallback(ctx context.Context, req GatewayCallback) (*GatewayCallbackResponse, error) {
+	var out GatewayCallbackResponse
+	if err := c.do(ctx, "POST", "/payments/callbacks", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RedeemBulkCode calls POST /bulk-codes/{code}/redeem - redeem a code for a seat in the block
+func (c *Client) RedeemBulkCode(ctx context.Context, code string) (*BulkPass, error) {
+	var out BulkPass
+	if err := c.do(ctx, "POST", "/bulk-codes/"+url.PathEscape(code)+"/redeem", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RedeemLoyaltyPoints calls POST /bookings/{id}/loyalty - pay part of a pending booking with points
+func (c *Client) RedeemLoyaltyPoints(ctx context.Context, id string, req RedeemPointsRequest) (*Booking, error) {
+	var out Booking
+	if err := c.do(ctx, "POST", "/bookings/"+url.PathEscape(id)+"/loyalty", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RedeliverEvent calls POST /admin/outbox/{id}/redeliver - deliver a dead-lettered event again
+func (c *Client) RedeliverEvent(ctx context.Context, id string) (*OutboxMessage, error) {
+	var out OutboxMessage
+	if err := c.do(ctx, "POST", "/admin/outbox/"+url.PathEscape(id)+"/redeliver", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RefundPayment calls POST /payments/{id}/refunds - refund part or all of a payment
+func (c *Client) RefundPayment(ctx context.Context, id string, req RefundPaymentRequest) (*Refund, error) {
+	var out Refund
+	if err := c.do(ctx, "POST", "/payments/"+url.PathEscape(id)+"/refunds", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RegisterScreenTemplate calls POST /admin/screen-templates - register a named screen layout
+func (c *Client) RegisterScreenTemplate(ctx context.Context, req RegisterScreenTemplateRequest) (*ScreenTemplate, error) {
+	var out ScreenTemplate
+	if err := c.do(ctx, "POST", "/admin/screen-templates", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RegisterSeatType calls POST /admin/seat-types - register a seat type for screen layouts
+func (c *Client) RegisterSeatType(ctx context.Context, req RegisterSeatTypeRequest) (map[string]*SeatTypeInfo, error) {
+	var out map[string]*SeatTypeInfo
+	if err := c.do(ctx, "POST", "/admin/seat-types", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RegisterWebhook calls POST /admin/theatres/{id}/webhooks - register a webhook; the only response holding its signing secret
+func (c *Client) RegisterWebhook(ctx context.Context, id string, req RegisterWebhookRequest) (*Webhook, error) {
+	var out Webhook
+	if err := c.do(ctx, "POST", "/admin/theatres/"+url.PathEscape(id)+"/webhooks", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RejectDiscountProfile calls POST /admin/users/{id}/discount-profile/reject - reject a user's discount profile
+func (c *Client) RejectDiscountProfile(ctx context.Context, id string, req RejectDiscountProfileRequest) (*User, error) {
+	var out User
+	if err := c.do(ctx, "POST", "/admin/users/"+url.PathEscape(id)+"/discount-profile/reject", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReleaseBulkSeats calls POST /bulk-bookings/{id}/release - hand back unredeemed seats
+func (c *Client) ReleaseBulkSeats(ctx context.Context, id string, req ReleaseBulkSeatsRequest) (*BulkRelease, error) {
+	var out BulkRelease
+	if err := c.do(ctx, "POST", "/bulk-bookings/"+url.PathEscape(id)+"/release", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReleaseHold calls POST /holds/{id}/release - give held seats back
+func (c *Client) ReleaseHold(ctx context.Context, id string) (*SeatHold, error) {
+	var out SeatHold
+	if err := c.do(ctx, "POST", "/holds/"+url.PathEscape(id)+"/release", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReleaseHouseSeats calls POST /admin/shows/{id}/house-seats/release - put held back seats on sale
+func (c *Client) ReleaseHouseSeats(ctx context.Context, id string, req ReleaseHouseSeatsRequest) ([]*HouseSeat, error) {
+	var out []*HouseSeat
+	if err := c.do(ctx, "POST", "/admin/shows/"+url.PathEscape(id)+"/house-seats/release", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RemoveFromWatchlist calls DELETE /users/{id}/watchlist/{movieID} - take a movie off the watchlist
+func (c *Client) RemoveFromWatchlist(ctx context.Context, id string, movieID string) error {
+	return c.do(ctx, "DELETE", "/users/"+url.PathEscape(id)+"/watchlist/"+url.PathEscape(movieID), nil, nil, nil)
+}
+
+// RemoveInstrument calls DELETE /users/{id}/instruments/{instrumentID} - remove a saved payment instrument
+func (c *Client) RemoveInstrument(ctx context.Context, id string, instrumentID string) error {
+	return c.do(ctx, "DELETE", "/users/"+url.PathEscape(id)+"/instruments/"+url.PathEscape(instrumentID), nil, nil, nil)
+}
+
+// RemoveWhatsAppTemplate calls DELETE /admin/whatsapp/templates/{kind} - stop sending a notification kind on WhatsApp
+func (c *Client) RemoveWhatsAppTemplate(ctx context.Context, kind string) error {
+	return c.do(ctx, "DELETE", "/admin/whatsapp/templates/"+url.PathEscape(kind), nil, nil, nil)
+}
+
+// ReplayNotification calls POST /admin/notifications/{id}/replay - send a dead-lettered notification again
+func (c *Client) ReplayNotification(ctx context.Context, id string) (*Notification, error) {
+	var out Notification
+	if err := c.do(ctx, "POST", "/admin/notifications/"+url.PathEscape(id)+"/replay", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RescheduleShow calls POST /admin/shows/{id}/reschedule - move a show to another start time
+func (c *Client) RescheduleShow(ctx context.Context, id string, req RescheduleShowRequest) (*ShowReschedule, error) {
+	var out ShowReschedule
+	if err := c.do(ctx, "POST", "/admin/shows/"+url.PathEscape(id)+"/reschedule", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReserveBulkBlock calls POST /bulk-bookings - reserve a block of seats with a redemption code per seat
+func (c *Client) ReserveBulkBlock(ctx context.Context, req ReserveBulkBlockRequest) (*BulkBookingDetails, error) {
+	var out BulkBookingDetails
+	if err := c.do(ctx, "POST", "/bulk-bookings", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RetryPayment calls POST /bookings/{id}/payments/retry - pay again after a failed attempt
+func (c *Client) RetryPayment(ctx context.Context, id string, req RetryPaymentRequest) (*Payment, error) {
+	var out Payment
+	if err := c.do(ctx, "POST", "/bookings/"+url.PathEscape(id)+"/payments/retry", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SearchShowsParams are the query parameters of SearchShows
+type SearchShowsParams struct {
+	MovieID  string
+	Format   string // 2D, 3D, IMAX or 4DX
+	Language string
+	City     string
+	Defaults string // off skips the preference defaults
+}
+
+func (p SearchShowsParams) values() url.Values {
+	query := url.Values{}
+	if p.MovieID != "" {
+		query.Set("movie_id", p.MovieID)
+	}
+	if p.Format != "" {
+		query.Set("format", p.Format)
+	}
+	if p.Language != "" {
+		query.Set("language", p.Language)
+	}
+	if p.City != "" {
+		query.Set("city", p.City)
+	}
+	if p.Defaults != "" {
+		query.Set("defaults", p.Defaults)
+	}
+	return query
+}
+
+// SearchShows calls GET /shows - bookable shows, soonest first; unset filters come from the signed-in user's preferences
+func (c *Client) SearchShows(ctx context.Context, params SearchShowsParams) ([]*Show, error) {
+	var out []*Show
+	if err := c.do(ctx, "GET", "/shows", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SetCancellationPolicy calls PUT /admin/theatres/{id}/cancellation-policy - set the refund tiers; no tiers restore the default
+func (c *Client) SetCancellationPolicy(ctx context.Context, id string, req CancellationPolicyRequest) (*Theatre, error) {
+	var out Theatre
+	if err := c.do(ctx, "PUT", "/admin/theatres/"+url.PathEscape(id)+"/cancellation-policy", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetDateOfBirth calls PUT /users/{id}/date-of-birth - set the date of birth age-rated shows check
+func (c *Client) SetDateOfBirth(ctx context.Context, id string, req DateOfBirthRequest) (*User, error) {
+	var out User
+	if err := c.do(ctx, "PUT", "/users/"+url.PathEscape(id)+"/date-of-birth", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetMovieSaleWindow calls PUT /admin/movies/{id}/sale-window - set when bookings open for the movie's shows created afterwards, overriding the theatres'
+func (c *Client) SetMovieSaleWindow(ctx context.Context, id string, req SaleWindowRequest) (*Movie, error) {
+	var out Movie
+	if err := c.do(ctx, "PUT", "/admin/movies/"+url.PathEscape(id)+"/sale-window", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetParkingCapacity calls PUT /admin/theatres/{id}/parking - size the theatre's car park; 0 stops new parking reservations
+func (c *Client) SetParkingCapacity(ctx context.Context, id string, req ParkingCapacityRequest) (*Theatre, error) {
+	var out Theatre
+	if err := c.do(ctx, "PUT", "/admin/theatres/"+url.PathEscape(id)+"/parking", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetPricingDay calls PUT /admin/pricing-calendar/{date} - flag a date as a holiday or peak day with a price multiplier
+func (c *Client) SetPricingDay(ctx context.Context, date string, req PricingDayRequest) (*CalendarDay, error) {
+	var out CalendarDay
+	if err := c.do(ctx, "PUT", "/admin/pricing-calendar/"+url.PathEscape(date), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetScreenMaintenance calls POST /admin/screens/{id}/maintenance - take a screen offline or bring it back
+func (c *Client) SetScreenMaintenance(ctx context.Context, id string, req MaintenanceRequest) (*Screen, error) {
+	var out Screen
+	if err := c.do(ctx, "POST", "/admin/screens/"+url.PathEscape(id)+"/maintenance", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetShowBookingTimeout calls PUT /admin/shows/{id}/booking-timeout - set a show's payment window; 0 falls back to the theatre's
+func (c *Client) SetShowBookingTimeout(ctx context.Context, id string, req BookingTimeoutRequest) (*Show, error) {
+	var out Show
+	if err := c.do(ctx, "PUT", "/admin/shows/"+url.PathEscape(id)+"/booking-timeout", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetTheatreBookingTimeout calls PUT /admin/theatres/{id}/booking-timeout - set the payment window of shows created afterwards
+func (c *Client) SetTheatreBookingTimeout(ctx context.Context, id string, req BookingTimeoutRequest) (*Theatre, error) {
+	var out Theatre
+	if err := c.do(ctx, "PUT", "/admin/theatres/"+url.PathEscape(id)+"/booking-timeout", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetTheatreSaleWindow calls PUT /admin/theatres/{id}/sale-window - set when bookings open for shows created afterwards, unless their movie has its own window
+func (c *Client) SetTheatreSaleWindow(ctx context.Context, id string, req SaleWindowRequest) (*Theatre, error) {
+	var out Theatre
+	if err := c.do(ctx, "PUT", "/admin/theatres/"+url.PathEscape(id)+"/sale-window", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetWhatsAppOptIn calls PUT /users/{id}/whatsapp-opt-in - opt in to or out of WhatsApp messages
+func (c *Client) SetWhatsAppOptIn(ctx context.Context, id string, req WhatsAppOptInRequest) (*User, error) {
+	var out User
+	if err := c.do(ctx, "PUT", "/users/"+url.PathEscape(id)+"/whatsapp-opt-in", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetWhatsAppTemplate calls PUT /admin/whatsapp/templates/{kind} - set the WhatsApp template a notification kind is sent with
+func (c *Client) SetWhatsAppTemplate(ctx context.Context, kind string, req WhatsAppTemplateRequest) (*WhatsAppTemplate, error) {
+	var out WhatsAppTemplate
+	if err := c.do(ctx, "PUT", "/admin/whatsapp/templates/"+url.PathEscape(kind), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Signup calls POST /auth/signup - create a customer and sign them in
+func (c *Client) Signup(ctx context.Context, req SignupRequest) (*AuthSession, error) {
+	var out AuthSession
+	if err := c.do(ctx, "POST", "/auth/signup", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// StreamSeats calls GET /shows/{id}/seats/stream - server-Sent Events: a seat map snapshot, then every seat blocked, booked or released
+func (c *Client) StreamSeats(ctx context.Context, id string) (io.ReadCloser, error) {
+	return c.stream(ctx, "GET", "/shows/"+url.PathEscape(id)+"/seats/stream", nil)
+}
+
+// SubmitDiscountProfile calls POST /users/{id}/discount-profile - apply for a student, senior citizen or military discount
+func (c *Client) SubmitDiscountProfile(ctx context.Context, id string, req DiscountProfileRequest) (*User, error) {
+	var out User
+	if err := c.do(ctx, "POST", "/users/"+url.PathEscape(id)+"/discount-profile", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SubmitReview calls POST /movies/{id}/reviews - review a movie; pending until moderated
+func (c *Client) SubmitReview(ctx context.Context, id string, req ReviewRequest) (*Review, error) {
+	var out Review
+	if err := c.do(ctx, "POST", "/movies/"+url.PathEscape(id)+"/reviews", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SuggestSeatsParams are the query parameters of SuggestSeats
+type SuggestSeatsParams struct {
+	Count int    // Required
+	Type  string // Seat type, e.g. REGULAR
+}
+
+func (p SuggestSeatsParams) values() url.Values {
+	query := url.Values{}
+	query.Set("count", strconv.Itoa(p.Count))
+	if p.Type != "" {
+		query.Set("type", p.Type)
+	}
+	return query
+}
+
+// SuggestSeats calls GET /shows/{id}/seats/suggest - best block of adjacent seats
+func (c *Client) SuggestSeats(ctx context.Context, id string, params SuggestSeatsParams) (*SeatSuggestion, error) {
+	var out SeatSuggestion
+	if err := c.do(ctx, "GET", "/shows/"+url.PathEscape(id)+"/seats/suggest", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SuggestSlotsParams are the query parameters of SuggestSlots
+type SuggestSlotsParams struct {
+	MovieID string
+	Date    time.Time // A UTC day, today by default
+}
+
+func (p SuggestSlotsParams) values() url.Values {
+	query := url.Values{}
+	if p.MovieID != "" {
+		query.Set("movie_id", p.MovieID)
+	}
+	if !p.Date.IsZero() {
+		query.Set("date", p.Date.Format(time.DateOnly))
+	}
+	return query
+}
+
+// SuggestSlots calls GET /admin/screens/{id}/slots - free start times on a screen that day, turnaround included
+func (c *Client) SuggestSlots(ctx context.Context, id string, params SuggestSlotsParams) (*SlotSuggestion, error) {
+	var out SlotSuggestion
+	if err := c.do(ctx, "GET", "/admin/screens/"+url.PathEscape(id)+"/slots", params.values(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TopUpWallet calls POST /users/{id}/wallet/topup - add money to the wallet
+func (c *Client) TopUpWallet(ctx context.Context, id string, req TopUpRequest) (*WalletTransaction, error) {
+	var out WalletTransaction
+	if err := c.do(ctx, "POST", "/users/"+url.PathEscape(id)+"/wallet/topup", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TransferBooking calls POST /bookings/{id}/transfer - offer a confirmed booking to another registered user
+func (c *Client) TransferBooking(ctx context.Context, id string, req TransferBookingRequest) (*BookingTransfer, error) {
+	var out BookingTransfer
+	if err := c.do(ctx, "POST", "/bookings/"+url.PathEscape(id)+"/transfer", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UnblockUser calls DELETE /admin/users/{id}/block - unblock a user
+func (c *Client) UnblockUser(ctx context.Context, id string) (*User, error) {
+	var out User
+	if err := c.do(ctx, "DELETE", "/admin/users/"+url.PathEscape(id)+"/block", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdatePreferences calls PUT /users/{id}/preferences - replace the whole preference profile
+func (c *Client) UpdatePreferences(ctx context.Context, id string, req UserPreferences) (*UserPreferences, error) {
+	var out UserPreferences
+	if err := c.do(ctx, "PUT", "/users/"+url.PathEscape(id)+"/preferences", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ValidateTicket calls POST /theatres/{id}/checkin/validate - check a scanned ticket without admitting it
+func (c *Client) ValidateTicket(ctx context.Context, id string, req CheckInRequest) (*TicketValidation, error) {
+	var out TicketValidation
+	if err := c.do(ctx, "POST", "/theatres/"+url.PathEscape(id)+"/checkin/validate", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// VerifyDiscountProfile calls POST /admin/users/{id}/discount-profile/verify - verify a user's discount profile so their bookings get its discount
+func (c *Client) VerifyDiscountProfile(ctx context.Context, id string) (*User, error) {
+	var out User
+	if err := c.do(ctx, "POST", "/admin/users/"+url.PathEscape(id)+"/discount-profile/verify", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// WithdrawResaleListing calls POST /resale/{id}/withdraw - take your booking off resale
+func (c *Client) WithdrawResaleListing(ctx context.Context, id string) (*ResaleListing, error) {
+	var out ResaleListing
+	if err := c.do(ctx, "POST", "/resale/"+url.PathEscape(id)+"/withdraw", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// WithholdSeats calls POST /admin/shows/{id}/house-seats - hold seats back from sale for this show
+func (c *Client) WithholdSeats(ctx context.Context, id string, req WithholdSeatsRequest) ([]*HouseSeat, error) {
+	var out []*HouseSeat
+	if err := c.do(ctx, "POST", "/admin/shows/"+url.PathEscape(id)+"/house-seats", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
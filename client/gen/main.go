@@ -0,0 +1,351 @@
+// Command gen writes the generated half of package client - a Go type per schema and a method per
+// operation of the API's OpenAPI document - and optionally the document itself
+package main
+
+import (
+	"bookmyshow-lld/internal/api"
+	"bookmyshow-lld/internal/openapi"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// initialisms keep Go's capitalization in field names, e.g. seat_ids becomes SeatIDs
+var initialisms = map[string]string{"id": "ID", "ids": "IDs", "url": "URL", "api": "API", "http": "HTTP", "json": "JSON", "qr": "QR", "ip": "IP"}
+
+// pathParam matches {name} in a path
+var pathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+func main() {
+	out := flag.String("o", "client_gen.go", "file to write the client to")
+	spec := flag.String("spec", "", "also write the OpenAPI document to this file")
+	flag.Parse()
+
+	document := api.OpenAPI()
+	if *spec != "" {
+		encoded, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*spec, append(encoded, '\n'), 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	source, err := generate(document)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, source, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate writes the types and methods and gofmts them
+func generate(document *openapi.Document) ([]byte, error) {
+	g := &generator{imports: map[string]bool{"context": true}}
+	g.types(document.Components.Schemas)
+	g.operations(document.Paths)
+
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "// Code generated by go run ./gen from the OpenAPI document; DO NOT EDIT.\n\npackage client\n\nimport (\n")
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	slices.Sort(imports)
+	for _, path := range imports {
+		fmt.Fprintf(&file, "\t%q\n", path)
+	}
+	fmt.Fprintf(&file, ")\n")
+	file.Write(g.body.Bytes())
+
+	source, err := format.Source(file.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated client: %w", err)
+	}
+	return source, nil
+}
+
+type generator struct {
+	body    bytes.Buffer
+	imports map[string]bool
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.body, format, args...)
+}
+
+// types writes a struct per component schema, fields sorted by name
+func (g *generator) types(schemas map[string]*openapi.Schema) {
+	for _, name := range sortedKeys(schemas) {
+		schema := schemas[name]
+		g.printf("\n// %s is the %s schema\ntype %s struct {\n", name, name, name)
+		for _, property := range sortedKeys(schema.Properties) {
+			field := schema.Properties[property]
+			tag := property
+			if !slices.Contains(schema.Required, property) {
+				tag += ",omitempty"
+			}
+			g.printf("\t%s %s `json:%q`", goName(property), g.goType(field), tag)
+			if field.Description != "" {
+				g.printf(" // %s", field.Description)
+			}
+			g.printf("\n")
+		}
+		g.printf("}\n")
+	}
+}
+
+// operation is one method to write
+type operation struct {
+	method string
+	path   string
+	*openapi.Operation
+}
+
+// operations writes a method per operation, and a params struct for those with query parameters
+func (g *generator) operations(paths map[string]*openapi.PathItem) {
+	var operations []operation
+	for path, item := range paths {
+		for method, op := range item.Operations() {
+			operations = append(operations, operation{method, path, op})
+		}
+	}
+	slices.SortFunc(operations, func(a, b operation) int { return strings.Compare(a.OperationID, b.OperationID) })
+
+	for _, op := range operations {
+		g.operation(op)
+	}
+}
+
+func (g *generator) operation(op operation) {
+	name := goName(op.OperationID)
+	args := []string{"ctx context.Context"}
+	var query []*openapi.Parameter
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			args = append(args, param.Name+" string")
+		case "query":
+			query = append(query, param)
+		}
+	}
+	body := "nil"
+	if op.RequestBody != nil {
+		args = append(args, "req "+strings.TrimPrefix(g.goType(op.RequestBody.Content["application/json"].Schema), "*"))
+		body = "req"
+	}
+	queryValues := "nil"
+	if len(query) > 0 {
+		g.params(name+"Params", query)
+		args = append(args, "params "+name+"Params")
+		queryValues = "params.values()"
+	}
+
+	g.printf("\n// %s calls %s %s", name, op.method, op.path)
+	if op.Summary != "" {
+		g.printf(" - %s", strings.ToLower(op.Summary[:1])+op.Summary[1:])
+	}
+	g.printf("\nfunc (c *Client) %s(%s) ", name, strings.Join(args, ", "))
+
+	path := g.pathExpression(op.path)
+	contentType, schema := successResponse(op.Operation)
+	switch {
+	case contentType == "text/event-stream":
+		g.imports["io"] = true
+		g.printf("(io.ReadCloser, error) {\n\treturn c.stream(ctx, %q, %s, %s)\n}\n", op.method, path, queryValues)
+	case contentType != "" && contentType != "application/json":
+		g.printf("([]byte, error) {\n\treturn c.bytes(ctx, %q, %s, %s)\n}\n", op.method, path, queryValues)
+	case schema == nil:
+		g.printf("error {\n\treturn c.do(ctx, %q, %s, %s, %s, nil)\n}\n", op.method, path, queryValues, body)
+	default:
+		result := g.goType(schema)
+		value := strings.TrimPrefix(result, "*")
+		g.printf("(%s, error) {\n\tvar out %s\n", result, value)
+		g.printf("\tif err := c.do(ctx, %q, %s, %s, %s, &out); err != nil {\n\t\treturn %s, err\n\t}\n", op.method, path, queryValues, body, zero(result))
+		if strings.HasPrefix(result, "*") {
+			g.printf("\treturn &out, nil\n}\n")
+		} else {
+			g.printf("\treturn out, nil\n}\n")
+		}
+	}
+}
+
+// params writes the struct of an operation's query parameters; zero fields are left out of the query
+func (g *generator) params(name string, query []*openapi.Parameter) {
+	g.imports["net/url"] = true
+	g.printf("\n// %s are the query parameters of %s\ntype %s struct {\n", name, strings.TrimSuffix(name, "Params"), name)
+	for _, param := range query {
+		g.printf("\t%s %s", goName(param.Name), paramType(param.Schema))
+		switch {
+		case param.Required && param.Description != "":
+			g.printf(" // Required. %s", param.Description)
+		case param.Required:
+			g.printf(" // Required")
+		case param.Description != "":
+			g.printf(" // %s", param.Description)
+		}
+		g.printf("\n")
+	}
+	g.printf("}\n\nfunc (p %s) values() url.Values {\n\tquery := url.Values{}\n", name)
+	for _, param := range query {
+		field := "p." + goName(param.Name)
+		var value, isSet string
+		switch paramType(param.Schema) {
+		case "time.Time":
+			g.imports["time"] = true
+			value, isSet = field+".Format(time.DateOnly)", "!"+field+".IsZero()"
+		case "int":
+			g.imports["strconv"] = true
+			value, isSet = "strconv.Itoa("+field+")", field+" != 0"
+		case "float64":
+			g.imports["strconv"] = true
+			value, isSet = "strconv.FormatFloat("+field+", 'f', -1, 64)", field+" != 0"
+		case "bool":
+			g.imports["strconv"] = true
+			value, isSet = "strconv.FormatBool("+field+")", field
+		default:
+			value, isSet = field, field+` != ""`
+		}
+		if param.Required {
+			g.printf("\tquery.Set(%q, %s)\n", param.Name, value)
+		} else {
+			g.printf("\tif %s {\n\t\tquery.Set(%q, %s)\n\t}\n", isSet, param.Name, value)
+		}
+	}
+	g.printf("\treturn query\n}\n")
+}
+
+// pathExpression turns /users/{id}/watchlist into "/users/" + url.PathEscape(id) + "/watchlist"
+func (g *generator) pathExpression(path string) string {
+	var parts []string
+	rest := path
+	for _, match := range pathParam.FindAllStringSubmatchIndex(path, -1) {
+		g.imports["net/url"] = true
+		offset := len(path) - len(rest)
+		if literal := rest[:match[0]-offset]; literal != "" {
+			parts = append(parts, fmt.Sprintf("%q", literal))
+		}
+		parts = append(parts, "url.PathEscape("+path[match[2]:match[3]]+")")
+		rest = path[match[1]:]
+	}
+	if rest != "" {
+		parts = append(parts, fmt.Sprintf("%q", rest))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// goType maps a schema to the Go type the client uses; structs are always pointers so null decodes cleanly
+func (g *generator) goType(schema *openapi.Schema) string {
+	if name := schema.RefName(); name != "" {
+		return "*" + name
+	}
+
+	var goType string
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			g.imports["time"] = true
+			goType = "time.Time"
+		case "byte", "binary":
+			return "[]byte"
+		default:
+			goType = "string"
+		}
+	case "integer":
+		goType = "int64"
+		if schema.Format == "int32" {
+			goType = "int32"
+		}
+	case "number":
+		goType = "float64"
+		if schema.Format == "float" {
+			goType = "float32"
+		}
+	case "boolean":
+		goType = "bool"
+	case "array":
+		return "[]" + g.goType(schema.Items)
+	case "object":
+		if schema.AdditionalProperties != nil {
+			return "map[string]" + g.goType(schema.AdditionalProperties)
+		}
+		g.imports["encoding/json"] = true
+		return "json.RawMessage"
+	default:
+		g.imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+	if schema.Nullable {
+		return "*" + goType
+	}
+	return goType
+}
+
+// paramType maps a query parameter's schema to its field type
+func paramType(schema *openapi.Schema) string {
+	switch schema.Type {
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+	if schema.Format == "date" {
+		return "time.Time"
+	}
+	return "string"
+}
+
+// successResponse returns the content type and schema of the 2xx response; both are empty for No Content
+func successResponse(op *openapi.Operation) (string, *openapi.Schema) {
+	for status, response := range op.Responses {
+		if !strings.HasPrefix(status, "2") {
+			continue
+		}
+		for contentType, media := range response.Content {
+			return contentType, media.Schema
+		}
+	}
+	return "", nil
+}
+
+// zero is the value a method returns with an error
+func zero(goType string) string {
+	if strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") {
+		return "nil"
+	}
+	return goType + "{}"
+}
+
+// goName turns a JSON name or operationId into an exported Go name, e.g. seat_ids into SeatIDs
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		if initialism, ok := initialisms[strings.ToLower(part)]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
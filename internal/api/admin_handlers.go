@@ -0,0 +1,755 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/pricing"
+	"bookmyshow-lld/internal/services"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type grantRoleRequest struct {
+	Role       models.UserRole `json:"role"`
+	TheatreIDs []string        `json:"theatre_ids,omitempty"` // Required for THEATRE_ADMIN
+}
+
+type blockUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+type adminScreenRequest struct {
+	Name      string                    `json:"name"`
+	BasePrice float64                   `json:"base_price"`
+	Currency  string                    `json:"currency,omitempty"`
+	Rows      []factories.RowConfig     `json:"rows,omitempty"`
+	Sections  []factories.SectionConfig `json:"sections,omitempty"` // e.g. STALLS then BALCONY, each with its rows
+}
+
+type registerSeatTypeRequest struct {
+	Type        models.SeatType `json:"type"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Multiplier  float64         `json:"multiplier"` // Price relative to the screen's base price
+}
+
+type addCityRequest struct {
+	Name   string `json:"name"`
+	Region string `json:"region,omitempty"`
+}
+
+type cloneScreenRequest struct {
+	Name      string `json:"name"`
+	TheatreID string `json:"theatre_id,omitempty"` // The theatre to add the copy to; the source's by default
+}
+
+type templateScreensRequest struct {
+	Template  string   `json:"template"` // e.g. IMAX; see GET /screen-templates
+	Names     []string `json:"names"`    // One screen per name
+	BasePrice float64  `json:"base_price"`
+	Currency  string   `json:"currency,omitempty"`
+}
+
+type registerScreenTemplateRequest struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description,omitempty"`
+	Rows        []factories.RowConfig     `json:"rows,omitempty"`
+	Sections    []factories.SectionConfig `json:"sections,omitempty"`
+}
+
+type pricingDayRequest struct {
+	Kind       pricing.DayKind `json:"kind"` // HOLIDAY or PEAK
+	Name       string          `json:"name,omitempty"`
+	Multiplier float64         `json:"multiplier"` // Above 1, e.g. 1.5 for half as much again
+}
+
+type whatsAppTemplateRequest struct {
+	Name     string                `json:"name"`             // As approved with WhatsApp, e.g. booking_confirmation
+	Language string                `json:"language"`         // e.g. en
+	Header   models.WhatsAppHeader `json:"header,omitempty"` // QR_CODE, for booking confirmations only
+	Body     string                `json:"body"`             // Placeholders such as {{reference}} are filled from the notification
+}
+
+type rejectDiscountProfileRequest struct {
+	Reason string `json:"reason"` // Shown to the user, e.g. "student ID has expired"
+}
+
+type maintenanceRequest struct {
+	Offline bool `json:"offline"`
+}
+
+type showSlotRequest struct {
+	Weekday   string `json:"weekday"`    // e.g. "FRIDAY"
+	StartTime string `json:"start_time"` // 24h "HH:MM"
+}
+
+type bulkShowsRequest struct {
+	MovieID   string            `json:"movie_id"`
+	TheatreID string            `json:"theatre_id"`
+	ScreenID  string            `json:"screen_id"`
+	WeekStart time.Time         `json:"week_start"`
+	Weeks     int               `json:"weeks"`
+	BasePrice float64           `json:"base_price"`
+	Currency  string            `json:"currency,omitempty"`
+	Format    models.ShowFormat `json:"format,omitempty"`
+	Language  models.Language   `json:"language,omitempty"`
+	Slots     []showSlotRequest `json:"slots"`
+}
+
+type cancelShowRequest struct {
+	Reason string `json:"reason"`
+}
+
+type rescheduleShowRequest struct {
+	StartTime time.Time `json:"start_time"`
+}
+
+type cancellationPolicyRequest struct {
+	Tiers []models.CancellationTier `json:"tiers"`
+}
+
+type bookingTimeoutRequest struct {
+	Minutes int `json:"minutes"` // 0 restores the default
+}
+
+type saleWindowRequest struct {
+	OpensBeforeHours int       `json:"opens_before_hours,omitempty"` // e.g. 168 to open bookings a week before each show
+	OpensAt          time.Time `json:"opens_at,omitzero"`            // Or a fixed opening; neither opens bookings straight away
+}
+
+// window validates the requested sale window
+func (req saleWindowRequest) window() (models.SaleWindow, error) {
+	return models.NewSaleWindow(time.Duration(req.OpensBeforeHours)*time.Hour, req.OpensAt)
+}
+
+type parkingCapacityRequest struct {
+	Slots int `json:"slots"` // 0 means no parking
+}
+
+type withholdSeatsRequest struct {
+	SeatIDs []string          `json:"seat_ids"`
+	Status  models.SeatStatus `json:"status"` // HOUSE or BLOCKED_ADMIN
+	Reason  string            `json:"reason"`
+}
+
+type releaseHouseSeatsRequest struct {
+	SeatIDs []string `json:"seat_ids,omitempty"` // Empty releases every held back seat
+}
+
+type bulkShowsResponse struct {
+	Shows  []*models.Show `json:"shows"`
+	Errors []string       `json:"errors,omitempty"`
+}
+
+// Admin handlers - theatre partner operations
+
+func (s *Server) grantRole(w http.ResponseWriter, r *http.Request) {
+	var req grantRoleRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user, err := s.adminService.GrantRole(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.Role, req.TheatreIDs...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) blockUser(w http.ResponseWriter, r *http.Request) {
+	var req blockUserRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user, err := s.adminService.BlockUser(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) listPendingDiscountProfiles(w http.ResponseWriter, r *http.Request) {
+	users, err := s.adminService.GetPendingDiscountProfiles(r.Context(), services.CallerFromContext(r.Context()))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, users)
+}
+
+func (s *Server) verifyDiscountProfile(w http.ResponseWriter, r *http.Request) {
+	user, err := s.adminService.VerifyDiscountProfile(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) rejectDiscountProfile(w http.ResponseWriter, r *http.Request) {
+	var req rejectDiscountProfileRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user, err := s.adminService.RejectDiscountProfile(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) unblockUser(w http.ResponseWriter, r *http.Request) {
+	user, err := s.adminService.UnblockUser(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) onboardTheatre(w http.ResponseWriter, r *http.Request) {
+	var req createTheatreRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	opts, err := theatreOptions(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	theatre, err := s.adminService.OnboardTheatre(r.Context(), services.CallerFromContext(r.Context()), req.Name, req.Address, req.City, opts...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, theatre)
+}
+
+func (s *Server) addCity(w http.ResponseWriter, r *http.Request) {
+	var req addCityRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	city, err := s.adminService.AddCity(r.Context(), services.CallerFromContext(r.Context()), req.Name, req.Region)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, city)
+}
+
+func (s *Server) registerSeatType(w http.ResponseWriter, r *http.Request) {
+	var req registerSeatTypeRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	seatType := models.SeatType(strings.ToUpper(string(req.Type)))
+	info := factories.SeatTypeInfo{Name: req.Name, Description: req.Description, Multiplier: req.Multiplier}
+	if _, err := s.adminService.RegisterSeatType(r.Context(), services.CallerFromContext(r.Context()), seatType, info); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[models.SeatType]factories.SeatTypeInfo{seatType: info})
+}
+
+func (s *Server) adminAddScreen(w http.ResponseWriter, r *http.Request) {
+	var req adminScreenRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	layout := factories.ScreenConfig{Rows: req.Rows, Sections: req.Sections}
+	basePrice := models.MoneyFromMajor(req.BasePrice, req.Currency)
+	screen, err := s.adminService.AddScreen(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.Name, layout, basePrice)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, screen)
+}
+
+func (s *Server) addScreensFromTemplate(w http.ResponseWriter, r *http.Request) {
+	var req templateScreensRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	basePrice := models.MoneyFromMajor(req.BasePrice, req.Currency)
+	screens, err := s.adminService.AddScreensFromTemplate(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.Template, req.Names, basePrice)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, screens)
+}
+
+func (s *Server) registerScreenTemplate(w http.ResponseWriter, r *http.Request) {
+	var req registerScreenTemplateRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	template := factories.ScreenTemplate{
+		Name:        req.Name,
+		Description: req.Description,
+		Layout:      factories.ScreenConfig{Rows: req.Rows, Sections: req.Sections},
+	}
+	template, err := s.adminService.RegisterScreenTemplate(r.Context(), services.CallerFromContext(r.Context()), template)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, template)
+}
+
+func (s *Server) getPricingCalendar(w http.ResponseWriter, r *http.Request) {
+	days, err := s.adminService.GetPricingCalendar(r.Context(), services.CallerFromContext(r.Context()))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, days)
+}
+
+// setPricingDay serves PUT /admin/pricing-calendar/{date}, the date as YYYY-MM-DD
+func (s *Server) setPricingDay(w http.ResponseWriter, r *http.Request) {
+	date, err := time.Parse(time.DateOnly, r.PathValue("date"))
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: date must be YYYY-MM-DD", errBadRequest))
+		return
+	}
+
+	var req pricingDayRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	day := pricing.CalendarDay{Date: date, Kind: req.Kind, Name: req.Name, Multiplier: req.Multiplier}
+	day, err = s.adminService.SetPricingDay(r.Context(), services.CallerFromContext(r.Context()), day)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, day)
+}
+
+func (s *Server) clearPricingDay(w http.ResponseWriter, r *http.Request) {
+	date, err := time.Parse(time.DateOnly, r.PathValue("date"))
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: date must be YYYY-MM-DD", errBadRequest))
+		return
+	}
+
+	if err := s.adminService.ClearPricingDay(r.Context(), services.CallerFromContext(r.Context()), date); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) cloneScreen(w http.ResponseWriter, r *http.Request) {
+	var req cloneScreenRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	screen, err := s.adminService.CloneScreen(r.Context(), services.CallerFromContext(r.Context()), req.TheatreID, r.PathValue("id"), req.Name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, screen)
+}
+
+func (s *Server) setScreenMaintenance(w http.ResponseWriter, r *http.Request) {
+	var req maintenanceRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	screen, err := s.adminService.SetScreenMaintenance(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.Offline)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, screen)
+}
+
+func (s *Server) createShowsFromTemplate(w http.ResponseWriter, r *http.Request) {
+	var req bulkShowsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	template := services.WeeklyShowTemplate{
+		MovieID:   req.MovieID,
+		TheatreID: req.TheatreID,
+		ScreenID:  req.ScreenID,
+		WeekStart: req.WeekStart,
+		Weeks:     req.Weeks,
+		BasePrice: models.MoneyFromMajor(req.BasePrice, req.Currency),
+		Format:    models.ShowFormat(strings.ToUpper(string(req.Format))),
+		Language:  req.Language,
+	}
+	for _, slot := range req.Slots {
+		parsed, ok := parseShowSlot(slot)
+		if !ok {
+			writeError(w, errBadRequest)
+			return
+		}
+		template.Slots = append(template.Slots, parsed)
+	}
+
+	shows, err := s.adminService.CreateShowsFromTemplate(r.Context(), services.CallerFromContext(r.Context()), template)
+	if err != nil && len(shows) == 0 {
+		writeError(w, err)
+		return
+	}
+
+	// Partial success still creates shows; report which slots were skipped
+	resp := bulkShowsResponse{Shows: shows}
+	if err != nil {
+		resp.Errors = strings.Split(err.Error(), "\n")
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (s *Server) cancelShow(w http.ResponseWriter, r *http.Request) {
+	var req cancelShowRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	cancellation, err := s.adminService.CancelShow(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, cancellation)
+}
+
+func (s *Server) rescheduleShow(w http.ResponseWriter, r *http.Request) {
+	var req rescheduleShowRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	reschedule, err := s.adminService.RescheduleShow(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.StartTime)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, reschedule)
+}
+
+// suggestSlots serves GET /admin/screens/{id}/slots?movie_id=...&date=2030-01-08 - a UTC day, today by default
+func (s *Server) suggestSlots(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	date := time.Now().UTC()
+	if raw := query.Get("date"); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			writeError(w, fmt.Errorf("%w: date must be YYYY-MM-DD", errBadQuery))
+			return
+		}
+		date = parsed
+	}
+
+	suggestion, err := s.showService.SuggestSlots(r.Context(), r.PathValue("id"), query.Get("movie_id"), date)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, suggestion)
+}
+
+func (s *Server) setCancellationPolicy(w http.ResponseWriter, r *http.Request) {
+	var req cancellationPolicyRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	theatre, err := s.adminService.SetCancellationPolicy(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.Tiers)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, theatre)
+}
+
+// setTheatreBookingTimeout serves PUT /admin/theatres/{id}/booking-timeout; it applies to shows created afterwards
+func (s *Server) setTheatreBookingTimeout(w http.ResponseWriter, r *http.Request) {
+	var req bookingTimeoutRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	timeout := time.Duration(req.Minutes) * time.Minute
+	theatre, err := s.adminService.SetTheatreBookingTimeout(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), timeout)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, theatre)
+}
+
+// setTheatreSaleWindow serves PUT /admin/theatres/{id}/sale-window; it applies to shows created afterwards
+func (s *Server) setTheatreSaleWindow(w http.ResponseWriter, r *http.Request) {
+	var req saleWindowRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	window, err := req.window()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	theatre, err := s.adminService.SetTheatreSaleWindow(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), window)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, theatre)
+}
+
+// setMovieSaleWindow serves PUT /admin/movies/{id}/sale-window; it applies to shows created afterwards
+func (s *Server) setMovieSaleWindow(w http.ResponseWriter, r *http.Request) {
+	var req saleWindowRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	window, err := req.window()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	movie, err := s.movieService.SetSaleWindow(r.Context(), r.PathValue("id"), window)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, movie)
+}
+
+// setParkingCapacity serves PUT /admin/theatres/{id}/parking; reservations already made keep their slots
+func (s *Server) setParkingCapacity(w http.ResponseWriter, r *http.Request) {
+	var req parkingCapacityRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	theatre, err := s.parkingService.SetCapacity(r.Context(), r.PathValue("id"), req.Slots)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, theatre)
+}
+
+// closeOutShow serves POST /admin/shows/{id}/close-out, for closing a show out before the scheduled job does
+func (s *Server) closeOutShow(w http.ResponseWriter, r *http.Request) {
+	show, err := s.closeOutService.CloseOutShow(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, show)
+}
+
+// setShowBookingTimeout serves PUT /admin/shows/{id}/booking-timeout; 0 falls back to the theatre's timeout
+func (s *Server) setShowBookingTimeout(w http.ResponseWriter, r *http.Request) {
+	var req bookingTimeoutRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	timeout := time.Duration(req.Minutes) * time.Minute
+	show, err := s.adminService.SetShowBookingTimeout(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), timeout)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, show)
+}
+
+// getHouseSeats serves GET /admin/shows/{id}/house-seats
+func (s *Server) getHouseSeats(w http.ResponseWriter, r *http.Request) {
+	houseSeats, err := s.adminService.GetHouseSeats(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, houseSeats)
+}
+
+// withholdSeats serves POST /admin/shows/{id}/house-seats - the seats disappear from the show's availability
+func (s *Server) withholdSeats(w http.ResponseWriter, r *http.Request) {
+	var req withholdSeatsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	houseSeats, err := s.adminService.WithholdSeats(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.SeatIDs, req.Status, req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, houseSeats)
+}
+
+// releaseHouseSeats serves POST /admin/shows/{id}/house-seats/release - the seats go back on sale
+func (s *Server) releaseHouseSeats(w http.ResponseWriter, r *http.Request) {
+	var req releaseHouseSeatsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	released, err := s.adminService.ReleaseHouseSeats(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.SeatIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, released)
+}
+
+func (s *Server) getDeadLetters(w http.ResponseWriter, r *http.Request) {
+	messages, err := s.adminService.GetDeadLetters(r.Context(), services.CallerFromContext(r.Context()))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, messages)
+}
+
+func (s *Server) redeliverEvent(w http.ResponseWriter, r *http.Request) {
+	message, err := s.adminService.RedeliverEvent(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, message)
+}
+
+func (s *Server) getNotificationDeadLetters(w http.ResponseWriter, r *http.Request) {
+	notifications, err := s.adminService.GetNotificationDeadLetters(r.Context(), services.CallerFromContext(r.Context()))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, notifications)
+}
+
+func (s *Server) replayNotification(w http.ResponseWriter, r *http.Request) {
+	notification, err := s.adminService.ReplayNotification(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, notification)
+}
+
+func (s *Server) getWhatsAppTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := s.adminService.GetWhatsAppTemplates(r.Context(), services.CallerFromContext(r.Context()))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, templates)
+}
+
+// setWhatsAppTemplate serves PUT /admin/whatsapp/templates/{kind}, the kind being a notification kind such as
+// SHOW_REMINDER
+func (s *Server) setWhatsAppTemplate(w http.ResponseWriter, r *http.Request) {
+	var req whatsAppTemplateRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	template := models.WhatsAppTemplate{
+		Kind:     models.NotificationKind(r.PathValue("kind")),
+		Name:     req.Name,
+		Language: req.Language,
+		Header:   req.Header,
+		Body:     req.Body,
+	}
+	template, err := s.adminService.SetWhatsAppTemplate(r.Context(), services.CallerFromContext(r.Context()), template)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, template)
+}
+
+func (s *Server) removeWhatsAppTemplate(w http.ResponseWriter, r *http.Request) {
+	kind := models.NotificationKind(r.PathValue("kind"))
+	if err := s.adminService.RemoveWhatsAppTemplate(r.Context(), services.CallerFromContext(r.Context()), kind); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getAuditTrail(w http.ResponseWriter, r *http.Request) {
+	entries, err := s.adminService.GetAuditTrail(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("entityID"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// parseShowSlot converts "FRIDAY" / "18:30" into a ShowSlot
+func parseShowSlot(slot showSlotRequest) (services.ShowSlot, bool) {
+	clock, err := time.Parse("15:04", slot.StartTime)
+	if err != nil {
+		return services.ShowSlot{}, false
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), slot.Weekday) {
+			return services.ShowSlot{
+				Weekday:   day,
+				StartTime: time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute,
+			}, true
+		}
+	}
+	return services.ShowSlot{}, false
+}
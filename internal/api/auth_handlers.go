@@ -0,0 +1,126 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"net/http"
+	"strings"
+)
+
+type signupRequest struct {
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	PhoneNumber string `json:"phone_number"`
+	Password    string `json:"password"`
+}
+
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Auth handlers - sessions are bearer tokens sent as "Authorization: Bearer <token>"
+
+// signup serves POST /auth/signup (and POST /users) - creates a customer and signs them in
+func (s *Server) signup(w http.ResponseWriter, r *http.Request) {
+	var req signupRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	session, err := s.authService.Signup(r.Context(), req.Name, req.Email, req.PhoneNumber, req.Password)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, session)
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	session, err := s.authService.Login(r.Context(), req.Email, req.Password)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, session)
+}
+
+func (s *Server) logout(w http.ResponseWriter, r *http.Request) {
+	if err := s.authService.Logout(r.Context(), bearerToken(r)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// me serves GET /auth/me - the signed-in user
+func (s *Server) me(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user, err := s.userService.GetUser(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+// authenticate resolves the bearer token to its user and records them as the caller.
+// Requests without a token go through anonymously; a bad or expired token is refused outright.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := bearerToken(r)
+		if token == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		user, err := s.authService.Authenticate(r.Context(), token)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(services.WithCaller(r.Context(), user.ID)))
+	})
+}
+
+// bearerToken reads the token from "Authorization: Bearer <token>"
+func bearerToken(r *http.Request) string {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// requireCaller returns the signed-in user's ID, for handlers that act on the caller's behalf
+func requireCaller(r *http.Request) (string, error) {
+	userID := services.CallerFromContext(r.Context())
+	if userID == "" {
+		return "", models.ErrUnauthorized
+	}
+	return userID, nil
+}
+
+// requireSelf lets only the user themselves at their profile, bookings, wallet and points
+func requireSelf(r *http.Request, userID string) error {
+	callerID, err := requireCaller(r)
+	if err != nil {
+		return err
+	}
+	if callerID != userID {
+		return models.ErrForbidden
+	}
+	return nil
+}
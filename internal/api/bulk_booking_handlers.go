@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/services"
+	"net/http"
+)
+
+type reserveBulkBlockRequest struct {
+	ShowID string `json:"show_id"`
+	services.BulkBlockRequest
+}
+
+type releaseBulkSeatsRequest struct {
+	Codes []string `json:"codes,omitempty"` // Empty releases every unredeemed seat
+}
+
+// Corporate block handlers - the account pays for the block's booking through /payments like any other
+
+func (s *Server) reserveBulkBlock(w http.ResponseWriter, r *http.Request) {
+	accountID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req reserveBulkBlockRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	bulk, err := s.bulkBookingSvc.ReserveBlock(r.Context(), accountID, req.ShowID, req.BulkBlockRequest)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, bulk)
+}
+
+func (s *Server) listBulkBookings(w http.ResponseWriter, r *http.Request) {
+	accountID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	blocks, err := s.bulkBookingSvc.GetAccountBulkBookings(r.Context(), accountID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, blocks)
+}
+
+func (s *Server) getBulkBooking(w http.ResponseWriter, r *http.Request) {
+	accountID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	bulk, err := s.bulkBookingSvc.GetBulkBooking(r.Context(), accountID, r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, bulk)
+}
+
+func (s *Server) releaseBulkSeats(w http.ResponseWriter, r *http.Request) {
+	accountID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req releaseBulkSeatsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	release, err := s.bulkBookingSvc.ReleaseSeats(r.Context(), accountID, r.PathValue("id"), req.Codes)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, release)
+}
+
+// redeemBulkCode serves POST /bulk-codes/{code}/redeem for the employee redeeming the code
+func (s *Server) redeemBulkCode(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	pass, err := s.bulkBookingSvc.RedeemCode(r.Context(), userID, r.PathValue("code"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, pass)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// defaultSearchRadiusKm applies to nearby search when the client doesn't pass radius_km
+const defaultSearchRadiusKm = 10
+
+// City and discovery handlers - the "pick city, then pick theatre" flow
+
+func (s *Server) listCities(w http.ResponseWriter, r *http.Request) {
+	cities, err := s.theatreService.GetCities(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, cities)
+}
+
+func (s *Server) getTheatresByCity(w http.ResponseWriter, r *http.Request) {
+	theatres, err := s.theatreService.GetTheatresByCity(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, theatres)
+}
+
+// getNearbyTheatres serves GET /theatres/nearby?lat=19.07&lng=72.87&radius_km=5
+func (s *Server) getNearbyTheatres(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	lat, err := strconv.ParseFloat(query.Get("lat"), 64)
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: lat must be a number", errBadQuery))
+		return
+	}
+	lng, err := strconv.ParseFloat(query.Get("lng"), 64)
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: lng must be a number", errBadQuery))
+		return
+	}
+	radius := float64(defaultSearchRadiusKm)
+	if raw := query.Get("radius_km"); raw != "" {
+		if radius, err = strconv.ParseFloat(raw, 64); err != nil {
+			writeError(w, fmt.Errorf("%w: radius_km must be a number", errBadQuery))
+			return
+		}
+	}
+
+	nearby, err := s.theatreService.GetTheatresNearLocation(r.Context(), lat, lng, radius)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, nearby)
+}
+
+// theatreOptions turns optional request coordinates into a CreateTheatre option; both or neither must be set
+func theatreOptions(req createTheatreRequest) ([]services.TheatreOption, error) {
+	if req.Latitude == nil && req.Longitude == nil {
+		return nil, nil
+	}
+	if req.Latitude == nil || req.Longitude == nil {
+		return nil, models.ErrInvalidLocation
+	}
+	return []services.TheatreOption{services.WithLocation(models.GeoPoint{Latitude: *req.Latitude, Longitude: *req.Longitude})}, nil
+}
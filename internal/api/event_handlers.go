@@ -0,0 +1,70 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/models"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type createEventRequest struct {
+	Title           string           `json:"title"`
+	Description     string           `json:"description"`
+	Type            models.EventType `json:"type"` // CONCERT, PLAY or STANDUP
+	DurationMinutes int              `json:"duration_minutes"`
+	Language        models.Language  `json:"language"`
+	Performers      []string         `json:"performers,omitempty"`
+}
+
+// Event handlers - live events share shows, seats and bookings with movies
+
+func (s *Server) createEvent(w http.ResponseWriter, r *http.Request) {
+	var req createEventRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	event, err := s.eventService.CreateEvent(
+		r.Context(),
+		req.Title,
+		req.Description,
+		req.Type,
+		time.Duration(req.DurationMinutes)*time.Minute,
+		req.Language,
+		req.Performers,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, event)
+}
+
+func (s *Server) listEvents(w http.ResponseWriter, r *http.Request) {
+	eventType := models.EventType(strings.ToUpper(r.URL.Query().Get("type")))
+	events, err := s.eventService.GetEventsByType(r.Context(), eventType)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+func (s *Server) getEvent(w http.ResponseWriter, r *http.Request) {
+	event, err := s.eventService.GetEvent(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, event)
+}
+
+func (s *Server) getShowsByEvent(w http.ResponseWriter, r *http.Request) {
+	shows, err := s.showService.GetShowsByEvent(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, shows)
+}
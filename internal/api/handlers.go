@@ -0,0 +1,1050 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Request payloads
+
+type createMovieRequest struct {
+	Title           string             `json:"title"`
+	Description     string             `json:"description"`
+	DurationMinutes int                `json:"duration_minutes"`
+	Genre           models.Genre       `json:"genre"`
+	Language        models.Language    `json:"language"`
+	Rating          float32            `json:"rating"`
+	ReleaseDate     time.Time          `json:"release_date"`
+	Certificate     models.Certificate `json:"certificate,omitempty"` // U, UA or A
+}
+
+type dateOfBirthRequest struct {
+	DateOfBirth string `json:"date_of_birth"` // YYYY-MM-DD
+}
+
+type whatsAppOptInRequest struct {
+	OptedIn bool `json:"opted_in"`
+}
+
+type discountProfileRequest struct {
+	Category   models.DiscountCategory `json:"category"`              // STUDENT, SENIOR_CITIZEN or MILITARY
+	DocumentID string                  `json:"document_id"`           // The proof an admin checks, e.g. a student ID
+	ValidUntil string                  `json:"valid_until,omitempty"` // YYYY-MM-DD the proof lapses; never when empty
+}
+
+type createTheatreRequest struct {
+	Name      string   `json:"name"`
+	Address   string   `json:"address"`
+	City      string   `json:"city"`
+	Latitude  *float64 `json:"latitude,omitempty"` // With longitude, lists the theatre in nearby search
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+type addScreenRequest struct {
+	Name      string  `json:"name"`
+	BasePrice float64 `json:"base_price"`
+	Currency  string  `json:"currency,omitempty"`
+}
+
+type createShowRequest struct {
+	MovieID   string            `json:"movie_id,omitempty"`
+	EventID   string            `json:"event_id,omitempty"` // Instead of movie_id for live events
+	TheatreID string            `json:"theatre_id"`
+	ScreenID  string            `json:"screen_id"`
+	StartTime time.Time         `json:"start_time"`
+	BasePrice float64           `json:"base_price"`
+	Currency  string            `json:"currency,omitempty"`
+	Format    models.ShowFormat `json:"format,omitempty"`   // Movies only: 2D, 3D, IMAX or 4DX
+	Language  models.Language   `json:"language,omitempty"` // Movies only: a dubbed language
+
+	BookingTimeoutMinutes int `json:"booking_timeout_minutes,omitempty"` // Movies only: payment window; 0 uses the theatre's
+}
+
+type createBookingRequest struct {
+	ShowID     string   `json:"show_id"`
+	SeatIDs    []string `json:"seat_ids"`
+	CouponCode string   `json:"coupon_code,omitempty"`
+	HoldID     string   `json:"hold_id,omitempty"`
+
+	WithGuardian bool                  `json:"with_guardian,omitempty"` // Lets a minor book an age-rated movie; checked at the door
+	AddOns       []models.AddOnRequest `json:"add_ons,omitempty"`       // e.g. {"type":"PARKING","quantity":2}
+}
+
+type createHoldRequest struct {
+	ShowID  string   `json:"show_id"`
+	SeatIDs []string `json:"seat_ids"`
+}
+
+type modifySeatsRequest struct {
+	SeatIDs []string `json:"seat_ids"`
+}
+
+type confirmBookingRequest struct {
+	PaymentID string `json:"payment_id"`
+}
+
+type processPaymentRequest struct {
+	BookingID    string               `json:"booking_id"`
+	Method       models.PaymentMethod `json:"method"`
+	TenureMonths int                  `json:"tenure_months,omitempty"` // Required for EMI: 3, 6, 9 or 12
+	InstrumentID string               `json:"instrument_id,omitempty"` // A saved card, UPI handle or wallet of the booking's owner
+}
+
+type retryPaymentRequest struct {
+	Method       models.PaymentMethod `json:"method"` // May differ from the failed attempt
+	TenureMonths int                  `json:"tenure_months,omitempty"`
+	InstrumentID string               `json:"instrument_id,omitempty"`
+}
+
+type completeChallengeRequest struct {
+	OTP string `json:"otp"` // The one-time password the card issuer sent
+}
+
+type refundPaymentRequest struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency,omitempty"`
+	Reason   string  `json:"reason"`
+	ToWallet bool    `json:"to_wallet,omitempty"` // Instant refund to the user's wallet
+}
+
+type createCouponRequest struct {
+	Code         string              `json:"code"`
+	DiscountType models.DiscountType `json:"discount_type"`
+	Value        float64             `json:"value"`
+	MinAmount    float64             `json:"min_amount"`
+	Currency     string              `json:"currency,omitempty"`
+	ExpiresAt    time.Time           `json:"expires_at"`
+	UsageLimit   int                 `json:"usage_limit"`
+}
+
+// User handlers
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user, err := s.userService.GetUser(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+// setWhatsAppOptIn serves PUT /users/{id}/whatsapp-opt-in; WhatsApp messages are only sent once the user opts in
+func (s *Server) setWhatsAppOptIn(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req whatsAppOptInRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user, err := s.userService.SetWhatsAppOptIn(r.Context(), r.PathValue("id"), req.OptedIn)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+// setDateOfBirth serves PUT /users/{id}/date-of-birth; age-rated shows need it before they can be booked
+func (s *Server) setDateOfBirth(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req dateOfBirthRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	dateOfBirth, err := time.Parse(time.DateOnly, req.DateOfBirth)
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: date_of_birth must be YYYY-MM-DD", errBadRequest))
+		return
+	}
+
+	user, err := s.userService.SetDateOfBirth(r.Context(), r.PathValue("id"), dateOfBirth)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+// submitDiscountProfile serves POST /users/{id}/discount-profile; bookings get the discount once an admin verifies it
+func (s *Server) submitDiscountProfile(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req discountProfileRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var validUntil *time.Time
+	if req.ValidUntil != "" {
+		parsed, err := time.Parse(time.DateOnly, req.ValidUntil)
+		if err != nil {
+			writeError(w, fmt.Errorf("%w: valid_until must be YYYY-MM-DD", errBadRequest))
+			return
+		}
+		validUntil = &parsed
+	}
+
+	user, err := s.userService.SubmitDiscountProfile(r.Context(), r.PathValue("id"), req.Category, req.DocumentID, validUntil)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) getPreferences(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	preferences, err := s.preferenceSvc.GetPreferences(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, preferences)
+}
+
+// updatePreferences serves PUT /users/{id}/preferences, replacing the whole profile
+func (s *Server) updatePreferences(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req models.UserPreferences
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	preferences, err := s.preferenceSvc.UpdatePreferences(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, preferences)
+}
+
+// getRecommendations serves GET /users/{id}/recommendations?limit=10
+func (s *Server) getRecommendations(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil {
+			writeError(w, fmt.Errorf("%w: limit must be a number", errBadQuery))
+			return
+		}
+	}
+
+	recommendations, err := s.recommendations.GetRecommendations(r.Context(), r.PathValue("id"), limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, recommendations)
+}
+
+// getUserBookings serves GET /users/{id}/bookings?category=upcoming&offset=0&limit=20
+func (s *Server) getUserBookings(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user, err := s.userService.GetUser(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	offset, limit, err := parsePage(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	filter := services.BookingFilter{
+		Category: services.BookingCategory(strings.ToUpper(r.URL.Query().Get("category"))),
+		Offset:   offset,
+		Limit:    limit,
+	}
+
+	bookings, err := s.bookingService.GetUserBookings(r.Context(), user.ID, filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, bookings)
+}
+
+// Movie handlers
+
+func (s *Server) createMovie(w http.ResponseWriter, r *http.Request) {
+	var req createMovieRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	movie, err := s.movieService.CreateMovie(
+		r.Context(),
+		req.Title,
+		req.Description,
+		time.Duration(req.DurationMinutes)*time.Minute,
+		req.Genre,
+		req.Language,
+		req.Rating,
+		req.ReleaseDate,
+		services.WithCertificate(req.Certificate),
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, movie)
+}
+
+// importCatalog pulls the configured movie source into the catalog; re-running it refreshes earlier imports
+func (s *Server) importCatalog(w http.ResponseWriter, r *http.Request) {
+	result, err := s.catalogImporter.Import(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) listReleasedMovies(w http.ResponseWriter, r *http.Request) {
+	movies, err := s.movieService.GetReleasedMovies(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, movies)
+}
+
+// getTrending serves GET /movies/trending?city=Mumbai
+func (s *Server) getTrending(w http.ResponseWriter, r *http.Request) {
+	city, err := s.listingCity(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	trending, err := s.movieService.GetTrending(r.Context(), city)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, trending)
+}
+
+// getNowShowing serves GET /shows/now-showing?city=Mumbai&date=2030-01-08 - a day in the server's zone, today by default
+func (s *Server) getNowShowing(w http.ResponseWriter, r *http.Request) {
+	city, err := s.listingCity(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	date := time.Now()
+	if raw := r.URL.Query().Get("date"); raw != "" {
+		if date, err = time.ParseInLocation(time.DateOnly, raw, time.Local); err != nil {
+			writeError(w, fmt.Errorf("%w: date must be YYYY-MM-DD", errBadQuery))
+			return
+		}
+	}
+
+	nowShowing, err := s.showService.GetNowShowing(r.Context(), city, date)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, nowShowing)
+}
+
+// getActiveDeals serves GET /shows/deals?city=Mumbai
+func (s *Server) getActiveDeals(w http.ResponseWriter, r *http.Request) {
+	city, err := s.listingCity(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	deals, err := s.dealsService.GetActiveDeals(r.Context(), city)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, deals)
+}
+
+// listingCity is ?city=, else the signed-in user's home city; empty lists every city
+func (s *Server) listingCity(r *http.Request) (string, error) {
+	if city := r.URL.Query().Get("city"); city != "" {
+		return city, nil
+	}
+	filter, err := s.preferenceSvc.ApplyShowDefaults(r.Context(), services.CallerFromContext(r.Context()), services.ShowFilter{})
+	return filter.City, err
+}
+
+func (s *Server) getMovie(w http.ResponseWriter, r *http.Request) {
+	movie, err := s.movieService.GetMovie(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, movie)
+}
+
+func (s *Server) getShowsByMovie(w http.ResponseWriter, r *http.Request) {
+	shows, err := s.showService.GetShowsByMovie(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, shows)
+}
+
+// Theatre handlers
+
+func (s *Server) createTheatre(w http.ResponseWriter, r *http.Request) {
+	var req createTheatreRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	opts, err := theatreOptions(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	theatre, err := s.theatreService.CreateTheatre(r.Context(), req.Name, req.Address, req.City, opts...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, theatre)
+}
+
+func (s *Server) getTheatre(w http.ResponseWriter, r *http.Request) {
+	theatre, err := s.theatreService.GetTheatre(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, theatre)
+}
+
+// addScreen creates a screen with the default seat layout - demonstrates Factory Pattern
+func (s *Server) addScreen(w http.ResponseWriter, r *http.Request) {
+	var req addScreenRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if req.Name == "" || req.BasePrice <= 0 {
+		writeError(w, models.ErrInvalidTheatreData)
+		return
+	}
+
+	theatreID := r.PathValue("id")
+	screen := models.NewScreen(req.Name, theatreID)
+	for _, seat := range s.seatFactory.CreateDefaultScreenSeats(models.MoneyFromMajor(req.BasePrice, req.Currency)) {
+		screen.AddSeat(seat)
+	}
+	s.seatFactory.ApplyAisles(screen, factories.DefaultScreenConfig())
+	if err := s.seatFactory.ApplySeatGroups(screen, factories.DefaultScreenConfig()); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := s.theatreService.AddScreen(r.Context(), theatreID, screen); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, screen)
+}
+
+// Show handlers
+
+func (s *Server) createShow(w http.ResponseWriter, r *http.Request) {
+	var req createShowRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if (req.MovieID == "") == (req.EventID == "") {
+		writeError(w, models.ErrInvalidShowData)
+		return
+	}
+	if req.EventID != "" && (req.Format != "" || req.Language != "") {
+		writeError(w, models.ErrInvalidShowData)
+		return
+	}
+
+	basePrice := models.MoneyFromMajor(req.BasePrice, req.Currency)
+	var show *models.Show
+	var err error
+	if req.EventID != "" {
+		show, err = s.showService.CreateEventShow(r.Context(), req.EventID, req.TheatreID, req.ScreenID, req.StartTime, basePrice)
+	} else {
+		show, err = s.showService.CreateShow(r.Context(), req.MovieID, req.TheatreID, req.ScreenID, req.StartTime, basePrice, showOptions(req.Format, req.Language, req.BookingTimeoutMinutes)...)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, show)
+}
+
+// showOptions turns the optional format, language and booking timeout of a show request into CreateShow options
+func showOptions(format models.ShowFormat, language models.Language, timeoutMinutes int) []services.ShowOption {
+	var opts []services.ShowOption
+	if format != "" {
+		opts = append(opts, services.WithFormat(models.ShowFormat(strings.ToUpper(string(format)))))
+	}
+	if language != "" {
+		opts = append(opts, services.WithLanguage(language))
+	}
+	if timeoutMinutes != 0 {
+		opts = append(opts, services.WithBookingTimeout(time.Duration(timeoutMinutes)*time.Minute))
+	}
+	return opts
+}
+
+// searchShows serves GET /shows?movie_id=...&format=IMAX&language=HINDI&city=Mumbai. Signed-in users get their
+// home city, preferred languages and favourite theatres for whatever they leave out, unless defaults=off.
+func (s *Server) searchShows(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := services.ShowFilter{
+		MovieID:  query.Get("movie_id"),
+		Format:   models.ShowFormat(strings.ToUpper(query.Get("format"))),
+		Language: models.Language(query.Get("language")),
+		City:     query.Get("city"),
+	}
+	if filter.MovieID == "" {
+		writeError(w, fmt.Errorf("%w: movie_id is required", errBadQuery))
+		return
+	}
+
+	if query.Get("defaults") != "off" {
+		var err error
+		if filter, err = s.preferenceSvc.ApplyShowDefaults(r.Context(), services.CallerFromContext(r.Context()), filter); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	shows, err := s.showService.SearchShows(r.Context(), filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, shows)
+}
+
+func (s *Server) getShow(w http.ResponseWriter, r *http.Request) {
+	show, err := s.showService.GetShow(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, show)
+}
+
+// listSeatTypes serves GET /seat-types - every registered seat type and its price multiplier
+func (s *Server) listSeatTypes(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.seatFactory.GetSeatTypeInfo())
+}
+
+// listScreenTemplates serves GET /screen-templates - the named layouts screens can be created from
+func (s *Server) listScreenTemplates(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.screenTemplates.Templates())
+}
+
+func (s *Server) getSeatAvailability(w http.ResponseWriter, r *http.Request) {
+	seatMap, err := s.showService.GetSeatAvailability(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, seatMap)
+}
+
+// getAvailabilitySummary serves GET /shows/{id}/availability - seat counts and a badge such as FILLING_FAST
+func (s *Server) getAvailabilitySummary(w http.ResponseWriter, r *http.Request) {
+	summary, err := s.showService.GetAvailabilitySummary(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+func (s *Server) suggestSeats(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	count, err := strconv.Atoi(query.Get("count"))
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: count must be a number", errBadQuery))
+		return
+	}
+
+	seatType := models.SeatType(strings.ToUpper(query.Get("type")))
+	suggestion, err := s.bookingService.SuggestSeats(r.Context(), r.PathValue("id"), count, seatType)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, suggestion)
+}
+
+// Seat hold handlers
+
+func (s *Server) createHold(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req createHoldRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	hold, err := s.seatHoldService.CreateHold(r.Context(), userID, req.ShowID, req.SeatIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, hold)
+}
+
+func (s *Server) getHold(w http.ResponseWriter, r *http.Request) {
+	hold, err := s.seatHoldService.GetHold(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hold)
+}
+
+func (s *Server) extendHold(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	hold, err := s.seatHoldService.ExtendHold(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hold)
+}
+
+func (s *Server) releaseHold(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	holdID := r.PathValue("id")
+	if err := s.seatHoldService.ReleaseHold(r.Context(), holdID, userID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	hold, err := s.seatHoldService.GetHold(r.Context(), holdID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hold)
+}
+
+// Booking handlers
+
+func (s *Server) createBooking(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req createBookingRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var opts []services.BookingOption
+	if req.CouponCode != "" {
+		opts = append(opts, services.WithCoupon(req.CouponCode))
+	}
+	if req.HoldID != "" {
+		opts = append(opts, services.WithHold(req.HoldID))
+	}
+	if req.WithGuardian {
+		opts = append(opts, services.WithGuardian())
+	}
+	if len(req.AddOns) > 0 {
+		opts = append(opts, services.WithAddOns(req.AddOns...))
+	}
+
+	booking, err := s.bookingService.CreateBooking(r.Context(), userID, req.ShowID, req.SeatIDs, opts...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, booking)
+}
+
+// getAddOnOffers serves GET /shows/{id}/add-ons
+func (s *Server) getAddOnOffers(w http.ResponseWriter, r *http.Request) {
+	offers, err := s.bookingService.GetAddOnOffers(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, offers)
+}
+
+// getParkingAvailability serves GET /shows/{id}/parking
+func (s *Server) getParkingAvailability(w http.ResponseWriter, r *http.Request) {
+	availability, err := s.parkingService.GetAvailability(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, availability)
+}
+
+func (s *Server) getBooking(w http.ResponseWriter, r *http.Request) {
+	if _, err := requireCaller(r); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	booking, err := s.bookingService.GetBooking(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.authorizer.AuthorizeBooking(r.Context(), booking); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, booking)
+}
+
+// getBookingByReference serves GET /bookings?reference=BMS-7F3K9Q
+func (s *Server) getBookingByReference(w http.ResponseWriter, r *http.Request) {
+	reference := r.URL.Query().Get("reference")
+	if reference == "" {
+		writeError(w, fmt.Errorf("%w: reference is required", errBadQuery))
+		return
+	}
+
+	booking, err := s.bookingService.GetBookingByReference(r.Context(), reference)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, booking)
+}
+
+// getParkingReservation serves GET /bookings/{id}/parking
+func (s *Server) getParkingReservation(w http.ResponseWriter, r *http.Request) {
+	reservation, err := s.parkingService.GetReservation(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, reservation)
+}
+
+func (s *Server) getBookingDetails(w http.ResponseWriter, r *http.Request) {
+	if _, err := requireCaller(r); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	details, err := s.bookingService.GetBookingDetails(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, details)
+}
+
+func (s *Server) confirmBooking(w http.ResponseWriter, r *http.Request) {
+	if _, err := requireCaller(r); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req confirmBookingRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	bookingID := r.PathValue("id")
+	if err := s.bookingService.ConfirmBooking(r.Context(), bookingID, req.PaymentID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	booking, err := s.bookingService.GetBooking(r.Context(), bookingID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, booking)
+}
+
+// extendBookingHold serves POST /bookings/{id}/extend for the booking's owner while they are paying
+func (s *Server) extendBookingHold(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	booking, err := s.bookingService.ExtendHold(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, booking)
+}
+
+func (s *Server) cancelBooking(w http.ResponseWriter, r *http.Request) {
+	if _, err := requireCaller(r); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	bookingID := r.PathValue("id")
+	if err := s.bookingService.CancelBooking(r.Context(), bookingID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	booking, err := s.bookingService.GetBooking(r.Context(), bookingID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, booking)
+}
+
+func (s *Server) modifySeats(w http.ResponseWriter, r *http.Request) {
+	if _, err := requireCaller(r); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req modifySeatsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	modification, err := s.bookingService.ModifySeats(r.Context(), r.PathValue("id"), req.SeatIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, modification)
+}
+
+// Payment handlers - demonstrates Strategy Pattern via HTTP
+
+func (s *Server) processPayment(w http.ResponseWriter, r *http.Request) {
+	if _, err := requireCaller(r); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req processPaymentRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	payment, err := s.paymentService.ProcessPayment(r.Context(), req.BookingID, req.Method, paymentOptions(req.TenureMonths, req.InstrumentID)...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, payment)
+}
+
+func (s *Server) retryPayment(w http.ResponseWriter, r *http.Request) {
+	if _, err := requireCaller(r); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req retryPaymentRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	payment, err := s.paymentService.RetryPayment(r.Context(), r.PathValue("id"), req.Method, paymentOptions(req.TenureMonths, req.InstrumentID)...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, payment)
+}
+
+// paymentOptions passes the chosen EMI tenure and saved instrument on to the payment service
+func paymentOptions(tenureMonths int, instrumentID string) []services.PaymentOption {
+	var opts []services.PaymentOption
+	if tenureMonths != 0 {
+		opts = append(opts, services.WithTenure(tenureMonths))
+	}
+	if instrumentID != "" {
+		opts = append(opts, services.WithInstrument(instrumentID))
+	}
+	return opts
+}
+
+func (s *Server) getPaymentAttempts(w http.ResponseWriter, r *http.Request) {
+	if _, err := requireCaller(r); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	attempts, err := s.paymentService.GetPaymentAttempts(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, attempts)
+}
+
+func (s *Server) getPayment(w http.ResponseWriter, r *http.Request) {
+	payment, err := s.paymentService.GetPayment(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, payment)
+}
+
+func (s *Server) completeChallenge(w http.ResponseWriter, r *http.Request) {
+	if _, err := requireCaller(r); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req completeChallengeRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	payment, err := s.paymentService.CompleteChallenge(r.Context(), r.PathValue("id"), req.OTP)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, payment)
+}
+
+func (s *Server) refundPayment(w http.ResponseWriter, r *http.Request) {
+	if _, err := requireCaller(r); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req refundPaymentRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var opts []services.RefundOption
+	if req.ToWallet {
+		opts = append(opts, services.ToWallet())
+	}
+
+	refund, err := s.paymentService.RefundPayment(r.Context(), r.PathValue("id"), models.MoneyFromMajor(req.Amount, req.Currency), req.Reason, opts...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, refund)
+}
+
+// Promotion handlers
+
+func (s *Server) createCoupon(w http.ResponseWriter, r *http.Request) {
+	var req createCouponRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	coupon, err := s.promotionService.CreateCoupon(r.Context(), req.Code, req.DiscountType, req.Value, models.MoneyFromMajor(req.MinAmount, req.Currency), req.ExpiresAt, req.UsageLimit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, coupon)
+}
+
+func (s *Server) getCoupon(w http.ResponseWriter, r *http.Request) {
+	coupon, err := s.promotionService.GetCoupon(r.Context(), r.PathValue("code"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, coupon)
+}
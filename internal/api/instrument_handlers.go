@@ -0,0 +1,75 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/models"
+	"net/http"
+)
+
+type addInstrumentRequest struct {
+	Type       models.InstrumentType `json:"type"`                  // CARD, UPI or WALLET
+	CardNumber string                `json:"card_number,omitempty"` // CARD only; tokenized, never stored
+	Expiry     string                `json:"expiry,omitempty"`      // CARD only, MM/YY
+	Handle     string                `json:"handle,omitempty"`      // UPI only, e.g. name@bank
+}
+
+// Payment instrument handlers - a user's own saved instruments only
+
+func (s *Server) getInstruments(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	instruments, err := s.instrumentSvc.GetInstruments(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, instruments)
+}
+
+// addInstrument serves POST /users/{id}/instruments; saving a UPI handle or the wallet again returns it again
+func (s *Server) addInstrument(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("id")
+	if err := requireSelf(r, userID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req addInstrumentRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var instrument *models.PaymentInstrument
+	var err error
+	switch req.Type {
+	case models.InstrumentTypeCard:
+		instrument, err = s.instrumentSvc.AddCard(r.Context(), userID, models.CardDetails{Number: req.CardNumber, Expiry: req.Expiry})
+	case models.InstrumentTypeUPI:
+		instrument, err = s.instrumentSvc.AddUPI(r.Context(), userID, req.Handle)
+	case models.InstrumentTypeWallet:
+		instrument, err = s.instrumentSvc.LinkWallet(r.Context(), userID)
+	default:
+		err = models.ErrInvalidPaymentInstrument
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, instrument)
+}
+
+func (s *Server) removeInstrument(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := s.instrumentSvc.RemoveInstrument(r.Context(), r.PathValue("id"), r.PathValue("instrumentID")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
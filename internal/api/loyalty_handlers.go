@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+)
+
+type redeemPointsRequest struct {
+	Points int64 `json:"points"`
+}
+
+// Loyalty handlers - points earned on confirmed bookings and spent towards pending ones
+
+func (s *Server) getLoyaltyAccount(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	account, err := s.loyaltyService.GetAccount(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, account)
+}
+
+// redeemLoyaltyPoints serves POST /bookings/{id}/loyalty - pays part of a pending booking with points
+func (s *Server) redeemLoyaltyPoints(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req redeemPointsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	booking, err := s.loyaltyService.RedeemPoints(r.Context(), userID, r.PathValue("id"), req.Points)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, booking)
+}
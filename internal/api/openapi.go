@@ -0,0 +1,154 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/openapi"
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// apiVersion is the version the OpenAPI document gives the API
+const apiVersion = "1.0.0"
+
+// bearerScheme names the security scheme of session tokens in the OpenAPI document
+const bearerScheme = "bearerAuth"
+
+// route is one JSON endpoint: the mux pattern, its handler and what the OpenAPI document says about it
+type route struct {
+	pattern string
+	handler http.HandlerFunc
+	doc     operation
+}
+
+// operation documents a route. The method, path parameters and operationId come from the pattern and the
+// handler's name; the request and response schemas are read off example values of the types the handler
+// decodes and writes.
+type operation struct {
+	ID       string  // operationId when the handler serves another route too; the handler's name by default
+	Summary  string  // One line for the reference
+	Auth     bool    // Needs a bearer token
+	Query    []param // Query parameters the handler reads
+	Request  any     // The JSON body the handler decodes; nil when it reads none
+	Response any     // The JSON body written on success; nil for none
+	Status   int     // Success status; 200 when zero
+	Produces string  // Content type of a response that isn't JSON, e.g. image/png
+}
+
+// param is a query parameter
+type param struct {
+	Name        string
+	Type        string // string, integer, number, boolean or date (YYYY-MM-DD); string when empty
+	Required    bool
+	Description string
+}
+
+// pathParam matches {name} in a mux pattern
+var pathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// OpenAPI describes every JSON endpoint as an OpenAPI 3 document, built from the route table so the
+// two can't drift apart
+func OpenAPI() *openapi.Document {
+	schemas := openapi.NewSchemas()
+	document := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: openapi.Info{
+			Title:       "BookMyShow API",
+			Description: "Movie and live event booking. Send the session token from /auth/login as Authorization: Bearer <token>.",
+			Version:     apiVersion,
+		},
+		Paths: make(map[string]*openapi.PathItem),
+	}
+
+	for _, route := range (&Server{}).routes() {
+		method, path, _ := strings.Cut(route.pattern, " ")
+		item, ok := document.Paths[path]
+		if !ok {
+			item = &openapi.PathItem{}
+			document.Paths[path] = item
+		}
+		item.SetOperation(method, route.doc.describe(path, handlerName(route.handler), schemas))
+	}
+
+	document.Components = openapi.Components{
+		Schemas:         schemas.Components(),
+		SecuritySchemes: map[string]*openapi.SecurityScheme{bearerScheme: {Type: "http", Scheme: "bearer"}},
+	}
+	return document
+}
+
+// describe turns the documentation of the route on path into an OpenAPI operation
+func (o operation) describe(path, handler string, schemas *openapi.Schemas) *openapi.Operation {
+	described := &openapi.Operation{
+		OperationID: o.ID,
+		Summary:     o.Summary,
+		Tags:        []string{strings.Split(strings.TrimPrefix(path, "/"), "/")[0]},
+		Responses: map[string]*openapi.Response{
+			"default": {Description: "Error", Content: jsonContent(schemas.For(errorResponse{}))},
+		},
+	}
+	if described.OperationID == "" {
+		described.OperationID = handler
+	}
+	if o.Auth {
+		described.Security = []map[string][]string{{bearerScheme: {}}}
+	}
+
+	for _, match := range pathParam.FindAllStringSubmatch(path, -1) {
+		described.Parameters = append(described.Parameters, &openapi.Parameter{
+			Name: match[1], In: "path", Required: true, Schema: &openapi.Schema{Type: "string"},
+		})
+	}
+	for _, query := range o.Query {
+		described.Parameters = append(described.Parameters, &openapi.Parameter{
+			Name: query.Name, In: "query", Required: query.Required, Description: query.Description, Schema: query.schema(),
+		})
+	}
+
+	if o.Request != nil {
+		described.RequestBody = &openapi.RequestBody{Required: true, Content: jsonContent(schemas.For(o.Request))}
+	}
+
+	status := o.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	response := &openapi.Response{Description: http.StatusText(status)}
+	switch {
+	case o.Produces != "":
+		response.Content = map[string]*openapi.MediaType{o.Produces: {Schema: &openapi.Schema{Type: "string", Format: "binary"}}}
+	case o.Response != nil:
+		response.Content = jsonContent(schemas.For(o.Response))
+	}
+	described.Responses[strconv.Itoa(status)] = response
+	return described
+}
+
+func (p param) schema() *openapi.Schema {
+	switch p.Type {
+	case "":
+		return &openapi.Schema{Type: "string"}
+	case "date":
+		return &openapi.Schema{Type: "string", Format: "date"}
+	default:
+		return &openapi.Schema{Type: p.Type}
+	}
+}
+
+func jsonContent(schema *openapi.Schema) map[string]*openapi.MediaType {
+	return map[string]*openapi.MediaType{"application/json": {Schema: schema}}
+}
+
+// handlerName returns the name of the Server method a handler is bound to, e.g. getShow
+func handlerName(handler http.HandlerFunc) string {
+	name := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name() // bookmyshow-lld/internal/api.(*Server).getShow-fm
+	name = name[strings.LastIndex(name, ".")+1:]
+	return strings.TrimSuffix(name, "-fm")
+}
+
+// getOpenAPI serves GET /openapi.json
+func (s *Server) getOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.openAPI)
+}
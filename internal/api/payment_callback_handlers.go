@@ -0,0 +1,54 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/models"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// maxCallbackBody bounds a gateway callback; providers' events are a few kilobytes
+const maxCallbackBody = 1 << 20
+
+// Statuses of a handled callback
+const (
+	callbackApplied = "APPLIED"
+	callbackIgnored = "IGNORED" // Verified, but about nothing bookings track
+)
+
+type gatewayCallbackResponse struct {
+	Status  string          `json:"status"`
+	Payment *models.Payment `json:"payment,omitempty"`
+}
+
+// receiveGatewayCallback serves POST /payments/callbacks. The gateway authenticates with the signature over the
+// raw body rather than a bearer token, so the body is read whole before anything parses it.
+func (s *Server) receiveGatewayCallback(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCallbackBody))
+	if err != nil {
+		if maxBytesErr := (*http.MaxBytesError)(nil); errors.As(err, &maxBytesErr) {
+			err = models.ErrInvalidGatewayCallback
+		} else {
+			err = errBadRequest
+		}
+		writeError(w, err)
+		return
+	}
+
+	callback, err := s.callbackVerifier.VerifyCallback(r.Header, body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if callback == nil {
+		writeJSON(w, http.StatusOK, gatewayCallbackResponse{Status: callbackIgnored})
+		return
+	}
+
+	payment, err := s.reconcileService.HandleCallback(r.Context(), callback)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, gatewayCallbackResponse{Status: callbackApplied, Payment: payment})
+}
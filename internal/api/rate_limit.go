@@ -0,0 +1,39 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/ratelimit"
+	"bookmyshow-lld/internal/services"
+	"net"
+	"net/http"
+)
+
+// rateLimited throttles the route on pattern with a token bucket per caller, refusing requests over the
+// limit with 429 and Retry-After. Routes keep separate buckets, so a chatty seat map poll can't starve checkout.
+func (s *Server) rateLimited(pattern string, next http.Handler) http.Handler {
+	limit := s.rateLimits.EndpointLimit(pattern)
+	if !limit.Enabled() {
+		return next
+	}
+
+	limiter := ratelimit.New(limit, s.clock)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if retryAfter, ok := limiter.Allow(rateLimitKey(r)); !ok {
+			writeError(w, &models.RateLimitError{Scope: pattern, RetryAfter: retryAfter})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimitKey identifies the caller: the signed-in user wherever they connect from, else the client's IP
+func rateLimitKey(r *http.Request) string {
+	if userID := services.CallerFromContext(r.Context()); userID != "" {
+		return "user:" + userID
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// defaultRevenueDays is the range served when the client passes no dates
+const defaultRevenueDays = 7
+
+// Reporting handlers - occupancy, revenue and settlements for theatre managers
+
+func (s *Server) getShowReport(w http.ResponseWriter, r *http.Request) {
+	report, err := s.reportingService.GetShowReport(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// getTheatreRevenue serves GET /admin/theatres/{id}/revenue?from=2030-01-01&to=2030-01-07 - inclusive UTC days, the last week by default
+func (s *Server) getTheatreRevenue(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	to := time.Now().UTC()
+	if raw := query.Get("to"); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			writeError(w, fmt.Errorf("%w: to must be YYYY-MM-DD", errBadQuery))
+			return
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, 1-defaultRevenueDays)
+	if raw := query.Get("from"); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			writeError(w, fmt.Errorf("%w: from must be YYYY-MM-DD", errBadQuery))
+			return
+		}
+		from = parsed
+	}
+
+	report, err := s.reportingService.GetTheatreDailyRevenue(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), from, to)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+type generateSettlementRequest struct {
+	From string `json:"from"` // YYYY-MM-DD, inclusive UTC days of show start times
+	To   string `json:"to"`
+}
+
+type markSettlementPaidRequest struct {
+	Reference string `json:"reference"` // Bank transfer or UTR number
+}
+
+func (s *Server) getTheatreSettlements(w http.ResponseWriter, r *http.Request) {
+	statement, err := s.reportingService.GetTheatreSettlements(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, statement)
+}
+
+// generateSettlement serves POST /admin/theatres/{id}/settlements for a period that is already over
+func (s *Server) generateSettlement(w http.ResponseWriter, r *http.Request) {
+	var req generateSettlementRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	from, errFrom := time.Parse(time.DateOnly, req.From)
+	to, errTo := time.Parse(time.DateOnly, req.To)
+	if errFrom != nil || errTo != nil {
+		writeError(w, fmt.Errorf("%w: from and to must be YYYY-MM-DD", models.ErrInvalidSettlementData))
+		return
+	}
+
+	settlement, err := s.settlementSvc.GenerateSettlement(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), from, to)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, settlement)
+}
+
+func (s *Server) markSettlementPaid(w http.ResponseWriter, r *http.Request) {
+	var req markSettlementPaidRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	settlement, err := s.settlementSvc.MarkSettlementPaid(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.Reference)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, settlement)
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/models"
+	"net/http"
+)
+
+type listForResaleRequest struct {
+	Price    float64 `json:"price"` // No more than the booking cost
+	Currency string  `json:"currency,omitempty"`
+}
+
+type buyResaleRequest struct {
+	Method       models.PaymentMethod `json:"method"`
+	TenureMonths int                  `json:"tenure_months,omitempty"` // Required for EMI: 3, 6, 9 or 12
+	InstrumentID string               `json:"instrument_id,omitempty"` // One of the buyer's saved instruments
+}
+
+// Resale handlers - selling a confirmed booking on to another user at no more than face value
+
+// listForResale serves POST /bookings/{id}/resale - puts the caller's booking up for resale
+func (s *Server) listForResale(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req listForResaleRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	listing, err := s.resaleService.ListBooking(r.Context(), r.PathValue("id"), userID, models.MoneyFromMajor(req.Price, req.Currency))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, listing)
+}
+
+func (s *Server) getShowResaleListings(w http.ResponseWriter, r *http.Request) {
+	listings, err := s.resaleService.GetShowListings(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, listings)
+}
+
+func (s *Server) getResaleListing(w http.ResponseWriter, r *http.Request) {
+	listing, err := s.resaleService.GetListing(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, listing)
+}
+
+func (s *Server) withdrawResaleListing(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	listing, err := s.resaleService.WithdrawListing(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, listing)
+}
+
+// buyResaleListing serves POST /resale/{id}/buy - charges the caller and hands them the booking
+func (s *Server) buyResaleListing(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req buyResaleRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	purchase, err := s.resaleService.BuyListing(r.Context(), r.PathValue("id"), userID, req.Method, paymentOptions(req.TenureMonths, req.InstrumentID)...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, purchase)
+}
+
+func (s *Server) getSellerResaleListings(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	listings, err := s.resaleService.GetSellerListings(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, listings)
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/errcodes"
+	"bookmyshow-lld/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// errorResponse is the JSON body returned for failed requests
+type errorResponse struct {
+	Error     string         `json:"error"`
+	Code      string         `json:"code"`                // Stable machine-readable code, e.g. SEAT_NOT_AVAILABLE
+	Retryable bool           `json:"retryable,omitempty"` // Sending the same request again may succeed
+	Details   map[string]any `json:"details,omitempty"`
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError maps a domain error to an HTTP status and writes it; rate limited callers are told when to retry
+func writeError(w http.ResponseWriter, err error) {
+	var rateLimited *models.RateLimitError
+	if errors.As(err, &rateLimited) {
+		w.Header().Set("Retry-After", strconv.Itoa(rateLimited.RetryAfterSeconds()))
+	}
+	domainErr := models.AsDomainError(err)
+	writeJSON(w, statusForError(err), errorResponse{
+		Error:     err.Error(),
+		Code:      domainErr.Code,
+		Retryable: domainErr.Retryable,
+		Details:   domainErr.Details,
+	})
+}
+
+// decodeJSON decodes the request body into dst, rejecting unknown fields
+func decodeJSON(r *http.Request, dst interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return errBadRequest
+	}
+	return nil
+}
+
+// errBadRequest is returned when the request body cannot be parsed
+var errBadRequest = models.NewDomainError(models.KindInvalid, "MALFORMED_BODY", "malformed request body")
+
+// errBadQuery is returned when a query parameter cannot be parsed
+var errBadQuery = models.NewDomainError(models.KindInvalid, "MALFORMED_QUERY", "malformed query parameter")
+
+// defaultPageSize applies to list endpoints when the client doesn't pass a limit
+const defaultPageSize = 20
+
+// parsePage reads ?offset= and ?limit= for list endpoints
+func parsePage(r *http.Request) (offset, limit int, err error) {
+	query := r.URL.Query()
+	offset, limit = 0, defaultPageSize
+
+	if raw := query.Get("offset"); raw != "" {
+		if offset, err = strconv.Atoi(raw); err != nil {
+			return 0, 0, fmt.Errorf("%w: offset must be a number", errBadQuery)
+		}
+	}
+	if raw := query.Get("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil {
+			return 0, 0, fmt.Errorf("%w: limit must be a number", errBadQuery)
+		}
+	}
+	return offset, limit, nil
+}
+
+// statusForError maps domain errors to HTTP status codes
+func statusForError(err error) int {
+	return errcodes.HTTPStatus(err)
+}
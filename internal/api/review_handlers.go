@@ -0,0 +1,116 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/services"
+	"net/http"
+)
+
+type reviewRequest struct {
+	Stars int    `json:"stars"` // 1-5
+	Text  string `json:"text,omitempty"`
+}
+
+type moderateReviewRequest struct {
+	Approve bool   `json:"approve"`
+	Note    string `json:"note,omitempty"`
+}
+
+// Review handlers
+
+func (s *Server) submitReview(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req reviewRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	review, err := s.reviewService.SubmitReview(r.Context(), userID, r.PathValue("id"), req.Stars, req.Text)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, review)
+}
+
+func (s *Server) editReview(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req reviewRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	review, err := s.reviewService.EditReview(r.Context(), r.PathValue("id"), userID, req.Stars, req.Text)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, review)
+}
+
+func (s *Server) listMovieReviews(w http.ResponseWriter, r *http.Request) {
+	offset, limit, err := parsePage(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	reviews, err := s.reviewService.ListMovieReviews(r.Context(), r.PathValue("id"), offset, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, reviews)
+}
+
+func (s *Server) getMovieRating(w http.ResponseWriter, r *http.Request) {
+	summary, err := s.reviewService.GetRatingSummary(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// Moderation handlers - admin only, caller from the session token
+
+func (s *Server) listPendingReviews(w http.ResponseWriter, r *http.Request) {
+	offset, limit, err := parsePage(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	reviews, err := s.reviewService.ListPendingReviews(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), offset, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, reviews)
+}
+
+func (s *Server) moderateReview(w http.ResponseWriter, r *http.Request) {
+	var req moderateReviewRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	review, err := s.reviewService.ModerateReview(r.Context(), services.CallerFromContext(r.Context()), r.PathValue("id"), req.Approve, req.Note)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, review)
+}
@@ -0,0 +1,222 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/pricing"
+	"bookmyshow-lld/internal/services"
+	"net/http"
+)
+
+// Query parameters several list endpoints share
+var pageParams = []param{
+	{Name: "offset", Type: "integer", Description: "Items to skip"},
+	{Name: "limit", Type: "integer", Description: "Items to return; 20 by default"},
+}
+
+// routes lists every JSON endpoint with its handler and what the OpenAPI document says about it
+func (s *Server) routes() []route {
+	return []route{
+		// Users
+		{"POST /auth/signup", s.signup, operation{Summary: "Create a customer and sign them in", Request: signupRequest{}, Response: services.AuthSession{}, Status: http.StatusCreated}},
+		{"POST /auth/login", s.login, operation{Summary: "Sign in with email and password", Request: loginRequest{}, Response: services.AuthSession{}}},
+		{"POST /auth/logout", s.logout, operation{Summary: "End the session of the bearer token", Status: http.StatusNoContent}},
+		{"GET /auth/me", s.me, operation{Summary: "The signed-in user", Auth: true, Response: models.User{}}},
+		{"POST /users", s.signup, operation{ID: "createUser", Summary: "Create a customer and sign them in, like /auth/signup", Request: signupRequest{}, Response: services.AuthSession{}, Status: http.StatusCreated}},
+		{"GET /users/{id}", s.getUser, operation{Summary: "A user's profile", Auth: true, Response: models.User{}}},
+		{"PUT /users/{id}/date-of-birth", s.setDateOfBirth, operation{Summary: "Set the date of birth age-rated shows check", Auth: true, Request: dateOfBirthRequest{}, Response: models.User{}}},
+		{"PUT /users/{id}/whatsapp-opt-in", s.setWhatsAppOptIn, operation{Summary: "Opt in to or out of WhatsApp messages", Auth: true, Request: whatsAppOptInRequest{}, Response: models.User{}}},
+		{"POST /users/{id}/discount-profile", s.submitDiscountProfile, operation{Summary: "Apply for a student, senior citizen or military discount", Auth: true, Request: discountProfileRequest{}, Response: models.User{}}},
+		{"GET /users/{id}/preferences", s.getPreferences, operation{Summary: "Home city, languages, genres and favourite theatres", Auth: true, Response: models.UserPreferences{}}},
+		{"PUT /users/{id}/preferences", s.updatePreferences, operation{Summary: "Replace the whole preference profile", Auth: true, Request: models.UserPreferences{}, Response: models.UserPreferences{}}},
+		{"GET /users/{id}/recommendations", s.getRecommendations, operation{Summary: "Movies the user hasn't booked, best first", Auth: true, Query: []param{{Name: "limit", Type: "integer", Description: "Movies to return"}}, Response: []*services.MovieRecommendation{}}},
+		{"GET /users/{id}/watchlist", s.getWatchlist, operation{Summary: "Watchlisted movies, newest first", Auth: true, Response: []*services.WatchlistItem{}}},
+		{"POST /users/{id}/watchlist", s.addToWatchlist, operation{Summary: "Watchlist a movie; adding it again returns the existing entry", Auth: true, Request: watchlistRequest{}, Response: models.WatchlistEntry{}, Status: http.StatusCreated}},
+		{"DELETE /users/{id}/watchlist/{movieID}", s.removeFromWatchlist, operation{Summary: "Take a movie off the watchlist", Auth: true, Status: http.StatusNoContent}},
+		{"GET /users/{id}/instruments", s.getInstruments, operation{Summary: "Saved cards, UPI handles and wallet links, oldest first", Auth: true, Response: []*models.PaymentInstrument{}}},
+		{"POST /users/{id}/instruments", s.addInstrument, operation{Summary: "Save a card, UPI handle or wallet link to pay with later", Auth: true, Request: addInstrumentRequest{}, Response: models.PaymentInstrument{}, Status: http.StatusCreated}},
+		{"DELETE /users/{id}/instruments/{instrumentID}", s.removeInstrument, operation{Summary: "Remove a saved payment instrument", Auth: true, Status: http.StatusNoContent}},
+		{"GET /users/{id}/bookings", s.getUserBookings, operation{Summary: "My Bookings: upcoming, past or cancelled", Auth: true, Query: append([]param{{Name: "category", Description: "UPCOMING, PAST or CANCELLED"}}, pageParams...), Response: services.UserBookings{}}},
+		{"GET /users/{id}/transfers", s.getIncomingTransfers, operation{Summary: "Bookings offered to the user, waiting for them to accept or decline", Auth: true, Response: []*models.Booking{}}},
+		{"GET /users/{id}/resale", s.getSellerResaleListings, operation{Summary: "Bookings the user has put up for resale, newest first", Auth: true, Response: []*models.ResaleListing{}}},
+		{"GET /users/{id}/wallet", s.getWallet, operation{Summary: "Wallet balance and transactions, newest first", Auth: true, Query: pageParams, Response: services.WalletStatement{}}},
+		{"POST /users/{id}/wallet/topup", s.topUpWallet, operation{Summary: "Add money to the wallet", Auth: true, Request: topUpRequest{}, Response: models.WalletTransaction{}, Status: http.StatusCreated}},
+		{"GET /users/{id}/loyalty", s.getLoyaltyAccount, operation{Summary: "Loyalty points balance", Auth: true, Response: models.LoyaltyAccount{}}},
+
+		// Movies
+		{"POST /movies", s.createMovie, operation{Summary: "Add a movie to the catalog", Auth: true, Request: createMovieRequest{}, Response: models.Movie{}, Status: http.StatusCreated}},
+		{"GET /movies", s.listReleasedMovies, operation{Summary: "Released movies", Response: []*models.Movie{}}},
+		{"GET /movies/trending", s.getTrending, operation{Summary: "Movies with the most seats booked lately", Query: []param{{Name: "city", Description: "The signed-in user's home city by default, else every city"}}, Response: []*services.TrendingMovie{}}},
+		{"GET /movies/{id}", s.getMovie, operation{Summary: "A movie", Response: models.Movie{}}},
+		{"GET /movies/{id}/shows", s.getShowsByMovie, operation{Summary: "Every show of a movie", Response: []*models.Show{}}},
+
+		// Live events
+		{"POST /events", s.createEvent, operation{Summary: "Add a concert, play or stand-up event", Auth: true, Request: createEventRequest{}, Response: models.Event{}, Status: http.StatusCreated}},
+		{"GET /events", s.listEvents, operation{Summary: "Live events", Query: []param{{Name: "type", Description: "e.g. CONCERT; every type when empty"}}, Response: []*models.Event{}}},
+		{"GET /events/{id}", s.getEvent, operation{Summary: "A live event", Response: models.Event{}}},
+		{"GET /events/{id}/shows", s.getShowsByEvent, operation{Summary: "Every show of a live event", Response: []*models.Show{}}},
+
+		// Reviews
+		{"POST /movies/{id}/reviews", s.submitReview, operation{Summary: "Review a movie; pending until moderated", Auth: true, Request: reviewRequest{}, Response: models.Review{}, Status: http.StatusCreated}},
+		{"GET /movies/{id}/reviews", s.listMovieReviews, operation{Summary: "Approved reviews, newest first", Query: pageParams, Response: services.MovieReviews{}}},
+		{"GET /movies/{id}/rating", s.getMovieRating, operation{Summary: "Aggregate rating and star distribution", Response: services.RatingSummary{}}},
+		{"PUT /reviews/{id}", s.editReview, operation{Summary: "Edit your review; it goes back to moderation", Auth: true, Request: reviewRequest{}, Response: models.Review{}}},
+
+		// Cities and theatre discovery
+		{"GET /cities", s.listCities, operation{Summary: "Cities with theatres", Response: []*models.City{}}},
+		{"GET /cities/{id}/theatres", s.getTheatresByCity, operation{Summary: "Theatres in a city, by name", Response: []*models.Theatre{}}},
+		{"GET /theatres/nearby", s.getNearbyTheatres, operation{Summary: "Theatres near a location, nearest first", Query: []param{
+			{Name: "lat", Type: "number", Required: true},
+			{Name: "lng", Type: "number", Required: true},
+			{Name: "radius_km", Type: "number", Description: "10 by default"},
+		}, Response: []*services.NearbyTheatre{}}},
+
+		// Theatres and screens
+		{"POST /theatres", s.createTheatre, operation{Summary: "Add a theatre", Auth: true, Request: createTheatreRequest{}, Response: models.Theatre{}, Status: http.StatusCreated}},
+		{"GET /theatres/{id}", s.getTheatre, operation{Summary: "A theatre with its screens", Response: models.Theatre{}}},
+		{"POST /theatres/{id}/screens", s.addScreen, operation{Summary: "Add a screen with the default seat layout", Auth: true, Request: addScreenRequest{}, Response: models.Screen{}, Status: http.StatusCreated}},
+
+		// Shows
+		{"POST /shows", s.createShow, operation{Summary: "Schedule a movie show, or a live event show with event_id", Auth: true, Request: createShowRequest{}, Response: models.Show{}, Status: http.StatusCreated}},
+		{"GET /shows", s.searchShows, operation{Summary: "Bookable shows, soonest first; unset filters come from the signed-in user's preferences", Query: []param{
+			{Name: "movie_id"},
+			{Name: "format", Description: "2D, 3D, IMAX or 4DX"},
+			{Name: "language"},
+			{Name: "city"},
+			{Name: "defaults", Description: "off skips the preference defaults"},
+		}, Response: []*models.Show{}}},
+		{"GET /shows/now-showing", s.getNowShowing, operation{Summary: "Movies bookable in a city that day, most shows first", Query: []param{
+			{Name: "city", Description: "The signed-in user's home city by default, else every city"},
+			{Name: "date", Type: "date", Description: "Today by default"},
+		}, Response: []*services.NowShowingMovie{}}},
+		{"GET /shows/deals", s.getActiveDeals, operation{Summary: "Last-minute deals running now, soonest show first", Query: []param{{Name: "city", Description: "The signed-in user's home city by default, else every city"}}, Response: []*models.Deal{}}},
+		{"GET /shows/{id}", s.getShow, operation{Summary: "A show", Response: models.Show{}}},
+		{"GET /seat-types", s.listSeatTypes, operation{Summary: "Every seat type and its price multiplier", Response: map[models.SeatType]factories.SeatTypeInfo{}}},
+		{"GET /screen-templates", s.listScreenTemplates, operation{Summary: "Named screen layouts, fewest seats first", Response: []factories.ScreenTemplate{}}},
+		{"GET /shows/{id}/seats", s.getSeatAvailability, operation{Summary: "Seat map with this show's seat status and prices", Response: models.SeatMap{}}},
+		{"GET /shows/{id}/availability", s.getAvailabilitySummary, operation{Summary: "Seat counts and a badge such as FILLING_FAST", Response: services.AvailabilitySummary{}}},
+		{"GET /shows/{id}/add-ons", s.getAddOnOffers, operation{Summary: "Add-ons a booking for the show can buy, e.g. parking", Response: []services.AddOnOffer{}}},
+		{"GET /shows/{id}/parking", s.getParkingAvailability, operation{Summary: "Parking slots left at the theatre for the length of the show", Response: services.ParkingAvailability{}}},
+		{"GET /shows/{id}/resale", s.getShowResaleListings, operation{Summary: "Bookings for the show on resale, cheapest first", Response: []*models.ResaleListing{}}},
+		{"GET /shows/{id}/seats/suggest", s.suggestSeats, operation{Summary: "Best block of adjacent seats", Query: []param{
+			{Name: "count", Type: "integer", Required: true},
+			{Name: "type", Description: "Seat type, e.g. REGULAR"},
+		}, Response: services.SeatSuggestion{}}},
+		{"GET /shows/{id}/seats/stream", s.streamSeats, operation{Summary: "Server-Sent Events: a seat map snapshot, then every seat blocked, booked or released", Produces: "text/event-stream"}},
+
+		// Seat holds
+		{"POST /holds", s.createHold, operation{Summary: "Hold seats for a few minutes before booking", Auth: true, Request: createHoldRequest{}, Response: models.SeatHold{}, Status: http.StatusCreated}},
+		{"GET /holds/{id}", s.getHold, operation{Summary: "A seat hold", Response: models.SeatHold{}}},
+		{"POST /holds/{id}/extend", s.extendHold, operation{Summary: "Extend a hold's expiry", Auth: true, Response: models.SeatHold{}}},
+		{"POST /holds/{id}/release", s.releaseHold, operation{Summary: "Give held seats back", Auth: true, Response: models.SeatHold{}}},
+
+		// Bookings
+		{"POST /bookings", s.createBooking, operation{Summary: "Book seats; the booking stays pending until paid", Auth: true, Request: createBookingRequest{}, Response: models.Booking{}, Status: http.StatusCreated}},
+		{"GET /bookings/{id}", s.getBooking, operation{Summary: "A booking", Auth: true, Response: models.Booking{}}},
+		{"GET /bookings", s.getBookingByReference, operation{Summary: "A booking by its reference code", Query: []param{{Name: "reference", Required: true, Description: "e.g. BMS-7F3K9Q"}}, Response: models.Booking{}}},
+		{"GET /bookings/{id}/parking", s.getParkingReservation, operation{Summary: "The parking slots held or booked with a booking", Response: models.ParkingReservation{}}},
+		{"GET /bookings/{id}/details", s.getBookingDetails, operation{Summary: "A booking with its show, theatre, seats, price breakdown and payment", Auth: true, Response: services.BookingDetails{}}},
+		{"POST /bookings/{id}/confirm", s.confirmBooking, operation{Summary: "Confirm a booking with its successful payment", Auth: true, Request: confirmBookingRequest{}, Response: models.Booking{}}},
+		{"POST /bookings/{id}/extend", s.extendBookingHold, operation{Summary: "Extend a pending booking's payment window, once per booking and a few times a day", Auth: true, Response: models.Booking{}}},
+		{"POST /bookings/{id}/cancel", s.cancelBooking, operation{Summary: "Cancel a booking, refunding it if it was confirmed", Auth: true, Response: models.Booking{}}},
+		{"POST /bookings/{id}/seats", s.modifySeats, operation{Summary: "Move a booking to other seats; upgrades are charged, downgrades refunded", Auth: true, Request: modifySeatsRequest{}, Response: services.SeatModification{}}},
+		{"POST /bookings/{id}/transfer", s.transferBooking, operation{Summary: "Offer a confirmed booking to another registered user", Auth: true, Request: transferBookingRequest{}, Response: models.BookingTransfer{}, Status: http.StatusCreated}},
+		{"POST /bookings/{id}/transfer/accept", s.acceptTransfer, operation{Summary: "Accept a booking offered to you; its ticket is signed again for you", Auth: true, Response: models.Booking{}}},
+		{"POST /bookings/{id}/transfer/decline", s.declineTransfer, operation{Summary: "Decline a booking offered to you", Auth: true, Response: models.Booking{}}},
+		{"POST /bookings/{id}/resale", s.listForResale, operation{Summary: "Put a confirmed booking up for resale at no more than it cost", Auth: true, Request: listForResaleRequest{}, Response: models.ResaleListing{}, Status: http.StatusCreated}},
+		{"POST /bookings/{id}/loyalty", s.redeemLoyaltyPoints, operation{Summary: "Pay part of a pending booking with points", Auth: true, Request: redeemPointsRequest{}, Response: models.Booking{}}},
+
+		// Resale
+		{"GET /resale/{id}", s.getResaleListing, operation{Summary: "A resale listing", Response: models.ResaleListing{}}},
+		{"POST /resale/{id}/buy", s.buyResaleListing, operation{Summary: "Buy a listed booking; the seller is refunded the price less the resale fee", Auth: true, Request: buyResaleRequest{}, Response: services.ResalePurchase{}, Status: http.StatusCreated}},
+		{"POST /resale/{id}/withdraw", s.withdrawResaleListing, operation{Summary: "Take your booking off resale", Auth: true, Response: models.ResaleListing{}}},
+
+		// Corporate blocks
+		{"POST /bulk-bookings", s.reserveBulkBlock, operation{Summary: "Reserve a block of seats with a redemption code per seat", Auth: true, Request: reserveBulkBlockRequest{}, Response: services.BulkBookingDetails{}, Status: http.StatusCreated}},
+		{"GET /bulk-bookings", s.listBulkBookings, operation{Summary: "The account's blocks, newest first", Auth: true, Response: []*services.BulkBookingDetails{}}},
+		{"GET /bulk-bookings/{id}", s.getBulkBooking, operation{Summary: "A block with its codes and counts", Auth: true, Response: services.BulkBookingDetails{}}},
+		{"POST /bulk-bookings/{id}/release", s.releaseBulkSeats, operation{Summary: "Hand back unredeemed seats", Auth: true, Request: releaseBulkSeatsRequest{}, Response: services.BulkRelease{}}},
+		{"POST /bulk-codes/{code}/redeem", s.redeemBulkCode, operation{Summary: "Redeem a code for a seat in the block", Auth: true, Response: services.BulkPass{}}},
+
+		// Tickets and check-in
+		{"POST /bookings/{id}/ticket", s.issueTicket, operation{Summary: "Issue the e-ticket of a confirmed booking", Response: models.Ticket{}}},
+		{"GET /bookings/{id}/ticket", s.getBookingTicket, operation{Summary: "A booking's e-ticket", Response: models.Ticket{}}},
+		{"GET /bookings/{id}/ticket/print", s.printTicket, operation{Summary: "Printable ticket and invoice", Query: []param{{Name: "download", Type: "boolean", Description: "Save instead of opening"}}, Produces: "text/html"}},
+		{"GET /bookings/{id}/calendar.ics", s.getBookingCalendar, operation{Summary: "Calendar event with the show times, theatre address, seats and reference", Produces: "text/calendar"}},
+		{"GET /tickets/{id}/qr", s.getTicketQRCode, operation{Summary: "QR code of the signed ticket payload", Query: []param{{Name: "size", Type: "integer", Description: "Edge length in pixels"}}, Produces: "image/png"}},
+		{"POST /theatres/{id}/checkin/validate", s.validateTicket, operation{Summary: "Check a scanned ticket without admitting it", Auth: true, Request: checkInRequest{}, Response: services.TicketValidation{}}},
+		{"POST /theatres/{id}/checkin", s.checkIn, operation{Summary: "Admit a scanned ticket once", Auth: true, Request: checkInRequest{}, Response: services.TicketValidation{}}},
+
+		// Payments
+		{"POST /payments", s.processPayment, operation{Summary: "Pay for a pending booking", Auth: true, Request: processPaymentRequest{}, Response: models.Payment{}, Status: http.StatusCreated}},
+		{"GET /payments/{id}", s.getPayment, operation{Summary: "A payment", Response: models.Payment{}}},
+		{"POST /payments/{id}/challenge", s.completeChallenge, operation{Summary: "Enter the card issuer's OTP for a payment that requires action", Auth: true, Request: completeChallengeRequest{}, Response: models.Payment{}}},
+		{"POST /payments/{id}/refunds", s.refundPayment, operation{Summary: "Refund part or all of a payment", Auth: true, Request: refundPaymentRequest{}, Response: models.Refund{}, Status: http.StatusCreated}},
+		{"POST /payments/callbacks", s.receiveGatewayCallback, operation{Summary: "Asynchronous payment gateway callback, authenticated by the provider's signature header", Request: models.GatewayCallback{}, Response: gatewayCallbackResponse{}}},
+		{"GET /bookings/{id}/payments", s.getPaymentAttempts, operation{Summary: "Every payment attempt with its failure reason", Auth: true, Response: []*models.Payment{}}},
+		{"POST /bookings/{id}/payments/retry", s.retryPayment, operation{Summary: "Pay again after a failed attempt", Auth: true, Request: retryPaymentRequest{}, Response: models.Payment{}, Status: http.StatusCreated}},
+
+		// Promotions
+		{"POST /coupons", s.createCoupon, operation{Summary: "Create a coupon", Auth: true, Request: createCouponRequest{}, Response: models.Coupon{}, Status: http.StatusCreated}},
+		{"GET /coupons/{code}", s.getCoupon, operation{Summary: "A coupon", Response: models.Coupon{}}},
+
+		// Admin - caller identified by the session token, role checked by AdminService
+		{"POST /admin/users/{id}/role", s.grantRole, operation{Summary: "Grant a user a role", Auth: true, Request: grantRoleRequest{}, Response: models.User{}}},
+		{"POST /admin/users/{id}/block", s.blockUser, operation{Summary: "Stop a user holding seats or booking", Auth: true, Request: blockUserRequest{}, Response: models.User{}}},
+		{"DELETE /admin/users/{id}/block", s.unblockUser, operation{Summary: "Unblock a user", Auth: true, Response: models.User{}}},
+		{"GET /admin/discount-profiles", s.listPendingDiscountProfiles, operation{Summary: "Users whose discount profiles wait for review, oldest first", Auth: true, Response: []models.User{}}},
+		{"POST /admin/users/{id}/discount-profile/verify", s.verifyDiscountProfile, operation{Summary: "Verify a user's discount profile so their bookings get its discount", Auth: true, Response: models.User{}}},
+		{"POST /admin/users/{id}/discount-profile/reject", s.rejectDiscountProfile, operation{Summary: "Reject a user's discount profile", Auth: true, Request: rejectDiscountProfileRequest{}, Response: models.User{}}},
+		{"POST /admin/cities", s.addCity, operation{Summary: "Add a city", Auth: true, Request: addCityRequest{}, Response: models.City{}, Status: http.StatusCreated}},
+		{"POST /admin/seat-types", s.registerSeatType, operation{Summary: "Register a seat type for screen layouts", Auth: true, Request: registerSeatTypeRequest{}, Response: map[models.SeatType]factories.SeatTypeInfo{}, Status: http.StatusCreated}},
+		{"POST /admin/screen-templates", s.registerScreenTemplate, operation{Summary: "Register a named screen layout", Auth: true, Request: registerScreenTemplateRequest{}, Response: factories.ScreenTemplate{}, Status: http.StatusCreated}},
+		{"GET /admin/pricing-calendar", s.getPricingCalendar, operation{Summary: "Holiday and peak days, earliest first", Auth: true, Response: []pricing.CalendarDay{}}},
+		{"PUT /admin/pricing-calendar/{date}", s.setPricingDay, operation{Summary: "Flag a date as a holiday or peak day with a price multiplier", Auth: true, Request: pricingDayRequest{}, Response: pricing.CalendarDay{}}},
+		{"DELETE /admin/pricing-calendar/{date}", s.clearPricingDay, operation{Summary: "Take a date off the pricing calendar", Auth: true, Status: http.StatusNoContent}},
+		{"POST /admin/catalog/import", s.importCatalog, operation{Summary: "Import movies from the configured catalog source", Auth: true, Response: services.CatalogImport{}}},
+		{"POST /admin/theatres", s.onboardTheatre, operation{Summary: "Onboard a theatre", Auth: true, Request: createTheatreRequest{}, Response: models.Theatre{}, Status: http.StatusCreated}},
+		{"POST /admin/theatres/{id}/screens", s.adminAddScreen, operation{Summary: "Add a screen with a custom layout: rows or priced sections, aisles and missing seats", Auth: true, Request: adminScreenRequest{}, Response: models.Screen{}, Status: http.StatusCreated}},
+		{"POST /admin/theatres/{id}/screens/from-template", s.addScreensFromTemplate, operation{Summary: "Add one screen per name, laid out by a screen template", Auth: true, Request: templateScreensRequest{}, Response: []*models.Screen{}, Status: http.StatusCreated}},
+		{"PUT /admin/theatres/{id}/cancellation-policy", s.setCancellationPolicy, operation{Summary: "Set the refund tiers; no tiers restore the default", Auth: true, Request: cancellationPolicyRequest{}, Response: models.Theatre{}}},
+		{"PUT /admin/theatres/{id}/booking-timeout", s.setTheatreBookingTimeout, operation{Summary: "Set the payment window of shows created afterwards", Auth: true, Request: bookingTimeoutRequest{}, Response: models.Theatre{}}},
+		{"PUT /admin/theatres/{id}/sale-window", s.setTheatreSaleWindow, operation{Summary: "Set when bookings open for shows created afterwards, unless their movie has its own window", Auth: true, Request: saleWindowRequest{}, Response: models.Theatre{}}},
+		{"PUT /admin/theatres/{id}/parking", s.setParkingCapacity, operation{Summary: "Size the theatre's car park; 0 stops new parking reservations", Auth: true, Request: parkingCapacityRequest{}, Response: models.Theatre{}}},
+		{"POST /admin/screens/{id}/clone", s.cloneScreen, operation{Summary: "Copy a screen's layout to a new screen, in any theatre the caller manages", Auth: true, Request: cloneScreenRequest{}, Response: models.Screen{}, Status: http.StatusCreated}},
+		{"POST /admin/screens/{id}/maintenance", s.setScreenMaintenance, operation{Summary: "Take a screen offline or bring it back", Auth: true, Request: maintenanceRequest{}, Response: models.Screen{}}},
+		{"GET /admin/screens/{id}/slots", s.suggestSlots, operation{Summary: "Free start times on a screen that day, turnaround included", Auth: true, Query: []param{
+			{Name: "movie_id"},
+			{Name: "date", Type: "date", Description: "A UTC day, today by default"},
+		}, Response: services.SlotSuggestion{}}},
+		{"POST /admin/shows/bulk", s.createShowsFromTemplate, operation{Summary: "Schedule shows from a weekly template", Auth: true, Request: bulkShowsRequest{}, Response: bulkShowsResponse{}, Status: http.StatusCreated}},
+		{"POST /admin/shows/{id}/cancel", s.cancelShow, operation{Summary: "Cancel a show, refunding its bookings in full", Auth: true, Request: cancelShowRequest{}, Response: services.ShowCancellation{}}},
+		{"POST /admin/shows/{id}/reschedule", s.rescheduleShow, operation{Summary: "Move a show to another start time", Auth: true, Request: rescheduleShowRequest{}, Response: services.ShowReschedule{}}},
+		{"POST /admin/shows/{id}/close-out", s.closeOutShow, operation{Summary: "Close out a show that has ended: unscanned tickets become no-shows and its figures are final", Auth: true, Response: models.Show{}}},
+		{"PUT /admin/shows/{id}/booking-timeout", s.setShowBookingTimeout, operation{Summary: "Set a show's payment window; 0 falls back to the theatre's", Auth: true, Request: bookingTimeoutRequest{}, Response: models.Show{}}},
+		{"GET /admin/shows/{id}/house-seats", s.getHouseSeats, operation{Summary: "Seats held back from sale", Auth: true, Response: []*models.HouseSeat{}}},
+		{"POST /admin/shows/{id}/house-seats", s.withholdSeats, operation{Summary: "Hold seats back from sale for this show", Auth: true, Request: withholdSeatsRequest{}, Response: []*models.HouseSeat{}, Status: http.StatusCreated}},
+		{"POST /admin/shows/{id}/house-seats/release", s.releaseHouseSeats, operation{Summary: "Put held back seats on sale", Auth: true, Request: releaseHouseSeatsRequest{}, Response: []*models.HouseSeat{}}},
+		{"PUT /admin/movies/{id}/sale-window", s.setMovieSaleWindow, operation{Summary: "Set when bookings open for the movie's shows created afterwards, overriding the theatres'", Auth: true, Request: saleWindowRequest{}, Response: models.Movie{}}},
+		{"GET /admin/movies/{id}/reviews/pending", s.listPendingReviews, operation{Summary: "Reviews waiting for moderation", Auth: true, Query: pageParams, Response: services.MovieReviews{}}},
+		{"POST /admin/reviews/{id}/moderate", s.moderateReview, operation{Summary: "Approve or reject a review", Auth: true, Request: moderateReviewRequest{}, Response: models.Review{}}},
+		{"GET /admin/shows/{id}/report", s.getShowReport, operation{Summary: "Occupancy and revenue of a show", Auth: true, Response: services.ShowReport{}}},
+		{"GET /admin/theatres/{id}/revenue", s.getTheatreRevenue, operation{Summary: "Daily revenue of a theatre", Auth: true, Query: []param{
+			{Name: "from", Type: "date", Description: "Inclusive UTC day; a week before to by default"},
+			{Name: "to", Type: "date", Description: "Inclusive UTC day; today by default"},
+		}, Response: services.TheatreRevenueReport{}}},
+		{"GET /admin/theatres/{id}/settlements", s.getTheatreSettlements, operation{Summary: "A theatre's settlements and what is still unsettled", Auth: true, Response: services.SettlementStatement{}}},
+		{"POST /admin/theatres/{id}/settlements", s.generateSettlement, operation{Summary: "Settle a period that is already over", Auth: true, Request: generateSettlementRequest{}, Response: models.Settlement{}, Status: http.StatusCreated}},
+		{"POST /admin/settlements/{id}/paid", s.markSettlementPaid, operation{Summary: "Record the transfer that paid a settlement", Auth: true, Request: markSettlementPaidRequest{}, Response: models.Settlement{}}},
+		{"POST /admin/theatres/{id}/webhooks", s.registerWebhook, operation{Summary: "Register a webhook; the only response holding its signing secret", Auth: true, Request: registerWebhookRequest{}, Response: models.Webhook{}, Status: http.StatusCreated}},
+		{"GET /admin/theatres/{id}/webhooks", s.listWebhooks, operation{Summary: "A theatre's webhooks, secrets left out", Auth: true, Response: []*models.Webhook{}}},
+		{"DELETE /admin/webhooks/{id}", s.deleteWebhook, operation{Summary: "Delete a webhook", Auth: true, Status: http.StatusNoContent}},
+		{"GET /admin/webhooks/{id}/deliveries", s.listWebhookDeliveries, operation{Summary: "A webhook's deliveries, newest first", Auth: true, Response: []*models.WebhookDelivery{}}},
+		{"GET /admin/outbox/dead-letters", s.getDeadLetters, operation{Summary: "Events that ran out of delivery attempts", Auth: true, Response: []*models.OutboxMessage{}}},
+		{"POST /admin/outbox/{id}/redeliver", s.redeliverEvent, operation{Summary: "Deliver a dead-lettered event again", Auth: true, Response: models.OutboxMessage{}}},
+		{"GET /admin/notifications/dead-letters", s.getNotificationDeadLetters, operation{Summary: "Notifications that ran out of delivery attempts or could never be delivered", Auth: true, Response: []*models.Notification{}}},
+		{"POST /admin/notifications/{id}/replay", s.replayNotification, operation{Summary: "Send a dead-lettered notification again", Auth: true, Response: models.Notification{}}},
+		{"GET /admin/whatsapp/templates", s.getWhatsAppTemplates, operation{Summary: "The template each notification kind is sent with on WhatsApp", Auth: true, Response: []models.WhatsAppTemplate{}}},
+		{"PUT /admin/whatsapp/templates/{kind}", s.setWhatsAppTemplate, operation{Summary: "Set the WhatsApp template a notification kind is sent with", Auth: true, Request: whatsAppTemplateRequest{}, Response: models.WhatsAppTemplate{}}},
+		{"DELETE /admin/whatsapp/templates/{kind}", s.removeWhatsAppTemplate, operation{Summary: "Stop sending a notification kind on WhatsApp", Auth: true, Status: http.StatusNoContent}},
+		{"GET /admin/audit/{entityID}", s.getAuditTrail, operation{Summary: "Every recorded change to an entity, oldest first", Auth: true, Response: []*models.AuditEntry{}}},
+	}
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/graphql"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/openapi"
+	"bookmyshow-lld/internal/realtime"
+	"bookmyshow-lld/internal/services"
+	"bookmyshow-lld/internal/tracing"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestIDHeader carries the request ID in and out; it is generated when the client sends none
+const requestIDHeader = "X-Request-ID"
+
+// Server exposes the business services as JSON HTTP endpoints
+type Server struct {
+	userService      services.UserService
+	preferenceSvc    services.PreferenceService
+	recommendations  services.RecommendationService
+	watchlistService services.WatchlistService
+	dealsService     services.DealsService
+	parkingService   services.ParkingService
+	resaleService    services.ResaleService
+	instrumentSvc    services.PaymentInstrumentService
+	closeOutService  services.ShowCloseOutService
+	authService      services.AuthService
+	movieService     services.MovieService
+	catalogImporter  services.CatalogImporter
+	eventService     services.EventService
+	theatreService   services.TheatreService
+	showService      services.ShowService
+	bookingService   services.BookingService
+	bulkBookingSvc   services.BulkBookingService
+	paymentService   services.PaymentService
+	reconcileService services.PaymentReconciliationService
+	callbackVerifier services.CallbackVerifier // Checks asynchronous gateway callbacks
+	promotionService services.PromotionService
+	seatHoldService  services.SeatHoldService
+	adminService     services.AdminService
+	authorizer       services.Authorizer // Who may read a booking
+	ticketService    services.TicketService
+	ticketRenderer   services.TicketRenderer
+	checkInService   services.CheckInService
+	reviewService    services.ReviewService
+	walletService    services.WalletService
+	loyaltyService   services.LoyaltyService
+	reportingService services.ReportingService
+	settlementSvc    services.SettlementService
+	webhookService   services.WebhookService
+	seatHub          *realtime.SeatHub // Live seat updates for the stream endpoint
+	metricsHandler   http.Handler      // Prometheus scrape endpoint
+	tracer           trace.Tracer      // Starts a span per request
+	seatFactory      *factories.SeatFactory
+	screenTemplates  *factories.ScreenTemplateLibrary
+	openAPI          *openapi.Document // Served at /openapi.json
+	healthChecker    services.HealthChecker
+	rateLimits       models.RateLimitConfig
+	clock            clock.Clock
+	mux              *http.ServeMux
+}
+
+// NewServer creates a new HTTP API server - demonstrates Dependency Injection
+func NewServer(
+	userService services.UserService,
+	preferenceService services.PreferenceService,
+	recommendationService services.RecommendationService,
+	watchlistService services.WatchlistService,
+	dealsService services.DealsService,
+	parkingService services.ParkingService,
+	resaleService services.ResaleService,
+	instrumentSvc services.PaymentInstrumentService,
+	closeOutService services.ShowCloseOutService,
+	authService services.AuthService,
+	movieService services.MovieService,
+	catalogImporter services.CatalogImporter,
+	eventService services.EventService,
+	theatreService services.TheatreService,
+	showService services.ShowService,
+	bookingService services.BookingService,
+	bulkBookingService services.BulkBookingService,
+	paymentService services.PaymentService,
+	reconcileService services.PaymentReconciliationService,
+	callbackVerifier services.CallbackVerifier,
+	promotionService services.PromotionService,
+	seatHoldService services.SeatHoldService,
+	adminService services.AdminService,
+	authorizer services.Authorizer,
+	ticketService services.TicketService,
+	ticketRenderer services.TicketRenderer,
+	checkInService services.CheckInService,
+	reviewService services.ReviewService,
+	walletService services.WalletService,
+	loyaltyService services.LoyaltyService,
+	reportingService services.ReportingService,
+	settlementService services.SettlementService,
+	webhookService services.WebhookService,
+	seatFactory *factories.SeatFactory,
+	seatHub *realtime.SeatHub,
+	metricsHandler http.Handler,
+	healthChecker services.HealthChecker,
+	tracerProvider trace.TracerProvider,
+	rateLimits models.RateLimitConfig,
+	clock clock.Clock,
+) *Server {
+	s := &Server{
+		userService:      userService,
+		preferenceSvc:    preferenceService,
+		recommendations:  recommendationService,
+		watchlistService: watchlistService,
+		dealsService:     dealsService,
+		parkingService:   parkingService,
+		resaleService:    resaleService,
+		instrumentSvc:    instrumentSvc,
+		closeOutService:  closeOutService,
+		authService:      authService,
+		movieService:     movieService,
+		catalogImporter:  catalogImporter,
+		eventService:     eventService,
+		theatreService:   theatreService,
+		showService:      showService,
+		bookingService:   bookingService,
+		bulkBookingSvc:   bulkBookingService,
+		paymentService:   paymentService,
+		reconcileService: reconcileService,
+		callbackVerifier: callbackVerifier,
+		promotionService: promotionService,
+		seatHoldService:  seatHoldService,
+		adminService:     adminService,
+		authorizer:       authorizer,
+		ticketService:    ticketService,
+		ticketRenderer:   ticketRenderer,
+		checkInService:   checkInService,
+		reviewService:    reviewService,
+		walletService:    walletService,
+		loyaltyService:   loyaltyService,
+		reportingService: reportingService,
+		settlementSvc:    settlementService,
+		webhookService:   webhookService,
+		seatHub:          seatHub,
+		metricsHandler:   metricsHandler,
+		healthChecker:    healthChecker,
+		tracer:           tracerProvider.Tracer(tracing.InstrumentationName),
+		seatFactory:      seatFactory,
+		screenTemplates:  factories.DefaultScreenTemplateLibrary(),
+		openAPI:          OpenAPI(),
+		rateLimits:       rateLimits,
+		clock:            clock,
+		mux:              http.NewServeMux(),
+	}
+	s.registerRoutes()
+	return s
+}
+
+// Handler returns the root HTTP handler
+func (s *Server) Handler() http.Handler {
+	return tracing.Middleware(s.tracer, withRequestID(s.authenticate(s.mux)))
+}
+
+// withRequestID tags the request context with an ID so every log record of the request carries it
+func withRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" {
+			requestID = logging.NewRequestID()
+		}
+
+		w.Header().Set(requestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+// registerRoutes wires every endpoint to its handler
+func (s *Server) registerRoutes() {
+	// Metrics
+	s.mux.Handle("GET /metrics", s.metricsHandler)
+
+	// Health - every dependency probed, for load balancers and uptime checks; never rate limited
+	s.mux.HandleFunc("GET /health", s.getHealth)
+
+	// GraphQL - movies, shows, seat maps and bookings with nested fields in one query
+	s.mux.Handle("POST /graphql", tracing.Route("POST /graphql", s.rateLimited("POST /graphql", graphql.NewHandler(s.movieService, s.eventService, s.theatreService, s.showService, s.bookingService))))
+
+	// The OpenAPI document of the JSON endpoints below
+	s.mux.HandleFunc("GET /openapi.json", s.getOpenAPI)
+
+	for _, route := range s.routes() {
+		s.mux.Handle(route.pattern, tracing.Route(route.pattern, s.rateLimited(route.pattern, route.handler)))
+	}
+}
+
+// getHealth serves GET /health: 200 while the application can take bookings, even degraded, and 503 once a
+// dependency is down
+func (s *Server) getHealth(w http.ResponseWriter, r *http.Request) {
+	report := s.healthChecker.HealthCheck(r.Context())
+	status := http.StatusOK
+	if report.Status == models.HealthDown {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, report)
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// streamHeartbeat keeps idle seat streams alive through proxies
+const streamHeartbeat = 15 * time.Second
+
+// Streaming handlers - Server-Sent Events for live seat maps
+
+// streamSeats serves GET /shows/{id}/seats/stream - a "snapshot" event with the seat map,
+// then a "seats" event whenever seats are blocked, booked or released
+func (s *Server) streamSeats(w http.ResponseWriter, r *http.Request) {
+	showID := r.PathValue("id")
+
+	// Subscribe before taking the snapshot so no change falls between the two
+	updates, cancel := s.seatHub.Subscribe(showID)
+	defer cancel()
+
+	seatMap, err := s.showService.GetSeatAvailability(r.Context(), showID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	stream := http.NewResponseController(w)
+	if writeEvent(w, "snapshot", 0, seatMap) != nil || stream.Flush() != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case update, ok := <-updates:
+			if !ok {
+				return // Fell behind, or the server is shutting down; the client reconnects for a fresh snapshot
+			}
+			if err := writeEvent(w, "seats", update.ID, update); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		if err := stream.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes one Server-Sent Event with a JSON payload; a zero id is left out
+func writeEvent(w http.ResponseWriter, event string, id uint64, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if id > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
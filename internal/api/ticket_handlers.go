@@ -0,0 +1,110 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+type checkInRequest struct {
+	Payload string `json:"payload"`        // Scanned from the QR code
+	Gate    string `json:"gate,omitempty"` // Entry point, recorded on the ticket
+}
+
+// Ticket handlers
+
+func (s *Server) issueTicket(w http.ResponseWriter, r *http.Request) {
+	ticket, err := s.ticketService.IssueTicket(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ticket)
+}
+
+func (s *Server) getBookingTicket(w http.ResponseWriter, r *http.Request) {
+	ticket, err := s.ticketService.GetTicketForBooking(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ticket)
+}
+
+// getTicketQRCode serves the ticket as a PNG QR code; ?size= sets the edge length in pixels
+func (s *Server) getTicketQRCode(w http.ResponseWriter, r *http.Request) {
+	size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+	png, err := s.ticketService.RenderQRCode(r.Context(), r.PathValue("id"), size)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.WriteHeader(http.StatusOK)
+	w.Write(png)
+}
+
+// printTicket serves the printable ticket and invoice; ?download=true saves it instead of opening it
+func (s *Server) printTicket(w http.ResponseWriter, r *http.Request) {
+	ticket, err := s.ticketRenderer.RenderTicket(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	disposition := "inline"
+	if r.URL.Query().Get("download") == "true" {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Type", ticket.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("%s; filename=%q", disposition, ticket.Filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(ticket.Content)
+}
+
+// getBookingCalendar serves the booking as an iCalendar event to add to a calendar app
+func (s *Server) getBookingCalendar(w http.ResponseWriter, r *http.Request) {
+	invite, err := s.ticketService.ExportICS(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", invite.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", invite.Filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(invite.Content)
+}
+
+// Check-in handlers - used by theatre gate scanners
+
+func (s *Server) validateTicket(w http.ResponseWriter, r *http.Request) {
+	var req checkInRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	validation, err := s.checkInService.ValidateTicket(r.Context(), r.PathValue("id"), req.Payload)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, validation)
+}
+
+func (s *Server) checkIn(w http.ResponseWriter, r *http.Request) {
+	var req checkInRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	validation, err := s.checkInService.CheckIn(r.Context(), r.PathValue("id"), req.Gate, req.Payload)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, validation)
+}
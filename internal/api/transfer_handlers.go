@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+)
+
+type transferBookingRequest struct {
+	Email string `json:"email"` // The recipient's account email
+}
+
+// Transfer handlers - handing a confirmed booking to another user, who accepts or declines it
+
+// transferBooking serves POST /bookings/{id}/transfer - offers the caller's booking to another user
+func (s *Server) transferBooking(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req transferBookingRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	transfer, err := s.bookingService.TransferBooking(r.Context(), r.PathValue("id"), userID, req.Email)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, transfer)
+}
+
+func (s *Server) acceptTransfer(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	booking, err := s.bookingService.AcceptTransfer(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, booking)
+}
+
+func (s *Server) declineTransfer(w http.ResponseWriter, r *http.Request) {
+	userID, err := requireCaller(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	booking, err := s.bookingService.DeclineTransfer(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, booking)
+}
+
+func (s *Server) getIncomingTransfers(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	bookings, err := s.bookingService.GetIncomingTransfers(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, bookings)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/models"
+	"net/http"
+)
+
+type topUpRequest struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+// Wallet handlers - a stored balance paid from with the WALLET method
+
+// getWallet serves GET /users/{id}/wallet?offset=0&limit=20 - the balance and recent transactions
+func (s *Server) getWallet(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	offset, limit, err := parsePage(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	statement, err := s.walletService.GetStatement(r.Context(), r.PathValue("id"), offset, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, statement)
+}
+
+func (s *Server) topUpWallet(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req topUpRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	tx, err := s.walletService.TopUp(r.Context(), r.PathValue("id"), models.MoneyFromMajor(req.Amount, req.Currency))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, tx)
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+)
+
+type watchlistRequest struct {
+	MovieID string `json:"movie_id"`
+}
+
+// Watchlist handlers - a user's own watchlist only
+
+func (s *Server) getWatchlist(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	items, err := s.watchlistService.GetWatchlist(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+// addToWatchlist serves POST /users/{id}/watchlist; adding a movie already there returns its entry again
+func (s *Server) addToWatchlist(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req watchlistRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entry, err := s.watchlistService.AddToWatchlist(r.Context(), r.PathValue("id"), req.MovieID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, entry)
+}
+
+func (s *Server) removeFromWatchlist(w http.ResponseWriter, r *http.Request) {
+	if err := requireSelf(r, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := s.watchlistService.RemoveFromWatchlist(r.Context(), r.PathValue("id"), r.PathValue("movieID")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"bookmyshow-lld/internal/events"
+	"net/http"
+)
+
+// Webhook handlers - theatre partners register callback URLs for booking and show events
+
+type registerWebhookRequest struct {
+	URL    string             `json:"url"`
+	Events []events.EventType `json:"events,omitempty"` // BOOKING_CONFIRMED, BOOKING_CANCELLED, SHOW_SOLD_OUT, DEAL_CREATED, SHOW_COMPLETED; all when empty
+}
+
+// registerWebhook serves POST /admin/theatres/{id}/webhooks; the response holds the signing secret, which is never shown again
+func (s *Server) registerWebhook(w http.ResponseWriter, r *http.Request) {
+	var req registerWebhookRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	webhook, err := s.webhookService.RegisterWebhook(r.Context(), r.PathValue("id"), req.URL, req.Events)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, webhook)
+}
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := s.webhookService.GetWebhooks(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, webhooks)
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := s.webhookService.DeleteWebhook(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listWebhookDeliveries serves GET /admin/webhooks/{id}/deliveries - newest first, with each one's attempts and last error
+func (s *Server) listWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := s.webhookService.GetDeliveries(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, deliveries)
+}
@@ -0,0 +1,246 @@
+package benchmarks
+
+import (
+	"bookmyshow-lld/internal/controllers"
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Shape of the large screen the hot path benchmarks run on: 20 rows of 25 seats
+const (
+	largeScreenRows    = 20
+	largeScreenRowSize = 25
+)
+
+// hotPathBookers is how many users book the same show at once in the contended booking benchmark
+const hotPathBookers = 32
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard) // Every booking logs its events
+	os.Exit(m.Run())
+}
+
+// BenchmarkCreateBookingContended books and cancels two adjacent seats per iteration, every goroutine on the
+// same show. Each goroutine keeps to its own seats, so they contend for the show's locks, not its seats.
+func BenchmarkCreateBookingContended(b *testing.B) {
+	ctx := context.Background()
+	fixture := newHotPathFixture(b, ctx, hotPathBookers)
+
+	stripe := len(fixture.seatIDs) / hotPathBookers
+	bookingService := fixture.app.GetBookingService()
+	var nextWorker, failed atomic.Int64
+
+	b.ReportAllocs()
+	b.SetParallelism(max(hotPathBookers/4, 1))
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		worker := int(nextWorker.Add(1)-1) % hotPathBookers
+		seats := fixture.seatIDs[worker*stripe : (worker+1)*stripe]
+		for i := 0; pb.Next(); i++ {
+			first := (2 * i) % (len(seats) - 1)
+			booking, err := bookingService.CreateBooking(ctx, fixture.userIDs[worker], fixture.show.ID, seats[first:first+2])
+			if err != nil {
+				if !errors.Is(err, models.ErrSeatNotAvailable) {
+					failed.Add(1)
+				}
+				continue
+			}
+			if err := bookingService.CancelBooking(ctx, booking.ID); err != nil {
+				failed.Add(1)
+			}
+		}
+	})
+	reportFailed(b, &failed)
+}
+
+// BenchmarkSeatMap500 draws the seat map of a 500-seat show with six seats per user held, pending or sold
+func BenchmarkSeatMap500(b *testing.B) {
+	ctx := context.Background()
+	fixture := newHotPathFixture(b, ctx, hotPathBookers)
+	fixture.fillShow(b, ctx)
+
+	showService := fixture.app.GetShowService()
+	var failed atomic.Int64
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if _, err := showService.GetSeatAvailability(ctx, fixture.show.ID); err != nil {
+			failed.Add(1)
+		}
+	}
+	reportFailed(b, &failed)
+}
+
+// BenchmarkBookingDetails assembles a confirmed booking's details - show, movie, theatre, seats, payment and
+// itemized price - on a show whose other seats are held, pending or sold
+func BenchmarkBookingDetails(b *testing.B) {
+	ctx := context.Background()
+	fixture := newHotPathFixture(b, ctx, hotPathBookers)
+	fixture.fillShow(b, ctx)
+
+	bookingService := fixture.app.GetBookingService()
+	bookingID := fixture.confirmed[0]
+	var failed atomic.Int64
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if _, err := bookingService.GetBookingDetails(ctx, bookingID); err != nil {
+			failed.Add(1)
+		}
+	}
+	reportFailed(b, &failed)
+}
+
+// reportFailed fails the benchmark if any call went wrong; seat conflicts don't count
+func reportFailed(b *testing.B, failed *atomic.Int64) {
+	if n := failed.Load(); n > 0 {
+		b.Errorf("%d calls failed", n)
+	}
+}
+
+// hotPathFixture is a fully wired app with one show on a 500-seat screen and users to book it
+type hotPathFixture struct {
+	app       *controllers.AppController
+	show      *models.Show
+	seatIDs   []string // Row by row, in seat order
+	userIDs   []string
+	confirmed []string // Booking IDs fillShow paid for
+}
+
+// newHotPathFixture wires an app whose payments always go through, with a show on a large screen; the app is
+// shut down when the benchmark ends
+func newHotPathFixture(b *testing.B, ctx context.Context, users int) *hotPathFixture {
+	b.Helper()
+	app := controllers.NewAppController(
+		controllers.WithLogger(logging.Nop()),
+		controllers.WithPaymentGateway(approvingGateway{}),
+	)
+	b.Cleanup(app.Shutdown)
+
+	fixture := &hotPathFixture{app: app}
+	if err := fixture.setUp(ctx, users); err != nil {
+		b.Fatal(err)
+	}
+	return fixture
+}
+
+func (f *hotPathFixture) setUp(ctx context.Context, users int) error {
+	admin, err := f.app.GetUserService().CreateUserWithRole(ctx, "Bench Admin", "admin@bench.test", "+15550000000", models.UserRoleSuperAdmin)
+	if err != nil {
+		return err
+	}
+	adminCtx := services.WithCaller(ctx, admin.ID)
+
+	movie, err := f.app.GetMovieService().CreateMovie(adminCtx, "Bench Movie", "Hot path fixture", 2*time.Hour, models.GenreAction, models.LanguageEnglish, 8.0, f.app.GetClock().Now())
+	if err != nil {
+		return err
+	}
+	theatre, err := f.app.GetAdminService().OnboardTheatre(ctx, admin.ID, "Bench Theatre", "1 Bench Street", "Mumbai")
+	if err != nil {
+		return err
+	}
+
+	var layout factories.ScreenConfig
+	for i := range largeScreenRows {
+		seatType := models.SeatTypeRegular
+		if i < 4 {
+			seatType = models.SeatTypePremium
+		}
+		layout.Rows = append(layout.Rows, factories.RowConfig{
+			Name:       string(rune('A' + i)),
+			Count:      largeScreenRowSize,
+			Type:       seatType,
+			AisleAfter: []int{6, 19},
+		})
+	}
+	basePrice := models.NewMoney(10000, models.DefaultCurrency)
+	screen, err := f.app.GetAdminService().AddScreen(ctx, admin.ID, theatre.ID, "Large Screen", layout, basePrice)
+	if err != nil {
+		return err
+	}
+	f.show, err = f.app.GetShowService().CreateShow(adminCtx, movie.ID, theatre.ID, screen.ID, f.app.GetClock().Now().Add(24*time.Hour), basePrice)
+	if err != nil {
+		return err
+	}
+
+	seatMap, err := f.app.GetShowService().GetSeatAvailability(ctx, f.show.ID)
+	if err != nil {
+		return err
+	}
+	for _, row := range seatMap.Rows {
+		for _, cell := range row.Cells {
+			if cell.IsSeat() {
+				f.seatIDs = append(f.seatIDs, cell.SeatID)
+			}
+		}
+	}
+
+	for i := range users {
+		user, err := f.app.GetUserService().CreateUser(ctx, fmt.Sprintf("Bench User %d", i+1), fmt.Sprintf("user%d@bench.test", i+1), fmt.Sprintf("+1555%07d", i+1))
+		if err != nil {
+			return err
+		}
+		f.userIDs = append(f.userIDs, user.ID)
+	}
+	return nil
+}
+
+// fillShow gives each user a sold, a pending and a held pair of seats, spread over the screen
+func (f *hotPathFixture) fillShow(b *testing.B, ctx context.Context) {
+	b.Helper()
+	bookingService := f.app.GetBookingService()
+	stripe := len(f.seatIDs) / len(f.userIDs)
+	for i, userID := range f.userIDs {
+		seats := f.seatIDs[i*stripe : (i+1)*stripe]
+
+		sold, err := bookingService.CreateBooking(ctx, userID, f.show.ID, seats[0:2])
+		if err != nil {
+			b.Fatal(err)
+		}
+		payment, err := f.app.GetPaymentService().ProcessPayment(ctx, sold.ID, models.PaymentMethodUPI)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := bookingService.ConfirmBooking(ctx, sold.ID, payment.ID); err != nil {
+			b.Fatal(err)
+		}
+		f.confirmed = append(f.confirmed, sold.ID)
+
+		if _, err := bookingService.CreateBooking(ctx, userID, f.show.ID, seats[2:4]); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := f.app.GetSeatHoldService().CreateHold(ctx, userID, f.show.ID, seats[4:6]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// approvingGateway approves every charge and refund, so payments never fail at random mid-benchmark
+type approvingGateway struct{}
+
+func (approvingGateway) ProcessPayment(ctx context.Context, amount models.Money, method models.PaymentMethod, metadata map[string]string) (*services.PaymentResult, error) {
+	return &services.PaymentResult{Success: true, TransactionID: "BENCH_" + metadata["payment_id"], Response: fmt.Sprintf("Paid %s via %s", amount, method)}, nil
+}
+
+func (approvingGateway) RefundPayment(ctx context.Context, transactionID string, amount models.Money, method models.PaymentMethod) (*services.PaymentResult, error) {
+	return &services.PaymentResult{Success: true, TransactionID: "REFUND_" + transactionID, Response: fmt.Sprintf("Refunded %s", amount)}, nil
+}
+
+func (approvingGateway) QueryTransaction(ctx context.Context, paymentID string, method models.PaymentMethod) (*services.PaymentResult, error) {
+	return &services.PaymentResult{Success: true, TransactionID: "BENCH_" + paymentID, Response: "Charge found"}, nil
+}
+
+func (approvingGateway) CompleteChallenge(ctx context.Context, token, otp string, method models.PaymentMethod) (*services.PaymentResult, error) {
+	return nil, models.ErrNoPaymentChallenge
+}
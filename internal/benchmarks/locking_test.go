@@ -0,0 +1,206 @@
+package benchmarks
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/metrics"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// benchmarkShows is how many shows the parallel bookers spread across
+const benchmarkShows = 64
+
+// storeLatency simulates a network-backed screen store; held locks are what make this expensive
+const storeLatency = 50 * time.Microsecond
+
+// BenchmarkBookingLocks compares a single global booking lock against per-show locks by booking and
+// cancelling one seat per iteration, each goroutine on its own show
+func BenchmarkBookingLocks(b *testing.B) {
+	// Each service gets its own manager, as each used to have its own mutex
+	managers := []struct {
+		name    string
+		manager func() locks.LockManager
+	}{
+		{"global-lock", func() locks.LockManager { return locks.NewGlobalLockManager() }},
+		{"per-show-lock", func() locks.LockManager { return locks.NewKeyedLockManager() }},
+	}
+
+	for _, m := range managers {
+		b.Run(m.name, func(b *testing.B) {
+			benchmarkBookings(b, m.manager(), m.manager())
+		})
+	}
+}
+
+// benchmarkBookings books and cancels seats on fixture shows, one show per goroutine
+func benchmarkBookings(b *testing.B, bookingLocks, holdLocks locks.LockManager) {
+	ctx := context.Background()
+	fixture, err := newBookingFixture(ctx, bookingLocks, holdLocks)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var nextWorker, failed atomic.Int64
+	b.SetParallelism(benchmarkShows / 4)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		show := fixture.shows[int(nextWorker.Add(1)-1)%len(fixture.shows)]
+		for i := 0; pb.Next(); i++ {
+			seatID := show.seatIDs[i%len(show.seatIDs)]
+
+			booking, err := fixture.bookingService.CreateBooking(ctx, fixture.userID, show.id, []string{seatID})
+			if err != nil {
+				failed.Add(1)
+				continue
+			}
+			if err := fixture.bookingService.CancelBooking(ctx, booking.ID); err != nil {
+				failed.Add(1)
+			}
+		}
+	})
+	reportFailed(b, &failed)
+}
+
+// benchmarkShow is a show with the seats a worker cycles through
+type benchmarkShow struct {
+	id      string
+	seatIDs []string
+}
+
+// bookingFixture is a booking service wired with in-memory repositories and no subscribers
+type bookingFixture struct {
+	bookingService services.BookingService
+	userID         string
+	shows          []benchmarkShow
+}
+
+// newBookingFixture creates one user and benchmarkShows shows, each on its own screen
+func newBookingFixture(ctx context.Context, bookingLocks, holdLocks locks.LockManager) (*bookingFixture, error) {
+	userRepo := repositories.NewMemoryUserRepository()
+	movieRepo := repositories.NewMemoryMovieRepository()
+	theatreRepo := repositories.NewMemoryTheatreRepository()
+	screenRepo := &slowScreenRepository{ScreenRepository: repositories.NewMemoryScreenRepository(), latency: storeLatency}
+	showRepo := repositories.NewMemoryShowRepository()
+
+	holdService := services.NewSeatHoldService(
+		repositories.NewMemorySeatHoldRepository(),
+		userRepo,
+		showRepo,
+		screenRepo,
+		nil,
+		holdLocks,
+		models.BookingTimeouts{},
+		logging.Nop(),
+		metrics.Nop(),
+		clock.New(),
+	)
+	bookingService := services.NewBookingService(
+		repositories.NewMemoryBookingRepository(),
+		showRepo,
+		screenRepo,
+		theatreRepo,
+		movieRepo,
+		repositories.NewMemoryEventRepository(),
+		repositories.NewMemoryPaymentRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		holdService,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		models.FeeConfig{},
+		models.BookingTimeouts{},
+		nil,
+		bookingLocks,
+		nil,
+		logging.Nop(),
+		metrics.Nop(),
+		nil,
+		nil,
+		clock.New(),
+	)
+
+	now := time.Now()
+	user, err := models.NewUser("Bench User", "bench@example.com", "+10000000000", now)
+	if err != nil {
+		return nil, err
+	}
+	if err := userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	movie, err := models.NewMovie("Bench Movie", "Benchmark fixture", 2*time.Hour, models.GenreAction, models.LanguageEnglish, 8.0, now, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := movieRepo.Create(ctx, movie); err != nil {
+		return nil, err
+	}
+
+	theatre, err := models.NewTheatre("Bench Theatre", "1 Bench Street", "Mumbai", now)
+	if err != nil {
+		return nil, err
+	}
+	if err := theatreRepo.Create(ctx, theatre); err != nil {
+		return nil, err
+	}
+
+	basePrice := models.NewMoney(10000, models.DefaultCurrency)
+	seatFactory := factories.NewSeatFactory()
+	fixture := &bookingFixture{bookingService: bookingService, userID: user.ID}
+
+	for i := 0; i < benchmarkShows; i++ {
+		screen := models.NewScreen(fmt.Sprintf("Screen %d", i+1), theatre.ID)
+		show := benchmarkShow{}
+		for _, seat := range seatFactory.CreateDefaultScreenSeats(basePrice) {
+			screen.AddSeat(seat)
+			show.seatIDs = append(show.seatIDs, seat.ID)
+		}
+		if err := screenRepo.Create(ctx, screen); err != nil {
+			return nil, err
+		}
+
+		s, err := models.NewShow(movie.ID, theatre.ID, screen.ID, now.Add(24*time.Hour), basePrice, movie.Duration, now)
+		if err != nil {
+			return nil, err
+		}
+		if err := showRepo.Create(ctx, s); err != nil {
+			return nil, err
+		}
+		show.id = s.ID
+		fixture.shows = append(fixture.shows, show)
+	}
+
+	return fixture, nil
+}
+
+// slowScreenRepository adds a fixed write latency, like a remote database round trip
+type slowScreenRepository struct {
+	repositories.ScreenRepository
+	latency time.Duration
+}
+
+// Update waits for the simulated round trip before delegating
+func (r *slowScreenRepository) Update(ctx context.Context, screen *models.Screen) error {
+	select {
+	case <-time.After(r.latency):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return r.ScreenRepository.Update(ctx, screen)
+}
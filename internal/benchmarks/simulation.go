@@ -0,0 +1,236 @@
+package benchmarks
+
+import (
+	"bookmyshow-lld/internal/controllers"
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SimulationConfig sizes the concurrent bookers load simulation
+type SimulationConfig struct {
+	Bookers     int // Goroutines released at once, each a different user
+	Attempts    int // Tries per booker; a booker who loses a race picks other seats, like a real user
+	MaxSeats    int // Each attempt books 1..MaxSeats adjacent seats
+	ContestRows int // Bookers only pick from the first seats of this many rows, so their picks overlap
+}
+
+// DefaultSimulationConfig has 300 bookers fight over the two front rows of one show
+func DefaultSimulationConfig() SimulationConfig {
+	return SimulationConfig{Bookers: 300, Attempts: 3, MaxSeats: 4, ContestRows: 2}
+}
+
+// SimulationResult is what the load simulation observed
+type SimulationResult struct {
+	Attempts     int
+	Booked       int // Attempts that created a booking
+	Conflicts    int // Attempts refused because another booker got a seat first
+	Errors       int // Anything else - should be zero
+	ContestSeats int
+	SeatsSold    int
+	DoubleBooked int // Seats held by more than one booking - must be zero
+	MapMismatch  int // Seats whose show seat map disagrees with the bookings made
+	Elapsed      time.Duration
+	Latencies    []time.Duration // Per attempt, sorted
+}
+
+// OK reports whether no seat was sold twice and the seat map agrees with the bookings
+func (r SimulationResult) OK() bool {
+	return r.DoubleBooked == 0 && r.MapMismatch == 0
+}
+
+// Percentile returns the latency below which p (0-1] of attempts finished
+func (r SimulationResult) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(r.Latencies)))) - 1
+	return r.Latencies[min(max(i, 0), len(r.Latencies)-1)]
+}
+
+// simulationAttempt is one booker's try, as the goroutine saw it
+type simulationAttempt struct {
+	seatIDs []string
+	latency time.Duration
+	err     error
+}
+
+// RunSimulation releases cfg.Bookers goroutines at the same instant against one show of a fully
+// wired in-memory app, each booking overlapping seats, then audits the outcome and prints a report
+func RunSimulation(w io.Writer, cfg SimulationConfig) (SimulationResult, error) {
+	ctx := context.Background()
+	app := controllers.NewAppController(controllers.WithLogger(logging.Nop()))
+	defer app.Shutdown()
+
+	show, contest, err := newSimulationShow(ctx, app, cfg.ContestRows)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	userIDs := make([]string, cfg.Bookers)
+	for i := range userIDs {
+		user, err := app.GetUserService().CreateUser(ctx, fmt.Sprintf("Booker %d", i+1), fmt.Sprintf("booker%d@simulation.test", i+1), fmt.Sprintf("+1666%07d", i+1))
+		if err != nil {
+			return SimulationResult{}, err
+		}
+		userIDs[i] = user.ID
+	}
+
+	bookingService := app.GetBookingService()
+	attempts := make([][]simulationAttempt, cfg.Bookers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range userIDs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(int64(i)))
+			<-start
+			for try := 0; try < cfg.Attempts; try++ {
+				seatIDs := pickAdjacent(rng, contest, 1+rng.Intn(max(cfg.MaxSeats, 1)))
+				began := time.Now()
+				_, err := bookingService.CreateBooking(ctx, userIDs[i], show.ID, seatIDs)
+				attempts[i] = append(attempts[i], simulationAttempt{seatIDs: seatIDs, latency: time.Since(began), err: err})
+				if err == nil {
+					return
+				}
+			}
+		}(i)
+	}
+
+	began := time.Now()
+	close(start)
+	wg.Wait()
+	result := SimulationResult{Elapsed: time.Since(began)}
+
+	// Audit: count every seat each successful booking claims, then compare with the show's seat map
+	soldTo := make(map[string]int)
+	for _, tries := range attempts {
+		for _, attempt := range tries {
+			result.Attempts++
+			result.Latencies = append(result.Latencies, attempt.latency)
+			switch {
+			case attempt.err == nil:
+				result.Booked++
+				for _, seatID := range attempt.seatIDs {
+					soldTo[seatID]++
+				}
+			case errors.Is(attempt.err, models.ErrSeatNotAvailable), errors.Is(attempt.err, models.ErrSeatAlreadyBooked):
+				result.Conflicts++
+			default:
+				result.Errors++
+			}
+		}
+	}
+	slices.Sort(result.Latencies)
+
+	for _, count := range soldTo {
+		if count > 1 {
+			result.DoubleBooked++
+		}
+	}
+	result.SeatsSold = len(soldTo)
+
+	seatMap, err := app.GetShowService().GetSeatAvailability(ctx, show.ID)
+	if err != nil {
+		return result, err
+	}
+	for _, row := range seatMap.Rows {
+		for _, cell := range row.Cells {
+			if cell.IsSeat() && (cell.Status != models.SeatStatusAvailable) != (soldTo[cell.SeatID] > 0) {
+				result.MapMismatch++
+			}
+		}
+	}
+	for _, row := range contest {
+		result.ContestSeats += len(row)
+	}
+
+	printSimulation(w, cfg, result)
+	return result, nil
+}
+
+// newSimulationShow creates a show on the default screen and returns the seat IDs of its first
+// rows, row by row in seat order, as the contested block
+func newSimulationShow(ctx context.Context, app *controllers.AppController, rows int) (*models.Show, [][]string, error) {
+	admin, err := app.GetUserService().CreateUserWithRole(ctx, "Simulation Admin", "admin@simulation.test", "+16660000000", models.UserRoleSuperAdmin)
+	if err != nil {
+		return nil, nil, err
+	}
+	adminCtx := services.WithCaller(ctx, admin.ID)
+
+	movie, err := app.GetMovieService().CreateMovie(adminCtx, "Simulation Movie", "Load simulation fixture", 2*time.Hour, models.GenreAction, models.LanguageEnglish, 8.0, app.GetClock().Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	theatre, err := app.GetAdminService().OnboardTheatre(ctx, admin.ID, "Simulation Theatre", "1 Load Street", "Mumbai")
+	if err != nil {
+		return nil, nil, err
+	}
+	basePrice := models.NewMoney(10000, models.DefaultCurrency)
+	screen, err := app.GetAdminService().AddScreen(ctx, admin.ID, theatre.ID, "Screen 1", factories.DefaultScreenConfig(), basePrice)
+	if err != nil {
+		return nil, nil, err
+	}
+	show, err := app.GetShowService().CreateShow(adminCtx, movie.ID, theatre.ID, screen.ID, app.GetClock().Now().Add(24*time.Hour), basePrice)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seatMap, err := app.GetShowService().GetSeatAvailability(ctx, show.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var contest [][]string
+	for _, row := range seatMap.Rows[:min(max(rows, 1), len(seatMap.Rows))] {
+		var seatIDs []string
+		for _, cell := range row.Cells {
+			if cell.IsSeat() {
+				seatIDs = append(seatIDs, cell.SeatID)
+			}
+		}
+		contest = append(contest, seatIDs)
+	}
+	return show, contest, nil
+}
+
+// pickAdjacent picks n neighbouring seats from a random contested row
+func pickAdjacent(rng *rand.Rand, rows [][]string, n int) []string {
+	row := rows[rng.Intn(len(rows))]
+	n = min(n, len(row))
+	first := rng.Intn(len(row) - n + 1)
+	return slices.Clone(row[first : first+n])
+}
+
+// printSimulation writes the report; the double-booking line is the one that matters
+func printSimulation(w io.Writer, cfg SimulationConfig, r SimulationResult) {
+	fmt.Fprintf(w, "Load simulation: %d bookers, up to %d tries of 1-%d adjacent seats from %d contested seats of one show\n",
+		cfg.Bookers, cfg.Attempts, cfg.MaxSeats, r.ContestSeats)
+	fmt.Fprintf(w, "  attempts:      %d (%d booked, %d conflicts, %d other errors)\n", r.Attempts, r.Booked, r.Conflicts, r.Errors)
+	fmt.Fprintf(w, "  seats sold:    %d of %d contested\n", r.SeatsSold, r.ContestSeats)
+
+	verdict := "✓"
+	if !r.OK() {
+		verdict = "✗"
+	}
+	fmt.Fprintf(w, "  double-booked: %d seats, seat map mismatches: %d %s\n", r.DoubleBooked, r.MapMismatch, verdict)
+
+	throughput := 0.0
+	if r.Elapsed > 0 {
+		throughput = float64(r.Attempts) / r.Elapsed.Seconds()
+	}
+	fmt.Fprintf(w, "  throughput:    %.0f attempts/s over %s\n", throughput, r.Elapsed.Round(time.Microsecond))
+	fmt.Fprintf(w, "  latency:       p50 %s  p90 %s  p99 %s  max %s\n",
+		r.Percentile(0.5).Round(time.Microsecond), r.Percentile(0.9).Round(time.Microsecond),
+		r.Percentile(0.99).Round(time.Microsecond), r.Percentile(1).Round(time.Microsecond))
+}
@@ -0,0 +1,40 @@
+package catalog
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// fixtureSource reads listings from a local JSON array of records
+type fixtureSource struct {
+	path string
+}
+
+func newFixture(path string) *fixtureSource {
+	return &fixtureSource{path: path}
+}
+
+func (s *fixtureSource) Name() string { return string(KindFixture) + ":" + s.path }
+
+// FetchMovies reads the whole file on every call, so edits show up on the next import
+func (s *fixtureSource) FetchMovies(ctx context.Context) ([]services.CatalogMovie, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrCatalogSource, err)
+	}
+
+	var records []record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("%w: malformed fixture %s: %v", models.ErrCatalogSource, s.path, err)
+	}
+
+	movies := make([]services.CatalogMovie, 0, len(records))
+	for _, rec := range records {
+		movies = append(movies, rec.toCatalogMovie())
+	}
+	return movies, nil
+}
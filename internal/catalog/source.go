@@ -0,0 +1,181 @@
+package catalog
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind selects a movie source implementation
+type Kind string
+
+const (
+	KindFixture Kind = "fixture" // Local JSON file, for demos and offline runs
+	KindTMDB    Kind = "tmdb"    // The Movie Database's now-playing list
+)
+
+// DefaultFixturePath is the bundled demo catalog, relative to the repository root
+const DefaultFixturePath = "data/movies.json"
+
+// DefaultLimit caps how many movies one import pulls from a remote source
+const DefaultLimit = 20
+
+// DefaultTimeout bounds every remote call
+const DefaultTimeout = 10 * time.Second
+
+// ErrInvalidConfig is returned when the selected source is missing its settings
+var ErrInvalidConfig = errors.New("invalid movie catalog configuration")
+
+// Config selects and configures the movie source
+type Config struct {
+	Kind        Kind
+	FixturePath string
+	APIKey      string // TMDB v3 API key
+	BaseURL     string // Overrides TMDB's API endpoint, e.g. for a local stub
+	Region      string // ISO 3166-1 country whose now-playing list is imported
+	Limit       int
+	Timeout     time.Duration
+}
+
+// ConfigFromEnv reads CATALOG_SOURCE (fixture|tmdb), CATALOG_FIXTURE, CATALOG_LIMIT and the TMDB_* settings.
+// Without CATALOG_SOURCE, TMDB is used when TMDB_API_KEY is set and the bundled fixture otherwise.
+func ConfigFromEnv() Config {
+	cfg := Config{
+		Kind:        Kind(os.Getenv("CATALOG_SOURCE")),
+		FixturePath: os.Getenv("CATALOG_FIXTURE"),
+		APIKey:      os.Getenv("TMDB_API_KEY"),
+		BaseURL:     os.Getenv("TMDB_BASE_URL"),
+		Region:      os.Getenv("TMDB_REGION"),
+		Limit:       DefaultLimit,
+		Timeout:     DefaultTimeout,
+	}
+	if cfg.Kind == "" {
+		cfg.Kind = KindFixture
+		if cfg.APIKey != "" {
+			cfg.Kind = KindTMDB
+		}
+	}
+	if limit, err := strconv.Atoi(os.Getenv("CATALOG_LIMIT")); err == nil && limit > 0 {
+		cfg.Limit = limit
+	}
+	if timeout, err := time.ParseDuration(os.Getenv("TMDB_TIMEOUT")); err == nil && timeout > 0 {
+		cfg.Timeout = timeout
+	}
+	return cfg
+}
+
+// New builds the configured movie source
+func New(cfg Config) (services.MovieSource, error) {
+	return NewWithClient(cfg, &http.Client{Timeout: cfg.Timeout})
+}
+
+// NewWithClient builds the configured movie source around a caller-supplied HTTP client
+func NewWithClient(cfg Config, client HTTPClient) (services.MovieSource, error) {
+	if cfg.Limit <= 0 {
+		cfg.Limit = DefaultLimit
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	switch cfg.Kind {
+	case KindFixture, "":
+		if cfg.FixturePath == "" {
+			cfg.FixturePath = DefaultFixturePath
+		}
+		return newFixture(cfg.FixturePath), nil
+	case KindTMDB:
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("%w: tmdb needs TMDB_API_KEY", ErrInvalidConfig)
+		}
+		return newTMDB(cfg, client), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown source %q", ErrInvalidConfig, cfg.Kind)
+	}
+}
+
+// genresByName maps TMDB's genre names onto ours; the rest (Music, War, Western...) are dropped
+var genresByName = map[string]models.Genre{
+	"action":          models.GenreAction,
+	"adventure":       models.GenreAdventure,
+	"animation":       models.GenreAnimation,
+	"comedy":          models.GenreComedy,
+	"crime":           models.GenreCrime,
+	"documentary":     models.GenreDocumentary,
+	"drama":           models.GenreDrama,
+	"family":          models.GenreFamily,
+	"fantasy":         models.GenreFantasy,
+	"horror":          models.GenreHorror,
+	"mystery":         models.GenreMystery,
+	"romance":         models.GenreRomance,
+	"science fiction": models.GenreSciFi,
+	"thriller":        models.GenreThriller,
+}
+
+// languagesByCode maps ISO 639-1 codes onto our languages; others are kept as the upper-cased code
+var languagesByCode = map[string]models.Language{
+	"en": models.LanguageEnglish,
+	"hi": models.LanguageHindi,
+	"ta": models.LanguageTamil,
+	"te": models.LanguageTelugu,
+}
+
+// mapGenres keeps the genres we list, in the source's order, without repeats
+func mapGenres(names []string) []models.Genre {
+	var genres []models.Genre
+	seen := make(map[models.Genre]bool)
+	for _, name := range names {
+		genre, ok := genresByName[strings.ToLower(strings.TrimSpace(name))]
+		if ok && !seen[genre] {
+			seen[genre] = true
+			genres = append(genres, genre)
+		}
+	}
+	return genres
+}
+
+// mapLanguage turns an ISO 639-1 code into a Language
+func mapLanguage(code string) models.Language {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if language, ok := languagesByCode[code]; ok {
+		return language
+	}
+	return models.Language(strings.ToUpper(code))
+}
+
+// record is a source-neutral listing before mapping; it is also the fixture file's format
+type record struct {
+	ExternalID  string   `json:"external_id"`
+	Title       string   `json:"title"`
+	Overview    string   `json:"overview"`
+	Runtime     int      `json:"runtime"` // Minutes
+	Genres      []string `json:"genres"`
+	Language    string   `json:"language"`    // ISO 639-1
+	Certificate string   `json:"certificate"` // U, UA or A; empty when the source doesn't rate
+	PosterURL   string   `json:"poster_url"`
+	ReleaseDate string   `json:"release_date"` // YYYY-MM-DD; empty or malformed dates read as already released
+	Rating      float32  `json:"rating"`       // Out of 10
+}
+
+// toCatalogMovie maps the record onto our genres, languages and types
+func (r record) toCatalogMovie() services.CatalogMovie {
+	releaseDate, _ := time.Parse(time.DateOnly, r.ReleaseDate)
+	return services.CatalogMovie{
+		ExternalID:  r.ExternalID,
+		Title:       strings.TrimSpace(r.Title),
+		Overview:    strings.TrimSpace(r.Overview),
+		Runtime:     time.Duration(r.Runtime) * time.Minute,
+		Genres:      mapGenres(r.Genres),
+		Language:    mapLanguage(r.Language),
+		Certificate: models.Certificate(strings.ToUpper(strings.TrimSpace(r.Certificate))),
+		PosterURL:   r.PosterURL,
+		ReleaseDate: releaseDate,
+		Rating:      r.Rating,
+	}
+}
@@ -0,0 +1,154 @@
+package catalog
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// tmdbBaseURL is TMDB's v3 API
+const tmdbBaseURL = "https://api.themoviedb.org/3"
+
+// tmdbImageBaseURL serves posters; w500 is wide enough for a listing card
+const tmdbImageBaseURL = "https://image.tmdb.org/t/p/w500"
+
+// maxResponseBytes caps how much of a TMDB response is read
+const maxResponseBytes = 1 << 20
+
+// HTTPClient is the subset of *http.Client the TMDB source needs - swap in a fake to test without network
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// tmdbSource adapts TMDB's now-playing list and movie details to services.MovieSource
+type tmdbSource struct {
+	cfg    Config
+	client HTTPClient
+}
+
+func newTMDB(cfg Config, client HTTPClient) *tmdbSource {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = tmdbBaseURL
+	}
+	return &tmdbSource{cfg: cfg, client: client}
+}
+
+func (s *tmdbSource) Name() string { return string(KindTMDB) }
+
+// tmdbPage is the subset of a now-playing page we read
+type tmdbPage struct {
+	Page       int `json:"page"`
+	TotalPages int `json:"total_pages"`
+	Results    []struct {
+		ID int `json:"id"`
+	} `json:"results"`
+}
+
+// tmdbMovie is the subset of a movie details response we read; only details carry the runtime
+type tmdbMovie struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Genres []struct {
+		Name string `json:"name"`
+	} `json:"genres"`
+	Overview         string  `json:"overview"`
+	Runtime          int     `json:"runtime"`
+	OriginalLanguage string  `json:"original_language"`
+	PosterPath       string  `json:"poster_path"`
+	ReleaseDate      string  `json:"release_date"`
+	VoteAverage      float32 `json:"vote_average"`
+}
+
+// FetchMovies pages through now playing until Limit movies are found, then fetches each one's details
+func (s *tmdbSource) FetchMovies(ctx context.Context) ([]services.CatalogMovie, error) {
+	var ids []int
+	for page := 1; len(ids) < s.cfg.Limit; page++ {
+		query := url.Values{"page": {strconv.Itoa(page)}}
+		if s.cfg.Region != "" {
+			query.Set("region", s.cfg.Region)
+		}
+
+		var list tmdbPage
+		if err := s.get(ctx, "/movie/now_playing", query, &list); err != nil {
+			return nil, err
+		}
+		for _, result := range list.Results {
+			if len(ids) < s.cfg.Limit {
+				ids = append(ids, result.ID)
+			}
+		}
+		if len(list.Results) == 0 || page >= list.TotalPages {
+			break
+		}
+	}
+
+	movies := make([]services.CatalogMovie, 0, len(ids))
+	for _, id := range ids {
+		var details tmdbMovie
+		if err := s.get(ctx, "/movie/"+strconv.Itoa(id), nil, &details); err != nil {
+			return nil, err
+		}
+		movies = append(movies, details.record().toCatalogMovie())
+	}
+	return movies, nil
+}
+
+// record converts TMDB details into the source-neutral record
+func (m tmdbMovie) record() record {
+	rec := record{
+		ExternalID:  "tmdb:" + strconv.Itoa(m.ID),
+		Title:       m.Title,
+		Overview:    m.Overview,
+		Runtime:     m.Runtime,
+		Language:    m.OriginalLanguage,
+		ReleaseDate: m.ReleaseDate,
+		Rating:      m.VoteAverage,
+	}
+	for _, genre := range m.Genres {
+		rec.Genres = append(rec.Genres, genre.Name)
+	}
+	if m.PosterPath != "" {
+		rec.PosterURL = tmdbImageBaseURL + m.PosterPath
+	}
+	return rec
+}
+
+// get calls one TMDB endpoint, bounded by the configured timeout, and decodes the JSON body into out
+func (s *tmdbSource) get(ctx context.Context, path string, query url.Values, out any) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("api_key", s.cfg.APIKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.BaseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("%w: %v", models.ErrCatalogSource, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", models.ErrCatalogSource, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: tmdb %s returned status %d", models.ErrCatalogSource, path, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("%w: %v", models.ErrCatalogSource, err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("%w: malformed tmdb response: %v", models.ErrCatalogSource, err)
+	}
+	return nil
+}
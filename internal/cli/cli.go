@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"bookmyshow-lld/internal/controllers"
+	"bookmyshow-lld/internal/models"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// command is one CLI verb - demonstrates Command Pattern: the loop only looks verbs up and runs them
+type command struct {
+	usage   string
+	help    string
+	needsID bool // Requires a signed-in user
+	run     func(ctx context.Context, args []string) error
+}
+
+// CLI drives the booking flow from stdin menus. Every command goes through the same services as the
+// REST API, so with -store=sqlite whatever it books is still there in the next session.
+type CLI struct {
+	app      *controllers.AppController
+	in       *bufio.Scanner
+	out      io.Writer
+	commands map[string]command
+
+	// Session state; numbered lists let the next command pick by position
+	user    *models.User
+	token   string
+	movies  []*models.Movie
+	shows   []*models.Show
+	show    *models.Show    // Picked by `seats`, booked by `book`
+	booking *models.Booking // Last booking made or paid, the default for `pay` and `cancel`
+}
+
+// errUsage makes the loop print the command's usage line
+var errUsage = errors.New("usage")
+
+// errSignedOut is returned by commands that need a user when nobody has logged in
+var errSignedOut = errors.New("log in or sign up first")
+
+// New creates a CLI over the controller's services reading commands from in
+func New(app *controllers.AppController, in io.Reader, out io.Writer) *CLI {
+	c := &CLI{app: app, in: bufio.NewScanner(in), out: out}
+	c.commands = map[string]command{
+		"signup":   {usage: "signup <email> <phone> <password> <name...>", help: "create an account and sign in", run: c.signup},
+		"login":    {usage: "login <email> <password>", help: "sign in", run: c.login},
+		"logout":   {usage: "logout", help: "sign out", needsID: true, run: c.logout},
+		"dob":      {usage: "dob <YYYY-MM-DD>", help: "set your date of birth, needed for A-certificate movies", needsID: true, run: c.setDateOfBirth},
+		"movies":   {usage: "movies", help: "list movies now showing", run: c.listMovies},
+		"trending": {usage: "trending [city]", help: "list the most booked movies lately", run: c.listTrending},
+		"shows":    {usage: "shows <movie #>", help: "list bookable shows of a movie", run: c.listShows},
+		"seats":    {usage: "seats <show #>", help: "show a show's seat map and pick it for booking", run: c.seatMap},
+		"book":     {usage: "book <seat> [seat...]", help: "hold and book seats of the picked show, e.g. book F7 F8", needsID: true, run: c.book},
+		"pay":      {usage: "pay <method> [reference]", help: "pay the last booking (or the given one): UPI, CREDIT_CARD, NET_BANKING, WALLET...", needsID: true, run: c.pay},
+		"cancel":   {usage: "cancel [reference]", help: "cancel the last booking (or the given one), refunding it if paid", needsID: true, run: c.cancel},
+		"bookings": {usage: "bookings [upcoming|past|cancelled]", help: "list your bookings", needsID: true, run: c.listBookings},
+		"help":     {usage: "help", help: "list commands", run: c.help},
+	}
+	return c
+}
+
+// Run reads commands until quit, exit or end of input
+func (c *CLI) Run(ctx context.Context) error {
+	fmt.Fprintln(c.out, "Type help for commands, quit to leave.")
+	for {
+		fmt.Fprint(c.out, c.prompt())
+		if !c.in.Scan() {
+			fmt.Fprintln(c.out)
+			return c.in.Err()
+		}
+
+		fields := strings.Fields(c.in.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		name, args := strings.ToLower(fields[0]), fields[1:]
+		if name == "quit" || name == "exit" {
+			return nil
+		}
+
+		cmd, ok := c.commands[name]
+		if !ok {
+			fmt.Fprintf(c.out, "unknown command %q - type help\n", name)
+			continue
+		}
+		if cmd.needsID && c.user == nil {
+			fmt.Fprintf(c.out, "%s: %v\n", name, errSignedOut)
+			continue
+		}
+		if err := cmd.run(ctx, args); err != nil {
+			if errors.Is(err, errUsage) {
+				fmt.Fprintf(c.out, "usage: %s\n", cmd.usage)
+			} else {
+				fmt.Fprintf(c.out, "%s: %v\n", name, err)
+			}
+		}
+	}
+}
+
+// prompt shows who is signed in
+func (c *CLI) prompt() string {
+	if c.user == nil {
+		return "bms> "
+	}
+	return fmt.Sprintf("bms (%s)> ", c.user.Email)
+}
+
+func (c *CLI) help(ctx context.Context, args []string) error {
+	names := make([]string, 0, len(c.commands))
+	for name := range c.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(c.out, "  %-44s %s\n", c.commands[name].usage, c.commands[name].help)
+	}
+	fmt.Fprintf(c.out, "  %-44s %s\n", "quit", "leave")
+	return nil
+}
+
+// pick parses a 1-based position into a list of n items
+func pick(arg string, n int) (int, error) {
+	i, err := strconv.Atoi(arg)
+	if err != nil || i < 1 || i > n {
+		return 0, fmt.Errorf("pick a number from 1 to %d", n)
+	}
+	return i - 1, nil
+}
@@ -0,0 +1,352 @@
+package cli
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Account commands - the session token is what the REST API would see; the CLI keeps it for logout
+
+func (c *CLI) signup(ctx context.Context, args []string) error {
+	if len(args) < 4 {
+		return errUsage
+	}
+	session, err := c.app.GetAuthService().Signup(ctx, strings.Join(args[3:], " "), args[0], args[1], args[2])
+	if err != nil {
+		return err
+	}
+	c.signIn(session)
+	return nil
+}
+
+func (c *CLI) login(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	session, err := c.app.GetAuthService().Login(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	c.signIn(session)
+	return nil
+}
+
+// signIn switches the CLI to the session's user, forgetting the previous user's booking
+func (c *CLI) signIn(session *services.AuthSession) {
+	c.user, c.token, c.booking = session.User, session.Token, nil
+	fmt.Fprintf(c.out, "Signed in as %s until %s\n", c.user.Name, session.ExpiresAt.Format("Jan 2 15:04"))
+}
+
+func (c *CLI) logout(ctx context.Context, args []string) error {
+	if err := c.app.GetAuthService().Logout(ctx, c.token); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "Signed out %s\n", c.user.Name)
+	c.user, c.token, c.booking = nil, "", nil
+	return nil
+}
+
+func (c *CLI) setDateOfBirth(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	dateOfBirth, err := time.Parse(time.DateOnly, args[0])
+	if err != nil {
+		return errUsage
+	}
+
+	user, err := c.app.GetUserService().SetDateOfBirth(ctx, c.user.ID, dateOfBirth)
+	if err != nil {
+		return err
+	}
+	c.user = user
+	fmt.Fprintf(c.out, "Date of birth set to %s\n", user.DateOfBirth.Format(time.DateOnly))
+	return nil
+}
+
+// Browsing commands - open to anyone
+
+func (c *CLI) listMovies(ctx context.Context, args []string) error {
+	movies, err := c.app.GetMovieService().GetReleasedMovies(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(movies, func(i, j int) bool { return movies[i].Title < movies[j].Title })
+
+	c.movies = movies
+	if len(movies) == 0 {
+		fmt.Fprintln(c.out, "No movies showing yet.")
+	}
+	for i, movie := range movies {
+		certificate := ""
+		if movie.Certificate != "" {
+			certificate = ", " + string(movie.Certificate)
+		}
+		fmt.Fprintf(c.out, "%2d. %s (%d min, %s, %s%s, %.1f/10)\n", i+1, movie.Title, int(movie.Duration.Minutes()), movie.Genre, movie.Language, certificate, movie.Rating)
+	}
+	return nil
+}
+
+// listTrending ranks movies by recent bookings in the city, or everywhere, and picks them for shows
+func (c *CLI) listTrending(ctx context.Context, args []string) error {
+	trending, err := c.app.GetMovieService().GetTrending(ctx, strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+
+	c.movies = make([]*models.Movie, len(trending))
+	if len(trending) == 0 {
+		fmt.Fprintln(c.out, "Nothing booked lately.")
+	}
+	for i, entry := range trending {
+		c.movies[i] = entry.Movie
+		fmt.Fprintf(c.out, "%2d. %s (%d seats in %d bookings)\n", entry.Rank, entry.Movie.Title, entry.SeatsBooked, entry.Bookings)
+	}
+	return nil
+}
+
+func (c *CLI) listShows(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	if c.movies == nil {
+		return fmt.Errorf("list movies first")
+	}
+	i, err := pick(args[0], len(c.movies))
+	if err != nil {
+		return err
+	}
+
+	movie := c.movies[i]
+	shows, err := c.app.GetShowService().SearchShows(ctx, services.ShowFilter{MovieID: movie.ID})
+	if err != nil {
+		return err
+	}
+
+	c.shows = shows
+	if len(shows) == 0 {
+		fmt.Fprintf(c.out, "No bookable shows of %s.\n", movie.Title)
+	}
+	for i, show := range shows {
+		theatreName := show.TheatreID
+		if theatre, err := c.app.GetTheatreService().GetTheatre(ctx, show.TheatreID); err == nil {
+			theatreName = theatre.Name
+		}
+		fmt.Fprintf(c.out, "%2d. %s  %s  %s %s  from %s\n", i+1, show.StartTime.Local().Format("Mon Jan 2 15:04"), theatreName, show.Format, show.Language, show.BasePrice)
+	}
+	return nil
+}
+
+func (c *CLI) seatMap(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	if c.shows == nil {
+		return fmt.Errorf("list shows first")
+	}
+	i, err := pick(args[0], len(c.shows))
+	if err != nil {
+		return err
+	}
+
+	show := c.shows[i]
+	seatMap, err := c.app.GetShowService().GetSeatAvailability(ctx, show.ID)
+	if err != nil {
+		return err
+	}
+
+	c.show = show
+	fmt.Fprintf(c.out, "SCREEN THIS WAY - %d/%d seats available, [X] taken\n", seatMap.Available, seatMap.Capacity)
+	rendered, width := make([]string, len(seatMap.Rows)), 0
+	for i, row := range seatMap.Rows {
+		rendered[i] = RenderSeatRow(row)
+		width = max(width, len(rendered[i]))
+	}
+	for i, row := range seatMap.Rows {
+		fmt.Fprintf(c.out, "  %-*s  %s\n", width, rendered[i], rowPrice(row))
+	}
+	fmt.Fprintln(c.out, "Book with e.g.: book", exampleSeat(seatMap))
+	return nil
+}
+
+// rowPrice describes a row's seat type and price from its first seat
+func rowPrice(row models.SeatMapRow) string {
+	for _, cell := range row.Cells {
+		if cell.IsSeat() {
+			return fmt.Sprintf("%s %s", cell.Type, cell.Price)
+		}
+	}
+	return ""
+}
+
+// exampleSeat names the first available seat, for the booking hint
+func exampleSeat(seatMap *models.SeatMap) string {
+	for _, row := range seatMap.Rows {
+		for _, cell := range row.Cells {
+			if cell.IsSeat() && cell.Status == models.SeatStatusAvailable {
+				return cell.Label
+			}
+		}
+	}
+	return "A1"
+}
+
+// RenderSeatRow draws a seat map row as text, e.g. "A  [1][2][3] | [4]   [6]..." with booked seats as [X]
+// and missing seats as blanks
+func RenderSeatRow(row models.SeatMapRow) string {
+	var b strings.Builder
+	b.WriteString(row.Name + "  ")
+	for _, cell := range row.Cells {
+		switch {
+		case cell.Aisle:
+			b.WriteString(" | ")
+		case cell.Gap:
+			b.WriteString("   ")
+		case cell.Status == models.SeatStatusAvailable:
+			fmt.Fprintf(&b, "[%d]", cell.Number)
+		default:
+			b.WriteString("[X]")
+		}
+	}
+	return b.String()
+}
+
+// Booking commands - act for the signed-in user only
+
+// book holds the seats first, so a booking that fails validation gives them straight back
+func (c *CLI) book(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	if c.show == nil {
+		return fmt.Errorf("pick a show with seats first")
+	}
+
+	seatMap, err := c.app.GetShowService().GetSeatAvailability(ctx, c.show.ID)
+	if err != nil {
+		return err
+	}
+	seatIDs, err := seatMap.SeatIDs(args)
+	if err != nil {
+		return err
+	}
+
+	hold, err := c.app.GetSeatHoldService().CreateHold(ctx, c.user.ID, c.show.ID, seatIDs)
+	if err != nil {
+		return err
+	}
+	booking, err := c.app.GetBookingService().CreateBooking(ctx, c.user.ID, c.show.ID, seatIDs, services.WithHold(hold.ID))
+	if err != nil {
+		_ = c.app.GetSeatHoldService().ReleaseHold(ctx, hold.ID, c.user.ID)
+		return err
+	}
+
+	c.booking = booking
+	breakdown := booking.PriceBreakdown
+	fmt.Fprintf(c.out, "Booking %s: %s\n", booking.Reference, strings.Join(args, ", "))
+	fmt.Fprintf(c.out, "  Tickets %s + fee %s + GST %s = %s\n", breakdown.Subtotal.Sub(breakdown.Discount), breakdown.ConvenienceFee, breakdown.Tax(), breakdown.Total)
+	fmt.Fprintf(c.out, "  Pay before %s, e.g.: pay UPI\n", booking.ExpiryTime.Local().Format("15:04"))
+	return nil
+}
+
+// pay charges the booking, retrying with the given method after a failed attempt, and confirms it once paid
+func (c *CLI) pay(ctx context.Context, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errUsage
+	}
+	booking, err := c.ownBooking(ctx, args[1:])
+	if err != nil {
+		return err
+	}
+
+	method := models.PaymentMethod(strings.ToUpper(args[0]))
+	payments := c.app.GetPaymentService()
+	attempts, err := payments.GetPaymentAttempts(ctx, booking.ID)
+	if err != nil {
+		return err
+	}
+
+	var payment *models.Payment
+	if len(attempts) == 0 {
+		payment, err = payments.ProcessPayment(ctx, booking.ID, method)
+	} else {
+		payment, err = payments.RetryPayment(ctx, booking.ID, method)
+	}
+	if err != nil {
+		return err
+	}
+	if !payment.IsSuccessful() {
+		fmt.Fprintf(c.out, "%s payment of %s failed: %s - try again, perhaps with another method\n", method, payment.Amount, payment.FailureReason)
+		return nil
+	}
+
+	if err := c.app.GetBookingService().ConfirmBooking(ctx, booking.ID, payment.ID); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "Paid %s by %s - booking %s confirmed\n", payment.Amount, method, booking.Reference)
+	return nil
+}
+
+func (c *CLI) cancel(ctx context.Context, args []string) error {
+	if len(args) > 1 {
+		return errUsage
+	}
+	booking, err := c.ownBooking(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.app.GetBookingService().CancelBooking(ctx, booking.ID); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "Booking %s cancelled\n", booking.Reference)
+	return nil
+}
+
+// ownBooking finds the booking by the optional reference, else the last one, and refuses other users' bookings
+func (c *CLI) ownBooking(ctx context.Context, args []string) (*models.Booking, error) {
+	if len(args) == 0 {
+		if c.booking == nil {
+			return nil, fmt.Errorf("no booking yet - book seats or pass a reference")
+		}
+		args = []string{c.booking.Reference}
+	}
+
+	booking, err := c.app.GetBookingService().GetBookingByReference(ctx, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != c.user.ID {
+		return nil, models.ErrForbidden
+	}
+	c.booking = booking
+	return booking, nil
+}
+
+func (c *CLI) listBookings(ctx context.Context, args []string) error {
+	if len(args) > 1 {
+		return errUsage
+	}
+	filter := services.BookingFilter{}
+	if len(args) == 1 {
+		filter.Category = services.BookingCategory(strings.ToUpper(args[0]))
+	}
+
+	bookings, err := c.app.GetBookingService().GetUserBookings(ctx, c.user.ID, filter)
+	if err != nil {
+		return err
+	}
+	if len(bookings.Bookings) == 0 {
+		fmt.Fprintln(c.out, "No bookings.")
+	}
+	for _, summary := range bookings.Bookings {
+		fmt.Fprintf(c.out, "  %s  %-9s %s  %s, %s  %s  %s\n", summary.Reference, summary.Status, summary.ShowStart.Local().Format("Jan 2 15:04"),
+			summary.Title, summary.TheatreName, strings.Join(summary.Seats, " "), summary.TotalAmount)
+	}
+	return nil
+}
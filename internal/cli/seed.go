@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// showGap is the cleaning break left between seeded shows on the one screen
+const showGap = 30 * time.Minute
+
+// SeedIfEmpty gives a fresh store something to book: the movie catalog import, one theatre with the
+// default screen, and a show of every movie back to back from the next hour. A store that already has
+// movies - e.g. a SQLite file from an earlier session - is left alone.
+func (c *CLI) SeedIfEmpty(ctx context.Context, adminID string) error {
+	movies, err := c.app.GetMovieService().GetReleasedMovies(ctx)
+	if err != nil || len(movies) > 0 {
+		return err
+	}
+
+	adminCtx := services.WithCaller(ctx, adminID)
+	imported, err := c.app.GetCatalogImporter().Import(adminCtx)
+	if err != nil {
+		return err
+	}
+	if movies, err = c.app.GetMovieService().GetReleasedMovies(ctx); err != nil {
+		return err
+	}
+	sort.Slice(movies, func(i, j int) bool { return movies[i].Title < movies[j].Title })
+
+	admin := c.app.GetAdminService()
+	theatre, err := admin.OnboardTheatre(ctx, adminID, "PVR Phoenix", "Phoenix Mall, Lower Parel", "Mumbai")
+	if err != nil {
+		return err
+	}
+	basePrice := models.NewMoney(10000, models.DefaultCurrency)
+	screen, err := admin.AddScreen(ctx, adminID, theatre.ID, "Audi 1", factories.DefaultScreenConfig(), basePrice)
+	if err != nil {
+		return err
+	}
+
+	start := c.app.GetClock().Now().Truncate(time.Hour).Add(time.Hour)
+	for _, movie := range movies {
+		show, err := c.app.GetShowService().CreateShow(adminCtx, movie.ID, theatre.ID, screen.ID, start, basePrice)
+		if err != nil {
+			return err
+		}
+		start = show.EndTime.Add(showGap).Truncate(15 * time.Minute)
+	}
+
+	fmt.Fprintf(c.out, "Seeded %d movies from %s with a show each at %s\n", len(movies), imported.Source, theatre.Name)
+	return nil
+}
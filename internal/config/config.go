@@ -0,0 +1,197 @@
+// Package config loads the settings an operator is most likely to change - booking and hold timeouts, seat
+// price multipliers, where state is stored, which payment gateway charges and where the API listens. Each
+// starts from a built-in default, is overridden by a YAML file and then by environment variables, and the
+// result is validated as a whole before the application starts.
+package config
+
+import (
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/filestore"
+	"bookmyshow-lld/internal/gateways"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/sqlite"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultShutdownTimeout is how long shutdown waits for work under way unless configured otherwise
+const DefaultShutdownTimeout = 30 * time.Second
+
+// Backend selects where state is kept
+type Backend string
+
+const (
+	BackendMemory Backend = "memory" // Lost on exit
+	BackendSQLite Backend = "sqlite" // Saved to a database file and reloaded on start
+	BackendFile   Backend = "file"   // Logged to a directory and replayed on start
+)
+
+// Config is every setting the config file may hold. Zero durations and multipliers mean the built-in default.
+type Config struct {
+	Server  ServerConfig  `yaml:"server"`
+	Booking BookingConfig `yaml:"booking"`
+	Pricing PricingConfig `yaml:"pricing"`
+	Storage StorageConfig `yaml:"storage"`
+	Payment PaymentConfig `yaml:"payment"`
+}
+
+// ServerConfig is where the REST API listens and how long it takes to stop
+type ServerConfig struct {
+	Addr            string   `yaml:"addr"`             // e.g. :8080; the interactive CLI runs instead while empty
+	ShutdownTimeout Duration `yaml:"shutdown_timeout"` // For requests, then bookings and payments, under way
+}
+
+// BookingConfig is how long checkouts may take
+type BookingConfig struct {
+	Timeout      Duration `yaml:"timeout"`       // Payment window of shows and theatres that don't set their own
+	HoldDuration Duration `yaml:"hold_duration"` // How long blocked seats stay held; never past the payment window
+}
+
+// PricingConfig is what seats cost relative to a screen's base price
+type PricingConfig struct {
+	SeatMultipliers factories.SeatMultipliers `yaml:"seat_multipliers"` // e.g. PREMIUM: 1.6; unlisted types keep theirs
+}
+
+// StorageConfig is where state and seat holds are kept
+type StorageConfig struct {
+	Backend    Backend `yaml:"backend"`
+	SQLitePath string  `yaml:"sqlite_path"` // Database file of the sqlite backend
+	FileDir    string  `yaml:"file_dir"`    // Data directory of the file backend
+	RedisAddr  string  `yaml:"redis_addr"`  // Seat holds, locks and the catalog cache move to Redis when set
+}
+
+// PaymentConfig is which gateway charges payments; its credentials stay in the environment
+type PaymentConfig struct {
+	Gateway gateways.Kind `yaml:"gateway"`
+}
+
+// Duration reads "15m" style strings
+type Duration time.Duration
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	parsed, err := time.ParseDuration(node.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", node.Line, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Default is the configuration without a file or environment: everything in memory on the mock gateway
+func Default() Config {
+	return Config{
+		Server:  ServerConfig{ShutdownTimeout: Duration(DefaultShutdownTimeout)},
+		Booking: BookingConfig{Timeout: Duration(models.BookingTimeout), HoldDuration: Duration(models.SeatHoldDuration)},
+		Storage: StorageConfig{Backend: BackendMemory, SQLitePath: sqlite.DefaultPath, FileDir: filestore.DefaultDir},
+		Payment: PaymentConfig{Gateway: gateways.KindMock},
+	}
+}
+
+// Load builds the configuration from Default, the YAML file at path when path is set, then the environment
+// (see FromEnv). Unknown keys in the file are errors, as they are almost always typos. Callers apply their own
+// overrides, e.g. command-line flags, then Validate.
+func Load(path string) (Config, error) {
+	config := Default()
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return config, err
+		}
+		defer file.Close()
+
+		if err := config.Decode(file); err != nil {
+			return config, fmt.Errorf("config %s: %w", path, err)
+		}
+	}
+	return config, config.FromEnv()
+}
+
+// Decode overrides the settings the YAML document in r sets, leaving the rest as they are
+func (c *Config) Decode(r io.Reader) error {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once, naming each by its key in the config file
+func (c Config) Validate() error {
+	var errs []error
+	invalid := func(key string, err error) {
+		errs = append(errs, fmt.Errorf("%s: %w", key, err))
+	}
+
+	if c.Server.Addr != "" {
+		if err := validateAddr(c.Server.Addr); err != nil {
+			invalid("server.addr", err)
+		}
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		invalid("server.shutdown_timeout", errors.New("must be positive"))
+	}
+
+	if err := models.ValidateBookingTimeout(time.Duration(c.Booking.Timeout)); err != nil {
+		invalid("booking.timeout", err)
+	}
+	if err := (models.BookingTimeouts{Hold: time.Duration(c.Booking.HoldDuration)}).Validate(); err != nil {
+		invalid("booking.hold_duration", err)
+	}
+
+	// A throwaway registry, so checking the multipliers doesn't apply them
+	if err := factories.NewSeatTypeRegistry().Reprice(c.Pricing.SeatMultipliers); err != nil {
+		invalid("pricing.seat_multipliers", err)
+	}
+
+	switch c.Storage.Backend {
+	case BackendMemory:
+	case BackendSQLite:
+		if c.Storage.SQLitePath == "" {
+			invalid("storage.sqlite_path", errors.New("is required for the sqlite backend"))
+		}
+	case BackendFile:
+		if c.Storage.FileDir == "" {
+			invalid("storage.file_dir", errors.New("is required for the file backend"))
+		}
+	default:
+		invalid("storage.backend", fmt.Errorf("unknown backend %q: want memory, sqlite or file", c.Storage.Backend))
+	}
+	if c.Storage.RedisAddr != "" {
+		if err := validateAddr(c.Storage.RedisAddr); err != nil {
+			invalid("storage.redis_addr", err)
+		}
+	}
+
+	switch c.Payment.Gateway {
+	case gateways.KindMock, gateways.KindRazorpay, gateways.KindStripe:
+	default:
+		invalid("payment.gateway", fmt.Errorf("unknown gateway %q: want mock, razorpay or stripe", c.Payment.Gateway))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Timeouts are the booking settings as the models take them
+func (c Config) Timeouts() models.BookingTimeouts {
+	return models.BookingTimeouts{Payment: time.Duration(c.Booking.Timeout), Hold: time.Duration(c.Booking.HoldDuration)}
+}
+
+// validateAddr accepts host:port addresses, where the host may be empty
+func validateAddr(addr string) error {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	if number, err := strconv.Atoi(port); err != nil || number < 0 || number > 65535 {
+		return fmt.Errorf("invalid port %q", port)
+	}
+	return nil
+}
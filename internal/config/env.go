@@ -0,0 +1,105 @@
+package config
+
+import (
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/gateways"
+	"bookmyshow-lld/internal/models"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FromEnv overrides the settings environment variables set:
+//
+//	SERVER_ADDR          server.addr
+//	SHUTDOWN_TIMEOUT     server.shutdown_timeout
+//	BOOKING_TIMEOUT      booking.timeout
+//	SEAT_HOLD_DURATION   booking.hold_duration
+//	SEAT_MULTIPLIERS     pricing.seat_multipliers, e.g. PREMIUM=1.6,VIP=2.2
+//	STORE                storage.backend
+//	SQLITE_PATH          storage.sqlite_path, and the sqlite backend unless STORE says otherwise
+//	FILESTORE_DIR        storage.file_dir, and the file backend unless STORE or SQLITE_PATH say otherwise
+//	REDIS_ADDR           storage.redis_addr
+//	PAYMENT_PROVIDER     payment.gateway
+//
+// Values that don't parse are errors rather than skipped, so a typo can't quietly keep a default.
+func (c *Config) FromEnv() error {
+	var errs []error
+	duration := func(key string, target *Duration) {
+		value := os.Getenv(key)
+		if value == "" {
+			return
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			return
+		}
+		*target = Duration(parsed)
+	}
+	text := func(key string, target *string) {
+		if value := os.Getenv(key); value != "" {
+			*target = value
+		}
+	}
+
+	text("SERVER_ADDR", &c.Server.Addr)
+	duration("SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+	duration("BOOKING_TIMEOUT", &c.Booking.Timeout)
+	duration("SEAT_HOLD_DURATION", &c.Booking.HoldDuration)
+
+	if value := os.Getenv("SEAT_MULTIPLIERS"); value != "" {
+		if multipliers, err := parseSeatMultipliers(value); err != nil {
+			errs = append(errs, fmt.Errorf("SEAT_MULTIPLIERS: %w", err))
+		} else {
+			c.Pricing.SeatMultipliers = mergeSeatMultipliers(c.Pricing.SeatMultipliers, multipliers)
+		}
+	}
+
+	if dir := os.Getenv("FILESTORE_DIR"); dir != "" {
+		c.Storage.FileDir, c.Storage.Backend = dir, BackendFile
+	}
+	if path := os.Getenv("SQLITE_PATH"); path != "" {
+		c.Storage.SQLitePath, c.Storage.Backend = path, BackendSQLite
+	}
+	if backend := os.Getenv("STORE"); backend != "" {
+		c.Storage.Backend = Backend(strings.ToLower(backend))
+	}
+	text("REDIS_ADDR", &c.Storage.RedisAddr)
+
+	if gateway := os.Getenv("PAYMENT_PROVIDER"); gateway != "" {
+		c.Payment.Gateway = gateways.Kind(strings.ToLower(gateway))
+	}
+	return errors.Join(errs...)
+}
+
+// parseSeatMultipliers reads TYPE=multiplier pairs separated by commas; none are returned unless all parse
+func parseSeatMultipliers(value string) (factories.SeatMultipliers, error) {
+	multipliers := make(factories.SeatMultipliers)
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		seatType, number, found := strings.Cut(entry, "=")
+		multiplier, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+		if !found || err != nil {
+			return nil, fmt.Errorf("%q is not TYPE=multiplier", entry)
+		}
+		multipliers[models.SeatType(strings.ToUpper(strings.TrimSpace(seatType)))] = multiplier
+	}
+	return multipliers, nil
+}
+
+// mergeSeatMultipliers overrides base's multipliers with those in overrides, keeping the types overrides omits
+func mergeSeatMultipliers(base, overrides factories.SeatMultipliers) factories.SeatMultipliers {
+	if base == nil {
+		base = make(factories.SeatMultipliers, len(overrides))
+	}
+	for seatType, multiplier := range overrides {
+		base[seatType] = multiplier
+	}
+	return base
+}
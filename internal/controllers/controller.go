@@ -1,35 +1,478 @@
 package controllers
 
 import (
+	"bookmyshow-lld/internal/catalog"
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/config"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/filestore"
+	"bookmyshow-lld/internal/gateways"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/metrics"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/pricing"
+	"bookmyshow-lld/internal/realtime"
+	"bookmyshow-lld/internal/redis"
 	"bookmyshow-lld/internal/repositories"
 	"bookmyshow-lld/internal/services"
+	"bookmyshow-lld/internal/sqlite"
 	"bookmyshow-lld/internal/strategies"
+	"bookmyshow-lld/internal/tracing"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// holdExpiryInterval is how often stale seat holds are released
+const holdExpiryInterval = 30 * time.Second
+
+// outboxDispatchInterval is how often failed event deliveries are retried
+const outboxDispatchInterval = 5 * time.Second
+
+// outboxRetention is how long delivered events stay in the outbox
+const outboxRetention = 24 * time.Hour
+
+// notificationDispatchInterval is how often failed notification deliveries are retried
+const notificationDispatchInterval = 5 * time.Second
+
+// notificationRetention is how long sent notifications are kept
+const notificationRetention = 24 * time.Hour
+
+// webhookDispatchInterval is how often failed partner webhook deliveries are retried
+const webhookDispatchInterval = 10 * time.Second
+
+// showCloseOutInterval is how often shows that have ended are closed out
+const showCloseOutInterval = time.Minute
+
+// paymentReconcileInterval is how often payments the gateway never answered for are checked again
+const paymentReconcileInterval = time.Minute
+
+// watchlistReminderInterval is how often watchlists are checked for movies newly showing in users' cities
+const watchlistReminderInterval = time.Minute
+
+// showReminderInterval is how often shows about to start are checked for bookings to remind
+const showReminderInterval = time.Minute
+
+// Config selects infrastructure backends for the AppController; the zero value runs fully in memory without fees, format surcharges, booking limits, loyalty points, add-ons, commission, payment retries or show reminders
+type Config struct {
+	Payment    gateways.Config  // PAYMENT_PROVIDER; the mock gateway when unset
+	Redis      redis.Config     // Seat holds, locks and the movie/theatre cache move to Redis when Addr is set
+	SQLite     sqlite.Config    // Everything else is saved to this file and reloaded on start when Path is set
+	File       filestore.Config // Or logged to this directory and replayed on start when Dir is set; SQLite wins if both are
+	Logging    logging.Config
+	Fees       models.FeeConfig            // Convenience fee and GST added to every booking
+	Loyalty    models.LoyaltyConfig        // Points earned per unit paid and what each point is worth
+	Formats    models.FormatSurcharges     // Per-seat surcharge for 3D, IMAX and 4DX shows
+	Scheduling models.SchedulingConfig     // Turnaround and opening hours for suggested show times
+	Limits     models.BookingLimits        // Anti-hoarding caps on seats per booking, unpaid bookings and tickets per show
+	Resilience strategies.ResilienceConfig // Timeouts, retries and circuit breakers around the payment gateway
+	Challenges strategies.ChallengeConfig  // When the mock card issuer asks for an OTP (3-D Secure); never while Threshold is zero
+	Outbox     models.OutboxConfig         // Event delivery retries; zero fields use models.DefaultOutboxConfig
+	Notify     models.NotificationConfig   // Notification channels and delivery retries; zero fields use models.DefaultNotificationConfig
+	Reminders  models.ShowReminderConfig   // When bookings' owners are reminded before their show; never without offsets
+	Webhooks   models.WebhookConfig        // Partner webhook timeouts and retries; zero fields use models.DefaultWebhookConfig
+	Auth       models.AuthConfig           // Session lifetime and password rules
+	Catalog    catalog.Config              // CATALOG_SOURCE; the bundled JSON fixture when unset
+	Settlement models.SettlementConfig     // Platform commission kept from theatre ticket sales
+	Pricing    pricing.Config              // Pricing calendar, holiday, festival and late-night pricing rules
+	Listings   models.ListingsConfig       // Trending window and how often cached listings are rebuilt
+	Deals      models.DealsConfig          // Last-minute discounts on emptier shows about to start; off while Percent is zero
+	AddOns     models.AddOnConfig          // Prices of cancellation insurance, 3D glasses and parking; unpriced ones aren't sold
+	Resale     models.ResaleConfig         // Fee kept from sellers when their bookings are resold
+	RateLimits models.RateLimitConfig      // API requests per caller and endpoint, and bookings while sales open
+	Fraud      models.FraudConfig          // Risk scores at which payments need step-up verification or are rejected
+	Tracing    tracing.Config              // OTEL_TRACES_EXPORTER; no spans are recorded when unset
+	Shutdown   time.Duration               // How long Shutdown waits for in-flight bookings and payments; config.DefaultShutdownTimeout when zero
+	Timeouts   models.BookingTimeouts      // Default payment window and seat hold length; zero fields use the built-in ones
+	Seats      factories.SeatMultipliers   // Price multipliers of registered seat types; unlisted types keep theirs
+}
+
+// ConfigFromEnv reads the controller configuration from environment variables
+func ConfigFromEnv() Config {
+	return Config{
+		Payment:    gateways.ConfigFromEnv(),
+		Redis:      redis.ConfigFromEnv(),
+		SQLite:     sqlite.ConfigFromEnv(),
+		File:       filestore.ConfigFromEnv(),
+		Logging:    logging.ConfigFromEnv(),
+		Fees:       feesFromEnv(),
+		Loyalty:    loyaltyFromEnv(),
+		Formats:    models.DefaultFormatSurcharges(),
+		Scheduling: schedulingFromEnv(),
+		Limits:     limitsFromEnv(),
+		Resilience: strategies.ResilienceConfigFromEnv(),
+		Challenges: strategies.ChallengeConfigFromEnv(),
+		Outbox:     models.DefaultOutboxConfig(),
+		Notify:     notificationsFromEnv(),
+		Reminders:  remindersFromEnv(),
+		Webhooks:   models.DefaultWebhookConfig(),
+		Auth:       authFromEnv(),
+		Catalog:    catalog.ConfigFromEnv(),
+		Settlement: settlementFromEnv(),
+		Pricing:    pricing.ConfigFromEnv(),
+		Listings:   listingsFromEnv(),
+		Deals:      dealsFromEnv(),
+		AddOns:     addOnsFromEnv(),
+		Resale:     resaleFromEnv(),
+		RateLimits: rateLimitsFromEnv(),
+		Fraud:      fraudFromEnv(),
+		Tracing:    tracing.ConfigFromEnv(),
+	}
+}
+
+// feesFromEnv reads CONVENIENCE_FEE_PERCENT and GST_PERCENT, defaulting to models.DefaultFeeConfig
+func feesFromEnv() models.FeeConfig {
+	fees := models.DefaultFeeConfig()
+	if percent, ok := percentFromEnv("CONVENIENCE_FEE_PERCENT"); ok {
+		fees.ConvenienceFeePercent = percent
+	}
+	if percent, ok := percentFromEnv("GST_PERCENT"); ok {
+		fees.GSTPercent = percent
+	}
+	return fees
+}
+
+// loyaltyFromEnv reads LOYALTY_POINTS_PER_UNIT and LOYALTY_POINT_VALUE (minor units), defaulting to models.DefaultLoyaltyConfig
+func loyaltyFromEnv() models.LoyaltyConfig {
+	loyalty := models.DefaultLoyaltyConfig()
+	if rate, err := strconv.ParseFloat(os.Getenv("LOYALTY_POINTS_PER_UNIT"), 64); err == nil && rate >= 0 {
+		loyalty.PointsPerUnit = rate
+	}
+	if value, err := strconv.ParseInt(os.Getenv("LOYALTY_POINT_VALUE"), 10, 64); err == nil && value >= 0 {
+		loyalty.PointValue = value
+	}
+	return loyalty
+}
+
+// limitsFromEnv reads MAX_SEATS_PER_BOOKING, MAX_PENDING_BOOKINGS_PER_USER, MAX_TICKETS_PER_USER_PER_SHOW and
+// MAX_HOLD_EXTENSIONS_PER_DAY (0 disables a limit) and HOLD_EXTENSION (a Go duration such as 5m; 0 allows no
+// extensions), defaulting to models.DefaultBookingLimits
+func limitsFromEnv() models.BookingLimits {
+	limits := models.DefaultBookingLimits()
+	if limit, ok := limitFromEnv("MAX_SEATS_PER_BOOKING"); ok {
+		limits.MaxSeatsPerBooking = limit
+	}
+	if limit, ok := limitFromEnv("MAX_PENDING_BOOKINGS_PER_USER"); ok {
+		limits.MaxPendingBookingsPerUser = limit
+	}
+	if limit, ok := limitFromEnv("MAX_TICKETS_PER_USER_PER_SHOW"); ok {
+		limits.MaxTicketsPerUserPerShow = limit
+	}
+	if limit, ok := limitFromEnv("MAX_HOLD_EXTENSIONS_PER_DAY"); ok {
+		limits.MaxHoldExtensionsPerDay = limit
+	}
+	if extension, err := time.ParseDuration(os.Getenv("HOLD_EXTENSION")); err == nil && extension >= 0 {
+		limits.HoldExtension = extension
+	}
+	return limits
+}
+
+// authFromEnv reads SESSION_TTL (a Go duration such as 12h), defaulting to models.DefaultAuthConfig
+func authFromEnv() models.AuthConfig {
+	auth := models.DefaultAuthConfig()
+	if ttl, err := time.ParseDuration(os.Getenv("SESSION_TTL")); err == nil && ttl > 0 {
+		auth.SessionTTL = ttl
+	}
+	return auth
+}
+
+// schedulingFromEnv reads SHOW_TURNAROUND and SHOW_SLOT_INTERVAL (Go durations such as 20m),
+// defaulting to models.DefaultSchedulingConfig
+func schedulingFromEnv() models.SchedulingConfig {
+	scheduling := models.DefaultSchedulingConfig()
+	if turnaround, err := time.ParseDuration(os.Getenv("SHOW_TURNAROUND")); err == nil && turnaround >= 0 {
+		scheduling.Turnaround = turnaround
+	}
+	if interval, err := time.ParseDuration(os.Getenv("SHOW_SLOT_INTERVAL")); err == nil && interval > 0 {
+		scheduling.SlotInterval = interval
+	}
+	return scheduling
+}
+
+// listingsFromEnv reads TRENDING_WINDOW and LISTINGS_REFRESH_INTERVAL (Go durations such as 48h),
+// defaulting to models.DefaultListingsConfig
+func listingsFromEnv() models.ListingsConfig {
+	listings := models.DefaultListingsConfig()
+	if window, err := time.ParseDuration(os.Getenv("TRENDING_WINDOW")); err == nil && window > 0 {
+		listings.TrendingWindow = window
+	}
+	if interval, err := time.ParseDuration(os.Getenv("LISTINGS_REFRESH_INTERVAL")); err == nil && interval > 0 {
+		listings.RefreshInterval = interval
+	}
+	return listings
+}
+
+// dealsFromEnv reads LAST_MINUTE_DEAL_PERCENT (off when unset), LAST_MINUTE_DEAL_MAX_OCCUPANCY (a percent) and
+// LAST_MINUTE_DEAL_WINDOW and LAST_MINUTE_DEAL_INTERVAL (Go durations such as 3h), defaulting to
+// models.DefaultDealsConfig
+func dealsFromEnv() models.DealsConfig {
+	deals := models.DefaultDealsConfig()
+	if percent, ok := percentFromEnv("LAST_MINUTE_DEAL_PERCENT"); ok && percent < 100 {
+		deals.Percent = percent
+	}
+	if percent, ok := percentFromEnv("LAST_MINUTE_DEAL_MAX_OCCUPANCY"); ok {
+		deals.MaxOccupancy = percent
+	}
+	if window, err := time.ParseDuration(os.Getenv("LAST_MINUTE_DEAL_WINDOW")); err == nil && window > 0 {
+		deals.Window = window
+	}
+	if interval, err := time.ParseDuration(os.Getenv("LAST_MINUTE_DEAL_INTERVAL")); err == nil && interval > 0 {
+		deals.Interval = interval
+	}
+	return deals
+}
+
+// addOnsFromEnv reads ADDON_INSURANCE_PERCENT, ADDON_3D_GLASSES_PRICE and ADDON_PARKING_PRICE (minor units;
+// 0 stops selling one), defaulting to models.DefaultAddOnConfig
+func addOnsFromEnv() models.AddOnConfig {
+	addOns := models.DefaultAddOnConfig()
+	if percent, ok := percentFromEnv("ADDON_INSURANCE_PERCENT"); ok {
+		addOns.InsurancePercent = percent
+	}
+	if price, err := strconv.ParseInt(os.Getenv("ADDON_3D_GLASSES_PRICE"), 10, 64); err == nil && price >= 0 {
+		addOns.GlassesPrice = price
+	}
+	if price, err := strconv.ParseInt(os.Getenv("ADDON_PARKING_PRICE"), 10, 64); err == nil && price >= 0 {
+		addOns.ParkingPrice = price
+	}
+	return addOns
+}
+
+// resaleFromEnv reads RESALE_FEE_PERCENT, defaulting to models.DefaultResaleConfig
+func resaleFromEnv() models.ResaleConfig {
+	resale := models.DefaultResaleConfig()
+	if percent, ok := percentFromEnv("RESALE_FEE_PERCENT"); ok && percent < 100 {
+		resale.FeePercent = percent
+	}
+	return resale
+}
+
+// fraudFromEnv reads FRAUD_STEP_UP_SCORE and FRAUD_REJECT_SCORE (0 turns either off), defaulting to
+// models.DefaultFraudConfig
+func fraudFromEnv() models.FraudConfig {
+	fraud := models.DefaultFraudConfig()
+	if score, ok := limitFromEnv("FRAUD_STEP_UP_SCORE"); ok {
+		fraud.StepUpScore = score
+	}
+	if score, ok := limitFromEnv("FRAUD_REJECT_SCORE"); ok {
+		fraud.RejectScore = score
+	}
+	return fraud
+}
+
+// notificationsFromEnv reads NOTIFICATION_CHANNELS, e.g. EMAIL,SMS,PUSH, defaulting to models.DefaultNotificationConfig
+func notificationsFromEnv() models.NotificationConfig {
+	notify := models.DefaultNotificationConfig()
+	if channels, ok := models.ParseNotificationChannels(os.Getenv("NOTIFICATION_CHANNELS")); ok && len(channels) > 0 {
+		notify.Channels = channels
+	}
+	return notify
+}
+
+// remindersFromEnv reads SHOW_REMINDER_OFFSETS, e.g. 24h,2h (0 turns reminders off), defaulting to
+// models.DefaultShowReminderConfig
+func remindersFromEnv() models.ShowReminderConfig {
+	reminders := models.DefaultShowReminderConfig()
+	switch value := os.Getenv("SHOW_REMINDER_OFFSETS"); value {
+	case "":
+	case "0":
+		reminders.Offsets = nil
+	default:
+		if offsets, ok := models.ParseShowReminderOffsets(value); ok && len(offsets) > 0 {
+			reminders.Offsets = offsets
+		}
+	}
+	return reminders
+}
+
+// rateLimitsFromEnv reads API_RATE_LIMIT and BOOKING_OPENING_RATE_LIMIT as requests per period, e.g. 120/1m
+// (0 disables a limit), and BOOKING_OPENING_WINDOW (a Go duration), defaulting to models.DefaultRateLimitConfig
+func rateLimitsFromEnv() models.RateLimitConfig {
+	limits := models.DefaultRateLimitConfig()
+	if limit, ok := rateLimitFromEnv("API_RATE_LIMIT"); ok {
+		limits.API = limit
+	}
+	if limit, ok := rateLimitFromEnv("BOOKING_OPENING_RATE_LIMIT"); ok {
+		limits.Opening = limit
+	}
+	if window, err := time.ParseDuration(os.Getenv("BOOKING_OPENING_WINDOW")); err == nil && window >= 0 {
+		limits.OpeningWindow = window
+	}
+	return limits
+}
+
+// rateLimitFromEnv parses requests/period, or 0 to disable the limit, ignoring unset or invalid values
+func rateLimitFromEnv(key string) (models.RateLimit, bool) {
+	value := os.Getenv(key)
+	if value == "0" {
+		return models.RateLimit{}, true
+	}
+	count, period, found := strings.Cut(value, "/")
+	requests, err := strconv.Atoi(count)
+	if !found || err != nil || requests <= 0 {
+		return models.RateLimit{}, false
+	}
+	duration, err := time.ParseDuration(period)
+	if err != nil || duration <= 0 {
+		return models.RateLimit{}, false
+	}
+	return models.RateLimit{Requests: requests, Period: duration}, true
+}
+
+// limitFromEnv parses a non-negative count, ignoring unset or invalid values
+func limitFromEnv(key string) (int, bool) {
+	limit, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || limit < 0 {
+		return 0, false
+	}
+	return limit, true
+}
+
+// settlementFromEnv reads PLATFORM_COMMISSION_PERCENT, defaulting to models.DefaultSettlementConfig
+func settlementFromEnv() models.SettlementConfig {
+	settlement := models.DefaultSettlementConfig()
+	if percent, ok := percentFromEnv("PLATFORM_COMMISSION_PERCENT"); ok {
+		settlement.CommissionPercent = percent
+	}
+	return settlement
+}
+
+// percentFromEnv parses a 0-100 percentage, ignoring unset or invalid values
+func percentFromEnv(key string) (float64, bool) {
+	percent, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil || percent < 0 || percent > 100 {
+		return 0, false
+	}
+	return percent, true
+}
+
 // AppController manages application lifecycle and dependency injection
 // This is the proper place for orchestration logic
 type AppController struct {
 	// Business Services
-	userService    services.UserService
-	movieService   services.MovieService
-	theatreService services.TheatreService
-	showService    services.ShowService
-	bookingService services.BookingService
-	paymentService services.PaymentService
+	userService      services.UserService
+	preferenceSvc    services.PreferenceService
+	recommendations  services.RecommendationService
+	authService      services.AuthService
+	movieService     services.MovieService
+	eventService     services.EventService
+	theatreService   services.TheatreService
+	showService      services.ShowService
+	bookingService   services.BookingService
+	bulkBookingSvc   services.BulkBookingService
+	paymentService   services.PaymentService
+	refundService    services.RefundService
+	promotionService services.PromotionService
+	seatHoldService  services.SeatHoldService
+	adminService     services.AdminService
+	ticketService    services.TicketService
+	checkInService   services.CheckInService
+	reviewService    services.ReviewService
+	walletService    services.WalletService
+	loyaltyService   services.LoyaltyService
+	reportingService services.ReportingService
+	settlementSvc    services.SettlementService
+	auditLog         services.AuditLog
+	webhookService   services.WebhookService
+	watchlistService services.WatchlistService
+	dealsService     services.DealsService
+	parkingService   services.ParkingService
+	resaleService    services.ResaleService
+	closeOutService  services.ShowCloseOutService
+	reminderService  services.ShowReminderService
+	reconcileService services.PaymentReconciliationService
+	callbackVerifier services.CallbackVerifier
+	instrumentSvc    services.PaymentInstrumentService
+	listings         *services.ListingsView
+	ticketRenderer   services.TicketRenderer
+	catalogImporter  services.CatalogImporter
+	authorizer       services.Authorizer
 
 	// Repository Layer - explicit dependencies for type safety
-	userRepo    repositories.UserRepository
-	movieRepo   repositories.MovieRepository
-	theatreRepo repositories.TheatreRepository
-	screenRepo  repositories.ScreenRepository
-	showRepo    repositories.ShowRepository
-	bookingRepo repositories.BookingRepository
-	paymentRepo repositories.PaymentRepository
+	userRepo      repositories.UserRepository
+	movieRepo     repositories.MovieRepository
+	eventRepo     repositories.EventRepository
+	theatreRepo   repositories.TheatreRepository
+	cityRepo      repositories.CityRepository
+	screenRepo    repositories.ScreenRepository
+	showRepo      repositories.ShowRepository
+	bookingRepo   repositories.BookingRepository
+	paymentRepo   repositories.PaymentRepository
+	refundRepo    repositories.RefundRepository
+	couponRepo    repositories.CouponRepository
+	holdRepo      repositories.SeatHoldRepository
+	ticketRepo    repositories.TicketRepository
+	reviewRepo    repositories.ReviewRepository
+	walletRepo    repositories.WalletRepository
+	loyaltyRepo   repositories.LoyaltyRepository
+	outboxRepo    repositories.OutboxRepository
+	notifyRepo    repositories.NotificationRepository
+	credRepo      repositories.CredentialRepository
+	sessionRepo   repositories.SessionRepository
+	settleRepo    repositories.SettlementRepository
+	auditRepo     repositories.AuditRepository
+	webhookRepo   repositories.WebhookRepository
+	deliveryRepo  repositories.WebhookDeliveryRepository
+	watchlistRepo repositories.WatchlistRepository
+	bulkRepo      repositories.BulkBookingRepository
+	parkingRepo   repositories.ParkingReservationRepository
+	resaleRepo    repositories.ResaleListingRepository
+	instrRepo     repositories.PaymentInstrumentRepository
+
+	// Infrastructure Layer
+	config      Config
+	redisClient *goredis.Client // nil when running purely in memory
+	sqlDB       *sql.DB         // nil unless Config.SQLite is set
+	fileLog     *filestore.Log  // nil unless Config.File is set and SQLite isn't
 
 	// External Services Layer
 	paymentGateway  services.PaymentGateway
+	tokenizer       services.Tokenizer              // Payment vault that saved cards are tokenized with
+	movieSource     services.MovieSource            // Where catalog imports pull listings from
+	pricingChain    *pricing.Chain                  // Seat pricing rules; more can be registered at runtime
+	pricingCalendar *pricing.Calendar               // Holiday and peak days admins flag; the chain consults it
+	seatFactory     *factories.SeatFactory          // Lays out new screens, priced from this controller's seat types
+	addOnCatalog    *services.AddOnCatalog          // Extras bookings can buy; more can be registered at runtime
+	validators      *services.BookingValidatorChain // Rules new bookings must pass; validators can be added or removed at runtime
+	fraudChecks     *services.FraudPipeline         // Risk rules payments are scored with before charging; rules can be added or removed at runtime
+	movieScorers    []services.WeightedScorer       // How recommendations are ranked; empty uses the defaults
+	notifications   services.NotificationQueue      // Stores notifications and delivers them on every configured channel
+	notifyChannels  []services.NotificationChannel  // Channel plugins notifications are delivered through; the logging ones unless injected
+	whatsApp        *services.WhatsAppTemplates     // Templates the default WhatsApp channel sends with; admins manage them
 	notificationSvc services.NotificationService
+	webhookClient   services.WebhookClient // Sends partner webhooks; http.DefaultClient unless injected
+	eventBus        events.EventBus
+	outbox          services.OutboxService        // The event bus every service publishes through
+	seatHub         *realtime.SeatHub             // Live seat updates for open seat maps
+	availability    *services.AvailabilityTracker // Per-show seat counters, kept current by the same seat events
+	lockManager     locks.LockManager
+	unitOfWork      repositories.UnitOfWork // Makes a booking's writes land together; a database transaction when persisted
+	logger          logging.Logger
+	metrics         *metrics.Prometheus
+	tracing         trace.TracerProvider // Spans around each step of the booking flow; a no-op unless an exporter is set
+	clock           clock.Clock
+	ticketKey       []byte // Signs e-ticket QR payloads; shared by issuing and check-in
+
+	// Background Workers
+	stopWorkers context.CancelFunc
+	heartbeats  *heartbeats // When each watched worker last ran, for health checks
+
+	// Lifecycle
+	inFlight     *services.InFlight // Bookings and payments under way, which Shutdown waits for
+	workers      sync.WaitGroup     // Running background workers
+	shutdownOnce sync.Once
 }
 
 var (
@@ -37,17 +480,43 @@ var (
 	once     sync.Once
 )
 
-// GetAppController returns singleton instance using dependency injection
-func GetAppController() *AppController {
+// GetAppController returns the process-wide controller, configured from the environment and then opts.
+// It is a thin wrapper over NewAppController for main; tests should build their own instances.
+func GetAppController(opts ...Option) *AppController {
 	once.Do(func() {
-		instance = &AppController{}
-		instance.initializeApp()
+		instance = NewAppController(append([]Option{WithConfig(ConfigFromEnv())}, opts...)...)
 	})
 	return instance
 }
 
+// NewAppController builds an isolated, fully wired controller - demonstrates Builder with Functional Options.
+// Without options everything runs in memory on the system clock; anything not overridden gets its default.
+func NewAppController(opts ...Option) *AppController {
+	ac := &AppController{clock: clock.New()}
+	for _, opt := range opts {
+		opt(ac)
+	}
+	ac.initializeApp()
+	return ac
+}
+
 // initializeApp sets up the entire application with proper dependency injection
 func (ac *AppController) initializeApp() {
+	// Step 0: Services log through one logger
+	ac.seatFactory = factories.NewSeatFactoryWithRegistry(factories.NewSeatTypeRegistry())
+	if err := ac.seatFactory.Registry().Reprice(ac.config.Seats); err != nil {
+		panic(fmt.Sprintf("failed to price seat types: %v", err))
+	}
+	ac.logger = orDefault(ac.logger, func() logging.Logger { return logging.New(ac.config.Logging) })
+	ac.metrics = metrics.NewPrometheus()
+	ac.tracing = orDefault(ac.tracing, func() trace.TracerProvider {
+		provider, err := tracing.New(ac.config.Tracing)
+		if err != nil {
+			ac.logger.Warn(context.Background(), "tracing is off", "error", err)
+		}
+		return provider
+	})
+
 	// Step 1: Initialize Infrastructure Layer (Repositories)
 	ac.initializeRepositories()
 
@@ -56,47 +525,468 @@ func (ac *AppController) initializeApp() {
 
 	// Step 3: Initialize Business Services with Dependencies
 	ac.initializeBusinessServices()
+
+	// Step 4: Start background workers
+	ac.startBackgroundWorkers()
 }
 
-// initializeRepositories creates all repository instances - explicit and type-safe
+// initializeRepositories creates every repository that wasn't injected - explicit and type-safe
 func (ac *AppController) initializeRepositories() {
-	ac.userRepo = repositories.NewMemoryUserRepository()
-	ac.movieRepo = repositories.NewMemoryMovieRepository()
-	ac.theatreRepo = repositories.NewMemoryTheatreRepository()
-	ac.screenRepo = repositories.NewMemoryScreenRepository()
-	ac.showRepo = repositories.NewMemoryShowRepository()
-	ac.bookingRepo = repositories.NewMemoryBookingRepository()
-	ac.paymentRepo = repositories.NewMemoryPaymentRepository()
+	if ac.config.Redis.Enabled() {
+		ac.initializeRedis()
+	}
+	if ac.config.SQLite.Enabled() {
+		ac.initializeSQLite()
+	} else if ac.config.File.Enabled() {
+		ac.initializeFileStore()
+	}
+
+	ac.userRepo = orDefault(ac.userRepo, repositories.NewMemoryUserRepository)
+	ac.movieRepo = orDefault(ac.movieRepo, repositories.NewMemoryMovieRepository)
+	ac.eventRepo = orDefault(ac.eventRepo, repositories.NewMemoryEventRepository)
+	ac.theatreRepo = orDefault(ac.theatreRepo, repositories.NewMemoryTheatreRepository)
+	ac.cityRepo = orDefault(ac.cityRepo, repositories.NewMemoryCityRepository)
+	ac.screenRepo = orDefault(ac.screenRepo, repositories.NewMemoryScreenRepository)
+	ac.showRepo = orDefault(ac.showRepo, repositories.NewMemoryShowRepository)
+	ac.bookingRepo = orDefault(ac.bookingRepo, repositories.NewMemoryBookingRepository)
+	ac.paymentRepo = orDefault(ac.paymentRepo, repositories.NewMemoryPaymentRepository)
+	ac.refundRepo = orDefault(ac.refundRepo, repositories.NewMemoryRefundRepository)
+	ac.couponRepo = orDefault(ac.couponRepo, repositories.NewMemoryCouponRepository)
+	ac.holdRepo = orDefault(ac.holdRepo, repositories.NewMemorySeatHoldRepository)
+	ac.ticketRepo = orDefault(ac.ticketRepo, repositories.NewMemoryTicketRepository)
+	ac.reviewRepo = orDefault(ac.reviewRepo, repositories.NewMemoryReviewRepository)
+	ac.walletRepo = orDefault(ac.walletRepo, repositories.NewMemoryWalletRepository)
+	ac.loyaltyRepo = orDefault(ac.loyaltyRepo, repositories.NewMemoryLoyaltyRepository)
+	ac.outboxRepo = orDefault(ac.outboxRepo, repositories.NewMemoryOutboxRepository)
+	ac.notifyRepo = orDefault(ac.notifyRepo, repositories.NewMemoryNotificationRepository)
+	ac.credRepo = orDefault(ac.credRepo, repositories.NewMemoryCredentialRepository)
+	ac.sessionRepo = orDefault(ac.sessionRepo, repositories.NewMemorySessionRepository)
+	ac.settleRepo = orDefault(ac.settleRepo, repositories.NewMemorySettlementRepository)
+	ac.auditRepo = orDefault(ac.auditRepo, repositories.NewMemoryAuditRepository)
+	ac.webhookRepo = orDefault(ac.webhookRepo, repositories.NewMemoryWebhookRepository)
+	ac.deliveryRepo = orDefault(ac.deliveryRepo, repositories.NewMemoryWebhookDeliveryRepository)
+	ac.watchlistRepo = orDefault(ac.watchlistRepo, repositories.NewMemoryWatchlistRepository)
+	ac.bulkRepo = orDefault(ac.bulkRepo, repositories.NewMemoryBulkBookingRepository)
+	ac.parkingRepo = orDefault(ac.parkingRepo, repositories.NewMemoryParkingReservationRepository)
+	ac.resaleRepo = orDefault(ac.resaleRepo, repositories.NewMemoryResaleListingRepository)
+	ac.instrRepo = orDefault(ac.instrRepo, repositories.NewMemoryPaymentInstrumentRepository)
+}
+
+// initializeRedis moves seat holds to Redis and caches movie/theatre reads - demonstrates Cache-Aside.
+// Injected repositories are left alone; an unreachable server falls back to the in-memory repositories.
+func (ac *AppController) initializeRedis() {
+	client, err := redis.Connect(context.Background(), ac.config.Redis)
+	if err != nil {
+		ac.logger.Warn(context.Background(), "redis unavailable, using in-memory seat holds and no cache", "error", err)
+		return
+	}
+	ac.redisClient = client
+
+	prefix, ttl := ac.config.Redis.KeyPrefix, ac.config.Redis.CacheTTL
+	if ac.holdRepo == nil {
+		ac.holdRepo = redis.NewSeatHoldRepository(client, prefix, ac.clock)
+	}
+	if ac.movieRepo == nil {
+		ac.movieRepo = redis.NewCachedMovieRepository(repositories.NewMemoryMovieRepository(), client, prefix, ttl, ac.logger)
+	}
+	if ac.theatreRepo == nil {
+		ac.theatreRepo = redis.NewCachedTheatreRepository(repositories.NewMemoryTheatreRepository(), client, prefix, ttl, ac.logger)
+	}
+}
+
+// initializeSQLite restores the repositories saved by earlier runs and keeps saving every write.
+// Runs after Redis so holds and the cache stay there; a file that can't be opened falls back to memory.
+func (ac *AppController) initializeSQLite() {
+	ctx := context.Background()
+	db, err := sqlite.Open(ctx, ac.config.SQLite)
+	if err != nil {
+		ac.logger.Warn(ctx, "state will not survive a restart", "error", err)
+		return
+	}
+	store, err := sqlite.Restore(ctx, db)
+	if err != nil {
+		db.Close()
+		ac.logger.Warn(ctx, "state will not survive a restart", "error", err)
+		return
+	}
+	ac.sqlDB = db
+	ac.logger.Info(ctx, "sqlite store opened", "path", ac.config.SQLite.Path, "restored", store.Restored)
+
+	ac.userRepo = orDefault(ac.userRepo, func() repositories.UserRepository { return store.Users })
+	ac.movieRepo = orDefault(ac.movieRepo, func() repositories.MovieRepository { return store.Movies })
+	ac.eventRepo = orDefault(ac.eventRepo, func() repositories.EventRepository { return store.Events })
+	ac.theatreRepo = orDefault(ac.theatreRepo, func() repositories.TheatreRepository { return store.Theatres })
+	ac.cityRepo = orDefault(ac.cityRepo, func() repositories.CityRepository { return store.Cities })
+	ac.screenRepo = orDefault(ac.screenRepo, func() repositories.ScreenRepository { return store.Screens })
+	ac.showRepo = orDefault(ac.showRepo, func() repositories.ShowRepository { return store.Shows })
+	ac.bookingRepo = orDefault(ac.bookingRepo, func() repositories.BookingRepository { return store.Bookings })
+	ac.paymentRepo = orDefault(ac.paymentRepo, func() repositories.PaymentRepository { return store.Payments })
+	ac.refundRepo = orDefault(ac.refundRepo, func() repositories.RefundRepository { return store.Refunds })
+	ac.couponRepo = orDefault(ac.couponRepo, func() repositories.CouponRepository { return store.Coupons })
+	ac.holdRepo = orDefault(ac.holdRepo, func() repositories.SeatHoldRepository { return store.SeatHolds })
+	ac.ticketRepo = orDefault(ac.ticketRepo, func() repositories.TicketRepository { return store.Tickets })
+	ac.reviewRepo = orDefault(ac.reviewRepo, func() repositories.ReviewRepository { return store.Reviews })
+	ac.walletRepo = orDefault(ac.walletRepo, func() repositories.WalletRepository { return store.Wallets })
+	ac.loyaltyRepo = orDefault(ac.loyaltyRepo, func() repositories.LoyaltyRepository { return store.Loyalty })
+	ac.outboxRepo = orDefault(ac.outboxRepo, func() repositories.OutboxRepository { return store.Outbox })
+	ac.notifyRepo = orDefault(ac.notifyRepo, func() repositories.NotificationRepository { return store.Notices })
+	ac.credRepo = orDefault(ac.credRepo, func() repositories.CredentialRepository { return store.Credentials })
+	ac.sessionRepo = orDefault(ac.sessionRepo, func() repositories.SessionRepository { return store.Sessions })
+	ac.settleRepo = orDefault(ac.settleRepo, func() repositories.SettlementRepository { return store.Settlements })
+	ac.auditRepo = orDefault(ac.auditRepo, func() repositories.AuditRepository { return store.Audit })
+	ac.webhookRepo = orDefault(ac.webhookRepo, func() repositories.WebhookRepository { return store.Webhooks })
+	ac.deliveryRepo = orDefault(ac.deliveryRepo, func() repositories.WebhookDeliveryRepository { return store.Deliveries })
+	ac.watchlistRepo = orDefault(ac.watchlistRepo, func() repositories.WatchlistRepository { return store.Watchlist })
+	ac.bulkRepo = orDefault(ac.bulkRepo, func() repositories.BulkBookingRepository { return store.BulkBookings })
+	ac.parkingRepo = orDefault(ac.parkingRepo, func() repositories.ParkingReservationRepository { return store.Parking })
+	ac.resaleRepo = orDefault(ac.resaleRepo, func() repositories.ResaleListingRepository { return store.Resale })
+	ac.instrRepo = orDefault(ac.instrRepo, func() repositories.PaymentInstrumentRepository { return store.Instruments })
+}
+
+// initializeFileStore restores the repositories logged by earlier runs and keeps logging every write - the
+// same decorators as SQLite over a write-ahead log and snapshot. A directory that can't be opened falls back to memory.
+func (ac *AppController) initializeFileStore() {
+	ctx := context.Background()
+	log, err := filestore.Open(ac.config.File)
+	if err != nil {
+		ac.logger.Warn(ctx, "state will not survive a restart", "error", err)
+		return
+	}
+	store, err := filestore.Restore(ctx, log)
+	if err != nil {
+		log.Close()
+		ac.logger.Warn(ctx, "state will not survive a restart", "error", err)
+		return
+	}
+	ac.fileLog = log
+	ac.logger.Info(ctx, "file store opened", "dir", ac.config.File.Dir, "restored", store.Restored)
+
+	ac.userRepo = orDefault(ac.userRepo, func() repositories.UserRepository { return store.Users })
+	ac.movieRepo = orDefault(ac.movieRepo, func() repositories.MovieRepository { return store.Movies })
+	ac.eventRepo = orDefault(ac.eventRepo, func() repositories.EventRepository { return store.Events })
+	ac.theatreRepo = orDefault(ac.theatreRepo, func() repositories.TheatreRepository { return store.Theatres })
+	ac.cityRepo = orDefault(ac.cityRepo, func() repositories.CityRepository { return store.Cities })
+	ac.screenRepo = orDefault(ac.screenRepo, func() repositories.ScreenRepository { return store.Screens })
+	ac.showRepo = orDefault(ac.showRepo, func() repositories.ShowRepository { return store.Shows })
+	ac.bookingRepo = orDefault(ac.bookingRepo, func() repositories.BookingRepository { return store.Bookings })
+	ac.paymentRepo = orDefault(ac.paymentRepo, func() repositories.PaymentRepository { return store.Payments })
+	ac.refundRepo = orDefault(ac.refundRepo, func() repositories.RefundRepository { return store.Refunds })
+	ac.couponRepo = orDefault(ac.couponRepo, func() repositories.CouponRepository { return store.Coupons })
+	ac.holdRepo = orDefault(ac.holdRepo, func() repositories.SeatHoldRepository { return store.SeatHolds })
+	ac.ticketRepo = orDefault(ac.ticketRepo, func() repositories.TicketRepository { return store.Tickets })
+	ac.reviewRepo = orDefault(ac.reviewRepo, func() repositories.ReviewRepository { return store.Reviews })
+	ac.walletRepo = orDefault(ac.walletRepo, func() repositories.WalletRepository { return store.Wallets })
+	ac.loyaltyRepo = orDefault(ac.loyaltyRepo, func() repositories.LoyaltyRepository { return store.Loyalty })
+	ac.outboxRepo = orDefault(ac.outboxRepo, func() repositories.OutboxRepository { return store.Outbox })
+	ac.notifyRepo = orDefault(ac.notifyRepo, func() repositories.NotificationRepository { return store.Notices })
+	ac.credRepo = orDefault(ac.credRepo, func() repositories.CredentialRepository { return store.Credentials })
+	ac.sessionRepo = orDefault(ac.sessionRepo, func() repositories.SessionRepository { return store.Sessions })
+	ac.settleRepo = orDefault(ac.settleRepo, func() repositories.SettlementRepository { return store.Settlements })
+	ac.auditRepo = orDefault(ac.auditRepo, func() repositories.AuditRepository { return store.Audit })
+	ac.webhookRepo = orDefault(ac.webhookRepo, func() repositories.WebhookRepository { return store.Webhooks })
+	ac.deliveryRepo = orDefault(ac.deliveryRepo, func() repositories.WebhookDeliveryRepository { return store.Deliveries })
+	ac.watchlistRepo = orDefault(ac.watchlistRepo, func() repositories.WatchlistRepository { return store.Watchlist })
+	ac.bulkRepo = orDefault(ac.bulkRepo, func() repositories.BulkBookingRepository { return store.BulkBookings })
+	ac.parkingRepo = orDefault(ac.parkingRepo, func() repositories.ParkingReservationRepository { return store.Parking })
+	ac.resaleRepo = orDefault(ac.resaleRepo, func() repositories.ResaleListingRepository { return store.Resale })
+	ac.instrRepo = orDefault(ac.instrRepo, func() repositories.PaymentInstrumentRepository { return store.Instruments })
 }
 
 // initializeExternalServices creates external service connections - explicit and type-safe
 func (ac *AppController) initializeExternalServices() {
-	ac.paymentGateway = strategies.NewPaymentGateway()
-	ac.notificationSvc = services.NewNotificationService()
+	// Wallet payments debit real balances, so the gateway needs the wallet service
+	ac.walletService = services.NewWalletService(ac.walletRepo, ac.userRepo, ac.clock)
+
+	// Adapter Pattern - PAYMENT_PROVIDER picks Razorpay or Stripe; misconfiguration falls back to the mock
+	if ac.paymentGateway == nil {
+		provider, err := gateways.New(ac.config.Payment)
+		if err != nil {
+			ac.logger.Warn(context.Background(), "using mock payment gateway", "error", err)
+		}
+		gateway := strategies.NewPaymentGatewayWithWallet(provider, ac.walletService, ac.clock).WithChallenges(ac.config.Challenges)
+
+		// Decorator Pattern - outages are retried and a failing method fails fast instead of piling up
+		ac.paymentGateway = strategies.NewResilientPaymentGateway(gateway, ac.config.Resilience, ac.clock, ac.metrics)
+	}
+	// Adapter Pattern - CATALOG_SOURCE picks TMDB or a JSON fixture; misconfiguration falls back to the bundled fixture
+	if ac.movieSource == nil {
+		source, err := catalog.New(ac.config.Catalog)
+		if err != nil {
+			ac.logger.Warn(context.Background(), "using the bundled catalog fixture", "path", catalog.DefaultFixturePath, "error", err)
+			source, _ = catalog.New(catalog.Config{Kind: catalog.KindFixture})
+		}
+		ac.movieSource = source
+	}
+	// Strategy Pattern - notifications are queued and delivered in the background through channel plugins
+	ac.whatsApp = services.NewWhatsAppTemplates(ac.clock, models.DefaultWhatsAppTemplates(ac.clock.Now())...)
+	if ac.notifyChannels == nil {
+		ac.notifyChannels = services.DefaultNotificationChannels(ac.whatsApp, ac.logger)
+	}
+	ac.notifications = services.NewNotificationQueue(ac.notifyRepo, ac.userRepo, ac.notifyChannels, ac.config.Notify, ac.logger, ac.clock)
+	ac.notificationSvc = orDefault(ac.notificationSvc, func() services.NotificationService {
+		return services.NewNotificationService(ac.notifications)
+	})
+
+	// Observer Pattern - notifications are one of several event subscribers
+	// Outbox Pattern - events are recorded before delivery, so a failing subscriber is retried instead of skipped
+	ac.outbox = services.NewOutboxEventBus(events.NewInMemoryEventBus(), ac.outboxRepo, ac.config.Outbox, ac.logger, ac.clock)
+	ac.eventBus = ac.outbox
+	ac.seatHub = realtime.NewSeatHub(ac.clock)
+	realtime.RegisterSeatSubscriber(ac.eventBus, ac.seatHub)
+	ac.availability = services.NewAvailabilityTracker(services.DefaultAvailabilityResync, ac.clock)
+	services.RegisterAvailabilitySubscriber(ac.eventBus, ac.availability)
+	ac.eventBus.SubscribeAll(events.NewLoggingSubscriber())
+
+	// Per-show locks - shared through Redis when configured so every instance sees them
+	if ac.lockManager == nil && ac.redisClient != nil {
+		ac.lockManager = redis.NewLockManager(ac.redisClient, ac.config.Redis.KeyPrefix, ac.logger)
+	}
+	ac.lockManager = orDefault(ac.lockManager, func() locks.LockManager { return locks.NewKeyedLockManager() })
+
+	// Booking writes commit together - in one SQL transaction or one log line when persisted
+	switch {
+	case ac.sqlDB != nil:
+		ac.unitOfWork = sqlite.NewUnitOfWork(ac.sqlDB)
+	case ac.fileLog != nil:
+		ac.unitOfWork = filestore.NewUnitOfWork(ac.fileLog)
+	default:
+		ac.unitOfWork = repositories.NewMemoryUnitOfWork()
+	}
+
+	// Kept in the database when tickets are, so saved QR codes still check in; random per process otherwise
+	key, err := ac.secret("ticket_signing_key")
+	if err != nil {
+		panic(fmt.Sprintf("failed to load ticket signing key: %v", err))
+	}
+	ac.ticketKey = key
+
+	// Likewise the vault key, so saved cards' tokens still open after a restart
+	ac.tokenizer = orDefault(ac.tokenizer, func() services.Tokenizer {
+		key, err := ac.secret("payment_vault_key")
+		if err != nil {
+			panic(fmt.Sprintf("failed to load payment vault key: %v", err))
+		}
+		vault, err := strategies.NewSealedVault(key)
+		if err != nil {
+			panic(err.Error())
+		}
+		return vault
+	})
+}
+
+// secret returns a 32 byte key kept with the persisted data, or a random one when nothing is persisted
+func (ac *AppController) secret(name string) ([]byte, error) {
+	switch {
+	case ac.sqlDB != nil:
+		return sqlite.Secret(context.Background(), ac.sqlDB, name, 32)
+	case ac.fileLog != nil:
+		return filestore.Secret(ac.fileLog, name, 32)
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
 }
 
 // initializeBusinessServices creates business services with proper dependencies
 func (ac *AppController) initializeBusinessServices() {
 	// Create business services with explicit dependencies - no type assertions needed
-	ac.userService = services.NewUserService(ac.userRepo)
-	ac.movieService = services.NewMovieService(ac.movieRepo)
-	ac.theatreService = services.NewTheatreService(ac.theatreRepo, ac.screenRepo)
-	ac.showService = services.NewShowService(ac.showRepo, ac.movieRepo, ac.theatreRepo, ac.screenRepo)
+	// Role checks - services that change the catalog, theatres or shows consult the authorizer
+	ac.authorizer = services.NewAuthorizer(ac.userRepo, ac.showRepo)
+	ac.auditLog = services.NewAuditLog(ac.auditRepo, ac.logger, ac.clock)
+
+	ac.userService = services.NewUserService(ac.userRepo, ac.clock)
+	ac.preferenceSvc = services.NewPreferenceService(ac.userRepo, ac.cityRepo, ac.theatreRepo, ac.clock)
+	ac.recommendations = services.NewRecommendationService(ac.userRepo, ac.movieRepo, ac.showRepo, ac.bookingRepo, ac.clock, ac.movieScorers...)
+	ac.authService = services.NewAuthService(ac.userService, ac.credRepo, ac.sessionRepo, ac.config.Auth, ac.clock)
+	ac.listings = services.NewListingsView(ac.movieRepo, ac.showRepo, ac.theatreRepo, ac.bookingRepo, ac.config.Listings, ac.clock)
+	ac.movieService = services.NewMovieService(ac.movieRepo, ac.authorizer, ac.listings, ac.clock)
+	ac.catalogImporter = services.NewCatalogImporter(ac.movieSource, ac.movieRepo, ac.authorizer, ac.clock)
+	ac.eventService = services.NewEventService(ac.eventRepo, ac.authorizer, ac.clock)
+	ac.theatreService = services.NewTheatreService(ac.theatreRepo, ac.screenRepo, ac.cityRepo, ac.authorizer, ac.clock)
+	ac.promotionService = services.NewPromotionService(ac.couponRepo, ac.authorizer, ac.clock)
+	ac.reviewService = services.NewReviewService(ac.reviewRepo, ac.movieRepo, ac.userRepo, ac.authorizer, ac.clock)
+	// Decorator Pattern - each step of the booking flow gets a span: blocking seats, charging and confirming
+	tracer := ac.tracing.Tracer(tracing.InstrumentationName)
+	ac.seatHoldService = tracing.NewSeatHoldService(services.NewSeatHoldService(ac.holdRepo, ac.userRepo, ac.showRepo, ac.screenRepo, ac.eventBus, ac.lockManager, ac.config.Timeouts, ac.logger, ac.metrics, ac.clock), tracer)
+	ac.refundService = services.NewRefundService(
+		ac.refundRepo,
+		ac.paymentRepo,
+		ac.paymentGateway,
+		ac.walletService,
+		ac.eventBus,
+		ac.auditLog,
+		ac.logger,
+		ac.clock,
+	)
+	ac.fraudChecks = orDefault(ac.fraudChecks, func() *services.FraudPipeline {
+		return services.NewFraudPipeline(ac.userRepo, ac.showRepo, ac.theatreRepo, ac.config.Fraud, ac.clock, services.DefaultFraudRules(ac.paymentRepo, ac.clock)...)
+	})
+	ac.paymentService = tracing.NewPaymentService(services.NewPaymentService(
+		ac.paymentRepo,
+		ac.bookingRepo,
+		tracing.NewPaymentGateway(ac.paymentGateway, tracer),
+		ac.eventBus,
+		ac.refundService,
+		ac.lockManager,
+		ac.logger,
+		ac.metrics,
+		ac.clock,
+		ac.instrRepo,
+		ac.tokenizer,
+		ac.fraudChecks,
+		ac.authorizer,
+	), tracer)
+	ac.inFlight = services.NewInFlight()
+	ac.paymentService = services.NewDrainingPaymentService(ac.paymentService, ac.inFlight)
+	ac.instrumentSvc = services.NewPaymentInstrumentService(ac.instrRepo, ac.userRepo, ac.tokenizer, ac.clock)
+	ac.pricingCalendar = ac.config.Pricing.NewCalendar()
+	ac.pricingChain = orDefault(ac.pricingChain, func() *pricing.Chain { return ac.config.Pricing.NewChain(ac.pricingCalendar) })
+	ac.parkingService = services.NewParkingService(ac.parkingRepo, ac.theatreRepo, ac.showRepo, ac.authorizer, ac.auditLog, ac.lockManager, ac.clock)
+	ac.addOnCatalog = services.NewAddOnCatalog(services.DefaultAddOns(ac.config.AddOns, ac.parkingService)...)
+	bookingRules := services.NewBookingRules(ac.bookingRepo, ac.config.Limits, ac.clock)
+	ac.validators = orDefault(ac.validators, func() *services.BookingValidatorChain {
+		return services.NewBookingValidatorChain(services.DefaultBookingValidators(ac.userRepo, ac.movieRepo, bookingRules)...)
+	})
 	ac.bookingService = services.NewBookingService(
 		ac.bookingRepo,
 		ac.showRepo,
 		ac.screenRepo,
 		ac.theatreRepo,
 		ac.movieRepo,
+		ac.eventRepo,
 		ac.paymentRepo,
-		ac.notificationSvc,
+		ac.userRepo,
+		ac.ticketRepo,
+		ac.eventBus,
+		ac.refundService,
+		ac.paymentService,
+		ac.promotionService,
+		ac.seatHoldService,
+		services.NewPolicyEngine(ac.theatreRepo, ac.clock),
+		ac.validators,
+		bookingRules,
+		services.NewProfileDiscountRules(ac.userRepo, ac.bookingRepo, ac.config.Pricing.ProfileDiscounts, ac.clock),
+		ac.addOnCatalog,
+		ac.config.Fees,
+		ac.config.Timeouts,
+		ac.pricingChain,
+		ac.lockManager,
+		ac.unitOfWork,
+		ac.logger,
+		ac.metrics,
+		ac.auditLog,
+		ac.authorizer,
+		ac.clock,
 	)
-	ac.paymentService = services.NewPaymentService(
-		ac.paymentRepo,
+	ac.bookingService = services.NewRateLimitedBookingService(ac.bookingService, ac.showRepo, ac.config.RateLimits, ac.clock)
+	ac.bookingService = tracing.NewBookingService(ac.bookingService, tracer)
+	ac.bookingService = services.NewDrainingBookingService(ac.bookingService, ac.inFlight)
+
+	// Bookings whose payment fraud checks rejected are cancelled, freeing their seats
+	services.RegisterFraudSubscriber(ac.eventBus, ac.bookingService)
+
+	// Payments left pending confirmation by gateway timeouts are settled by asking the gateway again
+	ac.reconcileService = services.NewPaymentReconciliationService(ac.paymentRepo, ac.bookingRepo, ac.paymentGateway, ac.bookingService, ac.refundService, ac.lockManager, ac.eventBus, ac.logger, ac.clock)
+
+	// The gateway's asynchronous callbacks settle payments too, once their signature checks out
+	ac.callbackVerifier = gateways.NewCallbackVerifier(ac.config.Payment, ac.clock)
+
+	// Corporate blocks are bookings with redemption codes on top
+	ac.bulkBookingSvc = services.NewBulkBookingService(ac.bulkRepo, ac.userRepo, ac.movieRepo, ac.showRepo, ac.screenRepo, ac.bookingService, ac.authorizer, ac.lockManager, ac.clock)
+
+	// Show cancellation cascades through bookings, payments and notifications
+	ac.showService = services.NewShowService(
+		ac.showRepo,
+		ac.movieRepo,
+		ac.eventRepo,
+		ac.theatreRepo,
+		ac.screenRepo,
 		ac.bookingRepo,
-		ac.paymentGateway,
+		ac.bookingService,
+		ac.seatHoldService,
+		ac.paymentService,
 		ac.notificationSvc,
+		ac.config.Formats,
+		ac.config.Scheduling,
+		ac.listings,
+		ac.availability,
+		ac.pricingChain,
+		ac.authorizer,
+		ac.auditLog,
+		ac.lockManager,
+		ac.eventBus,
+		ac.logger,
+		ac.clock,
 	)
+	// Emptier shows about to start are discounted through the pricing chain
+	ac.dealsService = services.NewDealsService(ac.showRepo, ac.theatreRepo, ac.showService, ac.eventBus, ac.config.Deals, ac.logger, ac.clock)
+	if ac.config.Deals.Percent > 0 {
+		if err := ac.pricingChain.Register(pricing.RuleDeal, pricing.LastMinuteDeal(ac.dealsService)); err != nil {
+			ac.logger.Warn(context.Background(), "failed to register last-minute deals", "error", err)
+		}
+	}
+	ac.adminService = services.NewAdminService(ac.userRepo, ac.theatreRepo, ac.screenRepo, ac.theatreService, ac.showService, ac.outbox, ac.notifications, ac.auditLog, ac.authorizer, ac.seatFactory, ac.pricingCalendar, ac.whatsApp, ac.clock)
+	ac.reportingService = services.NewReportingService(ac.authorizer, ac.theatreRepo, ac.screenRepo, ac.showRepo, ac.bookingRepo, ac.paymentRepo, ac.settleRepo, ac.seatFactory.Registry())
+	ac.settlementSvc = services.NewSettlementService(ac.authorizer, ac.theatreRepo, ac.showRepo, ac.bookingRepo, ac.settleRepo, ac.config.Settlement, ac.clock)
+
+	// Shows that have ended are closed out with their final figures, which reports use from then on
+	ac.closeOutService = services.NewShowCloseOutService(ac.showRepo, ac.screenRepo, ac.bookingRepo, ac.ticketRepo, ac.paymentRepo, ac.authorizer, ac.auditLog, ac.lockManager, ac.eventBus, ac.logger, ac.clock)
+	ac.reminderService = services.NewShowReminderService(ac.showRepo, ac.bookingRepo, ac.theatreRepo, ac.movieRepo, ac.eventRepo, ac.notificationSvc, ac.config.Reminders, ac.lockManager, ac.clock)
+
+	// Tickets are issued and voided in response to booking events
+	ac.ticketService = services.NewTicketService(ac.ticketRepo, ac.bookingRepo, ac.showRepo, ac.bookingService, ac.ticketKey, ac.clock)
+	ac.checkInService = services.NewCheckInService(ac.ticketRepo, ac.bookingRepo, ac.showRepo, ac.movieRepo, ac.eventRepo, ac.screenRepo, ac.authorizer, ac.ticketKey, ac.clock)
+	services.RegisterTicketSubscriber(ac.eventBus, ac.ticketService)
+
+	// Confirmation emails attach the printable ticket and a calendar invite, so they subscribe once tickets can be rendered
+	ac.ticketRenderer = services.NewTicketRenderer(ac.bookingService, ac.ticketService, ac.userRepo)
+	services.RegisterNotificationSubscriber(ac.eventBus, ac.notificationSvc, ac.ticketRenderer, ac.ticketService, ac.parkingService)
+
+	// Paid add-ons are fulfilled in response to confirmations; parking slots are freed with their bookings
+	services.RegisterAddOnSubscriber(ac.eventBus, ac.bookingService)
+	services.RegisterParkingSubscriber(ac.eventBus, ac.parkingService)
+
+	// Bookings can be resold at no more than face value; listings are withdrawn when their booking changes
+	ac.resaleService = services.NewResaleService(
+		ac.resaleRepo,
+		ac.bookingRepo,
+		ac.showRepo,
+		ac.userRepo,
+		ac.movieRepo,
+		ac.ticketRepo,
+		ac.paymentService,
+		ac.refundService,
+		ac.eventBus,
+		ac.lockManager,
+		ac.auditLog,
+		ac.config.Resale,
+		ac.logger,
+		ac.clock,
+	)
+	services.RegisterResaleSubscriber(ac.eventBus, ac.resaleService)
+
+	// Points are awarded and reversed in response to booking events
+	ac.loyaltyService = services.NewLoyaltyService(ac.loyaltyRepo, ac.userRepo, ac.bookingRepo, ac.config.Loyalty, ac.lockManager, ac.clock)
+	services.RegisterLoyaltySubscriber(ac.eventBus, ac.loyaltyService)
+
+	// Theatre partners hear about confirmations, cancellations and sell-outs on their own servers
+	ac.webhookService = services.NewWebhookService(
+		ac.webhookRepo,
+		ac.deliveryRepo,
+		ac.theatreRepo,
+		ac.showRepo,
+		ac.authorizer,
+		ac.webhookClient,
+		ac.config.Webhooks,
+		ac.logger,
+		ac.clock,
+	)
+	services.RegisterWebhookSubscriber(ac.eventBus, ac.webhookService)
+
+	// A background worker reminds users when a watchlisted movie reaches their city
+	ac.watchlistService = services.NewWatchlistService(ac.watchlistRepo, ac.userRepo, ac.movieRepo, ac.showRepo, ac.theatreRepo, ac.notificationSvc, ac.clock)
 }
 
 // Business Service Getters - Clean interface for accessing services
@@ -104,10 +994,30 @@ func (ac *AppController) GetUserService() services.UserService {
 	return ac.userService
 }
 
+func (ac *AppController) GetPreferenceService() services.PreferenceService {
+	return ac.preferenceSvc
+}
+
+func (ac *AppController) GetRecommendationService() services.RecommendationService {
+	return ac.recommendations
+}
+
+func (ac *AppController) GetAuthService() services.AuthService {
+	return ac.authService
+}
+
 func (ac *AppController) GetMovieService() services.MovieService {
 	return ac.movieService
 }
 
+func (ac *AppController) GetCatalogImporter() services.CatalogImporter {
+	return ac.catalogImporter
+}
+
+func (ac *AppController) GetEventService() services.EventService {
+	return ac.eventService
+}
+
 func (ac *AppController) GetTheatreService() services.TheatreService {
 	return ac.theatreService
 }
@@ -124,20 +1034,446 @@ func (ac *AppController) GetPaymentService() services.PaymentService {
 	return ac.paymentService
 }
 
+func (ac *AppController) GetPromotionService() services.PromotionService {
+	return ac.promotionService
+}
+
+func (ac *AppController) GetSeatHoldService() services.SeatHoldService {
+	return ac.seatHoldService
+}
+
+func (ac *AppController) GetAdminService() services.AdminService {
+	return ac.adminService
+}
+
+// GetAuthorizer checks callers' roles and which bookings they may act on
+func (ac *AppController) GetAuthorizer() services.Authorizer {
+	return ac.authorizer
+}
+
+func (ac *AppController) GetReviewService() services.ReviewService {
+	return ac.reviewService
+}
+
+func (ac *AppController) GetReportingService() services.ReportingService {
+	return ac.reportingService
+}
+
+func (ac *AppController) GetSettlementService() services.SettlementService {
+	return ac.settlementSvc
+}
+
+func (ac *AppController) GetAuditLog() services.AuditLog {
+	return ac.auditLog
+}
+
+// GetPricingChain returns the seat pricing rules, so decorators can be registered or removed at runtime
+func (ac *AppController) GetPricingChain() *pricing.Chain {
+	return ac.pricingChain
+}
+
+// GetPricingCalendar returns the holiday and peak days the pricing chain consults
+func (ac *AppController) GetPricingCalendar() *pricing.Calendar {
+	return ac.pricingCalendar
+}
+
+// GetSeatFactory returns the seat factory new screens are laid out with, and through its registry the seat types
+func (ac *AppController) GetSeatFactory() *factories.SeatFactory {
+	return ac.seatFactory
+}
+
+// GetAddOnCatalog returns the add-ons bookings can buy, e.g. to register a custom one
+func (ac *AppController) GetAddOnCatalog() *services.AddOnCatalog {
+	return ac.addOnCatalog
+}
+
+// GetListingsView returns the cached trending and now-showing listings, e.g. to refresh them right away
+func (ac *AppController) GetListingsView() *services.ListingsView {
+	return ac.listings
+}
+
+// GetBookingValidators returns the checks CreateBooking runs, so validators can be added or removed at runtime
+func (ac *AppController) GetBookingValidators() *services.BookingValidatorChain {
+	return ac.validators
+}
+
+// GetFraudPipeline returns the risk rules payments are scored with, so rules can be added or removed at runtime
+func (ac *AppController) GetFraudPipeline() *services.FraudPipeline {
+	return ac.fraudChecks
+}
+
+func (ac *AppController) GetWebhookService() services.WebhookService {
+	return ac.webhookService
+}
+
+func (ac *AppController) GetBulkBookingService() services.BulkBookingService {
+	return ac.bulkBookingSvc
+}
+
+func (ac *AppController) GetWatchlistService() services.WatchlistService {
+	return ac.watchlistService
+}
+
+func (ac *AppController) GetDealsService() services.DealsService {
+	return ac.dealsService
+}
+
+func (ac *AppController) GetParkingService() services.ParkingService {
+	return ac.parkingService
+}
+
+func (ac *AppController) GetResaleService() services.ResaleService {
+	return ac.resaleService
+}
+
+func (ac *AppController) GetPaymentInstrumentService() services.PaymentInstrumentService {
+	return ac.instrumentSvc
+}
+
+func (ac *AppController) GetShowCloseOutService() services.ShowCloseOutService {
+	return ac.closeOutService
+}
+
+func (ac *AppController) GetShowReminderService() services.ShowReminderService {
+	return ac.reminderService
+}
+
+func (ac *AppController) GetPaymentReconciliationService() services.PaymentReconciliationService {
+	return ac.reconcileService
+}
+
+func (ac *AppController) GetCallbackVerifier() services.CallbackVerifier {
+	return ac.callbackVerifier
+}
+
+func (ac *AppController) GetTicketService() services.TicketService {
+	return ac.ticketService
+}
+
+func (ac *AppController) GetTicketRenderer() services.TicketRenderer {
+	return ac.ticketRenderer
+}
+
+func (ac *AppController) GetCheckInService() services.CheckInService {
+	return ac.checkInService
+}
+
+func (ac *AppController) GetEventBus() events.EventBus {
+	return ac.eventBus
+}
+
+func (ac *AppController) GetRefundService() services.RefundService {
+	return ac.refundService
+}
+
+func (ac *AppController) GetWalletService() services.WalletService {
+	return ac.walletService
+}
+
+func (ac *AppController) GetLoyaltyService() services.LoyaltyService {
+	return ac.loyaltyService
+}
+
+// GetSeatHub streams seat status changes to clients watching a show
+func (ac *AppController) GetSeatHub() *realtime.SeatHub {
+	return ac.seatHub
+}
+
+func (ac *AppController) GetClock() clock.Clock {
+	return ac.clock
+}
+
+// GetRateLimits is how hard the API throttles each caller
+func (ac *AppController) GetRateLimits() models.RateLimitConfig {
+	return ac.config.RateLimits
+}
+
+func (ac *AppController) GetLogger() logging.Logger {
+	return ac.logger
+}
+
+// GetMetricsHandler serves the booking funnel metrics for Prometheus to scrape
+func (ac *AppController) GetMetricsHandler() http.Handler {
+	return ac.metrics.Handler()
+}
+
+// GetTracerProvider returns the provider the booking flow's spans are recorded with
+func (ac *AppController) GetTracerProvider() trace.TracerProvider {
+	return ac.tracing
+}
+
+// startBackgroundWorkers launches periodic maintenance jobs
+func (ac *AppController) startBackgroundWorkers() {
+	ctx, cancel := context.WithCancel(context.Background())
+	ac.stopWorkers = cancel
+	ac.heartbeats = newHeartbeats()
+
+	// Release seats of holds whose TTL has passed
+	ac.runWorker(func() {
+		ticker := time.NewTicker(holdExpiryInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := ac.seatHoldService.ReleaseExpiredHolds(ctx); err != nil {
+					ac.logger.Warn(ctx, "failed to release expired holds", "error", err)
+				}
+				ac.heartbeats.beat(holdReaperWorker)
+			}
+		}
+	})
+
+	// Retry event deliveries that failed and drop old delivered ones
+	ac.runWorker(func() {
+		ticker := time.NewTicker(outboxDispatchInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := ac.outbox.DispatchPending(ctx); err != nil {
+					ac.logger.Warn(ctx, "failed to dispatch outbox events", "error", err)
+				}
+				if _, err := ac.outbox.PurgeDelivered(ctx, outboxRetention); err != nil {
+					ac.logger.Warn(ctx, "failed to purge delivered outbox events", "error", err)
+				}
+				ac.heartbeats.beat(outboxWorker)
+			}
+		}
+	})
+
+	// Retry notification deliveries that failed and drop old sent ones
+	ac.runWorker(func() {
+		ticker := time.NewTicker(notificationDispatchInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := ac.notifications.DispatchPending(ctx); err != nil {
+					ac.logger.Warn(ctx, "failed to dispatch notifications", "error", err)
+				}
+				if _, err := ac.notifications.PurgeSent(ctx, notificationRetention); err != nil {
+					ac.logger.Warn(ctx, "failed to purge sent notifications", "error", err)
+				}
+			}
+		}
+	})
+
+	// Rebuild the trending and now-showing listings
+	ac.runWorker(func() {
+		ticker := time.NewTicker(ac.listings.RefreshInterval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := ac.listings.Refresh(ctx); err != nil {
+					ac.logger.Warn(ctx, "failed to refresh listings", "error", err)
+				}
+			}
+		}
+	})
+
+	// Remind users of watchlisted movies newly showing in their city
+	ac.runWorker(func() {
+		ticker := time.NewTicker(watchlistReminderInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := ac.watchlistService.SendReminders(ctx); err != nil {
+					ac.logger.Warn(ctx, "failed to send watchlist reminders", "error", err)
+				}
+			}
+		}
+	})
+
+	// Discount emptier shows about to start
+	if ac.config.Deals.Percent > 0 && ac.config.Deals.Interval > 0 {
+		ac.runWorker(func() {
+			ticker := time.NewTicker(ac.config.Deals.Interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if _, err := ac.dealsService.CreateDeals(ctx); err != nil {
+						ac.logger.Warn(ctx, "failed to create last-minute deals", "error", err)
+					}
+				}
+			}
+		})
+	}
+
+	// Close out shows that have ended, marking unscanned tickets as no-shows
+	ac.runWorker(func() {
+		ticker := time.NewTicker(showCloseOutInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := ac.closeOutService.CloseOutEndedShows(ctx); err != nil {
+					ac.logger.Warn(ctx, "failed to close out ended shows", "error", err)
+				}
+			}
+		}
+	})
+
+	// Remind bookings' owners of their upcoming shows
+	if len(ac.config.Reminders.Offsets) > 0 {
+		ac.runWorker(func() {
+			ticker := time.NewTicker(showReminderInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if _, err := ac.reminderService.SendShowReminders(ctx); err != nil {
+						ac.logger.Warn(ctx, "failed to send show reminders", "error", err)
+					}
+				}
+			}
+		})
+	}
+
+	// Settle payments the gateway timed out on, confirming or releasing their bookings
+	ac.runWorker(func() {
+		ticker := time.NewTicker(paymentReconcileInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := ac.reconcileService.ReconcilePayments(ctx); err != nil {
+					ac.logger.Warn(ctx, "failed to reconcile payments", "error", err)
+				}
+			}
+		}
+	})
+
+	// Retry partner webhooks that failed or timed out
+	ac.runWorker(func() {
+		ticker := time.NewTicker(webhookDispatchInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := ac.webhookService.DispatchPending(ctx); err != nil {
+					ac.logger.Warn(ctx, "failed to dispatch webhooks", "error", err)
+				}
+			}
+		}
+	})
+}
+
 // Application lifecycle management
+//
+// Shutdown stops the application gracefully. New bookings and first payments are refused with
+// models.ErrShuttingDown while the calls under way get up to Config.Shutdown to finish; then the background
+// workers stop, pending events and notifications are delivered once more, and the stores close. Whatever
+// can't be delivered in time stays pending in a persistent store for the next start. Later calls do nothing.
 func (ac *AppController) Shutdown() {
-	// Cleanup operations:
-	// - Close database connections
-	// - Stop background workers
-	// - Release resources
-	// - Graceful shutdown of services
-}
-
-// Health check for monitoring
-func (ac *AppController) HealthCheck() map[string]string {
-	return map[string]string{
-		"status":       "healthy",
-		"services":     "6 services running",
-		"repositories": "7 repositories connected",
+	ac.shutdownOnce.Do(func() {
+		timeout := ac.config.Shutdown
+		if timeout <= 0 {
+			timeout = config.DefaultShutdownTimeout
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		ac.shutdown(ctx)
+	})
+}
+
+func (ac *AppController) shutdown(ctx context.Context) {
+	if err := ac.inFlight.Drain(ctx); err != nil {
+		ac.logger.Warn(ctx, "stopped waiting for in-flight bookings and payments", "error", err)
+	}
+
+	if ac.stopWorkers != nil {
+		ac.stopWorkers()
+	}
+	if err := waitFor(ctx, &ac.workers); err != nil {
+		ac.logger.Warn(ctx, "stopped waiting for background workers", "error", err)
+	}
+
+	// Deliver what the workers would have retried next; events first, as their subscribers queue notifications
+	if _, err := ac.outbox.DispatchPending(ctx); err != nil {
+		ac.logger.Warn(ctx, "failed to dispatch outbox events", "error", err)
+	}
+	if _, err := ac.notifications.DispatchPending(ctx); err != nil {
+		ac.logger.Warn(ctx, "failed to dispatch notifications", "error", err)
+	}
+
+	// Let notifications already handed to their channels finish before the stores close
+	ac.notifications.Wait()
+
+	// Export the spans still buffered
+	if err := tracing.Shutdown(ctx, ac.tracing); err != nil {
+		ac.logger.Warn(ctx, "failed to export buffered spans", "error", err)
+	}
+
+	if ac.redisClient != nil {
+		ac.redisClient.Close()
+	}
+
+	if ac.sqlDB != nil {
+		ac.sqlDB.Close()
+	}
+
+	if ac.fileLog != nil {
+		if err := ac.fileLog.Close(); err != nil {
+			ac.logger.Warn(ctx, "failed to close the file store", "error", err)
+		}
+	}
+}
+
+// runWorker starts a background worker that Shutdown waits for
+func (ac *AppController) runWorker(worker func()) {
+	ac.workers.Add(1)
+	go func() {
+		defer ac.workers.Done()
+		worker()
+	}()
+}
+
+// waitFor waits for group, or until ctx is done
+func waitFor(ctx context.Context, group *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		group.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
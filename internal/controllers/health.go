@@ -0,0 +1,215 @@
+package controllers
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/strategies"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// healthProbeTimeout bounds each probe, so one hung store can't hang the whole health check
+const healthProbeTimeout = 2 * time.Second
+
+// Background workers health checks watch; a worker that misses two runs in a row is reported down
+const (
+	holdReaperWorker = "hold_reaper"
+	outboxWorker     = "outbox_dispatcher"
+)
+
+// heartbeats records when each background worker last finished a run. Workers tick on wall time, so
+// these do too, whatever clock the controller was given.
+type heartbeats struct {
+	mutex   sync.Mutex
+	started time.Time
+	last    map[string]time.Time
+}
+
+func newHeartbeats() *heartbeats {
+	return &heartbeats{started: time.Now(), last: make(map[string]time.Time)}
+}
+
+// beat records a run of worker
+func (h *heartbeats) beat(worker string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.last[worker] = time.Now()
+}
+
+// since is how long ago worker last ran, or how long ago workers started if it hasn't yet
+func (h *heartbeats) since(worker string) (time.Duration, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	last, ok := h.last[worker]
+	if !ok {
+		last = h.started
+	}
+	return time.Since(last), ok
+}
+
+// healthProbe checks one dependency; the error, if any, says why it is down
+type healthProbe struct {
+	name  string
+	check func(ctx context.Context) (models.HealthStatus, string, error)
+}
+
+// HealthCheck probes every dependency in parallel - the stores, the payment gateway's circuits, the event
+// bus and the background workers - and reports each one's status and how long its probe took
+func (ac *AppController) HealthCheck(ctx context.Context) *models.HealthReport {
+	probes := ac.healthProbes()
+	checks := make([]models.DependencyHealth, len(probes))
+
+	var wg sync.WaitGroup
+	for i, probe := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checks[i] = runProbe(ctx, probe)
+		}()
+	}
+	wg.Wait()
+
+	return models.NewHealthReport(checks, ac.clock.Now())
+}
+
+// runProbe runs one probe under healthProbeTimeout and times it
+func runProbe(ctx context.Context, probe healthProbe) models.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	status, detail, err := probe.check(ctx)
+	health := models.DependencyHealth{
+		Name:      probe.name,
+		Status:    status,
+		Detail:    detail,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		health.Status = models.HealthDown
+		health.Detail = err.Error()
+		if errors.Is(err, context.DeadlineExceeded) {
+			health.Detail = fmt.Sprintf("no answer within %s", healthProbeTimeout)
+		}
+	}
+	return health
+}
+
+// healthProbes lists what HealthCheck probes, backends first
+func (ac *AppController) healthProbes() []healthProbe {
+	var probes []healthProbe
+	if ac.redisClient != nil {
+		probes = append(probes, healthProbe{"redis", func(ctx context.Context) (models.HealthStatus, string, error) {
+			return models.HealthUp, ac.config.Redis.Addr, ac.redisClient.Ping(ctx).Err()
+		}})
+	}
+	if ac.sqlDB != nil {
+		probes = append(probes, healthProbe{"sqlite", func(ctx context.Context) (models.HealthStatus, string, error) {
+			return models.HealthUp, ac.config.SQLite.Path, ac.sqlDB.PingContext(ctx)
+		}})
+	}
+	if ac.fileLog != nil {
+		probes = append(probes, healthProbe{"filestore", func(ctx context.Context) (models.HealthStatus, string, error) {
+			return models.HealthUp, ac.config.File.Dir, ac.fileLog.Ping()
+		}})
+	}
+
+	persistence := "memory"
+	if ac.sqlDB != nil {
+		persistence = "sqlite"
+	} else if ac.fileLog != nil {
+		persistence = "file"
+	}
+	holdStore := persistence
+	if ac.redisClient != nil {
+		holdStore = "redis"
+	}
+
+	probes = append(probes,
+		repositoryProbe("users", persistence, ac.userRepo.GetByID),
+		repositoryProbe("movies", persistence, ac.movieRepo.GetByID),
+		repositoryProbe("events", persistence, ac.eventRepo.GetByID),
+		repositoryProbe("theatres", persistence, ac.theatreRepo.GetByID),
+		repositoryProbe("screens", persistence, ac.screenRepo.GetByID),
+		repositoryProbe("shows", persistence, ac.showRepo.GetByID),
+		repositoryProbe("seat_holds", holdStore, ac.holdRepo.GetByID),
+		repositoryProbe("bookings", persistence, ac.bookingRepo.GetByID),
+		repositoryProbe("payments", persistence, ac.paymentRepo.GetByID),
+		repositoryProbe("refunds", persistence, ac.refundRepo.GetByID),
+		repositoryProbe("tickets", persistence, ac.ticketRepo.GetByID),
+		repositoryProbe("notifications", persistence, ac.notifyRepo.GetByID),
+		healthProbe{"payment_gateway", ac.checkPaymentGateway},
+		healthProbe{"event_bus", ac.checkEventBus},
+		workerProbe(ac.heartbeats, holdReaperWorker, holdExpiryInterval),
+	)
+	return probes
+}
+
+// repositoryProbe looks up an ID no entity has; not finding it is the answer a reachable store gives.
+// backend names where the repository keeps its data.
+func repositoryProbe[T any](name, backend string, get func(ctx context.Context, id string) (T, error)) healthProbe {
+	return healthProbe{name, func(ctx context.Context) (models.HealthStatus, string, error) {
+		_, err := get(ctx, "health-probe")
+		if err != nil && models.AsDomainError(err).Kind != models.KindNotFound {
+			return models.HealthDown, backend, err
+		}
+		return models.HealthUp, backend, nil
+	}}
+}
+
+// checkPaymentGateway reports the gateway degraded while any payment method's circuit is open
+func (ac *AppController) checkPaymentGateway(ctx context.Context) (models.HealthStatus, string, error) {
+	gateway, ok := ac.paymentGateway.(*strategies.ResilientPaymentGateway)
+	if !ok {
+		return models.HealthUp, "no circuit breakers", nil
+	}
+	if open := gateway.OpenCircuits(); len(open) > 0 {
+		return models.HealthDegraded, fmt.Sprintf("circuits open: %v", open), nil
+	}
+	return models.HealthUp, "circuits closed", nil
+}
+
+// checkEventBus reports the outbox every event goes through: down when its store or dispatcher is, degraded
+// while events sit dead-lettered
+func (ac *AppController) checkEventBus(ctx context.Context) (models.HealthStatus, string, error) {
+	if status, detail, _ := workerProbe(ac.heartbeats, outboxWorker, outboxDispatchInterval).check(ctx); status == models.HealthDown {
+		return status, detail, nil
+	}
+
+	pending, err := ac.outboxRepo.GetByStatus(ctx, models.OutboxStatusPending)
+	if err != nil {
+		return models.HealthDown, "", err
+	}
+	deadLettered, err := ac.outboxRepo.GetByStatus(ctx, models.OutboxStatusDeadLettered)
+	if err != nil {
+		return models.HealthDown, "", err
+	}
+
+	detail := fmt.Sprintf("%d pending, %d dead-lettered", len(pending), len(deadLettered))
+	if len(deadLettered) > 0 {
+		return models.HealthDegraded, detail, nil
+	}
+	return models.HealthUp, detail, nil
+}
+
+// workerProbe reports a background worker down once it has gone two intervals without running. The hold
+// reaper is what frees the seats of checkouts abandoned before payment; unpaid bookings lapse on their own.
+func workerProbe(beats *heartbeats, worker string, interval time.Duration) healthProbe {
+	return healthProbe{worker, func(ctx context.Context) (models.HealthStatus, string, error) {
+		if beats == nil {
+			return models.HealthDown, "background workers are not running", nil
+		}
+		since, ran := beats.since(worker)
+		if since > 2*interval {
+			return models.HealthDown, fmt.Sprintf("no run for %s", since.Round(time.Second)), nil
+		}
+		if !ran {
+			return models.HealthUp, "waiting for its first run", nil
+		}
+		return models.HealthUp, fmt.Sprintf("last ran %s ago", since.Round(time.Millisecond)), nil
+	}}
+}
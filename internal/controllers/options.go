@@ -0,0 +1,248 @@
+package controllers
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/config"
+	"bookmyshow-lld/internal/gateways"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/pricing"
+	"bookmyshow-lld/internal/repositories"
+	"bookmyshow-lld/internal/services"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option overrides one dependency of a controller built by NewAppController - demonstrates Functional Options
+type Option func(*AppController)
+
+// WithConfig selects infrastructure backends (payment provider, Redis, logging)
+func WithConfig(config Config) Option {
+	return func(ac *AppController) { ac.config = config }
+}
+
+// WithSettings applies a loaded config file on top of the environment: where state is kept, the payment gateway,
+// the shutdown deadline, the default payment window and hold length, and seat type multipliers. Multipliers apply
+// to this controller's own seat types, so instances built with different settings price seats independently.
+func WithSettings(settings config.Config) Option {
+	return func(ac *AppController) {
+		ac.config.SQLite.Path, ac.config.File.Dir = "", ""
+		switch settings.Storage.Backend {
+		case config.BackendSQLite:
+			ac.config.SQLite.Path = settings.Storage.SQLitePath
+		case config.BackendFile:
+			ac.config.File.Dir = settings.Storage.FileDir
+		}
+		ac.config.Redis.Addr = settings.Storage.RedisAddr
+		if settings.Payment.Gateway != ac.config.Payment.Kind {
+			ac.config.Payment = gateways.ConfigFromEnvFor(settings.Payment.Gateway)
+		}
+		ac.config.Shutdown = time.Duration(settings.Server.ShutdownTimeout)
+		ac.config.Timeouts = settings.Timeouts()
+		ac.config.Seats = settings.Pricing.SeatMultipliers
+	}
+}
+
+// WithSQLite saves state to the database file at path and reloads it on start; an empty path keeps it in memory
+func WithSQLite(path string) Option {
+	return func(ac *AppController) { ac.config.SQLite.Path = path }
+}
+
+// WithFileStore logs state to the directory at dir and replays it on start; an empty dir keeps it in memory
+func WithFileStore(dir string) Option {
+	return func(ac *AppController) { ac.config.File.Dir = dir }
+}
+
+// WithClock drives booking expiry, hold TTLs and show cutoffs; pass a clock.FakeClock to fast-forward them.
+// Each instance keeps its own clock, so tests on different fake clocks can run in parallel.
+func WithClock(clk clock.Clock) Option {
+	return func(ac *AppController) { ac.clock = clk }
+}
+
+// WithLogger replaces the logger built from Config.Logging
+func WithLogger(logger logging.Logger) Option {
+	return func(ac *AppController) { ac.logger = logger }
+}
+
+// WithTracerProvider records the booking flow's spans with provider, e.g. one exporting over OTLP, instead of
+// the one Config.Tracing picks
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(ac *AppController) { ac.tracing = provider }
+}
+
+// WithPaymentGateway replaces the gateway chosen by Config.Payment, e.g. with a fake that always declines
+func WithPaymentGateway(gateway services.PaymentGateway) Option {
+	return func(ac *AppController) { ac.paymentGateway = gateway }
+}
+
+// WithPricingChain replaces the chain built from Config.Pricing, e.g. with one holding custom rules.
+// The injected chain doesn't consult the pricing calendar admins maintain; last-minute deals are still
+// registered on it when they are on.
+func WithPricingChain(chain *pricing.Chain) Option {
+	return func(ac *AppController) { ac.pricingChain = chain }
+}
+
+// WithBookingValidators replaces the default booking validator chain, e.g. with one holding custom checks
+func WithBookingValidators(chain *services.BookingValidatorChain) Option {
+	return func(ac *AppController) { ac.validators = chain }
+}
+
+// WithFraudPipeline replaces the default fraud rules, e.g. with a pipeline holding custom rules
+func WithFraudPipeline(pipeline *services.FraudPipeline) Option {
+	return func(ac *AppController) { ac.fraudChecks = pipeline }
+}
+
+// WithMovieScorers replaces the default recommendation scorers, e.g. to weigh popularity alone
+func WithMovieScorers(scorers ...services.WeightedScorer) Option {
+	return func(ac *AppController) { ac.movieScorers = scorers }
+}
+
+// WithMovieSource replaces the source chosen by Config.Catalog, e.g. with a fixed in-memory list
+func WithMovieSource(source services.MovieSource) Option {
+	return func(ac *AppController) { ac.movieSource = source }
+}
+
+// WithNotificationService replaces the logging notification service, e.g. with one that records what was sent
+func WithNotificationService(notificationSvc services.NotificationService) Option {
+	return func(ac *AppController) { ac.notificationSvc = notificationSvc }
+}
+
+// WithNotificationChannels replaces the logging email, SMS and push channels, e.g. with real providers' clients;
+// Config.Notify still decides which channels are used
+func WithNotificationChannels(channels ...services.NotificationChannel) Option {
+	return func(ac *AppController) { ac.notifyChannels = channels }
+}
+
+// WithWebhookClient replaces the HTTP client partner webhooks are sent with, e.g. with a fake partner
+func WithWebhookClient(client services.WebhookClient) Option {
+	return func(ac *AppController) { ac.webhookClient = client }
+}
+
+// WithLockManager replaces the per-show lock manager
+func WithLockManager(lockManager locks.LockManager) Option {
+	return func(ac *AppController) { ac.lockManager = lockManager }
+}
+
+// Repository overrides - anything not injected gets its in-memory (or Redis) default
+
+func WithUserRepository(repo repositories.UserRepository) Option {
+	return func(ac *AppController) { ac.userRepo = repo }
+}
+
+func WithMovieRepository(repo repositories.MovieRepository) Option {
+	return func(ac *AppController) { ac.movieRepo = repo }
+}
+
+func WithEventRepository(repo repositories.EventRepository) Option {
+	return func(ac *AppController) { ac.eventRepo = repo }
+}
+
+func WithTheatreRepository(repo repositories.TheatreRepository) Option {
+	return func(ac *AppController) { ac.theatreRepo = repo }
+}
+
+func WithScreenRepository(repo repositories.ScreenRepository) Option {
+	return func(ac *AppController) { ac.screenRepo = repo }
+}
+
+func WithShowRepository(repo repositories.ShowRepository) Option {
+	return func(ac *AppController) { ac.showRepo = repo }
+}
+
+func WithBookingRepository(repo repositories.BookingRepository) Option {
+	return func(ac *AppController) { ac.bookingRepo = repo }
+}
+
+func WithPaymentRepository(repo repositories.PaymentRepository) Option {
+	return func(ac *AppController) { ac.paymentRepo = repo }
+}
+
+func WithRefundRepository(repo repositories.RefundRepository) Option {
+	return func(ac *AppController) { ac.refundRepo = repo }
+}
+
+func WithCouponRepository(repo repositories.CouponRepository) Option {
+	return func(ac *AppController) { ac.couponRepo = repo }
+}
+
+func WithSeatHoldRepository(repo repositories.SeatHoldRepository) Option {
+	return func(ac *AppController) { ac.holdRepo = repo }
+}
+
+func WithTicketRepository(repo repositories.TicketRepository) Option {
+	return func(ac *AppController) { ac.ticketRepo = repo }
+}
+
+func WithReviewRepository(repo repositories.ReviewRepository) Option {
+	return func(ac *AppController) { ac.reviewRepo = repo }
+}
+
+func WithWalletRepository(repo repositories.WalletRepository) Option {
+	return func(ac *AppController) { ac.walletRepo = repo }
+}
+
+func WithLoyaltyRepository(repo repositories.LoyaltyRepository) Option {
+	return func(ac *AppController) { ac.loyaltyRepo = repo }
+}
+
+func WithCityRepository(repo repositories.CityRepository) Option {
+	return func(ac *AppController) { ac.cityRepo = repo }
+}
+
+func WithOutboxRepository(repo repositories.OutboxRepository) Option {
+	return func(ac *AppController) { ac.outboxRepo = repo }
+}
+
+func WithCredentialRepository(repo repositories.CredentialRepository) Option {
+	return func(ac *AppController) { ac.credRepo = repo }
+}
+
+func WithSessionRepository(repo repositories.SessionRepository) Option {
+	return func(ac *AppController) { ac.sessionRepo = repo }
+}
+
+func WithSettlementRepository(repo repositories.SettlementRepository) Option {
+	return func(ac *AppController) { ac.settleRepo = repo }
+}
+
+func WithAuditRepository(repo repositories.AuditRepository) Option {
+	return func(ac *AppController) { ac.auditRepo = repo }
+}
+
+func WithWebhookRepository(repo repositories.WebhookRepository) Option {
+	return func(ac *AppController) { ac.webhookRepo = repo }
+}
+
+func WithWebhookDeliveryRepository(repo repositories.WebhookDeliveryRepository) Option {
+	return func(ac *AppController) { ac.deliveryRepo = repo }
+}
+
+func WithWatchlistRepository(repo repositories.WatchlistRepository) Option {
+	return func(ac *AppController) { ac.watchlistRepo = repo }
+}
+
+func WithBulkBookingRepository(repo repositories.BulkBookingRepository) Option {
+	return func(ac *AppController) { ac.bulkRepo = repo }
+}
+
+func WithParkingReservationRepository(repo repositories.ParkingReservationRepository) Option {
+	return func(ac *AppController) { ac.parkingRepo = repo }
+}
+
+func WithResaleListingRepository(repo repositories.ResaleListingRepository) Option {
+	return func(ac *AppController) { ac.resaleRepo = repo }
+}
+
+func WithPaymentInstrumentRepository(repo repositories.PaymentInstrumentRepository) Option {
+	return func(ac *AppController) { ac.instrRepo = repo }
+}
+
+// orDefault keeps an injected dependency, building the default only when none was given
+func orDefault[T comparable](injected T, build func() T) T {
+	var zero T
+	if injected != zero {
+		return injected
+	}
+	return build()
+}
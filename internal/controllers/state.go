@@ -0,0 +1,258 @@
+package controllers
+
+import (
+	"bookmyshow-lld/internal/models"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// StateVersion is the snapshot format ExportState writes and ImportState accepts
+const StateVersion = 1
+
+// snapshot is every repository's contents, each list in a stable order so equal states export byte for byte equal
+type snapshot struct {
+	Version      int                          `json:"version"`
+	ExportedAt   time.Time                    `json:"exported_at"`
+	Users        []*models.User               `json:"users"`
+	Movies       []*models.Movie              `json:"movies"`
+	Events       []*models.Event              `json:"events"`
+	Theatres     []*models.Theatre            `json:"theatres"`
+	Cities       []*models.City               `json:"cities"`
+	Screens      []*models.Screen             `json:"screens"`
+	Shows        []*models.Show               `json:"shows"`
+	Bookings     []*models.Booking            `json:"bookings"`
+	Payments     []*models.Payment            `json:"payments"`
+	Refunds      []*models.Refund             `json:"refunds"`
+	Coupons      []*models.Coupon             `json:"coupons"`
+	SeatHolds    []*models.SeatHold           `json:"seat_holds"`
+	Tickets      []*models.Ticket             `json:"tickets"`
+	Reviews      []*models.Review             `json:"reviews"`
+	Wallets      []*models.Wallet             `json:"wallets"`
+	Transactions []*models.WalletTransaction  `json:"wallet_transactions"` // Grouped by wallet, each ledger oldest first
+	Loyalty      []*loyaltySnapshot           `json:"loyalty_accounts"`
+	Outbox       []*models.OutboxMessage      `json:"outbox_messages"` // Oldest first
+	Credentials  []*models.Credential         `json:"credentials"`
+	Sessions     []*models.Session            `json:"sessions"`
+	Settlements  []*models.Settlement         `json:"settlements"`
+	Audit        []*models.AuditEntry         `json:"audit_entries"` // Oldest first
+	Webhooks     []*models.Webhook            `json:"webhooks"`
+	Deliveries   []*models.WebhookDelivery    `json:"webhook_deliveries"`
+	Watchlist    []*models.WatchlistEntry     `json:"watchlist_entries"`
+	BulkBookings []*models.BulkBooking        `json:"bulk_bookings"`
+	Parking      []*models.ParkingReservation `json:"parking_reservations"`
+	Resale       []*models.ResaleListing      `json:"resale_listings"`
+	Instruments  []*models.PaymentInstrument  `json:"payment_instruments"`
+}
+
+// loyaltySnapshot is a loyalty account plus its per-booking ledger, which the account keeps unexported
+type loyaltySnapshot struct {
+	Account  *models.LoyaltyAccount `json:"account"`
+	Earned   map[string]int64       `json:"earned"`
+	Redeemed map[string]int64       `json:"redeemed"`
+}
+
+// records counts everything in the snapshot
+func (s *snapshot) records() int {
+	return len(s.Users) + len(s.Movies) + len(s.Events) + len(s.Theatres) + len(s.Cities) + len(s.Screens) +
+		len(s.Shows) + len(s.Bookings) + len(s.Payments) + len(s.Refunds) + len(s.Coupons) + len(s.SeatHolds) +
+		len(s.Tickets) + len(s.Reviews) + len(s.Wallets) + len(s.Transactions) + len(s.Loyalty) + len(s.Outbox) +
+		len(s.Credentials) + len(s.Sessions) + len(s.Settlements) + len(s.Audit) + len(s.Webhooks) +
+		len(s.Deliveries) + len(s.Watchlist) + len(s.BulkBookings) + len(s.Parking) + len(s.Resale) +
+		len(s.Instruments)
+}
+
+// ExportState writes every repository's contents as one JSON document, so a demo session can be saved, shared
+// and replayed with ImportState without a database. Locks, caches and live seat streams are rebuilt rather than
+// saved. Neither are the ticket signing and payment vault keys: QR codes issued and cards saved before the
+// export only work on an app with the same keys, e.g. one opened on the same SQLite file.
+func (ac *AppController) ExportState(w io.Writer) error {
+	state, err := ac.snapshot(context.Background())
+	if err != nil {
+		return fmt.Errorf("exporting state: %w", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(state); err != nil {
+		return fmt.Errorf("exporting state: %w", err)
+	}
+	return nil
+}
+
+// ImportState loads a document written by ExportState into an app that holds no data yet. Everything goes
+// through the repositories, so an app opened with WithSQLite saves the import to its database as well.
+func (ac *AppController) ImportState(r io.Reader) error {
+	ctx := context.Background()
+
+	var state snapshot
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return fmt.Errorf("importing state: %w", err)
+	}
+	if state.Version != StateVersion {
+		return fmt.Errorf("importing state: %w: %d, want %d", models.ErrUnsupportedSnapshot, state.Version, StateVersion)
+	}
+
+	current, err := ac.snapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("importing state: %w", err)
+	}
+	if current.records() > 0 {
+		return models.ErrStateNotEmpty
+	}
+
+	// Wallets before their transactions, the same order the SQLite store restores in
+	restores := []func() error{
+		func() error { return restoreAll(ctx, state.Users, ac.userRepo.Create) },
+		func() error { return restoreAll(ctx, state.Movies, ac.movieRepo.Create) },
+		func() error { return restoreAll(ctx, state.Events, ac.eventRepo.Create) },
+		func() error { return restoreAll(ctx, state.Theatres, ac.theatreRepo.Create) },
+		func() error { return restoreAll(ctx, state.Cities, ac.cityRepo.Create) },
+		func() error { return restoreAll(ctx, state.Screens, ac.screenRepo.Create) },
+		func() error { return restoreAll(ctx, state.Shows, ac.showRepo.Create) },
+		func() error { return restoreAll(ctx, state.Bookings, ac.bookingRepo.Create) },
+		func() error { return restoreAll(ctx, state.Payments, ac.paymentRepo.Create) },
+		func() error { return restoreAll(ctx, state.Refunds, ac.refundRepo.Create) },
+		func() error { return restoreAll(ctx, state.Coupons, ac.couponRepo.Create) },
+		func() error { return restoreAll(ctx, state.SeatHolds, ac.holdRepo.Create) },
+		func() error { return restoreAll(ctx, state.Tickets, ac.ticketRepo.Create) },
+		func() error { return restoreAll(ctx, state.Reviews, ac.reviewRepo.Create) },
+		func() error { return restoreAll(ctx, state.Wallets, ac.walletRepo.Create) },
+		func() error { return restoreAll(ctx, state.Transactions, ac.walletRepo.AddTransaction) },
+		func() error { return restoreAll(ctx, state.Loyalty, ac.restoreLoyaltyAccount) },
+		func() error { return restoreAll(ctx, state.Outbox, ac.outboxRepo.Create) },
+		func() error { return restoreAll(ctx, state.Credentials, ac.credRepo.Save) },
+		func() error { return restoreAll(ctx, state.Sessions, ac.sessionRepo.Create) },
+		func() error { return restoreAll(ctx, state.Settlements, ac.settleRepo.Create) },
+		func() error { return restoreAll(ctx, state.Audit, ac.auditRepo.Create) },
+		func() error { return restoreAll(ctx, state.Webhooks, ac.webhookRepo.Create) },
+		func() error { return restoreAll(ctx, state.Deliveries, ac.deliveryRepo.Create) },
+		func() error { return restoreAll(ctx, state.Watchlist, ac.watchlistRepo.Create) },
+		func() error { return restoreAll(ctx, state.BulkBookings, ac.bulkRepo.Create) },
+		func() error { return restoreAll(ctx, state.Parking, ac.parkingRepo.Create) },
+		func() error { return restoreAll(ctx, state.Resale, ac.resaleRepo.Create) },
+		func() error { return restoreAll(ctx, state.Instruments, ac.instrRepo.Create) },
+	}
+	for _, restore := range restores {
+		if err := restore(); err != nil {
+			return fmt.Errorf("importing state: %w", err)
+		}
+	}
+
+	if err := ac.listings.Refresh(ctx); err != nil {
+		return fmt.Errorf("importing state: %w", err)
+	}
+	ac.logger.Info(ctx, "state imported", "records", state.records(), "exported_at", state.ExportedAt)
+	return nil
+}
+
+// snapshot reads every repository
+func (ac *AppController) snapshot(ctx context.Context) (*snapshot, error) {
+	state := &snapshot{Version: StateVersion, ExportedAt: ac.clock.Now()}
+	var err error
+
+	reads := []func() error{
+		func() error { state.Users, err = ac.userRepo.List(ctx); return err },
+		func() error { state.Movies, err = ac.movieRepo.List(ctx); return err },
+		func() error { state.Events, err = ac.eventRepo.List(ctx); return err },
+		func() error { state.Theatres, err = ac.theatreRepo.GetAll(ctx); return err },
+		func() error { state.Cities, err = ac.cityRepo.GetAll(ctx); return err },
+		func() error { state.Screens, err = ac.screenRepo.List(ctx); return err },
+		func() error { state.Shows, err = ac.showRepo.List(ctx); return err },
+		func() error { state.Bookings, err = ac.bookingRepo.List(ctx); return err },
+		func() error { state.Payments, err = ac.paymentRepo.List(ctx); return err },
+		func() error { state.Refunds, err = ac.refundRepo.List(ctx); return err },
+		func() error { state.Coupons, err = ac.couponRepo.List(ctx); return err },
+		func() error { state.SeatHolds, err = ac.holdRepo.List(ctx); return err },
+		func() error { state.Tickets, err = ac.ticketRepo.List(ctx); return err },
+		func() error { state.Reviews, err = ac.reviewRepo.List(ctx); return err },
+		func() error { state.Wallets, err = ac.walletRepo.List(ctx); return err },
+		func() error { state.Transactions, err = ac.walletRepo.ListTransactions(ctx); return err },
+		func() error { state.Outbox, err = ac.outboxRepo.List(ctx); return err },
+		func() error { state.Credentials, err = ac.credRepo.List(ctx); return err },
+		func() error { state.Sessions, err = ac.sessionRepo.List(ctx); return err },
+		func() error { state.Settlements, err = ac.settleRepo.List(ctx); return err },
+		func() error { state.Audit, err = ac.auditRepo.List(ctx); return err },
+		func() error { state.Webhooks, err = ac.webhookRepo.List(ctx); return err },
+		func() error { state.Deliveries, err = ac.deliveryRepo.List(ctx); return err },
+		func() error { state.Watchlist, err = ac.watchlistRepo.List(ctx); return err },
+		func() error { state.BulkBookings, err = ac.bulkRepo.List(ctx); return err },
+		func() error { state.Parking, err = ac.parkingRepo.List(ctx); return err },
+		func() error { state.Resale, err = ac.resaleRepo.List(ctx); return err },
+		func() error { state.Instruments, err = ac.instrRepo.List(ctx); return err },
+	}
+	for _, read := range reads {
+		if err := read(); err != nil {
+			return nil, err
+		}
+	}
+
+	accounts, err := ac.loyaltyRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		earned, redeemed := account.Ledger()
+		state.Loyalty = append(state.Loyalty, &loyaltySnapshot{Account: account, Earned: earned, Redeemed: redeemed})
+	}
+
+	sortByID(state.Users)
+	sortByID(state.Movies)
+	sortByID(state.Events)
+	sortByID(state.Theatres)
+	sortByID(state.Cities)
+	sortByID(state.Screens)
+	sortByID(state.Shows)
+	sortByID(state.Bookings)
+	sortByID(state.Payments)
+	sortByID(state.Refunds)
+	sortByID(state.SeatHolds)
+	sortByID(state.Tickets)
+	sortByID(state.Reviews)
+	sortByID(state.Sessions)
+	sortByID(state.Settlements)
+	sortByID(state.Webhooks)
+	sortByID(state.Deliveries)
+	sortByID(state.Watchlist)
+	sortByID(state.BulkBookings)
+	sortByID(state.Parking)
+	sortByID(state.Resale)
+	sortByID(state.Instruments)
+	slices.SortFunc(state.Coupons, func(a, b *models.Coupon) int { return cmp.Compare(a.Code, b.Code) })
+	slices.SortFunc(state.Wallets, func(a, b *models.Wallet) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortStableFunc(state.Transactions, func(a, b *models.WalletTransaction) int { return cmp.Compare(a.WalletID, b.WalletID) })
+	slices.SortFunc(state.Loyalty, func(a, b *loyaltySnapshot) int { return cmp.Compare(a.Account.UserID, b.Account.UserID) })
+	slices.SortFunc(state.Credentials, func(a, b *models.Credential) int { return cmp.Compare(a.UserID, b.UserID) })
+	slices.SortFunc(state.Outbox, func(a, b *models.OutboxMessage) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	slices.SortFunc(state.Audit, func(a, b *models.AuditEntry) int {
+		return cmp.Or(a.At.Compare(b.At), cmp.Compare(a.ID, b.ID))
+	})
+	return state, nil
+}
+
+// restoreLoyaltyAccount puts an exported account and its ledger back
+func (ac *AppController) restoreLoyaltyAccount(ctx context.Context, record *loyaltySnapshot) error {
+	record.Account.RestoreLedger(record.Earned, record.Redeemed)
+	return ac.loyaltyRepo.Create(ctx, record.Account)
+}
+
+// restoreAll creates every entity in order, stopping at the first failure
+func restoreAll[T any](ctx context.Context, entities []T, create func(context.Context, T) error) error {
+	for _, entity := range entities {
+		if err := create(ctx, entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortByID orders entities by ID
+func sortByID[T interface{ GetID() string }](entities []T) {
+	slices.SortFunc(entities, func(a, b T) int { return cmp.Compare(a.GetID(), b.GetID()) })
+}
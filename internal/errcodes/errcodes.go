@@ -0,0 +1,36 @@
+// Package errcodes maps domain errors onto the status codes of the transports that serve them, so every
+// API layer answers the same failure the same way
+package errcodes
+
+import (
+	"bookmyshow-lld/internal/models"
+	"net/http"
+)
+
+// httpStatuses is the HTTP status of each kind of domain error
+var httpStatuses = map[models.ErrorKind]int{
+	models.KindInvalid:         http.StatusBadRequest,
+	models.KindNotFound:        http.StatusNotFound,
+	models.KindAlreadyExists:   http.StatusConflict,
+	models.KindConflict:        http.StatusConflict,
+	models.KindAborted:         http.StatusConflict,
+	models.KindGone:            http.StatusGone,
+	models.KindPaymentRequired: http.StatusPaymentRequired,
+	models.KindUnauthenticated: http.StatusUnauthorized,
+	models.KindForbidden:       http.StatusForbidden,
+	models.KindRateLimited:     http.StatusTooManyRequests,
+	models.KindUpstream:        http.StatusBadGateway,
+	models.KindUnavailable:     http.StatusServiceUnavailable,
+	models.KindInternal:        http.StatusInternalServerError,
+}
+
+// HTTPStatus is the HTTP status to answer err with; 200 for nil and 500 for errors that aren't domain errors
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	if status, ok := httpStatuses[models.AsDomainError(err).Kind]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,70 @@
+package errcodes
+
+import "bookmyshow-lld/internal/models"
+
+// Code is a gRPC status code. The values are those of google.golang.org/grpc/codes, so a gRPC server
+// converts with codes.Code(c) without this package depending on gRPC.
+type Code uint32
+
+const (
+	OK                 Code = 0
+	Canceled           Code = 1
+	Unknown            Code = 2
+	InvalidArgument    Code = 3
+	DeadlineExceeded   Code = 4
+	NotFound           Code = 5
+	AlreadyExists      Code = 6
+	PermissionDenied   Code = 7
+	ResourceExhausted  Code = 8
+	FailedPrecondition Code = 9
+	Aborted            Code = 10
+	OutOfRange         Code = 11
+	Unimplemented      Code = 12
+	Internal           Code = 13
+	Unavailable        Code = 14
+	DataLoss           Code = 15
+	Unauthenticated    Code = 16
+)
+
+// grpcCodes is the gRPC code of each kind of domain error. gRPC has no Gone or Payment Required, so
+// expired things and declined payments are failed preconditions.
+var grpcCodes = map[models.ErrorKind]Code{
+	models.KindInvalid:         InvalidArgument,
+	models.KindNotFound:        NotFound,
+	models.KindAlreadyExists:   AlreadyExists,
+	models.KindConflict:        FailedPrecondition,
+	models.KindAborted:         Aborted,
+	models.KindGone:            FailedPrecondition,
+	models.KindPaymentRequired: FailedPrecondition,
+	models.KindUnauthenticated: Unauthenticated,
+	models.KindForbidden:       PermissionDenied,
+	models.KindRateLimited:     ResourceExhausted,
+	models.KindUpstream:        Unavailable,
+	models.KindUnavailable:     Unavailable,
+	models.KindInternal:        Internal,
+}
+
+// GRPCCode is the gRPC code to answer err with; OK for nil and Internal for errors that aren't domain errors
+func GRPCCode(err error) Code {
+	if err == nil {
+		return OK
+	}
+	if code, ok := grpcCodes[models.AsDomainError(err).Kind]; ok {
+		return code
+	}
+	return Internal
+}
+
+var codeNames = [...]string{
+	"OK", "CANCELLED", "UNKNOWN", "INVALID_ARGUMENT", "DEADLINE_EXCEEDED", "NOT_FOUND", "ALREADY_EXISTS",
+	"PERMISSION_DENIED", "RESOURCE_EXHAUSTED", "FAILED_PRECONDITION", "ABORTED", "OUT_OF_RANGE",
+	"UNIMPLEMENTED", "INTERNAL", "UNAVAILABLE", "DATA_LOSS", "UNAUTHENTICATED",
+}
+
+// String is the code's canonical name, e.g. NOT_FOUND
+func (c Code) String() string {
+	if int(c) < len(codeNames) {
+		return codeNames[c]
+	}
+	return "UNKNOWN"
+}
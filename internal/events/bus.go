@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+)
+
+// Handler reacts to a published event - demonstrates Observer Pattern
+type Handler func(ctx context.Context, event Event) error
+
+// EventBus decouples publishers of domain events from their subscribers
+type EventBus interface {
+	Subscribe(eventType EventType, handler Handler)
+	SubscribeAll(handler Handler)
+	Publish(ctx context.Context, event Event) error
+}
+
+// InMemoryEventBus delivers events synchronously to in-process subscribers
+type InMemoryEventBus struct {
+	handlers    map[EventType][]Handler
+	allHandlers []Handler
+	mutex       sync.RWMutex
+}
+
+// NewInMemoryEventBus creates a new in-memory event bus
+func NewInMemoryEventBus() *InMemoryEventBus {
+	return &InMemoryEventBus{
+		handlers: make(map[EventType][]Handler),
+	}
+}
+
+// Subscribe registers a handler for a single event type
+func (b *InMemoryEventBus) Subscribe(eventType EventType, handler Handler) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// SubscribeAll registers a handler that receives every event
+func (b *InMemoryEventBus) SubscribeAll(handler Handler) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.allHandlers = append(b.allHandlers, handler)
+}
+
+// Publish notifies every subscriber; one failing subscriber doesn't stop the others
+func (b *InMemoryEventBus) Publish(ctx context.Context, event Event) error {
+	b.mutex.RLock()
+	handlers := make([]Handler, 0, len(b.handlers[event.Type()])+len(b.allHandlers))
+	handlers = append(handlers, b.handlers[event.Type()]...)
+	handlers = append(handlers, b.allHandlers...)
+	b.mutex.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewLoggingSubscriber returns a handler that logs every event - useful as an audit trail
+func NewLoggingSubscriber() Handler {
+	return func(ctx context.Context, event Event) error {
+		log.Printf("📢 EVENT: %s", event.Type())
+		return nil
+	}
+}
@@ -0,0 +1,390 @@
+package events
+
+import (
+	"bookmyshow-lld/internal/models"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventType identifies a kind of domain event
+type EventType string
+
+const (
+	EventBookingCreated          EventType = "BOOKING_CREATED"
+	EventBookingConfirmed        EventType = "BOOKING_CONFIRMED"
+	EventBookingCancelled        EventType = "BOOKING_CANCELLED"
+	EventBookingModified         EventType = "BOOKING_MODIFIED"
+	EventBookingExpired          EventType = "BOOKING_EXPIRED"
+	EventBookingTransferOffered  EventType = "BOOKING_TRANSFER_OFFERED"
+	EventBookingTransferred      EventType = "BOOKING_TRANSFERRED"
+	EventBookingTransferDeclined EventType = "BOOKING_TRANSFER_DECLINED"
+	EventBookingResold           EventType = "BOOKING_RESOLD"
+	EventBookingHoldExtended     EventType = "BOOKING_HOLD_EXTENDED"
+	EventHoldExtensionRefused    EventType = "HOLD_EXTENSION_REFUSED"
+	EventSeatHoldCreated         EventType = "SEAT_HOLD_CREATED"
+	EventSeatHoldReleased        EventType = "SEAT_HOLD_RELEASED"
+	EventPaymentFailed           EventType = "PAYMENT_FAILED"
+	EventPaymentReconciled       EventType = "PAYMENT_RECONCILED"
+	EventPaymentRejected         EventType = "PAYMENT_REJECTED"
+	EventRefundProcessed         EventType = "REFUND_PROCESSED"
+	EventShowCancelled           EventType = "SHOW_CANCELLED"
+	EventShowRescheduled         EventType = "SHOW_RESCHEDULED"
+	EventShowSoldOut             EventType = "SHOW_SOLD_OUT"
+	EventShowCompleted           EventType = "SHOW_COMPLETED"
+	EventHouseSeatsChanged       EventType = "HOUSE_SEATS_CHANGED"
+	EventDealCreated             EventType = "DEAL_CREATED"
+)
+
+// Event is implemented by every domain event published on the bus
+type Event interface {
+	Type() EventType
+	OccurredAt() time.Time
+}
+
+// BookingCreated is published when seats are blocked for a new pending booking
+type BookingCreated struct {
+	BookingID   string       `json:"booking_id"`
+	UserID      string       `json:"user_id"`
+	ShowID      string       `json:"show_id"`
+	SeatIDs     []string     `json:"seat_ids"`
+	TotalAmount models.Money `json:"total_amount"`
+	Timestamp   time.Time    `json:"timestamp"`
+}
+
+func (e BookingCreated) Type() EventType       { return EventBookingCreated }
+func (e BookingCreated) OccurredAt() time.Time { return e.Timestamp }
+
+// BookingConfirmed is published when a booking is paid for and its seats are booked
+type BookingConfirmed struct {
+	BookingID string    `json:"booking_id"`
+	UserID    string    `json:"user_id"`
+	ShowID    string    `json:"show_id"`
+	SeatIDs   []string  `json:"seat_ids"`
+	Reference string    `json:"reference"`
+	PaymentID string    `json:"payment_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e BookingConfirmed) Type() EventType       { return EventBookingConfirmed }
+func (e BookingConfirmed) OccurredAt() time.Time { return e.Timestamp }
+
+// BookingCancelled is published when a booking is cancelled and its seats released
+type BookingCancelled struct {
+	BookingID string    `json:"booking_id"`
+	UserID    string    `json:"user_id"`
+	ShowID    string    `json:"show_id"`
+	SeatIDs   []string  `json:"seat_ids"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e BookingCancelled) Type() EventType       { return EventBookingCancelled }
+func (e BookingCancelled) OccurredAt() time.Time { return e.Timestamp }
+
+// BookingModified is published when a booking moves to different seats
+type BookingModified struct {
+	BookingID       string               `json:"booking_id"`
+	UserID          string               `json:"user_id"`
+	ShowID          string               `json:"show_id"`
+	OldSeatIDs      []string             `json:"old_seat_ids"`
+	NewSeatIDs      []string             `json:"new_seat_ids"`
+	Status          models.BookingStatus `json:"status"` // Confirmed bookings book the new seats; pending ones block them
+	PriceDifference models.Money         `json:"price_difference"`
+	Timestamp       time.Time            `json:"timestamp"`
+}
+
+func (e BookingModified) Type() EventType       { return EventBookingModified }
+func (e BookingModified) OccurredAt() time.Time { return e.Timestamp }
+
+// BookingExpired is published when a pending booking is found past its payment window
+type BookingExpired struct {
+	BookingID string    `json:"booking_id"`
+	UserID    string    `json:"user_id"`
+	ShowID    string    `json:"show_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e BookingExpired) Type() EventType       { return EventBookingExpired }
+func (e BookingExpired) OccurredAt() time.Time { return e.Timestamp }
+
+// BookingTransferOffered is published when a booking's owner offers it to another user
+type BookingTransferOffered struct {
+	BookingID  string    `json:"booking_id"`
+	Reference  string    `json:"reference"`
+	ShowID     string    `json:"show_id"`
+	FromUserID string    `json:"from_user_id"`
+	ToUserID   string    `json:"to_user_id"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func (e BookingTransferOffered) Type() EventType       { return EventBookingTransferOffered }
+func (e BookingTransferOffered) OccurredAt() time.Time { return e.Timestamp }
+
+// BookingTransferred is published when the recipient accepts a booking and it becomes theirs
+type BookingTransferred struct {
+	BookingID  string    `json:"booking_id"`
+	Reference  string    `json:"reference"`
+	ShowID     string    `json:"show_id"`
+	FromUserID string    `json:"from_user_id"`
+	ToUserID   string    `json:"to_user_id"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func (e BookingTransferred) Type() EventType       { return EventBookingTransferred }
+func (e BookingTransferred) OccurredAt() time.Time { return e.Timestamp }
+
+// BookingTransferDeclined is published when the recipient turns a booking down; it stays with its owner
+type BookingTransferDeclined struct {
+	BookingID  string    `json:"booking_id"`
+	Reference  string    `json:"reference"`
+	ShowID     string    `json:"show_id"`
+	FromUserID string    `json:"from_user_id"`
+	ToUserID   string    `json:"to_user_id"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func (e BookingTransferDeclined) Type() EventType       { return EventBookingTransferDeclined }
+func (e BookingTransferDeclined) OccurredAt() time.Time { return e.Timestamp }
+
+// BookingResold is published when a buyer pays for a booking listed for resale and it becomes theirs
+type BookingResold struct {
+	BookingID    string       `json:"booking_id"`
+	Reference    string       `json:"reference"`
+	ShowID       string       `json:"show_id"`
+	ListingID    string       `json:"listing_id"`
+	SellerID     string       `json:"seller_id"`
+	BuyerID      string       `json:"buyer_id"`
+	Price        models.Money `json:"price"`         // Paid by the buyer
+	SellerPayout models.Money `json:"seller_payout"` // Refunded to the seller
+	Timestamp    time.Time    `json:"timestamp"`
+}
+
+func (e BookingResold) Type() EventType       { return EventBookingResold }
+func (e BookingResold) OccurredAt() time.Time { return e.Timestamp }
+
+// BookingHoldExtended is published when the owner of a pending booking buys more time to pay
+type BookingHoldExtended struct {
+	BookingID string    `json:"booking_id"`
+	UserID    string    `json:"user_id"`
+	ShowID    string    `json:"show_id"`
+	ExpiresAt time.Time `json:"expires_at"` // The payment window's new close
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e BookingHoldExtended) Type() EventType       { return EventBookingHoldExtended }
+func (e BookingHoldExtended) OccurredAt() time.Time { return e.Timestamp }
+
+// HoldExtensionRefused is published when a user has run out of payment window extensions, so repeated
+// attempts to sit on seats show up
+type HoldExtensionRefused struct {
+	BookingID string    `json:"booking_id"`
+	UserID    string    `json:"user_id"`
+	ShowID    string    `json:"show_id"`
+	Reason    string    `json:"reason"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e HoldExtensionRefused) Type() EventType       { return EventHoldExtensionRefused }
+func (e HoldExtensionRefused) OccurredAt() time.Time { return e.Timestamp }
+
+// SeatHoldCreated is published when seats are blocked for a user's hold
+type SeatHoldCreated struct {
+	HoldID    string    `json:"hold_id"`
+	UserID    string    `json:"user_id"`
+	ShowID    string    `json:"show_id"`
+	SeatIDs   []string  `json:"seat_ids"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e SeatHoldCreated) Type() EventType       { return EventSeatHoldCreated }
+func (e SeatHoldCreated) OccurredAt() time.Time { return e.Timestamp }
+
+// SeatHoldReleased is published when a hold gives its seats back - released, expired or its show cancelled.
+// Holds consumed by a booking are not released; the booking takes the seats over.
+type SeatHoldReleased struct {
+	HoldID    string    `json:"hold_id"`
+	UserID    string    `json:"user_id"`
+	ShowID    string    `json:"show_id"`
+	SeatIDs   []string  `json:"seat_ids"`
+	Reason    string    `json:"reason"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e SeatHoldReleased) Type() EventType       { return EventSeatHoldReleased }
+func (e SeatHoldReleased) OccurredAt() time.Time { return e.Timestamp }
+
+// PaymentFailed is published when the gateway rejects a payment
+type PaymentFailed struct {
+	PaymentID string    `json:"payment_id"`
+	BookingID string    `json:"booking_id"`
+	Reference string    `json:"reference"` // Booking reference
+	UserID    string    `json:"user_id"`
+	Method    string    `json:"method"`
+	Reason    string    `json:"reason"`
+	Attempt   int       `json:"attempt,omitempty"` // Zero for supplementary charges
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e PaymentFailed) Type() EventType       { return EventPaymentFailed }
+func (e PaymentFailed) OccurredAt() time.Time { return e.Timestamp }
+
+// PaymentReconciled is published when a payment the gateway never answered for is settled by asking again, or
+// when a gateway callback settles or refunds a payment, with what was done about its booking
+type PaymentReconciled struct {
+	PaymentID  string    `json:"payment_id"`
+	BookingID  string    `json:"booking_id"`
+	UserID     string    `json:"user_id"`
+	Status     string    `json:"status"`     // The payment's settled status
+	Resolution string    `json:"resolution"` // e.g. BOOKING_CONFIRMED or BOOKING_RELEASED
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func (e PaymentReconciled) Type() EventType       { return EventPaymentReconciled }
+func (e PaymentReconciled) OccurredAt() time.Time { return e.Timestamp }
+
+// PaymentRejected is published when fraud checks refuse to charge a payment
+type PaymentRejected struct {
+	PaymentID string    `json:"payment_id"`
+	BookingID string    `json:"booking_id"`
+	UserID    string    `json:"user_id"`
+	Score     int       `json:"score"`
+	Reasons   string    `json:"reasons"`           // Why the rules flagged it
+	Attempt   int       `json:"attempt,omitempty"` // Zero for supplementary charges
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e PaymentRejected) Type() EventType       { return EventPaymentRejected }
+func (e PaymentRejected) OccurredAt() time.Time { return e.Timestamp }
+
+// RefundProcessed is published when money is returned for a payment
+type RefundProcessed struct {
+	RefundID  string       `json:"refund_id"`
+	PaymentID string       `json:"payment_id"`
+	BookingID string       `json:"booking_id"`
+	UserID    string       `json:"user_id"`
+	Amount    models.Money `json:"amount"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+func (e RefundProcessed) Type() EventType       { return EventRefundProcessed }
+func (e RefundProcessed) OccurredAt() time.Time { return e.Timestamp }
+
+// ShowCancelled is published when a show is called off
+type ShowCancelled struct {
+	ShowID     string    `json:"show_id"`
+	MovieID    string    `json:"movie_id,omitempty"`
+	EventID    string    `json:"event_id,omitempty"` // Set for live events
+	TheatreID  string    `json:"theatre_id"`
+	Reason     string    `json:"reason"`
+	BookingIDs []string  `json:"booking_ids"` // Bookings cancelled with the show
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func (e ShowCancelled) Type() EventType       { return EventShowCancelled }
+func (e ShowCancelled) OccurredAt() time.Time { return e.Timestamp }
+
+// ShowRescheduled is published when a show moves to a new start time
+type ShowRescheduled struct {
+	ShowID        string    `json:"show_id"`
+	PreviousStart time.Time `json:"previous_start"`
+	StartTime     time.Time `json:"start_time"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+func (e ShowRescheduled) Type() EventType       { return EventShowRescheduled }
+func (e ShowRescheduled) OccurredAt() time.Time { return e.Timestamp }
+
+// ShowSoldOut is published when a confirmed booking takes the last seat of a show
+type ShowSoldOut struct {
+	ShowID    string    `json:"show_id"`
+	TheatreID string    `json:"theatre_id"`
+	Seats     int       `json:"seats"` // Seats on the screen, all of them booked
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e ShowSoldOut) Type() EventType       { return EventShowSoldOut }
+func (e ShowSoldOut) OccurredAt() time.Time { return e.Timestamp }
+
+// ShowCompleted is published when a show that has ended is closed out, with its final attendance and takings
+type ShowCompleted struct {
+	ShowID    string             `json:"show_id"`
+	TheatreID string             `json:"theatre_id"`
+	Summary   models.ShowSummary `json:"summary"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+func (e ShowCompleted) Type() EventType       { return EventShowCompleted }
+func (e ShowCompleted) OccurredAt() time.Time { return e.Timestamp }
+
+// HouseSeatsChanged is published when a theatre holds seats of a show back from sale or puts them back
+type HouseSeatsChanged struct {
+	ShowID    string            `json:"show_id"`
+	TheatreID string            `json:"theatre_id"`
+	SeatIDs   []string          `json:"seat_ids"`
+	Status    models.SeatStatus `json:"status"` // HOUSE or BLOCKED_ADMIN when held back, AVAILABLE when released
+	Reason    string            `json:"reason,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+func (e HouseSeatsChanged) Type() EventType       { return EventHouseSeatsChanged }
+func (e HouseSeatsChanged) OccurredAt() time.Time { return e.Timestamp }
+
+// DealCreated is published when a show about to start with plenty of seats left is discounted
+type DealCreated struct {
+	DealID    string    `json:"deal_id"`
+	ShowID    string    `json:"show_id"`
+	TheatreID string    `json:"theatre_id"`
+	City      string    `json:"city"`
+	Percent   float64   `json:"percent"`
+	Occupancy float64   `json:"occupancy"` // Percent of the seats on sale taken when the deal was made
+	EndsAt    time.Time `json:"ends_at"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e DealCreated) Type() EventType       { return EventDealCreated }
+func (e DealCreated) OccurredAt() time.Time { return e.Timestamp }
+
+// decoders rebuild each event type from its JSON - used to replay events stored in the outbox
+var decoders = map[EventType]func(payload []byte) (Event, error){
+	EventBookingCreated:          decode[BookingCreated],
+	EventBookingConfirmed:        decode[BookingConfirmed],
+	EventBookingCancelled:        decode[BookingCancelled],
+	EventBookingModified:         decode[BookingModified],
+	EventBookingExpired:          decode[BookingExpired],
+	EventBookingTransferOffered:  decode[BookingTransferOffered],
+	EventBookingTransferred:      decode[BookingTransferred],
+	EventBookingTransferDeclined: decode[BookingTransferDeclined],
+	EventBookingResold:           decode[BookingResold],
+	EventBookingHoldExtended:     decode[BookingHoldExtended],
+	EventHoldExtensionRefused:    decode[HoldExtensionRefused],
+	EventSeatHoldCreated:         decode[SeatHoldCreated],
+	EventSeatHoldReleased:        decode[SeatHoldReleased],
+	EventPaymentFailed:           decode[PaymentFailed],
+	EventPaymentReconciled:       decode[PaymentReconciled],
+	EventPaymentRejected:         decode[PaymentRejected],
+	EventRefundProcessed:         decode[RefundProcessed],
+	EventShowCancelled:           decode[ShowCancelled],
+	EventShowRescheduled:         decode[ShowRescheduled],
+	EventShowSoldOut:             decode[ShowSoldOut],
+	EventShowCompleted:           decode[ShowCompleted],
+	EventHouseSeatsChanged:       decode[HouseSeatsChanged],
+	EventDealCreated:             decode[DealCreated],
+}
+
+// Decode rebuilds an event from its type and JSON payload
+func Decode(eventType EventType, payload []byte) (Event, error) {
+	decoder, exists := decoders[eventType]
+	if !exists {
+		return nil, fmt.Errorf("unknown event type %q", eventType)
+	}
+	return decoder(payload)
+}
+
+// decode unmarshals a payload into a concrete event type
+func decode[T Event](payload []byte) (Event, error) {
+	var event T
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decoding %T: %w", event, err)
+	}
+	return event, nil
+}
@@ -0,0 +1,144 @@
+package factories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ScreenTemplate is a named screen layout that new screens can be created from
+type ScreenTemplate struct {
+	Name        string       `json:"name"` // e.g. IMAX
+	Description string       `json:"description"`
+	Layout      ScreenConfig `json:"layout"`
+	Capacity    int          `json:"capacity"` // Seats the layout creates
+}
+
+// ScreenTemplateLibrary holds the layouts screens can be created from by name, so onboarding a multiplex
+// doesn't mean writing out every screen's rows - demonstrates Registry Pattern, like SeatTypeRegistry
+type ScreenTemplateLibrary struct {
+	templates map[string]ScreenTemplate
+	mutex     sync.RWMutex
+}
+
+// defaultTemplates is shared like defaultRegistry, so a template registered once is usable everywhere
+var defaultTemplates = NewScreenTemplateLibrary()
+
+// DefaultScreenTemplateLibrary returns the process-wide library
+func DefaultScreenTemplateLibrary() *ScreenTemplateLibrary {
+	return defaultTemplates
+}
+
+// NewScreenTemplateLibrary creates a library holding the built-in SMALL, MEDIUM and IMAX layouts
+func NewScreenTemplateLibrary() *ScreenTemplateLibrary {
+	library := &ScreenTemplateLibrary{templates: make(map[string]ScreenTemplate)}
+	for _, template := range []ScreenTemplate{
+		{Name: "SMALL", Description: "Boutique screen: six rows, one centre aisle, premium at the back", Layout: smallScreenConfig()},
+		{Name: "MEDIUM", Description: "The standard layout: VIP up front, couple recliners at the back", Layout: DefaultScreenConfig()},
+		{Name: "IMAX", Description: "Large format: stalls and a balcony priced a tier higher, recliner boxes in the last row", Layout: imaxScreenConfig()},
+	} {
+		template.Capacity = template.Layout.Capacity()
+		library.templates[template.Name] = template
+	}
+	return library
+}
+
+// Register adds a template under its upper-cased name. The layout must already be valid; existing names
+// can't be redefined, so "IMAX" means the same seats on every screen created from it.
+func (l *ScreenTemplateLibrary) Register(template ScreenTemplate) (ScreenTemplate, error) {
+	template.Name = strings.ToUpper(strings.TrimSpace(template.Name))
+	if template.Name == "" || len(template.Layout.AllRows()) == 0 {
+		return ScreenTemplate{}, fmt.Errorf("%w: a screen template needs a name and at least one row", models.ErrInvalidTheatreData)
+	}
+	template.Capacity = template.Layout.Capacity()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, exists := l.templates[template.Name]; exists {
+		return ScreenTemplate{}, fmt.Errorf("%w: %s", models.ErrScreenTemplateExists, template.Name)
+	}
+	l.templates[template.Name] = template
+	return template, nil
+}
+
+// Lookup returns a template by name, in any case
+func (l *ScreenTemplateLibrary) Lookup(name string) (ScreenTemplate, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	template, exists := l.templates[strings.ToUpper(name)]
+	if !exists {
+		return ScreenTemplate{}, fmt.Errorf("%w: %s", models.ErrScreenTemplateNotFound, name)
+	}
+	return template, nil
+}
+
+// Templates lists every template from the fewest seats to the most
+func (l *ScreenTemplateLibrary) Templates() []ScreenTemplate {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	templates := make([]ScreenTemplate, 0, len(l.templates))
+	for _, template := range l.templates {
+		templates = append(templates, template)
+	}
+	slices.SortFunc(templates, func(a, b ScreenTemplate) int {
+		if a.Capacity != b.Capacity {
+			return a.Capacity - b.Capacity
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return templates
+}
+
+// Capacity counts the seats the layout creates
+func (c ScreenConfig) Capacity() int {
+	capacity := 0
+	for _, row := range c.AllRows() {
+		capacity += row.Count - len(row.Missing)
+	}
+	return capacity
+}
+
+// smallScreenConfig is 72 seats in six rows of twelve, with a wheelchair space and companion seat in row F
+func smallScreenConfig() ScreenConfig {
+	var rows []RowConfig
+	for _, name := range []string{"A", "B", "C", "D"} {
+		rows = append(rows, RowConfig{Name: name, Count: 12, Type: models.SeatTypeRegular, AisleAfter: []int{6}})
+	}
+	rows = append(rows,
+		RowConfig{Name: "E", Count: 12, Type: models.SeatTypePremium, AisleAfter: []int{6}},
+		RowConfig{Name: "F", Count: 12, Type: models.SeatTypePremium, AisleAfter: []int{6}, Wheelchair: []int{1}, Companion: []int{2}},
+	)
+	return ScreenConfig{Rows: rows}
+}
+
+// imaxScreenConfig is stalls of 13 wide rows, split by two aisles and a pillar either side at the back, and a
+// balcony of five premium rows with a row of couple recliners behind
+func imaxScreenConfig() ScreenConfig {
+	stalls := SectionConfig{Name: "STALLS"}
+	for i, name := range strings.Split("ABCDEFGHJKLMN", "") {
+		row := RowConfig{Name: name, Count: 24, Type: models.SeatTypeRegular, AisleAfter: []int{6, 18}}
+		if i >= 8 {
+			row.Type = models.SeatTypePremium
+		}
+		switch name {
+		case "A":
+			row.Wheelchair, row.Companion = []int{1, 24}, []int{2, 23}
+		case "N":
+			row.Missing = []int{7, 18}
+		}
+		stalls.Rows = append(stalls.Rows, row)
+	}
+
+	balcony := SectionConfig{Name: "BALCONY", PriceMultiplier: 1.2}
+	for _, name := range []string{"P", "Q", "R", "S", "T"} {
+		balcony.Rows = append(balcony.Rows, RowConfig{Name: name, Count: 20, Type: models.SeatTypePremium, AisleAfter: []int{10}, Offset: 2})
+	}
+	balcony.Rows = append(balcony.Rows, RowConfig{Name: "U", Count: 12, Type: models.SeatTypeRecliner, AisleAfter: []int{6}, GroupSize: 2, Offset: 5})
+
+	return ScreenConfig{Sections: []SectionConfig{stalls, balcony}}
+}
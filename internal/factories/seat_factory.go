@@ -3,122 +3,342 @@ package factories
 import (
 	"bookmyshow-lld/internal/models"
 	"fmt"
+	"slices"
 )
 
-// SeatFactory creates different types of seats
-type SeatFactory struct{}
+// SeatFactory creates different types of seats, priced from a SeatTypeRegistry
+type SeatFactory struct {
+	registry *SeatTypeRegistry
+}
 
-// NewSeatFactory creates a new seat factory
+// NewSeatFactory creates a seat factory backed by the default registry
 func NewSeatFactory() *SeatFactory {
-	return &SeatFactory{}
+	return NewSeatFactoryWithRegistry(DefaultSeatTypeRegistry())
+}
+
+// NewSeatFactoryWithRegistry creates a seat factory backed by the given registry
+func NewSeatFactoryWithRegistry(registry *SeatTypeRegistry) *SeatFactory {
+	return &SeatFactory{registry: registry}
+}
+
+// Registry returns the seat types the factory prices from
+func (sf *SeatFactory) Registry() *SeatTypeRegistry {
+	return sf.registry
 }
 
 // CreateSeat creates a seat based on type with appropriate pricing
-func (sf *SeatFactory) CreateSeat(rowName string, number int, seatType models.SeatType, basePrice float64) *models.Seat {
+func (sf *SeatFactory) CreateSeat(rowName string, number int, seatType models.SeatType, basePrice models.Money) *models.Seat {
 	price := sf.calculatePrice(seatType, basePrice)
 	return models.NewSeat(rowName, number, seatType, price)
 }
 
-// CreateSeatsForScreen creates seats for an entire screen
-func (sf *SeatFactory) CreateSeatsForScreen(screenID string, config ScreenConfig, basePrice float64) []*models.Seat {
+// CreateSeatsForScreen creates seats for an entire screen, section by section from the front, placing each
+// seat on the screen's grid. Rows are one grid row apart, sections two so a walkway separates them; in a row
+// the offset, aisles and missing seats each take a column.
+func (sf *SeatFactory) CreateSeatsForScreen(screenID string, config ScreenConfig, basePrice models.Money) []*models.Seat {
 	var seats []*models.Seat
 
-	for _, rowConfig := range config.Rows {
-		for i := 1; i <= rowConfig.Count; i++ {
-			seat := sf.CreateSeat(rowConfig.Name, i, rowConfig.Type, basePrice)
-			seats = append(seats, seat)
+	y := 0
+	for i, section := range config.sections() {
+		if i > 0 {
+			y++ // The walkway
+		}
+		for _, rowConfig := range section.Rows {
+			y++
+			for number := 1; number <= rowConfig.Count; number++ {
+				if slices.Contains(rowConfig.Missing, number) {
+					continue
+				}
+				price := basePrice.Mul(sf.getPriceMultiplier(rowConfig.Type) * section.priceMultiplier())
+				seat := models.NewSeat(rowConfig.Name, number, rowConfig.Type, price)
+				seat.Section = section.Name
+				seat.X, seat.Y = rowConfig.column(number), y
+				seat.Attributes = rowConfig.attributesOf(number)
+				seats = append(seats, seat)
+			}
 		}
 	}
 
 	return seats
 }
 
-// CreateDefaultScreenSeats creates a default seat configuration
-func (sf *SeatFactory) CreateDefaultScreenSeats(basePrice float64) []*models.Seat {
-	config := ScreenConfig{
+// ApplyAisles records each row's aisle positions on the screen
+func (sf *SeatFactory) ApplyAisles(screen *models.Screen, config ScreenConfig) {
+	for _, rowConfig := range config.AllRows() {
+		screen.SetAisles(rowConfig.Name, rowConfig.AisleAfter)
+	}
+}
+
+// ValidateLayout checks what spans rows: row names are unique across sections, so seat labels such as
+// "C7" stay unambiguous, and each section is named and priced sensibly
+func (sf *SeatFactory) ValidateLayout(config ScreenConfig) error {
+	sections := make(map[string]bool)
+	for _, section := range config.Sections {
+		if section.Name == "" || len(section.Rows) == 0 {
+			return fmt.Errorf("every section needs a name and at least one row")
+		}
+		if sections[section.Name] {
+			return fmt.Errorf("section %s is listed twice", section.Name)
+		}
+		sections[section.Name] = true
+		if section.PriceMultiplier < 0 {
+			return fmt.Errorf("section %s has a negative price multiplier", section.Name)
+		}
+	}
+
+	rows := make(map[string]bool)
+	for _, rowConfig := range config.AllRows() {
+		if rows[rowConfig.Name] {
+			return fmt.Errorf("row %s is listed twice", rowConfig.Name)
+		}
+		rows[rowConfig.Name] = true
+	}
+	return nil
+}
+
+// ValidateMissingSeats checks missing seats lie inside the row, leave it at least one seat and aren't
+// flagged as wheelchair or companion seats; grouped rows can't have any, since groups are taken left to right
+func (sf *SeatFactory) ValidateMissingSeats(rowConfig RowConfig) error {
+	if rowConfig.Offset < 0 {
+		return fmt.Errorf("row %s has a negative offset", rowConfig.Name)
+	}
+	if len(rowConfig.Missing) == 0 {
+		return nil
+	}
+	if rowConfig.GroupSize > 1 {
+		return fmt.Errorf("row %s of seat groups can't have missing seats", rowConfig.Name)
+	}
+
+	missing := make(map[int]bool)
+	for _, number := range rowConfig.Missing {
+		if number < 1 || number > rowConfig.Count {
+			return fmt.Errorf("missing seat %d is outside row %s", number, rowConfig.Name)
+		}
+		if slices.Contains(rowConfig.Wheelchair, number) || slices.Contains(rowConfig.Companion, number) {
+			return fmt.Errorf("missing seat %s%d can't be an accessible seat", rowConfig.Name, number)
+		}
+		missing[number] = true
+	}
+	if len(missing) == rowConfig.Count {
+		return fmt.Errorf("row %s has no seats left", rowConfig.Name)
+	}
+	return nil
+}
+
+// ValidateAisles checks every aisle falls between two seats of its row
+func (sf *SeatFactory) ValidateAisles(rowConfig RowConfig) error {
+	for _, after := range rowConfig.AisleAfter {
+		if after < 1 || after >= rowConfig.Count {
+			return fmt.Errorf("aisle after seat %d is outside row %s", after, rowConfig.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateAccessibility checks wheelchair and companion seats exist in the row and every companion
+// seat sits directly beside a wheelchair space, with no aisle between
+func (sf *SeatFactory) ValidateAccessibility(rowConfig RowConfig) error {
+	for _, number := range append(slices.Clone(rowConfig.Wheelchair), rowConfig.Companion...) {
+		if number < 1 || number > rowConfig.Count {
+			return fmt.Errorf("accessible seat %d is outside row %s", number, rowConfig.Name)
+		}
+	}
+
+	for _, number := range rowConfig.Companion {
+		if slices.Contains(rowConfig.Wheelchair, number) {
+			return fmt.Errorf("seat %s%d can't be both a wheelchair space and a companion seat", rowConfig.Name, number)
+		}
+		left := slices.Contains(rowConfig.Wheelchair, number-1) && !slices.Contains(rowConfig.AisleAfter, number-1)
+		right := slices.Contains(rowConfig.Wheelchair, number+1) && !slices.Contains(rowConfig.AisleAfter, number)
+		if !left && !right {
+			return fmt.Errorf("companion seat %s%d is not beside a wheelchair space", rowConfig.Name, number)
+		}
+	}
+	return nil
+}
+
+// ValidateSeatGroups checks a grouped row splits evenly into groups and no aisle runs through one
+func (sf *SeatFactory) ValidateSeatGroups(rowConfig RowConfig) error {
+	size := rowConfig.GroupSize
+	if size < 0 {
+		return fmt.Errorf("row %s has a negative group size", rowConfig.Name)
+	}
+	if size <= 1 {
+		return nil
+	}
+
+	if rowConfig.Count%size != 0 {
+		return fmt.Errorf("row %s of %d seats can't be split into groups of %d", rowConfig.Name, rowConfig.Count, size)
+	}
+	for _, after := range rowConfig.AisleAfter {
+		if after%size != 0 {
+			return fmt.Errorf("aisle after seat %d splits a group in row %s", after, rowConfig.Name)
+		}
+	}
+	return nil
+}
+
+// ApplySeatGroups groups each grouped row's seats left to right, GroupSize at a time
+func (sf *SeatFactory) ApplySeatGroups(screen *models.Screen, config ScreenConfig) error {
+	grouped := make(map[string]int)
+	for _, rowConfig := range config.AllRows() {
+		if rowConfig.GroupSize > 1 {
+			grouped[rowConfig.Name] = rowConfig.GroupSize
+		}
+	}
+	if len(grouped) == 0 {
+		return nil
+	}
+
+	for _, row := range screen.GetSeatMap().Rows {
+		size, ok := grouped[row.Name]
+		if !ok {
+			continue
+		}
+
+		var group []string
+		for _, cell := range row.Cells {
+			if !cell.IsSeat() {
+				continue
+			}
+			group = append(group, cell.SeatID)
+			if len(group) < size {
+				continue
+			}
+			if _, err := screen.GroupSeats(group); err != nil {
+				return err
+			}
+			group = nil
+		}
+	}
+	return nil
+}
+
+// DefaultScreenConfig is the standard layout: VIP at the front, regular in the middle, two aisles per row,
+// wheelchair spaces with companion seats at both ends of row H, and couple recliners at the back
+func DefaultScreenConfig() ScreenConfig {
+	return ScreenConfig{
 		Rows: []RowConfig{
-			{Name: "A", Count: 10, Type: models.SeatTypeVIP},
-			{Name: "B", Count: 12, Type: models.SeatTypeVIP},
-			{Name: "C", Count: 14, Type: models.SeatTypePremium},
-			{Name: "D", Count: 14, Type: models.SeatTypePremium},
-			{Name: "E", Count: 16, Type: models.SeatTypeRegular},
-			{Name: "F", Count: 16, Type: models.SeatTypeRegular},
-			{Name: "G", Count: 18, Type: models.SeatTypeRegular},
-			{Name: "H", Count: 18, Type: models.SeatTypeRegular},
+			{Name: "A", Count: 10, Type: models.SeatTypeVIP, AisleAfter: []int{3, 7}},
+			{Name: "B", Count: 12, Type: models.SeatTypeVIP, AisleAfter: []int{3, 9}},
+			{Name: "C", Count: 14, Type: models.SeatTypePremium, AisleAfter: []int{4, 10}},
+			{Name: "D", Count: 14, Type: models.SeatTypePremium, AisleAfter: []int{4, 10}},
+			{Name: "E", Count: 16, Type: models.SeatTypeRegular, AisleAfter: []int{4, 12}},
+			{Name: "F", Count: 16, Type: models.SeatTypeRegular, AisleAfter: []int{4, 12}},
+			{Name: "G", Count: 18, Type: models.SeatTypeRegular, AisleAfter: []int{5, 13}},
+			{Name: "H", Count: 18, Type: models.SeatTypeRegular, AisleAfter: []int{5, 13}, Wheelchair: []int{1, 18}, Companion: []int{2, 17}},
+			{Name: "J", Count: 8, Type: models.SeatTypeRecliner, AisleAfter: []int{4}, GroupSize: 2},
 		},
 	}
+}
 
-	return sf.CreateSeatsForScreen("", config, basePrice)
+// CreateDefaultScreenSeats creates a default seat configuration
+func (sf *SeatFactory) CreateDefaultScreenSeats(basePrice models.Money) []*models.Seat {
+	return sf.CreateSeatsForScreen("", DefaultScreenConfig(), basePrice)
 }
 
 // calculatePrice calculates price based on seat type
-func (sf *SeatFactory) calculatePrice(seatType models.SeatType, basePrice float64) float64 {
+func (sf *SeatFactory) calculatePrice(seatType models.SeatType, basePrice models.Money) models.Money {
 	multiplier := sf.getPriceMultiplier(seatType)
-	return basePrice * multiplier
+	return basePrice.Mul(multiplier)
 }
 
-// getPriceMultiplier returns price multiplier for different seat types
+// getPriceMultiplier returns the registered multiplier, charging base price for unknown types
 func (sf *SeatFactory) getPriceMultiplier(seatType models.SeatType) float64 {
-	switch seatType {
-	case models.SeatTypeVIP:
-		return 2.0
-	case models.SeatTypePremium:
-		return 1.5
-	case models.SeatTypeRecliner:
-		return 2.5
-	case models.SeatTypeRegular:
-		return 1.0
-	default:
-		return 1.0
+	if info, exists := sf.registry.Lookup(seatType); exists {
+		return info.Multiplier
 	}
+	return 1.0
 }
 
-// ValidateSeatType validates if seat type is supported
+// ValidateSeatType validates if seat type is registered
 func (sf *SeatFactory) ValidateSeatType(seatType models.SeatType) error {
-	switch seatType {
-	case models.SeatTypeRegular, models.SeatTypePremium, models.SeatTypeVIP, models.SeatTypeRecliner:
-		return nil
-	default:
+	if _, exists := sf.registry.Lookup(seatType); !exists {
 		return fmt.Errorf("unsupported seat type: %s", seatType)
 	}
+	return nil
 }
 
-// ScreenConfig represents screen seat configuration
+// ScreenConfig represents screen seat configuration: flat rows, sections such as stalls and a balcony, or both
 type ScreenConfig struct {
-	Rows []RowConfig `json:"rows"`
+	Rows     []RowConfig     `json:"rows,omitempty"`     // Rows outside any section, in front of the sections
+	Sections []SectionConfig `json:"sections,omitempty"` // Front to back
 }
 
-// RowConfig represents row configuration
+// SectionConfig is a block of rows sold as one pricing tier, e.g. the stalls or the balcony
+type SectionConfig struct {
+	Name            string      `json:"name"`
+	PriceMultiplier float64     `json:"price_multiplier,omitempty"` // Applied on top of each seat type's; 1 when zero
+	Rows            []RowConfig `json:"rows"`
+}
+
+// AllRows returns every row front to back, sections included
+func (c ScreenConfig) AllRows() []RowConfig {
+	var rows []RowConfig
+	for _, section := range c.sections() {
+		rows = append(rows, section.Rows...)
+	}
+	return rows
+}
+
+// sections returns the sections front to back, the rows outside any section first as an unnamed one
+func (c ScreenConfig) sections() []SectionConfig {
+	if len(c.Rows) == 0 {
+		return c.Sections
+	}
+	return append([]SectionConfig{{Rows: c.Rows}}, c.Sections...)
+}
+
+func (s SectionConfig) priceMultiplier() float64 {
+	if s.PriceMultiplier == 0 {
+		return 1
+	}
+	return s.PriceMultiplier
+}
+
+// RowConfig represents row configuration. Seats are numbered 1 to Count from the left; missing seats keep
+// their numbers, so the seats either side of a pillar are numbered as if it weren't there.
 type RowConfig struct {
-	Name  string          `json:"name"`
-	Count int             `json:"count"`
-	Type  models.SeatType `json:"type"`
+	Name       string          `json:"name"`
+	Count      int             `json:"count"`
+	Type       models.SeatType `json:"type"`
+	AisleAfter []int           `json:"aisle_after,omitempty"` // Seat numbers followed by an aisle
+	GroupSize  int             `json:"group_size,omitempty"`  // Seats booked together, e.g. 2 for couple recliners or 4 for boxes
+	Wheelchair []int           `json:"wheelchair,omitempty"`  // Seat numbers that are wheelchair spaces
+	Companion  []int           `json:"companion,omitempty"`   // Seat numbers beside a wheelchair space, for companions
+	Missing    []int           `json:"missing,omitempty"`     // Seat numbers with no seat, e.g. where a pillar stands
+	Offset     int             `json:"offset,omitempty"`      // Empty columns before seat 1, e.g. to centre a short row
 }
 
-// GetSeatTypeInfo returns information about seat types
-func (sf *SeatFactory) GetSeatTypeInfo() map[models.SeatType]SeatTypeInfo {
-	return map[models.SeatType]SeatTypeInfo{
-		models.SeatTypeRegular: {
-			Name:        "Regular",
-			Description: "Standard seating with basic comfort",
-			Multiplier:  1.0,
-		},
-		models.SeatTypePremium: {
-			Name:        "Premium",
-			Description: "Enhanced comfort with extra legroom",
-			Multiplier:  1.5,
-		},
-		models.SeatTypeVIP: {
-			Name:        "VIP",
-			Description: "Luxury seating with premium amenities",
-			Multiplier:  2.0,
-		},
-		models.SeatTypeRecliner: {
-			Name:        "Recliner",
-			Description: "Fully reclining seats with maximum comfort",
-			Multiplier:  2.5,
-		},
+// column places seat number of the row on the grid: after the offset, every seat before it and every aisle before it
+func (rc RowConfig) column(number int) int {
+	column := rc.Offset + number
+	for _, after := range rc.AisleAfter {
+		if after < number {
+			column++
+		}
+	}
+	return column
+}
+
+// attributesOf flags a seat of the row: wheelchair or companion as configured, aisle when an aisle runs beside it
+func (rc RowConfig) attributesOf(number int) []models.SeatAttribute {
+	var attributes []models.SeatAttribute
+	if slices.Contains(rc.Wheelchair, number) {
+		attributes = append(attributes, models.SeatAttributeWheelchair)
 	}
+	if slices.Contains(rc.Companion, number) {
+		attributes = append(attributes, models.SeatAttributeCompanion)
+	}
+	if slices.Contains(rc.AisleAfter, number) || slices.Contains(rc.AisleAfter, number-1) {
+		attributes = append(attributes, models.SeatAttributeAisle)
+	}
+	return attributes
+}
+
+// GetSeatTypeInfo returns information about the registered seat types
+func (sf *SeatFactory) GetSeatTypeInfo() map[models.SeatType]SeatTypeInfo {
+	return sf.registry.Info()
 }
 
 // SeatTypeInfo contains information about seat types
@@ -0,0 +1,132 @@
+package factories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// SeatTypeRegistry holds the seat types screens may use, with their price multipliers
+// - demonstrates Registry Pattern: new types are registered at runtime instead of added to a switch (Open/Closed).
+type SeatTypeRegistry struct {
+	types map[models.SeatType]SeatTypeInfo
+	mutex sync.RWMutex
+}
+
+// defaultRegistry is shared by every factory made with NewSeatFactory, so a type registered once is usable everywhere
+var defaultRegistry = NewSeatTypeRegistry()
+
+// DefaultSeatTypeRegistry returns the process-wide registry
+func DefaultSeatTypeRegistry() *SeatTypeRegistry {
+	return defaultRegistry
+}
+
+// NewSeatTypeRegistry creates a registry holding the built-in types
+func NewSeatTypeRegistry() *SeatTypeRegistry {
+	return &SeatTypeRegistry{
+		types: map[models.SeatType]SeatTypeInfo{
+			models.SeatTypeRegular: {
+				Name:        "Regular",
+				Description: "Standard seating with basic comfort",
+				Multiplier:  1.0,
+			},
+			models.SeatTypePremium: {
+				Name:        "Premium",
+				Description: "Enhanced comfort with extra legroom",
+				Multiplier:  1.5,
+			},
+			models.SeatTypeVIP: {
+				Name:        "VIP",
+				Description: "Luxury seating with premium amenities",
+				Multiplier:  2.0,
+			},
+			models.SeatTypeRecliner: {
+				Name:        "Recliner",
+				Description: "Fully reclining seats with maximum comfort",
+				Multiplier:  2.5,
+			},
+		},
+	}
+}
+
+// Register adds a seat type; existing types can't be redefined, as seats already priced from them would disagree
+func (r *SeatTypeRegistry) Register(seatType models.SeatType, info SeatTypeInfo) error {
+	if seatType == "" || info.Name == "" || info.Multiplier <= 0 {
+		return fmt.Errorf("%w: a seat type needs a code, a name and a positive multiplier", models.ErrInvalidSeatType)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.types[seatType]; exists {
+		return fmt.Errorf("%w: %s", models.ErrSeatTypeExists, seatType)
+	}
+	r.types[seatType] = info
+	return nil
+}
+
+// SeatMultipliers are seat types' price multipliers, relative to a screen's base price
+type SeatMultipliers map[models.SeatType]float64
+
+// Reprice changes the multipliers of registered types, e.g. from configuration at startup; nothing changes
+// unless every type is known and every multiplier positive. Seats already built keep the price they were given.
+func (r *SeatTypeRegistry) Reprice(multipliers SeatMultipliers) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for seatType, multiplier := range multipliers {
+		if _, exists := r.types[seatType]; !exists {
+			return fmt.Errorf("%w: unknown seat type %s", models.ErrInvalidSeatType, seatType)
+		}
+		if multiplier <= 0 {
+			return fmt.Errorf("%w: %s needs a positive multiplier", models.ErrInvalidSeatType, seatType)
+		}
+	}
+	for seatType, multiplier := range multipliers {
+		info := r.types[seatType]
+		info.Multiplier = multiplier
+		r.types[seatType] = info
+	}
+	return nil
+}
+
+// Lookup returns a registered type's details
+func (r *SeatTypeRegistry) Lookup(seatType models.SeatType) (SeatTypeInfo, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	info, exists := r.types[seatType]
+	return info, exists
+}
+
+// Types lists the registered types from cheapest to most premium
+func (r *SeatTypeRegistry) Types() []models.SeatType {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	types := make([]models.SeatType, 0, len(r.types))
+	for seatType := range r.types {
+		types = append(types, seatType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		mi, mj := r.types[types[i]].Multiplier, r.types[types[j]].Multiplier
+		if mi != mj {
+			return mi < mj
+		}
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// Info returns a copy of every registered type's details
+func (r *SeatTypeRegistry) Info() map[models.SeatType]SeatTypeInfo {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	info := make(map[models.SeatType]SeatTypeInfo, len(r.types))
+	for seatType, details := range r.types {
+		info[seatType] = details
+	}
+	return info
+}
@@ -0,0 +1,407 @@
+package filestore
+
+import (
+	"bufio"
+	"cmp"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// DefaultDir is where main keeps the files when -store=file is given without -dir
+const DefaultDir = "bookmyshow-data"
+
+// DefaultCompactAfter is how many log records accumulate before they are folded into a new snapshot
+const DefaultCompactAfter = 1000
+
+// formatVersion is recorded in every snapshot; snapshots written by a newer build are refused
+const formatVersion = 1
+
+// File names inside the directory
+const (
+	snapshotFile = "snapshot.json"
+	walFile      = "wal.jsonl"
+)
+
+// secretsTable keeps keys that must outlive the process, like the ticket signing key
+const secretsTable = "secrets"
+
+// Config selects the data directory; an empty Dir keeps everything in memory
+type Config struct {
+	Dir          string
+	CompactAfter int // Log records kept before compacting; DefaultCompactAfter when zero
+}
+
+// ConfigFromEnv reads FILESTORE_DIR and FILESTORE_COMPACT_AFTER
+func ConfigFromEnv() Config {
+	config := Config{Dir: os.Getenv("FILESTORE_DIR")}
+	if after, err := strconv.Atoi(os.Getenv("FILESTORE_COMPACT_AFTER")); err == nil && after > 0 {
+		config.CompactAfter = after
+	}
+	return config
+}
+
+// Enabled reports whether a data directory is configured
+func (c Config) Enabled() bool {
+	return c.Dir != ""
+}
+
+// record is one line of the write-ahead log: a document saved or deleted, or a batch of those committed
+// together. A batch is one line, so a crash keeps all of it or none.
+type record struct {
+	Seq   uint64          `json:"seq"` // The last of a batch's
+	Table string          `json:"table,omitempty"`
+	ID    string          `json:"id,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`  // Absent for deletes
+	Batch []record        `json:"batch,omitempty"` // Set instead of Table, ID and Data
+}
+
+// document is a saved entity and when it was first saved, so restores replay in insertion order
+type document struct {
+	Seq  uint64          `json:"seq"`
+	Data json.RawMessage `json:"data"`
+}
+
+// snapshot is the compacted state: every live document, by table and ID
+type snapshot struct {
+	Version int                             `json:"version"`
+	Seq     uint64                          `json:"seq"` // Last log record folded in
+	Tables  map[string]map[string]*document `json:"tables"`
+}
+
+// Log is a directory holding a snapshot and the write-ahead log of every change since. Each change is
+// appended and synced before the write returns; once CompactAfter records pile up the live documents are
+// written to a new snapshot and the log starts over. Opening replays the log over the snapshot.
+type Log struct {
+	dir          string
+	compactAfter int
+
+	mutex   sync.Mutex
+	wal     *os.File
+	pending int // Records in the log since the last compaction
+	seq     uint64
+	tables  map[string]map[string]*document
+}
+
+// Open opens (or creates) the data directory and loads the snapshot and log written by earlier runs
+func Open(config Config) (*Log, error) {
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("filestore %s: %w", config.Dir, err)
+	}
+
+	l := &Log{
+		dir:          config.Dir,
+		compactAfter: config.CompactAfter,
+		tables:       make(map[string]map[string]*document),
+	}
+	if l.compactAfter <= 0 {
+		l.compactAfter = DefaultCompactAfter
+	}
+	if err := l.loadSnapshot(); err != nil {
+		return nil, fmt.Errorf("filestore %s: %w", config.Dir, err)
+	}
+	if err := l.replay(); err != nil {
+		return nil, fmt.Errorf("filestore %s: %w", config.Dir, err)
+	}
+	return l, nil
+}
+
+// Ping checks the data directory and the open log are still there, for health checks
+func (l *Log) Ping() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, err := os.Stat(l.dir); err != nil {
+		return fmt.Errorf("filestore %s: %w", l.dir, err)
+	}
+	if l.wal != nil {
+		if _, err := l.wal.Stat(); err != nil {
+			return fmt.Errorf("filestore %s: %w", l.dir, err)
+		}
+	}
+	return nil
+}
+
+// Close folds the log into a fresh snapshot, so the next start has nothing to replay
+func (l *Log) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.wal == nil {
+		return nil
+	}
+	var err error
+	if l.pending > 0 {
+		err = l.compact()
+	}
+	if closeErr := l.wal.Close(); err == nil {
+		err = closeErr
+	}
+	l.wal = nil
+	return err
+}
+
+// Compact writes the live documents to a new snapshot and empties the log
+func (l *Log) Compact() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.compact()
+}
+
+// Records returns how many documents the directory holds
+func (l *Log) Records() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	records := 0
+	for name, docs := range l.tables {
+		if name != secretsTable {
+			records += len(docs)
+		}
+	}
+	return records
+}
+
+// put logs a document being saved; re-saving keeps the document's original place in the restore order
+func (l *Log) put(name, id string, data []byte) error {
+	return l.append(record{Table: name, ID: id, Data: data})
+}
+
+// delete logs documents being removed
+func (l *Log) delete(name string, ids []string) error {
+	records := make([]record, len(ids))
+	for i, id := range ids {
+		records[i] = record{Table: name, ID: id}
+	}
+	return l.append(records...)
+}
+
+// documents returns copies of a table's documents in the order they were first saved
+func (l *Log) documents(name string) []document {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	docs := make([]document, 0, len(l.tables[name]))
+	for _, doc := range l.tables[name] {
+		docs = append(docs, *doc)
+	}
+	slices.SortFunc(docs, func(a, b document) int { return cmp.Compare(a.Seq, b.Seq) })
+	return docs
+}
+
+// append writes and syncs records, applies them, and compacts once enough records have piled up. More than
+// one record is written as a batch.
+func (l *Log) append(records ...record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.wal == nil {
+		return errors.New("filestore: log is closed")
+	}
+	for i := range records {
+		l.seq++
+		records[i].Seq = l.seq
+	}
+	rec := records[0]
+	if len(records) > 1 {
+		rec = record{Seq: l.seq, Batch: records}
+	}
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("filestore: encoding %s: %w", rec.describe(), err)
+	}
+	if _, err := l.wal.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("filestore: logging %s: %w", rec.describe(), err)
+	}
+	if err := l.wal.Sync(); err != nil {
+		return fmt.Errorf("filestore: logging %s: %w", rec.describe(), err)
+	}
+	l.apply(rec)
+
+	l.pending += len(records)
+	if l.pending >= l.compactAfter {
+		return l.compact()
+	}
+	return nil
+}
+
+// describe names the record in errors
+func (rec record) describe() string {
+	if rec.Batch != nil {
+		return fmt.Sprintf("batch of %d", len(rec.Batch))
+	}
+	return rec.Table + " " + rec.ID
+}
+
+// apply folds a record into the live documents. Callers must hold mutex.
+func (l *Log) apply(rec record) {
+	if rec.Batch != nil {
+		for _, batched := range rec.Batch {
+			l.apply(batched)
+		}
+		return
+	}
+
+	docs := l.tables[rec.Table]
+	if rec.Data == nil {
+		delete(docs, rec.ID)
+		return
+	}
+	if docs == nil {
+		docs = make(map[string]*document)
+		l.tables[rec.Table] = docs
+	}
+	if doc, ok := docs[rec.ID]; ok {
+		doc.Data = rec.Data
+		return
+	}
+	docs[rec.ID] = &document{Seq: rec.Seq, Data: rec.Data}
+}
+
+// compact writes the snapshot beside the old one, renames it into place and truncates the log.
+// A crash before the truncate leaves records the snapshot already holds, which replay skips by sequence.
+// Callers must hold mutex.
+func (l *Log) compact() error {
+	data, err := json.Marshal(snapshot{Version: formatVersion, Seq: l.seq, Tables: l.tables})
+	if err != nil {
+		return fmt.Errorf("filestore: encoding snapshot: %w", err)
+	}
+	if err := writeFileSynced(filepath.Join(l.dir, snapshotFile), data); err != nil {
+		return fmt.Errorf("filestore: writing snapshot: %w", err)
+	}
+	if err := l.wal.Truncate(0); err != nil {
+		return fmt.Errorf("filestore: truncating log: %w", err)
+	}
+	if _, err := l.wal.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("filestore: truncating log: %w", err)
+	}
+	l.pending = 0
+	return nil
+}
+
+// loadSnapshot reads the last compacted state, if there is one
+func (l *Log) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(l.dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("decoding %s: %w", snapshotFile, err)
+	}
+	if snap.Version > formatVersion {
+		return fmt.Errorf("format version %d is newer than this build supports (%d)", snap.Version, formatVersion)
+	}
+	l.seq = snap.Seq
+	for name, docs := range snap.Tables {
+		l.tables[name] = docs
+	}
+	return nil
+}
+
+// replay applies the log written since the snapshot and leaves it open for appending. A last line without
+// its newline is a write the process died during; it was never acknowledged, so it is cut off.
+func (l *Log) replay() error {
+	wal, err := os.OpenFile(filepath.Join(l.dir, walFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(wal)
+	var offset int64 // End of the last whole record
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			wal.Close()
+			return err
+		}
+		var rec record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			wal.Close()
+			return fmt.Errorf("decoding %s at byte %d: %w", walFile, offset, err)
+		}
+		offset += int64(len(line))
+		if rec.Seq <= l.seq {
+			continue // Already in the snapshot
+		}
+		l.seq = rec.Seq
+		l.apply(rec)
+		l.pending += max(1, len(rec.Batch))
+	}
+
+	if err := wal.Truncate(offset); err != nil {
+		wal.Close()
+		return err
+	}
+	if _, err := wal.Seek(offset, io.SeekStart); err != nil {
+		wal.Close()
+		return err
+	}
+	l.wal = wal
+	return nil
+}
+
+// Secret returns the named random key, generating and logging it on first use so it outlives the process
+func Secret(l *Log, name string, size int) ([]byte, error) {
+	l.mutex.Lock()
+	doc, ok := l.tables[secretsTable][name]
+	l.mutex.Unlock()
+	if ok {
+		var encoded string
+		if err := json.Unmarshal(doc.Data, &encoded); err != nil {
+			return nil, fmt.Errorf("filestore: loading secret %s: %w", name, err)
+		}
+		return hex.DecodeString(encoded)
+	}
+
+	key := make([]byte, size)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(hex.EncodeToString(key))
+	if err := l.put(secretsTable, name, data); err != nil {
+		return nil, fmt.Errorf("filestore: saving secret %s: %w", name, err)
+	}
+	return key, nil
+}
+
+// writeFileSynced replaces path with data by writing a temporary file, syncing it and renaming it over path
+func writeFileSynced(path string, data []byte) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
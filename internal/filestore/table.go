@@ -0,0 +1,62 @@
+package filestore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// table logs one entity type as JSON documents keyed by ID.
+// Queries stay with the in-memory repository; the table only has to survive a restart.
+type table[T any] struct {
+	log  *Log
+	name string
+}
+
+// save logs the entity's document; replacing keeps the document's original insertion order
+func (t table[T]) save(ctx context.Context, id string, entity *T) error {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return fmt.Errorf("filestore: encoding %s %s: %w", t.name, id, err)
+	}
+	if batch := batchFrom(ctx); batch != nil {
+		batch.add(record{Table: t.name, ID: id, Data: data})
+		return nil
+	}
+	return t.log.put(t.name, id, data)
+}
+
+// delete logs the given documents as removed
+func (t table[T]) delete(ctx context.Context, ids []string) error {
+	if batch := batchFrom(ctx); batch != nil {
+		for _, id := range ids {
+			batch.add(record{Table: t.name, ID: id})
+		}
+		return nil
+	}
+	return t.log.delete(t.name, ids)
+}
+
+// restore replays every stored document, oldest first, into create
+func (t table[T]) restore(ctx context.Context, create func(context.Context, *T) error) (int, error) {
+	restored := 0
+	for _, doc := range t.log.documents(t.name) {
+		entity := new(T)
+		if err := json.Unmarshal(doc.Data, entity); err != nil {
+			return restored, fmt.Errorf("filestore: decoding %s #%d: %w", t.name, doc.Seq, err)
+		}
+		if err := create(ctx, entity); err != nil {
+			return restored, fmt.Errorf("filestore: restoring %s #%d: %w", t.name, doc.Seq, err)
+		}
+		restored++
+	}
+	return restored, nil
+}
+
+// write applies a change to the in-memory repository, then saves the entity
+func write[T any](ctx context.Context, t table[T], id string, entity *T, apply func(context.Context, *T) error) error {
+	if err := apply(ctx, entity); err != nil {
+		return err
+	}
+	return t.save(ctx, id, entity)
+}
@@ -0,0 +1,86 @@
+package filestore
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"sync"
+)
+
+// UnitOfWork runs repository transactions on the log: the documents saved with a transaction's context are
+// held back and logged as one batch on commit, and a rollback drops them and runs the undo steps that put
+// the in-memory side back
+type UnitOfWork struct {
+	log *Log
+}
+
+// NewUnitOfWork creates the unit of work of the repositories Restore builds on log
+func NewUnitOfWork(log *Log) repositories.UnitOfWork {
+	return &UnitOfWork{log: log}
+}
+
+// batch collects a transaction's records until it ends
+type batch struct {
+	mutex   sync.Mutex
+	records []record
+}
+
+type batchKey struct{}
+
+func (b *batch) add(rec record) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.records = append(b.records, rec)
+}
+
+// batchFrom returns the batch of the transaction ctx carries, or nil outside one
+func batchFrom(ctx context.Context) *batch {
+	b, _ := ctx.Value(batchKey{}).(*batch)
+	return b
+}
+
+// Begin starts a transaction; nothing reaches the log until it commits
+func (u *UnitOfWork) Begin(ctx context.Context) (context.Context, error) {
+	ctx, _ = repositories.BeginTransaction(ctx)
+	return context.WithValue(ctx, batchKey{}, &batch{}), nil
+}
+
+// Commit logs the batch; if that fails the transaction is rolled back, undo steps included
+func (u *UnitOfWork) Commit(ctx context.Context) error {
+	b, transaction, err := transactionOf(ctx)
+	if err != nil {
+		return err
+	}
+	b.mutex.Lock()
+	records := b.records
+	b.records = nil
+	b.mutex.Unlock()
+
+	if err := u.log.append(records...); err != nil {
+		transaction.Finish(true)
+		return err
+	}
+	return transaction.Finish(false)
+}
+
+// Rollback drops the batch and runs the undo steps
+func (u *UnitOfWork) Rollback(ctx context.Context) error {
+	b, transaction, err := transactionOf(ctx)
+	if err != nil {
+		return err
+	}
+	b.mutex.Lock()
+	b.records = nil
+	b.mutex.Unlock()
+	return transaction.Finish(true)
+}
+
+// transactionOf returns the batch and repository transaction ctx carries
+func transactionOf(ctx context.Context) (*batch, *repositories.Transaction, error) {
+	b := batchFrom(ctx)
+	transaction := repositories.TransactionFrom(ctx)
+	if b == nil || transaction == nil {
+		return nil, nil, models.ErrNoTransaction
+	}
+	return b, transaction, nil
+}
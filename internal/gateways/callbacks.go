@@ -0,0 +1,229 @@
+package gateways
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CallbackTolerance is how old a timestamped callback signature may be before it is refused as a replay
+const CallbackTolerance = 5 * time.Minute
+
+// Signature headers each provider sends its callbacks with
+const (
+	MockSignatureHeader     = "X-BMS-Signature"      // "t=<unix>,v1=<hex HMAC-SHA256 of "<unix>.<body>">", as for partner webhooks
+	StripeSignatureHeader   = "Stripe-Signature"     // Same scheme as the mock's
+	RazorpaySignatureHeader = "X-Razorpay-Signature" // Hex HMAC-SHA256 of the body
+	razorpayEventIDHeader   = "X-Razorpay-Event-Id"
+)
+
+// CallbackVerifier checks that asynchronous callbacks really come from the configured provider, using the
+// webhook secret it was set up with, and reads them into models.GatewayCallback - demonstrates Adapter Pattern
+type CallbackVerifier struct {
+	kind   Kind
+	secret []byte
+	clock  clock.Clock // How old a signature's timestamp is
+}
+
+// NewCallbackVerifier creates a verifier for cfg's provider; it refuses every callback while cfg has no webhook secret
+func NewCallbackVerifier(cfg Config, clock clock.Clock) *CallbackVerifier {
+	kind := cfg.Kind
+	if kind == "" {
+		kind = KindMock
+	}
+	return &CallbackVerifier{kind: kind, secret: []byte(cfg.WebhookSecret), clock: clock}
+}
+
+// VerifyCallback checks the callback's signature and reads it. Verified callbacks about things bookings don't
+// track, e.g. a Stripe customer update, return nil with no error so the provider stops sending them.
+func (v *CallbackVerifier) VerifyCallback(header http.Header, body []byte) (*models.GatewayCallback, error) {
+	if len(v.secret) == 0 {
+		return nil, models.ErrGatewayCallbacksDisabled
+	}
+
+	switch v.kind {
+	case KindStripe:
+		if err := v.verifyTimestamped(header.Get(StripeSignatureHeader), body); err != nil {
+			return nil, err
+		}
+		return parseStripeCallback(body)
+	case KindRazorpay:
+		if err := v.verifyBody(header.Get(RazorpaySignatureHeader), body); err != nil {
+			return nil, err
+		}
+		return parseRazorpayCallback(header.Get(razorpayEventIDHeader), body)
+	default:
+		if err := v.verifyTimestamped(header.Get(MockSignatureHeader), body); err != nil {
+			return nil, err
+		}
+		var callback models.GatewayCallback
+		if err := json.Unmarshal(body, &callback); err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidGatewayCallback, err)
+		}
+		return &callback, nil
+	}
+}
+
+// SignMockCallback signs a callback body the way the mock provider would, e.g. to simulate one locally
+func SignMockCallback(secret string, at time.Time, body []byte) string {
+	timestamp := at.Unix()
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac([]byte(secret), fmt.Sprintf("%d.", timestamp), body)))
+}
+
+// verifyTimestamped checks a "t=<unix>,v1=<hex>" signature over "<unix>.<body>" that is no older than
+// CallbackTolerance. Any of several v1 signatures may match, as providers send two while secrets rotate.
+func (v *CallbackVerifier) verifyTimestamped(signature string, body []byte) error {
+	var timestamp string
+	var candidates []string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			candidates = append(candidates, value)
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(candidates) == 0 {
+		return models.ErrInvalidCallbackSignature
+	}
+	if age := v.clock.Now().Sub(time.Unix(unix, 0)); age > CallbackTolerance || age < -CallbackTolerance {
+		return models.ErrInvalidCallbackSignature
+	}
+
+	expected := mac(v.secret, timestamp+".", body)
+	for _, candidate := range candidates {
+		if sum, err := hex.DecodeString(candidate); err == nil && hmac.Equal(sum, expected) {
+			return nil
+		}
+	}
+	return models.ErrInvalidCallbackSignature
+}
+
+// verifyBody checks a hex HMAC-SHA256 of the body alone
+func (v *CallbackVerifier) verifyBody(signature string, body []byte) error {
+	sum, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(sum, mac(v.secret, "", body)) {
+		return models.ErrInvalidCallbackSignature
+	}
+	return nil
+}
+
+// mac is the HMAC-SHA256 of prefix followed by body
+func mac(secret []byte, prefix string, body []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(prefix))
+	h.Write(body)
+	return h.Sum(nil)
+}
+
+// stripeEvent is the subset of Stripe's Event object we read
+type stripeEvent struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object struct {
+			ID               string            `json:"id"`
+			Status           string            `json:"status"`
+			Amount           int64             `json:"amount"`
+			Currency         string            `json:"currency"`
+			PaymentIntent    string            `json:"payment_intent"` // Refunds only
+			Metadata         map[string]string `json:"metadata"`
+			LastPaymentError *struct {
+				Message string `json:"message"`
+			} `json:"last_payment_error"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+// parseStripeCallback reads PaymentIntent outcomes and finished refunds
+func parseStripeCallback(body []byte) (*models.GatewayCallback, error) {
+	var event stripeEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidGatewayCallback, err)
+	}
+
+	object := event.Data.Object
+	callback := &models.GatewayCallback{ID: event.ID, TransactionID: object.ID, PaymentID: object.Metadata["payment_id"]}
+	switch event.Type {
+	case "payment_intent.succeeded":
+		callback.Type = models.GatewayCallbackPaymentCaptured
+	case "payment_intent.payment_failed":
+		callback.Type = models.GatewayCallbackPaymentFailed
+		callback.Reason = "Stripe payment failed"
+		if object.LastPaymentError != nil {
+			callback.Reason = "Stripe payment failed: " + object.LastPaymentError.Message
+		}
+	case "refund.created", "refund.updated":
+		if object.Status != "succeeded" {
+			return nil, nil
+		}
+		callback.Type = models.GatewayCallbackRefundProcessed
+		callback.TransactionID = object.PaymentIntent
+		callback.RefundID = object.ID
+		callback.Amount = models.NewMoney(object.Amount, object.Currency)
+	default:
+		return nil, nil
+	}
+	return callback, nil
+}
+
+// razorpayEntity is the subset of Razorpay's payment and refund entities we read
+type razorpayEntity struct {
+	ID               string            `json:"id"`
+	PaymentID        string            `json:"payment_id"` // Refunds only
+	Amount           int64             `json:"amount"`
+	Currency         string            `json:"currency"`
+	Notes            map[string]string `json:"notes"`
+	ErrorDescription string            `json:"error_description"`
+}
+
+// razorpayEvent is the subset of Razorpay's webhook payload we read
+type razorpayEvent struct {
+	Event   string `json:"event"`
+	Payload struct {
+		Payment struct {
+			Entity razorpayEntity `json:"entity"`
+		} `json:"payment"`
+		Refund struct {
+			Entity razorpayEntity `json:"entity"`
+		} `json:"refund"`
+	} `json:"payload"`
+}
+
+// parseRazorpayCallback reads captured and failed payments and processed refunds
+func parseRazorpayCallback(eventID string, body []byte) (*models.GatewayCallback, error) {
+	var event razorpayEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidGatewayCallback, err)
+	}
+
+	payment := event.Payload.Payment.Entity
+	callback := &models.GatewayCallback{ID: eventID, TransactionID: payment.ID, PaymentID: payment.Notes["payment_id"]}
+	switch event.Event {
+	case "payment.captured":
+		callback.Type = models.GatewayCallbackPaymentCaptured
+	case "payment.failed":
+		callback.Type = models.GatewayCallbackPaymentFailed
+		callback.Reason = "Razorpay payment failed: " + payment.ErrorDescription
+	case "refund.processed":
+		refund := event.Payload.Refund.Entity
+		callback.Type = models.GatewayCallbackRefundProcessed
+		callback.TransactionID = refund.PaymentID
+		callback.RefundID = refund.ID
+		callback.Amount = models.NewMoney(refund.Amount, refund.Currency)
+	default:
+		return nil, nil
+	}
+	return callback, nil
+}
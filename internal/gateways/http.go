@@ -0,0 +1,48 @@
+package gateways
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// maxResponseBytes caps how much of a provider response is read
+const maxResponseBytes = 1 << 20
+
+// send performs one provider call bounded by timeout and decodes the JSON body into out.
+// It returns the HTTP status so adapters can tell declines (4xx) from outages (5xx, network). Failures after
+// the provider may have acted on the request, e.g. timeouts and unreadable answers, also match ErrOutcomeUnknown.
+func send(ctx context.Context, client HTTPClient, timeout time.Duration, req *http.Request, out any) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || os.IsTimeout(err) {
+			return 0, fmt.Errorf("%w: %w: %v", ErrProviderUnavailable, ErrOutcomeUnknown, err)
+		}
+		return 0, fmt.Errorf("%w: %v", ErrProviderUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusGatewayTimeout {
+		return resp.StatusCode, fmt.Errorf("%w: %w: status %d", ErrProviderUnavailable, ErrOutcomeUnknown, resp.StatusCode)
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return resp.StatusCode, fmt.Errorf("%w: status %d", ErrProviderUnavailable, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("%w: %w: %v", ErrProviderUnavailable, ErrOutcomeUnknown, err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return resp.StatusCode, fmt.Errorf("%w: %w: malformed response: %v", ErrProviderUnavailable, ErrOutcomeUnknown, err)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,141 @@
+package gateways
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Provider is an external payment processor the payment strategies delegate to - demonstrates Adapter Pattern
+type Provider interface {
+	Name() string
+	Supports(method models.PaymentMethod) bool
+	Charge(ctx context.Context, req ChargeRequest) (*Result, error)
+	Refund(ctx context.Context, reference string, amount models.Money) (*Result, error)
+	Query(ctx context.Context, idempotencyKey string) (*Result, error) // How the charge sent with the key went
+}
+
+// HTTPClient is the subset of *http.Client adapters need - swap in a fake to test without network
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// ChargeRequest is a provider-neutral payment request; amounts are sent in minor units
+type ChargeRequest struct {
+	Amount         models.Money
+	Method         models.PaymentMethod
+	IdempotencyKey string            // Our payment ID - a retried request never charges twice
+	Metadata       map[string]string // Method details and booking references from the payment service
+}
+
+// Result is a provider's answer; a decline is a Result with Success false, not an error
+type Result struct {
+	Success   bool
+	Pending   bool   // Query only: the provider hasn't finished with the charge yet
+	Reference string // Provider's payment or refund ID
+	Message   string
+}
+
+// Provider errors - transport failures, as opposed to declines
+var (
+	ErrProviderUnavailable = errors.New("payment provider unavailable")
+	ErrMethodNotSupported  = errors.New("payment method not supported by provider")
+	ErrInvalidConfig       = errors.New("invalid payment provider configuration")
+	ErrOutcomeUnknown      = errors.New("payment provider may have acted on the request") // e.g. it timed out
+)
+
+// Kind selects a provider implementation
+type Kind string
+
+const (
+	KindMock     Kind = "mock" // Built-in random mock in the strategies
+	KindRazorpay Kind = "razorpay"
+	KindStripe   Kind = "stripe"
+)
+
+// DefaultTimeout bounds every provider call
+const DefaultTimeout = 10 * time.Second
+
+// Config selects and configures the payment provider
+type Config struct {
+	Kind      Kind
+	KeyID     string // Razorpay key ID
+	KeySecret string // Razorpay key secret or Stripe secret key
+	BaseURL   string // Overrides the provider's API endpoint, e.g. for a local sandbox
+	Sandbox   bool   // Uses provider test tokens where raw card data can't be sent
+	Timeout   time.Duration
+
+	WebhookSecret string // Signs the provider's asynchronous callbacks; they are refused while it is unset
+}
+
+// ConfigFromEnv reads PAYMENT_PROVIDER (mock|razorpay|stripe), its credentials and PAYMENT_WEBHOOK_SECRET from
+// the environment
+func ConfigFromEnv() Config {
+	return ConfigFromEnvFor(Kind(os.Getenv("PAYMENT_PROVIDER")))
+}
+
+// ConfigFromEnvFor reads the kind provider's credentials and the other settings from the environment, for a
+// provider chosen some other way than PAYMENT_PROVIDER; the mock when kind is empty
+func ConfigFromEnvFor(kind Kind) Config {
+	cfg := Config{
+		Kind:    kind,
+		BaseURL: os.Getenv("PAYMENT_PROVIDER_BASE_URL"),
+		Sandbox: true,
+		Timeout: DefaultTimeout,
+
+		WebhookSecret: os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+	}
+	if cfg.Kind == "" {
+		cfg.Kind = KindMock
+	}
+
+	switch cfg.Kind {
+	case KindRazorpay:
+		cfg.KeyID = os.Getenv("RAZORPAY_KEY_ID")
+		cfg.KeySecret = os.Getenv("RAZORPAY_KEY_SECRET")
+	case KindStripe:
+		cfg.KeySecret = os.Getenv("STRIPE_SECRET_KEY")
+	}
+
+	if live, err := strconv.ParseBool(os.Getenv("PAYMENT_PROVIDER_LIVE")); err == nil {
+		cfg.Sandbox = !live
+	}
+	if timeout, err := time.ParseDuration(os.Getenv("PAYMENT_PROVIDER_TIMEOUT")); err == nil && timeout > 0 {
+		cfg.Timeout = timeout
+	}
+	return cfg
+}
+
+// New builds the configured provider; KindMock returns nil so strategies keep their built-in mock
+func New(cfg Config) (Provider, error) {
+	return NewWithClient(cfg, &http.Client{Timeout: cfg.Timeout})
+}
+
+// NewWithClient builds the configured provider around a caller-supplied HTTP client
+func NewWithClient(cfg Config, client HTTPClient) (Provider, error) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	switch cfg.Kind {
+	case KindMock, "":
+		return nil, nil
+	case KindRazorpay:
+		if cfg.KeyID == "" || cfg.KeySecret == "" {
+			return nil, fmt.Errorf("%w: razorpay needs RAZORPAY_KEY_ID and RAZORPAY_KEY_SECRET", ErrInvalidConfig)
+		}
+		return newRazorpay(cfg, client), nil
+	case KindStripe:
+		if cfg.KeySecret == "" {
+			return nil, fmt.Errorf("%w: stripe needs STRIPE_SECRET_KEY", ErrInvalidConfig)
+		}
+		return newStripe(cfg, client), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown provider %q", ErrInvalidConfig, cfg.Kind)
+	}
+}
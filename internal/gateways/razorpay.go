@@ -0,0 +1,211 @@
+package gateways
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// razorpayBaseURL is Razorpay's API; test-mode keys (rzp_test_...) make it a sandbox
+const razorpayBaseURL = "https://api.razorpay.com/v1"
+
+// razorpayQueryWindow is how many of the account's latest payments Query searches
+const razorpayQueryWindow = 100
+
+// razorpayProvider adapts Razorpay's server-to-server payments API to Provider
+type razorpayProvider struct {
+	cfg    Config
+	client HTTPClient
+}
+
+func newRazorpay(cfg Config, client HTTPClient) *razorpayProvider {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = razorpayBaseURL
+	}
+	return &razorpayProvider{cfg: cfg, client: client}
+}
+
+func (p *razorpayProvider) Name() string { return string(KindRazorpay) }
+
+// Supports reports the methods Razorpay's S2S API charges directly
+func (p *razorpayProvider) Supports(method models.PaymentMethod) bool {
+	switch method {
+	case models.PaymentMethodCreditCard, models.PaymentMethodDebitCard, models.PaymentMethodUPI:
+		return true
+	}
+	return false
+}
+
+// razorpayPayment is the subset of Razorpay's payment/refund entity we read
+type razorpayPayment struct {
+	ID        string `json:"id"`
+	PaymentID string `json:"razorpay_payment_id"`
+	Status    string `json:"status"`
+	Error     *struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+	} `json:"error,omitempty"`
+}
+
+// Charge creates the payment and captures it immediately
+func (p *razorpayProvider) Charge(ctx context.Context, req ChargeRequest) (*Result, error) {
+	body := map[string]any{
+		"amount":   req.Amount.Minor,
+		"currency": req.Amount.Currency,
+		"email":    req.Metadata["email"],
+		"contact":  req.Metadata["contact"],
+		"notes":    map[string]string{"booking_id": req.Metadata["booking_id"], "payment_id": req.IdempotencyKey},
+	}
+
+	switch req.Method {
+	case models.PaymentMethodCreditCard, models.PaymentMethodDebitCard:
+		month, year, _ := strings.Cut(req.Metadata["expiry"], "/")
+		body["method"] = "card"
+		body["card"] = map[string]string{
+			"number":       strings.ReplaceAll(req.Metadata["card_number"], "-", ""),
+			"cvv":          req.Metadata["cvv"],
+			"expiry_month": month,
+			"expiry_year":  year,
+		}
+	case models.PaymentMethodUPI:
+		body["method"] = "upi"
+		body["vpa"] = req.Metadata["upi_id"]
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrMethodNotSupported, req.Method)
+	}
+
+	var created razorpayPayment
+	status, err := p.post(ctx, "/payments/create/json", body, &created)
+	if err != nil {
+		return nil, err
+	}
+	if result := razorpayDecline(status, created); result != nil {
+		return result, nil
+	}
+
+	paymentID := created.PaymentID
+	if paymentID == "" {
+		paymentID = created.ID
+	}
+
+	var captured razorpayPayment
+	status, err = p.post(ctx, "/payments/"+paymentID+"/capture", map[string]any{
+		"amount":   req.Amount.Minor,
+		"currency": req.Amount.Currency,
+	}, &captured)
+	if err != nil {
+		return nil, err
+	}
+	if result := razorpayDecline(status, captured); result != nil {
+		return result, nil
+	}
+
+	return &Result{
+		Success:   captured.Status == "captured",
+		Reference: paymentID,
+		Message:   "Razorpay payment " + captured.Status,
+	}, nil
+}
+
+// Refund returns part or all of a captured payment
+func (p *razorpayProvider) Refund(ctx context.Context, reference string, amount models.Money) (*Result, error) {
+	var refund razorpayPayment
+	status, err := p.post(ctx, "/payments/"+reference+"/refund", map[string]any{"amount": amount.Minor}, &refund)
+	if err != nil {
+		return nil, err
+	}
+	if result := razorpayDecline(status, refund); result != nil {
+		return result, nil
+	}
+
+	return &Result{
+		Success:   refund.Status == "processed" || refund.Status == "pending",
+		Reference: refund.ID,
+		Message:   "Razorpay refund " + refund.Status,
+	}, nil
+}
+
+// razorpayListed is a payment from Razorpay's payment list, with the notes Charge attached
+type razorpayListed struct {
+	ID     string          `json:"id"`
+	Status string          `json:"status"`
+	Notes  json.RawMessage `json:"notes"` // An object, or [] when the payment has none
+}
+
+// Query looks for the charge made with the idempotency key among the account's latest payments, by the
+// payment_id note Charge sets - Razorpay can't look payments up by note. One not found yet is reported pending.
+func (p *razorpayProvider) Query(ctx context.Context, idempotencyKey string) (*Result, error) {
+	var page struct {
+		Items []razorpayListed `json:"items"`
+	}
+	status, err := p.get(ctx, "/payments?count="+strconv.Itoa(razorpayQueryWindow), &page)
+	if err != nil {
+		return nil, err
+	}
+	if status >= http.StatusBadRequest {
+		return nil, fmt.Errorf("%w: listing payments failed with status %d", ErrProviderUnavailable, status)
+	}
+
+	for _, payment := range page.Items {
+		var notes map[string]string
+		if json.Unmarshal(payment.Notes, &notes) != nil || notes["payment_id"] != idempotencyKey {
+			continue
+		}
+
+		result := &Result{Reference: payment.ID, Message: "Razorpay payment " + payment.Status}
+		switch payment.Status {
+		case "captured":
+			result.Success = true
+		case "failed", "refunded":
+		default: // created or authorized - not captured yet
+			result.Pending = true
+		}
+		return result, nil
+	}
+	return &Result{Pending: true, Message: "Razorpay has no payment for " + idempotencyKey + " yet"}, nil
+}
+
+// get sends a read-only request with basic auth
+func (p *razorpayProvider) get(ctx context.Context, path string, out any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.BaseURL+path, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.SetBasicAuth(p.cfg.KeyID, p.cfg.KeySecret)
+
+	return send(ctx, p.client, p.cfg.Timeout, req, out)
+}
+
+// post sends a JSON request with basic auth
+func (p *razorpayProvider) post(ctx context.Context, path string, body any, out *razorpayPayment) (int, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.BaseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.SetBasicAuth(p.cfg.KeyID, p.cfg.KeySecret)
+	req.Header.Set("Content-Type", "application/json")
+
+	return send(ctx, p.client, p.cfg.Timeout, req, out)
+}
+
+// razorpayDecline turns a 4xx error body into a failed Result
+func razorpayDecline(status int, payment razorpayPayment) *Result {
+	if status < http.StatusBadRequest {
+		return nil
+	}
+	message := fmt.Sprintf("Razorpay declined (status %d)", status)
+	if payment.Error != nil {
+		message = "Razorpay declined: " + payment.Error.Description
+	}
+	return &Result{Success: false, Message: message}
+}
@@ -0,0 +1,184 @@
+package gateways
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// stripeBaseURL is Stripe's API; test-mode keys (sk_test_...) make it a sandbox
+const stripeBaseURL = "https://api.stripe.com/v1"
+
+// stripeTestPaymentMethod is Stripe's always-succeeding test card, used in sandbox mode
+const stripeTestPaymentMethod = "pm_card_visa"
+
+// stripeProvider adapts Stripe PaymentIntents to Provider
+type stripeProvider struct {
+	cfg    Config
+	client HTTPClient
+}
+
+func newStripe(cfg Config, client HTTPClient) *stripeProvider {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = stripeBaseURL
+	}
+	return &stripeProvider{cfg: cfg, client: client}
+}
+
+func (p *stripeProvider) Name() string { return string(KindStripe) }
+
+// Supports reports the methods Stripe can charge - it has no UPI
+func (p *stripeProvider) Supports(method models.PaymentMethod) bool {
+	return method == models.PaymentMethodCreditCard || method == models.PaymentMethodDebitCard
+}
+
+// stripeObject is the subset of Stripe's PaymentIntent/Refund objects we read
+type stripeObject struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Charge creates and confirms a PaymentIntent in one call
+func (p *stripeProvider) Charge(ctx context.Context, req ChargeRequest) (*Result, error) {
+	if !p.Supports(req.Method) {
+		return nil, fmt.Errorf("%w: %s", ErrMethodNotSupported, req.Method)
+	}
+
+	// Raw card numbers never go to Stripe - the client tokenizes them into a payment method
+	paymentMethod := req.Metadata["payment_method_token"]
+	if paymentMethod == "" {
+		if !p.cfg.Sandbox {
+			return &Result{Success: false, Message: "Stripe requires a tokenized payment method"}, nil
+		}
+		paymentMethod = stripeTestPaymentMethod
+	}
+
+	form := url.Values{
+		"amount":                             {strconv.FormatInt(req.Amount.Minor, 10)},
+		"currency":                           {strings.ToLower(req.Amount.Currency)},
+		"payment_method":                     {paymentMethod},
+		"confirm":                            {"true"},
+		"automatic_payment_methods[enabled]": {"true"},
+		"automatic_payment_methods[allow_redirects]": {"never"},
+		"metadata[booking_id]":                       {req.Metadata["booking_id"]},
+		"metadata[payment_id]":                       {req.IdempotencyKey},
+	}
+
+	var intent stripeObject
+	status, err := p.post(ctx, "/payment_intents", req.IdempotencyKey, form, &intent)
+	if err != nil {
+		return nil, err
+	}
+	if result := stripeDecline(status, intent); result != nil {
+		return result, nil
+	}
+
+	return &Result{
+		Success:   intent.Status == "succeeded",
+		Reference: intent.ID,
+		Message:   "Stripe payment " + intent.Status,
+	}, nil
+}
+
+// Refund refunds part or all of a PaymentIntent
+func (p *stripeProvider) Refund(ctx context.Context, reference string, amount models.Money) (*Result, error) {
+	form := url.Values{
+		"payment_intent": {reference},
+		"amount":         {strconv.FormatInt(amount.Minor, 10)},
+	}
+
+	var refund stripeObject
+	status, err := p.post(ctx, "/refunds", "", form, &refund)
+	if err != nil {
+		return nil, err
+	}
+	if result := stripeDecline(status, refund); result != nil {
+		return result, nil
+	}
+
+	return &Result{
+		Success:   refund.Status == "succeeded" || refund.Status == "pending",
+		Reference: refund.ID,
+		Message:   "Stripe refund " + refund.Status,
+	}, nil
+}
+
+// stripeSearch is a page of PaymentIntent search results
+type stripeSearch struct {
+	Data []stripeObject `json:"data"`
+}
+
+// Query finds the PaymentIntent Charge created for the idempotency key through its payment_id metadata. Search
+// lags up to a minute behind new intents, so one not found yet is reported pending.
+func (p *stripeProvider) Query(ctx context.Context, idempotencyKey string) (*Result, error) {
+	query := url.Values{"query": {fmt.Sprintf("metadata['payment_id']:'%s'", idempotencyKey)}}
+
+	var found stripeSearch
+	status, err := p.get(ctx, "/payment_intents/search?"+query.Encode(), &found)
+	if err != nil {
+		return nil, err
+	}
+	if status >= http.StatusBadRequest {
+		return nil, fmt.Errorf("%w: search failed with status %d", ErrProviderUnavailable, status)
+	}
+	if len(found.Data) == 0 {
+		return &Result{Pending: true, Message: "Stripe has no payment for " + idempotencyKey + " yet"}, nil
+	}
+
+	intent := found.Data[0]
+	result := &Result{Reference: intent.ID, Message: "Stripe payment " + intent.Status}
+	switch intent.Status {
+	case "succeeded":
+		result.Success = true
+	case "requires_payment_method", "canceled":
+	default: // processing, requires_action and the like
+		result.Pending = true
+	}
+	return result, nil
+}
+
+// post sends a form-encoded request with bearer auth and Stripe's Idempotency-Key header
+func (p *stripeProvider) post(ctx context.Context, path, idempotencyKey string, form url.Values, out *stripeObject) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.BaseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.cfg.KeySecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	return send(ctx, p.client, p.cfg.Timeout, req, out)
+}
+
+// get sends a read-only request with bearer auth
+func (p *stripeProvider) get(ctx context.Context, path string, out any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.BaseURL+path, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.cfg.KeySecret)
+
+	return send(ctx, p.client, p.cfg.Timeout, req, out)
+}
+
+// stripeDecline turns a 4xx error body (e.g. card_declined) into a failed Result
+func stripeDecline(status int, object stripeObject) *Result {
+	if status < http.StatusBadRequest {
+		return nil
+	}
+	message := fmt.Sprintf("Stripe declined (status %d)", status)
+	if object.Error != nil {
+		message = "Stripe declined: " + object.Error.Message
+	}
+	return &Result{Success: false, Message: message}
+}
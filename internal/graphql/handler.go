@@ -0,0 +1,20 @@
+package graphql
+
+import (
+	"bookmyshow-lld/internal/services"
+	"net/http"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+// maxDepth caps how deeply a query may nest, e.g. city > nowShowing > movie > shows > seatMap > rows > cells > price
+const maxDepth = 12
+
+// NewHandler serves GraphQL queries over the services, POSTed as {"query": ..., "variables": ...} -
+// demonstrates Facade Pattern: one query walks movies, shows, seat maps and bookings that take several
+// REST calls, and each nested field resolves through the same services the REST handlers use
+func NewHandler(movies services.MovieService, events services.EventService, theatres services.TheatreService, shows services.ShowService, bookings services.BookingService) http.Handler {
+	root := &Resolver{movies: movies, events: events, theatres: theatres, shows: shows, bookings: bookings}
+	return &relay.Handler{Schema: graphqlgo.MustParseSchema(schema, root, graphqlgo.MaxDepth(maxDepth))}
+}
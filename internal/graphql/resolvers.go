@@ -0,0 +1,678 @@
+package graphql
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+// Resolver answers the Query fields; every nested resolver keeps it to reach the services
+type Resolver struct {
+	movies   services.MovieService
+	events   services.EventService
+	theatres services.TheatreService
+	shows    services.ShowService
+	bookings services.BookingService
+}
+
+func (r *Resolver) Movies(ctx context.Context) ([]*movieResolver, error) {
+	movies, err := r.movies.GetReleasedMovies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.movieList(movies), nil
+}
+
+func (r *Resolver) Movie(ctx context.Context, args struct{ ID graphqlgo.ID }) (*movieResolver, error) {
+	movie, err := r.movies.GetMovie(ctx, string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &movieResolver{r, movie}, nil
+}
+
+func (r *Resolver) Event(ctx context.Context, args struct{ ID graphqlgo.ID }) (*eventResolver, error) {
+	event, err := r.events.GetEvent(ctx, string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &eventResolver{r, event}, nil
+}
+
+func (r *Resolver) Cities(ctx context.Context) ([]*cityResolver, error) {
+	cities, err := r.theatres.GetCities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*cityResolver, len(cities))
+	for i, city := range cities {
+		resolvers[i] = &cityResolver{r, city}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) City(ctx context.Context, args struct{ Name string }) (*cityResolver, error) {
+	cities, err := r.theatres.GetCities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, city := range cities {
+		if strings.EqualFold(city.Name, args.Name) {
+			return &cityResolver{r, city}, nil
+		}
+	}
+	return nil, models.ErrCityNotFound
+}
+
+func (r *Resolver) Theatre(ctx context.Context, args struct{ ID graphqlgo.ID }) (*theatreResolver, error) {
+	theatre, err := r.theatres.GetTheatre(ctx, string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &theatreResolver{r, theatre}, nil
+}
+
+func (r *Resolver) Show(ctx context.Context, args struct{ ID graphqlgo.ID }) (*showResolver, error) {
+	show, err := r.shows.GetShow(ctx, string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &showResolver{root: r, show: show}, nil
+}
+
+// showsArgs narrows a movie's bookable shows
+type showsArgs struct {
+	City     *string
+	Language *string
+	Format   *string
+}
+
+func (a showsArgs) filter(movieID string) services.ShowFilter {
+	filter := services.ShowFilter{MovieID: movieID}
+	if a.City != nil {
+		filter.City = *a.City
+	}
+	if a.Language != nil {
+		filter.Language = models.Language(*a.Language)
+	}
+	if a.Format != nil {
+		filter.Format = models.ShowFormat(strings.ToUpper(*a.Format))
+	}
+	return filter
+}
+
+func (r *Resolver) Shows(ctx context.Context, args struct {
+	MovieID graphqlgo.ID
+	showsArgs
+}) ([]*showResolver, error) {
+	shows, err := r.shows.SearchShows(ctx, args.showsArgs.filter(string(args.MovieID)))
+	if err != nil {
+		return nil, err
+	}
+	return r.showList(shows), nil
+}
+
+func (r *Resolver) NowShowing(ctx context.Context, args struct {
+	City *string
+	Date *graphqlgo.Time
+}) ([]*nowShowingResolver, error) {
+	city := ""
+	if args.City != nil {
+		city = *args.City
+	}
+	return r.nowShowing(ctx, city, args.Date)
+}
+
+func (r *Resolver) Booking(ctx context.Context, args struct {
+	ID        *graphqlgo.ID
+	Reference *string
+}) (*bookingResolver, error) {
+	var (
+		booking *models.Booking
+		err     error
+	)
+	switch {
+	case args.ID != nil && args.Reference == nil:
+		booking, err = r.bookings.GetBooking(ctx, string(*args.ID))
+	case args.Reference != nil && args.ID == nil:
+		booking, err = r.bookings.GetBookingByReference(ctx, *args.Reference)
+	default:
+		return nil, errors.New("booking needs exactly one of id and reference")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &bookingResolver{root: r, booking: booking}, nil
+}
+
+// nowShowing lists the movies bookable in the city on the date, today when date is nil
+func (r *Resolver) nowShowing(ctx context.Context, city string, date *graphqlgo.Time) ([]*nowShowingResolver, error) {
+	day := time.Now()
+	if date != nil {
+		day = date.Time
+	}
+	listings, err := r.shows.GetNowShowing(ctx, city, day)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*nowShowingResolver, len(listings))
+	for i, listing := range listings {
+		resolvers[i] = &nowShowingResolver{r, listing}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) movieList(movies []*models.Movie) []*movieResolver {
+	resolvers := make([]*movieResolver, len(movies))
+	for i, movie := range movies {
+		resolvers[i] = &movieResolver{r, movie}
+	}
+	return resolvers
+}
+
+func (r *Resolver) showList(shows []*models.Show) []*showResolver {
+	resolvers := make([]*showResolver, len(shows))
+	for i, show := range shows {
+		resolvers[i] = &showResolver{root: r, show: show}
+	}
+	return resolvers
+}
+
+// moneyResolver resolves Money
+type moneyResolver struct{ money models.Money }
+
+func (m moneyResolver) MinorUnits() float64 { return float64(m.money.Minor) }
+func (m moneyResolver) Currency() string    { return m.money.Currency }
+func (m moneyResolver) Formatted() string   { return m.money.String() }
+
+// cityResolver resolves City
+type cityResolver struct {
+	root *Resolver
+	city *models.City
+}
+
+func (c *cityResolver) ID() graphqlgo.ID { return graphqlgo.ID(c.city.ID) }
+func (c *cityResolver) Name() string     { return c.city.Name }
+func (c *cityResolver) Region() *string  { return optional(c.city.Region) }
+
+func (c *cityResolver) Theatres(ctx context.Context) ([]*theatreResolver, error) {
+	theatres, err := c.root.theatres.GetTheatresByCity(ctx, c.city.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*theatreResolver, len(theatres))
+	for i, theatre := range theatres {
+		resolvers[i] = &theatreResolver{c.root, theatre}
+	}
+	return resolvers, nil
+}
+
+func (c *cityResolver) NowShowing(ctx context.Context, args struct{ Date *graphqlgo.Time }) ([]*nowShowingResolver, error) {
+	return c.root.nowShowing(ctx, c.city.Name, args.Date)
+}
+
+// movieResolver resolves Movie
+type movieResolver struct {
+	root  *Resolver
+	movie *models.Movie
+}
+
+func (m *movieResolver) ID() graphqlgo.ID       { return graphqlgo.ID(m.movie.ID) }
+func (m *movieResolver) Title() string          { return m.movie.Title }
+func (m *movieResolver) Description() string    { return m.movie.Description }
+func (m *movieResolver) DurationMinutes() int32 { return int32(m.movie.Duration / time.Minute) }
+func (m *movieResolver) Genre() string          { return string(m.movie.Genre) }
+func (m *movieResolver) Language() string       { return string(m.movie.Language) }
+func (m *movieResolver) Certificate() *string   { return optional(string(m.movie.Certificate)) }
+func (m *movieResolver) Rating() float64        { return float64(m.movie.Rating) }
+func (m *movieResolver) ReviewCount() int32     { return int32(m.movie.ReviewCount) }
+func (m *movieResolver) ReleaseDate() graphqlgo.Time {
+	return graphqlgo.Time{Time: m.movie.ReleaseDate}
+}
+func (m *movieResolver) PosterURL() *string { return optional(m.movie.PosterURL) }
+
+func (m *movieResolver) Genres() []string {
+	if len(m.movie.Genres) == 0 {
+		return []string{string(m.movie.Genre)}
+	}
+	genres := make([]string, len(m.movie.Genres))
+	for i, genre := range m.movie.Genres {
+		genres[i] = string(genre)
+	}
+	return genres
+}
+
+func (m *movieResolver) Shows(ctx context.Context, args showsArgs) ([]*showResolver, error) {
+	shows, err := m.root.shows.SearchShows(ctx, args.filter(m.movie.ID))
+	if err != nil {
+		return nil, err
+	}
+	return m.root.showList(shows), nil
+}
+
+// eventResolver resolves Event
+type eventResolver struct {
+	root  *Resolver
+	event *models.Event
+}
+
+func (e *eventResolver) ID() graphqlgo.ID       { return graphqlgo.ID(e.event.ID) }
+func (e *eventResolver) Title() string          { return e.event.Title }
+func (e *eventResolver) Description() string    { return e.event.Description }
+func (e *eventResolver) Type() string           { return string(e.event.Type) }
+func (e *eventResolver) DurationMinutes() int32 { return int32(e.event.Duration / time.Minute) }
+func (e *eventResolver) Language() string       { return string(e.event.Language) }
+func (e *eventResolver) Performers() []string   { return append([]string{}, e.event.Performers...) }
+
+func (e *eventResolver) Shows(ctx context.Context) ([]*showResolver, error) {
+	shows, err := e.root.shows.GetShowsByEvent(ctx, e.event.ID)
+	if err != nil {
+		return nil, err
+	}
+	return e.root.showList(shows), nil
+}
+
+// theatreResolver resolves Theatre
+type theatreResolver struct {
+	root    *Resolver
+	theatre *models.Theatre
+}
+
+func (t *theatreResolver) ID() graphqlgo.ID { return graphqlgo.ID(t.theatre.ID) }
+func (t *theatreResolver) Name() string     { return t.theatre.Name }
+func (t *theatreResolver) Address() string  { return t.theatre.Address }
+func (t *theatreResolver) City() string     { return t.theatre.City }
+
+func (t *theatreResolver) Screens() []*screenResolver {
+	screens := t.theatre.GetAllScreens()
+	resolvers := make([]*screenResolver, len(screens))
+	for i, screen := range screens {
+		resolvers[i] = &screenResolver{screen}
+	}
+	return resolvers
+}
+
+// screenResolver resolves Screen
+type screenResolver struct{ screen *models.Screen }
+
+func (s *screenResolver) ID() graphqlgo.ID { return graphqlgo.ID(s.screen.ID) }
+func (s *screenResolver) Name() string     { return s.screen.Name }
+func (s *screenResolver) Capacity() int32  { return int32(s.screen.Capacity) }
+func (s *screenResolver) Status() string   { return string(s.screen.Status) }
+
+// showResolver resolves Show; its theatre is looked up once however many fields need it
+type showResolver struct {
+	root *Resolver
+	show *models.Show
+
+	theatreOnce sync.Once
+	theatre     *models.Theatre
+	theatreErr  error
+}
+
+func (s *showResolver) ID() graphqlgo.ID          { return graphqlgo.ID(s.show.ID) }
+func (s *showResolver) EventType() string         { return string(s.show.EventType) }
+func (s *showResolver) Format() *string           { return optional(string(s.show.Format)) }
+func (s *showResolver) Language() *string         { return optional(string(s.show.Language)) }
+func (s *showResolver) StartTime() graphqlgo.Time { return graphqlgo.Time{Time: s.show.StartTime} }
+func (s *showResolver) EndTime() graphqlgo.Time   { return graphqlgo.Time{Time: s.show.EndTime} }
+func (s *showResolver) BasePrice() moneyResolver  { return moneyResolver{s.show.BasePrice} }
+func (s *showResolver) Status() string            { return string(s.show.Status) }
+
+func (s *showResolver) Movie(ctx context.Context) (*movieResolver, error) {
+	if s.show.MovieID == "" {
+		return nil, nil
+	}
+	return s.root.Movie(ctx, struct{ ID graphqlgo.ID }{graphqlgo.ID(s.show.MovieID)})
+}
+
+func (s *showResolver) Event(ctx context.Context) (*eventResolver, error) {
+	if s.show.EventID == "" {
+		return nil, nil
+	}
+	return s.root.Event(ctx, struct{ ID graphqlgo.ID }{graphqlgo.ID(s.show.EventID)})
+}
+
+func (s *showResolver) Theatre(ctx context.Context) (*theatreResolver, error) {
+	theatre, err := s.loadTheatre(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &theatreResolver{s.root, theatre}, nil
+}
+
+func (s *showResolver) Screen(ctx context.Context) (*screenResolver, error) {
+	theatre, err := s.loadTheatre(ctx)
+	if err != nil {
+		return nil, err
+	}
+	screen, err := theatre.GetScreen(s.show.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+	return &screenResolver{screen}, nil
+}
+
+func (s *showResolver) SeatMap(ctx context.Context) (*seatMapResolver, error) {
+	seatMap, err := s.root.shows.GetSeatAvailability(ctx, s.show.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &seatMapResolver{seatMap}, nil
+}
+
+func (s *showResolver) Availability(ctx context.Context) (*availabilityResolver, error) {
+	summary, err := s.root.shows.GetAvailabilitySummary(ctx, s.show.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &availabilityResolver{summary}, nil
+}
+
+func (s *showResolver) loadTheatre(ctx context.Context) (*models.Theatre, error) {
+	s.theatreOnce.Do(func() {
+		s.theatre, s.theatreErr = s.root.theatres.GetTheatre(ctx, s.show.TheatreID)
+	})
+	return s.theatre, s.theatreErr
+}
+
+// seatMapResolver resolves SeatMap
+type seatMapResolver struct{ seatMap *models.SeatMap }
+
+func (m *seatMapResolver) Capacity() int32  { return int32(m.seatMap.Capacity) }
+func (m *seatMapResolver) Available() int32 { return int32(m.seatMap.Available) }
+
+func (m *seatMapResolver) Rows() []*seatRowResolver {
+	rows := make([]*seatRowResolver, len(m.seatMap.Rows))
+	for i := range m.seatMap.Rows {
+		rows[i] = &seatRowResolver{&m.seatMap.Rows[i]}
+	}
+	return rows
+}
+
+// seatRowResolver resolves SeatRow
+type seatRowResolver struct{ row *models.SeatMapRow }
+
+func (r *seatRowResolver) Name() string     { return r.row.Name }
+func (r *seatRowResolver) Section() *string { return optional(r.row.Section) }
+func (r *seatRowResolver) Y() *int32        { return optionalInt(r.row.Y) }
+
+func (r *seatRowResolver) Cells() []*seatCellResolver {
+	cells := make([]*seatCellResolver, len(r.row.Cells))
+	for i := range r.row.Cells {
+		cells[i] = &seatCellResolver{&r.row.Cells[i]}
+	}
+	return cells
+}
+
+// seatCellResolver resolves SeatCell; an aisle gap leaves every other field null, a missing seat all but number
+type seatCellResolver struct{ cell *models.SeatMapCell }
+
+func (c *seatCellResolver) Aisle() bool     { return c.cell.Aisle }
+func (c *seatCellResolver) Gap() bool       { return c.cell.Gap }
+func (c *seatCellResolver) X() *int32       { return optionalInt(c.cell.X) }
+func (c *seatCellResolver) Label() *string  { return optional(c.cell.Label) }
+func (c *seatCellResolver) Type() *string   { return optional(string(c.cell.Type)) }
+func (c *seatCellResolver) Status() *string { return optional(string(c.cell.Status)) }
+func (c *seatCellResolver) Group() *string  { return optional(c.cell.Group) }
+
+func (c *seatCellResolver) SeatID() *graphqlgo.ID {
+	if c.cell.SeatID == "" {
+		return nil
+	}
+	id := graphqlgo.ID(c.cell.SeatID)
+	return &id
+}
+
+func (c *seatCellResolver) Number() *int32 {
+	if c.cell.Aisle {
+		return nil
+	}
+	number := int32(c.cell.Number)
+	return &number
+}
+
+func (c *seatCellResolver) Price() *moneyResolver {
+	if !c.cell.IsSeat() {
+		return nil
+	}
+	return &moneyResolver{c.cell.Price}
+}
+
+// availabilityResolver resolves Availability
+type availabilityResolver struct{ summary *services.AvailabilitySummary }
+
+func (a *availabilityResolver) Total() int32     { return int32(a.summary.Total) }
+func (a *availabilityResolver) Available() int32 { return int32(a.summary.Available) }
+func (a *availabilityResolver) Blocked() int32   { return int32(a.summary.Blocked) }
+func (a *availabilityResolver) Booked() int32    { return int32(a.summary.Booked) }
+func (a *availabilityResolver) Withheld() int32  { return int32(a.summary.Withheld) }
+func (a *availabilityResolver) Badge() string    { return string(a.summary.Badge) }
+
+// nowShowingResolver resolves NowShowing
+type nowShowingResolver struct {
+	root    *Resolver
+	listing *services.NowShowingMovie
+}
+
+func (n *nowShowingResolver) Movie() *movieResolver { return &movieResolver{n.root, n.listing.Movie} }
+func (n *nowShowingResolver) ShowCount() int32      { return int32(n.listing.Shows) }
+func (n *nowShowingResolver) TheatreCount() int32   { return int32(n.listing.Theatres) }
+func (n *nowShowingResolver) FirstShow() graphqlgo.Time {
+	return graphqlgo.Time{Time: n.listing.FirstShow}
+}
+
+func (n *nowShowingResolver) Formats() []string {
+	formats := make([]string, len(n.listing.Formats))
+	for i, format := range n.listing.Formats {
+		formats[i] = string(format)
+	}
+	return formats
+}
+
+func (n *nowShowingResolver) Languages() []string {
+	languages := make([]string, len(n.listing.Languages))
+	for i, language := range n.listing.Languages {
+		languages[i] = string(language)
+	}
+	return languages
+}
+
+// bookingResolver resolves Booking. The show, theatre, seats, price and payment all come from one
+// BookingDetails, loaded the first time a field needs it.
+type bookingResolver struct {
+	root    *Resolver
+	booking *models.Booking
+
+	detailsOnce sync.Once
+	details     *services.BookingDetails
+	detailsErr  error
+}
+
+func (b *bookingResolver) ID() graphqlgo.ID     { return graphqlgo.ID(b.booking.ID) }
+func (b *bookingResolver) Reference() string    { return b.booking.Reference }
+func (b *bookingResolver) Status() string       { return string(b.booking.GetStatus()) }
+func (b *bookingResolver) UserID() graphqlgo.ID { return graphqlgo.ID(b.booking.UserID) }
+func (b *bookingResolver) BookingTime() graphqlgo.Time {
+	return graphqlgo.Time{Time: b.booking.BookingTime}
+}
+func (b *bookingResolver) ExpiryTime() graphqlgo.Time {
+	return graphqlgo.Time{Time: b.booking.ExpiryTime}
+}
+
+func (b *bookingResolver) SeatIDs(ctx context.Context) ([]graphqlgo.ID, error) {
+	details, err := b.loadDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphqlgo.ID, len(details.Booking.SeatIDs))
+	for i, id := range details.Booking.SeatIDs {
+		ids[i] = graphqlgo.ID(id)
+	}
+	return ids, nil
+}
+
+func (b *bookingResolver) TotalAmount(ctx context.Context) (moneyResolver, error) {
+	details, err := b.loadDetails(ctx)
+	if err != nil {
+		return moneyResolver{}, err
+	}
+	return moneyResolver{details.PriceBreakdown.Total}, nil
+}
+
+func (b *bookingResolver) Show(ctx context.Context) (*showResolver, error) {
+	details, err := b.loadDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &showResolver{root: b.root, show: details.Show}, nil
+}
+
+func (b *bookingResolver) Movie(ctx context.Context) (*movieResolver, error) {
+	details, err := b.loadDetails(ctx)
+	if err != nil || details.Movie == nil {
+		return nil, err
+	}
+	return &movieResolver{b.root, details.Movie}, nil
+}
+
+func (b *bookingResolver) Event(ctx context.Context) (*eventResolver, error) {
+	details, err := b.loadDetails(ctx)
+	if err != nil || details.Event == nil {
+		return nil, err
+	}
+	return &eventResolver{b.root, details.Event}, nil
+}
+
+func (b *bookingResolver) Theatre(ctx context.Context) (*theatreResolver, error) {
+	details, err := b.loadDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &theatreResolver{b.root, details.Theatre}, nil
+}
+
+func (b *bookingResolver) Screen(ctx context.Context) (*screenResolver, error) {
+	details, err := b.loadDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &screenResolver{details.Screen}, nil
+}
+
+func (b *bookingResolver) Seats(ctx context.Context) ([]*seatResolver, error) {
+	details, err := b.loadDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seats := make([]*seatResolver, len(details.Seats))
+	for i, seat := range details.Seats {
+		seats[i] = &seatResolver{seat}
+	}
+	return seats, nil
+}
+
+func (b *bookingResolver) LineItems(ctx context.Context) ([]*lineItemResolver, error) {
+	details, err := b.loadDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*lineItemResolver, len(details.LineItems))
+	for i := range details.LineItems {
+		items[i] = &lineItemResolver{details.LineItems[i]}
+	}
+	return items, nil
+}
+
+func (b *bookingResolver) PriceBreakdown(ctx context.Context) (*priceBreakdownResolver, error) {
+	details, err := b.loadDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &priceBreakdownResolver{details.PriceBreakdown}, nil
+}
+
+func (b *bookingResolver) Payment(ctx context.Context) (*paymentResolver, error) {
+	details, err := b.loadDetails(ctx)
+	if err != nil || details.Payment == nil {
+		return nil, err
+	}
+	return &paymentResolver{details.Payment}, nil
+}
+
+func (b *bookingResolver) loadDetails(ctx context.Context) (*services.BookingDetails, error) {
+	b.detailsOnce.Do(func() {
+		b.details, b.detailsErr = b.root.bookings.GetBookingDetails(ctx, b.booking.ID)
+	})
+	return b.details, b.detailsErr
+}
+
+// seatResolver resolves Seat
+type seatResolver struct{ seat *models.Seat }
+
+func (s *seatResolver) ID() graphqlgo.ID { return graphqlgo.ID(s.seat.ID) }
+func (s *seatResolver) Label() string    { return s.seat.GetSeatNumber() }
+func (s *seatResolver) Row() string      { return s.seat.RowName }
+func (s *seatResolver) Number() int32    { return int32(s.seat.Number) }
+func (s *seatResolver) Type() string     { return string(s.seat.Type) }
+
+// lineItemResolver resolves LineItem
+type lineItemResolver struct{ item services.LineItem }
+
+func (l *lineItemResolver) Description() string   { return l.item.Description }
+func (l *lineItemResolver) Amount() moneyResolver { return moneyResolver{l.item.Amount} }
+
+// priceBreakdownResolver resolves PriceBreakdown
+type priceBreakdownResolver struct{ price models.PriceBreakdown }
+
+func (p *priceBreakdownResolver) Subtotal() moneyResolver { return moneyResolver{p.price.Subtotal} }
+func (p *priceBreakdownResolver) Discount() moneyResolver { return moneyResolver{p.price.Discount} }
+func (p *priceBreakdownResolver) ConvenienceFee() moneyResolver {
+	return moneyResolver{p.price.ConvenienceFee}
+}
+func (p *priceBreakdownResolver) Cgst() moneyResolver  { return moneyResolver{p.price.CGST} }
+func (p *priceBreakdownResolver) Sgst() moneyResolver  { return moneyResolver{p.price.SGST} }
+func (p *priceBreakdownResolver) LoyaltyPoints() int32 { return int32(p.price.LoyaltyPoints) }
+func (p *priceBreakdownResolver) LoyaltyValue() moneyResolver {
+	return moneyResolver{p.price.LoyaltyValue}
+}
+func (p *priceBreakdownResolver) Total() moneyResolver { return moneyResolver{p.price.Total} }
+
+// paymentResolver resolves Payment
+type paymentResolver struct{ payment *models.Payment }
+
+func (p *paymentResolver) ID() graphqlgo.ID       { return graphqlgo.ID(p.payment.ID) }
+func (p *paymentResolver) Amount() moneyResolver  { return moneyResolver{p.payment.Amount} }
+func (p *paymentResolver) Method() string         { return string(p.payment.Method) }
+func (p *paymentResolver) Status() string         { return string(p.payment.Status) }
+func (p *paymentResolver) TransactionID() *string { return optional(p.payment.TransactionID) }
+
+func (p *paymentResolver) ProcessedAt() *graphqlgo.Time {
+	if p.payment.ProcessedAt == nil {
+		return nil
+	}
+	return &graphqlgo.Time{Time: *p.payment.ProcessedAt}
+}
+
+// optional maps an empty string to null
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// optionalInt is optional for numbers that are zero when unset
+func optionalInt(value int) *int32 {
+	if value == 0 {
+		return nil
+	}
+	n := int32(value)
+	return &n
+}
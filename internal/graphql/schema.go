@@ -0,0 +1,204 @@
+package graphql
+
+// schema is the read-only graph over the catalog, shows and bookings. Fields that need another lookup - a
+// show's theatre, a movie's shows, a booking's payment - are only resolved when the query asks for them.
+const schema = `
+scalar Time
+
+schema {
+	query: Query
+}
+
+type Query {
+	# Released movies
+	movies: [Movie!]!
+	movie(id: ID!): Movie
+	event(id: ID!): Event
+	cities: [City!]!
+	# A city by name, ignoring case
+	city(name: String!): City
+	theatre(id: ID!): Theatre
+	show(id: ID!): Show
+	# Bookable shows of a movie, soonest first
+	shows(movieId: ID!, city: String, language: String, format: String): [Show!]!
+	# Movies bookable in the city on the date, today when none is given; no city lists every city
+	nowShowing(city: String, date: Time): [NowShowing!]!
+	# A booking by ID or by its reference, e.g. BMS-7F3K9Q
+	booking(id: ID, reference: String): Booking
+}
+
+type Money {
+	minorUnits: Float!
+	currency: String!
+	# e.g. INR 250.00
+	formatted: String!
+}
+
+type City {
+	id: ID!
+	name: String!
+	region: String
+	theatres: [Theatre!]!
+	nowShowing(date: Time): [NowShowing!]!
+}
+
+type Movie {
+	id: ID!
+	title: String!
+	description: String!
+	durationMinutes: Int!
+	genre: String!
+	genres: [String!]!
+	language: String!
+	certificate: String
+	rating: Float!
+	reviewCount: Int!
+	releaseDate: Time!
+	posterUrl: String
+	# Bookable shows, soonest first
+	shows(city: String, language: String, format: String): [Show!]!
+}
+
+type Event {
+	id: ID!
+	title: String!
+	description: String!
+	type: String!
+	durationMinutes: Int!
+	language: String!
+	performers: [String!]!
+	shows: [Show!]!
+}
+
+type Theatre {
+	id: ID!
+	name: String!
+	address: String!
+	city: String!
+	screens: [Screen!]!
+}
+
+type Screen {
+	id: ID!
+	name: String!
+	capacity: Int!
+	status: String!
+}
+
+type Show {
+	id: ID!
+	eventType: String!
+	# Set for movie shows
+	movie: Movie
+	# Set for live events
+	event: Event
+	theatre: Theatre!
+	screen: Screen!
+	format: String
+	language: String
+	startTime: Time!
+	endTime: Time!
+	basePrice: Money!
+	status: String!
+	seatMap: SeatMap!
+	availability: Availability!
+}
+
+type SeatMap {
+	capacity: Int!
+	available: Int!
+	rows: [SeatRow!]!
+}
+
+type SeatRow {
+	name: String!
+	section: String
+	y: Int
+	cells: [SeatCell!]!
+}
+
+# One position in a row: a seat, an aisle gap with no other fields set, or a gap for a missing seat with only its number set
+type SeatCell {
+	aisle: Boolean!
+	gap: Boolean!
+	x: Int
+	seatId: ID
+	label: String
+	number: Int
+	type: String
+	status: String
+	price: Money
+	group: String
+}
+
+type Availability {
+	total: Int!
+	available: Int!
+	blocked: Int!
+	booked: Int!
+	withheld: Int!
+	badge: String!
+}
+
+type NowShowing {
+	movie: Movie!
+	showCount: Int!
+	theatreCount: Int!
+	firstShow: Time!
+	formats: [String!]!
+	languages: [String!]!
+}
+
+type Booking {
+	id: ID!
+	reference: String!
+	status: String!
+	userId: ID!
+	seatIds: [ID!]!
+	bookingTime: Time!
+	expiryTime: Time!
+	totalAmount: Money!
+	show: Show!
+	movie: Movie
+	event: Event
+	theatre: Theatre!
+	screen: Screen!
+	seats: [Seat!]!
+	lineItems: [LineItem!]!
+	priceBreakdown: PriceBreakdown!
+	payment: Payment
+}
+
+type Seat {
+	id: ID!
+	label: String!
+	row: String!
+	number: Int!
+	type: String!
+}
+
+type LineItem {
+	description: String!
+	amount: Money!
+}
+
+type PriceBreakdown {
+	subtotal: Money!
+	discount: Money!
+	convenienceFee: Money!
+	cgst: Money!
+	sgst: Money!
+	loyaltyPoints: Int!
+	loyaltyValue: Money!
+	total: Money!
+}
+
+type Payment {
+	id: ID!
+	amount: Money!
+	method: String!
+	status: String!
+	transactionId: String
+	processedAt: Time
+}
+`
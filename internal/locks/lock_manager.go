@@ -0,0 +1,162 @@
+package locks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Unlock releases a lock obtained from a LockManager
+type Unlock func()
+
+// LockManager hands out mutual exclusion per key - demonstrates Strategy Pattern
+// Services lock only what they touch (e.g. one show) so unrelated work never contends.
+type LockManager interface {
+	// Lock blocks until the key is free or ctx is done
+	Lock(ctx context.Context, key string) (Unlock, error)
+	// TryLock takes the key only if it is free right now; ok is false when someone else holds it.
+	// The lock lapses after ttl if it hasn't been released, so a holder that died can't keep it forever.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (unlock Unlock, ok bool, err error)
+}
+
+// keyedLock is a single-slot semaphore so waiters can give up when their context ends
+type keyedLock struct {
+	slot    chan struct{}
+	waiters int // Holders plus goroutines waiting; the entry is dropped when it reaches zero
+}
+
+// KeyedLockManager keeps one lock per key and forgets keys nobody is using
+type KeyedLockManager struct {
+	locks map[string]*keyedLock
+	mutex sync.Mutex // Guards the map only - never held while waiting for a key
+}
+
+// NewKeyedLockManager creates a lock manager with one lock per key
+func NewKeyedLockManager() *KeyedLockManager {
+	return &KeyedLockManager{
+		locks: make(map[string]*keyedLock),
+	}
+}
+
+// Lock acquires the lock for key
+func (lm *KeyedLockManager) Lock(ctx context.Context, key string) (Unlock, error) {
+	lm.mutex.Lock()
+	lock, exists := lm.locks[key]
+	if !exists {
+		lock = &keyedLock{slot: make(chan struct{}, 1)}
+		lm.locks[key] = lock
+	}
+	lock.waiters++
+	lm.mutex.Unlock()
+
+	select {
+	case lock.slot <- struct{}{}:
+		return lm.unlocker(key, lock, 0), nil
+	case <-ctx.Done():
+		lm.release(key, lock)
+		return nil, ctx.Err()
+	}
+}
+
+// TryLock acquires the lock for key unless it is taken; a ttl of zero or less never lapses
+func (lm *KeyedLockManager) TryLock(ctx context.Context, key string, ttl time.Duration) (Unlock, bool, error) {
+	lm.mutex.Lock()
+	defer lm.mutex.Unlock()
+
+	lock, exists := lm.locks[key]
+	if exists {
+		return nil, false, nil // Someone holds or waits for it
+	}
+	lock = &keyedLock{slot: make(chan struct{}, 1), waiters: 1}
+	lock.slot <- struct{}{}
+	lm.locks[key] = lock
+	return lm.unlocker(key, lock, ttl), true, nil
+}
+
+// unlocker releases a held lock once, when called or when ttl lapses, whichever comes first
+func (lm *KeyedLockManager) unlocker(key string, lock *keyedLock, ttl time.Duration) Unlock {
+	var once sync.Once
+	unlock := func() {
+		once.Do(func() {
+			<-lock.slot
+			lm.release(key, lock)
+		})
+	}
+	return withLease(unlock, ttl)
+}
+
+// release drops the caller's interest in a key and removes idle entries
+func (lm *KeyedLockManager) release(key string, lock *keyedLock) {
+	lm.mutex.Lock()
+	defer lm.mutex.Unlock()
+
+	lock.waiters--
+	if lock.waiters == 0 {
+		delete(lm.locks, key)
+	}
+}
+
+// GlobalLockManager maps every key onto one lock - the old single-mutex behaviour,
+// kept as a baseline for benchmarks. Not reentrant: services that nest locks need separate instances.
+type GlobalLockManager struct {
+	slot chan struct{}
+}
+
+// NewGlobalLockManager creates a lock manager that serializes all keys
+func NewGlobalLockManager() *GlobalLockManager {
+	return &GlobalLockManager{slot: make(chan struct{}, 1)}
+}
+
+// Lock acquires the single shared lock regardless of key
+func (lm *GlobalLockManager) Lock(ctx context.Context, key string) (Unlock, error) {
+	select {
+	case lm.slot <- struct{}{}:
+		return lm.unlocker(0), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// TryLock acquires the single shared lock unless it is taken
+func (lm *GlobalLockManager) TryLock(ctx context.Context, key string, ttl time.Duration) (Unlock, bool, error) {
+	select {
+	case lm.slot <- struct{}{}:
+		return lm.unlocker(ttl), true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+func (lm *GlobalLockManager) unlocker(ttl time.Duration) Unlock {
+	var once sync.Once
+	return withLease(func() {
+		once.Do(func() { <-lm.slot })
+	}, ttl)
+}
+
+// withLease also calls unlock when ttl lapses; unlock must be safe to call twice
+func withLease(unlock Unlock, ttl time.Duration) Unlock {
+	if ttl <= 0 {
+		return unlock
+	}
+	timer := time.AfterFunc(ttl, unlock)
+	return func() {
+		timer.Stop()
+		unlock()
+	}
+}
+
+// ShowKey namespaces a show lock for one owner so nested locks of different owners can't deadlock
+func ShowKey(owner, showID string) string {
+	return owner + ":show:" + showID
+}
+
+// BookingKey namespaces a per-booking lock for one owner
+func BookingKey(owner, bookingID string) string {
+	return owner + ":booking:" + bookingID
+}
+
+// TheatreKey namespaces a per-theatre lock for one owner, e.g. for stock shared by the theatre's shows
+func TheatreKey(owner, theatreID string) string {
+	return owner + ":theatre:" + theatreID
+}
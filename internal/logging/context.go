@@ -0,0 +1,26 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// requestIDKey is the context key for the request ID
+type requestIDKey struct{}
+
+// WithRequestID returns a context whose log records are tagged with id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the context's request ID, or "" outside a request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// NewRequestID generates an ID for a request that arrived without one
+func NewRequestID() string {
+	return uuid.New().String()
+}
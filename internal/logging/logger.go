@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Logger writes leveled, structured log records - services depend on this, not on slog directly
+type Logger interface {
+	Debug(ctx context.Context, msg string, args ...any)
+	Info(ctx context.Context, msg string, args ...any)
+	Warn(ctx context.Context, msg string, args ...any)
+	Error(ctx context.Context, msg string, args ...any)
+	With(args ...any) Logger // e.g. With("booking_id", id) for every record about one booking
+}
+
+// Format selects how records are rendered
+type Format string
+
+const (
+	FormatText Format = "text" // key=value pairs, for terminals
+	FormatJSON Format = "json" // one JSON object per line, for log shippers
+)
+
+// Config controls the minimum level, output format and destination
+type Config struct {
+	Level  slog.Level
+	Format Format
+	Output io.Writer // Defaults to stderr
+}
+
+// ConfigFromEnv reads LOG_LEVEL (debug, info, warn, error) and LOG_FORMAT (text, json)
+func ConfigFromEnv() Config {
+	config := Config{Level: slog.LevelInfo, Format: FormatText}
+
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		if err := config.Level.UnmarshalText([]byte(level)); err != nil {
+			config.Level = slog.LevelInfo
+		}
+	}
+	if strings.EqualFold(os.Getenv("LOG_FORMAT"), string(FormatJSON)) {
+		config.Format = FormatJSON
+	}
+	return config
+}
+
+// slogLogger implements Logger on top of log/slog - demonstrates Adapter Pattern
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// New creates a slog-backed logger; records carry the request ID and trace of the context they are logged with
+func New(config Config) Logger {
+	output := config.Output
+	if output == nil {
+		output = os.Stderr
+	}
+
+	options := &slog.HandlerOptions{Level: config.Level}
+	var handler slog.Handler
+	if config.Format == FormatJSON {
+		handler = slog.NewJSONHandler(output, options)
+	} else {
+		handler = slog.NewTextHandler(output, options)
+	}
+
+	return &slogLogger{logger: slog.New(contextHandler{handler})}
+}
+
+// Nop returns a logger that discards everything, for benchmarks and fixtures
+func Nop() Logger {
+	return &slogLogger{logger: slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError + 1}))}
+}
+
+func (l *slogLogger) Debug(ctx context.Context, msg string, args ...any) {
+	l.logger.Log(ctx, slog.LevelDebug, msg, args...)
+}
+
+func (l *slogLogger) Info(ctx context.Context, msg string, args ...any) {
+	l.logger.Log(ctx, slog.LevelInfo, msg, args...)
+}
+
+func (l *slogLogger) Warn(ctx context.Context, msg string, args ...any) {
+	l.logger.Log(ctx, slog.LevelWarn, msg, args...)
+}
+
+func (l *slogLogger) Error(ctx context.Context, msg string, args ...any) {
+	l.logger.Log(ctx, slog.LevelError, msg, args...)
+}
+
+func (l *slogLogger) With(args ...any) Logger {
+	return &slogLogger{logger: l.logger.With(args...)}
+}
+
+// contextHandler adds the context's request ID, and its trace and span IDs while it is traced, to every record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsSampled() {
+		record.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
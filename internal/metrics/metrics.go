@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"bookmyshow-lld/internal/models"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Recorder receives booking funnel measurements from the services - demonstrates Dependency Inversion:
+// services never import Prometheus, and fixtures pass Nop()
+type Recorder interface {
+	BookingCreated()
+	BookingConfirmed()
+	BookingExpired() // Payment arrived after the booking window closed
+	SeatConflict()   // Someone else already blocked or booked a requested seat
+	PaymentAttempt(method models.PaymentMethod, success bool)
+	PaymentRetry(method models.PaymentMethod)    // A charge is tried again after an outage or timeout
+	PaymentTimeout(method models.PaymentMethod)  // A gateway call ran past its deadline
+	PaymentRejected(method models.PaymentMethod) // An open circuit failed a call without trying it
+	PaymentCircuitChanged(method models.PaymentMethod, open bool)
+	ObserveBookingLatency(duration time.Duration, success bool)
+}
+
+// Prometheus implements Recorder with counters and a histogram on its own registry
+type Prometheus struct {
+	registry          *prometheus.Registry
+	bookingsCreated   prometheus.Counter
+	bookingsConfirmed prometheus.Counter
+	bookingsExpired   prometheus.Counter
+	seatConflicts     prometheus.Counter
+	payments          *prometheus.CounterVec
+	paymentRetries    *prometheus.CounterVec
+	paymentTimeouts   *prometheus.CounterVec
+	paymentRejections *prometheus.CounterVec
+	paymentCircuits   *prometheus.GaugeVec
+	bookingLatency    *prometheus.HistogramVec
+}
+
+// NewPrometheus creates the booking funnel metrics alongside the Go runtime and process collectors
+func NewPrometheus() *Prometheus {
+	p := &Prometheus{
+		registry: prometheus.NewRegistry(),
+		bookingsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "bms_bookings_created_total",
+			Help: "Bookings created with seats held, awaiting payment.",
+		}),
+		bookingsConfirmed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "bms_bookings_confirmed_total",
+			Help: "Bookings confirmed after a successful payment.",
+		}),
+		bookingsExpired: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "bms_bookings_expired_total",
+			Help: "Bookings that expired before they could be confirmed.",
+		}),
+		seatConflicts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "bms_seat_conflicts_total",
+			Help: "Attempts to block seats that were already blocked or booked.",
+		}),
+		payments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bms_payments_total",
+			Help: "Payment attempts by method and status (success or failure).",
+		}, []string{"method", "status"}),
+		paymentRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bms_payment_retries_total",
+			Help: "Charges retried after a gateway outage or timeout.",
+		}, []string{"method"}),
+		paymentTimeouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bms_payment_timeouts_total",
+			Help: "Payment gateway calls that ran past their deadline.",
+		}, []string{"method"}),
+		paymentRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bms_payment_circuit_rejections_total",
+			Help: "Payment calls failed fast because the method's circuit was open.",
+		}, []string{"method"}),
+		paymentCircuits: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "bms_payment_circuit_open",
+			Help: "1 while a payment method's circuit breaker is open or half-open, 0 when closed.",
+		}, []string{"method"}),
+		bookingLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "bms_booking_duration_seconds",
+			Help:    "Time taken by CreateBooking, including waiting for the show lock.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 12), // 0.5ms to ~1s
+		}, []string{"status"}),
+	}
+
+	p.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		p.bookingsCreated,
+		p.bookingsConfirmed,
+		p.bookingsExpired,
+		p.seatConflicts,
+		p.payments,
+		p.paymentRetries,
+		p.paymentTimeouts,
+		p.paymentRejections,
+		p.paymentCircuits,
+		p.bookingLatency,
+	)
+	return p
+}
+
+// Handler serves the metrics in the Prometheus text format
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{})
+}
+
+func (p *Prometheus) BookingCreated() {
+	p.bookingsCreated.Inc()
+}
+
+func (p *Prometheus) BookingConfirmed() {
+	p.bookingsConfirmed.Inc()
+}
+
+func (p *Prometheus) BookingExpired() {
+	p.bookingsExpired.Inc()
+}
+
+func (p *Prometheus) SeatConflict() {
+	p.seatConflicts.Inc()
+}
+
+func (p *Prometheus) PaymentAttempt(method models.PaymentMethod, success bool) {
+	p.payments.WithLabelValues(string(method), status(success)).Inc()
+}
+
+func (p *Prometheus) PaymentRetry(method models.PaymentMethod) {
+	p.paymentRetries.WithLabelValues(string(method)).Inc()
+}
+
+func (p *Prometheus) PaymentTimeout(method models.PaymentMethod) {
+	p.paymentTimeouts.WithLabelValues(string(method)).Inc()
+}
+
+func (p *Prometheus) PaymentRejected(method models.PaymentMethod) {
+	p.paymentRejections.WithLabelValues(string(method)).Inc()
+}
+
+func (p *Prometheus) PaymentCircuitChanged(method models.PaymentMethod, open bool) {
+	value := 0.0
+	if open {
+		value = 1
+	}
+	p.paymentCircuits.WithLabelValues(string(method)).Set(value)
+}
+
+func (p *Prometheus) ObserveBookingLatency(duration time.Duration, success bool) {
+	p.bookingLatency.WithLabelValues(status(success)).Observe(duration.Seconds())
+}
+
+// status turns an outcome into a label value
+func status(success bool) string {
+	if success {
+		return "success"
+	}
+	return "failure"
+}
+
+// nop discards every measurement
+type nop struct{}
+
+// Nop returns a Recorder that records nothing, for benchmarks and fixtures
+func Nop() Recorder {
+	return nop{}
+}
+
+func (nop) BookingCreated()                                  {}
+func (nop) BookingConfirmed()                                {}
+func (nop) BookingExpired()                                  {}
+func (nop) SeatConflict()                                    {}
+func (nop) PaymentAttempt(models.PaymentMethod, bool)        {}
+func (nop) PaymentRetry(models.PaymentMethod)                {}
+func (nop) PaymentTimeout(models.PaymentMethod)              {}
+func (nop) PaymentRejected(models.PaymentMethod)             {}
+func (nop) PaymentCircuitChanged(models.PaymentMethod, bool) {}
+func (nop) ObserveBookingLatency(time.Duration, bool)        {}
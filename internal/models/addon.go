@@ -0,0 +1,143 @@
+package models
+
+import "time"
+
+// AddOnType is a kind of extra sold with a booking
+type AddOnType string
+
+const (
+	AddOnCancellationInsurance AddOnType = "CANCELLATION_INSURANCE" // Refunds a cancelled booking in full, less the premium
+	AddOn3DGlasses             AddOnType = "3D_GLASSES"             // Pairs to collect at the counter; 3D shows only
+	AddOnParking               AddOnType = "PARKING"                // A parking spot per vehicle at the theatre
+)
+
+// AddOnStatus tracks an add-on from purchase to fulfilment
+type AddOnStatus string
+
+const (
+	AddOnStatusPending   AddOnStatus = "PENDING"   // Waiting for the booking to be paid
+	AddOnStatusFulfilled AddOnStatus = "FULFILLED" // Issued, e.g. a policy number or parking pass
+	AddOnStatusFailed    AddOnStatus = "FAILED"    // The fulfilment hook failed; it is tried again on the next confirmation event
+)
+
+// AddOnConfig prices the built-in add-ons; an add-on without a price isn't offered, so the zero value offers none
+type AddOnConfig struct {
+	InsurancePercent float64 // Cancellation insurance premium, as a percent of the discounted ticket amount
+	GlassesPrice     int64   // Per pair of 3D glasses, in minor units of the booking's currency
+	ParkingPrice     int64   // Per vehicle, in minor units
+}
+
+// DefaultAddOnConfig charges 5% of the tickets for insurance, 30.00 a pair of 3D glasses and 100.00 a vehicle
+func DefaultAddOnConfig() AddOnConfig {
+	return AddOnConfig{InsurancePercent: 5, GlassesPrice: 3000, ParkingPrice: 10000}
+}
+
+// AddOnRequest asks for an add-on with a new booking
+type AddOnRequest struct {
+	Type     AddOnType `json:"type"`
+	Quantity int       `json:"quantity,omitempty"` // Zero means one
+}
+
+// BookingAddOn is an add-on bought with a booking. Add-on prices include tax, so they are added to the total
+// after the convenience fee and GST.
+type BookingAddOn struct {
+	Type        AddOnType   `json:"type"`
+	Description string      `json:"description"`
+	Quantity    int         `json:"quantity"`
+	UnitPrice   Money       `json:"unit_price"`
+	Amount      Money       `json:"amount"`
+	Status      AddOnStatus `json:"status"`
+	Reference   string      `json:"reference,omitempty"` // What fulfilment issued, e.g. a policy number
+	Error       string      `json:"error,omitempty"`     // Why fulfilment last failed
+	FulfilledAt *time.Time  `json:"fulfilled_at,omitempty"`
+}
+
+// NewBookingAddOn prices quantity units of an add-on at unitPrice
+func NewBookingAddOn(addOnType AddOnType, description string, quantity int, unitPrice Money) (BookingAddOn, error) {
+	if addOnType == "" || quantity <= 0 || unitPrice.IsNegative() {
+		return BookingAddOn{}, ErrInvalidAddOn
+	}
+	return BookingAddOn{
+		Type:        addOnType,
+		Description: description,
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		Amount:      unitPrice.Times(quantity),
+		Status:      AddOnStatusPending,
+	}, nil
+}
+
+// AddAddOns adds add-ons to a pending booking, one of each type, and adds their price to the total
+func (b *Booking) AddAddOns(now time.Time, addOns ...BookingAddOn) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.Status != BookingStatusPending {
+		return ErrBookingNotPending
+	}
+
+	seen := make(map[AddOnType]bool, len(b.AddOns)+len(addOns))
+	for _, addOn := range b.AddOns {
+		seen[addOn.Type] = true
+	}
+	for _, addOn := range addOns {
+		if seen[addOn.Type] || !addOn.Amount.SameCurrency(b.SubtotalAmount) {
+			return ErrInvalidAddOn
+		}
+		seen[addOn.Type] = true
+	}
+
+	b.AddOns = append(b.AddOns, addOns...)
+	b.reprice(b.SubtotalAmount, b.DiscountAmount, b.ProfileDiscount)
+	b.UpdatedAt = now
+	return nil
+}
+
+// GetAddOns returns a copy of the booking's add-ons
+func (b *Booking) GetAddOns() []BookingAddOn {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return append([]BookingAddOn(nil), b.AddOns...)
+}
+
+// AddOn returns the booking's add-on of a type, if it has one
+func (b *Booking) AddOn(addOnType AddOnType) (BookingAddOn, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for _, addOn := range b.AddOns {
+		if addOn.Type == addOnType {
+			return addOn, true
+		}
+	}
+	return BookingAddOn{}, false
+}
+
+// FulfillAddOn records the result of an add-on's fulfilment hook: the reference it issued, or the error
+func (b *Booking) FulfillAddOn(addOnType AddOnType, reference string, err error, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for i := range b.AddOns {
+		addOn := &b.AddOns[i]
+		if addOn.Type != addOnType {
+			continue
+		}
+		if err != nil {
+			addOn.Status, addOn.Error = AddOnStatusFailed, err.Error()
+		} else {
+			addOn.Status, addOn.Reference, addOn.Error, addOn.FulfilledAt = AddOnStatusFulfilled, reference, "", &now
+		}
+		b.UpdatedAt = now
+		return
+	}
+}
+
+// addOnTotal sums the add-ons' prices; callers must hold the mutex
+func (b *Booking) addOnTotal() Money {
+	total := ZeroMoney(b.SubtotalAmount.Currency)
+	for _, addOn := range b.AddOns {
+		total = total.Add(addOn.Amount)
+	}
+	return total
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntityType is the kind of thing an audit entry is about
+type AuditEntityType string
+
+const (
+	AuditEntityBooking  AuditEntityType = "BOOKING"
+	AuditEntityShow     AuditEntityType = "SHOW"
+	AuditEntityUser     AuditEntityType = "USER"
+	AuditEntityTheatre  AuditEntityType = "THEATRE"
+	AuditEntityScreen   AuditEntityType = "SCREEN"
+	AuditEntitySeatType AuditEntityType = "SEAT_TYPE"       // Keyed by the seat type's name
+	AuditEntityTemplate AuditEntityType = "SCREEN_TEMPLATE" // Keyed by the screen template's name
+	AuditEntityPricing  AuditEntityType = "PRICING_DAY"     // Keyed by the calendar date, as YYYY-MM-DD
+	AuditEntityWhatsApp AuditEntityType = "WHATSAPP_TEMPLATE"
+)
+
+// AuditAction is what was done to the entity
+type AuditAction string
+
+const (
+	AuditBookingCreated           AuditAction = "BOOKING_CREATED"
+	AuditBookingConfirmed         AuditAction = "BOOKING_CONFIRMED"
+	AuditBookingCancelled         AuditAction = "BOOKING_CANCELLED"
+	AuditBookingSeatsChanged      AuditAction = "BOOKING_SEATS_CHANGED" // Includes the change in price
+	AuditBookingTransferred       AuditAction = "BOOKING_TRANSFERRED"   // From and to which user
+	AuditBookingResold            AuditAction = "BOOKING_RESOLD"        // Seller, buyer, price and fee
+	AuditBookingHoldExtended      AuditAction = "BOOKING_HOLD_EXTENDED" // The payment window's new close
+	AuditRefundIssued             AuditAction = "REFUND_ISSUED"         // Recorded against the refunded booking
+	AuditShowCancelled            AuditAction = "SHOW_CANCELLED"
+	AuditShowRescheduled          AuditAction = "SHOW_RESCHEDULED"
+	AuditShowClosedOut            AuditAction = "SHOW_CLOSED_OUT"         // Seats sold, attended and no-shows
+	AuditBookingTimeoutChange     AuditAction = "BOOKING_TIMEOUT_CHANGED" // On a show or a theatre
+	AuditSaleWindowChange         AuditAction = "SALE_WINDOW_CHANGED"     // On a theatre or a movie
+	AuditSeatsWithheld            AuditAction = "SEATS_WITHHELD"          // Seats, status and reason
+	AuditSeatsReleasedToSale      AuditAction = "SEATS_RELEASED_TO_SALE"  // Held back seats put back on sale
+	AuditRoleGranted              AuditAction = "ROLE_GRANTED"
+	AuditUserBlocked              AuditAction = "USER_BLOCKED"
+	AuditUserUnblocked            AuditAction = "USER_UNBLOCKED"
+	AuditDiscountProfileVerified  AuditAction = "DISCOUNT_PROFILE_VERIFIED" // Category and proof
+	AuditDiscountProfileRejected  AuditAction = "DISCOUNT_PROFILE_REJECTED" // Category and reason
+	AuditCancellationPolicyChange AuditAction = "CANCELLATION_POLICY_CHANGED"
+	AuditParkingCapacityChange    AuditAction = "PARKING_CAPACITY_CHANGED"
+	AuditScreenMaintenanceChange  AuditAction = "SCREEN_MAINTENANCE_CHANGED"
+	AuditSeatTypeRegistered       AuditAction = "SEAT_TYPE_REGISTERED" // Sets the seat type's price multiplier
+	AuditScreenTemplateRegistered AuditAction = "SCREEN_TEMPLATE_REGISTERED"
+	AuditPricingDaySet            AuditAction = "PRICING_DAY_SET" // Kind, name and multiplier
+	AuditPricingDayCleared        AuditAction = "PRICING_DAY_CLEARED"
+	AuditWhatsAppTemplateSet      AuditAction = "WHATSAPP_TEMPLATE_SET" // Name, language and header, keyed by the notification kind
+	AuditWhatsAppTemplateRemoved  AuditAction = "WHATSAPP_TEMPLATE_REMOVED"
+)
+
+// AuditSystemActor is the actor of changes no user asked for, e.g. a scheduled job
+const AuditSystemActor = "system"
+
+// AuditEntry records who changed what and when. Entries are only ever appended.
+type AuditEntry struct {
+	ID         string            `json:"id"`
+	EntityType AuditEntityType   `json:"entity_type"`
+	EntityID   string            `json:"entity_id"`
+	Action     AuditAction       `json:"action"`
+	ActorID    string            `json:"actor_id"`          // User ID, or AuditSystemActor
+	Details    map[string]string `json:"details,omitempty"` // What changed, e.g. "start_time" or "total"
+	At         time.Time         `json:"at"`
+}
+
+// NewAuditEntry stamps a change with an ID and the current time; an empty actor is the system
+func NewAuditEntry(entityType AuditEntityType, entityID string, action AuditAction, actorID string, details map[string]string, now time.Time) (*AuditEntry, error) {
+	if entityType == "" || entityID == "" || action == "" {
+		return nil, ErrInvalidAuditEntry
+	}
+	if actorID == "" {
+		actorID = AuditSystemActor
+	}
+
+	return &AuditEntry{
+		ID:         uuid.New().String(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		ActorID:    actorID,
+		Details:    details,
+		At:         now,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package models
+
+// AvailabilityBadge is the one-word availability a show listing shows
+type AvailabilityBadge string
+
+const (
+	AvailabilityBadgeAvailable   AvailabilityBadge = "AVAILABLE"
+	AvailabilityBadgeFillingFast AvailabilityBadge = "FILLING_FAST"
+	AvailabilityBadgeSoldOut     AvailabilityBadge = "SOLD_OUT"
+)
+
+// FillingFastShare is the share of a show's seats on sale at or below which it is filling fast
+const FillingFastShare = 0.2
+
+// SeatCounts tallies seats by status
+type SeatCounts struct {
+	Total     int `json:"total"`
+	Available int `json:"available"`
+	Blocked   int `json:"blocked"` // Held, or in bookings not yet paid for
+	Booked    int `json:"booked"`
+	Withheld  int `json:"withheld"` // House seats and admin blackouts
+}
+
+// Add counts delta seats of the status; a negative delta takes them away
+func (c *SeatCounts) Add(status SeatStatus, delta int) {
+	switch {
+	case status == SeatStatusAvailable:
+		c.Available += delta
+	case status == SeatStatusBooked:
+		c.Booked += delta
+	case status.IsWithheld():
+		c.Withheld += delta
+	default:
+		c.Blocked += delta
+	}
+}
+
+// Badge is SOLD_OUT with no seat available, FILLING_FAST once FillingFastShare or less of the seats on sale are
+// left, and AVAILABLE otherwise
+func (c SeatCounts) Badge() AvailabilityBadge {
+	onSale := c.Total - c.Withheld
+	switch {
+	case c.Available <= 0:
+		return AvailabilityBadgeSoldOut
+	case float64(c.Available) <= float64(onSale)*FillingFastShare:
+		return AvailabilityBadgeFillingFast
+	default:
+		return AvailabilityBadgeAvailable
+	}
+}
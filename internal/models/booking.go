@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"sync"
 	"time"
 
@@ -19,54 +20,113 @@ const (
 
 // Booking represents a ticket booking
 type Booking struct {
-	ID          string        `json:"id"`
-	UserID      string        `json:"user_id"`
-	ShowID      string        `json:"show_id"`
-	SeatIDs     []string      `json:"seat_ids"`
-	TotalAmount float64       `json:"total_amount"`
-	Status      BookingStatus `json:"status"`
-	BookingTime time.Time     `json:"booking_time"`
-	ExpiryTime  time.Time     `json:"expiry_time"`
-	PaymentID   string        `json:"payment_id,omitempty"`
-	CreatedAt   time.Time     `json:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at"`
-	mutex       sync.RWMutex
-}
-
-// BookingTimeout represents the timeout for pending bookings
+	ID              string                  `json:"id"`
+	Reference       string                  `json:"reference"` // Short code shown to users, e.g. BMS-7F3K9Q
+	UserID          string                  `json:"user_id"`
+	ShowID          string                  `json:"show_id"`
+	SeatIDs         []string                `json:"seat_ids"`
+	HoldID          string                  `json:"hold_id,omitempty"`
+	SubtotalAmount  Money                   `json:"subtotal_amount"`
+	CouponCode      string                  `json:"coupon_code,omitempty"`
+	WithGuardian    bool                    `json:"with_guardian,omitempty"`    // Age certificate waived; the guardian is checked at the door
+	BulkBookingID   string                  `json:"bulk_booking_id,omitempty"`  // Set when the booking holds a corporate block
+	DiscountAmount  Money                   `json:"discount_amount"`            // The coupon's part
+	ProfileDiscount *AppliedProfileDiscount `json:"profile_discount,omitempty"` // Student, senior citizen or military discount
+	AddOns          []BookingAddOn          `json:"add_ons,omitempty"`          // Insurance, 3D glasses, parking and the like
+	PriceBreakdown  PriceBreakdown          `json:"price_breakdown"`
+	TotalAmount     Money                   `json:"total_amount"` // Payable amount, including fees and tax
+	Status          BookingStatus           `json:"status"`
+	BookingTime     time.Time               `json:"booking_time"`
+	ExpiryTime      time.Time               `json:"expiry_time"`
+	HoldExtendedAt  time.Time               `json:"hold_extended_at,omitzero"` // When the owner bought more time to pay; once per booking
+	RemindedAt      *time.Time              `json:"reminded_at,omitempty"`     // When the owner was reminded the show is about to start
+	PaymentID       string                  `json:"payment_id,omitempty"`
+	ExtraPaymentIDs []string                `json:"extra_payment_ids,omitempty"`
+	PaymentAttempts []string                `json:"payment_attempts,omitempty"` // Every payment tried for the booking total, oldest first
+	SoldPaymentIDs  []string                `json:"sold_payment_ids,omitempty"` // Earlier owners' payments, settled when they resold it
+	StatusHistory   []BookingStatusChange   `json:"status_history,omitempty"`   // Every transition since PENDING, oldest first
+	Transfers       []BookingTransfer       `json:"transfers,omitempty"`        // Every offer to hand the booking to another user, oldest first
+	CreatedAt       time.Time               `json:"created_at"`
+	UpdatedAt       time.Time               `json:"updated_at"`
+	mutex           sync.RWMutex
+}
+
+// BookingTimeout is how long a pending booking waits for payment unless its show or theatre sets another, or
+// the platform is configured with other BookingTimeouts
 const BookingTimeout = 15 * time.Minute
 
-// NewBooking creates a new booking
-func NewBooking(userID, showID string, seatIDs []string, totalAmount float64) (*Booking, error) {
-	if userID == "" || showID == "" || len(seatIDs) == 0 || totalAmount <= 0 {
+// Bounds on the booking timeout a show or theatre may set
+const (
+	MinBookingTimeout = 2 * time.Minute
+	MaxBookingTimeout = time.Hour
+)
+
+// ValidateBookingTimeout accepts zero, meaning the default, or a timeout within the bounds
+func ValidateBookingTimeout(timeout time.Duration) error {
+	if timeout != 0 && (timeout < MinBookingTimeout || timeout > MaxBookingTimeout) {
+		return ErrInvalidBookingTimeout
+	}
+	return nil
+}
+
+// MaxPaymentRetries caps how many times a failed booking payment can be retried
+const MaxPaymentRetries = 2
+
+// NewBooking creates a new booking, adding fees and tax to the seat subtotal.
+// It expires unless paid within timeout; zero uses BookingTimeout.
+func NewBooking(userID, showID string, seatIDs []string, subtotal Money, fees FeeConfig, timeout time.Duration, now time.Time) (*Booking, error) {
+	if userID == "" || showID == "" || len(seatIDs) == 0 || !subtotal.IsPositive() {
 		return nil, ErrInvalidBookingData
 	}
+	if timeout <= 0 {
+		timeout = BookingTimeout
+	}
 
-	now := time.Now()
+	breakdown := fees.Calculate(subtotal, ZeroMoney(subtotal.Currency))
 	return &Booking{
-		ID:          uuid.New().String(),
-		UserID:      userID,
-		ShowID:      showID,
-		SeatIDs:     seatIDs,
-		TotalAmount: totalAmount,
-		Status:      BookingStatusPending,
-		BookingTime: now,
-		ExpiryTime:  now.Add(BookingTimeout),
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:             uuid.New().String(),
+		Reference:      NewBookingReference(),
+		UserID:         userID,
+		ShowID:         showID,
+		SeatIDs:        seatIDs,
+		SubtotalAmount: subtotal,
+		DiscountAmount: breakdown.Discount,
+		PriceBreakdown: breakdown,
+		TotalAmount:    breakdown.Total,
+		Status:         BookingStatusPending,
+		BookingTime:    now,
+		ExpiryTime:     now.Add(timeout),
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}, nil
 }
 
-// IsExpired checks if the booking has expired
-func (b *Booking) IsExpired() bool {
-	b.mutex.RLock()
-	defer b.mutex.RUnlock()
+// ApplyDiscount records a coupon discount and reduces the payable total
+func (b *Booking) ApplyDiscount(couponCode string, discount Money, now time.Time) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
 
-	return time.Now().After(b.ExpiryTime) && b.Status == BookingStatusPending
+	if b.Status != BookingStatusPending {
+		return ErrBookingNotPending
+	}
+
+	if couponCode == "" || !validDiscount(b.SubtotalAmount, discount, b.ProfileDiscount) {
+		return ErrInvalidBookingData
+	}
+
+	if !b.price(b.SubtotalAmount, discount, b.ProfileDiscount).Total.IsPositive() {
+		return ErrLoyaltyPointsNotAllowed
+	}
+
+	b.CouponCode = couponCode
+	b.reprice(b.SubtotalAmount, discount, b.ProfileDiscount)
+	b.UpdatedAt = now
+	return nil
 }
 
-// Confirm confirms the booking after successful payment
-func (b *Booking) Confirm(paymentID string) error {
+// ApplyProfileDiscount records the discount the user's verified profile earns and reduces the payable total.
+// It is applied before any coupon, which then has to fit in what is left.
+func (b *Booking) ApplyProfileDiscount(discount AppliedProfileDiscount, now time.Time) error {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
@@ -74,37 +134,116 @@ func (b *Booking) Confirm(paymentID string) error {
 		return ErrBookingNotPending
 	}
 
-	if time.Now().After(b.ExpiryTime) {
-		b.Status = BookingStatusExpired
-		return ErrBookingExpired
+	if !discount.Category.IsValid() || !discount.Amount.IsPositive() || !validDiscount(b.SubtotalAmount, b.DiscountAmount, &discount) {
+		return ErrInvalidBookingData
 	}
 
-	b.Status = BookingStatusConfirmed
-	b.PaymentID = paymentID
-	b.UpdatedAt = time.Now()
+	b.reprice(b.SubtotalAmount, b.DiscountAmount, &discount)
+	b.UpdatedAt = now
 	return nil
 }
 
-// Cancel cancels the booking
-func (b *Booking) Cancel() error {
+// ChangeSeats swaps the booked seats and reprices the booking with the coupon and profile discounts
+// recalculated for the new subtotal; a nil profile discount drops it
+func (b *Booking) ChangeSeats(seatIDs []string, subtotal, discount Money, profileDiscount *AppliedProfileDiscount, now time.Time) error {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	if b.Status == BookingStatusConfirmed {
-		return ErrBookingAlreadyConfirmed
+	if b.Status != BookingStatusPending && b.Status != BookingStatusConfirmed {
+		return ErrBookingNotModifiable
+	}
+
+	if len(seatIDs) == 0 || !subtotal.IsPositive() || !validDiscount(subtotal, discount, profileDiscount) {
+		return ErrInvalidBookingData
 	}
 
-	if b.Status == BookingStatusCancelled {
-		return ErrBookingAlreadyCancelled
+	// Points already spent must still leave something to pay
+	if !b.price(subtotal, discount, profileDiscount).Total.IsPositive() {
+		return ErrLoyaltyPointsNotAllowed
 	}
 
-	b.Status = BookingStatusCancelled
-	b.UpdatedAt = time.Now()
+	b.SeatIDs = seatIDs
+	b.reprice(subtotal, discount, profileDiscount)
+	b.UpdatedAt = now
 	return nil
 }
 
-// Expire marks the booking as expired
-func (b *Booking) Expire() error {
+// SeatSelection is a booking's seats and what they cost, kept so a seat change that couldn't be saved
+// can be undone
+type SeatSelection struct {
+	SeatIDs         []string
+	Subtotal        Money
+	Discount        Money
+	ProfileDiscount *AppliedProfileDiscount
+	ExtraPayments   int // How many extra payments the booking had
+}
+
+// SeatSelection returns the booking's current seats and their price
+func (b *Booking) SeatSelection() SeatSelection {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return SeatSelection{
+		SeatIDs:         b.SeatIDs,
+		Subtotal:        b.SubtotalAmount,
+		Discount:        b.DiscountAmount,
+		ProfileDiscount: b.ProfileDiscount,
+		ExtraPayments:   len(b.ExtraPaymentIDs),
+	}
+}
+
+// RestoreSeatSelection undoes ChangeSeats, and any extra payment added with it, for a change that couldn't be saved
+func (b *Booking) RestoreSeatSelection(selection SeatSelection, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.SeatIDs = selection.SeatIDs
+	b.reprice(selection.Subtotal, selection.Discount, selection.ProfileDiscount)
+	if len(b.ExtraPaymentIDs) > selection.ExtraPayments {
+		b.ExtraPaymentIDs = b.ExtraPaymentIDs[:selection.ExtraPayments]
+	}
+	b.UpdatedAt = now
+}
+
+// validDiscount checks the coupon and profile discounts are in the subtotal's currency and together don't exceed it
+func validDiscount(subtotal, discount Money, profileDiscount *AppliedProfileDiscount) bool {
+	if !discount.SameCurrency(subtotal) || discount.IsNegative() {
+		return false
+	}
+	if profileDiscount != nil {
+		if !profileDiscount.Amount.SameCurrency(subtotal) || profileDiscount.Amount.IsNegative() {
+			return false
+		}
+		discount = discount.Add(profileDiscount.Amount)
+	}
+	return !discount.GreaterThan(subtotal)
+}
+
+// GetPriceBreakdown returns the booking's current fee and tax itemization
+func (b *Booking) GetPriceBreakdown() PriceBreakdown {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.PriceBreakdown
+}
+
+// GetProfileDiscount returns what the user's discount profile took off the booking, nil when nothing
+func (b *Booking) GetProfileDiscount() *AppliedProfileDiscount {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.ProfileDiscount
+}
+
+// QuoteTotal returns what the booking would cost for a new subtotal and discounts, under its original fees
+func (b *Booking) QuoteTotal(subtotal, discount Money, profileDiscount *AppliedProfileDiscount) Money {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.price(subtotal, discount, profileDiscount).Total
+}
+
+// ApplyLoyaltyPoints pays part of a pending booking with points; at least some of it must stay payable
+func (b *Booking) ApplyLoyaltyPoints(points int64, value Money, now time.Time) error {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
@@ -112,11 +251,208 @@ func (b *Booking) Expire() error {
 		return ErrBookingNotPending
 	}
 
-	b.Status = BookingStatusExpired
-	b.UpdatedAt = time.Now()
+	if points <= 0 || b.PriceBreakdown.LoyaltyPoints > 0 || !value.SameCurrency(b.TotalAmount) || !value.IsPositive() {
+		return ErrInvalidLoyaltyData
+	}
+
+	if !value.LessThan(b.TotalAmount) {
+		return ErrLoyaltyPointsNotAllowed
+	}
+
+	b.PriceBreakdown = b.PriceBreakdown.withLoyalty(points, value)
+	b.TotalAmount = b.PriceBreakdown.Total
+	b.UpdatedAt = now
 	return nil
 }
 
+// price computes a breakdown under the booking's fees, with its add-ons and any points already applied; the
+// breakdown's discount is the coupon's and the profile's together. Callers must hold the mutex.
+func (b *Booking) price(subtotal, discount Money, profileDiscount *AppliedProfileDiscount) PriceBreakdown {
+	if profileDiscount != nil {
+		discount = discount.Add(profileDiscount.Amount)
+	}
+	return b.PriceBreakdown.Fees.Calculate(subtotal, discount).withAddOns(b.addOnTotal()).withLoyalty(b.PriceBreakdown.LoyaltyPoints, b.PriceBreakdown.LoyaltyValue)
+}
+
+// reprice recomputes the breakdown and totals; callers must hold the mutex
+func (b *Booking) reprice(subtotal, discount Money, profileDiscount *AppliedProfileDiscount) {
+	b.PriceBreakdown = b.price(subtotal, discount, profileDiscount)
+	b.SubtotalAmount = subtotal
+	b.DiscountAmount = discount
+	b.ProfileDiscount = profileDiscount
+	b.TotalAmount = b.PriceBreakdown.Total
+}
+
+// AddExtraPayment records a supplementary payment, e.g. for upgraded seats
+func (b *Booking) AddExtraPayment(paymentID string, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.ExtraPaymentIDs = append(b.ExtraPaymentIDs, paymentID)
+	b.UpdatedAt = now
+}
+
+// RecordPaymentAttempt adds a payment for the booking total, enforcing the retry cap.
+// It returns the attempt number, starting at 1.
+func (b *Booking) RecordPaymentAttempt(paymentID string, now time.Time) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.Status != BookingStatusPending {
+		return 0, ErrBookingNotPending
+	}
+
+	if len(b.PaymentAttempts) > MaxPaymentRetries {
+		return 0, ErrPaymentRetryLimitReached
+	}
+
+	b.PaymentAttempts = append(b.PaymentAttempts, paymentID)
+	b.UpdatedAt = now
+	return len(b.PaymentAttempts), nil
+}
+
+// GetPaymentAttempts returns the IDs of payments tried for the booking total
+func (b *Booking) GetPaymentAttempts() []string {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return append([]string(nil), b.PaymentAttempts...)
+}
+
+// PaymentIDs returns the original payment followed by any supplementary ones
+func (b *Booking) PaymentIDs() []string {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	var ids []string
+	if b.PaymentID != "" {
+		ids = append(ids, b.PaymentID)
+	}
+	return append(ids, b.ExtraPaymentIDs...)
+}
+
+// IsExpired checks if the booking has expired
+func (b *Booking) IsExpired(now time.Time) bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return now.After(b.ExpiryTime) && b.Status == BookingStatusPending
+}
+
+// ExtendHold gives the owner of a pending booking more time to pay. A booking is extended at most once, and
+// only while its payment window is still open.
+func (b *Booking) ExtendHold(by time.Duration, now time.Time) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch {
+	case by <= 0:
+		return ErrHoldExtensionUnavailable
+	case b.Status != BookingStatusPending:
+		return ErrBookingNotPending
+	case paymentWindowOpen(b, now) != nil:
+		return ErrBookingExpired
+	case !b.HoldExtendedAt.IsZero():
+		return ErrHoldAlreadyExtended
+	}
+
+	b.ExpiryTime = b.ExpiryTime.Add(by)
+	b.HoldExtendedAt = now
+	b.UpdatedAt = now
+	return nil
+}
+
+// GetHoldExtendedAt returns when the booking's payment window was extended, zero if it never was
+func (b *Booking) GetHoldExtendedAt() time.Time {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.HoldExtendedAt
+}
+
+// NeedsReminder reports whether the owner hasn't been reminded of the show since its reminder window opened;
+// a show moved later gets a fresh reminder
+func (b *Booking) NeedsReminder(windowOpens time.Time) bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.RemindedAt == nil || b.RemindedAt.Before(windowOpens)
+}
+
+// MarkReminded records that the owner was reminded the show is about to start
+func (b *Booking) MarkReminded(now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.RemindedAt = &now
+	b.UpdatedAt = now
+}
+
+// GetExpiryTime returns when the booking's payment window closes
+func (b *Booking) GetExpiryTime() time.Time {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.ExpiryTime
+}
+
+// Confirm confirms the booking after successful payment; a payment that arrives after the
+// window closed expires the booking instead
+func (b *Booking) Confirm(paymentID string, now time.Time) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if err := bookingStates.Fire(b, BookingEventConfirm, now); err != nil {
+		if errors.Is(err, ErrBookingExpired) {
+			bookingStates.Fire(b, BookingEventExpire, now)
+		}
+		return err
+	}
+
+	b.PaymentID = paymentID
+	return nil
+}
+
+// RevertConfirm undoes Confirm for a confirmation that couldn't be saved: the booking is pending again,
+// with the payment ID it had before
+func (b *Booking) RevertConfirm(previousPaymentID string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	last := len(b.StatusHistory) - 1
+	if b.Status != BookingStatusConfirmed || last < 0 || b.StatusHistory[last].Event != BookingEventConfirm {
+		return
+	}
+	b.Status = b.StatusHistory[last].From
+	b.StatusHistory = b.StatusHistory[:last]
+	b.PaymentID = previousPaymentID
+}
+
+// Cancel cancels a pending or confirmed booking
+func (b *Booking) Cancel(now time.Time) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return bookingStates.Fire(b, BookingEventCancel, now)
+}
+
+// RevertCancel undoes Cancel for a cancellation that couldn't be saved: the booking is pending or confirmed again
+func (b *Booking) RevertCancel() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	last := len(b.StatusHistory) - 1
+	if b.Status != BookingStatusCancelled || last < 0 || b.StatusHistory[last].Event != BookingEventCancel {
+		return
+	}
+	b.Status = b.StatusHistory[last].From
+	b.StatusHistory = b.StatusHistory[:last]
+}
+
+// Expire marks the booking as expired
+func (b *Booking) Expire(now time.Time) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return bookingStates.Fire(b, BookingEventExpire, now)
+}
+
 // GetStatus returns the current booking status (thread-safe)
 func (b *Booking) GetStatus() BookingStatus {
 	b.mutex.RLock()
@@ -132,7 +468,7 @@ func (b *Booking) GetSeatCount() int {
 }
 
 // TimeUntilExpiry returns time until booking expires
-func (b *Booking) TimeUntilExpiry() time.Duration {
+func (b *Booking) TimeUntilExpiry(now time.Time) time.Duration {
 	b.mutex.RLock()
 	defer b.mutex.RUnlock()
 
@@ -140,7 +476,7 @@ func (b *Booking) TimeUntilExpiry() time.Duration {
 		return 0
 	}
 
-	remaining := time.Until(b.ExpiryTime)
+	remaining := b.ExpiryTime.Sub(now)
 	if remaining < 0 {
 		return 0
 	}
@@ -152,5 +488,5 @@ func (b *Booking) CanBeCancelled() bool {
 	b.mutex.RLock()
 	defer b.mutex.RUnlock()
 
-	return b.Status == BookingStatusPending || b.Status == BookingStatusConfirmed
+	return bookingStates.Can(b.Status, BookingEventCancel)
 }
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// BookingLimits caps what one booking and one user can take, so seats can't be hoarded; zero disables a limit
+type BookingLimits struct {
+	MaxSeatsPerBooking        int           `json:"max_seats_per_booking"`
+	MaxPendingBookingsPerUser int           `json:"max_pending_bookings_per_user"` // Unpaid bookings across all shows
+	MaxTicketsPerUserPerShow  int           `json:"max_tickets_per_user_per_show"` // Seats in pending and confirmed bookings
+	HoldExtension             time.Duration `json:"hold_extension"`                // Added to a payment window extended once; zero allows no extensions
+	MaxHoldExtensionsPerDay   int           `json:"max_hold_extensions_per_day"`   // Per user, over the last 24 hours
+}
+
+// DefaultBookingLimits allows 10 seats per booking, 3 unpaid bookings and 10 tickets per show, and lets a user
+// extend a payment window by 5 minutes up to 3 times a day
+func DefaultBookingLimits() BookingLimits {
+	return BookingLimits{
+		MaxSeatsPerBooking:        10,
+		MaxPendingBookingsPerUser: 3,
+		MaxTicketsPerUserPerShow:  10,
+		HoldExtension:             5 * time.Minute,
+		MaxHoldExtensionsPerDay:   3,
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+// bookingReferencePrefix marks a code as a booking reference
+const bookingReferencePrefix = "BMS-"
+
+// bookingReferenceLength is the random part of a reference; 32^6 codes keep collisions rare
+const bookingReferenceLength = 6
+
+// bookingReferenceAlphabet leaves out 0/O and 1/I so references survive being read out over the phone
+const bookingReferenceAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// NewBookingReference generates a short human-friendly code such as BMS-7F3K9Q
+func NewBookingReference() string {
+	random := make([]byte, bookingReferenceLength)
+	if _, err := rand.Read(random); err != nil {
+		panic("booking reference: " + err.Error())
+	}
+
+	code := make([]byte, bookingReferenceLength)
+	for i, b := range random {
+		code[i] = bookingReferenceAlphabet[int(b)%len(bookingReferenceAlphabet)] // 256 is a multiple of 32, so no bias
+	}
+	return bookingReferencePrefix + string(code)
+}
+
+// NormalizeBookingReference canonicalizes a reference as typed by a user, e.g. " bms-7f3k9q"
+func NormalizeBookingReference(reference string) string {
+	return strings.ToUpper(strings.TrimSpace(reference))
+}
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BookingEvent is something that happens to a booking and may move it to another status
+type BookingEvent string
+
+const (
+	BookingEventConfirm BookingEvent = "CONFIRM" // Payment captured
+	BookingEventCancel  BookingEvent = "CANCEL"  // User, admin or show cancellation
+	BookingEventExpire  BookingEvent = "EXPIRE"  // Payment window lapsed
+)
+
+// BookingStatusChange records one transition in a booking's history
+type BookingStatusChange struct {
+	From  BookingStatus `json:"from"`
+	To    BookingStatus `json:"to"`
+	Event BookingEvent  `json:"event"`
+	At    time.Time     `json:"at"`
+}
+
+// BookingGuard can veto an allowed transition, e.g. confirming after the payment window closed
+type BookingGuard func(b *Booking, now time.Time) error
+
+// BookingEntryAction runs after a booking enters a status
+type BookingEntryAction func(b *Booking, change BookingStatusChange)
+
+// BookingTransition is one allowed edge: Event moves a booking From one status To another if Guard passes
+type BookingTransition struct {
+	From  BookingStatus
+	Event BookingEvent
+	To    BookingStatus
+	Guard BookingGuard // nil always passes
+}
+
+// BookingTransitionError is how the state machine rejects an event; errors.Is matches both
+// ErrInvalidBookingTransition and the specific reason, e.g. ErrBookingAlreadyCancelled
+type BookingTransitionError struct {
+	From  BookingStatus
+	Event BookingEvent
+	Err   error // Why; nil when the edge simply doesn't exist and no reason is registered
+}
+
+func (e *BookingTransitionError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: cannot %s a %s booking", ErrInvalidBookingTransition, strings.ToLower(string(e.Event)), strings.ToLower(string(e.From)))
+}
+
+func (e *BookingTransitionError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrInvalidBookingTransition}
+	}
+	return []error{ErrInvalidBookingTransition, e.Err}
+}
+
+// bookingEdge keys transitions and rejections by where the booking is and what happened
+type bookingEdge struct {
+	from  BookingStatus
+	event BookingEvent
+}
+
+// BookingStateMachine is the single place booking statuses change - demonstrates State Machine Pattern:
+// transitions are data, so every status change is checked, guarded and recorded the same way.
+// Configure it with Allow, Reject and OnEnter before use; Fire is then safe from any goroutine
+// holding the booking's lock.
+type BookingStateMachine struct {
+	transitions map[bookingEdge]BookingTransition
+	rejections  map[bookingEdge]error
+	onEnter     map[BookingStatus][]BookingEntryAction
+}
+
+// NewBookingStateMachine creates a machine with no transitions
+func NewBookingStateMachine() *BookingStateMachine {
+	return &BookingStateMachine{
+		transitions: make(map[bookingEdge]BookingTransition),
+		rejections:  make(map[bookingEdge]error),
+		onEnter:     make(map[BookingStatus][]BookingEntryAction),
+	}
+}
+
+// Allow adds a transition, replacing any earlier one for the same status and event
+func (m *BookingStateMachine) Allow(transition BookingTransition) *BookingStateMachine {
+	m.transitions[bookingEdge{transition.From, transition.Event}] = transition
+	return m
+}
+
+// Reject gives the reason an event is refused in a status, instead of the generic ErrInvalidBookingTransition
+func (m *BookingStateMachine) Reject(from BookingStatus, event BookingEvent, reason error) *BookingStateMachine {
+	m.rejections[bookingEdge{from, event}] = reason
+	return m
+}
+
+// OnEnter runs action every time a booking enters status, in registration order
+func (m *BookingStateMachine) OnEnter(status BookingStatus, action BookingEntryAction) *BookingStateMachine {
+	m.onEnter[status] = append(m.onEnter[status], action)
+	return m
+}
+
+// Can reports whether event has a transition out of status; guards may still refuse it
+func (m *BookingStateMachine) Can(status BookingStatus, event BookingEvent) bool {
+	_, ok := m.transitions[bookingEdge{status, event}]
+	return ok
+}
+
+// Fire applies event to the booking: it finds the transition, checks its guard, moves the status and
+// runs the new status's entry actions. The caller must hold the booking's lock.
+func (m *BookingStateMachine) Fire(b *Booking, event BookingEvent, now time.Time) error {
+	edge := bookingEdge{b.Status, event}
+	transition, ok := m.transitions[edge]
+	if !ok {
+		return &BookingTransitionError{From: b.Status, Event: event, Err: m.rejections[edge]}
+	}
+
+	if transition.Guard != nil {
+		if err := transition.Guard(b, now); err != nil {
+			return &BookingTransitionError{From: b.Status, Event: event, Err: err}
+		}
+	}
+
+	change := BookingStatusChange{From: b.Status, To: transition.To, Event: event, At: now}
+	b.Status = transition.To
+	for _, action := range m.onEnter[transition.To] {
+		action(b, change)
+	}
+	return nil
+}
+
+// bookingStates is the machine every Booking goes through:
+//
+//	PENDING --CONFIRM--> CONFIRMED   (guard: payment window still open)
+//	PENDING --CANCEL---> CANCELLED
+//	PENDING --EXPIRE---> EXPIRED
+//	CONFIRMED --CANCEL-> CANCELLED
+var bookingStates = newBookingStates()
+
+// BookingStates returns the machine bookings transition through, e.g. to ask which events a status allows
+func BookingStates() *BookingStateMachine {
+	return bookingStates
+}
+
+func newBookingStates() *BookingStateMachine {
+	m := NewBookingStateMachine().
+		Allow(BookingTransition{From: BookingStatusPending, Event: BookingEventConfirm, To: BookingStatusConfirmed, Guard: paymentWindowOpen}).
+		Allow(BookingTransition{From: BookingStatusPending, Event: BookingEventCancel, To: BookingStatusCancelled}).
+		Allow(BookingTransition{From: BookingStatusPending, Event: BookingEventExpire, To: BookingStatusExpired}).
+		Allow(BookingTransition{From: BookingStatusConfirmed, Event: BookingEventCancel, To: BookingStatusCancelled})
+
+	for _, status := range []BookingStatus{BookingStatusConfirmed, BookingStatusCancelled, BookingStatusExpired} {
+		m.Reject(status, BookingEventConfirm, ErrBookingNotPending).
+			Reject(status, BookingEventExpire, ErrBookingNotPending)
+	}
+	m.Reject(BookingStatusCancelled, BookingEventCancel, ErrBookingAlreadyCancelled).
+		Reject(BookingStatusExpired, BookingEventCancel, ErrBookingExpired)
+
+	for _, status := range []BookingStatus{BookingStatusConfirmed, BookingStatusCancelled, BookingStatusExpired} {
+		m.OnEnter(status, recordStatusChange)
+	}
+	return m
+}
+
+// paymentWindowOpen refuses to confirm a booking whose payment window has closed
+func paymentWindowOpen(b *Booking, now time.Time) error {
+	if now.After(b.ExpiryTime) {
+		return ErrBookingExpired
+	}
+	return nil
+}
+
+// recordStatusChange stamps the booking and appends the change to its history
+func recordStatusChange(b *Booking, change BookingStatusChange) {
+	b.StatusHistory = append(b.StatusHistory, change)
+	b.UpdatedAt = change.At
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"cmp"
+	"time"
+)
+
+// MinSeatHoldDuration is the shortest seat hold the platform may be configured with
+const MinSeatHoldDuration = time.Minute
+
+// BookingTimeouts are the platform's defaults for how long a pending booking waits for payment and how long
+// blocked seats stay held. Shows and theatres may still set their own payment window.
+type BookingTimeouts struct {
+	Payment time.Duration // BookingTimeout when zero
+	Hold    time.Duration // SeatHoldDuration when zero
+}
+
+// Validate accepts zero fields, meaning the built-in defaults, or timeouts within their bounds
+func (t BookingTimeouts) Validate() error {
+	if err := ValidateBookingTimeout(t.Payment); err != nil {
+		return err
+	}
+	if t.Hold != 0 && (t.Hold < MinSeatHoldDuration || t.Hold > MaxBookingTimeout) {
+		return ErrInvalidHoldDuration
+	}
+	return nil
+}
+
+// PaymentWindow is how long a pending booking waits for payment when its show and theatre set nothing
+func (t BookingTimeouts) PaymentWindow() time.Duration {
+	return cmp.Or(t.Payment, BookingTimeout)
+}
+
+// HoldDuration is how long blocked seats stay held, unless the show's payment window is shorter
+func (t BookingTimeouts) HoldDuration() time.Duration {
+	return cmp.Or(t.Hold, SeatHoldDuration)
+}
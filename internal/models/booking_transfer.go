@@ -0,0 +1,111 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BookingTransferStatus tracks an offer to hand a booking to another user
+type BookingTransferStatus string
+
+const (
+	BookingTransferPending   BookingTransferStatus = "PENDING"   // Waiting for the recipient to accept or decline
+	BookingTransferAccepted  BookingTransferStatus = "ACCEPTED"  // The booking is now the recipient's
+	BookingTransferDeclined  BookingTransferStatus = "DECLINED"  // The booking stays with its owner
+	BookingTransferWithdrawn BookingTransferStatus = "WITHDRAWN" // The owner offered it to someone else instead
+)
+
+// BookingTransfer is an offer of a confirmed booking to another registered user. The booking only changes
+// hands once the recipient accepts.
+type BookingTransfer struct {
+	ID          string                `json:"id"`
+	FromUserID  string                `json:"from_user_id"`
+	ToUserID    string                `json:"to_user_id"`
+	Status      BookingTransferStatus `json:"status"`
+	OfferedAt   time.Time             `json:"offered_at"`
+	RespondedAt *time.Time            `json:"responded_at,omitempty"`
+}
+
+// OfferTransfer offers a confirmed booking to another user, withdrawing any offer still waiting for an answer
+func (b *Booking) OfferTransfer(toUserID string, now time.Time) (BookingTransfer, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.Status != BookingStatusConfirmed {
+		return BookingTransfer{}, ErrBookingNotConfirmed
+	}
+	if toUserID == "" || toUserID == b.UserID {
+		return BookingTransfer{}, ErrInvalidTransfer
+	}
+
+	if pending := b.pendingTransfer(); pending != nil {
+		pending.Status, pending.RespondedAt = BookingTransferWithdrawn, &now
+	}
+
+	transfer := BookingTransfer{
+		ID:         uuid.New().String(),
+		FromUserID: b.UserID,
+		ToUserID:   toUserID,
+		Status:     BookingTransferPending,
+		OfferedAt:  now,
+	}
+	b.Transfers = append(b.Transfers, transfer)
+	b.UpdatedAt = now
+	return transfer, nil
+}
+
+// PendingTransfer returns the offer waiting for an answer, if there is one
+func (b *Booking) PendingTransfer() (BookingTransfer, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	if pending := b.pendingTransfer(); pending != nil {
+		return *pending, true
+	}
+	return BookingTransfer{}, false
+}
+
+// AcceptTransfer hands a confirmed booking to the user it was offered to
+func (b *Booking) AcceptTransfer(userID string, now time.Time) (BookingTransfer, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	pending := b.pendingTransfer()
+	if pending == nil || pending.ToUserID != userID {
+		return BookingTransfer{}, ErrTransferNotFound
+	}
+	if b.Status != BookingStatusConfirmed {
+		return BookingTransfer{}, ErrBookingNotConfirmed
+	}
+
+	pending.Status, pending.RespondedAt = BookingTransferAccepted, &now
+	b.UserID = userID
+	b.UpdatedAt = now
+	return *pending, nil
+}
+
+// DeclineTransfer turns down an offer; the booking stays with its owner
+func (b *Booking) DeclineTransfer(userID string, now time.Time) (BookingTransfer, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	pending := b.pendingTransfer()
+	if pending == nil || pending.ToUserID != userID {
+		return BookingTransfer{}, ErrTransferNotFound
+	}
+
+	pending.Status, pending.RespondedAt = BookingTransferDeclined, &now
+	b.UpdatedAt = now
+	return *pending, nil
+}
+
+// pendingTransfer finds the offer waiting for an answer - callers must hold the mutex
+func (b *Booking) pendingTransfer() *BookingTransfer {
+	for i := range b.Transfers {
+		if b.Transfers[i].Status == BookingTransferPending {
+			return &b.Transfers[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package models
+
+import (
+	"crypto/rand"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BulkCodeStatus is where one seat of a corporate block stands
+type BulkCodeStatus string
+
+const (
+	BulkCodeStatusIssued   BulkCodeStatus = "ISSUED"   // Waiting for an employee to redeem it
+	BulkCodeStatusRedeemed BulkCodeStatus = "REDEEMED" // The seat belongs to the employee who redeemed it
+	BulkCodeStatusReleased BulkCodeStatus = "RELEASED" // Handed back to the show before the release deadline
+)
+
+// MaxBulkBookingSeats caps one corporate block
+const MaxBulkBookingSeats = 250
+
+// DefaultBulkReleaseNotice is how long before the show unused seats can still be handed back, unless the block sets its own deadline
+const DefaultBulkReleaseNotice = 24 * time.Hour
+
+// bulkCodePrefix marks a code as a corporate redemption code
+const bulkCodePrefix = "EMP-"
+
+// bulkCodeLength is the random part of a code; 32^8 codes keep collisions and guessing out of reach
+const bulkCodeLength = 8
+
+// BulkCode is one seat of a corporate block, redeemable by one employee
+type BulkCode struct {
+	Code       string         `json:"code"`
+	SeatID     string         `json:"seat_id"`
+	Seat       string         `json:"seat"` // Label such as "F7"
+	Status     BulkCodeStatus `json:"status"`
+	RedeemedBy string         `json:"redeemed_by,omitempty"`
+	RedeemedAt *time.Time     `json:"redeemed_at,omitempty"`
+	ReleasedAt *time.Time     `json:"released_at,omitempty"`
+}
+
+// BulkSeat is a seat going into a new block
+type BulkSeat struct {
+	ID    string
+	Label string
+}
+
+// BulkBooking is a block of seats a corporate account reserved for one show. The seats are held by an ordinary
+// booking the account pays for; each seat gets a code an employee redeems for it.
+type BulkBooking struct {
+	ID              string      `json:"id"`
+	AccountID       string      `json:"account_id"` // The corporate user who reserved and pays for the block
+	Organization    string      `json:"organization"`
+	ShowID          string      `json:"show_id"`
+	BookingID       string      `json:"booking_id"`
+	Codes           []*BulkCode `json:"codes"`            // In seat order
+	ReleaseDeadline time.Time   `json:"release_deadline"` // Unredeemed seats can be handed back until then
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+	mutex           sync.RWMutex
+}
+
+// NewBulkBooking issues one code per seat; the caller links the booking once it exists
+func NewBulkBooking(accountID, organization, showID string, seats []BulkSeat, releaseDeadline, now time.Time) (*BulkBooking, error) {
+	organization = strings.TrimSpace(organization)
+	if accountID == "" || organization == "" || showID == "" || len(seats) == 0 || len(seats) > MaxBulkBookingSeats || releaseDeadline.IsZero() {
+		return nil, ErrInvalidBulkBooking
+	}
+
+	codes := make([]*BulkCode, 0, len(seats))
+	for _, seat := range seats {
+		codes = append(codes, &BulkCode{Code: NewBulkCode(), SeatID: seat.ID, Seat: seat.Label, Status: BulkCodeStatusIssued})
+	}
+
+	return &BulkBooking{
+		ID:              uuid.New().String(),
+		AccountID:       accountID,
+		Organization:    organization,
+		ShowID:          showID,
+		Codes:           codes,
+		ReleaseDeadline: releaseDeadline,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// NewBulkCode generates a redemption code such as EMP-7F3K9QWX, from the booking reference alphabet
+func NewBulkCode() string {
+	random := make([]byte, bulkCodeLength)
+	if _, err := rand.Read(random); err != nil {
+		panic("bulk code: " + err.Error())
+	}
+
+	code := make([]byte, bulkCodeLength)
+	for i, b := range random {
+		code[i] = bookingReferenceAlphabet[int(b)%len(bookingReferenceAlphabet)]
+	}
+	return bulkCodePrefix + string(code)
+}
+
+// NormalizeBulkCode canonicalizes a code as typed by an employee, e.g. " emp-7f3k9qwx"
+func NormalizeBulkCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// SetBooking links the booking that holds the block's seats
+func (b *BulkBooking) SetBooking(bookingID string, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.BookingID = bookingID
+	b.UpdatedAt = now
+}
+
+// HasCode reports whether the code belongs to the block
+func (b *BulkBooking) HasCode(code string) bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.code(code) != nil
+}
+
+// Redeem gives the code's seat to the employee; each employee redeems at most one code per block
+func (b *BulkBooking) Redeem(code, userID string, now time.Time) (*BulkCode, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	redeemable := b.code(code)
+	if redeemable == nil {
+		return nil, ErrBulkCodeNotFound
+	}
+	if redeemable.Status != BulkCodeStatusIssued {
+		return nil, ErrBulkCodeUnavailable
+	}
+	if slices.ContainsFunc(b.Codes, func(c *BulkCode) bool { return c.RedeemedBy == userID }) {
+		return nil, ErrBulkCodeAlreadyClaimed
+	}
+
+	redeemable.Status = BulkCodeStatusRedeemed
+	redeemable.RedeemedBy = userID
+	redeemable.RedeemedAt = &now
+	b.UpdatedAt = now
+	return redeemable, nil
+}
+
+// PlanRelease picks the issued codes to hand back - the given ones, or every issued code when none are given -
+// and the seats the booking keeps afterwards. Nothing changes until MarkReleased.
+func (b *BulkBooking) PlanRelease(codes []string, now time.Time) (released []*BulkCode, keptSeatIDs []string, err error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	if now.After(b.ReleaseDeadline) {
+		return nil, nil, ErrBulkReleaseClosed
+	}
+
+	if len(codes) == 0 {
+		for _, c := range b.Codes {
+			if c.Status == BulkCodeStatusIssued {
+				released = append(released, c)
+			}
+		}
+	}
+	for _, code := range codes {
+		c := b.code(code)
+		if c == nil {
+			return nil, nil, ErrBulkCodeNotFound
+		}
+		if c.Status != BulkCodeStatusIssued {
+			return nil, nil, ErrBulkCodeUnavailable
+		}
+		if !slices.Contains(released, c) {
+			released = append(released, c)
+		}
+	}
+	if len(released) == 0 {
+		return nil, nil, ErrBulkCodeUnavailable
+	}
+
+	for _, c := range b.Codes {
+		if c.Status != BulkCodeStatusReleased && !slices.Contains(released, c) {
+			keptSeatIDs = append(keptSeatIDs, c.SeatID)
+		}
+	}
+	if len(keptSeatIDs) == 0 {
+		return nil, nil, ErrBulkReleaseAll
+	}
+	return released, keptSeatIDs, nil
+}
+
+// MarkReleased records that the codes' seats went back to the show
+func (b *BulkBooking) MarkReleased(codes []*BulkCode, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, c := range codes {
+		c.Status = BulkCodeStatusReleased
+		c.ReleasedAt = &now
+	}
+	b.UpdatedAt = now
+}
+
+// Tally counts the block's codes by status
+func (b *BulkBooking) Tally() map[BulkCodeStatus]int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	tally := map[BulkCodeStatus]int{BulkCodeStatusIssued: 0, BulkCodeStatusRedeemed: 0, BulkCodeStatusReleased: 0}
+	for _, c := range b.Codes {
+		tally[c.Status]++
+	}
+	return tally
+}
+
+func (b *BulkBooking) code(code string) *BulkCode {
+	code = NormalizeBulkCode(code)
+	for _, c := range b.Codes {
+		if c.Code == code {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// CancellationTier refunds RefundPercent of what was paid when a booking is
+// cancelled more than HoursBefore hours before the show starts
+type CancellationTier struct {
+	HoursBefore   int     `json:"hours_before"`
+	RefundPercent float64 `json:"refund_percent"`
+}
+
+// CancellationPolicy is a theatre's tiered refund schedule for user cancellations.
+// Cancelling once the show has started matches no tier and refunds nothing.
+type CancellationPolicy struct {
+	Tiers []CancellationTier `json:"tiers"`
+}
+
+// NewCancellationPolicy validates the tiers and orders them from the longest notice down
+func NewCancellationPolicy(tiers []CancellationTier) (*CancellationPolicy, error) {
+	if len(tiers) == 0 {
+		return nil, ErrInvalidCancellationPolicy
+	}
+
+	seen := make(map[int]bool, len(tiers))
+	sorted := make([]CancellationTier, 0, len(tiers))
+	for _, tier := range tiers {
+		if tier.HoursBefore < 0 || tier.RefundPercent < 0 || tier.RefundPercent > 100 || seen[tier.HoursBefore] {
+			return nil, ErrInvalidCancellationPolicy
+		}
+		seen[tier.HoursBefore] = true
+		sorted = append(sorted, tier)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].HoursBefore > sorted[j].HoursBefore
+	})
+	return &CancellationPolicy{Tiers: sorted}, nil
+}
+
+// DefaultCancellationPolicy refunds 100% more than 24h before the show, 50% until it starts and nothing after
+func DefaultCancellationPolicy() *CancellationPolicy {
+	return &CancellationPolicy{
+		Tiers: []CancellationTier{
+			{HoursBefore: 24, RefundPercent: 100},
+			{HoursBefore: 0, RefundPercent: 50},
+		},
+	}
+}
+
+// RefundPercent returns the refund share for a cancellation made the given time before the show
+func (p *CancellationPolicy) RefundPercent(notice time.Duration) float64 {
+	for _, tier := range p.Tiers {
+		if notice > time.Duration(tier.HoursBefore)*time.Hour {
+			return tier.RefundPercent
+		}
+	}
+	return 0
+}
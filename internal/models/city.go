@@ -0,0 +1,63 @@
+package models
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// earthRadiusKm is the mean Earth radius used for great-circle distances
+const earthRadiusKm = 6371.0
+
+// City is a catalog entry the client picks before browsing theatres
+type City struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Region    string    `json:"region,omitempty"` // State or region, e.g. "Maharashtra"
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewCity creates a city catalog entry
+func NewCity(name, region string, now time.Time) (*City, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidCityData
+	}
+
+	return &City{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Region:    strings.TrimSpace(region),
+		CreatedAt: now,
+	}, nil
+}
+
+// GeoPoint is a latitude/longitude pair in decimal degrees
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// NewGeoPoint validates the coordinate ranges
+func NewGeoPoint(lat, lng float64) (GeoPoint, error) {
+	if math.IsNaN(lat) || math.IsNaN(lng) || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return GeoPoint{}, ErrInvalidLocation
+	}
+	return GeoPoint{Latitude: lat, Longitude: lng}, nil
+}
+
+// DistanceKm returns the great-circle (haversine) distance to other
+func (p GeoPoint) DistanceKm(other GeoPoint) float64 {
+	lat1, lat2 := radians(p.Latitude), radians(other.Latitude)
+	dLat := lat2 - lat1
+	dLng := radians(other.Longitude - p.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DiscountType represents how a coupon discount is calculated
+type DiscountType string
+
+const (
+	DiscountTypePercentage DiscountType = "PERCENTAGE"
+	DiscountTypeFlat       DiscountType = "FLAT"
+)
+
+// Coupon represents a promo code with redemption rules.
+// Value is a percentage for PERCENTAGE coupons and a major-unit amount for FLAT coupons.
+type Coupon struct {
+	ID           string       `json:"id"`
+	Code         string       `json:"code"`
+	DiscountType DiscountType `json:"discount_type"`
+	Value        float64      `json:"value"`
+	MinAmount    Money        `json:"min_amount"`
+	ExpiresAt    time.Time    `json:"expires_at"`
+	UsageLimit   int          `json:"usage_limit"`
+	UsedCount    int          `json:"used_count"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	mutex        sync.RWMutex
+}
+
+// NewCoupon creates a new coupon with validation
+func NewCoupon(code string, discountType DiscountType, value float64, minAmount Money, expiresAt time.Time, usageLimit int, now time.Time) (*Coupon, error) {
+	code = NormalizeCouponCode(code)
+	if code == "" || value <= 0 || minAmount.IsNegative() || usageLimit <= 0 {
+		return nil, ErrInvalidCouponData
+	}
+
+	switch discountType {
+	case DiscountTypePercentage:
+		if value > 100 {
+			return nil, ErrInvalidCouponData
+		}
+	case DiscountTypeFlat:
+	default:
+		return nil, ErrInvalidCouponData
+	}
+
+	if expiresAt.Before(now) {
+		return nil, ErrInvalidCouponData
+	}
+
+	return &Coupon{
+		ID:           uuid.New().String(),
+		Code:         code,
+		DiscountType: discountType,
+		Value:        value,
+		MinAmount:    minAmount,
+		ExpiresAt:    expiresAt,
+		UsageLimit:   usageLimit,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// NormalizeCouponCode makes coupon lookups case-insensitive
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks whether the coupon can be applied to an amount (thread-safe)
+func (c *Coupon) Validate(amount Money, now time.Time) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.validate(amount, now)
+}
+
+// CalculateDiscount returns the discount for an amount, never exceeding the amount
+func (c *Coupon) CalculateDiscount(amount Money) Money {
+	discount := ZeroMoney(amount.Currency)
+	switch c.DiscountType {
+	case DiscountTypePercentage:
+		discount = amount.Percent(c.Value)
+	case DiscountTypeFlat:
+		discount = MoneyFromMajor(c.Value, amount.Currency)
+	}
+
+	return discount.Min(amount)
+}
+
+// Redeem validates the coupon and consumes one use atomically
+func (c *Coupon) Redeem(amount Money, now time.Time) (Money, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.validate(amount, now); err != nil {
+		return ZeroMoney(amount.Currency), err
+	}
+
+	c.UsedCount++
+	c.UpdatedAt = now
+	return c.CalculateDiscount(amount), nil
+}
+
+// Release gives back a use, e.g. when the booking that redeemed it is cancelled
+func (c *Coupon) Release(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.UsedCount > 0 {
+		c.UsedCount--
+		c.UpdatedAt = now
+	}
+}
+
+// validate performs the checks without locking - callers must hold the mutex
+func (c *Coupon) validate(amount Money, now time.Time) error {
+	if now.After(c.ExpiresAt) {
+		return ErrCouponExpired
+	}
+
+	if c.UsedCount >= c.UsageLimit {
+		return ErrCouponUsageLimitReached
+	}
+
+	if !amount.SameCurrency(c.MinAmount) {
+		return ErrCurrencyMismatch
+	}
+
+	if amount.LessThan(c.MinAmount) {
+		return ErrCouponMinAmountNotMet
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DealsConfig shapes last-minute deals; a zero Percent turns them off
+type DealsConfig struct {
+	Window       time.Duration // Shows starting within this long are considered
+	MaxOccupancy float64       // Percent of the seats on sale taken, below which a show gets a deal
+	Percent      float64       // Taken off every seat of a show with a deal
+	Interval     time.Duration // How often shows are checked for new deals
+}
+
+// DefaultDealsConfig checks every 5 minutes for shows starting within 3 hours that are under 30% full; deals
+// stay off until a percent is set
+func DefaultDealsConfig() DealsConfig {
+	return DealsConfig{
+		Window:       3 * time.Hour,
+		MaxOccupancy: 30,
+		Interval:     5 * time.Minute,
+	}
+}
+
+// Deal is a time-boxed discount on a show that is about to start with plenty of seats left. It runs from
+// when it is made until the show starts.
+type Deal struct {
+	ID        string    `json:"id"`
+	ShowID    string    `json:"show_id"`
+	TheatreID string    `json:"theatre_id"`
+	MovieID   string    `json:"movie_id,omitempty"`
+	EventID   string    `json:"event_id,omitempty"` // Set for live events
+	City      string    `json:"city"`
+	Percent   float64   `json:"percent"`   // Off every seat
+	Occupancy float64   `json:"occupancy"` // Percent of the seats on sale taken when the deal was made
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"` // The show's start time
+}
+
+// NewDeal creates a deal on show, in the city of its theatre, running from now until the show starts
+func NewDeal(show *Show, city string, percent, occupancy float64, now time.Time) *Deal {
+	return &Deal{
+		ID:        uuid.New().String(),
+		ShowID:    show.ID,
+		TheatreID: show.TheatreID,
+		MovieID:   show.MovieID,
+		EventID:   show.EventID,
+		City:      city,
+		Percent:   percent,
+		Occupancy: occupancy,
+		StartsAt:  now,
+		EndsAt:    show.StartTime,
+	}
+}
+
+// IsActive reports whether the deal runs at the given time
+func (d *Deal) IsActive(at time.Time) bool {
+	return !at.Before(d.StartsAt) && at.Before(d.EndsAt)
+}
+
+// Occupancy is the percent of the seats on sale that are booked or blocked; ok is false when none are on sale
+func (c SeatCounts) Occupancy() (percent float64, ok bool) {
+	onSale := c.Total - c.Withheld
+	if onSale <= 0 {
+		return 0, false
+	}
+	return float64(c.Booked+c.Blocked) / float64(onSale) * 100, true
+}
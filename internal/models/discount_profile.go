@@ -0,0 +1,122 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DiscountCategory is a group of users entitled to cheaper tickets once they prove they belong to it
+type DiscountCategory string
+
+const (
+	DiscountStudent       DiscountCategory = "STUDENT"
+	DiscountSeniorCitizen DiscountCategory = "SENIOR_CITIZEN"
+	DiscountMilitary      DiscountCategory = "MILITARY" // Serving personnel and veterans
+)
+
+// DiscountCategories lists every category a user can apply for
+func DiscountCategories() []DiscountCategory {
+	return []DiscountCategory{DiscountStudent, DiscountSeniorCitizen, DiscountMilitary}
+}
+
+// IsValid reports whether the category is one users can apply for
+func (c DiscountCategory) IsValid() bool {
+	switch c {
+	case DiscountStudent, DiscountSeniorCitizen, DiscountMilitary:
+		return true
+	}
+	return false
+}
+
+// VerificationStatus is where a discount profile stands: submitted, then verified or rejected by an admin
+type VerificationStatus string
+
+const (
+	VerificationPending  VerificationStatus = "PENDING"
+	VerificationVerified VerificationStatus = "VERIFIED"
+	VerificationRejected VerificationStatus = "REJECTED"
+)
+
+// DiscountProfile is a user's claim to a discount category and the proof behind it.
+// Only a verified profile that hasn't lapsed earns the discount.
+type DiscountProfile struct {
+	Category     DiscountCategory   `json:"category"`
+	Status       VerificationStatus `json:"status"`
+	DocumentID   string             `json:"document_id"`           // The proof an admin checks, e.g. a student ID or service number
+	ValidUntil   *time.Time         `json:"valid_until,omitempty"` // When the proof lapses, e.g. the end of a course; nil never
+	SubmittedAt  time.Time          `json:"submitted_at"`
+	ReviewedBy   string             `json:"reviewed_by,omitempty"` // The admin who verified or rejected it
+	ReviewedAt   *time.Time         `json:"reviewed_at,omitempty"`
+	RejectReason string             `json:"reject_reason,omitempty"`
+}
+
+// AppliedProfileDiscount is what a booking saved through its user's verified discount profile
+type AppliedProfileDiscount struct {
+	Category DiscountCategory `json:"category"`
+	Amount   Money            `json:"amount"`
+}
+
+// SubmitDiscountProfile applies for a discount category. Submitting again replaces the profile, even a verified
+// one, and it waits for review again.
+func (u *User) SubmitDiscountProfile(category DiscountCategory, documentID string, validUntil *time.Time, now time.Time) error {
+	documentID = strings.TrimSpace(documentID)
+	if !category.IsValid() || documentID == "" {
+		return ErrInvalidDiscountProfile
+	}
+	if validUntil != nil && !validUntil.After(now) {
+		return fmt.Errorf("%w: the proof has already lapsed", ErrInvalidDiscountProfile)
+	}
+
+	u.DiscountProfile = &DiscountProfile{
+		Category:    category,
+		Status:      VerificationPending,
+		DocumentID:  documentID,
+		ValidUntil:  validUntil,
+		SubmittedAt: now,
+	}
+	u.UpdatedAt = now
+	return nil
+}
+
+// VerifyDiscountProfile accepts the user's pending profile on behalf of reviewerID
+func (u *User) VerifyDiscountProfile(reviewerID string, now time.Time) error {
+	return u.reviewDiscountProfile(reviewerID, VerificationVerified, "", now)
+}
+
+// RejectDiscountProfile turns down the user's pending profile; the user may submit a new one
+func (u *User) RejectDiscountProfile(reviewerID, reason string, now time.Time) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("%w: a rejection needs a reason", ErrInvalidDiscountProfile)
+	}
+	return u.reviewDiscountProfile(reviewerID, VerificationRejected, reason, now)
+}
+
+func (u *User) reviewDiscountProfile(reviewerID string, status VerificationStatus, reason string, now time.Time) error {
+	if u.DiscountProfile == nil {
+		return ErrDiscountProfileNotFound
+	}
+	if u.DiscountProfile.Status != VerificationPending {
+		return ErrDiscountProfileNotPending
+	}
+
+	u.DiscountProfile.Status = status
+	u.DiscountProfile.ReviewedBy = reviewerID
+	u.DiscountProfile.ReviewedAt = &now
+	u.DiscountProfile.RejectReason = reason
+	u.UpdatedAt = now
+	return nil
+}
+
+// VerifiedDiscount returns the category the user may claim a discount in at the given time; ok is false without
+// a verified profile or once its proof has lapsed
+func (u *User) VerifiedDiscount(at time.Time) (category DiscountCategory, ok bool) {
+	profile := u.DiscountProfile
+	if profile == nil || profile.Status != VerificationVerified {
+		return "", false
+	}
+	if profile.ValidUntil != nil && !at.Before(*profile.ValidUntil) {
+		return "", false
+	}
+	return profile.Category, true
+}
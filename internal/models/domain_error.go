@@ -0,0 +1,94 @@
+package models
+
+import (
+	"errors"
+	"maps"
+)
+
+// ErrorKind is the broad class of a failure, which decides its HTTP status and gRPC code
+type ErrorKind string
+
+const (
+	KindInvalid         ErrorKind = "INVALID"          // The request is malformed or breaks a rule of the data
+	KindNotFound        ErrorKind = "NOT_FOUND"        // Something the request names doesn't exist
+	KindAlreadyExists   ErrorKind = "ALREADY_EXISTS"   // What the request would create is already there
+	KindConflict        ErrorKind = "CONFLICT"         // The current state doesn't allow it, e.g. a seat already taken
+	KindAborted         ErrorKind = "ABORTED"          // Lost a race with a concurrent change; trying again may work
+	KindGone            ErrorKind = "GONE"             // It existed but has expired
+	KindPaymentRequired ErrorKind = "PAYMENT_REQUIRED" // The payment was declined or couldn't be taken
+	KindUnauthenticated ErrorKind = "UNAUTHENTICATED"  // No valid session
+	KindForbidden       ErrorKind = "FORBIDDEN"        // A known caller who isn't allowed to
+	KindRateLimited     ErrorKind = "RATE_LIMITED"     // Too many requests; retry later
+	KindUpstream        ErrorKind = "UPSTREAM"         // A provider we call failed
+	KindUnavailable     ErrorKind = "UNAVAILABLE"      // Temporarily out of service
+	KindInternal        ErrorKind = "INTERNAL"         // A bug or an unexpected failure
+)
+
+// Retryable reports whether the same request may succeed if sent again unchanged
+func (k ErrorKind) Retryable() bool {
+	switch k {
+	case KindAborted, KindRateLimited, KindUpstream, KindUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// DomainError is a failure with a stable, machine-readable code, so clients and API layers branch on the
+// code or kind rather than the message. The sentinels in errors.go are DomainErrors; errors.Is still
+// matches them as before, and a refined sentinel such as ErrWeakPassword still matches the broader one.
+type DomainError struct {
+	Kind      ErrorKind
+	Code      string         // e.g. SEAT_NOT_AVAILABLE
+	Message   string         // What Error returns
+	Retryable bool           // The same request may succeed if sent again
+	Details   map[string]any // Facts about this occurrence, e.g. the seconds to wait; nil on sentinels
+	cause     error          // The broader sentinel this one refines, or the sentinel a detailed copy came from
+}
+
+// NewDomainError creates an error of kind, retryable when the kind is
+func NewDomainError(kind ErrorKind, code, message string) *DomainError {
+	return &DomainError{Kind: kind, Code: code, Message: message, Retryable: kind.Retryable()}
+}
+
+func (e *DomainError) Error() string {
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.cause
+}
+
+// Refine creates a narrower error of the same kind; its message extends e's, and errors.Is matches both
+func (e *DomainError) Refine(code, message string) *DomainError {
+	return e.refineAs(e.Kind, code, message)
+}
+
+// refineAs is Refine for a narrower error of another kind, e.g. ErrForbidden under ErrUnauthorized
+func (e *DomainError) refineAs(kind ErrorKind, code, message string) *DomainError {
+	refined := NewDomainError(kind, code, e.Message+": "+message)
+	refined.cause = e
+	return refined
+}
+
+// WithDetails copies e with details added, for one occurrence of a sentinel; errors.Is still matches e
+func (e *DomainError) WithDetails(details map[string]any) *DomainError {
+	detailed := *e
+	detailed.Details = maps.Clone(e.Details)
+	if detailed.Details == nil {
+		detailed.Details = make(map[string]any, len(details))
+	}
+	maps.Copy(detailed.Details, details)
+	detailed.cause = e
+	return &detailed
+}
+
+// AsDomainError finds the DomainError in err's chain. Errors that aren't one - a bug, or a failure nothing
+// anticipated - come back as INTERNAL.
+func AsDomainError(err error) *DomainError {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+	return ErrInternalError
+}
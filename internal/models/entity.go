@@ -0,0 +1,53 @@
+package models
+
+// GetID methods let generic repositories store any of these entities by its ID
+
+func (u *User) GetID() string { return u.ID }
+
+func (m *Movie) GetID() string { return m.ID }
+
+func (e *Event) GetID() string { return e.ID }
+
+func (t *Theatre) GetID() string { return t.ID }
+
+func (c *City) GetID() string { return c.ID }
+
+func (s *Screen) GetID() string { return s.ID }
+
+func (s *Show) GetID() string { return s.ID }
+
+func (h *SeatHold) GetID() string { return h.ID }
+
+func (b *Booking) GetID() string { return b.ID }
+
+func (p *Payment) GetID() string { return p.ID }
+
+func (r *Refund) GetID() string { return r.ID }
+
+func (t *Ticket) GetID() string { return t.ID }
+
+func (r *Review) GetID() string { return r.ID }
+
+func (s *Session) GetID() string { return s.ID }
+
+func (s *Settlement) GetID() string { return s.ID }
+
+func (m *OutboxMessage) GetID() string { return m.ID }
+
+func (a *AuditEntry) GetID() string { return a.ID }
+
+func (w *Webhook) GetID() string { return w.ID }
+
+func (d *WebhookDelivery) GetID() string { return d.ID }
+
+func (e *WatchlistEntry) GetID() string { return e.ID }
+
+func (b *BulkBooking) GetID() string { return b.ID }
+
+func (r *ParkingReservation) GetID() string { return r.ID }
+
+func (l *ResaleListing) GetID() string { return l.ID }
+
+func (i *PaymentInstrument) GetID() string { return i.ID }
+
+func (n *Notification) GetID() string { return n.ID }
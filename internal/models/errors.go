@@ -1,71 +1,369 @@
 package models
 
-import "errors"
+import "fmt"
 
 // User errors
 var (
-	ErrInvalidUserData = errors.New("invalid user data provided")
-	ErrUserNotFound    = errors.New("user not found")
+	ErrInvalidUserData = NewDomainError(KindInvalid, "INVALID_USER_DATA", "invalid user data provided")
+	ErrUserNotFound    = NewDomainError(KindNotFound, "USER_NOT_FOUND", "user not found")
+	ErrUserBlocked     = NewDomainError(KindForbidden, "USER_BLOCKED", "user is blocked from booking")
+)
+
+// Discount profile errors
+var (
+	ErrInvalidDiscountProfile    = NewDomainError(KindInvalid, "INVALID_DISCOUNT_PROFILE", "invalid discount profile")
+	ErrDiscountProfileNotFound   = NewDomainError(KindNotFound, "DISCOUNT_PROFILE_NOT_FOUND", "user has no discount profile")
+	ErrDiscountProfileNotPending = NewDomainError(KindConflict, "DISCOUNT_PROFILE_NOT_PENDING", "discount profile has already been reviewed")
+)
+
+// Authentication errors
+var (
+	ErrWeakPassword       = ErrInvalidUserData.Refine("WEAK_PASSWORD", "password is too short")
+	ErrPasswordTooLong    = ErrInvalidUserData.Refine("PASSWORD_TOO_LONG", "password is too long")
+	ErrInvalidPreferences = ErrInvalidUserData.Refine("INVALID_PREFERENCES", "preferences hold at most 10 languages, genres and theatres each")
+	ErrInvalidCredentials = ErrUnauthorized.Refine("INVALID_CREDENTIALS", "wrong email or password")
+	ErrInvalidSession     = ErrUnauthorized.Refine("INVALID_SESSION", "session is invalid, expired or signed out")
+	ErrCredentialNotFound = NewDomainError(KindNotFound, "CREDENTIAL_NOT_FOUND", "credential not found")
+	ErrSessionNotFound    = NewDomainError(KindNotFound, "SESSION_NOT_FOUND", "session not found")
 )
 
 // Movie errors
 var (
-	ErrInvalidMovieData = errors.New("invalid movie data provided")
-	ErrMovieNotFound    = errors.New("movie not found")
+	ErrInvalidMovieData = NewDomainError(KindInvalid, "INVALID_MOVIE_DATA", "invalid movie data provided")
+	ErrMovieNotFound    = NewDomainError(KindNotFound, "MOVIE_NOT_FOUND", "movie not found")
+	ErrCatalogSource    = NewDomainError(KindUpstream, "CATALOG_SOURCE_FAILED", "movie catalog source failed") // Remote API or fixture couldn't be read
+
+	ErrInvalidCertificate = ErrInvalidMovieData.Refine("INVALID_CERTIFICATE", "unknown certificate")
+	ErrAgeRestricted      = NewDomainError(KindForbidden, "AGE_RESTRICTED", "viewer is too young for this certificate")
+)
+
+// Event errors
+var (
+	ErrInvalidEventData = NewDomainError(KindInvalid, "INVALID_EVENT_DATA", "invalid event data provided")
+	ErrEventNotFound    = NewDomainError(KindNotFound, "EVENT_NOT_FOUND", "event not found")
+)
+
+// Review errors
+var (
+	ErrInvalidReviewData = NewDomainError(KindInvalid, "INVALID_REVIEW_DATA", "invalid review data provided")
+	ErrReviewNotFound    = NewDomainError(KindNotFound, "REVIEW_NOT_FOUND", "review not found")
+	ErrDuplicateReview   = NewDomainError(KindAlreadyExists, "DUPLICATE_REVIEW", "user has already reviewed this movie")
+	ErrMovieNotReleased  = NewDomainError(KindConflict, "MOVIE_NOT_RELEASED", "movie has not been released yet")
+)
+
+// Watchlist errors
+var (
+	ErrInvalidWatchlistEntry  = NewDomainError(KindInvalid, "INVALID_WATCHLIST_ENTRY", "invalid watchlist entry")
+	ErrWatchlistEntryNotFound = NewDomainError(KindNotFound, "WATCHLIST_ENTRY_NOT_FOUND", "movie is not on the watchlist")
+	ErrWatchlistFull          = NewDomainError(KindConflict, "WATCHLIST_FULL", fmt.Sprintf("watchlist already holds the maximum of %d movies", MaxWatchlistSize))
 )
 
 // Theatre errors
 var (
-	ErrInvalidTheatreData = errors.New("invalid theatre data provided")
-	ErrTheatreNotFound    = errors.New("theatre not found")
+	ErrInvalidTheatreData = NewDomainError(KindInvalid, "INVALID_THEATRE_DATA", "invalid theatre data provided")
+	ErrTheatreNotFound    = NewDomainError(KindNotFound, "THEATRE_NOT_FOUND", "theatre not found")
+
+	ErrInvalidCancellationPolicy = NewDomainError(KindInvalid, "INVALID_CANCELLATION_POLICY", "invalid cancellation policy")
+	ErrInvalidLocation           = NewDomainError(KindInvalid, "INVALID_LOCATION", "invalid location coordinates")
+)
+
+// City errors
+var (
+	ErrInvalidCityData   = NewDomainError(KindInvalid, "INVALID_CITY_DATA", "invalid city data provided")
+	ErrCityNotFound      = NewDomainError(KindNotFound, "CITY_NOT_FOUND", "city not found")
+	ErrCityAlreadyExists = NewDomainError(KindAlreadyExists, "CITY_ALREADY_EXISTS", "city already exists")
 )
 
 // Screen errors
 var (
-	ErrScreenNotFound = errors.New("screen not found")
+	ErrScreenNotFound         = NewDomainError(KindNotFound, "SCREEN_NOT_FOUND", "screen not found")
+	ErrScreenUnderMaintenance = NewDomainError(KindConflict, "SCREEN_UNDER_MAINTENANCE", "screen is offline for maintenance")
+	ErrScreenTemplateNotFound = NewDomainError(KindNotFound, "SCREEN_TEMPLATE_NOT_FOUND", "screen template not found")
+	ErrScreenTemplateExists   = NewDomainError(KindAlreadyExists, "SCREEN_TEMPLATE_EXISTS", "screen template already registered")
 )
 
 // Seat errors
 var (
-	ErrSeatNotFound      = errors.New("seat not found")
-	ErrSeatNotAvailable  = errors.New("seat is not available")
-	ErrSeatNotBlocked    = errors.New("seat is not blocked")
-	ErrSeatAlreadyBooked = errors.New("seat is already booked")
+	ErrSeatNotFound      = NewDomainError(KindNotFound, "SEAT_NOT_FOUND", "seat not found")
+	ErrSeatNotAvailable  = NewDomainError(KindConflict, "SEAT_NOT_AVAILABLE", "seat is not available")
+	ErrSeatNotBlocked    = NewDomainError(KindConflict, "SEAT_NOT_BLOCKED", "seat is not blocked")
+	ErrSeatAlreadyBooked = NewDomainError(KindConflict, "SEAT_ALREADY_BOOKED", "seat is already booked")
+	ErrInvalidSeatType   = NewDomainError(KindInvalid, "INVALID_SEAT_TYPE", "invalid seat type")
+	ErrSeatTypeExists    = NewDomainError(KindAlreadyExists, "SEAT_TYPE_EXISTS", "seat type already registered")
+	ErrSeatGroupSplit    = NewDomainError(KindInvalid, "SEAT_GROUP_SPLIT", "seats in a group must be booked together")
+	ErrInvalidSeatGroup  = NewDomainError(KindInvalid, "INVALID_SEAT_GROUP", "invalid seat group")
+	ErrCompanionSeatOnly = NewDomainError(KindInvalid, "COMPANION_SEAT_ONLY", "companion seats can only be booked with the wheelchair space beside them")
+
+	ErrSeatWithheld    = ErrSeatNotAvailable.Refine("SEAT_WITHHELD", "seat is held back by the theatre")
+	ErrSeatNotWithheld = NewDomainError(KindConflict, "SEAT_NOT_WITHHELD", "seat is not held back")
+)
+
+// Seat hold errors
+var (
+	ErrInvalidSeatHoldData    = NewDomainError(KindInvalid, "INVALID_SEAT_HOLD_DATA", "invalid seat hold data provided")
+	ErrSeatHoldNotFound       = NewDomainError(KindNotFound, "SEAT_HOLD_NOT_FOUND", "seat hold not found")
+	ErrSeatHoldNotActive      = NewDomainError(KindConflict, "SEAT_HOLD_NOT_ACTIVE", "seat hold is not active")
+	ErrSeatHoldExpired        = NewDomainError(KindGone, "SEAT_HOLD_EXPIRED", "seat hold has expired")
+	ErrSeatHoldExtensionLimit = NewDomainError(KindConflict, "SEAT_HOLD_EXTENSION_LIMIT", "seat hold extension limit reached")
+	ErrSeatHoldMismatch       = NewDomainError(KindInvalid, "SEAT_HOLD_MISMATCH", "seat hold does not match booking request")
+	ErrInvalidHoldDuration    = ErrInvalidSeatHoldData.Refine("INVALID_HOLD_DURATION", "seat hold duration must be 0 for the default or from 1 to 60 minutes")
 )
 
 // Show errors
 var (
-	ErrInvalidShowData = errors.New("invalid show data provided")
-	ErrInvalidShowTime = errors.New("invalid show time")
-	ErrShowNotFound    = errors.New("show not found")
-	ErrShowNotBookable = errors.New("show is not available for booking")
+	ErrInvalidShowData = NewDomainError(KindInvalid, "INVALID_SHOW_DATA", "invalid show data provided")
+	ErrInvalidShowTime = NewDomainError(KindInvalid, "INVALID_SHOW_TIME", "invalid show time")
+	ErrShowNotFound    = NewDomainError(KindNotFound, "SHOW_NOT_FOUND", "show not found")
+	ErrShowNotBookable = NewDomainError(KindConflict, "SHOW_NOT_BOOKABLE", "show is not available for booking")
+	ErrShowNotOnSale   = ErrShowNotBookable.Refine("SHOW_NOT_ON_SALE", "bookings haven't opened yet")
+
+	ErrInvalidBookingTimeout = ErrInvalidShowData.Refine("INVALID_BOOKING_TIMEOUT", "booking timeout must be 0 for the default or from 2 to 60 minutes")
+	ErrInvalidHouseSeats     = ErrInvalidShowData.Refine("INVALID_HOUSE_SEATS", "held back seats need a HOUSE or BLOCKED_ADMIN status and a reason")
+	ErrInvalidSaleWindow     = ErrInvalidShowData.Refine("INVALID_SALE_WINDOW", "bookings open either up to 90 days before a show or at a fixed time")
+
+	ErrShowCancelled      = NewDomainError(KindConflict, "SHOW_CANCELLED", "show has been cancelled")
+	ErrShowAlreadyStarted = NewDomainError(KindConflict, "SHOW_ALREADY_STARTED", "show has already started")
+	ErrShowNotEnded       = NewDomainError(KindConflict, "SHOW_NOT_ENDED", "show hasn't ended yet")
+	ErrShowClosed         = NewDomainError(KindConflict, "SHOW_CLOSED", "show has already been closed out")
 )
 
 // Booking errors
 var (
-	ErrInvalidBookingData      = errors.New("invalid booking data provided")
-	ErrBookingNotFound         = errors.New("booking not found")
-	ErrBookingNotPending       = errors.New("booking is not in pending status")
-	ErrBookingExpired          = errors.New("booking has expired")
-	ErrBookingAlreadyConfirmed = errors.New("booking is already confirmed")
-	ErrBookingAlreadyCancelled = errors.New("booking is already cancelled")
-	ErrInsufficientSeats       = errors.New("insufficient available seats")
+	ErrInvalidBookingData        = NewDomainError(KindInvalid, "INVALID_BOOKING_DATA", "invalid booking data provided")
+	ErrBookingNotFound           = NewDomainError(KindNotFound, "BOOKING_NOT_FOUND", "booking not found")
+	ErrBookingNotPending         = NewDomainError(KindConflict, "BOOKING_NOT_PENDING", "booking is not in pending status")
+	ErrBookingExpired            = NewDomainError(KindGone, "BOOKING_EXPIRED", "booking has expired")
+	ErrBookingAlreadyConfirmed   = NewDomainError(KindConflict, "BOOKING_ALREADY_CONFIRMED", "booking is already confirmed")
+	ErrBookingAlreadyCancelled   = NewDomainError(KindConflict, "BOOKING_ALREADY_CANCELLED", "booking is already cancelled")
+	ErrInsufficientSeats         = NewDomainError(KindConflict, "INSUFFICIENT_SEATS", "insufficient available seats")
+	ErrBookingNotModifiable      = NewDomainError(KindConflict, "BOOKING_NOT_MODIFIABLE", "booking can no longer be modified")
+	ErrBookingNotConfirmed       = NewDomainError(KindConflict, "BOOKING_NOT_CONFIRMED", "booking is not confirmed")
+	ErrDuplicateBookingReference = NewDomainError(KindAlreadyExists, "DUPLICATE_BOOKING_REFERENCE", "booking reference already in use")
+	ErrInvalidBookingTransition  = NewDomainError(KindConflict, "INVALID_BOOKING_TRANSITION", "invalid booking status transition")
+
+	ErrTooManySeatsPerBooking = NewDomainError(KindInvalid, "TOO_MANY_SEATS_PER_BOOKING", "too many seats in one booking")
+	ErrTooManyPendingBookings = NewDomainError(KindConflict, "TOO_MANY_PENDING_BOOKINGS", "too many unpaid bookings")
+	ErrShowTicketLimitReached = NewDomainError(KindConflict, "SHOW_TICKET_LIMIT_REACHED", "ticket limit for this show reached")
+
+	ErrHoldExtensionUnavailable = NewDomainError(KindConflict, "HOLD_EXTENSION_UNAVAILABLE", "payment windows can't be extended")
+	ErrHoldAlreadyExtended      = NewDomainError(KindConflict, "HOLD_ALREADY_EXTENDED", "booking's payment window has already been extended")
+	ErrHoldExtensionLimit       = NewDomainError(KindRateLimited, "HOLD_EXTENSION_LIMIT", "too many payment windows extended in the last 24 hours")
+)
+
+// Booking transfer errors
+var (
+	ErrInvalidTransfer  = NewDomainError(KindInvalid, "INVALID_TRANSFER", "booking cannot be transferred to that user")
+	ErrTransferNotFound = NewDomainError(KindNotFound, "TRANSFER_NOT_FOUND", "no transfer of this booking is waiting for you")
+	ErrTransferClosed   = NewDomainError(KindConflict, "TRANSFER_CLOSED", "booking can no longer be transferred")
+)
+
+// Resale errors
+var (
+	ErrInvalidResaleListing      = NewDomainError(KindInvalid, "INVALID_RESALE_LISTING", "invalid resale listing")
+	ErrResalePriceAboveFaceValue = ErrInvalidResaleListing.Refine("RESALE_PRICE_ABOVE_FACE_VALUE", "resale price can't be more than the booking cost")
+	ErrResaleListingNotFound     = NewDomainError(KindNotFound, "RESALE_LISTING_NOT_FOUND", "resale listing not found")
+	ErrResaleListingClosed       = NewDomainError(KindConflict, "RESALE_LISTING_CLOSED", "resale listing is no longer open")
+	ErrBookingAlreadyListed      = NewDomainError(KindConflict, "BOOKING_ALREADY_LISTED", "booking is already listed for resale")
+)
+
+// Bulk booking errors
+var (
+	ErrInvalidBulkBooking     = NewDomainError(KindInvalid, "INVALID_BULK_BOOKING", "invalid bulk booking request")
+	ErrBulkBookingNotFound    = NewDomainError(KindNotFound, "BULK_BOOKING_NOT_FOUND", "bulk booking not found")
+	ErrBulkCodeNotFound       = NewDomainError(KindNotFound, "BULK_CODE_NOT_FOUND", "redemption code not found")
+	ErrBulkCodeUnavailable    = NewDomainError(KindConflict, "BULK_CODE_UNAVAILABLE", "redemption code has already been redeemed or released")
+	ErrBulkCodeAlreadyClaimed = NewDomainError(KindConflict, "BULK_CODE_ALREADY_CLAIMED", "you have already redeemed a code for this block")
+	ErrBulkReleaseClosed      = NewDomainError(KindConflict, "BULK_RELEASE_CLOSED", "release deadline for this block has passed")
+	ErrBulkReleaseAll         = ErrInvalidBulkBooking.Refine("BULK_RELEASE_ALL", "a release must keep at least one seat; cancel the booking to drop the whole block")
+)
+
+// Ticket errors
+var (
+	ErrInvalidTicket     = NewDomainError(KindInvalid, "INVALID_TICKET", "invalid ticket")
+	ErrTicketNotFound    = NewDomainError(KindNotFound, "TICKET_NOT_FOUND", "ticket not found")
+	ErrTicketAlreadyUsed = NewDomainError(KindConflict, "TICKET_ALREADY_USED", "ticket has already been used")
+	ErrTicketVoid        = NewDomainError(KindConflict, "TICKET_VOID", "ticket is void")
+	ErrTicketWrongVenue  = NewDomainError(KindConflict, "TICKET_WRONG_VENUE", "ticket is for a different theatre")
+	ErrTicketExpired     = NewDomainError(KindGone, "TICKET_EXPIRED", "show has ended")
 )
 
 // Payment errors
 var (
-	ErrInvalidPaymentData    = errors.New("invalid payment data provided")
-	ErrPaymentNotFound       = errors.New("payment not found")
-	ErrPaymentNotSuccessful  = errors.New("payment was not successful")
-	ErrInvalidRefundAmount   = errors.New("invalid refund amount")
-	ErrPaymentGatewayError   = errors.New("payment gateway error")
-	ErrPaymentProcessingFail = errors.New("payment processing failed")
+	ErrInvalidPaymentData    = NewDomainError(KindInvalid, "INVALID_PAYMENT_DATA", "invalid payment data provided")
+	ErrPaymentNotFound       = NewDomainError(KindNotFound, "PAYMENT_NOT_FOUND", "payment not found")
+	ErrPaymentNotSuccessful  = NewDomainError(KindConflict, "PAYMENT_NOT_SUCCESSFUL", "payment was not successful")
+	ErrInvalidRefundAmount   = NewDomainError(KindInvalid, "INVALID_REFUND_AMOUNT", "invalid refund amount")
+	ErrPaymentGatewayError   = NewDomainError(KindPaymentRequired, "PAYMENT_GATEWAY_ERROR", "payment gateway error")
+	ErrPaymentCircuitOpen    = NewDomainError(KindUnavailable, "PAYMENT_CIRCUIT_OPEN", "payment method temporarily unavailable")
+	ErrPaymentProcessingFail = NewDomainError(KindPaymentRequired, "PAYMENT_PROCESSING_FAILED", "payment processing failed")
+
+	ErrPaymentRetryLimitReached = NewDomainError(KindConflict, "PAYMENT_RETRY_LIMIT_REACHED", "payment retry limit reached")
+	ErrPaymentAlreadySucceeded  = NewDomainError(KindConflict, "PAYMENT_ALREADY_SUCCEEDED", "booking has already been paid")
+	ErrNoFailedPayment          = NewDomainError(KindConflict, "NO_FAILED_PAYMENT", "booking has no failed payment to retry")
+	ErrPaymentBookingMismatch   = NewDomainError(KindConflict, "PAYMENT_BOOKING_MISMATCH", "payment is for another booking")
+	ErrPaymentShortOfTotal      = NewDomainError(KindConflict, "PAYMENT_SHORT_OF_TOTAL", "payment doesn't cover the booking total")
+
+	ErrPaymentOutcomeUnknown       = ErrPaymentGatewayError.Refine("PAYMENT_OUTCOME_UNKNOWN", "the gateway didn't say whether the payment went through; it will be checked again")
+	ErrPaymentAwaitingConfirmation = NewDomainError(KindConflict, "PAYMENT_AWAITING_CONFIRMATION", "an earlier payment for this booking is still being confirmed with the gateway")
+
+	ErrNoPaymentChallenge  = NewDomainError(KindConflict, "NO_PAYMENT_CHALLENGE", "payment isn't waiting for an OTP")
+	ErrIncorrectOTP        = NewDomainError(KindInvalid, "INCORRECT_OTP", "incorrect OTP")
+	ErrOTPChallengeExpired = NewDomainError(KindGone, "OTP_CHALLENGE_EXPIRED", "OTP has expired")
+
+	ErrPaymentRejected             = NewDomainError(KindForbidden, "PAYMENT_REJECTED", "payment was rejected by fraud checks")
+	ErrPaymentVerificationRequired = NewDomainError(KindForbidden, "PAYMENT_VERIFICATION_REQUIRED", "payment needs verification; pay by card or UPI")
+)
+
+// Payment instrument errors
+var (
+	ErrInvalidPaymentInstrument  = NewDomainError(KindInvalid, "INVALID_PAYMENT_INSTRUMENT", "invalid payment instrument")
+	ErrPaymentInstrumentNotFound = NewDomainError(KindNotFound, "PAYMENT_INSTRUMENT_NOT_FOUND", "payment instrument not found")
+	ErrPaymentInstrumentLimit    = NewDomainError(KindConflict, "PAYMENT_INSTRUMENT_LIMIT", fmt.Sprintf("user already has the maximum of %d saved payment instruments", MaxPaymentInstruments))
+	ErrInstrumentMethodMismatch  = ErrInvalidPaymentInstrument.Refine("INSTRUMENT_METHOD_MISMATCH", "payment instrument can't pay by this method")
+	ErrInvalidCardNumber         = ErrInvalidPaymentInstrument.Refine("INVALID_CARD_NUMBER", "card number is invalid")
+	ErrInvalidCardExpiry         = ErrInvalidPaymentInstrument.Refine("INVALID_CARD_EXPIRY", "card expiry must be MM/YY")
+	ErrCardExpired               = ErrInvalidPaymentInstrument.Refine("CARD_EXPIRED", "card has expired")
+	ErrInvalidInstrumentToken    = ErrInvalidPaymentInstrument.Refine("INVALID_INSTRUMENT_TOKEN", "vault doesn't recognise the card token")
+)
+
+// Gateway callback errors
+var (
+	ErrInvalidGatewayCallback   = NewDomainError(KindInvalid, "INVALID_GATEWAY_CALLBACK", "invalid payment gateway callback")
+	ErrInvalidCallbackSignature = NewDomainError(KindUnauthenticated, "INVALID_CALLBACK_SIGNATURE", "payment gateway callback signature is missing, stale or wrong")
+	ErrGatewayCallbacksDisabled = NewDomainError(KindUnavailable, "GATEWAY_CALLBACKS_DISABLED", "payment gateway callbacks need PAYMENT_WEBHOOK_SECRET")
+)
+
+// Wallet errors
+var (
+	ErrInvalidWalletData         = NewDomainError(KindInvalid, "INVALID_WALLET_DATA", "invalid wallet data provided")
+	ErrWalletNotFound            = NewDomainError(KindNotFound, "WALLET_NOT_FOUND", "wallet not found")
+	ErrWalletTransactionNotFound = NewDomainError(KindNotFound, "WALLET_TRANSACTION_NOT_FOUND", "wallet transaction not found")
+	ErrInsufficientWalletBalance = NewDomainError(KindPaymentRequired, "INSUFFICIENT_WALLET_BALANCE", "insufficient wallet balance")
+)
+
+// Loyalty errors
+var (
+	ErrInvalidLoyaltyData        = NewDomainError(KindInvalid, "INVALID_LOYALTY_DATA", "invalid loyalty data provided")
+	ErrLoyaltyAccountNotFound    = NewDomainError(KindNotFound, "LOYALTY_ACCOUNT_NOT_FOUND", "loyalty account not found")
+	ErrInsufficientLoyaltyPoints = NewDomainError(KindConflict, "INSUFFICIENT_LOYALTY_POINTS", "insufficient loyalty points")
+	ErrLoyaltyPointsNotAllowed   = NewDomainError(KindInvalid, "LOYALTY_POINTS_NOT_ALLOWED", "loyalty points cannot cover the whole booking")
+)
+
+// Coupon errors
+var (
+	ErrInvalidCouponData       = NewDomainError(KindInvalid, "INVALID_COUPON_DATA", "invalid coupon data provided")
+	ErrCouponNotFound          = NewDomainError(KindNotFound, "COUPON_NOT_FOUND", "coupon not found")
+	ErrCouponExpired           = NewDomainError(KindInvalid, "COUPON_EXPIRED", "coupon has expired")
+	ErrCouponUsageLimitReached = NewDomainError(KindConflict, "COUPON_USAGE_LIMIT_REACHED", "coupon usage limit reached")
+	ErrCouponMinAmountNotMet   = NewDomainError(KindInvalid, "COUPON_MIN_AMOUNT_NOT_MET", "booking amount is below coupon minimum")
+)
+
+// Refund errors
+var (
+	ErrInvalidRefundData = NewDomainError(KindInvalid, "INVALID_REFUND_DATA", "invalid refund data provided")
+	ErrRefundNotFound    = NewDomainError(KindNotFound, "REFUND_NOT_FOUND", "refund not found")
+	ErrRefundFailed      = NewDomainError(KindUpstream, "REFUND_FAILED", "refund processing failed")
+)
+
+// Outbox errors
+var (
+	ErrInvalidOutboxMessage         = NewDomainError(KindInvalid, "INVALID_OUTBOX_MESSAGE", "invalid outbox message")
+	ErrOutboxMessageNotFound        = NewDomainError(KindNotFound, "OUTBOX_MESSAGE_NOT_FOUND", "outbox message not found")
+	ErrOutboxMessageNotDeadLettered = NewDomainError(KindConflict, "OUTBOX_MESSAGE_NOT_DEAD_LETTERED", "outbox message is not dead-lettered")
+)
+
+// Notification errors
+var (
+	ErrInvalidNotification         = NewDomainError(KindInvalid, "INVALID_NOTIFICATION", "invalid notification")
+	ErrNotificationNotFound        = NewDomainError(KindNotFound, "NOTIFICATION_NOT_FOUND", "notification not found")
+	ErrNotificationNotDeadLettered = NewDomainError(KindConflict, "NOTIFICATION_NOT_DEAD_LETTERED", "notification is not dead-lettered")
+	ErrNotificationUndeliverable   = NewDomainError(KindInvalid, "NOTIFICATION_UNDELIVERABLE", "notification can never be delivered")
+	ErrNotificationSkipped         = NewDomainError(KindInvalid, "NOTIFICATION_SKIPPED", "notification is not sent to this user on this channel")
+)
+
+// WhatsApp errors
+var (
+	ErrInvalidWhatsAppTemplate  = NewDomainError(KindInvalid, "INVALID_WHATSAPP_TEMPLATE", "invalid WhatsApp template")
+	ErrWhatsAppTemplateNotFound = NewDomainError(KindNotFound, "WHATSAPP_TEMPLATE_NOT_FOUND", "WhatsApp template not found")
+)
+
+// Webhook errors
+var (
+	ErrInvalidWebhook          = NewDomainError(KindInvalid, "INVALID_WEBHOOK", "invalid webhook")
+	ErrInvalidWebhookURL       = ErrInvalidWebhook.Refine("INVALID_WEBHOOK_URL", "callback URL must be an absolute http or https URL")
+	ErrWebhookNotFound         = NewDomainError(KindNotFound, "WEBHOOK_NOT_FOUND", "webhook not found")
+	ErrWebhookDeliveryNotFound = NewDomainError(KindNotFound, "WEBHOOK_DELIVERY_NOT_FOUND", "webhook delivery not found")
+)
+
+// Money errors
+var (
+	ErrCurrencyMismatch = NewDomainError(KindInvalid, "CURRENCY_MISMATCH", "currency mismatch")
+)
+
+// Pricing errors
+var (
+	ErrInvalidPricingDay  = NewDomainError(KindInvalid, "INVALID_PRICING_DAY", "invalid pricing calendar day")
+	ErrPricingDayNotFound = NewDomainError(KindNotFound, "PRICING_DAY_NOT_FOUND", "date is not on the pricing calendar")
+)
+
+// Add-on errors
+var (
+	ErrInvalidAddOn     = NewDomainError(KindInvalid, "INVALID_ADD_ON", "invalid add-on")
+	ErrAddOnUnavailable = NewDomainError(KindInvalid, "ADD_ON_UNAVAILABLE", "add-on is not offered for this show")
+)
+
+// Parking errors
+var (
+	ErrInvalidParkingCapacity     = NewDomainError(KindInvalid, "INVALID_PARKING_CAPACITY", "parking capacity cannot be negative")
+	ErrInvalidParkingReservation  = NewDomainError(KindInvalid, "INVALID_PARKING_RESERVATION", "invalid parking reservation data provided")
+	ErrNoParking                  = NewDomainError(KindInvalid, "NO_PARKING", "theatre has no parking")
+	ErrParkingFull                = NewDomainError(KindConflict, "PARKING_FULL", "not enough parking slots left for this show")
+	ErrParkingReservationNotFound = NewDomainError(KindNotFound, "PARKING_RESERVATION_NOT_FOUND", "parking reservation not found")
+	ErrParkingReservationNotHeld  = NewDomainError(KindConflict, "PARKING_RESERVATION_NOT_HELD", "parking reservation is no longer held")
+)
+
+// Reporting errors
+var (
+	ErrInvalidReportRange = NewDomainError(KindInvalid, "INVALID_REPORT_RANGE", "invalid report date range")
+)
+
+// Settlement errors
+var (
+	ErrInvalidSettlementData = NewDomainError(KindInvalid, "INVALID_SETTLEMENT_DATA", "invalid settlement data provided")
+	ErrSettlementNotFound    = NewDomainError(KindNotFound, "SETTLEMENT_NOT_FOUND", "settlement not found")
+	ErrSettlementOverlap     = NewDomainError(KindConflict, "SETTLEMENT_OVERLAP", "theatre already has a settlement covering part of this period")
+	ErrSettlementAlreadyPaid = NewDomainError(KindConflict, "SETTLEMENT_ALREADY_PAID", "settlement has already been paid")
+)
+
+// Transaction errors
+var (
+	ErrNoTransaction   = NewDomainError(KindInternal, "NO_TRANSACTION", "no transaction in progress")
+	ErrTransactionDone = NewDomainError(KindInternal, "TRANSACTION_DONE", "transaction has already been committed or rolled back")
+)
+
+// Audit errors
+var (
+	ErrInvalidAuditEntry  = NewDomainError(KindInvalid, "INVALID_AUDIT_ENTRY", "invalid audit entry")
+	ErrAuditEntryNotFound = NewDomainError(KindNotFound, "AUDIT_ENTRY_NOT_FOUND", "audit entry not found")
+)
+
+// State snapshot errors
+var (
+	ErrStateNotEmpty       = NewDomainError(KindConflict, "STATE_NOT_EMPTY", "state can only be imported into an empty app")
+	ErrUnsupportedSnapshot = NewDomainError(KindInvalid, "UNSUPPORTED_SNAPSHOT", "unsupported state snapshot version")
+)
+
+// Rate limit errors
+var (
+	ErrRateLimited = NewDomainError(KindRateLimited, "RATE_LIMITED", "too many requests")
 )
 
 // Service errors
 var (
-	ErrServiceUnavailable = errors.New("service temporarily unavailable")
-	ErrInternalError      = errors.New("internal server error")
-	ErrUnauthorized       = errors.New("unauthorized access")
-	ErrConcurrencyIssue   = errors.New("concurrency conflict occurred")
+	ErrServiceUnavailable = NewDomainError(KindUnavailable, "SERVICE_UNAVAILABLE", "service temporarily unavailable")
+	ErrShuttingDown       = NewDomainError(KindUnavailable, "SHUTTING_DOWN", "service is shutting down; try again shortly")
+	ErrInternalError      = NewDomainError(KindInternal, "INTERNAL_ERROR", "internal server error")
+	ErrUnauthorized       = NewDomainError(KindUnauthenticated, "UNAUTHORIZED", "unauthorized access")
+	ErrForbidden          = ErrUnauthorized.refineAs(KindForbidden, "FORBIDDEN", "operation not permitted for this role") // Known caller, wrong role or theatre
+	ErrConcurrencyIssue   = NewDomainError(KindAborted, "CONCURRENCY_ISSUE", "concurrency conflict occurred")
 )
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType is what a show is for - movies and live events share theatres, shows and bookings
+type EventType string
+
+const (
+	EventTypeMovie   EventType = "MOVIE"
+	EventTypeConcert EventType = "CONCERT"
+	EventTypePlay    EventType = "PLAY"
+	EventTypeStandup EventType = "STANDUP"
+)
+
+// IsLive reports whether the type is a live event rather than a movie
+func (t EventType) IsLive() bool {
+	switch t {
+	case EventTypeConcert, EventTypePlay, EventTypeStandup:
+		return true
+	}
+	return false
+}
+
+// Event represents a live event (concert, play, stand-up) sold alongside movies
+type Event struct {
+	ID          string        `json:"id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Type        EventType     `json:"type"`
+	Duration    time.Duration `json:"duration"`
+	Language    Language      `json:"language"`
+	Performers  []string      `json:"performers,omitempty"` // Artists, cast or comedians on the bill
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// NewEvent creates a new live event with validation
+func NewEvent(title, description string, eventType EventType, duration time.Duration, language Language, performers []string, now time.Time) (*Event, error) {
+	if title == "" || duration <= 0 || !eventType.IsLive() {
+		return nil, ErrInvalidEventData
+	}
+
+	return &Event{
+		ID:          uuid.New().String(),
+		Title:       title,
+		Description: description,
+		Type:        eventType,
+		Duration:    duration,
+		Language:    language,
+		Performers:  performers,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
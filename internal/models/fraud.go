@@ -0,0 +1,80 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxRiskScore caps a payment's risk score however many rules flag it
+const MaxRiskScore = 100
+
+// RiskDecision is what fraud checks decided about a payment
+type RiskDecision string
+
+const (
+	RiskDecisionAllow  RiskDecision = "ALLOW"
+	RiskDecisionStepUp RiskDecision = "STEP_UP" // Charged only once the payer verifies, e.g. with the card issuer's OTP
+	RiskDecisionReject RiskDecision = "REJECT"  // Never charged; its booking is cancelled
+)
+
+// FraudConfig sets the risk scores at which payments need step-up verification or are rejected
+type FraudConfig struct {
+	StepUpScore int // 0 never asks for verification
+	RejectScore int // 0 never rejects
+}
+
+// DefaultFraudConfig asks for verification from a score of 40 and rejects from 80
+func DefaultFraudConfig() FraudConfig {
+	return FraudConfig{StepUpScore: 40, RejectScore: 80}
+}
+
+// Decide maps a risk score to a decision
+func (c FraudConfig) Decide(score int) RiskDecision {
+	switch {
+	case c.RejectScore > 0 && score >= c.RejectScore:
+		return RiskDecisionReject
+	case c.StepUpScore > 0 && score >= c.StepUpScore:
+		return RiskDecisionStepUp
+	}
+	return RiskDecisionAllow
+}
+
+// RiskSignal is one fraud rule flagging a payment
+type RiskSignal struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Reason string `json:"reason"`
+}
+
+// RiskAssessment is the fraud checks' verdict on a payment before it was charged
+type RiskAssessment struct {
+	Score      int          `json:"score"` // Sum of the signals' scores, at most MaxRiskScore
+	Decision   RiskDecision `json:"decision"`
+	Signals    []RiskSignal `json:"signals,omitempty"`
+	AssessedAt time.Time    `json:"assessed_at"`
+}
+
+// NewRiskAssessment totals the signals and decides on them
+func NewRiskAssessment(signals []RiskSignal, config FraudConfig, now time.Time) *RiskAssessment {
+	score := 0
+	for _, signal := range signals {
+		score += signal.Score
+	}
+	score = min(score, MaxRiskScore)
+	return &RiskAssessment{Score: score, Decision: config.Decide(score), Signals: signals, AssessedAt: now}
+}
+
+// Reasons lists why the payment was flagged, in the order the rules ran
+func (a *RiskAssessment) Reasons() string {
+	reasons := make([]string, len(a.Signals))
+	for i, signal := range a.Signals {
+		reasons[i] = signal.Reason
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// AssessRisk records the fraud checks' verdict on the payment before it is charged
+func (p *Payment) AssessRisk(assessment *RiskAssessment, now time.Time) {
+	p.Risk = assessment
+	p.UpdatedAt = now
+}
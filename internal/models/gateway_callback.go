@@ -0,0 +1,40 @@
+package models
+
+import "fmt"
+
+// GatewayCallbackType is what a payment gateway's asynchronous callback reports
+type GatewayCallbackType string
+
+const (
+	GatewayCallbackPaymentCaptured GatewayCallbackType = "payment.captured"
+	GatewayCallbackPaymentFailed   GatewayCallbackType = "payment.failed"
+	GatewayCallbackRefundProcessed GatewayCallbackType = "refund.processed"
+)
+
+// GatewayCallback is a payment gateway's asynchronous notification, verified and read into a provider-neutral form
+type GatewayCallback struct {
+	ID            string              `json:"id"` // The gateway's event ID
+	Type          GatewayCallbackType `json:"type"`
+	TransactionID string              `json:"transaction_id,omitempty"` // The gateway's payment reference, saved on the payment once charged
+	PaymentID     string              `json:"payment_id,omitempty"`     // Our payment ID, sent with the charge as its idempotency key
+	RefundID      string              `json:"refund_id,omitempty"`      // The gateway's refund reference
+	Amount        Money               `json:"amount,omitzero"`          // How much a refund returned
+	Reason        string              `json:"reason,omitempty"`         // Why a payment failed
+}
+
+// Validate checks the callback names a payment, and for refunds the refund and its amount
+func (c *GatewayCallback) Validate() error {
+	if c.TransactionID == "" && c.PaymentID == "" {
+		return fmt.Errorf("%w: callback names no payment", ErrInvalidGatewayCallback)
+	}
+	switch c.Type {
+	case GatewayCallbackPaymentCaptured, GatewayCallbackPaymentFailed:
+		return nil
+	case GatewayCallbackRefundProcessed:
+		if c.RefundID == "" || !c.Amount.IsPositive() {
+			return fmt.Errorf("%w: refund callback needs the refund ID and a positive amount", ErrInvalidGatewayCallback)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unknown callback type %q", ErrInvalidGatewayCallback, c.Type)
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// HealthStatus is how usable a dependency, or the whole application, is right now
+type HealthStatus string
+
+const (
+	HealthUp       HealthStatus = "UP"
+	HealthDegraded HealthStatus = "DEGRADED" // Working, but part of it is failing fast or falling behind
+	HealthDown     HealthStatus = "DOWN"
+)
+
+// severity orders statuses from best to worst
+func (s HealthStatus) severity() int {
+	switch s {
+	case HealthUp:
+		return 0
+	case HealthDegraded:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// DependencyHealth is the result of probing one dependency
+type DependencyHealth struct {
+	Name      string       `json:"name"`
+	Status    HealthStatus `json:"status"`
+	Detail    string       `json:"detail,omitempty"` // e.g. which backend, or why it is down
+	LatencyMS float64      `json:"latency_ms"`       // How long the probe took
+}
+
+// HealthReport is every dependency's health and the worst of them
+type HealthReport struct {
+	Status    HealthStatus       `json:"status"`
+	Checks    []DependencyHealth `json:"checks"`
+	CheckedAt time.Time          `json:"checked_at"`
+}
+
+// NewHealthReport rolls checks up: the application is as healthy as its least healthy dependency
+func NewHealthReport(checks []DependencyHealth, checkedAt time.Time) *HealthReport {
+	status := HealthUp
+	for _, check := range checks {
+		if check.Status.severity() > status.severity() {
+			status = check.Status
+		}
+	}
+	return &HealthReport{Status: status, Checks: checks, CheckedAt: checkedAt}
+}
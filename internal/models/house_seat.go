@@ -0,0 +1,96 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// HouseSeat is a seat a theatre holds back from sale for one show, and who did so and why
+type HouseSeat struct {
+	SeatID    string     `json:"seat_id"`
+	Status    SeatStatus `json:"status"` // SeatStatusHouse or SeatStatusBlockedAdmin
+	Reason    string     `json:"reason"`
+	BlockedBy string     `json:"blocked_by"`
+	BlockedAt time.Time  `json:"blocked_at"`
+}
+
+// WithholdSeats takes seats off sale for this show only, e.g. a broken seat or seats kept for press.
+// Seats already held back are rejected, so an earlier reason is never overwritten. Callers check that
+// no hold or booking of the show has the seats.
+func (s *Show) WithholdSeats(seatIDs []string, status SeatStatus, reason, adminID string, now time.Time) ([]*HouseSeat, error) {
+	reason = strings.TrimSpace(reason)
+	if len(seatIDs) == 0 || !status.IsWithheld() || reason == "" {
+		return nil, ErrInvalidHouseSeats
+	}
+
+	// Copy on write - seat maps and holds read the map without a lock
+	houseSeats := make(map[string]*HouseSeat, len(s.HouseSeats)+len(seatIDs))
+	maps.Copy(houseSeats, s.HouseSeats)
+
+	withheld := make([]*HouseSeat, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		if _, taken := houseSeats[seatID]; taken {
+			return nil, fmt.Errorf("%w: %s", ErrSeatWithheld, seatID)
+		}
+		seat := &HouseSeat{SeatID: seatID, Status: status, Reason: reason, BlockedBy: adminID, BlockedAt: now}
+		houseSeats[seatID] = seat
+		withheld = append(withheld, seat)
+	}
+
+	s.HouseSeats = houseSeats
+	s.UpdatedAt = now
+	return withheld, nil
+}
+
+// ReleaseHouseSeats puts held back seats back on sale; no seat IDs releases all of them
+func (s *Show) ReleaseHouseSeats(seatIDs []string, now time.Time) ([]*HouseSeat, error) {
+	if len(seatIDs) == 0 {
+		seatIDs = make([]string, 0, len(s.HouseSeats))
+		for seatID := range s.HouseSeats {
+			seatIDs = append(seatIDs, seatID)
+		}
+		slices.Sort(seatIDs)
+		if len(seatIDs) == 0 {
+			return nil, ErrSeatNotWithheld
+		}
+	}
+
+	houseSeats := maps.Clone(s.HouseSeats)
+	released := make([]*HouseSeat, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		seat, ok := houseSeats[seatID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrSeatNotWithheld, seatID)
+		}
+		delete(houseSeats, seatID)
+		released = append(released, seat)
+	}
+
+	s.HouseSeats = houseSeats
+	s.UpdatedAt = now
+	return released, nil
+}
+
+// CheckSeatsOnSale rejects any seat the theatre holds back for this show
+func (s *Show) CheckSeatsOnSale(seatIDs []string) error {
+	houseSeats := s.HouseSeats
+	for _, seatID := range seatIDs {
+		if _, withheld := houseSeats[seatID]; withheld {
+			return fmt.Errorf("%w: %s", ErrSeatWithheld, seatID)
+		}
+	}
+	return nil
+}
+
+// HouseSeatStatuses maps each held back seat to its status, for overlaying on a seat map
+func (s *Show) HouseSeatStatuses() map[string]SeatStatus {
+	houseSeats := s.HouseSeats
+	statuses := make(map[string]SeatStatus, len(houseSeats))
+	for seatID, seat := range houseSeats {
+		statuses[seatID] = seat.Status
+	}
+	return statuses
+}
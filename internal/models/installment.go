@@ -0,0 +1,71 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Installment is one repayment the user owes the lender
+type Installment struct {
+	Number  int       `json:"number"` // 1-based
+	DueDate time.Time `json:"due_date"`
+	Amount  Money     `json:"amount"`
+}
+
+// InstallmentPlan is how an EMI or pay-later payment is repaid; the theatre is paid the principal up front
+type InstallmentPlan struct {
+	TenureMonths       int           `json:"tenure_months"`        // Zero for a single pay-later due date
+	AnnualInterestRate float64       `json:"annual_interest_rate"` // Percent; zero for no-cost EMI and pay later
+	Principal          Money         `json:"principal"`
+	Interest           Money         `json:"interest"`
+	TotalPayable       Money         `json:"total_payable"`
+	Installments       []Installment `json:"installments"`
+}
+
+// NewEMIPlan amortizes principal over tenureMonths at a reducing-balance annual rate, the first EMI due a month after start.
+// Every EMI is rounded to the minor unit and the last one absorbs the rounding, so the installments add up to TotalPayable.
+func NewEMIPlan(principal Money, tenureMonths int, annualRatePercent float64, start time.Time) (*InstallmentPlan, error) {
+	if !principal.IsPositive() || tenureMonths <= 0 || annualRatePercent < 0 || math.IsNaN(annualRatePercent) {
+		return nil, ErrInvalidPaymentData
+	}
+
+	monthlyRate := annualRatePercent / 12 / 100
+	emi := float64(principal.Minor) / float64(tenureMonths)
+	if monthlyRate > 0 {
+		growth := math.Pow(1+monthlyRate, float64(tenureMonths))
+		emi = float64(principal.Minor) * monthlyRate * growth / (growth - 1)
+	}
+	total := NewMoney(int64(math.Round(emi*float64(tenureMonths))), principal.Currency)
+	installment := NewMoney(int64(math.Round(emi)), principal.Currency)
+
+	plan := &InstallmentPlan{
+		TenureMonths:       tenureMonths,
+		AnnualInterestRate: annualRatePercent,
+		Principal:          principal,
+		Interest:           total.Sub(principal),
+		TotalPayable:       total,
+		Installments:       make([]Installment, 0, tenureMonths),
+	}
+	for i := 1; i <= tenureMonths; i++ {
+		amount := installment
+		if i == tenureMonths {
+			amount = total.Sub(installment.Times(tenureMonths - 1))
+		}
+		plan.Installments = append(plan.Installments, Installment{Number: i, DueDate: start.AddDate(0, i, 0), Amount: amount})
+	}
+	return plan, nil
+}
+
+// NewPayLaterPlan defers the whole principal, interest free, to a single due date
+func NewPayLaterPlan(principal Money, dueDate time.Time) (*InstallmentPlan, error) {
+	if !principal.IsPositive() || dueDate.IsZero() {
+		return nil, ErrInvalidPaymentData
+	}
+
+	return &InstallmentPlan{
+		Principal:    principal,
+		Interest:     ZeroMoney(principal.Currency),
+		TotalPayable: principal,
+		Installments: []Installment{{Number: 1, DueDate: dueDate, Amount: principal}},
+	}, nil
+}
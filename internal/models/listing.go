@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// ListingsConfig shapes the trending and now-showing listings
+type ListingsConfig struct {
+	TrendingWindow  time.Duration // Confirmed bookings made this recently count towards trending
+	TrendingLimit   int           // Movies in each city's trending list
+	RefreshInterval time.Duration // How often the cached listings are rebuilt, and so how stale they may be
+	Days            int           // Days from today the cache covers; now-showing for later dates is computed per call
+}
+
+// DefaultListingsConfig ranks the top 10 movies by the last 24 hours of bookings and caches a week of
+// now-showing listings, rebuilt every minute
+func DefaultListingsConfig() ListingsConfig {
+	return ListingsConfig{
+		TrendingWindow:  24 * time.Hour,
+		TrendingLimit:   10,
+		RefreshInterval: time.Minute,
+		Days:            7,
+	}
+}
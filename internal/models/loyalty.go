@@ -0,0 +1,136 @@
+package models
+
+import (
+	"maps"
+	"math"
+	"sync"
+	"time"
+)
+
+// LoyaltyConfig sets how loyalty points are earned and what they are worth; the zero value turns loyalty off
+type LoyaltyConfig struct {
+	PointsPerUnit float64 `json:"points_per_unit"` // Points earned per major currency unit paid
+	PointValue    int64   `json:"point_value"`     // Minor units one point pays for
+}
+
+// DefaultLoyaltyConfig earns 1 point per 10 spent, each worth 0.25
+func DefaultLoyaltyConfig() LoyaltyConfig {
+	return LoyaltyConfig{PointsPerUnit: 0.1, PointValue: 25}
+}
+
+// PointsFor returns the whole points earned by paying amount
+func (c LoyaltyConfig) PointsFor(paid Money) int64 {
+	if !paid.IsPositive() || c.PointsPerUnit <= 0 {
+		return 0
+	}
+	return int64(math.Floor(paid.Major() * c.PointsPerUnit))
+}
+
+// ValueOf returns what the given points pay for
+func (c LoyaltyConfig) ValueOf(points int64, currency string) Money {
+	return NewMoney(points*c.PointValue, currency)
+}
+
+// LoyaltyAccount is a user's loyalty points balance.
+// It remembers per booking what was earned and spent so cancellations can reverse both.
+type LoyaltyAccount struct {
+	UserID         string    `json:"user_id"`
+	Points         int64     `json:"points"`
+	LifetimePoints int64     `json:"lifetime_points"` // Everything ever earned, for tiering
+	UpdatedAt      time.Time `json:"updated_at"`
+	earned         map[string]int64
+	redeemed       map[string]int64
+	mutex          sync.RWMutex
+}
+
+// NewLoyaltyAccount creates an account with no points
+func NewLoyaltyAccount(userID string, now time.Time) (*LoyaltyAccount, error) {
+	if userID == "" {
+		return nil, ErrInvalidLoyaltyData
+	}
+
+	return &LoyaltyAccount{
+		UserID:    userID,
+		UpdatedAt: now,
+		earned:    make(map[string]int64),
+		redeemed:  make(map[string]int64),
+	}, nil
+}
+
+// Earn credits points for a booking; it reports false if the booking already earned
+func (a *LoyaltyAccount) Earn(bookingID string, points int64, now time.Time) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if _, done := a.earned[bookingID]; done || points <= 0 {
+		return false
+	}
+
+	a.earned[bookingID] = points
+	a.Points += points
+	a.LifetimePoints += points
+	a.UpdatedAt = now
+	return true
+}
+
+// Redeem spends points towards a booking; each booking can redeem once
+func (a *LoyaltyAccount) Redeem(bookingID string, points int64, now time.Time) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if points <= 0 || a.redeemed[bookingID] > 0 {
+		return ErrInvalidLoyaltyData
+	}
+	if points > a.Points {
+		return ErrInsufficientLoyaltyPoints
+	}
+
+	a.redeemed[bookingID] = points
+	a.Points -= points
+	a.UpdatedAt = now
+	return nil
+}
+
+// Reverse undoes a booking: spent points come back and earned points are taken away (never below zero).
+// It returns the net change to the balance.
+func (a *LoyaltyAccount) Reverse(bookingID string, now time.Time) int64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	restored := a.redeemed[bookingID]
+	revoked := min(a.earned[bookingID], a.Points+restored)
+	delete(a.redeemed, bookingID)
+	delete(a.earned, bookingID)
+
+	a.Points += restored - revoked
+	a.LifetimePoints -= revoked
+	a.UpdatedAt = now
+	return restored - revoked
+}
+
+// GetPoints returns the spendable balance
+func (a *LoyaltyAccount) GetPoints() int64 {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	return a.Points
+}
+
+// Ledger returns copies of the points earned and redeemed per booking - for persisting the account
+func (a *LoyaltyAccount) Ledger() (earned, redeemed map[string]int64) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	return maps.Clone(a.earned), maps.Clone(a.redeemed)
+}
+
+// RestoreLedger replaces the per-booking ledger, e.g. when loading a saved account
+func (a *LoyaltyAccount) RestoreLedger(earned, redeemed map[string]int64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.earned = make(map[string]int64, len(earned))
+	a.redeemed = make(map[string]int64, len(redeemed))
+	maps.Copy(a.earned, earned)
+	maps.Copy(a.redeemed, redeemed)
+}
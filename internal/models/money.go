@@ -0,0 +1,135 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DefaultCurrency is used wherever a price is created without an explicit currency
+const DefaultCurrency = "USD"
+
+// minorUnitsPerMajor is the number of minor units (e.g. cents) in one major unit
+const minorUnitsPerMajor = 100
+
+// Money is an amount in integer minor units plus an ISO 4217 currency code.
+// Amounts are never float64 so totals, discounts and refunds add up exactly.
+type Money struct {
+	Minor    int64  `json:"minor_units"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney creates an amount from minor units
+func NewMoney(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: normalizeCurrency(currency)}
+}
+
+// MoneyFromMajor converts a decimal major-unit amount (e.g. 149.99) rounding half away from zero.
+// Meant for parsing external input only - arithmetic should stay on Money.
+func MoneyFromMajor(major float64, currency string) Money {
+	return NewMoney(int64(math.Round(major*minorUnitsPerMajor)), currency)
+}
+
+// ZeroMoney returns a zero amount in the given currency
+func ZeroMoney(currency string) Money {
+	return NewMoney(0, currency)
+}
+
+// Add returns m + other; both amounts must share a currency
+func (m Money) Add(other Money) Money {
+	m.mustMatch(other)
+	return Money{Minor: m.Minor + other.Minor, Currency: m.Currency}
+}
+
+// Sub returns m - other; both amounts must share a currency
+func (m Money) Sub(other Money) Money {
+	m.mustMatch(other)
+	return Money{Minor: m.Minor - other.Minor, Currency: m.Currency}
+}
+
+// Mul scales the amount by a factor, rounding half away from zero
+func (m Money) Mul(factor float64) Money {
+	return Money{Minor: int64(math.Round(float64(m.Minor) * factor)), Currency: m.Currency}
+}
+
+// Percent returns the given percentage of the amount, rounding half away from zero
+func (m Money) Percent(percent float64) Money {
+	return m.Mul(percent / 100)
+}
+
+// Times multiplies the amount by an integer quantity
+func (m Money) Times(quantity int) Money {
+	return Money{Minor: m.Minor * int64(quantity), Currency: m.Currency}
+}
+
+// SameCurrency reports whether both amounts can be combined
+func (m Money) SameCurrency(other Money) bool {
+	return m.Currency == other.Currency
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Minor == 0
+}
+
+// IsPositive reports whether the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.Minor > 0
+}
+
+// IsNegative reports whether the amount is below zero
+func (m Money) IsNegative() bool {
+	return m.Minor < 0
+}
+
+// LessThan compares two amounts of the same currency
+func (m Money) LessThan(other Money) bool {
+	m.mustMatch(other)
+	return m.Minor < other.Minor
+}
+
+// GreaterThan compares two amounts of the same currency
+func (m Money) GreaterThan(other Money) bool {
+	m.mustMatch(other)
+	return m.Minor > other.Minor
+}
+
+// Min returns the smaller of two amounts of the same currency
+func (m Money) Min(other Money) Money {
+	if other.LessThan(m) {
+		return other
+	}
+	return m
+}
+
+// Major returns the amount in major units - for display and external gateways only
+func (m Money) Major() float64 {
+	return float64(m.Minor) / minorUnitsPerMajor
+}
+
+// String formats the amount as "USD 149.99"
+func (m Money) String() string {
+	sign := ""
+	minor := m.Minor
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s %s%d.%02d", m.Currency, sign, minor/minorUnitsPerMajor, minor%minorUnitsPerMajor)
+}
+
+// mustMatch guards against silently mixing currencies - that's a programming error, not user input
+func (m Money) mustMatch(other Money) {
+	if !m.SameCurrency(other) {
+		panic(fmt.Sprintf("money: currency mismatch %q vs %q", m.Currency, other.Currency))
+	}
+}
+
+// normalizeCurrency upper-cases codes and falls back to the default currency
+func normalizeCurrency(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return DefaultCurrency
+	}
+	return currency
+}
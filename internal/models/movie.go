@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +19,14 @@ const (
 	GenreRomance  Genre = "ROMANCE"
 	GenreSciFi    Genre = "SCI_FI"
 	GenreThriller Genre = "THRILLER"
+
+	GenreAdventure   Genre = "ADVENTURE"
+	GenreAnimation   Genre = "ANIMATION"
+	GenreCrime       Genre = "CRIME"
+	GenreDocumentary Genre = "DOCUMENTARY"
+	GenreFamily      Genre = "FAMILY"
+	GenreFantasy     Genre = "FANTASY"
+	GenreMystery     Genre = "MYSTERY"
 )
 
 // Language represents movie languages
@@ -29,22 +39,76 @@ const (
 	LanguageTelugu  Language = "TELUGU"
 )
 
+// Certificate is a movie's censor board rating
+type Certificate string
+
+const (
+	CertificateU  Certificate = "U"  // Universal
+	CertificateUA Certificate = "UA" // Universal, parental guidance for under-12s
+	CertificateA  Certificate = "A"  // Adults only
+)
+
+// MinimumAge is how old a viewer must be to book the certificate; zero means anyone
+func (c Certificate) MinimumAge() int {
+	if c == CertificateA {
+		return 18
+	}
+	return 0
+}
+
+// AgeRestrictionError is how a booking is refused for a viewer too young for the certificate;
+// errors.Is matches ErrAgeRestricted
+type AgeRestrictionError struct {
+	Certificate Certificate
+	MinimumAge  int
+	Age         int  // The viewer's age on the show's day; meaningless unless AgeKnown
+	AgeKnown    bool // False when the account has no date of birth
+}
+
+func (e *AgeRestrictionError) Error() string {
+	if !e.AgeKnown {
+		return fmt.Sprintf("%s: %s movies need a date of birth on the account", ErrAgeRestricted, e.Certificate)
+	}
+	return fmt.Sprintf("%s: %s movies are for %d and over", ErrAgeRestricted, e.Certificate, e.MinimumAge)
+}
+
+func (e *AgeRestrictionError) Unwrap() error {
+	return ErrAgeRestricted.WithDetails(map[string]any{"certificate": e.Certificate, "minimum_age": e.MinimumAge})
+}
+
+// Valid reports whether the certificate is known; an empty certificate means unrated
+func (c Certificate) Valid() bool {
+	switch c {
+	case "", CertificateU, CertificateUA, CertificateA:
+		return true
+	default:
+		return false
+	}
+}
+
 // Movie represents a movie in the system
 type Movie struct {
 	ID          string        `json:"id"`
 	Title       string        `json:"title"`
 	Description string        `json:"description"`
 	Duration    time.Duration `json:"duration"`
-	Genre       Genre         `json:"genre"`
+	Genre       Genre         `json:"genre"`            // Primary genre
+	Genres      []Genre       `json:"genres,omitempty"` // Every genre, primary first; set by catalog imports
 	Language    Language      `json:"language"`
-	Rating      float32       `json:"rating"`
+	Certificate Certificate   `json:"certificate,omitempty"`
+	Rating      float32       `json:"rating"`      // Out of 10; aggregate of approved reviews once there are any
+	BaseRating  float32       `json:"base_rating"` // Editorial rating used until the movie has reviews
+	ReviewCount int           `json:"review_count"`
 	ReleaseDate time.Time     `json:"release_date"`
+	PosterURL   string        `json:"poster_url,omitempty"`
+	ExternalID  string        `json:"external_id,omitempty"` // Catalog source key such as tmdb:27205 - re-imports update instead of duplicating
+	SaleWindow  SaleWindow    `json:"sale_window,omitzero"`  // Given to new shows instead of the theatre's
 	CreatedAt   time.Time     `json:"created_at"`
 	UpdatedAt   time.Time     `json:"updated_at"`
 }
 
 // NewMovie creates a new movie with validation
-func NewMovie(title, description string, duration time.Duration, genre Genre, language Language, rating float32, releaseDate time.Time) (*Movie, error) {
+func NewMovie(title, description string, duration time.Duration, genre Genre, language Language, rating float32, releaseDate, now time.Time) (*Movie, error) {
 	if title == "" || duration <= 0 || rating < 0 || rating > 10 {
 		return nil, ErrInvalidMovieData
 	}
@@ -57,26 +121,68 @@ func NewMovie(title, description string, duration time.Duration, genre Genre, la
 		Genre:       genre,
 		Language:    language,
 		Rating:      rating,
+		BaseRating:  rating,
 		ReleaseDate: releaseDate,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
 	}, nil
 }
 
 // UpdateMovie updates movie information
-func (m *Movie) UpdateMovie(title, description string, rating float32) error {
+func (m *Movie) UpdateMovie(title, description string, rating float32, now time.Time) error {
 	if title == "" || rating < 0 || rating > 10 {
 		return ErrInvalidMovieData
 	}
 
 	m.Title = title
 	m.Description = description
-	m.Rating = rating
-	m.UpdatedAt = time.Now()
+	m.BaseRating = rating
+	if m.ReviewCount == 0 {
+		m.Rating = rating
+	}
+	m.UpdatedAt = now
 	return nil
 }
 
+// ApplyCatalog refreshes the listing from a re-imported copy of the movie, keeping its ID and review aggregate
+func (m *Movie) ApplyCatalog(imported *Movie, now time.Time) {
+	m.Title = imported.Title
+	m.Description = imported.Description
+	m.Duration = imported.Duration
+	m.Genre = imported.Genre
+	m.Genres = imported.Genres
+	m.Language = imported.Language
+	m.Certificate = imported.Certificate
+	m.PosterURL = imported.PosterURL
+	m.ReleaseDate = imported.ReleaseDate
+	m.BaseRating = imported.BaseRating
+	if m.ReviewCount == 0 {
+		m.Rating = imported.BaseRating
+	}
+	m.UpdatedAt = now
+}
+
+// ApplyReviewAggregate sets the rating from approved reviews' average stars (1-5, scaled to 10).
+// With no reviews the editorial base rating applies again.
+func (m *Movie) ApplyReviewAggregate(averageStars float64, count int, now time.Time) {
+	m.ReviewCount = count
+	if count == 0 {
+		m.Rating = m.BaseRating
+	} else {
+		m.Rating = float32(math.Round(averageStars*2*10) / 10)
+	}
+	m.UpdatedAt = now
+}
+
+// AllGenres returns every genre of the movie, primary first
+func (m *Movie) AllGenres() []Genre {
+	if len(m.Genres) > 0 {
+		return m.Genres
+	}
+	return []Genre{m.Genre}
+}
+
 // IsReleased checks if the movie has been released
-func (m *Movie) IsReleased() bool {
-	return time.Now().After(m.ReleaseDate)
+func (m *Movie) IsReleased(now time.Time) bool {
+	return now.After(m.ReleaseDate)
 }
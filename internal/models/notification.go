@@ -0,0 +1,190 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationChannel is a way of reaching a user
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail    NotificationChannel = "EMAIL"
+	NotificationChannelSMS      NotificationChannel = "SMS"
+	NotificationChannelPush     NotificationChannel = "PUSH"
+	NotificationChannelWhatsApp NotificationChannel = "WHATSAPP" // Only to users who opted in, and only for kinds with a template
+)
+
+// ParseNotificationChannels reads a comma-separated list such as "EMAIL,SMS", ignoring case, blanks and repeats.
+// It reports false when a name isn't a channel.
+func ParseNotificationChannels(list string) ([]NotificationChannel, bool) {
+	var channels []NotificationChannel
+	for _, name := range strings.Split(list, ",") {
+		channel := NotificationChannel(strings.ToUpper(strings.TrimSpace(name)))
+		if channel == "" {
+			continue
+		}
+		if !channel.IsValid() {
+			return nil, false
+		}
+		if !slices.Contains(channels, channel) {
+			channels = append(channels, channel)
+		}
+	}
+	return channels, true
+}
+
+// IsValid reports whether the channel is one notifications can be sent on
+func (c NotificationChannel) IsValid() bool {
+	switch c {
+	case NotificationChannelEmail, NotificationChannelSMS, NotificationChannelPush, NotificationChannelWhatsApp:
+		return true
+	}
+	return false
+}
+
+// NotificationKind is what a notification tells the user about
+type NotificationKind string
+
+const (
+	NotificationBookingConfirmed  NotificationKind = "BOOKING_CONFIRMED"
+	NotificationRefundProcessed   NotificationKind = "REFUND_PROCESSED"
+	NotificationPaymentFailed     NotificationKind = "PAYMENT_FAILED"
+	NotificationShowCancelled     NotificationKind = "SHOW_CANCELLED"
+	NotificationShowRescheduled   NotificationKind = "SHOW_RESCHEDULED"
+	NotificationShowReminder      NotificationKind = "SHOW_REMINDER"
+	NotificationWatchlistReminder NotificationKind = "WATCHLIST_REMINDER"
+	NotificationTransferOffered   NotificationKind = "TRANSFER_OFFERED"
+	NotificationTransferred       NotificationKind = "BOOKING_TRANSFERRED"
+	NotificationTransferDeclined  NotificationKind = "TRANSFER_DECLINED"
+	NotificationBookingResold     NotificationKind = "BOOKING_RESOLD"
+)
+
+// NotificationStatus is where a notification stands in delivery
+type NotificationStatus string
+
+const (
+	NotificationStatusPending      NotificationStatus = "PENDING"       // Waiting for its first or next delivery attempt
+	NotificationStatusSent         NotificationStatus = "SENT"          // The channel took it
+	NotificationStatusDeadLettered NotificationStatus = "DEAD_LETTERED" // Gave up; needs a manual replay
+	NotificationStatusSkipped      NotificationStatus = "SKIPPED"       // Not for this user on this channel, e.g. they never opted in
+)
+
+// NotificationConfig controls which channels notifications go out on and how failed deliveries are retried
+type NotificationConfig struct {
+	Channels     []NotificationChannel // Every notification is sent on each of these
+	MaxAttempts  int                   // Deliveries tried before a notification is dead-lettered
+	RetryBackoff time.Duration         // Wait after the first failure, doubled after each one
+	MaxBackoff   time.Duration         // Cap on the wait between attempts
+	BatchSize    int                   // Notifications the dispatcher delivers per run
+}
+
+// DefaultNotificationConfig sends by email and retries five times over roughly half a minute
+func DefaultNotificationConfig() NotificationConfig {
+	return NotificationConfig{
+		Channels:     []NotificationChannel{NotificationChannelEmail},
+		MaxAttempts:  5,
+		RetryBackoff: 2 * time.Second,
+		MaxBackoff:   5 * time.Minute,
+		BatchSize:    100,
+	}
+}
+
+// Backoff returns how long to wait after the given number of failed attempts
+func (c NotificationConfig) Backoff(attempts int) time.Duration {
+	return exponentialBackoff(c.RetryBackoff, c.MaxBackoff, attempts)
+}
+
+// NotificationAttachment is a file sent along with a notification, such as a printable ticket
+type NotificationAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+}
+
+// Notification is one message to one user on one channel, queued until the channel takes it. It keeps what
+// the message says rather than how a channel renders it, so it can be replayed later.
+type Notification struct {
+	ID            string                   `json:"id"`
+	Kind          NotificationKind         `json:"kind"`
+	Channel       NotificationChannel      `json:"channel"`
+	UserID        string                   `json:"user_id"`
+	Subject       string                   `json:"subject"`
+	Fields        map[string]string        `json:"fields,omitempty"` // e.g. reference and booking_id
+	Attachments   []NotificationAttachment `json:"attachments,omitempty"`
+	Status        NotificationStatus       `json:"status"`
+	Attempts      int                      `json:"attempts"`
+	LastError     string                   `json:"last_error,omitempty"`
+	NextAttemptAt time.Time                `json:"next_attempt_at"`
+	SentAt        *time.Time               `json:"sent_at,omitempty"`
+	CreatedAt     time.Time                `json:"created_at"`
+}
+
+// NewNotification creates a pending notification that is due immediately
+func NewNotification(kind NotificationKind, channel NotificationChannel, userID, subject string, fields map[string]string, attachments []NotificationAttachment, now time.Time) (*Notification, error) {
+	if kind == "" || channel == "" || userID == "" || subject == "" {
+		return nil, ErrInvalidNotification
+	}
+
+	return &Notification{
+		ID:            uuid.New().String(),
+		Kind:          kind,
+		Channel:       channel,
+		UserID:        userID,
+		Subject:       subject,
+		Fields:        fields,
+		Attachments:   attachments,
+		Status:        NotificationStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// IsDue reports whether a pending notification should be delivered now
+func (n *Notification) IsDue(now time.Time) bool {
+	return n.Status == NotificationStatusPending && !now.Before(n.NextAttemptAt)
+}
+
+// MarkSent records a successful delivery
+func (n *Notification) MarkSent(now time.Time) {
+	n.Attempts++
+	n.Status = NotificationStatusSent
+	n.LastError = ""
+	n.SentAt = &now
+}
+
+// MarkFailed records a failed delivery, scheduling a retry or dead-lettering the notification once
+// maxAttempts is reached; it reports whether the notification was dead-lettered
+func (n *Notification) MarkFailed(reason string, retryAt time.Time, maxAttempts int) bool {
+	n.Attempts++
+	n.LastError = reason
+	if n.Attempts >= maxAttempts {
+		n.Status = NotificationStatusDeadLettered
+		return true
+	}
+
+	n.NextAttemptAt = retryAt
+	return false
+}
+
+// MarkSkipped records that the channel doesn't send this notification to its user, which is not a failure
+func (n *Notification) MarkSkipped(reason string) {
+	n.Attempts++
+	n.Status = NotificationStatusSkipped
+	n.LastError = reason
+}
+
+// Requeue gives a dead-lettered notification a fresh set of attempts
+func (n *Notification) Requeue(now time.Time) error {
+	if n.Status != NotificationStatusDeadLettered {
+		return ErrNotificationNotDeadLettered
+	}
+
+	n.Status = NotificationStatusPending
+	n.Attempts = 0
+	n.NextAttemptAt = now
+	return nil
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// MaxOTPAttempts is how many wrong OTPs fail a challenged payment
+const MaxOTPAttempts = 3
+
+// OTPChallenge is a 3-D Secure style step-up: the card issuer sent the cardholder an OTP, and the charge only
+// goes through once it is entered. The token identifies the challenge to the gateway; the OTP is never stored.
+type OTPChallenge struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Attempts  int       `json:"attempts,omitempty"` // Wrong OTPs entered so far
+}
+
+// IsExpired checks if the OTP can no longer be entered
+func (c *OTPChallenge) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// RequireAction records that the issuer challenged the charge; it completes or fails with the challenge
+func (p *Payment) RequireAction(challenge *OTPChallenge, now time.Time) {
+	p.Status = PaymentStatusRequiresAction
+	p.Challenge = challenge
+	p.UpdatedAt = now
+}
+
+// RecordWrongOTP counts a wrong OTP and reports whether the attempts are used up
+func (p *Payment) RecordWrongOTP(now time.Time) bool {
+	p.Challenge.Attempts++
+	p.UpdatedAt = now
+	return p.Challenge.Attempts >= MaxOTPAttempts
+}
+
+// IsAwaitingAction checks if the payment is waiting for the cardholder's OTP
+func (p *Payment) IsAwaitingAction() bool {
+	return p.Status == PaymentStatusRequiresAction
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxStatus is where an outbox message stands in delivery
+type OutboxStatus string
+
+const (
+	OutboxStatusPending      OutboxStatus = "PENDING"       // Waiting for its first or next delivery attempt
+	OutboxStatusDelivered    OutboxStatus = "DELIVERED"     // Every subscriber handled it
+	OutboxStatusDeadLettered OutboxStatus = "DEAD_LETTERED" // Gave up after MaxAttempts; needs a manual redelivery
+)
+
+// OutboxConfig controls how often failed deliveries are retried before they are dead-lettered
+type OutboxConfig struct {
+	MaxAttempts  int           // Deliveries tried before a message is dead-lettered
+	RetryBackoff time.Duration // Wait after the first failure, doubled after each one
+	MaxBackoff   time.Duration // Cap on the wait between attempts
+	BatchSize    int           // Messages the dispatcher delivers per run
+}
+
+// DefaultOutboxConfig retries five times over roughly half a minute
+func DefaultOutboxConfig() OutboxConfig {
+	return OutboxConfig{
+		MaxAttempts:  5,
+		RetryBackoff: 2 * time.Second,
+		MaxBackoff:   5 * time.Minute,
+		BatchSize:    100,
+	}
+}
+
+// Backoff returns how long to wait after the given number of failed attempts
+func (c OutboxConfig) Backoff(attempts int) time.Duration {
+	return exponentialBackoff(c.RetryBackoff, c.MaxBackoff, attempts)
+}
+
+// exponentialBackoff doubles base after every failed attempt past the first, capped at limit when it is set
+func exponentialBackoff(base, limit time.Duration, attempts int) time.Duration {
+	if attempts < 1 || base <= 0 {
+		return base
+	}
+
+	wait := base
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if limit > 0 && wait >= limit {
+			return limit
+		}
+	}
+	return wait
+}
+
+// OutboxMessage is a domain event recorded alongside the change that raised it - demonstrates Outbox Pattern.
+// The payload is the event's JSON, so messages can be replayed without the original Go value.
+type OutboxMessage struct {
+	ID            string          `json:"id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        OutboxStatus    `json:"status"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error,omitempty"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// NewOutboxMessage creates a pending message that is due immediately
+func NewOutboxMessage(eventType string, payload []byte, now time.Time) (*OutboxMessage, error) {
+	if eventType == "" || len(payload) == 0 {
+		return nil, ErrInvalidOutboxMessage
+	}
+
+	return &OutboxMessage{
+		ID:            uuid.New().String(),
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// IsDue reports whether a pending message should be delivered now
+func (m *OutboxMessage) IsDue(now time.Time) bool {
+	return m.Status == OutboxStatusPending && !now.Before(m.NextAttemptAt)
+}
+
+// MarkDelivered records a successful delivery
+func (m *OutboxMessage) MarkDelivered(now time.Time) {
+	m.Attempts++
+	m.Status = OutboxStatusDelivered
+	m.LastError = ""
+	m.DeliveredAt = &now
+}
+
+// MarkFailed records a failed delivery, scheduling a retry or dead-lettering the message once
+// maxAttempts is reached; it reports whether the message was dead-lettered
+func (m *OutboxMessage) MarkFailed(reason string, retryAt time.Time, maxAttempts int) bool {
+	m.Attempts++
+	m.LastError = reason
+	if m.Attempts >= maxAttempts {
+		m.Status = OutboxStatusDeadLettered
+		return true
+	}
+
+	m.NextAttemptAt = retryAt
+	return false
+}
+
+// Requeue gives a dead-lettered message a fresh set of attempts
+func (m *OutboxMessage) Requeue(now time.Time) error {
+	if m.Status != OutboxStatusDeadLettered {
+		return ErrOutboxMessageNotDeadLettered
+	}
+
+	m.Status = OutboxStatusPending
+	m.Attempts = 0
+	m.NextAttemptAt = now
+	return nil
+}
@@ -0,0 +1,155 @@
+package models
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ParkingReservationStatus follows the booking a reservation was made with
+type ParkingReservationStatus string
+
+const (
+	ParkingReservationHeld      ParkingReservationStatus = "HELD"      // Until the booking is paid or its payment window closes
+	ParkingReservationConfirmed ParkingReservationStatus = "CONFIRMED" // The booking was paid
+	ParkingReservationCancelled ParkingReservationStatus = "CANCELLED" // The booking was cancelled, or never made it
+	ParkingReservationExpired   ParkingReservationStatus = "EXPIRED"   // The booking wasn't paid in time
+)
+
+// ParkingSlotCode names the nth slot of a theatre's car park, counting from 1, e.g. P007
+func ParkingSlotCode(n int) string {
+	return fmt.Sprintf("P%03d", n)
+}
+
+// ParkingReservation holds slots in a theatre's car park for the length of a show, alongside a booking's seats
+type ParkingReservation struct {
+	ID        string                   `json:"id"`
+	BookingID string                   `json:"booking_id"`
+	UserID    string                   `json:"user_id"`
+	ShowID    string                   `json:"show_id"`
+	TheatreID string                   `json:"theatre_id"`
+	Slots     []string                 `json:"slots"` // Slot codes, e.g. P007
+	Status    ParkingReservationStatus `json:"status"`
+	StartsAt  time.Time                `json:"starts_at"`  // The slots are taken from the show's start...
+	EndsAt    time.Time                `json:"ends_at"`    // ...to its end
+	ExpiresAt time.Time                `json:"expires_at"` // A held reservation lapses with its unpaid booking
+	CreatedAt time.Time                `json:"created_at"`
+	UpdatedAt time.Time                `json:"updated_at"`
+	mutex     sync.RWMutex
+}
+
+// NewParkingReservation holds slots for a pending booking until its payment window closes
+func NewParkingReservation(booking *Booking, show *Show, slots []string, now time.Time) (*ParkingReservation, error) {
+	if booking == nil || show == nil || len(slots) == 0 {
+		return nil, ErrInvalidParkingReservation
+	}
+
+	return &ParkingReservation{
+		ID:        uuid.New().String(),
+		BookingID: booking.ID,
+		UserID:    booking.UserID,
+		ShowID:    show.ID,
+		TheatreID: show.TheatreID,
+		Slots:     slots,
+		Status:    ParkingReservationHeld,
+		StartsAt:  show.StartTime,
+		EndsAt:    show.EndTime,
+		ExpiresAt: booking.ExpiryTime,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// GetStatus returns the reservation's status, reporting a held reservation past its expiry as expired
+func (r *ParkingReservation) GetStatus(now time.Time) ParkingReservationStatus {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.status(now)
+}
+
+// GetSlots returns a copy of the reserved slot codes
+func (r *ParkingReservation) GetSlots() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return append([]string(nil), r.Slots...)
+}
+
+// Occupies reports whether the reservation takes its slots for any part of [from, to) as of now
+func (r *ParkingReservation) Occupies(from, to, now time.Time) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	switch r.status(now) {
+	case ParkingReservationHeld, ParkingReservationConfirmed:
+		return r.StartsAt.Before(to) && from.Before(r.EndsAt)
+	default:
+		return false
+	}
+}
+
+// Confirm keeps the slots once the booking is paid
+func (r *ParkingReservation) Confirm(now time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.status(now) != ParkingReservationHeld {
+		return ErrParkingReservationNotHeld
+	}
+	r.Status = ParkingReservationConfirmed
+	r.UpdatedAt = now
+	return nil
+}
+
+// Cancel frees the slots of a held or confirmed reservation
+func (r *ParkingReservation) Cancel(now time.Time) error {
+	return r.end(ParkingReservationCancelled, now)
+}
+
+// Expire frees the slots of a reservation whose booking wasn't paid in time
+func (r *ParkingReservation) Expire(now time.Time) error {
+	return r.end(ParkingReservationExpired, now)
+}
+
+// ExtendHold keeps held slots until the booking's extended payment window closes
+func (r *ParkingReservation) ExtendHold(expiresAt, now time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.status(now) != ParkingReservationHeld {
+		return ErrParkingReservationNotHeld
+	}
+	r.ExpiresAt = expiresAt
+	r.UpdatedAt = now
+	return nil
+}
+
+// Reschedule moves the reservation with its show
+func (r *ParkingReservation) Reschedule(startsAt, endsAt, now time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.StartsAt, r.EndsAt = startsAt, endsAt
+	r.UpdatedAt = now
+}
+
+func (r *ParkingReservation) end(status ParkingReservationStatus, now time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.Status != ParkingReservationHeld && r.Status != ParkingReservationConfirmed {
+		return ErrParkingReservationNotHeld
+	}
+	r.Status = status
+	r.UpdatedAt = now
+	return nil
+}
+
+// status works out the status as of now - callers must hold the mutex
+func (r *ParkingReservation) status(now time.Time) ParkingReservationStatus {
+	if r.Status == ParkingReservationHeld && now.After(r.ExpiresAt) {
+		return ParkingReservationExpired
+	}
+	return r.Status
+}
@@ -15,6 +15,8 @@ const (
 	PaymentMethodUPI        PaymentMethod = "UPI"
 	PaymentMethodNetBanking PaymentMethod = "NET_BANKING"
 	PaymentMethodWallet     PaymentMethod = "WALLET"
+	PaymentMethodEMI        PaymentMethod = "EMI"       // Card EMI, repaid monthly with interest
+	PaymentMethodPayLater   PaymentMethod = "PAY_LATER" // Buy now, pay later on a single due date
 )
 
 // PaymentStatus represents the status of a payment
@@ -26,48 +28,57 @@ const (
 	PaymentStatusFailed    PaymentStatus = "FAILED"
 	PaymentStatusRefunded  PaymentStatus = "REFUNDED"
 	PaymentStatusCancelled PaymentStatus = "CANCELLED"
+
+	PaymentStatusPartiallyRefunded PaymentStatus = "PARTIALLY_REFUNDED"
+
+	PaymentStatusPendingConfirmation PaymentStatus = "PENDING_CONFIRMATION" // The gateway never answered; reconciliation asks again
+	PaymentStatusRequiresAction      PaymentStatus = "REQUIRES_ACTION"      // The issuer wants an OTP before charging
 )
 
 // Payment represents a payment transaction
 type Payment struct {
-	ID              string        `json:"id"`
-	BookingID       string        `json:"booking_id"`
-	UserID          string        `json:"user_id"`
-	Amount          float64       `json:"amount"`
-	Method          PaymentMethod `json:"method"`
-	Status          PaymentStatus `json:"status"`
-	TransactionID   string        `json:"transaction_id,omitempty"`
-	GatewayResponse string        `json:"gateway_response,omitempty"`
-	FailureReason   string        `json:"failure_reason,omitempty"`
-	RefundAmount    float64       `json:"refund_amount,omitempty"`
-	RefundReason    string        `json:"refund_reason,omitempty"`
-	ProcessedAt     *time.Time    `json:"processed_at,omitempty"`
-	RefundedAt      *time.Time    `json:"refunded_at,omitempty"`
-	CreatedAt       time.Time     `json:"created_at"`
-	UpdatedAt       time.Time     `json:"updated_at"`
+	ID              string           `json:"id"`
+	BookingID       string           `json:"booking_id"`
+	UserID          string           `json:"user_id"`
+	Amount          Money            `json:"amount"`
+	Method          PaymentMethod    `json:"method"`
+	Attempt         int              `json:"attempt,omitempty"` // 1 for the first try at the booking total; zero for supplements
+	Status          PaymentStatus    `json:"status"`
+	TransactionID   string           `json:"transaction_id,omitempty"`
+	GatewayResponse string           `json:"gateway_response,omitempty"`
+	FailureReason   string           `json:"failure_reason,omitempty"`
+	RefundAmount    Money            `json:"refund_amount"`
+	RefundReason    string           `json:"refund_reason,omitempty"`
+	Installments    *InstallmentPlan `json:"installments,omitempty"` // EMI and pay-later repayment schedule
+	Challenge       *OTPChallenge    `json:"challenge,omitempty"`    // The issuer's OTP step, while one is or was asked for
+	Risk            *RiskAssessment  `json:"risk,omitempty"`         // What fraud checks made of the payment before charging it
+	ProcessedAt     *time.Time       `json:"processed_at,omitempty"`
+	RefundedAt      *time.Time       `json:"refunded_at,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
 }
 
 // NewPayment creates a new payment
-func NewPayment(bookingID, userID string, amount float64, method PaymentMethod) (*Payment, error) {
-	if bookingID == "" || userID == "" || amount <= 0 {
+func NewPayment(bookingID, userID string, amount Money, method PaymentMethod, now time.Time) (*Payment, error) {
+	if bookingID == "" || userID == "" || !amount.IsPositive() {
 		return nil, ErrInvalidPaymentData
 	}
 
 	return &Payment{
-		ID:        uuid.New().String(),
-		BookingID: bookingID,
-		UserID:    userID,
-		Amount:    amount,
-		Method:    method,
-		Status:    PaymentStatusPending,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:           uuid.New().String(),
+		BookingID:    bookingID,
+		UserID:       userID,
+		Amount:       amount,
+		RefundAmount: ZeroMoney(amount.Currency),
+		Method:       method,
+		Status:       PaymentStatusPending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}, nil
 }
 
 // MarkSuccess marks the payment as successful
-func (p *Payment) MarkSuccess(transactionID, gatewayResponse string) {
-	now := time.Now()
+func (p *Payment) MarkSuccess(transactionID, gatewayResponse string, now time.Time) {
 	p.Status = PaymentStatusSuccess
 	p.TransactionID = transactionID
 	p.GatewayResponse = gatewayResponse
@@ -75,34 +86,50 @@ func (p *Payment) MarkSuccess(transactionID, gatewayResponse string) {
 	p.UpdatedAt = now
 }
 
+// AttachInstallments records how a successful EMI or pay-later payment is repaid
+func (p *Payment) AttachInstallments(plan *InstallmentPlan, now time.Time) {
+	p.Installments = plan
+	p.UpdatedAt = now
+}
+
 // MarkFailed marks the payment as failed
-func (p *Payment) MarkFailed(failureReason string) {
-	now := time.Now()
+func (p *Payment) MarkFailed(failureReason string, now time.Time) {
 	p.Status = PaymentStatusFailed
 	p.FailureReason = failureReason
 	p.ProcessedAt = &now
 	p.UpdatedAt = now
 }
 
+// MarkPendingConfirmation records that the gateway never said whether the payment went through, e.g. it
+// timed out, so it must be asked again before the payment counts as taken or failed
+func (p *Payment) MarkPendingConfirmation(reason string, now time.Time) {
+	p.Status = PaymentStatusPendingConfirmation
+	p.GatewayResponse = reason
+	p.UpdatedAt = now
+}
+
 // MarkCancelled marks the payment as cancelled
-func (p *Payment) MarkCancelled() {
+func (p *Payment) MarkCancelled(now time.Time) {
 	p.Status = PaymentStatusCancelled
-	p.UpdatedAt = time.Now()
+	p.UpdatedAt = now
 }
 
-// ProcessRefund processes a refund for the payment
-func (p *Payment) ProcessRefund(refundAmount float64, refundReason string) error {
-	if p.Status != PaymentStatusSuccess {
+// ProcessRefund processes a full or partial refund for the payment
+func (p *Payment) ProcessRefund(refundAmount Money, refundReason string, now time.Time) error {
+	if !p.CanBeRefunded() {
 		return ErrPaymentNotSuccessful
 	}
 
-	if refundAmount <= 0 || refundAmount > p.Amount {
+	if !refundAmount.SameCurrency(p.Amount) || !refundAmount.IsPositive() || refundAmount.GreaterThan(p.RefundableAmount()) {
 		return ErrInvalidRefundAmount
 	}
 
-	now := time.Now()
-	p.Status = PaymentStatusRefunded
-	p.RefundAmount = refundAmount
+	p.RefundAmount = p.RefundAmount.Add(refundAmount)
+	if p.RefundableAmount().IsZero() {
+		p.Status = PaymentStatusRefunded
+	} else {
+		p.Status = PaymentStatusPartiallyRefunded
+	}
 	p.RefundReason = refundReason
 	p.RefundedAt = &now
 	p.UpdatedAt = now
@@ -119,6 +146,11 @@ func (p *Payment) IsPending() bool {
 	return p.Status == PaymentStatusPending
 }
 
+// IsAwaitingConfirmation checks if the gateway still has to be asked how the payment went
+func (p *Payment) IsAwaitingConfirmation() bool {
+	return p.Status == PaymentStatusPendingConfirmation
+}
+
 // IsFailed checks if payment failed
 func (p *Payment) IsFailed() bool {
 	return p.Status == PaymentStatusFailed
@@ -131,5 +163,13 @@ func (p *Payment) IsRefunded() bool {
 
 // CanBeRefunded checks if payment can be refunded
 func (p *Payment) CanBeRefunded() bool {
-	return p.Status == PaymentStatusSuccess
+	return p.Status == PaymentStatusSuccess || p.Status == PaymentStatusPartiallyRefunded
+}
+
+// RefundableAmount returns the amount that has not been refunded yet
+func (p *Payment) RefundableAmount() Money {
+	if !p.CanBeRefunded() {
+		return ZeroMoney(p.Amount.Currency)
+	}
+	return p.Amount.Sub(p.RefundAmount)
 }
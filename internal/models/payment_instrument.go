@@ -0,0 +1,194 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxPaymentInstruments caps how many instruments one user can save
+const MaxPaymentInstruments = 10
+
+// InstrumentType is the kind of saved payment instrument
+type InstrumentType string
+
+const (
+	InstrumentTypeCard   InstrumentType = "CARD"   // Tokenized card; pays by credit card, debit card or EMI
+	InstrumentTypeUPI    InstrumentType = "UPI"    // UPI handle, e.g. name@bank
+	InstrumentTypeWallet InstrumentType = "WALLET" // The user's own wallet, linked for one-tap payments
+)
+
+// CardDetails is what the cardholder types in. Only the vault ever keeps the number; instruments keep its
+// token, brand and last four digits.
+type CardDetails struct {
+	Number string `json:"number"`
+	Expiry string `json:"expiry"` // MM/YY
+}
+
+// Validate checks the number's length and check digit, and that the card hasn't expired
+func (c CardDetails) Validate(now time.Time) error {
+	number := c.digits()
+	if len(number) < 12 || len(number) > 19 || !luhnValid(number) {
+		return ErrInvalidCardNumber
+	}
+	expires, err := cardExpiry(c.Expiry)
+	if err != nil {
+		return err
+	}
+	if !now.Before(expires) {
+		return ErrCardExpired
+	}
+	return nil
+}
+
+// Brand guesses the card network from the number's leading digits
+func (c CardDetails) Brand() string {
+	number := c.digits()
+	switch {
+	case strings.HasPrefix(number, "4"):
+		return "VISA"
+	case strings.HasPrefix(number, "34"), strings.HasPrefix(number, "37"):
+		return "AMEX"
+	case len(number) >= 2 && number[0] == '5' && number[1] >= '1' && number[1] <= '5',
+		strings.HasPrefix(number, "2"):
+		return "MASTERCARD"
+	case strings.HasPrefix(number, "60"), strings.HasPrefix(number, "65"), strings.HasPrefix(number, "81"),
+		strings.HasPrefix(number, "82"):
+		return "RUPAY"
+	}
+	return "CARD"
+}
+
+// Last4 returns the last four digits, the only part of the number shown back to the user
+func (c CardDetails) Last4() string {
+	number := c.digits()
+	return number[max(len(number)-4, 0):]
+}
+
+// digits strips the spaces and dashes people type between groups of digits
+func (c CardDetails) digits() string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(c.Number)
+}
+
+// PaymentInstrument is a card, UPI handle or wallet a user saved to pay with again. Cards are stored as a
+// vault token: the instrument itself never holds a card number.
+type PaymentInstrument struct {
+	ID        string         `json:"id"`
+	UserID    string         `json:"user_id"`
+	Type      InstrumentType `json:"type"`
+	Token     string         `json:"token,omitempty"`  // Vault token standing in for the card; never shown to the user
+	Brand     string         `json:"brand,omitempty"`  // Cards only
+	Last4     string         `json:"last4,omitempty"`  // Cards only
+	Expiry    string         `json:"expiry,omitempty"` // Cards only, MM/YY
+	Handle    string         `json:"handle,omitempty"` // UPI only
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// NewCardInstrument saves a card the vault has tokenized
+func NewCardInstrument(userID, token string, card CardDetails, now time.Time) (*PaymentInstrument, error) {
+	if userID == "" || token == "" {
+		return nil, ErrInvalidPaymentInstrument
+	}
+	if err := card.Validate(now); err != nil {
+		return nil, err
+	}
+	return &PaymentInstrument{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Type:      InstrumentTypeCard,
+		Token:     token,
+		Brand:     card.Brand(),
+		Last4:     card.Last4(),
+		Expiry:    card.Expiry,
+		CreatedAt: now,
+	}, nil
+}
+
+// NewUPIInstrument saves a UPI handle such as name@bank
+func NewUPIInstrument(userID, handle string, now time.Time) (*PaymentInstrument, error) {
+	name, bank, ok := strings.Cut(strings.TrimSpace(handle), "@")
+	if userID == "" || !ok || name == "" || bank == "" || strings.ContainsAny(bank, "@ ") {
+		return nil, ErrInvalidPaymentInstrument
+	}
+	return &PaymentInstrument{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Type:      InstrumentTypeUPI,
+		Handle:    strings.ToLower(strings.TrimSpace(handle)),
+		CreatedAt: now,
+	}, nil
+}
+
+// NewWalletInstrument links the user's wallet
+func NewWalletInstrument(userID string, now time.Time) (*PaymentInstrument, error) {
+	if userID == "" {
+		return nil, ErrInvalidPaymentInstrument
+	}
+	return &PaymentInstrument{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Type:      InstrumentTypeWallet,
+		CreatedAt: now,
+	}, nil
+}
+
+// Supports reports whether the instrument can pay by the given method
+func (i *PaymentInstrument) Supports(method PaymentMethod) bool {
+	switch i.Type {
+	case InstrumentTypeCard:
+		return method == PaymentMethodCreditCard || method == PaymentMethodDebitCard || method == PaymentMethodEMI
+	case InstrumentTypeUPI:
+		return method == PaymentMethodUPI
+	case InstrumentTypeWallet:
+		return method == PaymentMethodWallet
+	}
+	return false
+}
+
+// IsExpired checks if a saved card has passed its expiry month; other instruments don't expire
+func (i *PaymentInstrument) IsExpired(now time.Time) bool {
+	if i.Type != InstrumentTypeCard {
+		return false
+	}
+	expires, err := cardExpiry(i.Expiry)
+	return err != nil || !now.Before(expires)
+}
+
+// Redacted returns a copy without the vault token, for showing instruments to their owner
+func (i *PaymentInstrument) Redacted() *PaymentInstrument {
+	redacted := *i
+	redacted.Token = ""
+	return &redacted
+}
+
+// cardExpiry parses an MM/YY expiry into the instant the card stops working: the start of the following month
+func cardExpiry(expiry string) (time.Time, error) {
+	month, year, ok := strings.Cut(expiry, "/")
+	m, monthErr := strconv.Atoi(month)
+	y, yearErr := strconv.Atoi(year)
+	if !ok || len(month) != 2 || len(year) != 2 || monthErr != nil || yearErr != nil || m < 1 || m > 12 {
+		return time.Time{}, ErrInvalidCardExpiry
+	}
+	return time.Date(2000+y, time.Month(m)+1, 1, 0, 0, 0, 0, time.UTC), nil
+}
+
+// luhnValid checks a card number's check digit
+func luhnValid(number string) bool {
+	sum := 0
+	for i := range len(number) {
+		digit := int(number[len(number)-1-i] - '0')
+		if digit < 0 || digit > 9 {
+			return false
+		}
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,47 @@
+package models
+
+// Permission is an action only some roles may take
+type Permission string
+
+const (
+	PermissionManageCatalog    Permission = "MANAGE_CATALOG"    // Movies, live events and cities
+	PermissionManagePromotions Permission = "MANAGE_PROMOTIONS" // Platform-wide coupons
+	PermissionManageUsers      Permission = "MANAGE_USERS"      // Granting roles
+	PermissionOnboardTheatre   Permission = "ONBOARD_THEATRE"
+	PermissionManageTheatre    Permission = "MANAGE_THEATRE" // Screens, shows and cancellation policy of one theatre
+	PermissionViewReports      Permission = "VIEW_REPORTS"   // Occupancy and revenue of one theatre
+	PermissionModerateReviews  Permission = "MODERATE_REVIEWS"
+	PermissionOperate          Permission = "OPERATE"        // Event outbox and notification dead letters, WhatsApp templates
+	PermissionSettlePayouts    Permission = "SETTLE_PAYOUTS" // Generating and paying out theatre settlements
+	PermissionViewAudit        Permission = "VIEW_AUDIT"     // Who changed what, for any entity
+	PermissionBulkBook         Permission = "BULK_BOOK"      // Reserving blocks of seats beyond the per-user limits
+)
+
+// IsTheatreScoped reports whether the permission only covers the theatres a user manages
+func (p Permission) IsTheatreScoped() bool {
+	return p == PermissionManageTheatre || p == PermissionViewReports
+}
+
+// rolePermissions lists what each role may do; customers only act on their own bookings, which services check directly
+var rolePermissions = map[UserRole][]Permission{
+	UserRoleCorporate: {
+		PermissionBulkBook,
+	},
+	UserRoleTheatreAdmin: {
+		PermissionManageTheatre,
+		PermissionViewReports,
+	},
+	UserRoleSuperAdmin: {
+		PermissionManageCatalog,
+		PermissionManagePromotions,
+		PermissionManageUsers,
+		PermissionOnboardTheatre,
+		PermissionManageTheatre,
+		PermissionViewReports,
+		PermissionModerateReviews,
+		PermissionOperate,
+		PermissionSettlePayouts,
+		PermissionViewAudit,
+		PermissionBulkBook,
+	},
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxPreferenceItems caps each list in a user's preferences
+const MaxPreferenceItems = 10
+
+// UserPreferences is what a user told us they like. Show searches default to it where the user
+// didn't ask for something else, recommendations can build on it, and notifications go out on the
+// channels it names.
+type UserPreferences struct {
+	HomeCity           string                `json:"home_city,omitempty"`
+	PreferredLanguages []Language            `json:"preferred_languages,omitempty"` // Most preferred first
+	FavoriteGenres     []Genre               `json:"favorite_genres,omitempty"`
+	FavoriteTheatreIDs []string              `json:"favorite_theatre_ids,omitempty"`
+	Channels           []NotificationChannel `json:"channels,omitempty"` // How the user wants to be notified; empty means every channel in use
+}
+
+// Normalize trims and upper-cases languages, genres and channels, drops blanks and duplicates, and enforces MaxPreferenceItems.
+// Order is kept, so the first language stays the most preferred.
+func (p UserPreferences) Normalize() (UserPreferences, error) {
+	normalized := UserPreferences{
+		HomeCity:           strings.TrimSpace(p.HomeCity),
+		PreferredLanguages: distinct(p.PreferredLanguages, func(l Language) Language { return Language(strings.ToUpper(strings.TrimSpace(string(l)))) }),
+		FavoriteGenres:     distinct(p.FavoriteGenres, func(g Genre) Genre { return Genre(strings.ToUpper(strings.TrimSpace(string(g)))) }),
+		FavoriteTheatreIDs: distinct(p.FavoriteTheatreIDs, strings.TrimSpace),
+		Channels: distinct(p.Channels, func(c NotificationChannel) NotificationChannel {
+			return NotificationChannel(strings.ToUpper(strings.TrimSpace(string(c))))
+		}),
+	}
+	if len(normalized.PreferredLanguages) > MaxPreferenceItems ||
+		len(normalized.FavoriteGenres) > MaxPreferenceItems ||
+		len(normalized.FavoriteTheatreIDs) > MaxPreferenceItems {
+		return UserPreferences{}, ErrInvalidPreferences
+	}
+	for _, channel := range normalized.Channels {
+		if !channel.IsValid() {
+			return UserPreferences{}, fmt.Errorf("%w: unknown notification channel %q", ErrInvalidPreferences, channel)
+		}
+	}
+	return normalized, nil
+}
+
+// PrefersChannel reports whether the user wants notifications on the channel; users who haven't picked any
+// take every channel
+func (p UserPreferences) PrefersChannel(channel NotificationChannel) bool {
+	return len(p.Channels) == 0 || slices.Contains(p.Channels, channel)
+}
+
+// IsFavoriteTheatre reports whether the user marked the theatre as a favourite
+func (p UserPreferences) IsFavoriteTheatre(theatreID string) bool {
+	return slices.Contains(p.FavoriteTheatreIDs, theatreID)
+}
+
+// SetPreferences replaces the user's preferences with a normalized copy
+func (u *User) SetPreferences(preferences UserPreferences, now time.Time) error {
+	normalized, err := preferences.Normalize()
+	if err != nil {
+		return err
+	}
+	u.Preferences = normalized
+	u.UpdatedAt = now
+	return nil
+}
+
+// distinct cleans each item, keeping the first occurrence of every non-empty result
+func distinct[T ~string](items []T, clean func(T) T) []T {
+	var result []T
+	for _, item := range items {
+		if item = clean(item); item != "" && !slices.Contains(result, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
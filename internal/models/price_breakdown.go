@@ -0,0 +1,73 @@
+package models
+
+// FeeConfig holds the charges added on top of ticket prices; the zero value adds nothing
+type FeeConfig struct {
+	ConvenienceFeePercent float64 `json:"convenience_fee_percent"` // Of the discounted ticket amount
+	GSTPercent            float64 `json:"gst_percent"`             // Of tickets plus fee, split equally into CGST and SGST
+}
+
+// DefaultFeeConfig charges a 2% convenience fee and 18% GST
+func DefaultFeeConfig() FeeConfig {
+	return FeeConfig{ConvenienceFeePercent: 2, GSTPercent: 18}
+}
+
+// PriceBreakdown itemizes what a booking costs, from seat prices to the payable total
+type PriceBreakdown struct {
+	Fees           FeeConfig `json:"fees"`
+	Subtotal       Money     `json:"subtotal"` // Sum of seat prices
+	Discount       Money     `json:"discount"`
+	ConvenienceFee Money     `json:"convenience_fee"`
+	CGST           Money     `json:"cgst"`
+	SGST           Money     `json:"sgst"`
+	AddOns         Money     `json:"add_ons"`                  // Add-on prices, tax included, so outside the fee and GST
+	LoyaltyPoints  int64     `json:"loyalty_points,omitempty"` // Points spent towards the total
+	LoyaltyValue   Money     `json:"loyalty_value"`
+	Total          Money     `json:"total"` // Left to pay after loyalty points
+}
+
+// Calculate prices a subtotal and discount under these fees.
+// CGST takes the rounded half of the tax and SGST the rest, so the parts always add up to Total.
+func (c FeeConfig) Calculate(subtotal, discount Money) PriceBreakdown {
+	tickets := subtotal.Sub(discount)
+	fee := tickets.Percent(c.ConvenienceFeePercent)
+	gst := tickets.Add(fee).Percent(c.GSTPercent)
+	cgst := gst.Percent(50)
+
+	return PriceBreakdown{
+		Fees:           c,
+		Subtotal:       subtotal,
+		Discount:       discount,
+		ConvenienceFee: fee,
+		CGST:           cgst,
+		SGST:           gst.Sub(cgst),
+		AddOns:         ZeroMoney(subtotal.Currency),
+		LoyaltyValue:   ZeroMoney(subtotal.Currency),
+		Total:          tickets.Add(fee).Add(gst),
+	}
+}
+
+// withAddOns adds the add-ons' prices to the total
+func (p PriceBreakdown) withAddOns(amount Money) PriceBreakdown {
+	if amount.IsZero() {
+		return p
+	}
+	p.AddOns = amount
+	p.Total = p.Total.Add(amount)
+	return p
+}
+
+// withLoyalty pays part of the total with points
+func (p PriceBreakdown) withLoyalty(points int64, value Money) PriceBreakdown {
+	if points == 0 {
+		return p
+	}
+	p.LoyaltyPoints = points
+	p.LoyaltyValue = value
+	p.Total = p.Total.Sub(value)
+	return p
+}
+
+// Tax returns the combined GST
+func (p PriceBreakdown) Tax() Money {
+	return p.CGST.Add(p.SGST)
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateLimit lets Requests through per Period for each caller, refilling evenly, so a quiet caller can burst
+// up to Requests at once; zero Requests disables the limit
+type RateLimit struct {
+	Requests int           `json:"requests"`
+	Period   time.Duration `json:"period"`
+}
+
+// Enabled reports whether the limit refuses anything
+func (l RateLimit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// RateLimitConfig throttles the API per caller and endpoint, and bookings for shows whose sales just opened
+type RateLimitConfig struct {
+	API           RateLimit            // Each caller on each endpoint: the signed-in user, else the client IP
+	Endpoints     map[string]RateLimit // Overrides API for a route pattern, e.g. "POST /auth/login"
+	Opening       RateLimit            // CreateBooking per user and show, for shows put on sale less than OpeningWindow ago
+	OpeningWindow time.Duration
+}
+
+// DefaultRateLimitConfig allows 120 requests a minute per endpoint, 10 logins and 5 signups a minute, and
+// 3 booking attempts a minute per user in the first 30 minutes a show is on sale
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		API: RateLimit{Requests: 120, Period: time.Minute},
+		Endpoints: map[string]RateLimit{
+			"POST /auth/login":  {Requests: 10, Period: time.Minute},
+			"POST /auth/signup": {Requests: 5, Period: time.Minute},
+		},
+		Opening:       RateLimit{Requests: 3, Period: time.Minute},
+		OpeningWindow: 30 * time.Minute,
+	}
+}
+
+// EndpointLimit is the limit of one route pattern
+func (c RateLimitConfig) EndpointLimit(pattern string) RateLimit {
+	if limit, ok := c.Endpoints[pattern]; ok {
+		return limit
+	}
+	return c.API
+}
+
+// InOpening reports whether show went on sale less than OpeningWindow before now
+func (c RateLimitConfig) InOpening(show *Show, now time.Time) bool {
+	return c.Opening.Enabled() && now.Sub(show.CreatedAt) < c.OpeningWindow
+}
+
+// RateLimitError is how a caller over a rate limit is refused; errors.Is matches ErrRateLimited
+type RateLimitError struct {
+	Scope      string        // What was limited, e.g. a route pattern
+	RetryAfter time.Duration // Until the next request would be let through
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s: %s, retry in %ds", ErrRateLimited, e.Scope, e.RetryAfterSeconds())
+}
+
+// RetryAfterSeconds rounds RetryAfter up to whole seconds, at least one, as the Retry-After header wants them
+func (e *RateLimitError) RetryAfterSeconds() int {
+	return max(1, int((e.RetryAfter+time.Second-1)/time.Second))
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited.WithDetails(map[string]any{"scope": e.Scope, "retry_after_seconds": e.RetryAfterSeconds()})
+}
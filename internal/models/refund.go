@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefundStatus represents the status of a refund
+type RefundStatus string
+
+const (
+	RefundStatusInitiated RefundStatus = "INITIATED"
+	RefundStatusProcessed RefundStatus = "PROCESSED"
+	RefundStatusFailed    RefundStatus = "FAILED"
+)
+
+// RefundDestination is where refunded money goes
+type RefundDestination string
+
+const (
+	RefundDestinationSource RefundDestination = "SOURCE" // Back to the original payment method
+	RefundDestinationWallet RefundDestination = "WALLET" // Credited to the user's wallet
+)
+
+// Refund represents money moving back to the user for a payment
+type Refund struct {
+	ID               string            `json:"id"`
+	PaymentID        string            `json:"payment_id"`
+	BookingID        string            `json:"booking_id"`
+	UserID           string            `json:"user_id"`
+	Amount           Money             `json:"amount"`
+	Reason           string            `json:"reason"`
+	Destination      RefundDestination `json:"destination"`
+	Status           RefundStatus      `json:"status"`
+	GatewayReference string            `json:"gateway_reference,omitempty"`
+	FailureReason    string            `json:"failure_reason,omitempty"`
+	ProcessedAt      *time.Time        `json:"processed_at,omitempty"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+}
+
+// NewRefund creates a new refund in initiated state
+func NewRefund(paymentID, bookingID, userID string, amount Money, reason string, now time.Time) (*Refund, error) {
+	if paymentID == "" || bookingID == "" || userID == "" {
+		return nil, ErrInvalidRefundData
+	}
+
+	if !amount.IsPositive() {
+		return nil, ErrInvalidRefundAmount
+	}
+
+	return &Refund{
+		ID:          uuid.New().String(),
+		PaymentID:   paymentID,
+		BookingID:   bookingID,
+		UserID:      userID,
+		Amount:      amount,
+		Reason:      reason,
+		Destination: RefundDestinationSource,
+		Status:      RefundStatusInitiated,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// MarkProcessed marks the refund as processed by the gateway
+func (r *Refund) MarkProcessed(gatewayReference string, now time.Time) {
+	r.Status = RefundStatusProcessed
+	r.GatewayReference = gatewayReference
+	r.ProcessedAt = &now
+	r.UpdatedAt = now
+}
+
+// MarkFailed marks the refund as failed
+func (r *Refund) MarkFailed(failureReason string, now time.Time) {
+	r.Status = RefundStatusFailed
+	r.FailureReason = failureReason
+	r.ProcessedAt = &now
+	r.UpdatedAt = now
+}
+
+// IsProcessed checks if the refund was processed
+func (r *Refund) IsProcessed() bool {
+	return r.Status == RefundStatusProcessed
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResaleConfig sets what the platform keeps when a booking is resold
+type ResaleConfig struct {
+	FeePercent float64 `json:"fee_percent"` // Of the resale price, taken from the seller's refund
+}
+
+// DefaultResaleConfig keeps 10% of each resale
+func DefaultResaleConfig() ResaleConfig {
+	return ResaleConfig{FeePercent: 10}
+}
+
+// ResaleListingStatus tracks a booking put up for resale
+type ResaleListingStatus string
+
+const (
+	ResaleListingListed    ResaleListingStatus = "LISTED"    // Open to buyers
+	ResaleListingSold      ResaleListingStatus = "SOLD"      // A buyer paid; the booking is theirs
+	ResaleListingWithdrawn ResaleListingStatus = "WITHDRAWN" // By the seller, or because the booking changed or was cancelled
+	ResaleListingExpired   ResaleListingStatus = "EXPIRED"   // The show started before anyone bought it
+)
+
+// ResaleListing offers a confirmed booking to any buyer at no more than its face value. The first buyer to pay
+// gets the booking; the seller is refunded the price less the platform's fee.
+type ResaleListing struct {
+	ID           string              `json:"id"`
+	BookingID    string              `json:"booking_id"`
+	ShowID       string              `json:"show_id"`
+	SellerID     string              `json:"seller_id"`
+	Seats        int                 `json:"seats"`
+	Price        Money               `json:"price"`         // What the buyer pays
+	FaceValue    Money               `json:"face_value"`    // What the booking cost; the price can't exceed it
+	Fee          Money               `json:"fee"`           // Kept by the platform
+	SellerPayout Money               `json:"seller_payout"` // Refunded to the seller once the booking sells
+	Status       ResaleListingStatus `json:"status"`
+	BuyerID      string              `json:"buyer_id,omitempty"`
+	PaymentID    string              `json:"payment_id,omitempty"` // The buyer's
+	RefundIDs    []string            `json:"refund_ids,omitempty"` // The seller's payout, across their payments
+	Reason       string              `json:"reason,omitempty"`     // Why it was withdrawn
+	ListedAt     time.Time           `json:"listed_at"`
+	SoldAt       *time.Time          `json:"sold_at,omitempty"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+	mutex        sync.RWMutex
+}
+
+// NewResaleListing lists a confirmed booking at price, which must not exceed what the booking cost and must
+// leave the seller something after the fee
+func NewResaleListing(booking *Booking, price Money, config ResaleConfig, now time.Time) (*ResaleListing, error) {
+	if booking == nil || !price.IsPositive() || config.FeePercent < 0 || config.FeePercent >= 100 {
+		return nil, ErrInvalidResaleListing
+	}
+	if booking.GetStatus() != BookingStatusConfirmed {
+		return nil, ErrBookingNotConfirmed
+	}
+
+	booking.mutex.RLock()
+	faceValue := booking.TotalAmount
+	booking.mutex.RUnlock()
+	if !price.SameCurrency(faceValue) {
+		return nil, ErrInvalidResaleListing
+	}
+	if price.GreaterThan(faceValue) {
+		return nil, ErrResalePriceAboveFaceValue
+	}
+
+	fee := price.Percent(config.FeePercent)
+	return &ResaleListing{
+		ID:           uuid.New().String(),
+		BookingID:    booking.ID,
+		ShowID:       booking.ShowID,
+		SellerID:     booking.UserID,
+		Seats:        booking.GetSeatCount(),
+		Price:        price,
+		FaceValue:    faceValue,
+		Fee:          fee,
+		SellerPayout: price.Sub(fee),
+		Status:       ResaleListingListed,
+		ListedAt:     now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// GetStatus returns the current listing status (thread-safe)
+func (l *ResaleListing) GetStatus() ResaleListingStatus {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.Status
+}
+
+// MarkSold records the buyer, their payment and the seller's refunds
+func (l *ResaleListing) MarkSold(buyerID, paymentID string, refundIDs []string, now time.Time) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.Status != ResaleListingListed {
+		return ErrResaleListingClosed
+	}
+
+	l.Status = ResaleListingSold
+	l.BuyerID, l.PaymentID, l.RefundIDs = buyerID, paymentID, refundIDs
+	l.SoldAt = &now
+	l.UpdatedAt = now
+	return nil
+}
+
+// Withdraw takes an open listing off sale
+func (l *ResaleListing) Withdraw(reason string, now time.Time) error {
+	return l.close(ResaleListingWithdrawn, reason, now)
+}
+
+// Expire closes an open listing whose show has started
+func (l *ResaleListing) Expire(now time.Time) error {
+	return l.close(ResaleListingExpired, "", now)
+}
+
+func (l *ResaleListing) close(status ResaleListingStatus, reason string, now time.Time) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.Status != ResaleListingListed {
+		return ErrResaleListingClosed
+	}
+	l.Status, l.Reason = status, reason
+	l.UpdatedAt = now
+	return nil
+}
+
+// Resell hands a confirmed booking to the buyer who paid for it with paymentID. The seller's payments no
+// longer count as the booking's, so a later cancellation refunds the buyer; any offer to transfer it is
+// withdrawn.
+func (b *Booking) Resell(buyerID, paymentID string, now time.Time) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.Status != BookingStatusConfirmed {
+		return ErrBookingNotConfirmed
+	}
+	if buyerID == "" || buyerID == b.UserID || paymentID == "" {
+		return ErrInvalidResaleListing
+	}
+
+	if pending := b.pendingTransfer(); pending != nil {
+		pending.Status, pending.RespondedAt = BookingTransferWithdrawn, &now
+	}
+
+	b.SoldPaymentIDs = append(b.SoldPaymentIDs, b.PaymentID)
+	b.SoldPaymentIDs = append(b.SoldPaymentIDs, b.ExtraPaymentIDs...)
+	b.PaymentID, b.ExtraPaymentIDs = paymentID, nil
+	b.UserID = buyerID
+	b.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewStatus represents the moderation state of a review
+type ReviewStatus string
+
+const (
+	ReviewStatusPending  ReviewStatus = "PENDING"
+	ReviewStatusApproved ReviewStatus = "APPROVED" // Visible and counted in the movie rating
+	ReviewStatusRejected ReviewStatus = "REJECTED"
+)
+
+// Review limits
+const (
+	MinReviewStars     = 1
+	MaxReviewStars     = 5
+	MaxReviewTextRunes = 2000
+)
+
+// Review is a user's star rating and write-up for a movie
+type Review struct {
+	ID             string       `json:"id"`
+	UserID         string       `json:"user_id"`
+	MovieID        string       `json:"movie_id"`
+	Stars          int          `json:"stars"`
+	Text           string       `json:"text,omitempty"`
+	Status         ReviewStatus `json:"status"`
+	ModeratedBy    string       `json:"moderated_by,omitempty"`
+	ModerationNote string       `json:"moderation_note,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	mutex          sync.RWMutex
+}
+
+// NewReview creates a review awaiting moderation
+func NewReview(userID, movieID string, stars int, text string, now time.Time) (*Review, error) {
+	if userID == "" || movieID == "" {
+		return nil, ErrInvalidReviewData
+	}
+	if err := validateReview(stars, text); err != nil {
+		return nil, err
+	}
+
+	return &Review{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		MovieID:   movieID,
+		Stars:     stars,
+		Text:      text,
+		Status:    ReviewStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// GetStatus returns the moderation status (thread-safe)
+func (r *Review) GetStatus() ReviewStatus {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.Status
+}
+
+// Edit changes the stars and text; edited reviews go back to moderation
+func (r *Review) Edit(stars int, text string, now time.Time) error {
+	if err := validateReview(stars, text); err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.Stars = stars
+	r.Text = text
+	r.Status = ReviewStatusPending
+	r.ModeratedBy = ""
+	r.ModerationNote = ""
+	r.UpdatedAt = now
+	return nil
+}
+
+// Moderate approves or rejects a review; approved reviews can be taken down later
+func (r *Review) Moderate(moderatorID string, approve bool, note string, now time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.Status = ReviewStatusRejected
+	if approve {
+		r.Status = ReviewStatusApproved
+	}
+	r.ModeratedBy = moderatorID
+	r.ModerationNote = note
+	r.UpdatedAt = now
+}
+
+// validateReview checks the star range and text length
+func validateReview(stars int, text string) error {
+	if stars < MinReviewStars || stars > MaxReviewStars || len([]rune(text)) > MaxReviewTextRunes {
+		return ErrInvalidReviewData
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// MaxSaleLeadTime caps how far ahead of a show bookings can open
+const MaxSaleLeadTime = 90 * 24 * time.Hour
+
+// SaleWindow is when bookings for a show open: a lead time before each show starts, e.g. 7 days, or a fixed
+// "bookings open" time for every show. The zero window opens bookings as soon as a show is scheduled.
+type SaleWindow struct {
+	OpensBefore time.Duration `json:"opens_before,omitempty"` // Lead time before the show starts
+	OpensAt     time.Time     `json:"opens_at,omitzero"`      // Fixed opening, e.g. a blockbuster's advance sales
+}
+
+// NewSaleWindow validates a sale window; at most one of the lead time and the fixed opening may be set
+func NewSaleWindow(opensBefore time.Duration, opensAt time.Time) (SaleWindow, error) {
+	if opensBefore < 0 || opensBefore > MaxSaleLeadTime || (opensBefore > 0 && !opensAt.IsZero()) {
+		return SaleWindow{}, ErrInvalidSaleWindow
+	}
+	return SaleWindow{OpensBefore: opensBefore, OpensAt: opensAt}, nil
+}
+
+// IsZero reports whether bookings open as soon as a show is scheduled
+func (w SaleWindow) IsZero() bool {
+	return w.OpensBefore == 0 && w.OpensAt.IsZero()
+}
+
+// OpensFor returns when bookings open for a show starting at start; zero means they are open already
+func (w SaleWindow) OpensFor(start time.Time) time.Time {
+	if !w.OpensAt.IsZero() {
+		return w.OpensAt
+	}
+	if w.OpensBefore > 0 {
+		return start.Add(-w.OpensBefore)
+	}
+	return time.Time{}
+}
+
+// SetSaleWindow changes when bookings for the show open. Bookings already made are kept.
+func (s *Show) SetSaleWindow(window SaleWindow, now time.Time) {
+	s.SaleWindow = window
+	s.UpdatedAt = now
+}
+
+// SaleOpensAt returns when bookings for the show open, zero if they opened when it was scheduled. A lead
+// time follows the show when it is rescheduled.
+func (s *Show) SaleOpensAt() time.Time {
+	return s.SaleWindow.OpensFor(s.StartTime)
+}
+
+// IsOnSale checks if bookings for the show have opened
+func (s *Show) IsOnSale(now time.Time) bool {
+	opensAt := s.SaleOpensAt()
+	return opensAt.IsZero() || !now.Before(opensAt)
+}
+
+// SetSaleWindow changes when bookings open for the theatre's new shows, unless their movie has its own window
+func (t *Theatre) SetSaleWindow(window SaleWindow, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.SaleWindow = window
+	t.UpdatedAt = now
+}
+
+// GetSaleWindow returns when bookings open for the theatre's new shows
+func (t *Theatre) GetSaleWindow() SaleWindow {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.SaleWindow
+}
+
+// SetSaleWindow changes when bookings open for the movie's new shows, overriding the theatres' windows
+func (m *Movie) SetSaleWindow(window SaleWindow, now time.Time) {
+	m.SaleWindow = window
+	m.UpdatedAt = now
+}
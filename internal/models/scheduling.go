@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// SchedulingConfig shapes the start times suggested for new shows on a screen
+type SchedulingConfig struct {
+	Turnaround   time.Duration // Cleaning time kept free between one show ending and the next starting
+	SlotInterval time.Duration // Start times fall on multiples of this past the hour, e.g. every 15 minutes
+	DayStart     time.Duration // Earliest start, as a time of day
+	DayEnd       time.Duration // Shows must finish by this time of day; past 24h runs into the next morning
+}
+
+// DefaultSchedulingConfig keeps 20 minutes between shows and suggests quarter-hour starts for shows
+// running between 09:00 and 01:00
+func DefaultSchedulingConfig() SchedulingConfig {
+	return SchedulingConfig{
+		Turnaround:   20 * time.Minute,
+		SlotInterval: 15 * time.Minute,
+		DayStart:     9 * time.Hour,
+		DayEnd:       25 * time.Hour,
+	}
+}
@@ -1,18 +1,31 @@
 package models
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
 	"sync"
 
 	"github.com/google/uuid"
 )
 
+// ScreenStatus represents whether a screen can host shows
+type ScreenStatus string
+
+const (
+	ScreenStatusActive      ScreenStatus = "ACTIVE"
+	ScreenStatusMaintenance ScreenStatus = "MAINTENANCE"
+)
+
 // Screen represents a screen in a theatre
 type Screen struct {
 	ID         string           `json:"id"`
 	Name       string           `json:"name"`
 	TheatreID  string           `json:"theatre_id"`
 	Capacity   int              `json:"capacity"`
+	Status     ScreenStatus     `json:"status"`
 	Seats      map[string]*Seat `json:"seats"`
+	Aisles     map[string][]int `json:"aisles,omitempty"` // Row name -> seat numbers followed by an aisle
 	seatsMutex sync.RWMutex
 }
 
@@ -22,10 +35,49 @@ func NewScreen(name, theatreID string) *Screen {
 		ID:        uuid.New().String(),
 		Name:      name,
 		TheatreID: theatreID,
+		Status:    ScreenStatusActive,
 		Seats:     make(map[string]*Seat),
 	}
 }
 
+// Clone copies the seat layout and pricing into a new, empty screen
+func (s *Screen) Clone(name string) *Screen {
+	s.seatsMutex.RLock()
+	defer s.seatsMutex.RUnlock()
+
+	clone := NewScreen(name, s.TheatreID)
+	for _, seat := range s.Seats {
+		cloned := NewSeat(seat.RowName, seat.Number, seat.Type, seat.GetPrice())
+		cloned.GroupID = seat.GroupID
+		cloned.Section, cloned.X, cloned.Y = seat.Section, seat.X, seat.Y
+		cloned.Attributes = slices.Clone(seat.Attributes)
+		clone.AddSeat(cloned)
+	}
+	for row, after := range s.Aisles {
+		clone.SetAisles(row, after)
+	}
+	return clone
+}
+
+// IsOperational checks if the screen can host shows and bookings (thread-safe)
+func (s *Screen) IsOperational() bool {
+	s.seatsMutex.RLock()
+	defer s.seatsMutex.RUnlock()
+	return s.Status == ScreenStatusActive
+}
+
+// SetMaintenance takes the screen offline or brings it back (thread-safe)
+func (s *Screen) SetMaintenance(offline bool) {
+	s.seatsMutex.Lock()
+	defer s.seatsMutex.Unlock()
+
+	if offline {
+		s.Status = ScreenStatusMaintenance
+	} else {
+		s.Status = ScreenStatusActive
+	}
+}
+
 // AddSeat adds a seat to the screen
 func (s *Screen) AddSeat(seat *Seat) {
 	s.seatsMutex.Lock()
@@ -35,6 +87,21 @@ func (s *Screen) AddSeat(seat *Seat) {
 	s.Capacity++
 }
 
+// SetAisles records that an aisle follows each of the given seat numbers in a row
+func (s *Screen) SetAisles(row string, afterSeats []int) {
+	s.seatsMutex.Lock()
+	defer s.seatsMutex.Unlock()
+
+	if len(afterSeats) == 0 {
+		delete(s.Aisles, row)
+		return
+	}
+	if s.Aisles == nil {
+		s.Aisles = make(map[string][]int)
+	}
+	s.Aisles[row] = slices.Clone(afterSeats)
+}
+
 // GetSeat retrieves a seat by ID (thread-safe)
 func (s *Screen) GetSeat(seatID string) (*Seat, error) {
 	s.seatsMutex.RLock()
@@ -47,18 +114,29 @@ func (s *Screen) GetSeat(seatID string) (*Seat, error) {
 	return seat, nil
 }
 
-// GetAvailableSeats returns all available seats (thread-safe)
-func (s *Screen) GetAvailableSeats() []*Seat {
+// SeatIDs returns the IDs of every seat on the screen, in no particular order
+func (s *Screen) SeatIDs() []string {
+	s.seatsMutex.RLock()
+	defer s.seatsMutex.RUnlock()
+
+	seatIDs := make([]string, 0, len(s.Seats))
+	for seatID := range s.Seats {
+		seatIDs = append(seatIDs, seatID)
+	}
+	return seatIDs
+}
+
+// SeatTypes returns the distinct seat types on the screen, sorted by code
+func (s *Screen) SeatTypes() []SeatType {
 	s.seatsMutex.RLock()
 	defer s.seatsMutex.RUnlock()
 
-	var availableSeats []*Seat
+	var types []SeatType
 	for _, seat := range s.Seats {
-		if seat.IsAvailable() {
-			availableSeats = append(availableSeats, seat)
-		}
+		types = append(types, seat.Type)
 	}
-	return availableSeats
+	slices.Sort(types)
+	return slices.Compact(types)
 }
 
 // GetSeatsByType returns seats of a specific type
@@ -75,36 +153,102 @@ func (s *Screen) GetSeatsByType(seatType SeatType) []*Seat {
 	return seats
 }
 
-// BlockSeats blocks multiple seats atomically
-func (s *Screen) BlockSeats(seatIDs []string) error {
+// GroupSeats makes the seats a group that can only be booked together, e.g. a couple recliner or a box.
+// The group is named after its first and last seat, e.g. "J1-J2".
+func (s *Screen) GroupSeats(seatIDs []string) (string, error) {
 	s.seatsMutex.Lock()
 	defer s.seatsMutex.Unlock()
 
-	// First check if all seats are available
+	if len(seatIDs) < 2 {
+		return "", fmt.Errorf("%w: a group needs at least two seats", ErrInvalidSeatGroup)
+	}
+
+	seats := make([]*Seat, 0, len(seatIDs))
 	for _, seatID := range seatIDs {
 		seat, exists := s.Seats[seatID]
 		if !exists {
-			return ErrSeatNotFound
+			return "", ErrSeatNotFound
 		}
-		if !seat.IsAvailable() {
-			return ErrSeatNotAvailable
+		if seat.GroupID != "" {
+			return "", fmt.Errorf("%w: %s is already in group %s", ErrInvalidSeatGroup, seat.GetSeatNumber(), seat.GroupID)
 		}
+		if slices.Contains(seats, seat) {
+			return "", fmt.Errorf("%w: %s is listed twice", ErrInvalidSeatGroup, seat.GetSeatNumber())
+		}
+		seats = append(seats, seat)
+	}
+
+	slices.SortFunc(seats, func(a, b *Seat) int {
+		if byRow := compareRowNames(a.RowName, b.RowName); byRow != 0 {
+			return byRow
+		}
+		return a.Number - b.Number
+	})
+	groupID := seats[0].GetSeatNumber() + "-" + seats[len(seats)-1].GetSeatNumber()
+	for _, seat := range seats {
+		seat.GroupID = groupID
+	}
+	return groupID, nil
+}
+
+// ValidateSeatGroups checks the seats include every member of any group they touch (thread-safe)
+func (s *Screen) ValidateSeatGroups(seatIDs []string) error {
+	s.seatsMutex.RLock()
+	defer s.seatsMutex.RUnlock()
+	return s.checkSeatGroups(seatIDs)
+}
+
+// checkSeatGroups is ValidateSeatGroups for callers holding seatsMutex
+func (s *Screen) checkSeatGroups(seatIDs []string) error {
+	picked := make(map[string]bool, len(seatIDs))
+	grouped := false
+	for _, seatID := range seatIDs {
+		picked[seatID] = true
+		if seat, exists := s.Seats[seatID]; exists && seat.GroupID != "" {
+			grouped = true
+		}
+	}
+	if !grouped {
+		return nil
 	}
 
-	// Block all seats
 	for _, seatID := range seatIDs {
-		if err := s.Seats[seatID].Block(); err != nil {
-			// Rollback previous blocks
-			for i := 0; i < len(seatIDs); i++ {
-				if seatIDs[i] == seatID {
-					break
-				}
-				s.Seats[seatIDs[i]].Unblock()
+		seat, exists := s.Seats[seatID]
+		if !exists || seat.GroupID == "" {
+			continue
+		}
+		for _, other := range s.Seats {
+			if other.GroupID == seat.GroupID && !picked[other.ID] {
+				return fmt.Errorf("%w: %s also needs %s", ErrSeatGroupSplit, seat.GroupID, other.GetSeatNumber())
 			}
-			return err
+		}
+	}
+	return nil
+}
+
+// ValidateCompanionSeats checks every companion seat comes with a wheelchair space next to it (thread-safe)
+func (s *Screen) ValidateCompanionSeats(seatIDs []string) error {
+	s.seatsMutex.RLock()
+	defer s.seatsMutex.RUnlock()
+
+	wheelchairs := make(map[string]bool)
+	for _, seatID := range seatIDs {
+		if seat, exists := s.Seats[seatID]; exists && seat.HasAttribute(SeatAttributeWheelchair) {
+			wheelchairs[seat.RowName+":"+strconv.Itoa(seat.Number)] = true
 		}
 	}
 
+	for _, seatID := range seatIDs {
+		seat, exists := s.Seats[seatID]
+		if !exists || !seat.HasAttribute(SeatAttributeCompanion) {
+			continue
+		}
+		left := seat.RowName + ":" + strconv.Itoa(seat.Number-1)
+		right := seat.RowName + ":" + strconv.Itoa(seat.Number+1)
+		if !wheelchairs[left] && !wheelchairs[right] {
+			return fmt.Errorf("%w: %s", ErrCompanionSeatOnly, seat.GetSeatNumber())
+		}
+	}
 	return nil
 }
 
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"slices"
+	"strconv"
 	"sync"
 
 	"github.com/google/uuid"
@@ -16,6 +18,15 @@ const (
 	SeatTypeRecliner SeatType = "RECLINER"
 )
 
+// SeatAttribute flags a seat feature that matters to seat choice
+type SeatAttribute string
+
+const (
+	SeatAttributeWheelchair SeatAttribute = "WHEELCHAIR" // Space for a wheelchair user
+	SeatAttributeCompanion  SeatAttribute = "COMPANION"  // Beside a wheelchair space, for the person accompanying them
+	SeatAttributeAisle      SeatAttribute = "AISLE"      // Next to an aisle
+)
+
 // SeatStatus represents the status of a seat
 type SeatStatus string
 
@@ -23,92 +34,65 @@ const (
 	SeatStatusAvailable SeatStatus = "AVAILABLE"
 	SeatStatusBooked    SeatStatus = "BOOKED"
 	SeatStatusBlocked   SeatStatus = "BLOCKED"
+
+	// Seats a theatre holds back from sale for one show; see Show.WithholdSeats
+	SeatStatusHouse        SeatStatus = "HOUSE"         // Management holds, e.g. for press or staff
+	SeatStatusBlockedAdmin SeatStatus = "BLOCKED_ADMIN" // Broken seats and other admin blackouts
 )
 
-// Seat represents a seat in a screen with thread-safe operations
+// IsWithheld reports whether the status takes a seat off sale by the theatre's decision
+func (s SeatStatus) IsWithheld() bool {
+	return s == SeatStatusHouse || s == SeatStatusBlockedAdmin
+}
+
+// Seat represents a seat in a screen with thread-safe operations. Whether it is taken differs from show
+// to show, so its status is kept by each show - see Show.SeatStatus.
 type Seat struct {
-	ID      string     `json:"id"`
-	RowName string     `json:"row_name"`
-	Number  int        `json:"number"`
-	Type    SeatType   `json:"type"`
-	Status  SeatStatus `json:"status"`
-	Price   float64    `json:"price"`
-	mutex   sync.RWMutex
+	ID      string   `json:"id"`
+	RowName string   `json:"row_name"`
+	Number  int      `json:"number"`
+	Type    SeatType `json:"type"`
+	Price   Money    `json:"price"`
+	GroupID string   `json:"group_id,omitempty"` // Seats sharing a group, e.g. a couple recliner, are booked together
+	Section string   `json:"section,omitempty"`  // Part of the auditorium, e.g. STALLS or BALCONY; empty for a flat layout
+	// Place on the screen's grid, counted from 1: X is the column from the left, aisles and missing seats
+	// included, and Y the row from the front. Zero for seats laid out before screens had coordinates.
+	X int `json:"x,omitempty"`
+	Y int `json:"y,omitempty"`
+	// Accessibility and placement flags, set from the screen layout
+	Attributes []SeatAttribute `json:"attributes,omitempty"`
+	mutex      sync.RWMutex
 }
 
 // NewSeat creates a new seat
-func NewSeat(rowName string, number int, seatType SeatType, price float64) *Seat {
+func NewSeat(rowName string, number int, seatType SeatType, price Money) *Seat {
 	return &Seat{
 		ID:      uuid.New().String(),
 		RowName: rowName,
 		Number:  number,
 		Type:    seatType,
-		Status:  SeatStatusAvailable,
 		Price:   price,
 	}
 }
 
-// IsAvailable checks if the seat is available for booking (thread-safe)
-func (s *Seat) IsAvailable() bool {
+// GetPrice returns the seat price
+func (s *Seat) GetPrice() Money {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
-	return s.Status == SeatStatusAvailable
-}
-
-// Block blocks the seat temporarily (thread-safe)
-func (s *Seat) Block() error {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
-	if s.Status != SeatStatusAvailable {
-		return ErrSeatNotAvailable
-	}
-
-	s.Status = SeatStatusBlocked
-	return nil
-}
-
-// Book books the seat (thread-safe)
-func (s *Seat) Book() error {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
-	if s.Status != SeatStatusBlocked {
-		return ErrSeatNotBlocked
-	}
-
-	s.Status = SeatStatusBooked
-	return nil
-}
-
-// Unblock unblocks the seat (thread-safe)
-func (s *Seat) Unblock() error {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
-	if s.Status != SeatStatusBlocked {
-		return ErrSeatNotBlocked
-	}
-
-	s.Status = SeatStatusAvailable
-	return nil
+	return s.Price
 }
 
-// GetStatus returns the current status (thread-safe)
-func (s *Seat) GetStatus() SeatStatus {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-	return s.Status
+// HasAttribute checks if the seat has the given flag
+func (s *Seat) HasAttribute(attribute SeatAttribute) bool {
+	return slices.Contains(s.Attributes, attribute)
 }
 
-// GetPrice returns the seat price
-func (s *Seat) GetPrice() float64 {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-	return s.Price
+// IsAccessible checks if the seat is a wheelchair space or its companion seat, kept for those who need them
+func (s *Seat) IsAccessible() bool {
+	return s.HasAttribute(SeatAttributeWheelchair) || s.HasAttribute(SeatAttributeCompanion)
 }
 
 // GetSeatNumber returns formatted seat number
 func (s *Seat) GetSeatNumber() string {
-	return s.RowName + string(rune('0'+s.Number))
+	return s.RowName + strconv.Itoa(s.Number)
 }
@@ -0,0 +1,171 @@
+package models
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SeatHoldStatus represents the lifecycle of a seat hold
+type SeatHoldStatus string
+
+const (
+	SeatHoldStatusActive   SeatHoldStatus = "ACTIVE"
+	SeatHoldStatusConsumed SeatHoldStatus = "CONSUMED"
+	SeatHoldStatusReleased SeatHoldStatus = "RELEASED"
+	SeatHoldStatusExpired  SeatHoldStatus = "EXPIRED"
+)
+
+// SeatHoldDuration is how long a user may sit on blocked seats before booking, unless the show's payment window is
+// shorter or the platform is configured with other BookingTimeouts
+const SeatHoldDuration = 10 * time.Minute
+
+// MaxSeatHoldExtensions caps how often a user can extend the same hold
+const MaxSeatHoldExtensions = 1
+
+// SeatHold ties temporarily blocked seats to the user who blocked them
+type SeatHold struct {
+	ID         string         `json:"id"`
+	UserID     string         `json:"user_id"`
+	ShowID     string         `json:"show_id"`
+	SeatIDs    []string       `json:"seat_ids"`
+	Status     SeatHoldStatus `json:"status"`
+	ExpiresAt  time.Time      `json:"expires_at"`
+	TTL        time.Duration  `json:"ttl,omitempty"` // Granted at creation and again on each extension; zero is SeatHoldDuration
+	Extensions int            `json:"extensions"`
+	BookingID  string         `json:"booking_id,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	mutex      sync.RWMutex
+}
+
+// NewSeatHold creates an active hold for a user's seats that expires after ttl; zero uses SeatHoldDuration
+func NewSeatHold(userID, showID string, seatIDs []string, ttl time.Duration, now time.Time) (*SeatHold, error) {
+	if userID == "" || showID == "" || len(seatIDs) == 0 {
+		return nil, ErrInvalidSeatHoldData
+	}
+	if ttl <= 0 {
+		ttl = SeatHoldDuration
+	}
+
+	return &SeatHold{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		ShowID:    showID,
+		SeatIDs:   seatIDs,
+		Status:    SeatHoldStatusActive,
+		ExpiresAt: now.Add(ttl),
+		TTL:       ttl,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsExpired checks if an active hold has run past its TTL (thread-safe)
+func (h *SeatHold) IsExpired(now time.Time) bool {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.Status == SeatHoldStatusActive && now.After(h.ExpiresAt)
+}
+
+// GetStatus returns the current hold status (thread-safe)
+func (h *SeatHold) GetStatus() SeatHoldStatus {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return h.Status
+}
+
+// Extend pushes the expiry out by another TTL
+func (h *SeatHold) Extend(now time.Time) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err := h.checkActive(now); err != nil {
+		return err
+	}
+
+	if h.Extensions >= MaxSeatHoldExtensions {
+		return ErrSeatHoldExtensionLimit
+	}
+
+	h.Extensions++
+	ttl := h.TTL
+	if ttl <= 0 {
+		ttl = SeatHoldDuration // Holds saved before TTLs were recorded
+	}
+	h.ExpiresAt = now.Add(ttl)
+	h.UpdatedAt = now
+	return nil
+}
+
+// Consume hands the held seats over to a booking
+func (h *SeatHold) Consume(bookingID string, now time.Time) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err := h.checkActive(now); err != nil {
+		return err
+	}
+
+	h.Status = SeatHoldStatusConsumed
+	h.BookingID = bookingID
+	h.UpdatedAt = now
+	return nil
+}
+
+// RevertConsume undoes Consume for a booking that couldn't be saved: the hold is active again, and its
+// seats the user's until it expires as before
+func (h *SeatHold) RevertConsume(bookingID string, now time.Time) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.Status != SeatHoldStatusConsumed || h.BookingID != bookingID {
+		return
+	}
+	h.Status = SeatHoldStatusActive
+	h.BookingID = ""
+	h.UpdatedAt = now
+}
+
+// Release gives the seats up voluntarily
+func (h *SeatHold) Release(now time.Time) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.Status != SeatHoldStatusActive {
+		return ErrSeatHoldNotActive
+	}
+
+	h.Status = SeatHoldStatusReleased
+	h.UpdatedAt = now
+	return nil
+}
+
+// Expire marks an active hold as expired
+func (h *SeatHold) Expire(now time.Time) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.Status != SeatHoldStatusActive {
+		return ErrSeatHoldNotActive
+	}
+
+	h.Status = SeatHoldStatusExpired
+	h.UpdatedAt = now
+	return nil
+}
+
+// checkActive verifies the hold can still be used - callers must hold the mutex
+func (h *SeatHold) checkActive(now time.Time) error {
+	if h.Status != SeatHoldStatusActive {
+		return ErrSeatHoldNotActive
+	}
+
+	if now.After(h.ExpiresAt) {
+		return ErrSeatHoldExpired
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package models
+
+import "maps"
+
+// SeatStatus returns the seat's status at this show; a seat no hold or booking has taken is available.
+// Seats the theatre holds back are not included - see HouseSeatStatuses.
+func (s *Show) SeatStatus(seatID string) SeatStatus {
+	s.seatsMutex.RLock()
+	defer s.seatsMutex.RUnlock()
+	return s.seatStatus(seatID)
+}
+
+// seatStatus is SeatStatus for callers holding seatsMutex
+func (s *Show) seatStatus(seatID string) SeatStatus {
+	if status, taken := s.Seats[seatID]; taken {
+		return status
+	}
+	return SeatStatusAvailable
+}
+
+// IsSeatAvailable checks if no hold or booking of this show has the seat
+func (s *Show) IsSeatAvailable(seatID string) bool {
+	return s.SeatStatus(seatID) == SeatStatusAvailable
+}
+
+// SeatStatuses returns a copy of the show's taken seats and their statuses, for overlaying on a seat map
+func (s *Show) SeatStatuses() map[string]SeatStatus {
+	s.seatsMutex.RLock()
+	defer s.seatsMutex.RUnlock()
+	return maps.Clone(s.Seats)
+}
+
+// SeatMap lays out the show's screen with each seat's status at this show, held back seats included
+func (s *Show) SeatMap(screen *Screen) *SeatMap {
+	seatMap := screen.GetSeatMap()
+	seatMap.ShowID = s.ID
+	seatMap.ApplyStatuses(s.SeatStatuses())
+	seatMap.MarkSeats(s.HouseSeatStatuses())
+	return seatMap
+}
+
+// BlockSeats blocks seats of the show's screen atomically: all of them or, if any is missing, taken or
+// splits a seat group, none
+func (s *Show) BlockSeats(screen *Screen, seatIDs []string) error {
+	if err := screen.ValidateSeatGroups(seatIDs); err != nil {
+		return err
+	}
+
+	s.seatsMutex.Lock()
+	defer s.seatsMutex.Unlock()
+
+	for _, seatID := range seatIDs {
+		if _, err := screen.GetSeat(seatID); err != nil {
+			return err
+		}
+		if s.seatStatus(seatID) != SeatStatusAvailable {
+			return ErrSeatNotAvailable
+		}
+	}
+
+	for _, seatID := range seatIDs {
+		s.setSeat(seatID, SeatStatusBlocked)
+	}
+	return nil
+}
+
+// BookSeat books a blocked seat
+func (s *Show) BookSeat(seatID string) error {
+	return s.moveSeat(seatID, SeatStatusBlocked, SeatStatusBooked, ErrSeatNotBlocked)
+}
+
+// UnbookSeat puts a booked seat back to blocked, undoing BookSeat for a confirmation that rolled back
+func (s *Show) UnbookSeat(seatID string) error {
+	return s.moveSeat(seatID, SeatStatusBooked, SeatStatusBlocked, ErrSeatNotAvailable)
+}
+
+// UnblockSeat makes a blocked seat available again
+func (s *Show) UnblockSeat(seatID string) error {
+	return s.moveSeat(seatID, SeatStatusBlocked, SeatStatusAvailable, ErrSeatNotBlocked)
+}
+
+// ReleaseSeat makes a blocked or booked seat available again
+func (s *Show) ReleaseSeat(seatID string) error {
+	s.seatsMutex.Lock()
+	defer s.seatsMutex.Unlock()
+
+	if s.seatStatus(seatID) == SeatStatusAvailable {
+		return ErrSeatNotBlocked
+	}
+	s.setSeat(seatID, SeatStatusAvailable)
+	return nil
+}
+
+// RestoreSeat puts a seat back to a status it had before, e.g. when the release of a cancelled booking's
+// seats rolls back
+func (s *Show) RestoreSeat(seatID string, status SeatStatus) {
+	s.seatsMutex.Lock()
+	defer s.seatsMutex.Unlock()
+	s.setSeat(seatID, status)
+}
+
+// moveSeat changes a seat from one status to another, failing with err if it isn't in the first
+func (s *Show) moveSeat(seatID string, from, to SeatStatus, err error) error {
+	s.seatsMutex.Lock()
+	defer s.seatsMutex.Unlock()
+
+	if s.seatStatus(seatID) != from {
+		return err
+	}
+	s.setSeat(seatID, to)
+	return nil
+}
+
+// setSeat records a seat's status, dropping available seats; callers must hold seatsMutex
+func (s *Show) setSeat(seatID string, status SeatStatus) {
+	if status == SeatStatusAvailable {
+		delete(s.Seats, seatID)
+		return
+	}
+	if s.Seats == nil {
+		s.Seats = make(map[string]SeatStatus)
+	}
+	s.Seats[seatID] = status
+}
+
+// IsSoldOut reports whether every seat of the show's screen is booked; held seats can still come back on sale
+func (s *Show) IsSoldOut(screen *Screen) bool {
+	s.seatsMutex.RLock()
+	defer s.seatsMutex.RUnlock()
+
+	seatIDs := screen.SeatIDs()
+	for _, seatID := range seatIDs {
+		if s.seatStatus(seatID) != SeatStatusBooked {
+			return false
+		}
+	}
+	return len(seatIDs) > 0
+}
@@ -0,0 +1,202 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SeatMap is a screen's seats laid out row by row for a seat-picker UI
+type SeatMap struct {
+	ScreenID  string       `json:"screen_id"`
+	ShowID    string       `json:"show_id,omitempty"` // Set when the map reflects a show's bookings
+	Rows      []SeatMapRow `json:"rows"`
+	Capacity  int          `json:"capacity"`
+	Available int          `json:"available"`
+}
+
+// SeatMapRow is one row in front-to-back order; cells run left to right
+type SeatMapRow struct {
+	Name    string        `json:"name"`
+	Section string        `json:"section,omitempty"` // e.g. BALCONY; empty for a flat layout
+	Y       int           `json:"y,omitempty"`       // The row's place from the front, as on its seats
+	Cells   []SeatMapCell `json:"cells"`
+}
+
+// SeatMapCell is a seat, an aisle gap between seats, or a gap where a seat is missing
+type SeatMapCell struct {
+	Aisle  bool       `json:"aisle,omitempty"`
+	Gap    bool       `json:"gap,omitempty"` // No seat at this number, e.g. for a pillar; Number is set
+	SeatID string     `json:"seat_id,omitempty"`
+	Label  string     `json:"label,omitempty"` // e.g. "C7"
+	Number int        `json:"number,omitempty"`
+	Type   SeatType   `json:"type,omitempty"`
+	Status SeatStatus `json:"status,omitempty"`
+	Price  Money      `json:"price,omitzero"`
+	Group  string     `json:"group,omitempty"` // Seats with the same group can only be picked together
+	X      int        `json:"x,omitempty"`     // The seat's column from the left
+	// Wheelchair, companion and aisle flags
+	Attributes []SeatAttribute `json:"attributes,omitempty"`
+}
+
+// IsSeat reports whether the cell holds a seat rather than an aisle or a gap
+func (c SeatMapCell) IsSeat() bool {
+	return c.SeatID != ""
+}
+
+// GetSeatMap returns the seats ordered by row then seat number, with aisle gaps and gaps for missing seats.
+// Rows run front to back by their Y coordinate, or by name for screens laid out without coordinates (thread-safe).
+// Every seat is available; Show.SeatMap marks the ones taken at a show.
+func (s *Screen) GetSeatMap() *SeatMap {
+	s.seatsMutex.RLock()
+	defer s.seatsMutex.RUnlock()
+
+	byRow := make(map[string][]*Seat)
+	for _, seat := range s.Seats {
+		byRow[seat.RowName] = append(byRow[seat.RowName], seat)
+	}
+
+	rowNames := make([]string, 0, len(byRow))
+	for name := range byRow {
+		rowNames = append(rowNames, name)
+	}
+	slices.SortFunc(rowNames, func(a, b string) int {
+		if ya, yb := byRow[a][0].Y, byRow[b][0].Y; ya != 0 && yb != 0 && ya != yb {
+			return ya - yb
+		}
+		return compareRowNames(a, b)
+	})
+
+	seatMap := &SeatMap{ScreenID: s.ID, Rows: make([]SeatMapRow, 0, len(rowNames))}
+	for _, name := range rowNames {
+		seats := byRow[name]
+		slices.SortFunc(seats, func(a, b *Seat) int { return a.Number - b.Number })
+
+		row := SeatMapRow{Name: name, Section: seats[0].Section, Y: seats[0].Y}
+		previous := 0
+		for _, seat := range seats {
+			// Walk the numbers since the previous seat: an aisle may follow any of them, and any skipped is missing
+			for number := previous; number < seat.Number; number++ {
+				if number > 0 && s.hasAisleAfter(name, number) {
+					row.Cells = append(row.Cells, SeatMapCell{Aisle: true})
+				}
+				if number+1 < seat.Number {
+					row.Cells = append(row.Cells, SeatMapCell{Gap: true, Number: number + 1})
+				}
+			}
+			previous = seat.Number
+
+			seatMap.Available++
+			row.Cells = append(row.Cells, SeatMapCell{
+				SeatID:     seat.ID,
+				Label:      seat.GetSeatNumber(),
+				Number:     seat.Number,
+				Type:       seat.Type,
+				Status:     SeatStatusAvailable,
+				Price:      seat.GetPrice(),
+				Group:      seat.GroupID,
+				X:          seat.X,
+				Attributes: seat.Attributes,
+			})
+		}
+
+		seatMap.Capacity += len(seats)
+		seatMap.Rows = append(seatMap.Rows, row)
+	}
+
+	return seatMap
+}
+
+// ApplyStatuses overrides every seat's status, e.g. with one show's bookings and holds.
+// Seats missing from statuses are available.
+func (m *SeatMap) ApplyStatuses(statuses map[string]SeatStatus) {
+	m.Available = 0
+	for r := range m.Rows {
+		for c := range m.Rows[r].Cells {
+			cell := &m.Rows[r].Cells[c]
+			if !cell.IsSeat() {
+				continue
+			}
+
+			cell.Status = SeatStatusAvailable
+			if status, taken := statuses[cell.SeatID]; taken {
+				cell.Status = status
+			}
+			if cell.Status == SeatStatusAvailable {
+				m.Available++
+			}
+		}
+	}
+}
+
+// MarkSeats overrides the status of the listed seats only, e.g. with a show's held back seats
+func (m *SeatMap) MarkSeats(statuses map[string]SeatStatus) {
+	if len(statuses) == 0 {
+		return
+	}
+	for r := range m.Rows {
+		for c := range m.Rows[r].Cells {
+			cell := &m.Rows[r].Cells[c]
+			status, marked := statuses[cell.SeatID]
+			if !cell.IsSeat() || !marked || status == cell.Status {
+				continue
+			}
+
+			if cell.Status == SeatStatusAvailable {
+				m.Available--
+			} else if status == SeatStatusAvailable {
+				m.Available++
+			}
+			cell.Status = status
+		}
+	}
+}
+
+// SeatIDs resolves seat labels such as "F7" (any case) to seat IDs
+func (m *SeatMap) SeatIDs(labels []string) ([]string, error) {
+	byLabel := make(map[string]string)
+	for _, row := range m.Rows {
+		for _, cell := range row.Cells {
+			if cell.IsSeat() {
+				byLabel[cell.Label] = cell.SeatID
+			}
+		}
+	}
+
+	seatIDs := make([]string, 0, len(labels))
+	for _, label := range labels {
+		seatID, ok := byLabel[strings.ToUpper(label)]
+		if !ok {
+			return nil, fmt.Errorf("%w: no seat %s", ErrSeatNotFound, label)
+		}
+		seatIDs = append(seatIDs, seatID)
+	}
+	return seatIDs, nil
+}
+
+// Reprice replaces every seat's listed price with what price returns for it, e.g. the show's price
+// after format surcharges and pricing rules
+func (m *SeatMap) Reprice(price func(cell SeatMapCell) Money) {
+	for r := range m.Rows {
+		for c := range m.Rows[r].Cells {
+			cell := &m.Rows[r].Cells[c]
+			if cell.IsSeat() {
+				cell.Price = price(*cell)
+			}
+		}
+	}
+}
+
+// hasAisleAfter reports whether an aisle runs between seat number and number+1 of a row.
+// Callers must hold seatsMutex.
+func (s *Screen) hasAisleAfter(row string, number int) bool {
+	return slices.Contains(s.Aisles[row], number)
+}
+
+// compareRowNames orders rows like a cinema: A..Z, then AA, AB..
+func compareRowNames(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// MaxPasswordBytes is as much of a password as bcrypt reads; longer ones are refused rather than truncated
+const MaxPasswordBytes = 72
+
+// AuthConfig controls password rules and how long a login lasts
+type AuthConfig struct {
+	SessionTTL        time.Duration // How long a session token stays valid after login
+	MinPasswordLength int           // Characters, not bytes
+}
+
+// DefaultAuthConfig keeps users signed in for a day and asks for eight-character passwords
+func DefaultAuthConfig() AuthConfig {
+	return AuthConfig{
+		SessionTTL:        24 * time.Hour,
+		MinPasswordLength: 8,
+	}
+}
+
+// ValidatePassword checks a new password against the configured rules
+func (c AuthConfig) ValidatePassword(password string) error {
+	if utf8.RuneCountInString(password) < c.MinPasswordLength {
+		return ErrWeakPassword
+	}
+	if len(password) > MaxPasswordBytes {
+		return ErrPasswordTooLong
+	}
+	return nil
+}
+
+// Credential holds a user's password hash, kept apart from User so it never leaves through the API
+type Credential struct {
+	UserID       string    `json:"user_id"`
+	PasswordHash string    `json:"password_hash"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Session is a signed-in user. The ID is a hash of the bearer token, so a leaked session store can't be replayed.
+type Session struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewSession creates a session for the user that lasts ttl
+func NewSession(id, userID string, ttl time.Duration, now time.Time) *Session {
+	return &Session{
+		ID:        id,
+		UserID:    userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+// IsExpired checks if the session can no longer authenticate requests
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SettlementConfig sets the platform's cut of theatre ticket sales; the zero value takes nothing
+type SettlementConfig struct {
+	CommissionPercent float64 `json:"commission_percent"` // Of seat prices; convenience fees and GST stay with the platform
+}
+
+// DefaultSettlementConfig keeps a 10% commission
+func DefaultSettlementConfig() SettlementConfig {
+	return SettlementConfig{CommissionPercent: 10}
+}
+
+// SettlementStatus represents whether a theatre has been paid out
+type SettlementStatus string
+
+const (
+	SettlementStatusPending SettlementStatus = "PENDING"
+	SettlementStatusPaid    SettlementStatus = "PAID"
+)
+
+// Settlement is what the platform owes a theatre partner for the shows of one period.
+// Coupons are platform promotions, so the theatre is credited full seat prices whatever discount the customer got.
+type Settlement struct {
+	ID                string           `json:"id"`
+	TheatreID         string           `json:"theatre_id"`
+	PeriodStart       time.Time        `json:"period_start"` // Shows starting from here...
+	PeriodEnd         time.Time        `json:"period_end"`   // ...up to, not including, here
+	CommissionPercent float64          `json:"commission_percent"`
+	BookingIDs        []string         `json:"booking_ids"` // Confirmed bookings settled
+	Seats             int              `json:"seats"`
+	TicketSales       Money            `json:"ticket_sales"` // Seat prices of the settled bookings
+	Commission        Money            `json:"commission"`
+	Payable           Money            `json:"payable"` // Ticket sales less commission
+	Status            SettlementStatus `json:"status"`
+	PayoutReference   string           `json:"payout_reference,omitempty"` // Bank transfer or UTR number, once paid
+	PaidAt            *time.Time       `json:"paid_at,omitempty"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+}
+
+// NewSettlement starts an empty pending settlement for a theatre's period
+func NewSettlement(theatreID string, periodStart, periodEnd time.Time, config SettlementConfig, currency string, now time.Time) (*Settlement, error) {
+	if theatreID == "" || !periodEnd.After(periodStart) || config.CommissionPercent < 0 || config.CommissionPercent > 100 {
+		return nil, ErrInvalidSettlementData
+	}
+
+	return &Settlement{
+		ID:                uuid.New().String(),
+		TheatreID:         theatreID,
+		PeriodStart:       periodStart,
+		PeriodEnd:         periodEnd,
+		CommissionPercent: config.CommissionPercent,
+		BookingIDs:        []string{},
+		TicketSales:       ZeroMoney(currency),
+		Commission:        ZeroMoney(currency),
+		Payable:           ZeroMoney(currency),
+		Status:            SettlementStatusPending,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}, nil
+}
+
+// AddBooking credits a confirmed booking's seat prices; bookings in another currency are left out
+func (s *Settlement) AddBooking(booking *Booking) bool {
+	subtotal := booking.SubtotalAmount
+	if booking.GetStatus() != BookingStatusConfirmed || !subtotal.SameCurrency(s.TicketSales) {
+		return false
+	}
+
+	s.BookingIDs = append(s.BookingIDs, booking.ID)
+	s.Seats += len(booking.SeatIDs)
+	s.TicketSales = s.TicketSales.Add(subtotal)
+	s.Commission = s.TicketSales.Percent(s.CommissionPercent)
+	s.Payable = s.TicketSales.Sub(s.Commission)
+	return true
+}
+
+// Overlaps reports whether the settlement covers any part of [start, end)
+func (s *Settlement) Overlaps(start, end time.Time) bool {
+	return s.PeriodStart.Before(end) && start.Before(s.PeriodEnd)
+}
+
+// MarkPaid records the payout to the theatre
+func (s *Settlement) MarkPaid(reference string, now time.Time) error {
+	if reference == "" {
+		return ErrInvalidSettlementData
+	}
+	if s.Status == SettlementStatusPaid {
+		return ErrSettlementAlreadyPaid
+	}
+
+	s.Status = SettlementStatusPaid
+	s.PayoutReference = reference
+	s.PaidAt = &now
+	s.UpdatedAt = now
+	return nil
+}
@@ -1,82 +1,244 @@
 package models
 
 import (
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ShowStatus represents whether a show will still run, or has run and been closed out
+type ShowStatus string
+
+const (
+	ShowStatusScheduled ShowStatus = "SCHEDULED"
+	ShowStatusCancelled ShowStatus = "CANCELLED"
+	ShowStatusCompleted ShowStatus = "COMPLETED" // Ended and closed out; its summary is final
+)
+
 // Show represents a movie show at a specific theatre and time
 type Show struct {
-	ID        string    `json:"id"`
-	MovieID   string    `json:"movie_id"`
-	TheatreID string    `json:"theatre_id"`
-	ScreenID  string    `json:"screen_id"`
-	StartTime time.Time `json:"start_time"`
-	EndTime   time.Time `json:"end_time"`
-	BasePrice float64   `json:"base_price"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-}
-
-// NewShow creates a new show with validation
-func NewShow(movieID, theatreID, screenID string, startTime time.Time, basePrice float64, movieDuration time.Duration) (*Show, error) {
-	if movieID == "" || theatreID == "" || screenID == "" || basePrice <= 0 {
+	ID              string                `json:"id"`
+	EventType       EventType             `json:"event_type"`
+	MovieID         string                `json:"movie_id,omitempty"` // Set for movie shows
+	EventID         string                `json:"event_id,omitempty"` // Set for live events
+	TheatreID       string                `json:"theatre_id"`
+	ScreenID        string                `json:"screen_id"`
+	Format          ShowFormat            `json:"format,omitempty"`   // Movie shows only; 2D unless set
+	Language        Language              `json:"language,omitempty"` // May differ from the movie's for dubbed screenings
+	StartTime       time.Time             `json:"start_time"`
+	EndTime         time.Time             `json:"end_time"`
+	BasePrice       Money                 `json:"base_price"`
+	FormatSurcharge Money                 `json:"format_surcharge,omitzero"` // Added to every seat's price
+	Status          ShowStatus            `json:"status"`
+	CancelReason    string                `json:"cancel_reason,omitempty"`
+	BookingTimeout  time.Duration         `json:"booking_timeout,omitempty"` // Payment window for its bookings; zero is the platform default
+	HouseSeats      map[string]*HouseSeat `json:"house_seats,omitempty"`     // Seats held back from sale, by seat ID
+	Seats           map[string]SeatStatus `json:"seats,omitempty"`           // Seats its holds and bookings have taken, by seat ID; the rest are available
+	SaleWindow      SaleWindow            `json:"sale_window,omitzero"`      // When bookings open; zero when scheduled
+	Summary         *ShowSummary          `json:"summary,omitempty"`         // Set once, when the show is closed out
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+	seatsMutex      sync.RWMutex
+}
+
+// NewShow creates a new movie show with validation
+func NewShow(movieID, theatreID, screenID string, startTime time.Time, basePrice Money, movieDuration time.Duration, now time.Time) (*Show, error) {
+	if movieID == "" {
+		return nil, ErrInvalidShowData
+	}
+
+	show, err := newShow(EventTypeMovie, theatreID, screenID, startTime, basePrice, movieDuration, now)
+	if err != nil {
+		return nil, err
+	}
+	show.MovieID = movieID
+	show.Format = ShowFormat2D
+	return show, nil
+}
+
+// NewEventShow creates a new show for a live event with validation
+func NewEventShow(event *Event, theatreID, screenID string, startTime time.Time, basePrice Money, now time.Time) (*Show, error) {
+	if event == nil || !event.Type.IsLive() {
+		return nil, ErrInvalidShowData
+	}
+
+	show, err := newShow(event.Type, theatreID, screenID, startTime, basePrice, event.Duration, now)
+	if err != nil {
+		return nil, err
+	}
+	show.EventID = event.ID
+	show.Language = event.Language
+	return show, nil
+}
+
+// newShow validates and builds the parts of a show common to movies and live events
+func newShow(eventType EventType, theatreID, screenID string, startTime time.Time, basePrice Money, duration time.Duration, now time.Time) (*Show, error) {
+	if theatreID == "" || screenID == "" || !basePrice.IsPositive() {
 		return nil, ErrInvalidShowData
 	}
 
-	if startTime.Before(time.Now()) {
+	if startTime.Before(now) {
 		return nil, ErrInvalidShowTime
 	}
 
-	endTime := startTime.Add(movieDuration)
+	endTime := startTime.Add(duration)
 
 	return &Show{
 		ID:        uuid.New().String(),
-		MovieID:   movieID,
+		EventType: eventType,
 		TheatreID: theatreID,
 		ScreenID:  screenID,
 		StartTime: startTime,
 		EndTime:   endTime,
 		BasePrice: basePrice,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Status:    ShowStatusScheduled,
+		CreatedAt: now,
+		UpdatedAt: now,
 	}, nil
 }
 
+// SetFormat sets how and in which language a movie show screens, with the per-seat surcharge for the format
+func (s *Show) SetFormat(format ShowFormat, language Language, surcharge Money, now time.Time) error {
+	if !s.IsMovie() || !format.IsValid() || language == "" {
+		return ErrInvalidShowData
+	}
+	if surcharge.IsNegative() || !surcharge.SameCurrency(s.BasePrice) {
+		return ErrInvalidShowData
+	}
+
+	s.Format = format
+	s.Language = language
+	s.FormatSurcharge = surcharge
+	s.UpdatedAt = now
+	return nil
+}
+
+// SetBookingTimeout changes how long new bookings for the show wait for payment, e.g. 5 minutes for a
+// blockbuster opening; zero restores the default. Bookings and holds already made keep their expiry.
+func (s *Show) SetBookingTimeout(timeout time.Duration, now time.Time) error {
+	if err := ValidateBookingTimeout(timeout); err != nil {
+		return err
+	}
+
+	s.BookingTimeout = timeout
+	s.UpdatedAt = now
+	return nil
+}
+
+// PaymentWindow is how long a booking for this show may stay unpaid, falling back on the platform's defaults
+func (s *Show) PaymentWindow(defaults BookingTimeouts) time.Duration {
+	if s.BookingTimeout > 0 {
+		return s.BookingTimeout
+	}
+	return defaults.PaymentWindow()
+}
+
+// SeatHoldWindow is how long seats of this show may stay held; a hold never outlasts the payment window
+func (s *Show) SeatHoldWindow(defaults BookingTimeouts) time.Duration {
+	return min(defaults.HoldDuration(), s.PaymentWindow(defaults))
+}
+
+// PriceFor returns what one seat costs at this show, including any format surcharge
+func (s *Show) PriceFor(seat *Seat) Money {
+	price := seat.GetPrice()
+	if s.FormatSurcharge.IsPositive() && s.FormatSurcharge.SameCurrency(price) {
+		price = price.Add(s.FormatSurcharge)
+	}
+	return price
+}
+
 // IsActive checks if the show is currently active
-func (s *Show) IsActive() bool {
-	now := time.Now()
+func (s *Show) IsActive(now time.Time) bool {
 	return now.After(s.StartTime) && now.Before(s.EndTime)
 }
 
 // IsUpcoming checks if the show is scheduled for the future
-func (s *Show) IsUpcoming() bool {
-	return time.Now().Before(s.StartTime)
+func (s *Show) IsUpcoming(now time.Time) bool {
+	return now.Before(s.StartTime)
 }
 
 // IsCompleted checks if the show has ended
-func (s *Show) IsCompleted() bool {
-	return time.Now().After(s.EndTime)
+func (s *Show) IsCompleted(now time.Time) bool {
+	return now.After(s.EndTime)
+}
+
+// IsMovie checks if the show screens a movie rather than hosting a live event
+func (s *Show) IsMovie() bool {
+	return s.EventType == EventTypeMovie
 }
 
-// CanBeBooked checks if the show can still be booked
-func (s *Show) CanBeBooked() bool {
+// ListingID returns the movie or live event the show is for
+func (s *Show) ListingID() string {
+	if s.IsMovie() {
+		return s.MovieID
+	}
+	return s.EventID
+}
+
+// IsCancelled checks if the show has been called off
+func (s *Show) IsCancelled() bool {
+	return s.Status == ShowStatusCancelled
+}
+
+// CanBeBooked checks if bookings for the show have opened and it can still be booked
+func (s *Show) CanBeBooked(now time.Time) bool {
+	if s.IsCancelled() || !s.IsOnSale(now) {
+		return false
+	}
+
 	// Allow booking until 30 minutes after start time
 	bookingCutoff := s.StartTime.Add(30 * time.Minute)
-	return time.Now().Before(bookingCutoff)
+	return now.Before(bookingCutoff)
 }
 
 // UpdateShow updates show information
-func (s *Show) UpdateShow(startTime time.Time, basePrice float64, movieDuration time.Duration) error {
-	if startTime.Before(time.Now()) || basePrice <= 0 {
+func (s *Show) UpdateShow(startTime time.Time, basePrice Money, movieDuration time.Duration, now time.Time) error {
+	if startTime.Before(now) || !basePrice.IsPositive() {
 		return ErrInvalidShowData
 	}
 
 	s.StartTime = startTime
 	s.EndTime = startTime.Add(movieDuration)
 	s.BasePrice = basePrice
-	s.UpdatedAt = time.Now()
+	s.UpdatedAt = now
+	return nil
+}
+
+// Cancel calls off a show that hasn't finished yet
+func (s *Show) Cancel(reason string, now time.Time) error {
+	if s.IsCancelled() {
+		return ErrShowCancelled
+	}
+
+	if s.IsCompleted(now) {
+		return ErrShowAlreadyStarted
+	}
+
+	s.Status = ShowStatusCancelled
+	s.CancelReason = reason
+	s.UpdatedAt = now
+	return nil
+}
+
+// Reschedule moves an upcoming show to a new start time, keeping its duration
+func (s *Show) Reschedule(startTime, now time.Time) error {
+	if s.IsCancelled() {
+		return ErrShowCancelled
+	}
+
+	if !s.IsUpcoming(now) {
+		return ErrShowAlreadyStarted
+	}
+
+	if !startTime.After(now) {
+		return ErrInvalidShowTime
+	}
+
+	duration := s.GetDuration()
+	s.StartTime = startTime
+	s.EndTime = startTime.Add(duration)
+	s.UpdatedAt = now
 	return nil
 }
 
@@ -86,9 +248,9 @@ func (s *Show) GetDuration() time.Duration {
 }
 
 // TimeUntilStart returns duration until show starts
-func (s *Show) TimeUntilStart() time.Duration {
-	if s.IsUpcoming() {
-		return s.StartTime.Sub(time.Now())
+func (s *Show) TimeUntilStart(now time.Time) time.Duration {
+	if s.IsUpcoming(now) {
+		return s.StartTime.Sub(now)
 	}
 	return 0
 }
@@ -0,0 +1,38 @@
+package models
+
+// ShowFormat is how a movie is projected; premium formats carry a per-seat surcharge
+type ShowFormat string
+
+const (
+	ShowFormat2D   ShowFormat = "2D"
+	ShowFormat3D   ShowFormat = "3D"
+	ShowFormatIMAX ShowFormat = "IMAX"
+	ShowFormat4DX  ShowFormat = "4DX"
+)
+
+// IsValid checks the format is one the system prices
+func (f ShowFormat) IsValid() bool {
+	switch f {
+	case ShowFormat2D, ShowFormat3D, ShowFormatIMAX, ShowFormat4DX:
+		return true
+	}
+	return false
+}
+
+// FormatSurcharges is the extra charged per seat by format, in minor units of the show's currency.
+// Formats missing from the map, and the nil map, add nothing.
+type FormatSurcharges map[ShowFormat]int64
+
+// DefaultFormatSurcharges charges 30.00 per seat for 3D, 100.00 for IMAX and 120.00 for 4DX
+func DefaultFormatSurcharges() FormatSurcharges {
+	return FormatSurcharges{
+		ShowFormat3D:   3000,
+		ShowFormatIMAX: 10000,
+		ShowFormat4DX:  12000,
+	}
+}
+
+// For returns the per-seat surcharge for a format in the given currency
+func (s FormatSurcharges) For(format ShowFormat, currency string) Money {
+	return NewMoney(s[format], currency)
+}
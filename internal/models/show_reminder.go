@@ -0,0 +1,62 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ShowReminderConfig sets when the owners of confirmed bookings are reminded of their show; no offsets turns
+// reminders off
+type ShowReminderConfig struct {
+	Offsets []time.Duration // How long before the show starts each reminder goes out, e.g. 24h and 2h
+}
+
+// DefaultShowReminderConfig reminds a day before the show and again two hours before
+func DefaultShowReminderConfig() ShowReminderConfig {
+	return ShowReminderConfig{Offsets: []time.Duration{24 * time.Hour, 2 * time.Hour}}
+}
+
+// ParseShowReminderOffsets reads a comma-separated list of Go durations such as "24h,2h", ignoring blanks and
+// repeats, longest first. It reports false when one isn't a positive duration.
+func ParseShowReminderOffsets(list string) ([]time.Duration, bool) {
+	var offsets []time.Duration
+	for _, value := range strings.Split(list, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		offset, err := time.ParseDuration(value)
+		if err != nil || offset <= 0 {
+			return nil, false
+		}
+		if !slices.Contains(offsets, offset) {
+			offsets = append(offsets, offset)
+		}
+	}
+	slices.SortFunc(offsets, func(a, b time.Duration) int { return cmp.Compare(b, a) })
+	return offsets, true
+}
+
+// Lookahead is how far ahead shows need reminding: the longest offset
+func (c ShowReminderConfig) Lookahead() time.Duration {
+	if len(c.Offsets) == 0 {
+		return 0
+	}
+	return slices.Max(c.Offsets)
+}
+
+// LatestWindow returns when the latest reminder window for a show starting at start opened, as of now; false
+// when none has opened yet. Only the latest counts, so a booking made the evening before gets the two-hour
+// reminder without the day-before one.
+func (c ShowReminderConfig) LatestWindow(start, now time.Time) (time.Time, bool) {
+	var latest time.Time
+	for _, offset := range c.Offsets {
+		opens := start.Add(-offset)
+		if !opens.After(now) && opens.After(latest) {
+			latest = opens
+		}
+	}
+	return latest, !latest.IsZero()
+}
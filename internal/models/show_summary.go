@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// ShowSummary is a show's final attendance and takings, fixed when the show is closed out. Seats are counted
+// across confirmed bookings; a booking's seats attended if its ticket was scanned and were no-shows if not.
+type ShowSummary struct {
+	Capacity          int       `json:"capacity"`
+	SeatsSold         int       `json:"seats_sold"`
+	Attended          int       `json:"attended"`
+	NoShows           int       `json:"no_shows"`
+	NoShowBookings    int       `json:"no_show_bookings"`
+	OccupancyPercent  float64   `json:"occupancy_percent"`  // Seats sold, of capacity
+	AttendancePercent float64   `json:"attendance_percent"` // Seats attended, of those sold
+	Payments          int       `json:"payments"`
+	Gross             Money     `json:"gross"`
+	Refunded          Money     `json:"refunded"`
+	Net               Money     `json:"net"`
+	ClosedAt          time.Time `json:"closed_at"`
+}
+
+// Complete closes out a show that has ended, recording its final summary. A show is closed out once; its
+// summary never changes afterwards.
+func (s *Show) Complete(summary ShowSummary, now time.Time) error {
+	switch {
+	case s.IsCancelled():
+		return ErrShowCancelled
+	case s.IsClosed():
+		return ErrShowClosed
+	case !s.IsCompleted(now):
+		return ErrShowNotEnded
+	}
+
+	summary.ClosedAt = now
+	s.Status = ShowStatusCompleted
+	s.Summary = &summary
+	s.UpdatedAt = summary.ClosedAt
+	return nil
+}
+
+// IsClosed checks if the show has been closed out after it ended
+func (s *Show) IsClosed() bool {
+	return s.Status == ShowStatusCompleted
+}
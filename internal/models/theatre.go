@@ -9,18 +9,23 @@ import (
 
 // Theatre represents a theatre with multiple screens
 type Theatre struct {
-	ID        string             `json:"id"`
-	Name      string             `json:"name"`
-	Address   string             `json:"address"`
-	City      string             `json:"city"`
-	Screens   map[string]*Screen `json:"screens"`
-	CreatedAt time.Time          `json:"created_at"`
-	UpdatedAt time.Time          `json:"updated_at"`
-	mutex     sync.RWMutex
+	ID                 string              `json:"id"`
+	Name               string              `json:"name"`
+	Address            string              `json:"address"`
+	City               string              `json:"city"`
+	Location           *GeoPoint           `json:"location,omitempty"` // nil until the partner shares coordinates
+	Screens            map[string]*Screen  `json:"screens"`
+	CancellationPolicy *CancellationPolicy `json:"cancellation_policy,omitempty"` // nil means DefaultCancellationPolicy
+	BookingTimeout     time.Duration       `json:"booking_timeout,omitempty"`     // Given to new shows that don't set their own; zero is the platform default
+	ParkingSlots       int                 `json:"parking_slots,omitempty"`       // Size of the car park; zero means no parking
+	SaleWindow         SaleWindow          `json:"sale_window,omitzero"`          // Given to new shows whose movie has none
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+	mutex              sync.RWMutex
 }
 
 // NewTheatre creates a new theatre
-func NewTheatre(name, address, city string) (*Theatre, error) {
+func NewTheatre(name, address, city string, now time.Time) (*Theatre, error) {
 	if name == "" || address == "" || city == "" {
 		return nil, ErrInvalidTheatreData
 	}
@@ -31,19 +36,19 @@ func NewTheatre(name, address, city string) (*Theatre, error) {
 		Address:   address,
 		City:      city,
 		Screens:   make(map[string]*Screen),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		CreatedAt: now,
+		UpdatedAt: now,
 	}, nil
 }
 
 // AddScreen adds a screen to the theatre
-func (t *Theatre) AddScreen(screen *Screen) {
+func (t *Theatre) AddScreen(screen *Screen, now time.Time) {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
 	screen.TheatreID = t.ID
 	t.Screens[screen.ID] = screen
-	t.UpdatedAt = time.Now()
+	t.UpdatedAt = now
 }
 
 // GetScreen retrieves a screen by ID
@@ -71,7 +76,7 @@ func (t *Theatre) GetAllScreens() []*Screen {
 }
 
 // RemoveScreen removes a screen from the theatre
-func (t *Theatre) RemoveScreen(screenID string) error {
+func (t *Theatre) RemoveScreen(screenID string, now time.Time) error {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
@@ -80,7 +85,7 @@ func (t *Theatre) RemoveScreen(screenID string) error {
 	}
 
 	delete(t.Screens, screenID)
-	t.UpdatedAt = time.Now()
+	t.UpdatedAt = now
 	return nil
 }
 
@@ -97,7 +102,7 @@ func (t *Theatre) GetTotalCapacity() int {
 }
 
 // UpdateTheatre updates theatre information
-func (t *Theatre) UpdateTheatre(name, address, city string) error {
+func (t *Theatre) UpdateTheatre(name, address, city string, now time.Time) error {
 	if name == "" || address == "" || city == "" {
 		return ErrInvalidTheatreData
 	}
@@ -108,6 +113,88 @@ func (t *Theatre) UpdateTheatre(name, address, city string) error {
 	t.Name = name
 	t.Address = address
 	t.City = city
-	t.UpdatedAt = time.Now()
+	t.UpdatedAt = now
 	return nil
 }
+
+// SetLocation stores the theatre's coordinates for nearby search
+func (t *Theatre) SetLocation(location GeoPoint, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.Location = &location
+	t.UpdatedAt = now
+}
+
+// GetLocation returns the theatre's coordinates, if known
+func (t *Theatre) GetLocation() (GeoPoint, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.Location == nil {
+		return GeoPoint{}, false
+	}
+	return *t.Location, true
+}
+
+// SetCancellationPolicy replaces the theatre's refund tiers; nil restores the default
+func (t *Theatre) SetCancellationPolicy(policy *CancellationPolicy, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.CancellationPolicy = policy
+	t.UpdatedAt = now
+}
+
+// GetCancellationPolicy returns the theatre's refund tiers, falling back to the default
+func (t *Theatre) GetCancellationPolicy() *CancellationPolicy {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.CancellationPolicy == nil {
+		return DefaultCancellationPolicy()
+	}
+	return t.CancellationPolicy
+}
+
+// SetBookingTimeout changes the payment window new shows at the theatre start with; zero restores the default
+func (t *Theatre) SetBookingTimeout(timeout time.Duration, now time.Time) error {
+	if err := ValidateBookingTimeout(timeout); err != nil {
+		return err
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.BookingTimeout = timeout
+	t.UpdatedAt = now
+	return nil
+}
+
+// GetBookingTimeout returns the payment window for new shows, zero meaning the default
+func (t *Theatre) GetBookingTimeout() time.Duration {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.BookingTimeout
+}
+
+// SetParkingSlots sizes the theatre's car park; zero means it has none
+func (t *Theatre) SetParkingSlots(slots int, now time.Time) error {
+	if slots < 0 {
+		return ErrInvalidParkingCapacity
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.ParkingSlots = slots
+	t.UpdatedAt = now
+	return nil
+}
+
+// GetParkingSlots returns the size of the theatre's car park
+func (t *Theatre) GetParkingSlots() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.ParkingSlots
+}
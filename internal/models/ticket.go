@@ -0,0 +1,137 @@
+package models
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TicketStatus represents the lifecycle of an e-ticket
+type TicketStatus string
+
+const (
+	TicketStatusIssued TicketStatus = "ISSUED"
+	TicketStatusUsed   TicketStatus = "USED"    // Scanned at entry
+	TicketStatusVoid   TicketStatus = "VOID"    // Booking cancelled
+	TicketStatusNoShow TicketStatus = "NO_SHOW" // Never scanned; set when the show is closed out
+)
+
+// Ticket is the signed e-ticket for a confirmed booking, presented as a QR code at entry
+type Ticket struct {
+	ID          string       `json:"id"`
+	BookingID   string       `json:"booking_id"`
+	UserID      string       `json:"user_id"`
+	ShowID      string       `json:"show_id"`
+	TheatreID   string       `json:"theatre_id"`
+	Payload     string       `json:"payload"` // Signed string encoded in the QR code
+	Status      TicketStatus `json:"status"`
+	IssuedAt    time.Time    `json:"issued_at"`
+	Reissues    int          `json:"reissues,omitempty"` // Times the ticket was signed again, e.g. for a new owner
+	CheckedInAt time.Time    `json:"checked_in_at,omitzero"`
+	CheckedInBy string       `json:"checked_in_by,omitempty"` // Gate or staff identifier
+	UpdatedAt   time.Time    `json:"updated_at"`
+	mutex       sync.RWMutex
+}
+
+// NewTicket creates an issued ticket; the payload is signed separately once the ID is known
+func NewTicket(bookingID, userID, showID, theatreID string, now time.Time) (*Ticket, error) {
+	if bookingID == "" || userID == "" || showID == "" || theatreID == "" {
+		return nil, ErrInvalidTicket
+	}
+
+	return &Ticket{
+		ID:        uuid.New().String(),
+		BookingID: bookingID,
+		UserID:    userID,
+		ShowID:    showID,
+		TheatreID: theatreID,
+		Status:    TicketStatusIssued,
+		IssuedAt:  now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// GetStatus returns the current ticket status (thread-safe)
+func (t *Ticket) GetStatus() TicketStatus {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.Status
+}
+
+// CheckIn marks the ticket as used - atomic, so a ticket scanned at two gates only admits once
+func (t *Ticket) CheckIn(gate string, now time.Time) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	switch t.Status {
+	case TicketStatusUsed:
+		return ErrTicketAlreadyUsed
+	case TicketStatusVoid:
+		return ErrTicketVoid
+	case TicketStatusNoShow:
+		return ErrTicketExpired
+	}
+
+	t.Status = TicketStatusUsed
+	t.CheckedInAt = now
+	t.CheckedInBy = gate
+	t.UpdatedAt = now
+	return nil
+}
+
+// Reissue hands an unused ticket to the booking's new owner; the caller signs a new payload, which
+// invalidates the old QR code
+func (t *Ticket) Reissue(userID string, now time.Time) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	switch t.Status {
+	case TicketStatusUsed:
+		return ErrTicketAlreadyUsed
+	case TicketStatusVoid:
+		return ErrTicketVoid
+	}
+	if userID == "" {
+		return ErrInvalidTicket
+	}
+
+	t.UserID = userID
+	t.IssuedAt = now
+	t.Reissues++
+	t.UpdatedAt = now
+	return nil
+}
+
+// MarkNoShow records that an issued ticket was never scanned before its show ended
+func (t *Ticket) MarkNoShow(now time.Time) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	switch t.Status {
+	case TicketStatusUsed:
+		return ErrTicketAlreadyUsed
+	case TicketStatusVoid:
+		return ErrTicketVoid
+	case TicketStatusNoShow:
+		return nil
+	}
+
+	t.Status = TicketStatusNoShow
+	t.UpdatedAt = now
+	return nil
+}
+
+// Void invalidates an unused ticket when its booking is cancelled
+func (t *Ticket) Void(now time.Time) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.Status == TicketStatusUsed {
+		return ErrTicketAlreadyUsed
+	}
+
+	t.Status = TicketStatusVoid
+	t.UpdatedAt = now
+	return nil
+}
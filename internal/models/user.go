@@ -1,23 +1,51 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// UserRole decides what a user is allowed to do
+type UserRole string
+
+const (
+	UserRoleCustomer     UserRole = "CUSTOMER"
+	UserRoleTheatreAdmin UserRole = "THEATRE_ADMIN" // Runs the screens and shows of the theatres assigned to them
+	UserRoleSuperAdmin   UserRole = "SUPER_ADMIN"   // Runs the platform: catalog, partners, roles, moderation
+	UserRoleCorporate    UserRole = "CORPORATE"     // Books blocks of seats for a company's employees
+)
+
+// UnmarshalText reads roles saved before theatre admins existed, when every admin ran the whole platform
+func (r *UserRole) UnmarshalText(text []byte) error {
+	*r = UserRole(text)
+	if *r == "ADMIN" {
+		*r = UserRoleSuperAdmin
+	}
+	return nil
+}
+
 // User represents a user in the system
 type User struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Email       string    `json:"email"`
-	PhoneNumber string    `json:"phone_number"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID              string           `json:"id"`
+	Name            string           `json:"name"`
+	Email           string           `json:"email"`
+	PhoneNumber     string           `json:"phone_number"`
+	Role            UserRole         `json:"role"`
+	TheatreIDs      []string         `json:"theatre_ids,omitempty"`   // Theatres a THEATRE_ADMIN manages
+	DateOfBirth     *time.Time       `json:"date_of_birth,omitempty"` // Checked against certificates like A; nil until the user gives it
+	Blocked         bool             `json:"blocked,omitempty"`       // Blocked users can't hold seats or book
+	BlockReason     string           `json:"block_reason,omitempty"`
+	Preferences     UserPreferences  `json:"preferences,omitzero"`       // Personalization profile the user filled in
+	DiscountProfile *DiscountProfile `json:"discount_profile,omitempty"` // Student, senior citizen or military claim
+	WhatsAppOptIn   *WhatsAppOptIn   `json:"whatsapp_opt_in,omitempty"`  // Nil until the user answers
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
 }
 
 // NewUser creates a new user with validation
-func NewUser(name, email, phoneNumber string) (*User, error) {
+func NewUser(name, email, phoneNumber string, now time.Time) (*User, error) {
 	if name == "" || email == "" || phoneNumber == "" {
 		return nil, ErrInvalidUserData
 	}
@@ -27,13 +55,14 @@ func NewUser(name, email, phoneNumber string) (*User, error) {
 		Name:        name,
 		Email:       email,
 		PhoneNumber: phoneNumber,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		Role:        UserRoleCustomer,
+		CreatedAt:   now,
+		UpdatedAt:   now,
 	}, nil
 }
 
 // UpdateProfile updates user profile information
-func (u *User) UpdateProfile(name, email, phoneNumber string) error {
+func (u *User) UpdateProfile(name, email, phoneNumber string, now time.Time) error {
 	if name == "" || email == "" || phoneNumber == "" {
 		return ErrInvalidUserData
 	}
@@ -41,6 +70,92 @@ func (u *User) UpdateProfile(name, email, phoneNumber string) error {
 	u.Name = name
 	u.Email = email
 	u.PhoneNumber = phoneNumber
-	u.UpdatedAt = time.Now()
+	u.UpdatedAt = now
+	return nil
+}
+
+// IsAdmin checks if the user has any admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == UserRoleTheatreAdmin || u.Role == UserRoleSuperAdmin
+}
+
+// ManagesTheatre checks if the user may act on the theatre's screens, shows and reports
+func (u *User) ManagesTheatre(theatreID string) bool {
+	switch u.Role {
+	case UserRoleSuperAdmin:
+		return true
+	case UserRoleTheatreAdmin:
+		return slices.Contains(u.TheatreIDs, theatreID)
+	default:
+		return false
+	}
+}
+
+// Can checks a permission; theatreID scopes theatre permissions and is ignored for the rest
+func (u *User) Can(permission Permission, theatreID string) bool {
+	if !slices.Contains(rolePermissions[u.Role], permission) {
+		return false
+	}
+	return !permission.IsTheatreScoped() || u.ManagesTheatre(theatreID)
+}
+
+// SetRole changes the user's role; theatre admins need the theatres they manage, other roles take none
+func (u *User) SetRole(role UserRole, now time.Time, theatreIDs ...string) error {
+	switch role {
+	case UserRoleTheatreAdmin:
+		if len(theatreIDs) == 0 {
+			return ErrInvalidUserData
+		}
+	case UserRoleCustomer, UserRoleSuperAdmin, UserRoleCorporate:
+		if len(theatreIDs) > 0 {
+			return ErrInvalidUserData
+		}
+	default:
+		return ErrInvalidUserData
+	}
+
+	theatreIDs = slices.Clone(theatreIDs)
+	slices.Sort(theatreIDs)
+	u.Role = role
+	u.TheatreIDs = slices.Compact(theatreIDs)
+	u.UpdatedAt = now
 	return nil
 }
+
+// Block stops the user from holding seats or booking until unblocked
+func (u *User) Block(reason string, now time.Time) {
+	u.Blocked = true
+	u.BlockReason = reason
+	u.UpdatedAt = now
+}
+
+// Unblock lets a blocked user book again
+func (u *User) Unblock(now time.Time) {
+	u.Blocked = false
+	u.BlockReason = ""
+	u.UpdatedAt = now
+}
+
+// SetDateOfBirth records the user's birthday; it can't be in the future
+func (u *User) SetDateOfBirth(dateOfBirth, now time.Time) error {
+	if dateOfBirth.IsZero() || dateOfBirth.After(now) {
+		return ErrInvalidUserData
+	}
+	day := time.Date(dateOfBirth.Year(), dateOfBirth.Month(), dateOfBirth.Day(), 0, 0, 0, 0, time.UTC)
+	u.DateOfBirth = &day
+	u.UpdatedAt = now
+	return nil
+}
+
+// AgeOn returns the user's age in whole years on the given day; ok is false without a date of birth
+func (u *User) AgeOn(day time.Time) (age int, ok bool) {
+	if u.DateOfBirth == nil {
+		return 0, false
+	}
+	born := *u.DateOfBirth
+	age = day.Year() - born.Year()
+	if day.Month() < born.Month() || (day.Month() == born.Month() && day.Day() < born.Day()) {
+		age--
+	}
+	return age, true
+}
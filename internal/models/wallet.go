@@ -0,0 +1,119 @@
+package models
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WalletTransactionType says which way money moved
+type WalletTransactionType string
+
+const (
+	WalletTransactionCredit WalletTransactionType = "CREDIT" // Top-ups and refunds
+	WalletTransactionDebit  WalletTransactionType = "DEBIT"  // Payments
+)
+
+// Wallet is a user's stored balance, usable as a payment method
+type Wallet struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Balance   Money     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	mutex     sync.RWMutex
+}
+
+// WalletTransaction is one entry in a wallet's ledger
+type WalletTransaction struct {
+	ID           string                `json:"id"`
+	WalletID     string                `json:"wallet_id"`
+	UserID       string                `json:"user_id"`
+	Type         WalletTransactionType `json:"type"`
+	Amount       Money                 `json:"amount"`
+	BalanceAfter Money                 `json:"balance_after"`
+	Reference    string                `json:"reference,omitempty"` // e.g. the payment or refund that moved the money
+	Description  string                `json:"description"`
+	CreatedAt    time.Time             `json:"created_at"`
+}
+
+// NewWallet creates an empty wallet for a user
+func NewWallet(userID, currency string, now time.Time) (*Wallet, error) {
+	if userID == "" {
+		return nil, ErrInvalidWalletData
+	}
+
+	return &Wallet{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Balance:   ZeroMoney(currency),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Credit adds money to the wallet and returns the ledger entry
+func (w *Wallet) Credit(amount Money, reference, description string, now time.Time) (*WalletTransaction, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if err := w.validateAmount(amount); err != nil {
+		return nil, err
+	}
+
+	w.Balance = w.Balance.Add(amount)
+	return w.record(WalletTransactionCredit, amount, reference, description, now), nil
+}
+
+// Debit takes money out of the wallet, refusing to overdraw it
+func (w *Wallet) Debit(amount Money, reference, description string, now time.Time) (*WalletTransaction, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if err := w.validateAmount(amount); err != nil {
+		return nil, err
+	}
+
+	if amount.GreaterThan(w.Balance) {
+		return nil, ErrInsufficientWalletBalance
+	}
+
+	w.Balance = w.Balance.Sub(amount)
+	return w.record(WalletTransactionDebit, amount, reference, description, now), nil
+}
+
+// GetBalance returns the current balance
+func (w *Wallet) GetBalance() Money {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.Balance
+}
+
+// validateAmount checks an amount can move in or out of this wallet; callers must hold the mutex
+func (w *Wallet) validateAmount(amount Money) error {
+	if !amount.SameCurrency(w.Balance) {
+		return ErrCurrencyMismatch
+	}
+	if !amount.IsPositive() {
+		return ErrInvalidWalletData
+	}
+	return nil
+}
+
+// record builds a ledger entry for a balance change; callers must hold the mutex
+func (w *Wallet) record(txType WalletTransactionType, amount Money, reference, description string, now time.Time) *WalletTransaction {
+	w.UpdatedAt = now
+	return &WalletTransaction{
+		ID:           uuid.New().String(),
+		WalletID:     w.ID,
+		UserID:       w.UserID,
+		Type:         txType,
+		Amount:       amount,
+		BalanceAfter: w.Balance,
+		Reference:    reference,
+		Description:  description,
+		CreatedAt:    now,
+	}
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxWatchlistSize caps how many movies one user can keep on their watchlist
+const MaxWatchlistSize = 100
+
+// WatchlistEntry is a movie a user wants to see. They are reminded once, the first time the movie has a
+// bookable show in their home city.
+type WatchlistEntry struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"user_id"`
+	MovieID        string     `json:"movie_id"`
+	AddedAt        time.Time  `json:"added_at"`
+	RemindedAt     *time.Time `json:"reminded_at,omitempty"`
+	RemindedCity   string     `json:"reminded_city,omitempty"`
+	RemindedShowID string     `json:"reminded_show_id,omitempty"` // The show the reminder pointed to
+	mutex          sync.RWMutex
+}
+
+// NewWatchlistEntry adds a movie to a user's watchlist
+func NewWatchlistEntry(userID, movieID string, now time.Time) (*WatchlistEntry, error) {
+	if userID == "" || movieID == "" {
+		return nil, ErrInvalidWatchlistEntry
+	}
+	return &WatchlistEntry{
+		ID:      uuid.New().String(),
+		UserID:  userID,
+		MovieID: movieID,
+		AddedAt: now,
+	}, nil
+}
+
+// IsReminded reports whether the user has already been told the movie is showing
+func (e *WatchlistEntry) IsReminded() bool {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.RemindedAt != nil
+}
+
+// MarkReminded records the reminder sent for the show in city
+func (e *WatchlistEntry) MarkReminded(city, showID string, now time.Time) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.RemindedAt = &now
+	e.RemindedCity = city
+	e.RemindedShowID = showID
+}
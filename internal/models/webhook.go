@@ -0,0 +1,185 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookConfig controls how webhook deliveries are sent and retried
+type WebhookConfig struct {
+	MaxAttempts  int           // Deliveries tried before one is given up on
+	RetryBackoff time.Duration // Wait after the first failure, doubled after each one
+	MaxBackoff   time.Duration // Cap on the wait between attempts
+	Timeout      time.Duration // How long a partner has to answer one POST
+	BatchSize    int           // Deliveries the dispatcher sends per run
+}
+
+// DefaultWebhookConfig retries eight times over roughly two hours
+func DefaultWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		MaxAttempts:  8,
+		RetryBackoff: 30 * time.Second,
+		MaxBackoff:   time.Hour,
+		Timeout:      10 * time.Second,
+		BatchSize:    100,
+	}
+}
+
+// Backoff returns how long to wait after the given number of failed attempts
+func (c WebhookConfig) Backoff(attempts int) time.Duration {
+	return exponentialBackoff(c.RetryBackoff, c.MaxBackoff, attempts)
+}
+
+// Webhook is a theatre partner's callback URL and the event types it wants
+type Webhook struct {
+	ID         string    `json:"id"`
+	TheatreID  string    `json:"theatre_id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`      // e.g. BOOKING_CONFIRMED
+	Secret     string    `json:"secret,omitempty"` // Signs every delivery; only shown when the webhook is registered
+	CreatedBy  string    `json:"created_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewWebhook creates a webhook with a fresh signing secret
+func NewWebhook(theatreID, callbackURL string, eventTypes []string, now time.Time) (*Webhook, error) {
+	if theatreID == "" || len(eventTypes) == 0 {
+		return nil, ErrInvalidWebhook
+	}
+	parsed, err := url.Parse(callbackURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, ErrInvalidWebhookURL
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	return &Webhook{
+		ID:         uuid.New().String(),
+		TheatreID:  theatreID,
+		URL:        callbackURL,
+		EventTypes: slices.Clone(eventTypes),
+		Secret:     "whsec_" + hex.EncodeToString(secret),
+		CreatedAt:  now,
+	}, nil
+}
+
+// Wants reports whether the webhook subscribed to the event type
+func (w *Webhook) Wants(eventType string) bool {
+	return slices.Contains(w.EventTypes, eventType)
+}
+
+// Redacted returns a copy without the secret, for listing webhooks after they were registered
+func (w *Webhook) Redacted() *Webhook {
+	redacted := *w
+	redacted.EventTypes = slices.Clone(w.EventTypes)
+	redacted.Secret = ""
+	return &redacted
+}
+
+// Sign returns the signature header for a body sent at the given time: "t=<unix>,v1=<hex HMAC-SHA256>".
+// The HMAC covers "<unix>.<body>", so partners can reject replays of old deliveries.
+func (w *Webhook) Sign(at time.Time, body []byte) string {
+	timestamp := at.Unix()
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// WebhookDeliveryStatus is where a webhook delivery stands
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"   // Waiting for its first or next attempt
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "DELIVERED" // The partner answered 2xx
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"    // Gave up after MaxAttempts
+)
+
+// WebhookDelivery is one event on its way to one webhook, with the outcome of every attempt so far
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	WebhookID      string                `json:"webhook_id"`
+	EventType      string                `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"` // The exact body POSTed on every attempt
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	LastStatusCode int                   `json:"last_status_code,omitempty"` // Zero when the partner couldn't be reached
+	LastError      string                `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	mutex          sync.Mutex
+}
+
+// NewWebhookDelivery creates a pending delivery that is due immediately; id is chosen by the caller so
+// the same event queued twice maps to the same delivery
+func NewWebhookDelivery(id, webhookID, eventType string, payload []byte, now time.Time) (*WebhookDelivery, error) {
+	if id == "" || webhookID == "" || eventType == "" || len(payload) == 0 {
+		return nil, ErrInvalidWebhook
+	}
+
+	return &WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhookID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// IsDue reports whether a pending delivery should be attempted now
+func (d *WebhookDelivery) IsDue(now time.Time) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.Status == WebhookDeliveryPending && !now.Before(d.NextAttemptAt)
+}
+
+// MarkDelivered records a 2xx answer
+func (d *WebhookDelivery) MarkDelivered(statusCode int, now time.Time) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.Attempts++
+	d.Status = WebhookDeliveryDelivered
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+// MarkFailed records a failed attempt, scheduling a retry or giving up once maxAttempts is reached;
+// it reports whether the delivery was given up on
+func (d *WebhookDelivery) MarkFailed(statusCode int, reason string, retryAt time.Time, maxAttempts int, now time.Time) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = reason
+	d.UpdatedAt = now
+	if d.Attempts >= maxAttempts {
+		d.Status = WebhookDeliveryFailed
+		return true
+	}
+
+	d.NextAttemptAt = retryAt
+	return false
+}
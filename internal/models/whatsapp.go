@@ -0,0 +1,114 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// WhatsAppHeader is what a WhatsApp template shows above its text
+type WhatsAppHeader string
+
+const (
+	WhatsAppHeaderNone   WhatsAppHeader = ""
+	WhatsAppHeaderQRCode WhatsAppHeader = "QR_CODE" // The booking's entry QR code as an image
+)
+
+// templateField matches a placeholder such as {{reference}} in a template body
+var templateField = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// WhatsAppTemplate is the message WhatsApp sends for one kind of notification. WhatsApp only lets businesses
+// start conversations with templates approved under a name, so kinds without one aren't sent on WhatsApp.
+type WhatsAppTemplate struct {
+	Kind      NotificationKind `json:"kind"`
+	Name      string           `json:"name"`     // Name it was approved under, e.g. booking_confirmation
+	Language  string           `json:"language"` // e.g. en
+	Header    WhatsAppHeader   `json:"header,omitempty"`
+	Body      string           `json:"body"` // Text with {{field}} placeholders filled from the notification's fields
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// DefaultWhatsAppTemplates are the booking confirmation, with the entry QR code, and the show reminder
+func DefaultWhatsAppTemplates(now time.Time) []WhatsAppTemplate {
+	return []WhatsAppTemplate{
+		{
+			Kind:      NotificationBookingConfirmed,
+			Name:      "booking_confirmation",
+			Language:  "en",
+			Header:    WhatsAppHeaderQRCode,
+			Body:      "Your booking {{reference}} is confirmed. Show this QR code at the gate.",
+			UpdatedAt: now,
+		},
+		{
+			Kind:      NotificationShowReminder,
+			Name:      "show_reminder",
+			Language:  "en",
+			Body:      "Reminder: {{title}} starts at {{start_time}} at {{theatre}}. Your booking is {{reference}}.",
+			UpdatedAt: now,
+		},
+	}
+}
+
+// Validate checks the template has a kind, an approval name, a language and a body, and a header its kind
+// can fill
+func (t WhatsAppTemplate) Validate() error {
+	if t.Kind == "" || strings.TrimSpace(t.Name) == "" || strings.TrimSpace(t.Language) == "" || strings.TrimSpace(t.Body) == "" {
+		return fmt.Errorf("%w: a template needs a kind, a name, a language and a body", ErrInvalidWhatsAppTemplate)
+	}
+	switch t.Header {
+	case WhatsAppHeaderNone:
+	case WhatsAppHeaderQRCode:
+		if t.Kind != NotificationBookingConfirmed {
+			return fmt.Errorf("%w: only booking confirmations carry a QR code", ErrInvalidWhatsAppTemplate)
+		}
+	default:
+		return fmt.Errorf("%w: unknown header %q", ErrInvalidWhatsAppTemplate, t.Header)
+	}
+	return nil
+}
+
+// Render fills the body's placeholders from fields. A placeholder the notification has no field for can't be
+// filled on a retry either, so it makes the notification undeliverable.
+func (t WhatsAppTemplate) Render(fields map[string]string) (string, error) {
+	var missing []string
+	text := templateField.ReplaceAllStringFunc(t.Body, func(placeholder string) string {
+		name := templateField.FindStringSubmatch(placeholder)[1]
+		value, ok := fields[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%w: template %s needs %s", ErrNotificationUndeliverable, t.Name, strings.Join(missing, ", "))
+	}
+	return text, nil
+}
+
+// WhatsAppOptIn is a user's consent to WhatsApp messages, which WhatsApp requires before a business writes to
+// them. Both times are kept so support can tell when a user changed their mind.
+type WhatsAppOptIn struct {
+	OptedIn    bool       `json:"opted_in"`
+	OptedInAt  *time.Time `json:"opted_in_at,omitempty"`
+	OptedOutAt *time.Time `json:"opted_out_at,omitempty"`
+}
+
+// SetWhatsAppOptIn records the user opting in to or out of WhatsApp messages
+func (u *User) SetWhatsAppOptIn(optedIn bool, now time.Time) {
+	if u.WhatsAppOptIn == nil {
+		u.WhatsAppOptIn = &WhatsAppOptIn{}
+	}
+	u.WhatsAppOptIn.OptedIn = optedIn
+	if optedIn {
+		u.WhatsAppOptIn.OptedInAt = &now
+	} else {
+		u.WhatsAppOptIn.OptedOutAt = &now
+	}
+	u.UpdatedAt = now
+}
+
+// IsWhatsAppOptedIn reports whether WhatsApp messages may be sent to the user
+func (u *User) IsWhatsAppOptedIn() bool {
+	return u.WhatsAppOptIn != nil && u.WhatsAppOptIn.OptedIn
+}
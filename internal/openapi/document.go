@@ -0,0 +1,134 @@
+package openapi
+
+import "strings"
+
+// Version is the OpenAPI release the documents follow
+const Version = "3.0.3"
+
+// refPrefix starts every reference to a component schema
+const refPrefix = "#/components/schemas/"
+
+// Document is an OpenAPI 3 document - the subset the API describes itself with and the client generator reads
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"` // By path, e.g. /shows/{id}
+	Components Components           `json:"components"`
+}
+
+// Info names and versions the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Components holds the schemas operations refer to and the security schemes they need
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how a request proves who sent it
+type SecurityScheme struct {
+	Type   string `json:"type"`             // e.g. http
+	Scheme string `json:"scheme,omitempty"` // e.g. bearer
+}
+
+// PathItem holds the operations on one path, by method
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+	Patch  *Operation `json:"patch,omitempty"`
+}
+
+// Operation is one method on one path
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"` // By status code, plus "default" for errors
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter is a path or query parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path or query
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the body an operation takes
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is what an operation answers with for one status
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"` // Absent for 204 No Content
+}
+
+// MediaType is a body in one content type
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Schema describes a JSON value. Named structs are kept once under components and referred to by Ref.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`   // object, array, string, integer, number or boolean
+	Format               string             `json:"format,omitempty"` // e.g. date-time, int64, binary
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Operations lists the operations on the path, by method
+func (p *PathItem) Operations() map[string]*Operation {
+	operations := make(map[string]*Operation)
+	for method, operation := range map[string]*Operation{"GET": p.Get, "PUT": p.Put, "POST": p.Post, "DELETE": p.Delete, "PATCH": p.Patch} {
+		if operation != nil {
+			operations[method] = operation
+		}
+	}
+	return operations
+}
+
+// SetOperation puts the operation on the path under method, reporting false for a method it doesn't hold
+func (p *PathItem) SetOperation(method string, operation *Operation) bool {
+	switch method {
+	case "GET":
+		p.Get = operation
+	case "PUT":
+		p.Put = operation
+	case "POST":
+		p.Post = operation
+	case "DELETE":
+		p.Delete = operation
+	case "PATCH":
+		p.Patch = operation
+	default:
+		return false
+	}
+	return true
+}
+
+// RefName returns the component name a schema refers to, or "" for an inline schema
+func (s *Schema) RefName() string {
+	name, ok := strings.CutPrefix(s.Ref, refPrefix)
+	if !ok {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,142 @@
+package pricing
+
+import (
+	"bookmyshow-lld/internal/models"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DayKind says why a calendar day is priced differently
+type DayKind string
+
+const (
+	DayHoliday DayKind = "HOLIDAY" // A public holiday, e.g. Diwali
+	DayPeak    DayKind = "PEAK"    // A busy day that isn't a holiday, e.g. New Year's Eve
+)
+
+// MaxCalendarMultiplier caps how far one calendar day can raise prices
+const MaxCalendarMultiplier = 5.0
+
+// CalendarDay prices every show starting on Date by Multiplier, e.g. 1.25 for a quarter more
+type CalendarDay struct {
+	Date       time.Time `json:"date"` // Only the day counts
+	Kind       DayKind   `json:"kind"`
+	Name       string    `json:"name"`
+	Multiplier float64   `json:"multiplier"`
+}
+
+// Validate checks the day has a date, a known kind and a multiplier above 1 and at most MaxCalendarMultiplier
+func (d CalendarDay) Validate() error {
+	switch {
+	case d.Date.IsZero():
+		return fmt.Errorf("%w: a date is required", models.ErrInvalidPricingDay)
+	case d.Kind != DayHoliday && d.Kind != DayPeak:
+		return fmt.Errorf("%w: kind must be %s or %s", models.ErrInvalidPricingDay, DayHoliday, DayPeak)
+	case d.Multiplier <= 1 || d.Multiplier > MaxCalendarMultiplier:
+		return fmt.Errorf("%w: multiplier must be above 1 and at most %g", models.ErrInvalidPricingDay, MaxCalendarMultiplier)
+	}
+	return nil
+}
+
+// label names the day's adjustment on a quote, e.g. "Diwali (holiday x1.5)"
+func (d CalendarDay) label() string {
+	kind := strings.ToLower(string(d.Kind))
+	if d.Name == "" {
+		return fmt.Sprintf("%s%s pricing (x%g)", strings.ToUpper(kind[:1]), kind[1:], d.Multiplier)
+	}
+	return fmt.Sprintf("%s (%s x%g)", d.Name, kind, d.Multiplier)
+}
+
+// Calendar holds the days admins have flagged as holidays or peak days. The pricing engine reads it on every
+// quote, so flagging Diwali raises the price of its shows without touching show data.
+type Calendar struct {
+	days  map[string]CalendarDay // By date, as YYYY-MM-DD
+	mutex sync.RWMutex
+}
+
+// NewCalendar creates a calendar holding days; invalid ones are skipped
+func NewCalendar(days ...CalendarDay) *Calendar {
+	calendar := &Calendar{days: make(map[string]CalendarDay)}
+	for _, day := range days {
+		calendar.Set(day)
+	}
+	return calendar
+}
+
+// Set flags a day, replacing whatever was set for the same date, and returns it with the date at midnight UTC
+func (c *Calendar) Set(day CalendarDay) (CalendarDay, error) {
+	day.Name = strings.TrimSpace(day.Name)
+	day.Kind = DayKind(strings.ToUpper(string(day.Kind)))
+	if err := day.Validate(); err != nil {
+		return CalendarDay{}, err
+	}
+	key := day.Date.Format(time.DateOnly)
+	day.Date, _ = time.Parse(time.DateOnly, key)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.days[key] = day
+	return day, nil
+}
+
+// Remove clears a date, reporting whether it was flagged
+func (c *Calendar) Remove(date time.Time) bool {
+	key := date.Format(time.DateOnly)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, exists := c.days[key]; !exists {
+		return false
+	}
+	delete(c.days, key)
+	return true
+}
+
+// Day returns what is set for a date, if anything
+func (c *Calendar) Day(date time.Time) (CalendarDay, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	day, ok := c.days[date.Format(time.DateOnly)]
+	return day, ok
+}
+
+// Days lists every flagged day, earliest first
+func (c *Calendar) Days() []CalendarDay {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	days := make([]CalendarDay, 0, len(c.days))
+	for _, day := range c.days {
+		days = append(days, day)
+	}
+	slices.SortFunc(days, func(a, b CalendarDay) int { return a.Date.Compare(b.Date) })
+	return days
+}
+
+// CalendarPricing multiplies the price of shows starting on a flagged day, judged in loc. The calendar is read
+// on every quote, so days set after the chain is built apply straight away.
+func CalendarPricing(calendar *Calendar, loc *time.Location) Decorator {
+	return func(next Pricer) Pricer {
+		return &calendarPricing{next: next, calendar: calendar, loc: orLocal(loc)}
+	}
+}
+
+type calendarPricing struct {
+	next     Pricer
+	calendar *Calendar
+	loc      *time.Location
+}
+
+func (c *calendarPricing) Quote(show *models.Show, seat *models.Seat) Quote {
+	quote := c.next.Quote(show, seat)
+	day, ok := c.calendar.Day(show.StartTime.In(c.loc))
+	if !ok {
+		return quote
+	}
+	return adjust(quote, day.label(), (day.Multiplier-1)*100)
+}
@@ -0,0 +1,187 @@
+package pricing
+
+import (
+	"bookmyshow-lld/internal/models"
+	"maps"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule names the controller registers the configured decorators under
+const (
+	RuleCalendar  = "calendar"
+	RuleHoliday   = "holiday"
+	RuleFestival  = "festival"
+	RuleLateNight = "late-night"
+	RuleDeal      = "last-minute-deal" // Registered by the controller once deals are on
+)
+
+// Config turns on the built-in pricing rules; a rule with a zero percent isn't registered
+type Config struct {
+	Location *time.Location // Zone show times are judged in; nil is the server's
+
+	Calendar []CalendarDay // Holiday and peak days the pricing calendar starts with; admins maintain it from there
+
+	Holidays                []Holiday
+	HolidaySurchargePercent float64
+
+	Festivals               []Festival
+	FestivalDiscountPercent float64
+
+	LateNightFrom            time.Duration // Time of day the late-night window opens
+	LateNightUntil           time.Duration // Time of day it closes; before From means the next morning
+	LateNightDiscountPercent float64
+
+	ProfileDiscounts ProfileDiscounts // What verified students, senior citizens and military personnel save per booking
+}
+
+// DefaultConfig registers no rules; its late-night window runs from 22:00 to 04:00 and verified profiles get
+// DefaultProfileDiscounts
+func DefaultConfig() Config {
+	return Config{LateNightFrom: 22 * time.Hour, LateNightUntil: 4 * time.Hour, ProfileDiscounts: DefaultProfileDiscounts()}
+}
+
+// ConfigFromEnv reads PRICING_TIMEZONE, PRICING_CALENDAR (2026-11-08=HOLIDAY:1.5:Diwali,...), HOLIDAYS (2026-12-25=Christmas,...), HOLIDAY_SURCHARGE_PERCENT,
+// FESTIVALS (Diwali=2026-11-06..2026-11-10,...), FESTIVAL_DISCOUNT_PERCENT, LATE_NIGHT_WINDOW (22:00-04:00),
+// LATE_NIGHT_DISCOUNT_PERCENT and PROFILE_DISCOUNTS (STUDENT=10:100:300,... as percent, per-booking cap and
+// per-month cap, replacing that category's default; a zero percent turns it off). Malformed entries are skipped.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig()
+	if zone := os.Getenv("PRICING_TIMEZONE"); zone != "" {
+		if loc, err := time.LoadLocation(zone); err == nil {
+			cfg.Location = loc
+		}
+	}
+
+	for _, entry := range list("PRICING_CALENDAR") {
+		day, rule, _ := strings.Cut(entry, "=")
+		fields := strings.SplitN(rule, ":", 3)
+		date, dateErr := time.Parse(time.DateOnly, strings.TrimSpace(day))
+		if len(fields) < 2 || dateErr != nil {
+			continue
+		}
+		multiplier, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+		if err != nil {
+			continue
+		}
+		calendarDay := CalendarDay{Date: date, Kind: DayKind(strings.ToUpper(strings.TrimSpace(fields[0]))), Multiplier: multiplier}
+		if len(fields) == 3 {
+			calendarDay.Name = strings.TrimSpace(fields[2])
+		}
+		if calendarDay.Validate() == nil {
+			cfg.Calendar = append(cfg.Calendar, calendarDay)
+		}
+	}
+
+	for _, entry := range list("HOLIDAYS") {
+		day, name, _ := strings.Cut(entry, "=")
+		if date, err := time.Parse(time.DateOnly, strings.TrimSpace(day)); err == nil {
+			cfg.Holidays = append(cfg.Holidays, Holiday{Date: date, Name: orDefault(strings.TrimSpace(name), "Holiday")})
+		}
+	}
+	cfg.HolidaySurchargePercent = percent("HOLIDAY_SURCHARGE_PERCENT")
+
+	for _, entry := range list("FESTIVALS") {
+		name, days, ok := strings.Cut(entry, "=")
+		first, last, _ := strings.Cut(days, "..")
+		start, startErr := time.Parse(time.DateOnly, strings.TrimSpace(first))
+		end, endErr := time.Parse(time.DateOnly, strings.TrimSpace(orDefault(last, first)))
+		if ok && startErr == nil && endErr == nil && !end.Before(start) {
+			cfg.Festivals = append(cfg.Festivals, Festival{Name: strings.TrimSpace(name), Start: start, End: end})
+		}
+	}
+	cfg.FestivalDiscountPercent = percent("FESTIVAL_DISCOUNT_PERCENT")
+
+	if from, until, ok := strings.Cut(os.Getenv("LATE_NIGHT_WINDOW"), "-"); ok {
+		fromTime, fromErr := time.Parse("15:04", strings.TrimSpace(from))
+		untilTime, untilErr := time.Parse("15:04", strings.TrimSpace(until))
+		if fromErr == nil && untilErr == nil {
+			cfg.LateNightFrom = sinceMidnight(fromTime)
+			cfg.LateNightUntil = sinceMidnight(untilTime)
+		}
+	}
+	cfg.LateNightDiscountPercent = percent("LATE_NIGHT_DISCOUNT_PERCENT")
+
+	for _, entry := range list("PROFILE_DISCOUNTS") {
+		category, rule, _ := strings.Cut(entry, "=")
+		fields := strings.Split(rule, ":")
+		if len(fields) > 3 {
+			continue
+		}
+		var values []float64
+		for _, field := range fields {
+			if value, err := strconv.ParseFloat(strings.TrimSpace(field), 64); err == nil {
+				values = append(values, value)
+			}
+		}
+		if len(values) != len(fields) {
+			continue
+		}
+		values = append(values, 0, 0) // Missing caps are uncapped
+		discount := ProfileDiscounts{models.DiscountCategory(strings.ToUpper(strings.TrimSpace(category))): {
+			Percent: values[0], MaxPerBooking: values[1], MaxPerMonth: values[2],
+		}}
+		if discount.Validate() == nil {
+			maps.Copy(cfg.ProfileDiscounts, discount)
+		}
+	}
+	return cfg
+}
+
+// NewCalendar creates the pricing calendar holding the configured days
+func (cfg Config) NewCalendar() *Calendar {
+	return NewCalendar(cfg.Calendar...)
+}
+
+// NewChain registers a decorator for every rule the config turns on, in the order calendar, holiday,
+// festival, late-night. The calendar rule is registered whenever a calendar is given, even an empty one,
+// so days flagged later are priced.
+func (cfg Config) NewChain(calendar *Calendar) *Chain {
+	chain := NewChain()
+	if calendar != nil {
+		chain.Register(RuleCalendar, CalendarPricing(calendar, cfg.Location))
+	}
+	if cfg.HolidaySurchargePercent > 0 && len(cfg.Holidays) > 0 {
+		chain.Register(RuleHoliday, HolidaySurcharge(cfg.Holidays, cfg.HolidaySurchargePercent, cfg.Location))
+	}
+	if cfg.FestivalDiscountPercent > 0 && len(cfg.Festivals) > 0 {
+		chain.Register(RuleFestival, FestivalDiscount(cfg.Festivals, cfg.FestivalDiscountPercent, cfg.Location))
+	}
+	if cfg.LateNightDiscountPercent > 0 && cfg.LateNightFrom != cfg.LateNightUntil {
+		chain.Register(RuleLateNight, LateNightDiscount(cfg.LateNightFrom, cfg.LateNightUntil, cfg.LateNightDiscountPercent, cfg.Location))
+	}
+	return chain
+}
+
+// list splits a comma-separated variable, dropping blanks
+func list(key string) []string {
+	var entries []string
+	for _, entry := range strings.Split(os.Getenv(key), ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// percent parses a 0-100 percentage, reading unset or invalid values as zero
+func percent(key string) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil || value < 0 || value > 100 {
+		return 0
+	}
+	return value
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
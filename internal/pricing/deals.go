@@ -0,0 +1,33 @@
+package pricing
+
+import (
+	"bookmyshow-lld/internal/models"
+	"fmt"
+)
+
+// DealFinder finds the last-minute deal running on a show right now, e.g. services.DealsService
+type DealFinder interface {
+	ActiveDeal(showID string) (*models.Deal, bool)
+}
+
+// LastMinuteDeal takes a show's deal off its seats while the deal runs. Deals are looked up on every quote,
+// so a deal made after the chain is built applies straight away.
+func LastMinuteDeal(deals DealFinder) Decorator {
+	return func(next Pricer) Pricer {
+		return &lastMinuteDeal{next: next, deals: deals}
+	}
+}
+
+type lastMinuteDeal struct {
+	next  Pricer
+	deals DealFinder
+}
+
+func (l *lastMinuteDeal) Quote(show *models.Show, seat *models.Seat) Quote {
+	quote := l.next.Quote(show, seat)
+	deal, ok := l.deals.ActiveDeal(show.ID)
+	if !ok {
+		return quote
+	}
+	return adjust(quote, fmt.Sprintf("Last-minute deal (%g%%)", deal.Percent), -deal.Percent)
+}
@@ -0,0 +1,109 @@
+package pricing
+
+import (
+	"bookmyshow-lld/internal/models"
+	"fmt"
+	"time"
+)
+
+// Holiday is a calendar day with its own pricing, e.g. Christmas
+type Holiday struct {
+	Date time.Time // Only the day counts
+	Name string
+}
+
+// Festival is a run of days, both ends included, e.g. Diwali week
+type Festival struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+// HolidaySurcharge adds percent to shows starting on a holiday, judged in loc
+func HolidaySurcharge(holidays []Holiday, percent float64, loc *time.Location) Decorator {
+	byDay := make(map[string]string, len(holidays))
+	for _, holiday := range holidays {
+		byDay[holiday.Date.Format(time.DateOnly)] = holiday.Name
+	}
+	return func(next Pricer) Pricer {
+		return &holidaySurcharge{next: next, holidays: byDay, percent: percent, loc: orLocal(loc)}
+	}
+}
+
+type holidaySurcharge struct {
+	next     Pricer
+	holidays map[string]string
+	percent  float64
+	loc      *time.Location
+}
+
+func (h *holidaySurcharge) Quote(show *models.Show, seat *models.Seat) Quote {
+	quote := h.next.Quote(show, seat)
+	name, ok := h.holidays[show.StartTime.In(h.loc).Format(time.DateOnly)]
+	if !ok {
+		return quote
+	}
+	return adjust(quote, fmt.Sprintf("%s surcharge (%g%%)", name, h.percent), h.percent)
+}
+
+// FestivalDiscount takes percent off shows starting during a festival, judged in loc
+func FestivalDiscount(festivals []Festival, percent float64, loc *time.Location) Decorator {
+	return func(next Pricer) Pricer {
+		return &festivalDiscount{next: next, festivals: festivals, percent: percent, loc: orLocal(loc)}
+	}
+}
+
+type festivalDiscount struct {
+	next      Pricer
+	festivals []Festival
+	percent   float64
+	loc       *time.Location
+}
+
+func (f *festivalDiscount) Quote(show *models.Show, seat *models.Seat) Quote {
+	quote := f.next.Quote(show, seat)
+	day := show.StartTime.In(f.loc).Format(time.DateOnly)
+	for _, festival := range f.festivals {
+		// DateOnly strings sort like the days they name
+		if day >= festival.Start.Format(time.DateOnly) && day <= festival.End.Format(time.DateOnly) {
+			return adjust(quote, fmt.Sprintf("%s discount (%g%%)", festival.Name, f.percent), -f.percent)
+		}
+	}
+	return quote
+}
+
+// LateNightDiscount takes percent off shows starting between from and until, as times of day in loc.
+// The window may wrap past midnight, e.g. 22:00 to 04:00.
+func LateNightDiscount(from, until time.Duration, percent float64, loc *time.Location) Decorator {
+	return func(next Pricer) Pricer {
+		return &lateNightDiscount{next: next, from: from, until: until, percent: percent, loc: orLocal(loc)}
+	}
+}
+
+type lateNightDiscount struct {
+	next        Pricer
+	from, until time.Duration
+	percent     float64
+	loc         *time.Location
+}
+
+func (l *lateNightDiscount) Quote(show *models.Show, seat *models.Seat) Quote {
+	quote := l.next.Quote(show, seat)
+	timeOfDay := sinceMidnight(show.StartTime.In(l.loc))
+
+	late := timeOfDay >= l.from && timeOfDay < l.until
+	if l.from > l.until {
+		late = timeOfDay >= l.from || timeOfDay < l.until
+	}
+	if !late {
+		return quote
+	}
+	return adjust(quote, fmt.Sprintf("Late-night discount (%g%%)", l.percent), -l.percent)
+}
+
+func orLocal(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.Local
+	}
+	return loc
+}
@@ -0,0 +1,132 @@
+package pricing
+
+import (
+	"bookmyshow-lld/internal/models"
+	"fmt"
+	"sync"
+)
+
+// Quote is what one seat costs at one show, with each rule that moved the price
+type Quote struct {
+	Price       models.Money
+	Adjustments []Adjustment
+}
+
+// Adjustment is one rule's change to a seat price; discounts are negative
+type Adjustment struct {
+	Name   string
+	Amount models.Money
+}
+
+// Pricer prices a seat at a show - demonstrates Decorator Pattern: every pricing rule is a Pricer
+// wrapping another, so rules stack without the booking service knowing how many there are
+type Pricer interface {
+	Quote(show *models.Show, seat *models.Seat) Quote
+}
+
+// Base is the innermost pricer: the seat's price from the SeatFactory plus the show's format surcharge
+type Base struct{}
+
+func (Base) Quote(show *models.Show, seat *models.Seat) Quote {
+	return Quote{Price: show.PriceFor(seat)}
+}
+
+// Decorator wraps a pricer with one more rule
+type Decorator func(next Pricer) Pricer
+
+// adjust applies a percentage to a quote's running price, recording it as an adjustment
+func adjust(quote Quote, name string, percent float64) Quote {
+	amount := quote.Price.Percent(percent)
+	if amount.IsZero() {
+		return quote
+	}
+	quote.Price = quote.Price.Add(amount)
+	quote.Adjustments = append(quote.Adjustments, Adjustment{Name: name, Amount: amount})
+	return quote
+}
+
+// Chain holds the registered decorators around Base, in registration order, and is itself a Pricer
+// - demonstrates Registry Pattern: rules are added and removed at runtime, each independently
+type Chain struct {
+	names      []string
+	decorators map[string]Decorator
+	pricer     Pricer // Base wrapped by every decorator, rebuilt on each change
+	mutex      sync.RWMutex
+}
+
+// NewChain creates a chain that charges Base prices until decorators are registered
+func NewChain() *Chain {
+	return &Chain{decorators: make(map[string]Decorator), pricer: Base{}}
+}
+
+// Register wraps the chain in one more named rule; a name can only be registered once
+func (c *Chain) Register(name string, decorator Decorator) error {
+	if name == "" || decorator == nil {
+		return fmt.Errorf("a pricing rule needs a name and a decorator")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, exists := c.decorators[name]; exists {
+		return fmt.Errorf("pricing rule %q is already registered", name)
+	}
+	c.names = append(c.names, name)
+	c.decorators[name] = decorator
+	c.rebuild()
+	return nil
+}
+
+// Unregister removes a rule, reporting whether it was registered
+func (c *Chain) Unregister(name string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, exists := c.decorators[name]; !exists {
+		return false
+	}
+	delete(c.decorators, name)
+	for i, registered := range c.names {
+		if registered == name {
+			c.names = append(c.names[:i], c.names[i+1:]...)
+			break
+		}
+	}
+	c.rebuild()
+	return true
+}
+
+// Names lists the registered rules, innermost first
+func (c *Chain) Names() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return append([]string(nil), c.names...)
+}
+
+// Quote prices the seat through every registered rule
+func (c *Chain) Quote(show *models.Show, seat *models.Seat) Quote {
+	c.mutex.RLock()
+	pricer := c.pricer
+	c.mutex.RUnlock()
+	return pricer.Quote(show, seat)
+}
+
+// rebuild wraps Base in the decorators in registration order. Callers must hold mutex.
+func (c *Chain) rebuild() {
+	var pricer Pricer = Base{}
+	for _, name := range c.names {
+		pricer = c.decorators[name](pricer)
+	}
+	c.pricer = pricer
+}
+
+// PriceSeatMap shows each seat of the map at its quoted price for the show
+func PriceSeatMap(pricer Pricer, show *models.Show, screen *models.Screen, seatMap *models.SeatMap) {
+	seatMap.Reprice(func(cell models.SeatMapCell) models.Money {
+		seat, err := screen.GetSeat(cell.SeatID)
+		if err != nil {
+			return cell.Price
+		}
+		return pricer.Quote(show, seat).Price
+	})
+}
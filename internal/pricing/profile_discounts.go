@@ -0,0 +1,63 @@
+package pricing
+
+import (
+	"bookmyshow-lld/internal/models"
+	"fmt"
+)
+
+// ProfileDiscount is what users verified in one discount category get off their seats
+type ProfileDiscount struct {
+	Percent       float64 `json:"percent"`         // Off the seat subtotal, below 100
+	MaxPerBooking float64 `json:"max_per_booking"` // In major units of the booking's currency; zero is uncapped
+	MaxPerMonth   float64 `json:"max_per_month"`   // Across the user's live bookings made in a calendar month; zero is uncapped
+}
+
+// ProfileDiscounts is the discount rule for verified discount profiles: a reduction per category, capped per
+// booking and per month. Categories it leaves out get nothing.
+type ProfileDiscounts map[models.DiscountCategory]ProfileDiscount
+
+// DefaultProfileDiscounts gives students 10% (up to 100 a booking, 300 a month), senior citizens 20% (up to
+// 150 a booking, 600 a month) and military personnel 15% (up to 200 a booking, 800 a month)
+func DefaultProfileDiscounts() ProfileDiscounts {
+	return ProfileDiscounts{
+		models.DiscountStudent:       {Percent: 10, MaxPerBooking: 100, MaxPerMonth: 300},
+		models.DiscountSeniorCitizen: {Percent: 20, MaxPerBooking: 150, MaxPerMonth: 600},
+		models.DiscountMilitary:      {Percent: 15, MaxPerBooking: 200, MaxPerMonth: 800},
+	}
+}
+
+// Validate checks every rule names a real category, takes off less than the whole price and has no negative cap
+func (d ProfileDiscounts) Validate() error {
+	for category, rule := range d {
+		if !category.IsValid() {
+			return fmt.Errorf("unknown discount category %q", category)
+		}
+		if rule.Percent < 0 || rule.Percent >= 100 || rule.MaxPerBooking < 0 || rule.MaxPerMonth < 0 {
+			return fmt.Errorf("discount for %s must be 0-99%% with caps of zero or more", category)
+		}
+	}
+	return nil
+}
+
+// Discount returns what a user verified in category saves on a seat subtotal, given how much their profile
+// already saved them this month. It is zero for categories without a rule and once the monthly cap is used up.
+func (d ProfileDiscounts) Discount(category models.DiscountCategory, subtotal, usedThisMonth models.Money) models.Money {
+	none := models.ZeroMoney(subtotal.Currency)
+	rule, ok := d[category]
+	if !ok || rule.Percent <= 0 {
+		return none
+	}
+
+	discount := subtotal.Percent(rule.Percent)
+	if rule.MaxPerBooking > 0 {
+		discount = discount.Min(models.MoneyFromMajor(rule.MaxPerBooking, subtotal.Currency))
+	}
+	if rule.MaxPerMonth > 0 {
+		left := models.MoneyFromMajor(rule.MaxPerMonth, subtotal.Currency).Sub(usedThisMonth)
+		if !left.IsPositive() {
+			return none
+		}
+		discount = discount.Min(left)
+	}
+	return discount
+}
@@ -0,0 +1,89 @@
+// Package ratelimit throttles callers with token buckets
+package ratelimit
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"sync"
+	"time"
+)
+
+// Limiter keeps a token bucket per key. Each bucket holds up to Requests tokens, refilled evenly over
+// Period; a request takes one, and is refused while the bucket is empty. It is safe for concurrent use.
+type Limiter struct {
+	limit models.RateLimit
+	clock clock.Clock
+
+	mutex     sync.Mutex
+	buckets   map[string]*bucket
+	lastPrune time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// New creates a limiter enforcing limit on every key
+func New(limit models.RateLimit, clock clock.Clock) *Limiter {
+	return &Limiter{
+		limit:     limit,
+		clock:     clock,
+		buckets:   make(map[string]*bucket),
+		lastPrune: clock.Now(),
+	}
+}
+
+// Allow takes a token from key's bucket. When it is empty the request is refused, with how long until
+// the next token; a disabled limit allows everything.
+func (l *Limiter) Allow(key string) (retryAfter time.Duration, ok bool) {
+	if !l.limit.Enabled() {
+		return 0, true
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.clock.Now()
+	l.prune(now)
+
+	b, found := l.buckets[key]
+	if !found {
+		b = &bucket{tokens: float64(l.limit.Requests), updated: now}
+		l.buckets[key] = b
+	}
+	l.refill(b, now)
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) * float64(l.interval())), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+// refill adds the tokens earned since the bucket was last touched
+func (l *Limiter) refill(b *bucket, now time.Time) {
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = min(float64(l.limit.Requests), b.tokens+float64(elapsed)/float64(l.interval()))
+		b.updated = now
+	}
+}
+
+// interval is how long one token takes to come back
+func (l *Limiter) interval() time.Duration {
+	return l.limit.Period / time.Duration(l.limit.Requests)
+}
+
+// prune drops buckets that have refilled, at most once a period; a full bucket is the same as none,
+// so callers who went quiet don't hold memory
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < l.limit.Period {
+		return
+	}
+	l.lastPrune = now
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= l.limit.Period {
+			delete(l.buckets, key)
+		}
+	}
+}
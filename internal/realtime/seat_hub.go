@@ -0,0 +1,126 @@
+package realtime
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"sync"
+	"time"
+)
+
+// subscriberBuffer is how many updates a subscriber may fall behind before it is dropped
+const subscriberBuffer = 64
+
+// SeatUpdate is one change to a show's seat availability
+type SeatUpdate struct {
+	ID        uint64            `json:"id"` // Increases with every update the hub sends
+	ShowID    string            `json:"show_id"`
+	SeatIDs   []string          `json:"seat_ids"`
+	Status    models.SeatStatus `json:"status"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// SeatHub fans seat updates out to everyone watching a show's seat map - demonstrates Publish-Subscribe
+type SeatHub struct {
+	subscribers map[string]map[chan SeatUpdate]struct{} // showID -> subscriber channels
+	nextID      uint64
+	closed      bool
+	clock       clock.Clock // Stamps each update
+	mutex       sync.Mutex
+}
+
+// NewSeatHub creates a hub with no subscribers
+func NewSeatHub(clock clock.Clock) *SeatHub {
+	return &SeatHub{
+		subscribers: make(map[string]map[chan SeatUpdate]struct{}),
+		clock:       clock,
+	}
+}
+
+// Subscribe starts receiving a show's seat updates; call cancel to stop.
+// The channel is closed on cancel, or early if the subscriber falls too far behind -
+// it should then reload the seat map and subscribe again. Once the hub is closed the channel comes closed.
+func (h *SeatHub) Subscribe(showID string) (<-chan SeatUpdate, func()) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	updates := make(chan SeatUpdate, subscriberBuffer)
+	if h.closed {
+		close(updates)
+		return updates, func() {}
+	}
+	if h.subscribers[showID] == nil {
+		h.subscribers[showID] = make(map[chan SeatUpdate]struct{})
+	}
+	h.subscribers[showID][updates] = struct{}{}
+
+	cancel := func() {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+		h.remove(showID, updates)
+	}
+	return updates, cancel
+}
+
+// Publish sends an update to the show's subscribers without ever blocking the publisher
+func (h *SeatHub) Publish(showID string, seatIDs []string, status models.SeatStatus) {
+	if len(seatIDs) == 0 {
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.subscribers[showID]) == 0 {
+		return
+	}
+
+	h.nextID++
+	update := SeatUpdate{
+		ID:        h.nextID,
+		ShowID:    showID,
+		SeatIDs:   seatIDs,
+		Status:    status,
+		Timestamp: h.clock.Now(),
+	}
+	for updates := range h.subscribers[showID] {
+		select {
+		case updates <- update:
+		default:
+			h.remove(showID, updates) // Too slow - a missed update would leave its seat map wrong
+		}
+	}
+}
+
+// Close ends every subscription, so streams of open seat maps finish and the server can shut down
+func (h *SeatHub) Close() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.closed = true
+	for showID, subscribers := range h.subscribers {
+		for updates := range subscribers {
+			h.remove(showID, updates)
+		}
+	}
+}
+
+// Subscribers returns how many clients are watching a show
+func (h *SeatHub) Subscribers(showID string) int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return len(h.subscribers[showID])
+}
+
+// remove closes a subscriber's channel once; callers must hold the mutex
+func (h *SeatHub) remove(showID string, updates chan SeatUpdate) {
+	if _, ok := h.subscribers[showID][updates]; !ok {
+		return
+	}
+
+	delete(h.subscribers[showID], updates)
+	if len(h.subscribers[showID]) == 0 {
+		delete(h.subscribers, showID)
+	}
+	close(updates)
+}
@@ -0,0 +1,73 @@
+package realtime
+
+import (
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/models"
+	"context"
+)
+
+// RegisterSeatSubscriber turns booking, hold and house seat events into seat updates on the hub - demonstrates Observer Pattern
+func RegisterSeatSubscriber(bus events.EventBus, hub *SeatHub) {
+	bus.Subscribe(events.EventSeatHoldCreated, func(ctx context.Context, event events.Event) error {
+		e := event.(events.SeatHoldCreated)
+		hub.Publish(e.ShowID, e.SeatIDs, models.SeatStatusBlocked)
+		return nil
+	})
+
+	bus.Subscribe(events.EventSeatHoldReleased, func(ctx context.Context, event events.Event) error {
+		e := event.(events.SeatHoldReleased)
+		hub.Publish(e.ShowID, e.SeatIDs, models.SeatStatusAvailable)
+		return nil
+	})
+
+	bus.Subscribe(events.EventBookingCreated, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingCreated)
+		hub.Publish(e.ShowID, e.SeatIDs, models.SeatStatusBlocked)
+		return nil
+	})
+
+	bus.Subscribe(events.EventBookingConfirmed, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingConfirmed)
+		hub.Publish(e.ShowID, e.SeatIDs, models.SeatStatusBooked)
+		return nil
+	})
+
+	bus.Subscribe(events.EventBookingCancelled, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingCancelled)
+		hub.Publish(e.ShowID, e.SeatIDs, models.SeatStatusAvailable)
+		return nil
+	})
+
+	bus.Subscribe(events.EventBookingModified, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingModified)
+		status := models.SeatStatusBlocked
+		if e.Status == models.BookingStatusConfirmed {
+			status = models.SeatStatusBooked
+		}
+		hub.Publish(e.ShowID, difference(e.OldSeatIDs, e.NewSeatIDs), models.SeatStatusAvailable)
+		hub.Publish(e.ShowID, difference(e.NewSeatIDs, e.OldSeatIDs), status)
+		return nil
+	})
+
+	bus.Subscribe(events.EventHouseSeatsChanged, func(ctx context.Context, event events.Event) error {
+		e := event.(events.HouseSeatsChanged)
+		hub.Publish(e.ShowID, e.SeatIDs, e.Status)
+		return nil
+	})
+}
+
+// difference returns the seats in a that are not in b
+func difference(a, b []string) []string {
+	exclude := make(map[string]bool, len(b))
+	for _, id := range b {
+		exclude[id] = true
+	}
+
+	var result []string
+	for _, id := range a {
+		if !exclude[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
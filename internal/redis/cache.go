@@ -0,0 +1,122 @@
+package redis
+
+import (
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// readThrough serves key from Redis, falling back to load and caching the result - demonstrates Cache-Aside.
+// Redis failures are logged and treated as misses so the cache can never take reads down.
+func readThrough[T any](ctx context.Context, client *goredis.Client, logger logging.Logger, key string, ttl time.Duration, load func() (*T, error)) (*T, error) {
+	data, err := client.Get(ctx, key).Bytes()
+	if err == nil {
+		var cached T
+		if err := json.Unmarshal(data, &cached); err == nil {
+			return &cached, nil
+		}
+	} else if !errors.Is(err, goredis.Nil) {
+		logger.Warn(ctx, "cache read failed", "key", key, "error", err)
+	}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(value); err == nil {
+		if err := client.Set(ctx, key, data, ttl).Err(); err != nil {
+			logger.Warn(ctx, "cache write failed", "key", key, "error", err)
+		}
+	}
+	return value, nil
+}
+
+// invalidate drops key after a write so the next read reloads it
+func invalidate(ctx context.Context, client *goredis.Client, logger logging.Logger, key string) {
+	if err := client.Del(ctx, key).Err(); err != nil {
+		logger.Warn(ctx, "cache invalidation failed", "key", key, "error", err)
+	}
+}
+
+// cacheTTL falls back to DefaultCacheTTL when none is configured
+func cacheTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return DefaultCacheTTL
+	}
+	return ttl
+}
+
+// CachedMovieRepository decorates a MovieRepository with a read-through cache - demonstrates Decorator Pattern
+type CachedMovieRepository struct {
+	repositories.MovieRepository
+	client *goredis.Client
+	logger logging.Logger
+	keys   keyspace
+	ttl    time.Duration
+}
+
+// NewCachedMovieRepository caches GetByID lookups of repo for ttl; Redis failures are logged to logger
+func NewCachedMovieRepository(repo repositories.MovieRepository, client *goredis.Client, prefix string, ttl time.Duration, logger logging.Logger) repositories.MovieRepository {
+	return &CachedMovieRepository{
+		MovieRepository: repo,
+		client:          client,
+		logger:          logger,
+		keys:            newKeyspace(prefix),
+		ttl:             cacheTTL(ttl),
+	}
+}
+
+func (r *CachedMovieRepository) GetByID(ctx context.Context, id string) (*models.Movie, error) {
+	return readThrough(ctx, r.client, r.logger, r.keys.key("cache", "movie", id), r.ttl, func() (*models.Movie, error) {
+		return r.MovieRepository.GetByID(ctx, id)
+	})
+}
+
+func (r *CachedMovieRepository) Update(ctx context.Context, movie *models.Movie) error {
+	if err := r.MovieRepository.Update(ctx, movie); err != nil {
+		return err
+	}
+	invalidate(ctx, r.client, r.logger, r.keys.key("cache", "movie", movie.ID))
+	return nil
+}
+
+// CachedTheatreRepository decorates a TheatreRepository with a read-through cache - demonstrates Decorator Pattern
+type CachedTheatreRepository struct {
+	repositories.TheatreRepository
+	client *goredis.Client
+	logger logging.Logger
+	keys   keyspace
+	ttl    time.Duration
+}
+
+// NewCachedTheatreRepository caches GetByID lookups of repo for ttl; Redis failures are logged to logger
+func NewCachedTheatreRepository(repo repositories.TheatreRepository, client *goredis.Client, prefix string, ttl time.Duration, logger logging.Logger) repositories.TheatreRepository {
+	return &CachedTheatreRepository{
+		TheatreRepository: repo,
+		client:            client,
+		logger:            logger,
+		keys:              newKeyspace(prefix),
+		ttl:               cacheTTL(ttl),
+	}
+}
+
+func (r *CachedTheatreRepository) GetByID(ctx context.Context, id string) (*models.Theatre, error) {
+	return readThrough(ctx, r.client, r.logger, r.keys.key("cache", "theatre", id), r.ttl, func() (*models.Theatre, error) {
+		return r.TheatreRepository.GetByID(ctx, id)
+	})
+}
+
+func (r *CachedTheatreRepository) Update(ctx context.Context, theatre *models.Theatre) error {
+	if err := r.TheatreRepository.Update(ctx, theatre); err != nil {
+		return err
+	}
+	invalidate(ctx, r.client, r.logger, r.keys.key("cache", "theatre", theatre.ID))
+	return nil
+}
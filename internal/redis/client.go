@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// DefaultKeyPrefix namespaces every key this package writes
+const DefaultKeyPrefix = "bms"
+
+// DefaultCacheTTL bounds how stale a cached movie or theatre can get
+const DefaultCacheTTL = 5 * time.Minute
+
+// Config selects the Redis server and how it is used; an empty Addr keeps everything in memory
+type Config struct {
+	Addr      string
+	Password  string
+	DB        int
+	KeyPrefix string
+	CacheTTL  time.Duration
+}
+
+// ConfigFromEnv reads REDIS_ADDR, REDIS_PASSWORD, REDIS_DB, REDIS_KEY_PREFIX and REDIS_CACHE_TTL
+func ConfigFromEnv() Config {
+	config := Config{
+		Addr:      os.Getenv("REDIS_ADDR"),
+		Password:  os.Getenv("REDIS_PASSWORD"),
+		KeyPrefix: os.Getenv("REDIS_KEY_PREFIX"),
+	}
+	if db, err := strconv.Atoi(os.Getenv("REDIS_DB")); err == nil {
+		config.DB = db
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("REDIS_CACHE_TTL")); err == nil {
+		config.CacheTTL = ttl
+	}
+	return config
+}
+
+// Enabled reports whether a Redis server is configured
+func (c Config) Enabled() bool {
+	return c.Addr != ""
+}
+
+// Connect opens a client and checks the server answers
+func Connect(ctx context.Context, config Config) (*goredis.Client, error) {
+	client := goredis.NewClient(&goredis.Options{
+		Addr:     config.Addr,
+		Password: config.Password,
+		DB:       config.DB,
+	})
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("redis %s unreachable: %w", config.Addr, err)
+	}
+	return client, nil
+}
+
+// keyspace builds namespaced keys, e.g. "bms:hold:<id>"
+type keyspace string
+
+func newKeyspace(prefix string) keyspace {
+	if prefix == "" {
+		prefix = DefaultKeyPrefix
+	}
+	return keyspace(prefix)
+}
+
+func (k keyspace) key(parts ...string) string {
+	key := string(k)
+	for _, part := range parts {
+		key += ":" + part
+	}
+	return key
+}
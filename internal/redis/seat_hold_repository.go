@@ -0,0 +1,183 @@
+package redis
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// holdRetention keeps finished and lapsed holds around long enough for the expiry worker
+// to release their seats; Redis then drops the key on its own
+const holdRetention = time.Hour
+
+// updateHoldScript rewrites a hold unless it already reached a different final status,
+// so two instances can't both consume, release or expire the same hold
+var updateHoldScript = goredis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if not current then
+	return -1
+end
+local status = cjson.decode(current).status
+if status ~= 'ACTIVE' and status ~= ARGV[2] then
+	return -2
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+if ARGV[2] == 'ACTIVE' then
+	redis.call('ZADD', KEYS[2], ARGV[4], ARGV[5])
+else
+	redis.call('ZREM', KEYS[2], ARGV[5])
+end
+return 1
+`)
+
+// SeatHoldRepository implements repositories.SeatHoldRepository on Redis - holds are shared by
+// every app instance and expire through key TTLs instead of growing an in-process map
+type SeatHoldRepository struct {
+	client *goredis.Client
+	keys   keyspace
+	clock  clock.Clock // How long active holds have left
+}
+
+// NewSeatHoldRepository creates a Redis-backed seat hold store
+func NewSeatHoldRepository(client *goredis.Client, prefix string, clock clock.Clock) repositories.SeatHoldRepository {
+	return &SeatHoldRepository{
+		client: client,
+		keys:   newKeyspace(prefix),
+		clock:  clock,
+	}
+}
+
+func (r *SeatHoldRepository) Create(ctx context.Context, hold *models.SeatHold) error {
+	data, err := json.Marshal(hold)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Set(ctx, r.holdKey(hold.ID), data, r.ttl(hold))
+		pipe.ZAdd(ctx, r.activeKey(), goredis.Z{Score: expiryScore(hold), Member: hold.ID})
+		return nil
+	})
+	return err
+}
+
+func (r *SeatHoldRepository) GetByID(ctx context.Context, id string) (*models.SeatHold, error) {
+	data, err := r.client.Get(ctx, r.holdKey(id)).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, models.ErrSeatHoldNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeHold(data)
+}
+
+func (r *SeatHoldRepository) Update(ctx context.Context, hold *models.SeatHold) error {
+	data, err := json.Marshal(hold)
+	if err != nil {
+		return err
+	}
+
+	result, err := updateHoldScript.Run(ctx, r.client,
+		[]string{r.holdKey(hold.ID), r.activeKey()},
+		data, string(hold.GetStatus()), r.ttl(hold).Milliseconds(), expiryScore(hold), hold.ID,
+	).Int()
+	if err != nil {
+		return err
+	}
+
+	switch result {
+	case -1:
+		return models.ErrSeatHoldNotFound
+	case -2:
+		return models.ErrSeatHoldNotActive
+	}
+	return nil
+}
+
+// GetActive reads the active index; IDs whose keys already expired are pruned as they are found
+func (r *SeatHoldRepository) GetActive(ctx context.Context) ([]*models.SeatHold, error) {
+	ids, err := r.client.ZRange(ctx, r.activeKey(), 0, -1).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = r.holdKey(id)
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var holds []*models.SeatHold
+	var gone []interface{}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			gone = append(gone, ids[i])
+			continue
+		}
+
+		hold, err := decodeHold([]byte(data))
+		if err != nil {
+			return nil, err
+		}
+		if hold.GetStatus() == models.SeatHoldStatusActive {
+			holds = append(holds, hold)
+		}
+	}
+
+	if len(gone) > 0 {
+		r.client.ZRem(ctx, r.activeKey(), gone...)
+	}
+	return holds, nil
+}
+
+// List returns the active holds; finished ones only live until their keys expire
+func (r *SeatHoldRepository) List(ctx context.Context) ([]*models.SeatHold, error) {
+	return r.GetActive(ctx)
+}
+
+// ttl keeps active holds until their expiry plus the retention window
+func (r *SeatHoldRepository) ttl(hold *models.SeatHold) time.Duration {
+	if hold.GetStatus() != models.SeatHoldStatusActive {
+		return holdRetention
+	}
+
+	remaining := hold.ExpiresAt.Sub(r.clock.Now())
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining + holdRetention
+}
+
+func (r *SeatHoldRepository) holdKey(id string) string {
+	return r.keys.key("hold", id)
+}
+
+func (r *SeatHoldRepository) activeKey() string {
+	return r.keys.key("holds", "active")
+}
+
+// expiryScore orders the active index by expiry so it can be inspected with ZRANGEBYSCORE
+func expiryScore(hold *models.SeatHold) float64 {
+	return float64(hold.ExpiresAt.Unix())
+}
+
+func decodeHold(data []byte) (*models.SeatHold, error) {
+	var hold models.SeatHold
+	if err := json.Unmarshal(data, &hold); err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+)
+
+// MemoryAuditRepository implements AuditRepository - demonstrates Repository Pattern
+type MemoryAuditRepository struct {
+	*MemoryRepository[*models.AuditEntry]
+	byEntity map[string][]string // entityID -> entry IDs in the order recorded, guarded by the embedded repository's lock
+}
+
+func NewMemoryAuditRepository() AuditRepository {
+	return &MemoryAuditRepository{
+		MemoryRepository: NewMemoryRepository[*models.AuditEntry](models.ErrAuditEntryNotFound),
+		byEntity:         make(map[string][]string),
+	}
+}
+
+func (r *MemoryAuditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	return r.write(func(entries map[string]*models.AuditEntry) error {
+		entries[entry.ID] = entry
+		r.byEntity[entry.EntityID] = append(r.byEntity[entry.EntityID], entry.ID)
+		return nil
+	})
+}
+
+func (r *MemoryAuditRepository) GetByEntityID(ctx context.Context, entityID string) ([]*models.AuditEntry, error) {
+	history := make([]*models.AuditEntry, 0)
+	r.read(func(entries map[string]*models.AuditEntry) {
+		for _, id := range r.byEntity[entityID] {
+			history = append(history, entries[id])
+		}
+	})
+	return history, nil
+}
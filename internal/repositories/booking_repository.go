@@ -2,151 +2,254 @@ package repositories
 
 import (
 	"bookmyshow-lld/internal/models"
-	"sync"
+	"context"
+	"sort"
 	"time"
 )
 
 // MemoryShowRepository implements ShowRepository - demonstrates Repository Pattern
 type MemoryShowRepository struct {
-	shows map[string]*models.Show
-	mutex sync.RWMutex
+	*MemoryRepository[*models.Show]
 }
 
 func NewMemoryShowRepository() ShowRepository {
-	return &MemoryShowRepository{
-		shows: make(map[string]*models.Show),
-	}
+	return &MemoryShowRepository{NewMemoryRepository[*models.Show](models.ErrShowNotFound)}
 }
 
-func (r *MemoryShowRepository) Create(show *models.Show) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
-	r.shows[show.ID] = show
-	return nil
+func (r *MemoryShowRepository) GetByMovieID(ctx context.Context, movieID string) ([]*models.Show, error) {
+	return r.filter(func(show *models.Show) bool { return show.MovieID == movieID }), nil
 }
 
-func (r *MemoryShowRepository) GetByID(id string) (*models.Show, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
-	show, exists := r.shows[id]
-	if !exists {
-		return nil, models.ErrShowNotFound
-	}
-	return show, nil
+func (r *MemoryShowRepository) GetByTheatreBetween(ctx context.Context, theatreID string, from, to time.Time) ([]*models.Show, error) {
+	return r.filter(func(show *models.Show) bool {
+		return show.TheatreID == theatreID && !show.StartTime.Before(from) && show.StartTime.Before(to)
+	}), nil
 }
 
-func (r *MemoryShowRepository) GetByMovieID(movieID string) ([]*models.Show, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
+func (r *MemoryShowRepository) GetAwaitingCloseOut(ctx context.Context, endedBy time.Time) ([]*models.Show, error) {
+	shows := r.filter(func(show *models.Show) bool {
+		return show.Status == models.ShowStatusScheduled && !show.EndTime.After(endedBy)
+	})
+	sort.Slice(shows, func(i, j int) bool { return shows[i].EndTime.Before(shows[j].EndTime) })
+	return shows, nil
+}
 
-	var shows []*models.Show
-	for _, show := range r.shows {
-		if show.MovieID == movieID {
-			shows = append(shows, show)
-		}
-	}
+func (r *MemoryShowRepository) GetStartingBetween(ctx context.Context, from, to time.Time) ([]*models.Show, error) {
+	shows := r.filter(func(show *models.Show) bool {
+		return show.Status == models.ShowStatusScheduled && !show.StartTime.Before(from) && show.StartTime.Before(to)
+	})
+	sort.Slice(shows, func(i, j int) bool { return shows[i].StartTime.Before(shows[j].StartTime) })
 	return shows, nil
 }
 
-func (r *MemoryShowRepository) CheckConflict(screenID string, startTime, endTime time.Time) (bool, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
+func (r *MemoryShowRepository) GetByEventID(ctx context.Context, eventID string) ([]*models.Show, error) {
+	return r.filter(func(show *models.Show) bool { return show.EventID == eventID }), nil
+}
 
-	for _, show := range r.shows {
-		if show.ScreenID == screenID {
-			// Check for time overlap - demonstrates business rules
-			if startTime.Before(show.EndTime) && endTime.After(show.StartTime) {
-				return true, nil
-			}
+func (r *MemoryShowRepository) CheckConflict(ctx context.Context, screenID string, startTime, endTime time.Time, excludeShowID string) (bool, error) {
+	conflicts := r.filter(func(show *models.Show) bool {
+		// Cancelled shows free their slot
+		if show.ID == excludeShowID || show.IsCancelled() || show.ScreenID != screenID {
+			return false
 		}
-	}
-	return false, nil
+		// Check for time overlap - demonstrates business rules
+		return startTime.Before(show.EndTime) && endTime.After(show.StartTime)
+	})
+	return len(conflicts) > 0, nil
 }
 
 // MemoryBookingRepository implements BookingRepository - demonstrates Repository Pattern
 type MemoryBookingRepository struct {
-	bookings map[string]*models.Booking
-	mutex    sync.RWMutex
+	*MemoryRepository[*models.Booking]
+	references map[string]string // reference -> bookingID, guarded by the embedded repository's lock
 }
 
 func NewMemoryBookingRepository() BookingRepository {
 	return &MemoryBookingRepository{
-		bookings: make(map[string]*models.Booking),
+		MemoryRepository: NewMemoryRepository[*models.Booking](models.ErrBookingNotFound),
+		references:       make(map[string]string),
 	}
 }
 
-func (r *MemoryBookingRepository) Create(booking *models.Booking) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+func (r *MemoryBookingRepository) Create(ctx context.Context, booking *models.Booking) error {
+	return r.write(func(bookings map[string]*models.Booking) error {
+		if _, taken := r.references[booking.Reference]; taken {
+			return models.ErrDuplicateBookingReference
+		}
 
-	r.bookings[booking.ID] = booking
-	return nil
+		bookings[booking.ID] = booking
+		r.references[booking.Reference] = booking.ID
+		return nil
+	})
 }
 
-func (r *MemoryBookingRepository) GetByID(id string) (*models.Booking, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
+// Delete removes a booking and frees its reference
+func (r *MemoryBookingRepository) Delete(ctx context.Context, id string) error {
+	return r.write(func(bookings map[string]*models.Booking) error {
+		if booking, exists := bookings[id]; exists {
+			delete(r.references, booking.Reference)
+			delete(bookings, id)
+		}
+		return nil
+	})
+}
 
-	booking, exists := r.bookings[id]
-	if !exists {
+func (r *MemoryBookingRepository) GetByReference(ctx context.Context, reference string) (*models.Booking, error) {
+	var booking *models.Booking
+	r.read(func(bookings map[string]*models.Booking) {
+		if id, exists := r.references[models.NormalizeBookingReference(reference)]; exists {
+			booking = bookings[id]
+		}
+	})
+	if booking == nil {
 		return nil, models.ErrBookingNotFound
 	}
 	return booking, nil
 }
 
-func (r *MemoryBookingRepository) Update(booking *models.Booking) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+func (r *MemoryBookingRepository) GetByUserID(ctx context.Context, userID string, page Page) ([]*models.Booking, int, error) {
+	bookings := r.filter(func(booking *models.Booking) bool { return booking.UserID == userID })
+
+	// Newest first, ID as tie-breaker so pages are stable
+	sort.Slice(bookings, func(i, j int) bool {
+		if !bookings[i].CreatedAt.Equal(bookings[j].CreatedAt) {
+			return bookings[i].CreatedAt.After(bookings[j].CreatedAt)
+		}
+		return bookings[i].ID < bookings[j].ID
+	})
+
+	return paginate(bookings, page), len(bookings), nil
+}
 
-	if _, exists := r.bookings[booking.ID]; !exists {
-		return models.ErrBookingNotFound
+func (r *MemoryBookingRepository) GetByShowID(ctx context.Context, showID string) ([]*models.Booking, error) {
+	return r.filter(func(booking *models.Booking) bool { return booking.ShowID == showID }), nil
+}
+
+func (r *MemoryBookingRepository) GetPendingTransfersTo(ctx context.Context, userID string) ([]*models.Booking, error) {
+	bookings := r.filter(func(booking *models.Booking) bool {
+		transfer, pending := booking.PendingTransfer()
+		return pending && transfer.ToUserID == userID
+	})
+
+	offeredAt := func(booking *models.Booking) time.Time {
+		transfer, _ := booking.PendingTransfer()
+		return transfer.OfferedAt
 	}
+	sort.Slice(bookings, func(i, j int) bool {
+		return offeredAt(bookings[i]).Before(offeredAt(bookings[j]))
+	})
+	return bookings, nil
+}
+
+func (r *MemoryBookingRepository) GetSeatIDsByStatus(ctx context.Context, showID string, status models.BookingStatus) ([]string, error) {
+	bookings := r.filter(func(booking *models.Booking) bool {
+		return booking.ShowID == showID && booking.GetStatus() == status
+	})
 
-	r.bookings[booking.ID] = booking
-	return nil
+	var seatIDs []string
+	for _, booking := range bookings {
+		seatIDs = append(seatIDs, booking.SeatIDs...)
+	}
+	return seatIDs, nil
+}
+
+// paginate returns the slice window selected by page
+func paginate[T any](items []T, page Page) []T {
+	if page.Offset >= len(items) {
+		return nil
+	}
+	end := len(items)
+	if page.Limit > 0 && page.Offset+page.Limit < end {
+		end = page.Offset + page.Limit
+	}
+	return items[page.Offset:end]
 }
 
 // MemoryPaymentRepository implements PaymentRepository - demonstrates Repository Pattern
 type MemoryPaymentRepository struct {
-	payments map[string]*models.Payment
-	mutex    sync.RWMutex
+	*MemoryRepository[*models.Payment]
 }
 
 func NewMemoryPaymentRepository() PaymentRepository {
-	return &MemoryPaymentRepository{
-		payments: make(map[string]*models.Payment),
+	return &MemoryPaymentRepository{NewMemoryRepository[*models.Payment](models.ErrPaymentNotFound)}
+}
+
+func (r *MemoryPaymentRepository) GetSettledByBookingIDs(ctx context.Context, bookingIDs []string) ([]*models.Payment, error) {
+	wanted := make(map[string]bool, len(bookingIDs))
+	for _, id := range bookingIDs {
+		wanted[id] = true
 	}
+
+	return r.filter(func(payment *models.Payment) bool { return wanted[payment.BookingID] && isSettled(payment) }), nil
 }
 
-func (r *MemoryPaymentRepository) Create(payment *models.Payment) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+func (r *MemoryPaymentRepository) GetSettledBetween(ctx context.Context, from, to time.Time) ([]*models.Payment, error) {
+	payments := r.filter(func(payment *models.Payment) bool {
+		return isSettled(payment) && !payment.ProcessedAt.Before(from) && payment.ProcessedAt.Before(to)
+	})
 
-	r.payments[payment.ID] = payment
-	return nil
+	sort.Slice(payments, func(i, j int) bool {
+		if !payments[i].ProcessedAt.Equal(*payments[j].ProcessedAt) {
+			return payments[i].ProcessedAt.Before(*payments[j].ProcessedAt)
+		}
+		return payments[i].ID < payments[j].ID
+	})
+	return payments, nil
 }
 
-func (r *MemoryPaymentRepository) GetByID(id string) (*models.Payment, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
+func (r *MemoryPaymentRepository) GetAwaitingConfirmation(ctx context.Context) ([]*models.Payment, error) {
+	payments := r.filter(func(payment *models.Payment) bool { return payment.IsAwaitingConfirmation() })
 
-	payment, exists := r.payments[id]
-	if !exists {
-		return nil, models.ErrPaymentNotFound
-	}
-	return payment, nil
+	sort.Slice(payments, func(i, j int) bool {
+		if !payments[i].CreatedAt.Equal(payments[j].CreatedAt) {
+			return payments[i].CreatedAt.Before(payments[j].CreatedAt)
+		}
+		return payments[i].ID < payments[j].ID
+	})
+	return payments, nil
 }
 
-func (r *MemoryPaymentRepository) Update(payment *models.Payment) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+func (r *MemoryPaymentRepository) GetByTransactionID(ctx context.Context, transactionID string) (*models.Payment, error) {
+	return r.find(func(payment *models.Payment) bool {
+		return transactionID != "" && payment.TransactionID == transactionID
+	})
+}
+
+func (r *MemoryPaymentRepository) GetByUserSince(ctx context.Context, userID string, since time.Time) ([]*models.Payment, error) {
+	payments := r.filter(func(payment *models.Payment) bool {
+		return payment.UserID == userID && !payment.CreatedAt.Before(since)
+	})
+
+	sort.Slice(payments, func(i, j int) bool {
+		if !payments[i].CreatedAt.Equal(payments[j].CreatedAt) {
+			return payments[i].CreatedAt.Before(payments[j].CreatedAt)
+		}
+		return payments[i].ID < payments[j].ID
+	})
+	return payments, nil
+}
 
-	if _, exists := r.payments[payment.ID]; !exists {
-		return models.ErrPaymentNotFound
+// isSettled reports whether money was taken, including payments refunded since
+func isSettled(payment *models.Payment) bool {
+	if payment.ProcessedAt == nil {
+		return false
+	}
+	switch payment.Status {
+	case models.PaymentStatusSuccess, models.PaymentStatusPartiallyRefunded, models.PaymentStatusRefunded:
+		return true
 	}
+	return false
+}
+
+// MemoryRefundRepository implements RefundRepository - demonstrates Repository Pattern
+type MemoryRefundRepository struct {
+	*MemoryRepository[*models.Refund]
+}
+
+func NewMemoryRefundRepository() RefundRepository {
+	return &MemoryRefundRepository{NewMemoryRepository[*models.Refund](models.ErrRefundNotFound)}
+}
 
-	r.payments[payment.ID] = payment
-	return nil
+func (r *MemoryRefundRepository) GetByPaymentID(ctx context.Context, paymentID string) ([]*models.Refund, error) {
+	return r.filter(func(refund *models.Refund) bool { return refund.PaymentID == paymentID }), nil
 }
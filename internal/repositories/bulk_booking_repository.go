@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sort"
+)
+
+// MemoryBulkBookingRepository implements BulkBookingRepository - demonstrates Repository Pattern
+type MemoryBulkBookingRepository struct {
+	*MemoryRepository[*models.BulkBooking]
+}
+
+func NewMemoryBulkBookingRepository() BulkBookingRepository {
+	return &MemoryBulkBookingRepository{NewMemoryRepository[*models.BulkBooking](models.ErrBulkBookingNotFound)}
+}
+
+func (r *MemoryBulkBookingRepository) GetByCode(ctx context.Context, code string) (*models.BulkBooking, error) {
+	bulk, err := r.find(func(bulk *models.BulkBooking) bool { return bulk.HasCode(code) })
+	if err != nil {
+		return nil, models.ErrBulkCodeNotFound
+	}
+	return bulk, nil
+}
+
+func (r *MemoryBulkBookingRepository) GetByAccountID(ctx context.Context, accountID string) ([]*models.BulkBooking, error) {
+	blocks := r.filter(func(bulk *models.BulkBooking) bool { return bulk.AccountID == accountID })
+	sort.Slice(blocks, func(i, j int) bool {
+		if !blocks[i].CreatedAt.Equal(blocks[j].CreatedAt) {
+			return blocks[i].CreatedAt.After(blocks[j].CreatedAt)
+		}
+		return blocks[i].ID < blocks[j].ID
+	})
+	return blocks, nil
+}
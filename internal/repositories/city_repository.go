@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sort"
+	"strings"
+)
+
+// MemoryCityRepository implements CityRepository - demonstrates Repository Pattern
+type MemoryCityRepository struct {
+	*MemoryRepository[*models.City]
+	byName map[string]string // lower-cased name -> city ID, guarded by the embedded repository's lock
+}
+
+func NewMemoryCityRepository() CityRepository {
+	return &MemoryCityRepository{
+		MemoryRepository: NewMemoryRepository[*models.City](models.ErrCityNotFound),
+		byName:           make(map[string]string),
+	}
+}
+
+func (r *MemoryCityRepository) Create(ctx context.Context, city *models.City) error {
+	return r.write(func(cities map[string]*models.City) error {
+		key := strings.ToLower(city.Name)
+		if _, exists := r.byName[key]; exists {
+			return models.ErrCityAlreadyExists
+		}
+
+		cities[city.ID] = city
+		r.byName[key] = city.ID
+		return nil
+	})
+}
+
+func (r *MemoryCityRepository) GetByName(ctx context.Context, name string) (*models.City, error) {
+	var city *models.City
+	r.read(func(cities map[string]*models.City) {
+		if id, exists := r.byName[strings.ToLower(strings.TrimSpace(name))]; exists {
+			city = cities[id]
+		}
+	})
+	if city == nil {
+		return nil, models.ErrCityNotFound
+	}
+	return city, nil
+}
+
+func (r *MemoryCityRepository) GetAll(ctx context.Context) ([]*models.City, error) {
+	cities, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(cities, func(i, j int) bool { return cities[i].Name < cities[j].Name })
+	return cities, nil
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sort"
+)
+
+// MemoryEventRepository implements EventRepository - demonstrates Repository Pattern
+type MemoryEventRepository struct {
+	*MemoryRepository[*models.Event]
+}
+
+func NewMemoryEventRepository() EventRepository {
+	return &MemoryEventRepository{NewMemoryRepository[*models.Event](models.ErrEventNotFound)}
+}
+
+func (r *MemoryEventRepository) GetByType(ctx context.Context, eventType models.EventType) ([]*models.Event, error) {
+	events := r.filter(func(event *models.Event) bool { return eventType == "" || event.Type == eventType })
+
+	sort.Slice(events, func(i, j int) bool { return events[i].Title < events[j].Title })
+	return events, nil
+}
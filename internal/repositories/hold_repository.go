@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+)
+
+// MemorySeatHoldRepository implements SeatHoldRepository - demonstrates Repository Pattern
+type MemorySeatHoldRepository struct {
+	*MemoryRepository[*models.SeatHold]
+}
+
+func NewMemorySeatHoldRepository() SeatHoldRepository {
+	return &MemorySeatHoldRepository{NewMemoryRepository[*models.SeatHold](models.ErrSeatHoldNotFound)}
+}
+
+func (r *MemorySeatHoldRepository) GetActive(ctx context.Context) ([]*models.SeatHold, error) {
+	return r.filter(func(hold *models.SeatHold) bool { return hold.GetStatus() == models.SeatHoldStatusActive }), nil
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sort"
+)
+
+// MemoryPaymentInstrumentRepository implements PaymentInstrumentRepository - demonstrates Repository Pattern
+type MemoryPaymentInstrumentRepository struct {
+	*MemoryRepository[*models.PaymentInstrument]
+}
+
+func NewMemoryPaymentInstrumentRepository() PaymentInstrumentRepository {
+	return &MemoryPaymentInstrumentRepository{NewMemoryRepository[*models.PaymentInstrument](models.ErrPaymentInstrumentNotFound)}
+}
+
+func (r *MemoryPaymentInstrumentRepository) GetByUserID(ctx context.Context, userID string) ([]*models.PaymentInstrument, error) {
+	instruments := r.filter(func(instrument *models.PaymentInstrument) bool { return instrument.UserID == userID })
+	sort.Slice(instruments, func(i, j int) bool {
+		if !instruments[i].CreatedAt.Equal(instruments[j].CreatedAt) {
+			return instruments[i].CreatedAt.Before(instruments[j].CreatedAt)
+		}
+		return instruments[i].ID < instruments[j].ID
+	})
+	return instruments, nil
+}
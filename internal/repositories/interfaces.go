@@ -2,54 +2,300 @@ package repositories
 
 import (
 	"bookmyshow-lld/internal/models"
+	"context"
 	"time"
 )
 
 // UserRepository defines core user data access operations
 type UserRepository interface {
-	Create(user *models.User) error
-	GetByID(id string) (*models.User, error)
+	Create(ctx context.Context, user *models.User) error
+	GetByID(ctx context.Context, id string) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	Update(ctx context.Context, user *models.User) error // Needed for role changes
+	List(ctx context.Context) ([]*models.User, error)    // Everything, for state snapshots
+}
+
+// CredentialRepository stores password hashes, one per user
+type CredentialRepository interface {
+	Save(ctx context.Context, credential *models.Credential) error // Creates or replaces the user's credential
+	GetByUserID(ctx context.Context, userID string) (*models.Credential, error)
+	List(ctx context.Context) ([]*models.Credential, error) // Everything, for state snapshots
+}
+
+// SessionRepository stores signed-in sessions by token hash
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	GetByID(ctx context.Context, id string) (*models.Session, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*models.Session, error) // Everything, for state snapshots
 }
 
 // MovieRepository defines core movie data access operations
 type MovieRepository interface {
-	Create(movie *models.Movie) error
-	GetByID(id string) (*models.Movie, error)
-	GetReleased() ([]*models.Movie, error) // For demo
+	Create(ctx context.Context, movie *models.Movie) error
+	GetByID(ctx context.Context, id string) (*models.Movie, error)
+	GetByExternalID(ctx context.Context, externalID string) (*models.Movie, error) // Catalog imports upsert on it
+	GetReleased(ctx context.Context, now time.Time) ([]*models.Movie, error)       // For demo
+	Update(ctx context.Context, movie *models.Movie) error                         // Needed for rating aggregation
+	List(ctx context.Context) ([]*models.Movie, error)                             // Everything, for state snapshots
+}
+
+// EventRepository defines live event (concert, play, stand-up) data access operations
+type EventRepository interface {
+	Create(ctx context.Context, event *models.Event) error
+	GetByID(ctx context.Context, id string) (*models.Event, error)
+	GetByType(ctx context.Context, eventType models.EventType) ([]*models.Event, error) // Empty type matches all live events
+	List(ctx context.Context) ([]*models.Event, error)                                  // Everything, for state snapshots
+}
+
+// ReviewRepository defines movie review data access operations
+type ReviewRepository interface {
+	Create(ctx context.Context, review *models.Review) error
+	GetByID(ctx context.Context, id string) (*models.Review, error)
+	GetByMovieID(ctx context.Context, movieID string, status models.ReviewStatus, page Page) ([]*models.Review, int, error) // Newest first; empty status matches all
+	GetByUserAndMovie(ctx context.Context, userID, movieID string) (*models.Review, error)
+	Update(ctx context.Context, review *models.Review) error
+	List(ctx context.Context) ([]*models.Review, error) // Everything, for state snapshots
+}
+
+// WatchlistRepository stores the movies users want to see
+type WatchlistRepository interface {
+	Create(ctx context.Context, entry *models.WatchlistEntry) error
+	GetByID(ctx context.Context, id string) (*models.WatchlistEntry, error)
+	GetByUserID(ctx context.Context, userID string) ([]*models.WatchlistEntry, error) // Newest first
+	GetByUserAndMovie(ctx context.Context, userID, movieID string) (*models.WatchlistEntry, error)
+	GetUnreminded(ctx context.Context) ([]*models.WatchlistEntry, error) // Entries still waiting for a show, oldest first
+	Update(ctx context.Context, entry *models.WatchlistEntry) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*models.WatchlistEntry, error) // Everything, for state snapshots
+}
+
+// AuditRepository stores the append-only audit trail
+type AuditRepository interface {
+	Create(ctx context.Context, entry *models.AuditEntry) error
+	GetByEntityID(ctx context.Context, entityID string) ([]*models.AuditEntry, error) // Oldest first
+	List(ctx context.Context) ([]*models.AuditEntry, error)                           // Everything, for state snapshots
+}
+
+// SettlementRepository defines theatre payout data access operations
+type SettlementRepository interface {
+	Create(ctx context.Context, settlement *models.Settlement) error
+	GetByID(ctx context.Context, id string) (*models.Settlement, error)
+	GetByTheatreID(ctx context.Context, theatreID string) ([]*models.Settlement, error) // Oldest period first
+	Update(ctx context.Context, settlement *models.Settlement) error
+	List(ctx context.Context) ([]*models.Settlement, error) // Everything, for state snapshots
 }
 
 // TheatreRepository defines core theatre data access operations
 type TheatreRepository interface {
-	Create(theatre *models.Theatre) error
-	GetByID(id string) (*models.Theatre, error)
-	Update(theatre *models.Theatre) error // Needed for adding screens
+	Create(ctx context.Context, theatre *models.Theatre) error
+	GetByID(ctx context.Context, id string) (*models.Theatre, error)
+	Update(ctx context.Context, theatre *models.Theatre) error             // Needed for adding screens
+	GetByCity(ctx context.Context, city string) ([]*models.Theatre, error) // Case-insensitive, sorted by name
+	GetAll(ctx context.Context) ([]*models.Theatre, error)                 // For nearby search
+}
+
+// CityRepository defines the city catalog; names are unique ignoring case
+type CityRepository interface {
+	Create(ctx context.Context, city *models.City) error
+	GetByID(ctx context.Context, id string) (*models.City, error)
+	GetByName(ctx context.Context, name string) (*models.City, error)
+	GetAll(ctx context.Context) ([]*models.City, error) // Sorted by name
 }
 
 // ScreenRepository defines core screen data access operations
 type ScreenRepository interface {
-	Create(screen *models.Screen) error
-	GetByID(id string) (*models.Screen, error)
-	Update(screen *models.Screen) error // Needed for seat blocking/booking
+	Create(ctx context.Context, screen *models.Screen) error
+	GetByID(ctx context.Context, id string) (*models.Screen, error)
+	Update(ctx context.Context, screen *models.Screen) error // Needed for seat blocking/booking
+	List(ctx context.Context) ([]*models.Screen, error)      // Everything, for state snapshots
 }
 
 // ShowRepository defines core show data access operations
 type ShowRepository interface {
-	Create(show *models.Show) error
-	GetByID(id string) (*models.Show, error)
-	GetByMovieID(movieID string) ([]*models.Show, error)                       // For demo
-	CheckConflict(screenID string, startTime, endTime time.Time) (bool, error) // Business rule
+	Create(ctx context.Context, show *models.Show) error
+	GetByID(ctx context.Context, id string) (*models.Show, error)
+	GetByMovieID(ctx context.Context, movieID string) ([]*models.Show, error) // For demo
+	GetByEventID(ctx context.Context, eventID string) ([]*models.Show, error)
+	GetByTheatreBetween(ctx context.Context, theatreID string, from, to time.Time) ([]*models.Show, error) // Shows starting in [from, to), for settlements
+	GetAwaitingCloseOut(ctx context.Context, endedBy time.Time) ([]*models.Show, error)                    // Scheduled shows that ended by endedBy, earliest end first
+	GetStartingBetween(ctx context.Context, from, to time.Time) ([]*models.Show, error)                    // Scheduled shows starting in [from, to), soonest first
+	Update(ctx context.Context, show *models.Show) error                                                   // Needed for cancelling and rescheduling
+	// CheckConflict reports whether a scheduled show other than excludeShowID overlaps the slot - business rule
+	CheckConflict(ctx context.Context, screenID string, startTime, endTime time.Time, excludeShowID string) (bool, error)
+	List(ctx context.Context) ([]*models.Show, error) // Everything, for state snapshots
+}
+
+// Page selects a window of a list result; a zero Limit returns everything from Offset
+type Page struct {
+	Offset int
+	Limit  int
 }
 
 // BookingRepository defines core booking data access operations
 type BookingRepository interface {
-	Create(booking *models.Booking) error
-	GetByID(id string) (*models.Booking, error)
-	Update(booking *models.Booking) error // Needed for confirming bookings
+	Create(ctx context.Context, booking *models.Booking) error
+	GetByID(ctx context.Context, id string) (*models.Booking, error)
+	GetByReference(ctx context.Context, reference string) (*models.Booking, error)             // Case-insensitive, e.g. "bms-7f3k9q"
+	GetByUserID(ctx context.Context, userID string, page Page) ([]*models.Booking, int, error) // Newest first, plus total count
+	GetByShowID(ctx context.Context, showID string) ([]*models.Booking, error)                 // Needed for per-show seat status
+	GetPendingTransfersTo(ctx context.Context, userID string) ([]*models.Booking, error)       // Offered to the user, oldest offer first
+	Update(ctx context.Context, booking *models.Booking) error                                 // Needed for confirming bookings
+	Delete(ctx context.Context, id string) error                                               // Only to undo a create that rolled back
+	// GetSeatIDsByStatus returns every seat held by the show's bookings in the given status - for occupancy reporting
+	GetSeatIDsByStatus(ctx context.Context, showID string, status models.BookingStatus) ([]string, error)
+	List(ctx context.Context) ([]*models.Booking, error) // Everything, for state snapshots
+}
+
+// BulkBookingRepository stores corporate seat blocks and their redemption codes
+type BulkBookingRepository interface {
+	Create(ctx context.Context, bulk *models.BulkBooking) error
+	GetByID(ctx context.Context, id string) (*models.BulkBooking, error)
+	GetByCode(ctx context.Context, code string) (*models.BulkBooking, error)             // Case-insensitive, e.g. "emp-7f3k9qwx"
+	GetByAccountID(ctx context.Context, accountID string) ([]*models.BulkBooking, error) // Newest first
+	Update(ctx context.Context, bulk *models.BulkBooking) error
+	List(ctx context.Context) ([]*models.BulkBooking, error) // Everything, for state snapshots
+}
+
+// ParkingReservationRepository stores the parking slots held and booked with bookings
+type ParkingReservationRepository interface {
+	Create(ctx context.Context, reservation *models.ParkingReservation) error
+	GetByID(ctx context.Context, id string) (*models.ParkingReservation, error)
+	GetByBookingID(ctx context.Context, bookingID string) (*models.ParkingReservation, error)
+	GetByShowID(ctx context.Context, showID string) ([]*models.ParkingReservation, error)
+	GetActiveByTheatre(ctx context.Context, theatreID string, now time.Time) ([]*models.ParkingReservation, error) // Held and not yet expired at now, or confirmed
+	Update(ctx context.Context, reservation *models.ParkingReservation) error
+	List(ctx context.Context) ([]*models.ParkingReservation, error) // Everything, for state snapshots
+}
+
+// ResaleListingRepository stores bookings put up for resale
+type ResaleListingRepository interface {
+	Create(ctx context.Context, listing *models.ResaleListing) error
+	GetByID(ctx context.Context, id string) (*models.ResaleListing, error)
+	GetOpenByBookingID(ctx context.Context, bookingID string) (*models.ResaleListing, error) // A booking has at most one open listing
+	GetOpenByShowID(ctx context.Context, showID string) ([]*models.ResaleListing, error)     // Cheapest first
+	GetBySellerID(ctx context.Context, sellerID string) ([]*models.ResaleListing, error)     // Newest first, whatever their status
+	Update(ctx context.Context, listing *models.ResaleListing) error
+	List(ctx context.Context) ([]*models.ResaleListing, error) // Everything, for state snapshots
+}
+
+// TicketRepository defines e-ticket data access operations
+type TicketRepository interface {
+	Create(ctx context.Context, ticket *models.Ticket) error
+	GetByID(ctx context.Context, id string) (*models.Ticket, error)
+	GetByBookingID(ctx context.Context, bookingID string) (*models.Ticket, error) // One ticket per booking
+	Update(ctx context.Context, ticket *models.Ticket) error
+	List(ctx context.Context) ([]*models.Ticket, error) // Everything, for state snapshots
 }
 
 // PaymentRepository defines core payment data access operations
 type PaymentRepository interface {
-	Create(payment *models.Payment) error
-	GetByID(id string) (*models.Payment, error)
-	Update(payment *models.Payment) error // Needed for updating payment status
+	Create(ctx context.Context, payment *models.Payment) error
+	GetByID(ctx context.Context, id string) (*models.Payment, error)
+	Update(ctx context.Context, payment *models.Payment) error // Needed for updating payment status
+	// Settled payments (taken, possibly refunded since) - for revenue reporting
+	GetSettledByBookingIDs(ctx context.Context, bookingIDs []string) ([]*models.Payment, error)
+	GetSettledBetween(ctx context.Context, from, to time.Time) ([]*models.Payment, error) // Processed in [from, to), oldest first
+	GetAwaitingConfirmation(ctx context.Context) ([]*models.Payment, error)               // Gateway never answered, oldest first
+	List(ctx context.Context) ([]*models.Payment, error)                                  // Everything, for state snapshots
+	// The user's payments created at or after since, oldest first - for fraud velocity checks
+	GetByUserSince(ctx context.Context, userID string, since time.Time) ([]*models.Payment, error)
+	// The payment the gateway knows by this reference - for matching its callbacks
+	GetByTransactionID(ctx context.Context, transactionID string) (*models.Payment, error)
+}
+
+// PaymentInstrumentRepository stores the cards, UPI handles and wallet links users saved
+type PaymentInstrumentRepository interface {
+	Create(ctx context.Context, instrument *models.PaymentInstrument) error
+	GetByID(ctx context.Context, id string) (*models.PaymentInstrument, error)
+	GetByUserID(ctx context.Context, userID string) ([]*models.PaymentInstrument, error) // Oldest first
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*models.PaymentInstrument, error) // Everything, for state snapshots
+}
+
+// RefundRepository defines core refund data access operations
+type RefundRepository interface {
+	Create(ctx context.Context, refund *models.Refund) error
+	GetByID(ctx context.Context, id string) (*models.Refund, error)
+	GetByPaymentID(ctx context.Context, paymentID string) ([]*models.Refund, error) // Needed for refund tracking
+	Update(ctx context.Context, refund *models.Refund) error
+	List(ctx context.Context) ([]*models.Refund, error) // Everything, for state snapshots
+}
+
+// WalletRepository defines wallet and wallet ledger data access operations
+type WalletRepository interface {
+	Create(ctx context.Context, wallet *models.Wallet) error
+	GetByUserID(ctx context.Context, userID string) (*models.Wallet, error) // One wallet per user
+	Update(ctx context.Context, wallet *models.Wallet) error
+	AddTransaction(ctx context.Context, tx *models.WalletTransaction) error
+	GetTransaction(ctx context.Context, id string) (*models.WalletTransaction, error)
+	GetTransactions(ctx context.Context, walletID string, page Page) ([]*models.WalletTransaction, int, error) // Newest first, plus total count
+	List(ctx context.Context) ([]*models.Wallet, error)                                                        // Everything, for state snapshots
+	ListTransactions(ctx context.Context) ([]*models.WalletTransaction, error)                                 // Every ledger, each oldest first
+}
+
+// LoyaltyRepository defines loyalty account data access operations
+type LoyaltyRepository interface {
+	Create(ctx context.Context, account *models.LoyaltyAccount) error
+	GetByUserID(ctx context.Context, userID string) (*models.LoyaltyAccount, error) // One account per user
+	Update(ctx context.Context, account *models.LoyaltyAccount) error
+	List(ctx context.Context) ([]*models.LoyaltyAccount, error) // Everything, for state snapshots
+}
+
+// CouponRepository defines core coupon data access operations
+type CouponRepository interface {
+	Create(ctx context.Context, coupon *models.Coupon) error
+	GetByCode(ctx context.Context, code string) (*models.Coupon, error)
+	Update(ctx context.Context, coupon *models.Coupon) error // Needed for tracking usage
+	List(ctx context.Context) ([]*models.Coupon, error)      // Everything, for state snapshots
+}
+
+// SeatHoldRepository defines core seat hold data access operations
+type SeatHoldRepository interface {
+	Create(ctx context.Context, hold *models.SeatHold) error
+	GetByID(ctx context.Context, id string) (*models.SeatHold, error)
+	Update(ctx context.Context, hold *models.SeatHold) error
+	GetActive(ctx context.Context) ([]*models.SeatHold, error) // Needed for expiring stale holds
+	List(ctx context.Context) ([]*models.SeatHold, error)      // Everything still stored, for state snapshots
+}
+
+// OutboxRepository stores domain events until every subscriber has handled them (Outbox Pattern)
+type OutboxRepository interface {
+	Create(ctx context.Context, message *models.OutboxMessage) error
+	GetByID(ctx context.Context, id string) (*models.OutboxMessage, error)
+	Update(ctx context.Context, message *models.OutboxMessage) error
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*models.OutboxMessage, error) // Pending messages ready for delivery, oldest first
+	GetByStatus(ctx context.Context, status models.OutboxStatus) ([]*models.OutboxMessage, error)
+	DeleteDeliveredBefore(ctx context.Context, cutoff time.Time) (int, error)
+	Delete(ctx context.Context, id string) error               // Drops a message whose transaction rolled back
+	List(ctx context.Context) ([]*models.OutboxMessage, error) // Everything, for state snapshots
+}
+
+// NotificationRepository stores notifications until their channel has taken them
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	GetByID(ctx context.Context, id string) (*models.Notification, error)
+	Update(ctx context.Context, notification *models.Notification) error
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*models.Notification, error) // Pending notifications ready for delivery, oldest first
+	GetByStatus(ctx context.Context, status models.NotificationStatus) ([]*models.Notification, error)
+	DeleteSentBefore(ctx context.Context, cutoff time.Time) (int, error) // Sent and skipped notifications
+}
+
+// WebhookRepository stores the callback URLs theatre partners registered
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *models.Webhook) error
+	GetByID(ctx context.Context, id string) (*models.Webhook, error)
+	Delete(ctx context.Context, id string) error
+	GetByTheatreID(ctx context.Context, theatreID string) ([]*models.Webhook, error) // Oldest first
+	List(ctx context.Context) ([]*models.Webhook, error)                             // Everything, for state snapshots
+}
+
+// WebhookDeliveryRepository tracks every event sent, or still to be sent, to a webhook
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetByID(ctx context.Context, id string) (*models.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) // Pending deliveries ready to send, oldest first
+	GetByWebhookID(ctx context.Context, webhookID string) ([]*models.WebhookDelivery, error) // Newest first
+	List(ctx context.Context) ([]*models.WebhookDelivery, error)                             // Everything, for state snapshots
 }
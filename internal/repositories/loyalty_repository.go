@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sync"
+)
+
+// MemoryLoyaltyRepository implements LoyaltyRepository - demonstrates Repository Pattern
+type MemoryLoyaltyRepository struct {
+	accounts map[string]*models.LoyaltyAccount // userID -> account
+	mutex    sync.RWMutex
+}
+
+func NewMemoryLoyaltyRepository() LoyaltyRepository {
+	return &MemoryLoyaltyRepository{
+		accounts: make(map[string]*models.LoyaltyAccount),
+	}
+}
+
+func (r *MemoryLoyaltyRepository) Create(ctx context.Context, account *models.LoyaltyAccount) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.accounts[account.UserID]; exists {
+		return models.ErrInvalidLoyaltyData
+	}
+
+	r.accounts[account.UserID] = account
+	return nil
+}
+
+func (r *MemoryLoyaltyRepository) GetByUserID(ctx context.Context, userID string) (*models.LoyaltyAccount, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	account, exists := r.accounts[userID]
+	if !exists {
+		return nil, models.ErrLoyaltyAccountNotFound
+	}
+	return account, nil
+}
+
+func (r *MemoryLoyaltyRepository) Update(ctx context.Context, account *models.LoyaltyAccount) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.accounts[account.UserID]; !exists {
+		return models.ErrLoyaltyAccountNotFound
+	}
+
+	r.accounts[account.UserID] = account
+	return nil
+}
+
+// List returns every account, in no particular order
+func (r *MemoryLoyaltyRepository) List(ctx context.Context) ([]*models.LoyaltyAccount, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	accounts := make([]*models.LoyaltyAccount, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+)
+
+// Entity is anything a MemoryRepository can store, keyed by its ID
+type Entity interface {
+	GetID() string
+}
+
+// MemoryRepository is the map and RWMutex every memory repository is built on. Concrete repositories
+// embed it for Create, GetByID, Update, Delete and List, and add only their own queries.
+type MemoryRepository[T Entity] struct {
+	items    map[string]T
+	notFound error // Returned for unknown IDs, e.g. models.ErrUserNotFound
+	mutex    sync.RWMutex
+}
+
+// NewMemoryRepository creates an empty store that reports missing entities with notFound
+func NewMemoryRepository[T Entity](notFound error) *MemoryRepository[T] {
+	return &MemoryRepository[T]{
+		items:    make(map[string]T),
+		notFound: notFound,
+	}
+}
+
+// Create stores the entity under its ID
+func (r *MemoryRepository[T]) Create(ctx context.Context, item T) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.items[item.GetID()] = item
+	return nil
+}
+
+func (r *MemoryRepository[T]) GetByID(ctx context.Context, id string) (T, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	item, exists := r.items[id]
+	if !exists {
+		var zero T
+		return zero, r.notFound
+	}
+	return item, nil
+}
+
+// Update replaces a stored entity; it must have been created first
+func (r *MemoryRepository[T]) Update(ctx context.Context, item T) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.items[item.GetID()]; !exists {
+		return r.notFound
+	}
+
+	r.items[item.GetID()] = item
+	return nil
+}
+
+// Delete removes an entity; deleting one that isn't there is not an error
+func (r *MemoryRepository[T]) Delete(ctx context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.items, id)
+	return nil
+}
+
+// List returns every stored entity, in no particular order
+func (r *MemoryRepository[T]) List(ctx context.Context) ([]T, error) {
+	return r.filter(func(T) bool { return true }), nil
+}
+
+// filter returns the entities match accepts, in no particular order
+func (r *MemoryRepository[T]) filter(match func(T) bool) []T {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	items := make([]T, 0)
+	for _, item := range r.items {
+		if match(item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// find returns the first entity match accepts, or notFound
+func (r *MemoryRepository[T]) find(match func(T) bool) (T, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, item := range r.items {
+		if match(item) {
+			return item, nil
+		}
+	}
+	var zero T
+	return zero, r.notFound
+}
+
+// read runs fn under the read lock, for queries that also consult an embedding repository's indexes
+func (r *MemoryRepository[T]) read(fn func(items map[string]T)) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	fn(r.items)
+}
+
+// write runs fn under the write lock, for changes that must keep an embedding repository's indexes in step
+func (r *MemoryRepository[T]) write(fn func(items map[string]T) error) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return fn(r.items)
+}
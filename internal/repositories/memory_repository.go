@@ -2,173 +2,81 @@ package repositories
 
 import (
 	"bookmyshow-lld/internal/models"
-	"sync"
+	"context"
+	"sort"
+	"strings"
+	"time"
 )
 
 // MemoryUserRepository implements UserRepository - demonstrates Repository Pattern
 type MemoryUserRepository struct {
-	users map[string]*models.User
-	mutex sync.RWMutex
+	*MemoryRepository[*models.User]
 }
 
 func NewMemoryUserRepository() UserRepository {
-	return &MemoryUserRepository{
-		users: make(map[string]*models.User),
-	}
+	return &MemoryUserRepository{NewMemoryRepository[*models.User](models.ErrUserNotFound)}
 }
 
-func (r *MemoryUserRepository) Create(user *models.User) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
-	// Simple validation - prevent duplicate emails
-	for _, existingUser := range r.users {
-		if existingUser.Email == user.Email {
-			return models.ErrInvalidUserData
+func (r *MemoryUserRepository) Create(ctx context.Context, user *models.User) error {
+	return r.write(func(users map[string]*models.User) error {
+		// Simple validation - prevent duplicate emails
+		for _, existingUser := range users {
+			if existingUser.Email == user.Email {
+				return models.ErrInvalidUserData
+			}
 		}
-	}
 
-	r.users[user.ID] = user
-	return nil
+		users[user.ID] = user
+		return nil
+	})
 }
 
-func (r *MemoryUserRepository) GetByID(id string) (*models.User, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
-	user, exists := r.users[id]
-	if !exists {
-		return nil, models.ErrUserNotFound
-	}
-	return user, nil
+func (r *MemoryUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.find(func(user *models.User) bool { return user.Email == email })
 }
 
 // MemoryMovieRepository implements MovieRepository - demonstrates Repository Pattern
 type MemoryMovieRepository struct {
-	movies map[string]*models.Movie
-	mutex  sync.RWMutex
+	*MemoryRepository[*models.Movie]
 }
 
 func NewMemoryMovieRepository() MovieRepository {
-	return &MemoryMovieRepository{
-		movies: make(map[string]*models.Movie),
-	}
-}
-
-func (r *MemoryMovieRepository) Create(movie *models.Movie) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
-	r.movies[movie.ID] = movie
-	return nil
+	return &MemoryMovieRepository{NewMemoryRepository[*models.Movie](models.ErrMovieNotFound)}
 }
 
-func (r *MemoryMovieRepository) GetByID(id string) (*models.Movie, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
-	movie, exists := r.movies[id]
-	if !exists {
-		return nil, models.ErrMovieNotFound
-	}
-	return movie, nil
+func (r *MemoryMovieRepository) GetByExternalID(ctx context.Context, externalID string) (*models.Movie, error) {
+	return r.find(func(movie *models.Movie) bool { return externalID != "" && movie.ExternalID == externalID })
 }
 
-func (r *MemoryMovieRepository) GetReleased() ([]*models.Movie, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
-	var movies []*models.Movie
-	for _, movie := range r.movies {
-		if movie.IsReleased() {
-			movies = append(movies, movie)
-		}
-	}
-	return movies, nil
+func (r *MemoryMovieRepository) GetReleased(ctx context.Context, now time.Time) ([]*models.Movie, error) {
+	return r.filter(func(movie *models.Movie) bool { return movie.IsReleased(now) }), nil
 }
 
 // MemoryTheatreRepository implements TheatreRepository - demonstrates Repository Pattern
 type MemoryTheatreRepository struct {
-	theatres map[string]*models.Theatre
-	mutex    sync.RWMutex
+	*MemoryRepository[*models.Theatre]
 }
 
 func NewMemoryTheatreRepository() TheatreRepository {
-	return &MemoryTheatreRepository{
-		theatres: make(map[string]*models.Theatre),
-	}
+	return &MemoryTheatreRepository{NewMemoryRepository[*models.Theatre](models.ErrTheatreNotFound)}
 }
 
-func (r *MemoryTheatreRepository) Create(theatre *models.Theatre) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+func (r *MemoryTheatreRepository) GetByCity(ctx context.Context, city string) ([]*models.Theatre, error) {
+	theatres := r.filter(func(theatre *models.Theatre) bool { return strings.EqualFold(theatre.City, city) })
 
-	r.theatres[theatre.ID] = theatre
-	return nil
+	sort.Slice(theatres, func(i, j int) bool { return theatres[i].Name < theatres[j].Name })
+	return theatres, nil
 }
 
-func (r *MemoryTheatreRepository) GetByID(id string) (*models.Theatre, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
-	theatre, exists := r.theatres[id]
-	if !exists {
-		return nil, models.ErrTheatreNotFound
-	}
-	return theatre, nil
-}
-
-func (r *MemoryTheatreRepository) Update(theatre *models.Theatre) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
-	if _, exists := r.theatres[theatre.ID]; !exists {
-		return models.ErrTheatreNotFound
-	}
-
-	r.theatres[theatre.ID] = theatre
-	return nil
+func (r *MemoryTheatreRepository) GetAll(ctx context.Context) ([]*models.Theatre, error) {
+	return r.List(ctx)
 }
 
 // MemoryScreenRepository implements ScreenRepository - demonstrates Repository Pattern
 type MemoryScreenRepository struct {
-	screens map[string]*models.Screen
-	mutex   sync.RWMutex
+	*MemoryRepository[*models.Screen]
 }
 
 func NewMemoryScreenRepository() ScreenRepository {
-	return &MemoryScreenRepository{
-		screens: make(map[string]*models.Screen),
-	}
-}
-
-func (r *MemoryScreenRepository) Create(screen *models.Screen) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
-	r.screens[screen.ID] = screen
-	return nil
-}
-
-func (r *MemoryScreenRepository) GetByID(id string) (*models.Screen, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
-	screen, exists := r.screens[id]
-	if !exists {
-		return nil, models.ErrScreenNotFound
-	}
-	return screen, nil
-}
-
-func (r *MemoryScreenRepository) Update(screen *models.Screen) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
-	if _, exists := r.screens[screen.ID]; !exists {
-		return models.ErrScreenNotFound
-	}
-
-	r.screens[screen.ID] = screen
-	return nil
+	return &MemoryScreenRepository{NewMemoryRepository[*models.Screen](models.ErrScreenNotFound)}
 }
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sort"
+	"time"
+)
+
+// MemoryNotificationRepository implements NotificationRepository - demonstrates Repository Pattern.
+// Its indexes are guarded by the embedded repository's lock.
+type MemoryNotificationRepository struct {
+	*MemoryRepository[*models.Notification]
+	sequence map[string]int64    // notificationID -> insertion order, so same-instant notifications keep their order
+	pending  map[string]struct{} // IDs still awaiting delivery - the dispatcher only scans these
+	next     int64
+}
+
+func NewMemoryNotificationRepository() NotificationRepository {
+	return &MemoryNotificationRepository{
+		MemoryRepository: NewMemoryRepository[*models.Notification](models.ErrNotificationNotFound),
+		sequence:         make(map[string]int64),
+		pending:          make(map[string]struct{}),
+	}
+}
+
+func (r *MemoryNotificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	return r.write(func(notifications map[string]*models.Notification) error {
+		notifications[notification.ID] = notification
+		r.sequence[notification.ID] = r.next
+		r.next++
+		r.track(notification)
+		return nil
+	})
+}
+
+func (r *MemoryNotificationRepository) Update(ctx context.Context, notification *models.Notification) error {
+	return r.write(func(notifications map[string]*models.Notification) error {
+		if _, exists := notifications[notification.ID]; !exists {
+			return models.ErrNotificationNotFound
+		}
+
+		notifications[notification.ID] = notification
+		r.track(notification)
+		return nil
+	})
+}
+
+func (r *MemoryNotificationRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*models.Notification, error) {
+	var due []*models.Notification
+	r.read(func(notifications map[string]*models.Notification) {
+		for id := range r.pending {
+			if notification := notifications[id]; notification.IsDue(now) {
+				due = append(due, notification)
+			}
+		}
+		r.sortOldestFirst(due)
+	})
+
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (r *MemoryNotificationRepository) GetByStatus(ctx context.Context, status models.NotificationStatus) ([]*models.Notification, error) {
+	var matching []*models.Notification
+	r.read(func(notifications map[string]*models.Notification) {
+		for _, notification := range notifications {
+			if notification.Status == status {
+				matching = append(matching, notification)
+			}
+		}
+		r.sortOldestFirst(matching)
+	})
+	return matching, nil
+}
+
+func (r *MemoryNotificationRepository) DeleteSentBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	deleted := 0
+	err := r.write(func(notifications map[string]*models.Notification) error {
+		for id, notification := range notifications {
+			sent := notification.Status == models.NotificationStatusSent && notification.SentAt != nil && notification.SentAt.Before(cutoff)
+			// Skipping happens on the first attempt, moments after the notification is created
+			skipped := notification.Status == models.NotificationStatusSkipped && notification.CreatedAt.Before(cutoff)
+			if sent || skipped {
+				delete(notifications, id)
+				delete(r.sequence, id)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// track keeps the pending index in step with a notification's status. Callers must hold the write lock.
+func (r *MemoryNotificationRepository) track(notification *models.Notification) {
+	if notification.Status == models.NotificationStatusPending {
+		r.pending[notification.ID] = struct{}{}
+	} else {
+		delete(r.pending, notification.ID)
+	}
+}
+
+// sortOldestFirst orders notifications by when they were queued. Callers must hold the lock.
+func (r *MemoryNotificationRepository) sortOldestFirst(notifications []*models.Notification) {
+	sort.Slice(notifications, func(i, j int) bool {
+		return r.sequence[notifications[i].ID] < r.sequence[notifications[j].ID]
+	})
+}
@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sort"
+	"time"
+)
+
+// MemoryOutboxRepository implements OutboxRepository - demonstrates Repository Pattern.
+// Its indexes are guarded by the embedded repository's lock. It keeps and hands out copies of messages, so a
+// delivery marking its own message can't race the dispatcher or a purge reading the stored one.
+type MemoryOutboxRepository struct {
+	*MemoryRepository[*models.OutboxMessage]
+	sequence map[string]int64    // messageID -> insertion order, so same-instant events keep their order
+	pending  map[string]struct{} // IDs still awaiting delivery - the dispatcher only scans these
+	next     int64
+}
+
+func NewMemoryOutboxRepository() OutboxRepository {
+	return &MemoryOutboxRepository{
+		MemoryRepository: NewMemoryRepository[*models.OutboxMessage](models.ErrOutboxMessageNotFound),
+		sequence:         make(map[string]int64),
+		pending:          make(map[string]struct{}),
+	}
+}
+
+func (r *MemoryOutboxRepository) Create(ctx context.Context, message *models.OutboxMessage) error {
+	return r.write(func(messages map[string]*models.OutboxMessage) error {
+		messages[message.ID] = copyOf(message)
+		r.sequence[message.ID] = r.next
+		r.next++
+		r.track(message)
+		return nil
+	})
+}
+
+func (r *MemoryOutboxRepository) Update(ctx context.Context, message *models.OutboxMessage) error {
+	return r.write(func(messages map[string]*models.OutboxMessage) error {
+		if _, exists := messages[message.ID]; !exists {
+			return models.ErrOutboxMessageNotFound
+		}
+
+		messages[message.ID] = copyOf(message)
+		r.track(message)
+		return nil
+	})
+}
+
+// Delete removes a message and its indexes; deleting one that isn't there is not an error
+func (r *MemoryOutboxRepository) Delete(ctx context.Context, id string) error {
+	return r.write(func(messages map[string]*models.OutboxMessage) error {
+		delete(messages, id)
+		delete(r.sequence, id)
+		delete(r.pending, id)
+		return nil
+	})
+}
+
+func (r *MemoryOutboxRepository) GetByID(ctx context.Context, id string) (*models.OutboxMessage, error) {
+	message, err := r.MemoryRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return copyOf(message), nil
+}
+
+// List returns every message, in no particular order
+func (r *MemoryOutboxRepository) List(ctx context.Context) ([]*models.OutboxMessage, error) {
+	all := make([]*models.OutboxMessage, 0)
+	r.read(func(messages map[string]*models.OutboxMessage) {
+		for _, message := range messages {
+			all = append(all, copyOf(message))
+		}
+	})
+	return all, nil
+}
+
+func (r *MemoryOutboxRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*models.OutboxMessage, error) {
+	var due []*models.OutboxMessage
+	r.read(func(messages map[string]*models.OutboxMessage) {
+		for id := range r.pending {
+			if message := messages[id]; message.IsDue(now) {
+				due = append(due, copyOf(message))
+			}
+		}
+		r.sortOldestFirst(due)
+	})
+
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (r *MemoryOutboxRepository) GetByStatus(ctx context.Context, status models.OutboxStatus) ([]*models.OutboxMessage, error) {
+	var matching []*models.OutboxMessage
+	r.read(func(messages map[string]*models.OutboxMessage) {
+		for _, message := range messages {
+			if message.Status == status {
+				matching = append(matching, copyOf(message))
+			}
+		}
+		r.sortOldestFirst(matching)
+	})
+	return matching, nil
+}
+
+func (r *MemoryOutboxRepository) DeleteDeliveredBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	deleted := 0
+	err := r.write(func(messages map[string]*models.OutboxMessage) error {
+		for id, message := range messages {
+			if message.Status == models.OutboxStatusDelivered && message.DeliveredAt != nil && message.DeliveredAt.Before(cutoff) {
+				delete(messages, id)
+				delete(r.sequence, id)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// track keeps the pending index in step with a message's status. Callers must hold the write lock.
+func (r *MemoryOutboxRepository) track(message *models.OutboxMessage) {
+	if message.Status == models.OutboxStatusPending {
+		r.pending[message.ID] = struct{}{}
+	} else {
+		delete(r.pending, message.ID)
+	}
+}
+
+// sortOldestFirst orders messages by when they were written. Callers must hold the lock.
+func (r *MemoryOutboxRepository) sortOldestFirst(messages []*models.OutboxMessage) {
+	sort.Slice(messages, func(i, j int) bool {
+		return r.sequence[messages[i].ID] < r.sequence[messages[j].ID]
+	})
+}
+
+// copyOf is a copy of message that shares only what is never changed, e.g. its payload
+func copyOf(message *models.OutboxMessage) *models.OutboxMessage {
+	clone := *message
+	return &clone
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"time"
+)
+
+// MemoryParkingReservationRepository implements ParkingReservationRepository - demonstrates Repository Pattern
+type MemoryParkingReservationRepository struct {
+	*MemoryRepository[*models.ParkingReservation]
+}
+
+func NewMemoryParkingReservationRepository() ParkingReservationRepository {
+	return &MemoryParkingReservationRepository{NewMemoryRepository[*models.ParkingReservation](models.ErrParkingReservationNotFound)}
+}
+
+func (r *MemoryParkingReservationRepository) GetByBookingID(ctx context.Context, bookingID string) (*models.ParkingReservation, error) {
+	return r.find(func(reservation *models.ParkingReservation) bool { return reservation.BookingID == bookingID })
+}
+
+func (r *MemoryParkingReservationRepository) GetByShowID(ctx context.Context, showID string) ([]*models.ParkingReservation, error) {
+	return r.filter(func(reservation *models.ParkingReservation) bool { return reservation.ShowID == showID }), nil
+}
+
+func (r *MemoryParkingReservationRepository) GetActiveByTheatre(ctx context.Context, theatreID string, now time.Time) ([]*models.ParkingReservation, error) {
+	return r.filter(func(reservation *models.ParkingReservation) bool {
+		status := reservation.GetStatus(now)
+		return reservation.TheatreID == theatreID && (status == models.ParkingReservationHeld || status == models.ParkingReservationConfirmed)
+	}), nil
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sync"
+)
+
+// MemoryCouponRepository implements CouponRepository - demonstrates Repository Pattern
+type MemoryCouponRepository struct {
+	coupons map[string]*models.Coupon // keyed by normalized code
+	mutex   sync.RWMutex
+}
+
+func NewMemoryCouponRepository() CouponRepository {
+	return &MemoryCouponRepository{
+		coupons: make(map[string]*models.Coupon),
+	}
+}
+
+func (r *MemoryCouponRepository) Create(ctx context.Context, coupon *models.Coupon) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Coupon codes must be unique
+	if _, exists := r.coupons[coupon.Code]; exists {
+		return models.ErrInvalidCouponData
+	}
+
+	r.coupons[coupon.Code] = coupon
+	return nil
+}
+
+func (r *MemoryCouponRepository) GetByCode(ctx context.Context, code string) (*models.Coupon, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	coupon, exists := r.coupons[models.NormalizeCouponCode(code)]
+	if !exists {
+		return nil, models.ErrCouponNotFound
+	}
+	return coupon, nil
+}
+
+func (r *MemoryCouponRepository) Update(ctx context.Context, coupon *models.Coupon) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.coupons[coupon.Code]; !exists {
+		return models.ErrCouponNotFound
+	}
+
+	r.coupons[coupon.Code] = coupon
+	return nil
+}
+
+// List returns every coupon, in no particular order
+func (r *MemoryCouponRepository) List(ctx context.Context) ([]*models.Coupon, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	coupons := make([]*models.Coupon, 0, len(r.coupons))
+	for _, coupon := range r.coupons {
+		coupons = append(coupons, coupon)
+	}
+	return coupons, nil
+}
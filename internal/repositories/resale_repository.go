@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sort"
+)
+
+// MemoryResaleListingRepository implements ResaleListingRepository - demonstrates Repository Pattern
+type MemoryResaleListingRepository struct {
+	*MemoryRepository[*models.ResaleListing]
+}
+
+func NewMemoryResaleListingRepository() ResaleListingRepository {
+	return &MemoryResaleListingRepository{NewMemoryRepository[*models.ResaleListing](models.ErrResaleListingNotFound)}
+}
+
+func (r *MemoryResaleListingRepository) GetOpenByBookingID(ctx context.Context, bookingID string) (*models.ResaleListing, error) {
+	return r.find(func(listing *models.ResaleListing) bool {
+		return listing.BookingID == bookingID && listing.GetStatus() == models.ResaleListingListed
+	})
+}
+
+func (r *MemoryResaleListingRepository) GetOpenByShowID(ctx context.Context, showID string) ([]*models.ResaleListing, error) {
+	listings := r.filter(func(listing *models.ResaleListing) bool {
+		return listing.ShowID == showID && listing.GetStatus() == models.ResaleListingListed
+	})
+
+	// Cheapest first, oldest listing as tie-breaker
+	sort.Slice(listings, func(i, j int) bool {
+		if listings[i].Price.Minor != listings[j].Price.Minor {
+			return listings[i].Price.Minor < listings[j].Price.Minor
+		}
+		return listings[i].ListedAt.Before(listings[j].ListedAt)
+	})
+	return listings, nil
+}
+
+func (r *MemoryResaleListingRepository) GetBySellerID(ctx context.Context, sellerID string) ([]*models.ResaleListing, error) {
+	listings := r.filter(func(listing *models.ResaleListing) bool { return listing.SellerID == sellerID })
+	sort.Slice(listings, func(i, j int) bool { return listings[i].ListedAt.After(listings[j].ListedAt) })
+	return listings, nil
+}
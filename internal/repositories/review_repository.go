@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sort"
+)
+
+// MemoryReviewRepository implements ReviewRepository - demonstrates Repository Pattern
+type MemoryReviewRepository struct {
+	*MemoryRepository[*models.Review]
+}
+
+func NewMemoryReviewRepository() ReviewRepository {
+	return &MemoryReviewRepository{NewMemoryRepository[*models.Review](models.ErrReviewNotFound)}
+}
+
+func (r *MemoryReviewRepository) GetByMovieID(ctx context.Context, movieID string, status models.ReviewStatus, page Page) ([]*models.Review, int, error) {
+	reviews := r.filter(func(review *models.Review) bool {
+		return review.MovieID == movieID && (status == "" || review.GetStatus() == status)
+	})
+
+	sort.Slice(reviews, func(i, j int) bool {
+		if !reviews[i].CreatedAt.Equal(reviews[j].CreatedAt) {
+			return reviews[i].CreatedAt.After(reviews[j].CreatedAt)
+		}
+		return reviews[i].ID < reviews[j].ID
+	})
+
+	return paginate(reviews, page), len(reviews), nil
+}
+
+func (r *MemoryReviewRepository) GetByUserAndMovie(ctx context.Context, userID, movieID string) (*models.Review, error) {
+	return r.find(func(review *models.Review) bool { return review.UserID == userID && review.MovieID == movieID })
+}
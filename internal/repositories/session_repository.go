@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sync"
+)
+
+// MemoryCredentialRepository implements CredentialRepository - demonstrates Repository Pattern
+type MemoryCredentialRepository struct {
+	credentials map[string]*models.Credential // userID -> credential
+	mutex       sync.RWMutex
+}
+
+func NewMemoryCredentialRepository() CredentialRepository {
+	return &MemoryCredentialRepository{
+		credentials: make(map[string]*models.Credential),
+	}
+}
+
+func (r *MemoryCredentialRepository) Save(ctx context.Context, credential *models.Credential) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.credentials[credential.UserID] = credential
+	return nil
+}
+
+func (r *MemoryCredentialRepository) GetByUserID(ctx context.Context, userID string) (*models.Credential, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	credential, exists := r.credentials[userID]
+	if !exists {
+		return nil, models.ErrCredentialNotFound
+	}
+	return credential, nil
+}
+
+// List returns every credential, in no particular order
+func (r *MemoryCredentialRepository) List(ctx context.Context) ([]*models.Credential, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	credentials := make([]*models.Credential, 0, len(r.credentials))
+	for _, credential := range r.credentials {
+		credentials = append(credentials, credential)
+	}
+	return credentials, nil
+}
+
+// MemorySessionRepository implements SessionRepository - demonstrates Repository Pattern
+type MemorySessionRepository struct {
+	*MemoryRepository[*models.Session]
+}
+
+func NewMemorySessionRepository() SessionRepository {
+	return &MemorySessionRepository{NewMemoryRepository[*models.Session](models.ErrSessionNotFound)}
+}
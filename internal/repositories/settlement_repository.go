@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sort"
+)
+
+// MemorySettlementRepository implements SettlementRepository - demonstrates Repository Pattern
+type MemorySettlementRepository struct {
+	*MemoryRepository[*models.Settlement]
+}
+
+func NewMemorySettlementRepository() SettlementRepository {
+	return &MemorySettlementRepository{NewMemoryRepository[*models.Settlement](models.ErrSettlementNotFound)}
+}
+
+func (r *MemorySettlementRepository) GetByTheatreID(ctx context.Context, theatreID string) ([]*models.Settlement, error) {
+	settlements := r.filter(func(settlement *models.Settlement) bool { return settlement.TheatreID == theatreID })
+
+	sort.Slice(settlements, func(i, j int) bool {
+		return settlements[i].PeriodStart.Before(settlements[j].PeriodStart)
+	})
+	return settlements, nil
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+)
+
+// MemoryTicketRepository implements TicketRepository - demonstrates Repository Pattern
+type MemoryTicketRepository struct {
+	*MemoryRepository[*models.Ticket]
+	byBooking map[string]string // bookingID -> ticketID, guarded by the embedded repository's lock
+}
+
+func NewMemoryTicketRepository() TicketRepository {
+	return &MemoryTicketRepository{
+		MemoryRepository: NewMemoryRepository[*models.Ticket](models.ErrTicketNotFound),
+		byBooking:        make(map[string]string),
+	}
+}
+
+func (r *MemoryTicketRepository) Create(ctx context.Context, ticket *models.Ticket) error {
+	return r.write(func(tickets map[string]*models.Ticket) error {
+		tickets[ticket.ID] = ticket
+		r.byBooking[ticket.BookingID] = ticket.ID
+		return nil
+	})
+}
+
+func (r *MemoryTicketRepository) GetByBookingID(ctx context.Context, bookingID string) (*models.Ticket, error) {
+	var ticket *models.Ticket
+	r.read(func(tickets map[string]*models.Ticket) {
+		if ticketID, exists := r.byBooking[bookingID]; exists {
+			ticket = tickets[ticketID]
+		}
+	})
+	if ticket == nil {
+		return nil, models.ErrTicketNotFound
+	}
+	return ticket, nil
+}
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"errors"
+	"sync"
+)
+
+// UnitOfWork groups the repository writes of one operation into a transaction - demonstrates Unit of Work
+// Pattern. Begin returns a context carrying the transaction; repositories given that context write inside
+// it, so the writes reach the store together on Commit, or not at all on Rollback.
+//
+// Memory repositories apply writes straight away and services change entities in place, so whoever changes
+// in-memory state inside a transaction registers how to put it back with OnRollback. Work that must wait
+// until the writes are durable, e.g. delivering an event, is registered with OnCommit.
+type UnitOfWork interface {
+	Begin(ctx context.Context) (context.Context, error)
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
+// Transaction is the part of a transaction every backend shares: the undo steps registered with
+// OnRollback, run newest first if it rolls back, and the steps registered with OnCommit, run in order
+// once it commits
+type Transaction struct {
+	mutex     sync.Mutex
+	outside   context.Context // What the transaction began from
+	undo      []func()
+	committed []func()
+	done      bool
+}
+
+type transactionKey struct{}
+
+// BeginTransaction returns a context carrying a new transaction; backends call it from Begin
+func BeginTransaction(ctx context.Context) (context.Context, *Transaction) {
+	tx := &Transaction{outside: ctx}
+	return context.WithValue(ctx, transactionKey{}, tx), tx
+}
+
+// TransactionFrom returns the transaction ctx carries, or nil outside one
+func TransactionFrom(ctx context.Context) *Transaction {
+	tx, _ := ctx.Value(transactionKey{}).(*Transaction)
+	return tx
+}
+
+// OnRollback registers undo to run if ctx's transaction rolls back; outside a transaction it does nothing
+func OnRollback(ctx context.Context, undo func()) {
+	if tx := TransactionFrom(ctx); tx != nil {
+		tx.mutex.Lock()
+		defer tx.mutex.Unlock()
+		tx.undo = append(tx.undo, undo)
+	}
+}
+
+// OnCommit registers fn to run once ctx's transaction commits; outside a transaction it runs straight away.
+// fn runs after the backend has committed, so it should write through OutsideTransaction's context.
+func OnCommit(ctx context.Context, fn func()) {
+	if tx := TransactionFrom(ctx); tx != nil {
+		tx.mutex.Lock()
+		defer tx.mutex.Unlock()
+		tx.committed = append(tx.committed, fn)
+		return
+	}
+	fn()
+}
+
+// OutsideTransaction returns the context ctx's transaction began from, for work that runs after it ends;
+// outside a transaction it returns ctx
+func OutsideTransaction(ctx context.Context) context.Context {
+	if tx := TransactionFrom(ctx); tx != nil {
+		return tx.outside
+	}
+	return ctx
+}
+
+// Finish ends the transaction, running its undo steps newest first when rolling back and its commit steps
+// in order otherwise
+func (t *Transaction) Finish(rollback bool) error {
+	t.mutex.Lock()
+	if t.done {
+		t.mutex.Unlock()
+		return models.ErrTransactionDone
+	}
+	t.done = true
+	undo, committed := t.undo, t.committed
+	t.undo, t.committed = nil, nil
+	t.mutex.Unlock()
+
+	if rollback {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+		return nil
+	}
+	for _, fn := range committed {
+		fn()
+	}
+	return nil
+}
+
+// InTransaction runs fn in a transaction of uow, committing if it succeeds and rolling back if it fails or
+// panics. Inside a transaction already, fn joins it and the outermost call commits or rolls back.
+func InTransaction(ctx context.Context, uow UnitOfWork, fn func(ctx context.Context) error) (err error) {
+	if TransactionFrom(ctx) != nil {
+		return fn(ctx)
+	}
+
+	txCtx, err := uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			uow.Rollback(txCtx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(txCtx); err != nil {
+		if rollbackErr := uow.Rollback(txCtx); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+	return uow.Commit(txCtx)
+}
+
+// MemoryUnitOfWork implements UnitOfWork for the memory repositories. Their writes are visible at once,
+// so a transaction is only its undo steps.
+type MemoryUnitOfWork struct{}
+
+// NewMemoryUnitOfWork creates the unit of work of the memory repositories
+func NewMemoryUnitOfWork() UnitOfWork {
+	return MemoryUnitOfWork{}
+}
+
+func (MemoryUnitOfWork) Begin(ctx context.Context) (context.Context, error) {
+	ctx, _ = BeginTransaction(ctx)
+	return ctx, nil
+}
+
+func (MemoryUnitOfWork) Commit(ctx context.Context) error {
+	return finish(ctx, false)
+}
+
+func (MemoryUnitOfWork) Rollback(ctx context.Context) error {
+	return finish(ctx, true)
+}
+
+// finish ends ctx's transaction
+func finish(ctx context.Context, rollback bool) error {
+	tx := TransactionFrom(ctx)
+	if tx == nil {
+		return models.ErrNoTransaction
+	}
+	return tx.Finish(rollback)
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sync"
+)
+
+// MemoryWalletRepository implements WalletRepository - demonstrates Repository Pattern
+type MemoryWalletRepository struct {
+	wallets      map[string]*models.Wallet              // userID -> wallet
+	transactions map[string]*models.WalletTransaction   // transactionID -> entry
+	ledgers      map[string][]*models.WalletTransaction // walletID -> entries, oldest first
+	mutex        sync.RWMutex
+}
+
+func NewMemoryWalletRepository() WalletRepository {
+	return &MemoryWalletRepository{
+		wallets:      make(map[string]*models.Wallet),
+		transactions: make(map[string]*models.WalletTransaction),
+		ledgers:      make(map[string][]*models.WalletTransaction),
+	}
+}
+
+func (r *MemoryWalletRepository) Create(ctx context.Context, wallet *models.Wallet) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.wallets[wallet.UserID]; exists {
+		return models.ErrInvalidWalletData
+	}
+
+	r.wallets[wallet.UserID] = wallet
+	return nil
+}
+
+func (r *MemoryWalletRepository) GetByUserID(ctx context.Context, userID string) (*models.Wallet, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	wallet, exists := r.wallets[userID]
+	if !exists {
+		return nil, models.ErrWalletNotFound
+	}
+	return wallet, nil
+}
+
+func (r *MemoryWalletRepository) Update(ctx context.Context, wallet *models.Wallet) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.wallets[wallet.UserID]; !exists {
+		return models.ErrWalletNotFound
+	}
+
+	r.wallets[wallet.UserID] = wallet
+	return nil
+}
+
+func (r *MemoryWalletRepository) AddTransaction(ctx context.Context, tx *models.WalletTransaction) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.transactions[tx.ID] = tx
+	r.ledgers[tx.WalletID] = append(r.ledgers[tx.WalletID], tx)
+	return nil
+}
+
+func (r *MemoryWalletRepository) GetTransaction(ctx context.Context, id string) (*models.WalletTransaction, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	tx, exists := r.transactions[id]
+	if !exists {
+		return nil, models.ErrWalletTransactionNotFound
+	}
+	return tx, nil
+}
+
+func (r *MemoryWalletRepository) GetTransactions(ctx context.Context, walletID string, page Page) ([]*models.WalletTransaction, int, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	// Ledger is append-only, so reversing gives newest first
+	ledger := r.ledgers[walletID]
+	newestFirst := make([]*models.WalletTransaction, len(ledger))
+	for i, tx := range ledger {
+		newestFirst[len(ledger)-1-i] = tx
+	}
+
+	return paginate(newestFirst, page), len(newestFirst), nil
+}
+
+// List returns every wallet, in no particular order
+func (r *MemoryWalletRepository) List(ctx context.Context) ([]*models.Wallet, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	wallets := make([]*models.Wallet, 0, len(r.wallets))
+	for _, wallet := range r.wallets {
+		wallets = append(wallets, wallet)
+	}
+	return wallets, nil
+}
+
+// ListTransactions returns every wallet's ledger, one after another, each oldest first
+func (r *MemoryWalletRepository) ListTransactions(ctx context.Context) ([]*models.WalletTransaction, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	transactions := make([]*models.WalletTransaction, 0, len(r.transactions))
+	for _, ledger := range r.ledgers {
+		transactions = append(transactions, ledger...)
+	}
+	return transactions, nil
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sort"
+)
+
+// MemoryWatchlistRepository implements WatchlistRepository - demonstrates Repository Pattern
+type MemoryWatchlistRepository struct {
+	*MemoryRepository[*models.WatchlistEntry]
+}
+
+func NewMemoryWatchlistRepository() WatchlistRepository {
+	return &MemoryWatchlistRepository{NewMemoryRepository[*models.WatchlistEntry](models.ErrWatchlistEntryNotFound)}
+}
+
+func (r *MemoryWatchlistRepository) GetByUserID(ctx context.Context, userID string) ([]*models.WatchlistEntry, error) {
+	entries := r.filter(func(entry *models.WatchlistEntry) bool { return entry.UserID == userID })
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].AddedAt.Equal(entries[j].AddedAt) {
+			return entries[i].AddedAt.After(entries[j].AddedAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries, nil
+}
+
+func (r *MemoryWatchlistRepository) GetByUserAndMovie(ctx context.Context, userID, movieID string) (*models.WatchlistEntry, error) {
+	return r.find(func(entry *models.WatchlistEntry) bool { return entry.UserID == userID && entry.MovieID == movieID })
+}
+
+func (r *MemoryWatchlistRepository) GetUnreminded(ctx context.Context) ([]*models.WatchlistEntry, error) {
+	entries := r.filter(func(entry *models.WatchlistEntry) bool { return !entry.IsReminded() })
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].AddedAt.Equal(entries[j].AddedAt) {
+			return entries[i].AddedAt.Before(entries[j].AddedAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries, nil
+}
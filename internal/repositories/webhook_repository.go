@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sort"
+	"time"
+)
+
+// MemoryWebhookRepository implements WebhookRepository - demonstrates Repository Pattern
+type MemoryWebhookRepository struct {
+	*MemoryRepository[*models.Webhook]
+}
+
+func NewMemoryWebhookRepository() WebhookRepository {
+	return &MemoryWebhookRepository{
+		MemoryRepository: NewMemoryRepository[*models.Webhook](models.ErrWebhookNotFound),
+	}
+}
+
+func (r *MemoryWebhookRepository) GetByTheatreID(ctx context.Context, theatreID string) ([]*models.Webhook, error) {
+	webhooks := r.filter(func(webhook *models.Webhook) bool {
+		return webhook.TheatreID == theatreID
+	})
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+	return webhooks, nil
+}
+
+// MemoryWebhookDeliveryRepository implements WebhookDeliveryRepository - demonstrates Repository Pattern.
+// Its pending index is guarded by the embedded repository's lock.
+type MemoryWebhookDeliveryRepository struct {
+	*MemoryRepository[*models.WebhookDelivery]
+	pending map[string]struct{} // IDs still to be sent - the dispatcher only scans these
+}
+
+func NewMemoryWebhookDeliveryRepository() WebhookDeliveryRepository {
+	return &MemoryWebhookDeliveryRepository{
+		MemoryRepository: NewMemoryRepository[*models.WebhookDelivery](models.ErrWebhookDeliveryNotFound),
+		pending:          make(map[string]struct{}),
+	}
+}
+
+func (r *MemoryWebhookDeliveryRepository) Create(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return r.write(func(deliveries map[string]*models.WebhookDelivery) error {
+		deliveries[delivery.ID] = delivery
+		r.track(delivery)
+		return nil
+	})
+}
+
+func (r *MemoryWebhookDeliveryRepository) Update(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return r.write(func(deliveries map[string]*models.WebhookDelivery) error {
+		if _, exists := deliveries[delivery.ID]; !exists {
+			return models.ErrWebhookDeliveryNotFound
+		}
+
+		deliveries[delivery.ID] = delivery
+		r.track(delivery)
+		return nil
+	})
+}
+
+func (r *MemoryWebhookDeliveryRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var due []*models.WebhookDelivery
+	r.read(func(deliveries map[string]*models.WebhookDelivery) {
+		for id := range r.pending {
+			if delivery := deliveries[id]; delivery.IsDue(now) {
+				due = append(due, delivery)
+			}
+		}
+	})
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].CreatedAt.Before(due[j].CreatedAt)
+	})
+
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (r *MemoryWebhookDeliveryRepository) GetByWebhookID(ctx context.Context, webhookID string) ([]*models.WebhookDelivery, error) {
+	deliveries := r.filter(func(delivery *models.WebhookDelivery) bool {
+		return delivery.WebhookID == webhookID
+	})
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+	return deliveries, nil
+}
+
+// track keeps the pending index in step with a delivery's status. Callers must hold the write lock.
+func (r *MemoryWebhookDeliveryRepository) track(delivery *models.WebhookDelivery) {
+	if delivery.Status == models.WebhookDeliveryPending {
+		r.pending[delivery.ID] = struct{}{}
+	} else {
+		delete(r.pending, delivery.ID)
+	}
+}
@@ -0,0 +1,92 @@
+package scenario
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Outcome is what the scripted gateway does with the next charges
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success" // Charge goes through (the default)
+	OutcomeDecline Outcome = "decline" // The bank says no - a failed payment the user may retry
+	OutcomeOutage  Outcome = "outage"  // The gateway is down - the call itself errors
+)
+
+// scriptedGateway answers charges with whatever outcome the current step asked for, so scenarios
+// don't depend on the mock strategies' random failures - demonstrates Strategy Pattern (a test double)
+type scriptedGateway struct {
+	mutex   sync.Mutex
+	outcome Outcome
+	answers map[string]*services.PaymentResult // By payment ID, for QueryTransaction
+	charges atomic.Int64
+}
+
+func newScriptedGateway() *scriptedGateway {
+	return &scriptedGateway{outcome: OutcomeSuccess, answers: make(map[string]*services.PaymentResult)}
+}
+
+// script sets the outcome of every charge until the next call
+func (g *scriptedGateway) script(outcome Outcome) {
+	if outcome == "" {
+		outcome = OutcomeSuccess
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.outcome = outcome
+}
+
+func (g *scriptedGateway) ProcessPayment(ctx context.Context, amount models.Money, method models.PaymentMethod, metadata map[string]string) (*services.PaymentResult, error) {
+	g.mutex.Lock()
+	outcome := g.outcome
+	g.mutex.Unlock()
+
+	n := g.charges.Add(1)
+	var result *services.PaymentResult
+	switch outcome {
+	case OutcomeDecline:
+		result = &services.PaymentResult{Success: false, ErrorMessage: fmt.Sprintf("%s declined by the scenario", method)}
+	case OutcomeOutage:
+		return nil, fmt.Errorf("%w: scripted outage", models.ErrPaymentGatewayError)
+	default:
+		result = &services.PaymentResult{
+			Success:       true,
+			TransactionID: fmt.Sprintf("SCENARIO_%d", n),
+			Response:      fmt.Sprintf("Paid %s via %s", amount, method),
+		}
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.answers[metadata["payment_id"]] = result
+	return result, nil
+}
+
+func (g *scriptedGateway) RefundPayment(ctx context.Context, transactionID string, amount models.Money, method models.PaymentMethod) (*services.PaymentResult, error) {
+	return &services.PaymentResult{
+		Success:       true,
+		TransactionID: "REFUND_" + transactionID,
+		Response:      fmt.Sprintf("Refunded %s", amount),
+	}, nil
+}
+
+// QueryTransaction repeats the answer given to the payment's charge
+func (g *scriptedGateway) QueryTransaction(ctx context.Context, paymentID string, method models.PaymentMethod) (*services.PaymentResult, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if result, ok := g.answers[paymentID]; ok {
+		return result, nil
+	}
+	return &services.PaymentResult{Success: false, ErrorMessage: "no charge for payment " + paymentID}, nil
+}
+
+// CompleteChallenge has nothing to complete: the scripted gateway never asks for an OTP
+func (g *scriptedGateway) CompleteChallenge(ctx context.Context, token, otp string, method models.PaymentMethod) (*services.PaymentResult, error) {
+	return nil, models.ErrNoPaymentChallenge
+}
@@ -0,0 +1,455 @@
+package scenario
+
+import (
+	"bookmyshow-lld/internal/controllers"
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Report tallies a scenario's assertions
+type Report struct {
+	Passed int
+	Failed int
+}
+
+// OK reports whether every assertion held
+func (r *Report) OK() bool { return r.Failed == 0 }
+
+// Runner executes a scenario against its own in-memory AppController, so runs never touch the
+// demo's store and start from the same empty state every time
+type Runner struct {
+	app     *controllers.AppController
+	gateway *scriptedGateway
+	out     io.Writer
+	admin   *models.User
+	report  Report
+
+	// Named results of earlier steps
+	users    map[string][]*models.User // A group has several users
+	movies   map[string]*models.Movie
+	theatres map[string]*models.Theatre
+	screens  map[string]*models.Screen // By theatre name
+	shows    map[string]*models.Show
+	bookings map[string]*models.Booking
+	phones   int
+}
+
+// Run executes every step in order, printing each assertion as it is checked. Assertion failures
+// are counted in the report; a step that can't run at all, e.g. naming an unknown show, stops the run.
+func Run(ctx context.Context, s *Scenario, out io.Writer) (*Report, error) {
+	gateway := newScriptedGateway()
+	app := controllers.NewAppController(
+		controllers.WithPaymentGateway(gateway),
+		controllers.WithLogger(logging.Nop()),
+	)
+	defer app.Shutdown()
+
+	r := &Runner{
+		app:      app,
+		gateway:  gateway,
+		out:      out,
+		users:    make(map[string][]*models.User),
+		movies:   make(map[string]*models.Movie),
+		theatres: make(map[string]*models.Theatre),
+		screens:  make(map[string]*models.Screen),
+		shows:    make(map[string]*models.Show),
+		bookings: make(map[string]*models.Booking),
+	}
+
+	admin, err := app.GetUserService().CreateUserWithRole(ctx, "Scenario Admin", "admin@scenario.test", r.phone(), models.UserRoleSuperAdmin)
+	if err != nil {
+		return nil, err
+	}
+	r.admin = admin
+
+	fmt.Fprintf(out, "▶ %s\n", s.Name)
+	if s.Description != "" {
+		fmt.Fprintf(out, "  %s\n", strings.TrimSpace(s.Description))
+	}
+	for i, step := range s.Steps {
+		fmt.Fprintf(out, "%2d. %s\n", i+1, describe(step))
+		if err := r.run(ctx, step); err != nil {
+			return &r.report, fmt.Errorf("step %d (%s): %w", i+1, step.Do, err)
+		}
+	}
+	fmt.Fprintf(out, "%d passed, %d failed\n", r.report.Passed, r.report.Failed)
+	return &r.report, nil
+}
+
+// run dispatches one step by its action
+func (r *Runner) run(ctx context.Context, step Step) error {
+	switch step.Do {
+	case "user":
+		return r.createUsers(ctx, step)
+	case "movie":
+		return r.createMovie(ctx, step)
+	case "theatre":
+		return r.createTheatre(ctx, step)
+	case "show":
+		return r.createShow(ctx, step)
+	case "book":
+		return r.book(ctx, step)
+	case "pay":
+		return r.pay(ctx, step)
+	case "cancel":
+		return r.cancel(ctx, step)
+	case "check":
+		return r.check(ctx, step)
+	default:
+		return fmt.Errorf("unknown action %q", step.Do)
+	}
+}
+
+// describe prints a step the way a reader of the script would say it
+func describe(step Step) string {
+	parts := []string{step.Do}
+	for _, field := range []struct{ key, value string }{
+		{"as", step.As}, {"user", step.User}, {"show", step.Show}, {"booking", step.Booking},
+		{"seats", strings.Join(step.Seats, " ")}, {"method", step.Method}, {"gateway", string(step.Gateway)},
+	} {
+		if field.value != "" {
+			parts = append(parts, field.key+"="+field.value)
+		}
+	}
+	if step.Times > 1 {
+		parts = append(parts, fmt.Sprintf("times=%d", step.Times))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Setup steps - these must succeed, so their errors stop the run
+
+func (r *Runner) createUsers(ctx context.Context, step Step) error {
+	if step.As == "" {
+		return errors.New("user needs as")
+	}
+	count := max(step.Count, 1)
+	for i := 1; i <= count; i++ {
+		handle := step.As
+		if count > 1 {
+			handle = fmt.Sprintf("%s%d", step.As, i)
+		}
+		name := step.Name
+		if name == "" {
+			name = handle
+		}
+		user, err := r.app.GetUserService().CreateUser(ctx, name, handle+"@scenario.test", r.phone())
+		if err != nil {
+			return err
+		}
+		r.users[step.As] = append(r.users[step.As], user)
+		if count > 1 {
+			r.users[handle] = []*models.User{user}
+		}
+	}
+	return nil
+}
+
+func (r *Runner) createMovie(ctx context.Context, step Step) error {
+	minutes := step.Minutes
+	if minutes == 0 {
+		minutes = 150
+	}
+	genre, language := models.Genre(strings.ToUpper(step.Genre)), models.Language(strings.ToUpper(step.Language))
+	if genre == "" {
+		genre = models.GenreDrama
+	}
+	if language == "" {
+		language = models.LanguageEnglish
+	}
+
+	movie, err := r.app.GetMovieService().CreateMovie(r.asAdmin(ctx), step.Title, step.Title, time.Duration(minutes)*time.Minute, genre, language, 8, r.app.GetClock().Now().AddDate(0, 0, -1))
+	if err != nil {
+		return err
+	}
+	r.movies[step.As] = movie
+	return nil
+}
+
+// createTheatre onboards the theatre with one screen in the default layout, priced from Price
+func (r *Runner) createTheatre(ctx context.Context, step Step) error {
+	city := step.City
+	if city == "" {
+		city = "Mumbai"
+	}
+	admin := r.app.GetAdminService()
+	theatre, err := admin.OnboardTheatre(ctx, r.admin.ID, step.Name, step.Name+" Mall", city)
+	if err != nil {
+		return err
+	}
+	screen, err := admin.AddScreen(ctx, r.admin.ID, theatre.ID, "Screen 1", factories.DefaultScreenConfig(), price(step.Price))
+	if err != nil {
+		return err
+	}
+	r.theatres[step.As] = theatre
+	r.screens[step.As] = screen
+	return nil
+}
+
+func (r *Runner) createShow(ctx context.Context, step Step) error {
+	movie, ok := r.movies[step.Movie]
+	if !ok {
+		return fmt.Errorf("no movie %q", step.Movie)
+	}
+	theatre, ok := r.theatres[step.Theatre]
+	if !ok {
+		return fmt.Errorf("no theatre %q", step.Theatre)
+	}
+	startsIn := time.Duration(step.StartsIn)
+	if startsIn == 0 {
+		startsIn = 2 * time.Hour
+	}
+
+	show, err := r.app.GetShowService().CreateShow(r.asAdmin(ctx), movie.ID, theatre.ID, r.screens[step.Theatre].ID, r.app.GetClock().Now().Add(startsIn), price(step.Price))
+	if err != nil {
+		return err
+	}
+	r.shows[step.As] = show
+	return nil
+}
+
+// Flow steps - their errors are outcomes to assert on
+
+// book books the seats for the user, or for every user of a group at once: the goroutines are
+// released together so they really do race for the same seats
+func (r *Runner) book(ctx context.Context, step Step) error {
+	users, ok := r.users[step.User]
+	if !ok {
+		return fmt.Errorf("no user %q", step.User)
+	}
+	show, ok := r.shows[step.Show]
+	if !ok {
+		return fmt.Errorf("no show %q", step.Show)
+	}
+	seatMap, err := r.app.GetShowService().GetSeatAvailability(ctx, show.ID)
+	if err != nil {
+		return err
+	}
+	seatIDs, err := seatMap.SeatIDs(step.Seats)
+	if err != nil {
+		return err
+	}
+
+	bookings := make([]*models.Booking, len(users))
+	errs := race(len(users), func(i int) error {
+		booking, err := r.app.GetBookingService().CreateBooking(ctx, users[i].ID, show.ID, seatIDs)
+		bookings[i] = booking
+		return err
+	})
+
+	for i, booking := range bookings {
+		if errs[i] == nil && step.As != "" {
+			r.bookings[step.As] = booking
+		}
+	}
+	r.assertOutcome(step, errs)
+	return nil
+}
+
+// pay charges the booking Times times at once, with the gateway scripted to Gateway's outcome.
+// A successful charge confirms the booking, as the payment webhook would.
+func (r *Runner) pay(ctx context.Context, step Step) error {
+	booking, ok := r.bookings[step.Booking]
+	if !ok {
+		return fmt.Errorf("no booking %q", step.Booking)
+	}
+	switch step.Gateway {
+	case "", OutcomeSuccess, OutcomeDecline, OutcomeOutage:
+	default:
+		return fmt.Errorf("unknown gateway outcome %q", step.Gateway)
+	}
+	method := models.PaymentMethod(strings.ToUpper(step.Method))
+	if method == "" {
+		method = models.PaymentMethodUPI
+	}
+	r.gateway.script(step.Gateway)
+
+	payments := r.app.GetPaymentService()
+	errs := race(max(step.Times, 1), func(int) error {
+		attempts, err := payments.GetPaymentAttempts(ctx, booking.ID)
+		if err != nil {
+			return err
+		}
+		var payment *models.Payment
+		if len(attempts) == 0 {
+			payment, err = payments.ProcessPayment(ctx, booking.ID, method)
+		} else {
+			payment, err = payments.RetryPayment(ctx, booking.ID, method)
+		}
+		if err != nil {
+			return err
+		}
+		if !payment.IsSuccessful() {
+			return fmt.Errorf("%w: %s", models.ErrPaymentProcessingFail, payment.FailureReason)
+		}
+		return r.app.GetBookingService().ConfirmBooking(ctx, booking.ID, payment.ID)
+	})
+	r.assertOutcome(step, errs)
+	return nil
+}
+
+func (r *Runner) cancel(ctx context.Context, step Step) error {
+	booking, ok := r.bookings[step.Booking]
+	if !ok {
+		return fmt.Errorf("no booking %q", step.Booking)
+	}
+	r.assertOutcome(step, []error{r.app.GetBookingService().CancelBooking(ctx, booking.ID)})
+	return nil
+}
+
+// check asserts on the state the flow left behind
+func (r *Runner) check(ctx context.Context, step Step) error {
+	if step.Show == "" && step.Booking == "" {
+		return errors.New("check needs a show or a booking")
+	}
+	if step.Show != "" {
+		show, ok := r.shows[step.Show]
+		if !ok {
+			return fmt.Errorf("no show %q", step.Show)
+		}
+		seatMap, err := r.app.GetShowService().GetSeatAvailability(ctx, show.ID)
+		if err != nil {
+			return err
+		}
+		if step.Available != nil {
+			r.assert(seatMap.Available == *step.Available, "%d seats available (want %d)", seatMap.Available, *step.Available)
+		}
+	}
+	if step.Booking != "" {
+		saved, ok := r.bookings[step.Booking]
+		if !ok {
+			return fmt.Errorf("no booking %q", step.Booking)
+		}
+		booking, err := r.app.GetBookingService().GetBooking(ctx, saved.ID)
+		if err != nil {
+			return err
+		}
+		if step.Status != "" {
+			status := string(booking.GetStatus())
+			r.assert(strings.EqualFold(status, step.Status), "booking %s is %s (want %s)", step.Booking, status, strings.ToUpper(step.Status))
+		}
+	}
+	return nil
+}
+
+// Assertions
+
+// assertOutcome checks how many calls succeeded, then that each result matches Expect.
+// With Succeeded set, Expect applies to the calls that failed.
+func (r *Runner) assertOutcome(step Step, errs []error) {
+	succeeded, failures := 0, make(map[string]int)
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		} else {
+			failures[err.Error()]++
+		}
+	}
+
+	if len(errs) > 1 || step.Succeeded != nil {
+		fmt.Fprintf(r.out, "    %d of %d succeeded\n", succeeded, len(errs))
+		messages := make([]string, 0, len(failures))
+		for message := range failures {
+			messages = append(messages, message)
+		}
+		sort.Strings(messages)
+		for _, message := range messages {
+			fmt.Fprintf(r.out, "    %d× %s\n", failures[message], message)
+		}
+	}
+
+	if step.Succeeded != nil {
+		r.assert(succeeded == *step.Succeeded, "%d succeeded (want %d)", succeeded, *step.Succeeded)
+		if step.Expect == "" {
+			return
+		}
+		for message := range failures {
+			r.assert(strings.Contains(strings.ToLower(message), strings.ToLower(step.Expect)), "failed with %q (want %q)", message, step.Expect)
+		}
+		return
+	}
+
+	expect := step.Expect
+	if expect == "" {
+		expect = "ok"
+	}
+	for _, err := range errs {
+		switch {
+		case expect == "ok":
+			r.assert(err == nil, "succeeded%s", errSuffix(err))
+		case err == nil:
+			r.assert(false, "succeeded (want an error containing %q)", expect)
+		default:
+			r.assert(strings.Contains(strings.ToLower(err.Error()), strings.ToLower(expect)), "failed with %q (want %q)", err, expect)
+		}
+	}
+}
+
+// assert prints one ✓ or ✗ line and counts it
+func (r *Runner) assert(ok bool, format string, args ...any) {
+	mark := "✓"
+	if ok {
+		r.report.Passed++
+	} else {
+		r.report.Failed++
+		mark = "✗"
+	}
+	fmt.Fprintf(r.out, "    %s %s\n", mark, fmt.Sprintf(format, args...))
+}
+
+func errSuffix(err error) string {
+	if err == nil {
+		return ""
+	}
+	return fmt.Sprintf(" - got %q", err)
+}
+
+// Helpers
+
+// race runs fn n times on separate goroutines released at the same instant and collects their errors
+func race(n int, fn func(i int) error) []error {
+	errs := make([]error, n)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = fn(i)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	return errs
+}
+
+// asAdmin acts as the scenario's super admin for services that authorize the caller
+func (r *Runner) asAdmin(ctx context.Context) context.Context {
+	return services.WithCaller(ctx, r.admin.ID)
+}
+
+// phone hands out unique phone numbers, which user creation requires
+func (r *Runner) phone() string {
+	r.phones++
+	return fmt.Sprintf("+1555%07d", r.phones)
+}
+
+// price converts a script's price in major units, defaulting to 100
+func price(major float64) models.Money {
+	if major == 0 {
+		major = 100
+	}
+	return models.NewMoney(int64(math.Round(major*100)), models.DefaultCurrency)
+}
@@ -0,0 +1,92 @@
+package scenario
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario is a scripted flow of steps run against a fresh, in-memory application
+type Scenario struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Steps       []Step `yaml:"steps"`
+}
+
+// Step is one action of a scenario. Do picks the action; the other fields are its arguments,
+// and names given with As let later steps refer back to what this one created.
+//
+//	user     As, Name, Count          - Count > 1 creates a group As1..AsN that races together
+//	movie    As, Title, Minutes, Genre, Language
+//	theatre  As, Name, City, Price    - onboarded with one default-layout screen
+//	show     As, Movie, Theatre, StartsIn, Price
+//	book     As, User, Show, Seats    - a group user books the same seats concurrently
+//	pay      Booking, Method, Gateway, Times - Times > 1 pays the booking concurrently
+//	cancel   Booking
+//	check    Show+Available and/or Booking+Status
+type Step struct {
+	Do       string   `yaml:"do"`
+	As       string   `yaml:"as"`
+	Name     string   `yaml:"name"`
+	Count    int      `yaml:"count"`
+	Title    string   `yaml:"title"`
+	Minutes  int      `yaml:"minutes"`
+	Genre    string   `yaml:"genre"`
+	Language string   `yaml:"language"`
+	City     string   `yaml:"city"`
+	Price    float64  `yaml:"price"`
+	Movie    string   `yaml:"movie"`
+	Theatre  string   `yaml:"theatre"`
+	StartsIn Duration `yaml:"starts_in"`
+	User     string   `yaml:"user"`
+	Show     string   `yaml:"show"`
+	Seats    []string `yaml:"seats"`
+	Booking  string   `yaml:"booking"`
+	Method   string   `yaml:"method"`
+	Gateway  Outcome  `yaml:"gateway"`
+	Times    int      `yaml:"times"`
+
+	// Assertions
+	Expect    string `yaml:"expect"`    // "ok" (the default) or text the error must contain, e.g. "not available"
+	Succeeded *int   `yaml:"succeeded"` // For races: how many of the concurrent calls must succeed
+	Available *int   `yaml:"available"` // check: seats still free on Show
+	Status    string `yaml:"status"`    // check: Booking's status, e.g. CONFIRMED
+}
+
+// Duration reads "2h30m" style strings
+type Duration time.Duration
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	parsed, err := time.ParseDuration(node.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", node.Line, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Load reads a scenario from a YAML file; JSON is valid YAML, so .json scripts load too
+func Load(path string) (*Scenario, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unknown keys are almost always typos that would silently skip an assertion
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+	var s Scenario
+	if err := decoder.Decode(&s); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(s.Steps) == 0 {
+		return nil, fmt.Errorf("%s has no steps", path)
+	}
+	if s.Name == "" {
+		s.Name = path
+	}
+	return &s, nil
+}
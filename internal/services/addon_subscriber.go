@@ -0,0 +1,15 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/events"
+	"context"
+)
+
+// RegisterAddOnSubscriber fulfils the add-ons of confirmed bookings, e.g. issuing insurance policies and parking
+// passes - demonstrates Observer Pattern
+func RegisterAddOnSubscriber(bus events.EventBus, bookingService BookingService) {
+	bus.Subscribe(events.EventBookingConfirmed, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingConfirmed)
+		return bookingService.FulfillAddOns(ctx, e.BookingID)
+	})
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// AddOn is one kind of extra sold with bookings - demonstrates Strategy Pattern: each add-on prices itself and
+// has its own fulfilment hook, so the booking service handles insurance, 3D glasses and parking the same way
+type AddOn interface {
+	Type() models.AddOnType
+	Offer(ctx context.Context, show *models.Show) (AddOnOffer, bool) // False when the show can't have it
+	Price(show *models.Show, booking *models.Booking, quantity int) (models.BookingAddOn, error)
+	Fulfill(ctx context.Context, booking *models.Booking, addOn models.BookingAddOn) (reference string, err error) // Runs once the booking is paid
+}
+
+// ReservableAddOn is an add-on drawn from limited stock, such as parking slots. Its units are reserved while the
+// booking is made, before it is saved; Unreserve gives them back if the booking falls through after that.
+type ReservableAddOn interface {
+	AddOn
+	Reserve(ctx context.Context, show *models.Show, booking *models.Booking, addOn models.BookingAddOn) error
+	Unreserve(ctx context.Context, booking *models.Booking)
+}
+
+// AddOnOffer is an add-on a booking for a show can buy, at a fixed price or a percent of the tickets
+type AddOnOffer struct {
+	Type        models.AddOnType `json:"type"`
+	Description string           `json:"description"`
+	UnitPrice   *models.Money    `json:"unit_price,omitempty"`
+	Percent     float64          `json:"percent,omitempty"`      // Of the discounted ticket amount
+	MaxPerSeat  bool             `json:"max_per_seat,omitempty"` // At most one unit per booked seat
+	PerBooking  bool             `json:"per_booking,omitempty"`  // Exactly one unit covers the booking
+	Available   *int             `json:"available,omitempty"`    // Units left, for add-ons from limited stock
+}
+
+// AddOnCatalog holds the add-ons bookings can buy - demonstrates Registry Pattern: add-ons can be registered
+// while the service runs, and are offered in registration order
+type AddOnCatalog struct {
+	addOns []AddOn
+	mutex  sync.RWMutex
+}
+
+// NewAddOnCatalog creates a catalog of the given add-ons
+func NewAddOnCatalog(addOns ...AddOn) *AddOnCatalog {
+	catalog := &AddOnCatalog{}
+	for _, addOn := range addOns {
+		catalog.Register(addOn)
+	}
+	return catalog
+}
+
+// DefaultAddOns is cancellation insurance, 3D glasses and parking, leaving out those the config doesn't price.
+// Parking slots come from the theatre's car park through the parking service; without one, parking is
+// offered at every theatre without a limit.
+func DefaultAddOns(config models.AddOnConfig, parkingService ParkingService) []AddOn {
+	var addOns []AddOn
+	if config.InsurancePercent > 0 {
+		addOns = append(addOns, &cancellationInsurance{percent: config.InsurancePercent})
+	}
+	if config.GlassesPrice > 0 {
+		addOns = append(addOns, &glasses3D{price: config.GlassesPrice})
+	}
+	if config.ParkingPrice > 0 {
+		addOns = append(addOns, &parking{price: config.ParkingPrice, lots: parkingService})
+	}
+	return addOns
+}
+
+// Register adds an add-on; each type can be registered once
+func (c *AddOnCatalog) Register(addOn AddOn) error {
+	if addOn == nil || addOn.Type() == "" {
+		return models.ErrInvalidAddOn
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, registered := range c.addOns {
+		if registered.Type() == addOn.Type() {
+			return fmt.Errorf("%w: %s is already registered", models.ErrInvalidAddOn, addOn.Type())
+		}
+	}
+	c.addOns = append(c.addOns, addOn)
+	return nil
+}
+
+// Lookup returns the add-on of a type
+func (c *AddOnCatalog) Lookup(addOnType models.AddOnType) (AddOn, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	for _, addOn := range c.addOns {
+		if addOn.Type() == addOnType {
+			return addOn, true
+		}
+	}
+	return nil, false
+}
+
+// Offers lists the add-ons a booking for the show can buy
+func (c *AddOnCatalog) Offers(ctx context.Context, show *models.Show) []AddOnOffer {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	offers := []AddOnOffer{}
+	for _, addOn := range c.addOns {
+		if offer, ok := addOn.Offer(ctx, show); ok {
+			offers = append(offers, offer)
+		}
+	}
+	return offers
+}
+
+// Price prices the requested add-ons for a booking; an unknown type, one the show can't have or a type asked
+// for twice fails the lot
+func (c *AddOnCatalog) Price(ctx context.Context, show *models.Show, booking *models.Booking, requests []models.AddOnRequest) ([]models.BookingAddOn, error) {
+	priced := make([]models.BookingAddOn, 0, len(requests))
+	seen := make(map[models.AddOnType]bool, len(requests))
+	for _, request := range requests {
+		if seen[request.Type] || request.Quantity < 0 {
+			return nil, fmt.Errorf("%w: %s", models.ErrInvalidAddOn, request.Type)
+		}
+		seen[request.Type] = true
+
+		addOn, ok := c.Lookup(request.Type)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", models.ErrAddOnUnavailable, request.Type)
+		}
+		if _, ok := addOn.Offer(ctx, show); !ok {
+			return nil, fmt.Errorf("%w: %s", models.ErrAddOnUnavailable, request.Type)
+		}
+
+		item, err := addOn.Price(show, booking, max(request.Quantity, 1))
+		if err != nil {
+			return nil, err
+		}
+		priced = append(priced, item)
+	}
+	return priced, nil
+}
+
+// cancellationInsurance refunds a cancelled booking in full, less its premium, whatever the theatre's policy.
+// The refund itself is worked out by BookingService.CancelBooking.
+type cancellationInsurance struct {
+	percent float64
+}
+
+func (i *cancellationInsurance) Type() models.AddOnType {
+	return models.AddOnCancellationInsurance
+}
+
+func (i *cancellationInsurance) Offer(ctx context.Context, show *models.Show) (AddOnOffer, bool) {
+	return AddOnOffer{
+		Type:        i.Type(),
+		Description: "Cancellation insurance: a full refund, less the premium, whenever you cancel",
+		Percent:     i.percent,
+		PerBooking:  true,
+	}, true
+}
+
+func (i *cancellationInsurance) Price(show *models.Show, booking *models.Booking, quantity int) (models.BookingAddOn, error) {
+	if quantity != 1 {
+		return models.BookingAddOn{}, fmt.Errorf("%w: one policy covers the whole booking", models.ErrInvalidAddOn)
+	}
+	breakdown := booking.GetPriceBreakdown()
+	premium := breakdown.Subtotal.Sub(breakdown.Discount).Percent(i.percent)
+	return models.NewBookingAddOn(i.Type(), fmt.Sprintf("Cancellation insurance (%g%%)", i.percent), 1, premium)
+}
+
+func (i *cancellationInsurance) Fulfill(ctx context.Context, booking *models.Booking, addOn models.BookingAddOn) (string, error) {
+	return "INS-" + booking.Reference, nil
+}
+
+// glasses3D are pairs of 3D glasses collected at the counter, at most one per seat
+type glasses3D struct {
+	price int64
+}
+
+func (g *glasses3D) Type() models.AddOnType {
+	return models.AddOn3DGlasses
+}
+
+func (g *glasses3D) Offer(ctx context.Context, show *models.Show) (AddOnOffer, bool) {
+	if show.Format != models.ShowFormat3D {
+		return AddOnOffer{}, false
+	}
+	price := models.NewMoney(g.price, show.BasePrice.Currency)
+	return AddOnOffer{Type: g.Type(), Description: "3D glasses to keep, collected at the counter", UnitPrice: &price, MaxPerSeat: true}, true
+}
+
+func (g *glasses3D) Price(show *models.Show, booking *models.Booking, quantity int) (models.BookingAddOn, error) {
+	if quantity > booking.GetSeatCount() {
+		return models.BookingAddOn{}, fmt.Errorf("%w: at most one pair of 3D glasses per seat", models.ErrInvalidAddOn)
+	}
+	return models.NewBookingAddOn(g.Type(), "3D glasses", quantity, models.NewMoney(g.price, booking.SubtotalAmount.Currency))
+}
+
+func (g *glasses3D) Fulfill(ctx context.Context, booking *models.Booking, addOn models.BookingAddOn) (string, error) {
+	return fmt.Sprintf("GLS-%s-%d", booking.Reference, addOn.Quantity), nil
+}
+
+// parking reserves a slot per vehicle in the theatre's car park, at most one per seat. Slots are held with the
+// booking and issued as its parking pass once it is paid; see ParkingService.
+type parking struct {
+	price int64
+	lots  ParkingService // nil offers parking everywhere, without slots
+}
+
+func (p *parking) Type() models.AddOnType {
+	return models.AddOnParking
+}
+
+func (p *parking) Offer(ctx context.Context, show *models.Show) (AddOnOffer, bool) {
+	price := models.NewMoney(p.price, show.BasePrice.Currency)
+	offer := AddOnOffer{Type: p.Type(), Description: "A parking slot at the theatre for the show", UnitPrice: &price, MaxPerSeat: true}
+	if p.lots == nil {
+		return offer, true
+	}
+
+	availability, err := p.lots.GetAvailability(ctx, show.ID)
+	if err != nil || availability.Capacity == 0 {
+		return AddOnOffer{}, false
+	}
+	offer.Available = &availability.Available
+	return offer, true
+}
+
+func (p *parking) Price(show *models.Show, booking *models.Booking, quantity int) (models.BookingAddOn, error) {
+	if quantity > booking.GetSeatCount() {
+		return models.BookingAddOn{}, fmt.Errorf("%w: at most one parking slot per seat", models.ErrInvalidAddOn)
+	}
+	return models.NewBookingAddOn(p.Type(), "Parking", quantity, models.NewMoney(p.price, booking.SubtotalAmount.Currency))
+}
+
+func (p *parking) Reserve(ctx context.Context, show *models.Show, booking *models.Booking, addOn models.BookingAddOn) error {
+	if p.lots == nil {
+		return nil
+	}
+	_, err := p.lots.Reserve(ctx, show, booking, addOn.Quantity)
+	return err
+}
+
+func (p *parking) Unreserve(ctx context.Context, booking *models.Booking) {
+	if p.lots != nil {
+		p.lots.Cancel(ctx, booking.ID)
+	}
+}
+
+// Fulfill confirms the held slots; the pass names them, e.g. "PRK-BMS-7F3K9Q P004,P005"
+func (p *parking) Fulfill(ctx context.Context, booking *models.Booking, addOn models.BookingAddOn) (string, error) {
+	if p.lots == nil {
+		return fmt.Sprintf("PRK-%s-%d", booking.Reference, addOn.Quantity), nil
+	}
+
+	reservation, err := p.lots.Confirm(ctx, booking.ID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("PRK-%s %s", booking.Reference, strings.Join(reservation.GetSlots(), ",")), nil
+}
@@ -0,0 +1,686 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/pricing"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AdminServiceImpl implements AdminService - theatre partner operations behind a role check
+type AdminServiceImpl struct {
+	userRepo       repositories.UserRepository
+	theatreRepo    repositories.TheatreRepository
+	screenRepo     repositories.ScreenRepository
+	theatreService TheatreService
+	showService    ShowService
+	outbox         OutboxService     // Dead letters are inspected and redelivered by admins
+	notifications  NotificationQueue // As are notifications that couldn't be delivered
+	auditLog       AuditLog          // Admin changes are recorded here, and read back by entity
+	authorizer     Authorizer
+	seatFactory    *factories.SeatFactory           // Factory Pattern - seat layouts for new screens
+	templates      *factories.ScreenTemplateLibrary // Named layouts screens can be created from
+	calendar       *pricing.Calendar                // Holiday and peak days the seat pricing consults
+	whatsApp       *WhatsAppTemplates               // Templates the WhatsApp channel sends notifications with
+	clock          clock.Clock
+}
+
+// NewAdminService creates a new admin service
+func NewAdminService(
+	userRepo repositories.UserRepository,
+	theatreRepo repositories.TheatreRepository,
+	screenRepo repositories.ScreenRepository,
+	theatreService TheatreService,
+	showService ShowService,
+	outbox OutboxService,
+	notifications NotificationQueue,
+	auditLog AuditLog,
+	authorizer Authorizer,
+	seatFactory *factories.SeatFactory,
+	calendar *pricing.Calendar,
+	whatsAppTemplates *WhatsAppTemplates,
+	clock clock.Clock,
+) AdminService {
+	if seatFactory == nil {
+		seatFactory = factories.NewSeatFactory()
+	}
+	if calendar == nil {
+		calendar = pricing.NewCalendar()
+	}
+	if whatsAppTemplates == nil {
+		whatsAppTemplates = NewWhatsAppTemplates(clock, models.DefaultWhatsAppTemplates(clock.Now())...)
+	}
+	return &AdminServiceImpl{
+		userRepo:       userRepo,
+		theatreRepo:    theatreRepo,
+		screenRepo:     screenRepo,
+		theatreService: theatreService,
+		showService:    showService,
+		outbox:         outbox,
+		notifications:  notifications,
+		auditLog:       auditLog,
+		authorizer:     authorizer,
+		seatFactory:    seatFactory,
+		templates:      factories.DefaultScreenTemplateLibrary(),
+		calendar:       calendar,
+		whatsApp:       whatsAppTemplates,
+		clock:          clock,
+	}
+}
+
+// GrantRole changes another user's role
+func (as *AdminServiceImpl) GrantRole(ctx context.Context, adminID, userID string, role models.UserRole, theatreIDs ...string) (*models.User, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageUsers, ""); err != nil {
+		return nil, err
+	}
+
+	for _, theatreID := range theatreIDs {
+		if _, err := as.theatreRepo.GetByID(ctx, theatreID); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := as.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := user.Role
+	if err := user.SetRole(role, as.clock.Now(), theatreIDs...); err != nil {
+		return nil, err
+	}
+
+	if err := as.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{"old_role": string(previous), "new_role": string(role)}
+	if len(theatreIDs) > 0 {
+		details["theatres"] = strings.Join(theatreIDs, ",")
+	}
+	as.record(ctx, adminID, models.AuditEntityUser, user.ID, models.AuditRoleGranted, details)
+	return user, nil
+}
+
+// BlockUser stops a user from holding seats or booking; bookings they already made stand
+func (as *AdminServiceImpl) BlockUser(ctx context.Context, adminID, userID, reason string) (*models.User, error) {
+	user, err := as.updateUser(ctx, adminID, userID, func(user *models.User) error {
+		user.Block(reason, as.clock.Now())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	as.record(ctx, adminID, models.AuditEntityUser, user.ID, models.AuditUserBlocked, map[string]string{"reason": reason})
+	return user, nil
+}
+
+// UnblockUser lets a blocked user book again
+func (as *AdminServiceImpl) UnblockUser(ctx context.Context, adminID, userID string) (*models.User, error) {
+	user, err := as.updateUser(ctx, adminID, userID, func(user *models.User) error {
+		user.Unblock(as.clock.Now())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	as.record(ctx, adminID, models.AuditEntityUser, user.ID, models.AuditUserUnblocked, nil)
+	return user, nil
+}
+
+// GetPendingDiscountProfiles lists the users whose discount profiles wait for review
+func (as *AdminServiceImpl) GetPendingDiscountProfiles(ctx context.Context, adminID string) ([]*models.User, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageUsers, ""); err != nil {
+		return nil, err
+	}
+
+	users, err := as.userRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []*models.User
+	for _, user := range users {
+		if user.DiscountProfile != nil && user.DiscountProfile.Status == models.VerificationPending {
+			pending = append(pending, user)
+		}
+	}
+	slices.SortFunc(pending, func(a, b *models.User) int {
+		return a.DiscountProfile.SubmittedAt.Compare(b.DiscountProfile.SubmittedAt)
+	})
+	return pending, nil
+}
+
+// VerifyDiscountProfile accepts a user's pending discount profile, so their bookings get its discount
+func (as *AdminServiceImpl) VerifyDiscountProfile(ctx context.Context, adminID, userID string) (*models.User, error) {
+	user, err := as.updateUser(ctx, adminID, userID, func(user *models.User) error { return user.VerifyDiscountProfile(adminID, as.clock.Now()) })
+	if err != nil {
+		return nil, err
+	}
+
+	as.record(ctx, adminID, models.AuditEntityUser, user.ID, models.AuditDiscountProfileVerified, map[string]string{
+		"category":    string(user.DiscountProfile.Category),
+		"document_id": user.DiscountProfile.DocumentID,
+	})
+	return user, nil
+}
+
+// RejectDiscountProfile turns down a user's pending discount profile with a reason they can act on
+func (as *AdminServiceImpl) RejectDiscountProfile(ctx context.Context, adminID, userID, reason string) (*models.User, error) {
+	user, err := as.updateUser(ctx, adminID, userID, func(user *models.User) error { return user.RejectDiscountProfile(adminID, reason, as.clock.Now()) })
+	if err != nil {
+		return nil, err
+	}
+
+	as.record(ctx, adminID, models.AuditEntityUser, user.ID, models.AuditDiscountProfileRejected, map[string]string{
+		"category": string(user.DiscountProfile.Category),
+		"reason":   reason,
+	})
+	return user, nil
+}
+
+// updateUser applies change to a user on behalf of an admin who may manage users
+func (as *AdminServiceImpl) updateUser(ctx context.Context, adminID, userID string, change func(*models.User) error) (*models.User, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageUsers, ""); err != nil {
+		return nil, err
+	}
+
+	user, err := as.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := change(user); err != nil {
+		return nil, err
+	}
+	if err := as.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// OnboardTheatre registers a new partner theatre
+func (as *AdminServiceImpl) OnboardTheatre(ctx context.Context, adminID, name, address, city string, opts ...TheatreOption) (*models.Theatre, error) {
+	return as.theatreService.CreateTheatre(WithCaller(ctx, adminID), name, address, city, opts...)
+}
+
+// AddCity adds a city to the catalog users pick from
+func (as *AdminServiceImpl) AddCity(ctx context.Context, adminID, name, region string) (*models.City, error) {
+	return as.theatreService.AddCity(WithCaller(ctx, adminID), name, region)
+}
+
+// RegisterSeatType makes a new seat type available to screen layouts - demonstrates Open/Closed Principle
+func (as *AdminServiceImpl) RegisterSeatType(ctx context.Context, adminID string, seatType models.SeatType, info factories.SeatTypeInfo) (factories.SeatTypeInfo, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageCatalog, ""); err != nil {
+		return factories.SeatTypeInfo{}, err
+	}
+
+	if err := as.seatFactory.Registry().Register(seatType, info); err != nil {
+		return factories.SeatTypeInfo{}, err
+	}
+
+	as.record(ctx, adminID, models.AuditEntitySeatType, string(seatType), models.AuditSeatTypeRegistered, map[string]string{
+		"multiplier": strconv.FormatFloat(info.Multiplier, 'f', -1, 64),
+	})
+	return info, nil
+}
+
+// AddScreen creates a screen with the given seat layout - demonstrates Factory Pattern
+func (as *AdminServiceImpl) AddScreen(ctx context.Context, adminID, theatreID, name string, layout factories.ScreenConfig, basePrice models.Money) (*models.Screen, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageTheatre, theatreID); err != nil {
+		return nil, err
+	}
+
+	if name == "" || !basePrice.IsPositive() {
+		return nil, models.ErrInvalidTheatreData
+	}
+	if err := as.validateLayout(layout); err != nil {
+		return nil, err
+	}
+
+	screen := models.NewScreen(name, theatreID)
+	for _, seat := range as.seatFactory.CreateSeatsForScreen(screen.ID, layout, basePrice) {
+		screen.AddSeat(seat)
+	}
+	as.seatFactory.ApplyAisles(screen, layout)
+	if err := as.seatFactory.ApplySeatGroups(screen, layout); err != nil {
+		return nil, err
+	}
+
+	if err := as.theatreService.AddScreen(WithCaller(ctx, adminID), theatreID, screen); err != nil {
+		return nil, err
+	}
+
+	return screen, nil
+}
+
+// CloneScreen copies an existing screen's layout and pricing into a new screen of theatreID, or of the
+// source's own theatre when theatreID is empty
+func (as *AdminServiceImpl) CloneScreen(ctx context.Context, adminID, theatreID, screenID, name string) (*models.Screen, error) {
+	if theatreID == "" {
+		source, err := as.screenRepo.GetByID(ctx, screenID)
+		if err != nil {
+			return nil, err
+		}
+		theatreID = source.TheatreID
+	}
+	return as.theatreService.CloneScreen(WithCaller(ctx, adminID), theatreID, screenID, name)
+}
+
+// AddScreensFromTemplate creates one screen per name, each laid out by the named template - a multiplex is
+// onboarded in one call. Names must be distinct; on a failure the screens created so far are returned with it.
+func (as *AdminServiceImpl) AddScreensFromTemplate(ctx context.Context, adminID, theatreID, template string, names []string, basePrice models.Money) ([]*models.Screen, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageTheatre, theatreID); err != nil {
+		return nil, err
+	}
+
+	screenTemplate, err := as.templates.Lookup(template)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, models.ErrInvalidTheatreData
+	}
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name == "" || seen[name] {
+			return nil, fmt.Errorf("%w: screen names must be given and distinct", models.ErrInvalidTheatreData)
+		}
+		seen[name] = true
+	}
+
+	screens := make([]*models.Screen, 0, len(names))
+	for _, name := range names {
+		screen, err := as.AddScreen(ctx, adminID, theatreID, name, screenTemplate.Layout, basePrice)
+		if err != nil {
+			return screens, err
+		}
+		screens = append(screens, screen)
+	}
+	return screens, nil
+}
+
+// RegisterScreenTemplate adds a named layout to the library after checking it as AddScreen would
+func (as *AdminServiceImpl) RegisterScreenTemplate(ctx context.Context, adminID string, template factories.ScreenTemplate) (factories.ScreenTemplate, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageCatalog, ""); err != nil {
+		return factories.ScreenTemplate{}, err
+	}
+
+	if err := as.validateLayout(template.Layout); err != nil {
+		return factories.ScreenTemplate{}, err
+	}
+	registered, err := as.templates.Register(template)
+	if err != nil {
+		return factories.ScreenTemplate{}, err
+	}
+
+	as.record(ctx, adminID, models.AuditEntityTemplate, registered.Name, models.AuditScreenTemplateRegistered, map[string]string{
+		"capacity": strconv.Itoa(registered.Capacity),
+	})
+	return registered, nil
+}
+
+// GetPricingCalendar lists the holiday and peak days, earliest first
+func (as *AdminServiceImpl) GetPricingCalendar(ctx context.Context, adminID string) ([]pricing.CalendarDay, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageCatalog, ""); err != nil {
+		return nil, err
+	}
+	return as.calendar.Days(), nil
+}
+
+// SetPricingDay flags a date as a holiday or peak day; shows starting that day are priced by its multiplier
+// from the next quote on, bookings already made keep their price
+func (as *AdminServiceImpl) SetPricingDay(ctx context.Context, adminID string, day pricing.CalendarDay) (pricing.CalendarDay, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageCatalog, ""); err != nil {
+		return pricing.CalendarDay{}, err
+	}
+
+	day, err := as.calendar.Set(day)
+	if err != nil {
+		return pricing.CalendarDay{}, err
+	}
+
+	as.record(ctx, adminID, models.AuditEntityPricing, day.Date.Format(time.DateOnly), models.AuditPricingDaySet, map[string]string{
+		"kind":       string(day.Kind),
+		"name":       day.Name,
+		"multiplier": strconv.FormatFloat(day.Multiplier, 'f', -1, 64),
+	})
+	return day, nil
+}
+
+// ClearPricingDay takes a date off the calendar, so its shows go back to their usual prices
+func (as *AdminServiceImpl) ClearPricingDay(ctx context.Context, adminID string, date time.Time) error {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageCatalog, ""); err != nil {
+		return err
+	}
+
+	if !as.calendar.Remove(date) {
+		return fmt.Errorf("%w: %s", models.ErrPricingDayNotFound, date.Format(time.DateOnly))
+	}
+	as.record(ctx, adminID, models.AuditEntityPricing, date.Format(time.DateOnly), models.AuditPricingDayCleared, nil)
+	return nil
+}
+
+// validateLayout checks a screen layout has rows and every row and section makes sense
+func (as *AdminServiceImpl) validateLayout(layout factories.ScreenConfig) error {
+	if len(layout.AllRows()) == 0 {
+		return models.ErrInvalidTheatreData
+	}
+	if err := as.seatFactory.ValidateLayout(layout); err != nil {
+		return fmt.Errorf("%w: %v", models.ErrInvalidTheatreData, err)
+	}
+
+	for _, row := range layout.AllRows() {
+		if row.Name == "" || row.Count <= 0 {
+			return models.ErrInvalidTheatreData
+		}
+		if err := as.seatFactory.ValidateSeatType(row.Type); err != nil {
+			return fmt.Errorf("%w: %v", models.ErrInvalidTheatreData, err)
+		}
+		for _, validate := range []func(factories.RowConfig) error{
+			as.seatFactory.ValidateAisles,
+			as.seatFactory.ValidateSeatGroups,
+			as.seatFactory.ValidateAccessibility,
+			as.seatFactory.ValidateMissingSeats,
+		} {
+			if err := validate(row); err != nil {
+				return fmt.Errorf("%w: %v", models.ErrInvalidTheatreData, err)
+			}
+		}
+	}
+	return nil
+}
+
+// CreateShowsFromTemplate creates every show in a weekly schedule.
+// Slots that fail (e.g. clashes) are skipped; the created shows are returned with the joined errors.
+func (as *AdminServiceImpl) CreateShowsFromTemplate(ctx context.Context, adminID string, template WeeklyShowTemplate) ([]*models.Show, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageTheatre, template.TheatreID); err != nil {
+		return nil, err
+	}
+	ctx = WithCaller(ctx, adminID)
+
+	if len(template.Slots) == 0 || template.WeekStart.IsZero() || template.Weeks < 0 {
+		return nil, models.ErrInvalidShowData
+	}
+
+	weeks := template.Weeks
+	if weeks == 0 {
+		weeks = 1
+	}
+
+	var opts []ShowOption
+	if template.Format != "" {
+		opts = append(opts, WithFormat(template.Format))
+	}
+	if template.Language != "" {
+		opts = append(opts, WithLanguage(template.Language))
+	}
+
+	start := template.WeekStart
+	midnight := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+
+	var shows []*models.Show
+	var errs []error
+	for day := 0; day < weeks*7; day++ {
+		date := midnight.AddDate(0, 0, day)
+		for _, slot := range template.Slots {
+			if slot.Weekday != date.Weekday() {
+				continue
+			}
+
+			if err := ctx.Err(); err != nil {
+				return shows, errors.Join(append(errs, err)...)
+			}
+
+			startTime := date.Add(slot.StartTime)
+			show, err := as.showService.CreateShow(ctx, template.MovieID, template.TheatreID, template.ScreenID, startTime, template.BasePrice, opts...)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("show at %s: %w", startTime.Format(time.RFC3339), err))
+				continue
+			}
+			shows = append(shows, show)
+		}
+	}
+
+	return shows, errors.Join(errs...)
+}
+
+// SetScreenMaintenance takes a screen offline (no new shows or bookings) or brings it back
+func (as *AdminServiceImpl) SetScreenMaintenance(ctx context.Context, adminID, screenID string, offline bool) (*models.Screen, error) {
+	screen, err := as.screenRepo.GetByID(ctx, screenID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageTheatre, screen.TheatreID); err != nil {
+		return nil, err
+	}
+
+	screen.SetMaintenance(offline)
+	if err := as.screenRepo.Update(ctx, screen); err != nil {
+		return nil, err
+	}
+
+	as.record(ctx, adminID, models.AuditEntityScreen, screen.ID, models.AuditScreenMaintenanceChange, map[string]string{
+		"offline": strconv.FormatBool(offline),
+	})
+
+	return screen, nil
+}
+
+// CancelShow calls off a show, refunding and notifying everyone who booked it
+func (as *AdminServiceImpl) CancelShow(ctx context.Context, adminID, showID, reason string) (*ShowCancellation, error) {
+	return as.showService.CancelShow(WithCaller(ctx, adminID), showID, reason)
+}
+
+// RescheduleShow moves a show to a new start time and notifies its bookers
+func (as *AdminServiceImpl) RescheduleShow(ctx context.Context, adminID, showID string, startTime time.Time) (*ShowReschedule, error) {
+	return as.showService.RescheduleShow(WithCaller(ctx, adminID), showID, startTime)
+}
+
+// SetCancellationPolicy configures a theatre's tiered refunds for user cancellations
+func (as *AdminServiceImpl) SetCancellationPolicy(ctx context.Context, adminID, theatreID string, tiers []models.CancellationTier) (*models.Theatre, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageTheatre, theatreID); err != nil {
+		return nil, err
+	}
+
+	theatre, err := as.theatreRepo.GetByID(ctx, theatreID)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy *models.CancellationPolicy
+	if len(tiers) > 0 {
+		if policy, err = models.NewCancellationPolicy(tiers); err != nil {
+			return nil, err
+		}
+	}
+
+	theatre.SetCancellationPolicy(policy, as.clock.Now())
+	if err := as.theatreRepo.Update(ctx, theatre); err != nil {
+		return nil, err
+	}
+
+	as.record(ctx, adminID, models.AuditEntityTheatre, theatre.ID, models.AuditCancellationPolicyChange, map[string]string{
+		"tiers": strconv.Itoa(len(tiers)),
+	})
+
+	return theatre, nil
+}
+
+// SetTheatreBookingTimeout changes the payment window the theatre's new shows start with; existing shows keep theirs
+func (as *AdminServiceImpl) SetTheatreBookingTimeout(ctx context.Context, adminID, theatreID string, timeout time.Duration) (*models.Theatre, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageTheatre, theatreID); err != nil {
+		return nil, err
+	}
+
+	theatre, err := as.theatreRepo.GetByID(ctx, theatreID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := theatre.GetBookingTimeout()
+	if err := theatre.SetBookingTimeout(timeout, as.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := as.theatreRepo.Update(ctx, theatre); err != nil {
+		return nil, err
+	}
+
+	as.record(ctx, adminID, models.AuditEntityTheatre, theatre.ID, models.AuditBookingTimeoutChange, map[string]string{
+		"old_timeout": previous.String(),
+		"new_timeout": timeout.String(),
+	})
+	return theatre, nil
+}
+
+// SetTheatreSaleWindow changes when bookings open for the theatre's shows created afterwards, e.g. 7 days
+// before each show. Movies with their own window keep it.
+func (as *AdminServiceImpl) SetTheatreSaleWindow(ctx context.Context, adminID, theatreID string, window models.SaleWindow) (*models.Theatre, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionManageTheatre, theatreID); err != nil {
+		return nil, err
+	}
+
+	theatre, err := as.theatreRepo.GetByID(ctx, theatreID)
+	if err != nil {
+		return nil, err
+	}
+
+	theatre.SetSaleWindow(window, as.clock.Now())
+	if err := as.theatreRepo.Update(ctx, theatre); err != nil {
+		return nil, err
+	}
+
+	as.record(ctx, adminID, models.AuditEntityTheatre, theatre.ID, models.AuditSaleWindowChange, saleWindowDetails(window))
+	return theatre, nil
+}
+
+// saleWindowDetails describes a sale window for the audit trail
+func saleWindowDetails(window models.SaleWindow) map[string]string {
+	details := map[string]string{"opens_before": window.OpensBefore.String()}
+	if !window.OpensAt.IsZero() {
+		details["opens_at"] = window.OpensAt.Format(time.RFC3339)
+	}
+	return details
+}
+
+// SetShowBookingTimeout changes how long new bookings for one show wait for payment
+func (as *AdminServiceImpl) SetShowBookingTimeout(ctx context.Context, adminID, showID string, timeout time.Duration) (*models.Show, error) {
+	return as.showService.SetBookingTimeout(WithCaller(ctx, adminID), showID, timeout)
+}
+
+// WithholdSeats takes seats of one show off sale, e.g. broken seats or seats kept for press
+func (as *AdminServiceImpl) WithholdSeats(ctx context.Context, adminID, showID string, seatIDs []string, status models.SeatStatus, reason string) ([]*models.HouseSeat, error) {
+	return as.showService.WithholdSeats(WithCaller(ctx, adminID), showID, seatIDs, status, reason)
+}
+
+// ReleaseHouseSeats puts held back seats of a show back on sale
+func (as *AdminServiceImpl) ReleaseHouseSeats(ctx context.Context, adminID, showID string, seatIDs []string) ([]*models.HouseSeat, error) {
+	return as.showService.ReleaseHouseSeats(WithCaller(ctx, adminID), showID, seatIDs)
+}
+
+// GetHouseSeats lists a show's held back seats with who held them back and why
+func (as *AdminServiceImpl) GetHouseSeats(ctx context.Context, adminID, showID string) ([]*models.HouseSeat, error) {
+	return as.showService.GetHouseSeats(WithCaller(ctx, adminID), showID)
+}
+
+// GetDeadLetters lists events whose delivery ran out of retries
+func (as *AdminServiceImpl) GetDeadLetters(ctx context.Context, adminID string) ([]*models.OutboxMessage, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionOperate, ""); err != nil {
+		return nil, err
+	}
+
+	return as.outbox.GetDeadLetters(ctx)
+}
+
+// RedeliverEvent sends a dead-lettered event to its subscribers again, e.g. once a notification channel is back
+func (as *AdminServiceImpl) RedeliverEvent(ctx context.Context, adminID, messageID string) (*models.OutboxMessage, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionOperate, ""); err != nil {
+		return nil, err
+	}
+
+	return as.outbox.Redeliver(ctx, messageID)
+}
+
+// GetNotificationDeadLetters lists notifications that were given up on, with the last error of each
+func (as *AdminServiceImpl) GetNotificationDeadLetters(ctx context.Context, adminID string) ([]*models.Notification, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionOperate, ""); err != nil {
+		return nil, err
+	}
+
+	return as.notifications.GetDeadLetters(ctx)
+}
+
+// ReplayNotification sends a dead-lettered notification again, e.g. once the user has fixed their phone number
+func (as *AdminServiceImpl) ReplayNotification(ctx context.Context, adminID, notificationID string) (*models.Notification, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionOperate, ""); err != nil {
+		return nil, err
+	}
+
+	return as.notifications.Replay(ctx, notificationID)
+}
+
+// GetWhatsAppTemplates lists the template each kind of notification is sent with on WhatsApp
+func (as *AdminServiceImpl) GetWhatsAppTemplates(ctx context.Context, adminID string) ([]models.WhatsAppTemplate, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionOperate, ""); err != nil {
+		return nil, err
+	}
+	return as.whatsApp.List(), nil
+}
+
+// SetWhatsAppTemplate sets the template a kind of notification is sent with on WhatsApp, from the next message
+// on. The name must be one WhatsApp approved, or WhatsApp refuses the messages.
+func (as *AdminServiceImpl) SetWhatsAppTemplate(ctx context.Context, adminID string, template models.WhatsAppTemplate) (models.WhatsAppTemplate, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionOperate, ""); err != nil {
+		return models.WhatsAppTemplate{}, err
+	}
+
+	template, err := as.whatsApp.Set(template)
+	if err != nil {
+		return models.WhatsAppTemplate{}, err
+	}
+
+	as.record(ctx, adminID, models.AuditEntityWhatsApp, string(template.Kind), models.AuditWhatsAppTemplateSet, map[string]string{
+		"name":     template.Name,
+		"language": template.Language,
+		"header":   string(template.Header),
+	})
+	return template, nil
+}
+
+// RemoveWhatsAppTemplate stops a kind of notification being sent on WhatsApp; it still goes out on the other
+// channels
+func (as *AdminServiceImpl) RemoveWhatsAppTemplate(ctx context.Context, adminID string, kind models.NotificationKind) error {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionOperate, ""); err != nil {
+		return err
+	}
+
+	if !as.whatsApp.Remove(kind) {
+		return fmt.Errorf("%w: %s", models.ErrWhatsAppTemplateNotFound, kind)
+	}
+	as.record(ctx, adminID, models.AuditEntityWhatsApp, string(kind), models.AuditWhatsAppTemplateRemoved, nil)
+	return nil
+}
+
+// GetAuditTrail lists who changed an entity and how, e.g. a booking's confirmation, refunds and seat changes
+func (as *AdminServiceImpl) GetAuditTrail(ctx context.Context, adminID, entityID string) ([]*models.AuditEntry, error) {
+	if _, err := as.authorizer.Authorize(ctx, adminID, models.PermissionViewAudit, ""); err != nil {
+		return nil, err
+	}
+
+	return as.auditLog.GetEntityHistory(ctx, entityID)
+}
+
+// record adds an admin's change to the audit trail
+func (as *AdminServiceImpl) record(ctx context.Context, adminID string, entityType models.AuditEntityType, entityID string, action models.AuditAction, details map[string]string) {
+	as.auditLog.Record(ctx, AuditChange{EntityType: entityType, EntityID: entityID, Action: action, ActorID: adminID, Details: details})
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+)
+
+// AuditLogImpl implements AuditLog by appending entries to a repository
+type AuditLogImpl struct {
+	auditRepo repositories.AuditRepository
+	logger    logging.Logger
+	clock     clock.Clock
+}
+
+// NewAuditLog creates an audit trail kept in auditRepo
+func NewAuditLog(auditRepo repositories.AuditRepository, logger logging.Logger, clock clock.Clock) AuditLog {
+	return &AuditLogImpl{auditRepo: auditRepo, logger: logger, clock: clock}
+}
+
+// Record appends a change. The caller on the context is the actor, then the change's own ActorID, then the system.
+// The change has already happened, so a failure to record it is logged rather than returned.
+func (al *AuditLogImpl) Record(ctx context.Context, change AuditChange) {
+	actorID := CallerFromContext(ctx)
+	if actorID == "" {
+		actorID = change.ActorID
+	}
+
+	entry, err := models.NewAuditEntry(change.EntityType, change.EntityID, change.Action, actorID, change.Details, al.clock.Now())
+	if err == nil {
+		// A request cancelled after its change went through must still leave a trace
+		err = al.auditRepo.Create(context.WithoutCancel(ctx), entry)
+	}
+	if err != nil {
+		al.logger.Error(ctx, "failed to record audit entry", "entity_id", change.EntityID, "action", change.Action, "error", err)
+	}
+}
+
+// GetEntityHistory returns every change recorded against an entity, oldest first
+func (al *AuditLogImpl) GetEntityHistory(ctx context.Context, entityID string) ([]*models.AuditEntry, error) {
+	return al.auditRepo.GetByEntityID(ctx, entityID)
+}
+
+// NopAuditRecorder discards every change, for services wired without an audit trail
+func NopAuditRecorder() AuditRecorder {
+	return nopAuditRecorder{}
+}
+
+type nopAuditRecorder struct{}
+
+func (nopAuditRecorder) Record(ctx context.Context, change AuditChange) {}
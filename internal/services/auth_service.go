@@ -0,0 +1,156 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// sessionTokenSize is the random bytes in a session token
+const sessionTokenSize = 32
+
+// AuthServiceImpl implements AuthService with bcrypt password hashes and opaque session tokens.
+// Only a SHA-256 of each token is stored, so the session store alone can't be used to sign in.
+type AuthServiceImpl struct {
+	userService    UserService
+	credentialRepo repositories.CredentialRepository
+	sessionRepo    repositories.SessionRepository
+	config         models.AuthConfig
+	clock          clock.Clock
+	dummyHash      []byte // Compared against for unknown emails, so they take as long as wrong passwords
+}
+
+func NewAuthService(userService UserService, credentialRepo repositories.CredentialRepository, sessionRepo repositories.SessionRepository, config models.AuthConfig, clock clock.Clock) AuthService {
+	if config.SessionTTL <= 0 {
+		config.SessionTTL = models.DefaultAuthConfig().SessionTTL
+	}
+
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+	return &AuthServiceImpl{
+		userService:    userService,
+		credentialRepo: credentialRepo,
+		sessionRepo:    sessionRepo,
+		config:         config,
+		clock:          clock,
+		dummyHash:      dummyHash,
+	}
+}
+
+func (as *AuthServiceImpl) Signup(ctx context.Context, name, email, phoneNumber, password string) (*AuthSession, error) {
+	if err := as.config.ValidatePassword(password); err != nil {
+		return nil, err
+	}
+
+	user, err := as.userService.CreateUser(ctx, name, email, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := as.SetPassword(ctx, user.ID, password); err != nil {
+		return nil, err
+	}
+	return as.startSession(ctx, user)
+}
+
+func (as *AuthServiceImpl) Login(ctx context.Context, email, password string) (*AuthSession, error) {
+	user, err := as.userService.GetUserByEmail(ctx, email)
+	if errors.Is(err, models.ErrUserNotFound) {
+		bcrypt.CompareHashAndPassword(as.dummyHash, []byte(password))
+		return nil, models.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := as.credentialRepo.GetByUserID(ctx, user.ID)
+	if errors.Is(err, models.ErrCredentialNotFound) {
+		bcrypt.CompareHashAndPassword(as.dummyHash, []byte(password))
+		return nil, models.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(credential.PasswordHash), []byte(password)) != nil {
+		return nil, models.ErrInvalidCredentials
+	}
+	return as.startSession(ctx, user)
+}
+
+// Logout ends the token's session; unknown tokens are already signed out
+func (as *AuthServiceImpl) Logout(ctx context.Context, token string) error {
+	return as.sessionRepo.Delete(ctx, sessionID(token))
+}
+
+// Authenticate returns the user a live session token belongs to
+func (as *AuthServiceImpl) Authenticate(ctx context.Context, token string) (*models.User, error) {
+	if token == "" {
+		return nil, models.ErrInvalidSession
+	}
+
+	session, err := as.sessionRepo.GetByID(ctx, sessionID(token))
+	if errors.Is(err, models.ErrSessionNotFound) {
+		return nil, models.ErrInvalidSession
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if session.IsExpired(as.clock.Now()) {
+		as.sessionRepo.Delete(ctx, session.ID)
+		return nil, models.ErrInvalidSession
+	}
+
+	user, err := as.userService.GetUser(ctx, session.UserID)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return nil, models.ErrInvalidSession
+	}
+	return user, err
+}
+
+func (as *AuthServiceImpl) SetPassword(ctx context.Context, userID, password string) error {
+	if err := as.config.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	return as.credentialRepo.Save(ctx, &models.Credential{
+		UserID:       userID,
+		PasswordHash: string(hash),
+		UpdatedAt:    as.clock.Now(),
+	})
+}
+
+// startSession issues a new random token for the user
+func (as *AuthServiceImpl) startSession(ctx context.Context, user *models.User) (*AuthSession, error) {
+	raw := make([]byte, sessionTokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	session := models.NewSession(sessionID(token), user.ID, as.config.SessionTTL, as.clock.Now())
+	if err := as.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return &AuthSession{Token: token, ExpiresAt: session.ExpiresAt, User: user}, nil
+}
+
+// sessionID is the stored form of a token
+func sessionID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+)
+
+// callerKey is the context key for the ID of the user making the request
+type callerKey struct{}
+
+// WithCaller records who is making the request, for service methods that check roles without taking a user ID
+func WithCaller(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, callerKey{}, userID)
+}
+
+// CallerFromContext returns the user recorded by WithCaller, or "" for anonymous requests
+func CallerFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(callerKey{}).(string)
+	return userID
+}
+
+// RoleAuthorizer implements Authorizer by checking the user's role and managed theatres - demonstrates Policy Pattern
+type RoleAuthorizer struct {
+	userRepo repositories.UserRepository
+	showRepo repositories.ShowRepository // Which theatre a booking's show is at
+}
+
+// NewAuthorizer creates an authorizer that looks callers up in userRepo and bookings' shows in showRepo
+func NewAuthorizer(userRepo repositories.UserRepository, showRepo repositories.ShowRepository) Authorizer {
+	return &RoleAuthorizer{userRepo: userRepo, showRepo: showRepo}
+}
+
+// Authorize returns the user if they hold the permission, for theatreID when the permission is theatre-scoped
+func (ra *RoleAuthorizer) Authorize(ctx context.Context, userID string, permission models.Permission, theatreID string) (*models.User, error) {
+	if userID == "" {
+		return nil, models.ErrUnauthorized
+	}
+
+	user, err := ra.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			return nil, models.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	if !user.Can(permission, theatreID) {
+		return nil, models.ErrForbidden
+	}
+	return user, nil
+}
+
+// AuthorizeCaller authorizes the user recorded by WithCaller
+func (ra *RoleAuthorizer) AuthorizeCaller(ctx context.Context, permission models.Permission, theatreID string) (*models.User, error) {
+	return ra.Authorize(ctx, CallerFromContext(ctx), permission, theatreID)
+}
+
+// AuthorizeBooking lets the caller recorded by WithCaller at a booking they own, or at any booking of a show at a
+// theatre they manage. Requests without a caller come from the platform itself, e.g. reconciliation or a show's
+// cancellation, and are let through - the API requires a caller on every booking and payment route.
+func (ra *RoleAuthorizer) AuthorizeBooking(ctx context.Context, booking *models.Booking) error {
+	callerID := CallerFromContext(ctx)
+	if callerID == "" || callerID == booking.UserID {
+		return nil
+	}
+
+	show, err := ra.showRepo.GetByID(ctx, booking.ShowID)
+	if err != nil {
+		return err
+	}
+	_, err = ra.Authorize(ctx, callerID, models.PermissionManageTheatre, show.TheatreID)
+	return err
+}
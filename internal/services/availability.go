@@ -0,0 +1,268 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultAvailabilityResync is how old a show's counters may get before they are counted again from its seat map
+const DefaultAvailabilityResync = time.Minute
+
+// SeatMapCounter computes a show's seat map from scratch, e.g. ShowService.GetSeatAvailability
+type SeatMapCounter func(ctx context.Context, showID string) (*models.SeatMap, error)
+
+// AvailabilityTracker keeps seat counters per show - demonstrates Observer Pattern: the seat events that feed the
+// live seat stream adjust the counters of every show already counted, so a summary is a map lookup. A show is
+// counted from its seat map when first asked for and again once its count is older than the resync interval,
+// which also catches changes no event announces, such as a pending booking expiring.
+type AvailabilityTracker struct {
+	resync time.Duration
+	clock  clock.Clock
+
+	mutex sync.Mutex
+	shows map[string]*showAvailability
+}
+
+// showAvailability is one show's seat statuses and the counters kept from them
+type showAvailability struct {
+	counting sync.Mutex // One count from the seat map at a time
+
+	mutex     sync.Mutex
+	seatTypes map[string]models.SeatType
+	statuses  map[string]models.SeatStatus
+	changed   map[string]models.SeatStatus // Seats changed by events while a count runs; they win over it
+	total     models.SeatCounts
+	byType    map[models.SeatType]*models.SeatCounts
+	counted   bool
+	countedAt time.Time
+}
+
+// NewAvailabilityTracker creates a tracker that counts shows again after resync, or DefaultAvailabilityResync
+func NewAvailabilityTracker(resync time.Duration, clk clock.Clock) *AvailabilityTracker {
+	if resync <= 0 {
+		resync = DefaultAvailabilityResync
+	}
+	return &AvailabilityTracker{resync: resync, clock: clk, shows: make(map[string]*showAvailability)}
+}
+
+// Summary returns the show's counters, counting its seat map with count when they are missing or stale
+func (t *AvailabilityTracker) Summary(ctx context.Context, showID string, count SeatMapCounter) (*AvailabilitySummary, error) {
+	t.mutex.Lock()
+	show, tracked := t.shows[showID]
+	if !tracked {
+		show = &showAvailability{}
+		t.shows[showID] = show
+	}
+	t.mutex.Unlock()
+
+	if summary := show.summary(showID, t.clock.Now(), t.resync); summary != nil {
+		return summary, nil
+	}
+
+	show.counting.Lock()
+	defer show.counting.Unlock()
+
+	// Another caller may have counted while this one waited
+	if summary := show.summary(showID, t.clock.Now(), t.resync); summary != nil {
+		return summary, nil
+	}
+
+	show.startCount()
+	seatMap, err := count(ctx, showID)
+	if err != nil {
+		t.mutex.Lock()
+		if !show.isCounted() {
+			delete(t.shows, showID) // Unknown shows aren't tracked
+		}
+		t.mutex.Unlock()
+		show.cancelCount()
+		return nil, err
+	}
+	show.finishCount(seatMap, t.clock.Now())
+	return show.summary(showID, t.clock.Now(), 0), nil
+}
+
+// SetSeats records seats of a show changing status; shows nobody asked about are ignored
+func (t *AvailabilityTracker) SetSeats(showID string, seatIDs []string, status models.SeatStatus) {
+	t.mutex.Lock()
+	show, tracked := t.shows[showID]
+	t.mutex.Unlock()
+	if tracked {
+		show.set(seatIDs, status)
+	}
+}
+
+// Forget drops a show's counters, e.g. once it is cancelled; the next summary counts it again
+func (t *AvailabilityTracker) Forget(showID string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.shows, showID)
+}
+
+// summary copies the counters, or returns nil when they are older than maxAge (zero accepts any age)
+func (s *showAvailability) summary(showID string, now time.Time, maxAge time.Duration) *AvailabilitySummary {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.counted || (maxAge > 0 && now.Sub(s.countedAt) >= maxAge) {
+		return nil
+	}
+
+	byType := make(map[models.SeatType]models.SeatCounts, len(s.byType))
+	for seatType, counts := range s.byType {
+		byType[seatType] = *counts
+	}
+	return &AvailabilitySummary{
+		ShowID:     showID,
+		SeatCounts: s.total,
+		Badge:      s.total.Badge(),
+		ByType:     byType,
+		CountedAt:  s.countedAt,
+	}
+}
+
+func (s *showAvailability) isCounted() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.counted
+}
+
+// startCount begins collecting the changes events report while the seat map is read
+func (s *showAvailability) startCount() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.changed = make(map[string]models.SeatStatus)
+}
+
+func (s *showAvailability) cancelCount() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.changed = nil
+}
+
+// finishCount replaces the counters with the seat map's, overlaid with the changes reported since the count began
+func (s *showAvailability) finishCount(seatMap *models.SeatMap, now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	seatTypes := make(map[string]models.SeatType)
+	statuses := make(map[string]models.SeatStatus)
+	for _, row := range seatMap.Rows {
+		for _, cell := range row.Cells {
+			if cell.IsSeat() {
+				seatTypes[cell.SeatID] = cell.Type
+				statuses[cell.SeatID] = cell.Status
+			}
+		}
+	}
+	for seatID, status := range s.changed {
+		if _, exists := statuses[seatID]; exists {
+			statuses[seatID] = status
+		}
+	}
+
+	s.seatTypes, s.statuses, s.changed = seatTypes, statuses, nil
+	s.total = models.SeatCounts{}
+	s.byType = make(map[models.SeatType]*models.SeatCounts)
+	for seatID, status := range statuses {
+		s.total.Total++
+		s.typeCounts(seatID).Total++
+		s.count(seatID, status, 1)
+	}
+	s.counted, s.countedAt = true, now
+}
+
+// set moves seats to status, adjusting the counters of the statuses they leave and join
+func (s *showAvailability) set(seatIDs []string, status models.SeatStatus) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, seatID := range seatIDs {
+		if s.changed != nil {
+			s.changed[seatID] = status
+		}
+		previous, exists := s.statuses[seatID]
+		if !s.counted || !exists || previous == status {
+			continue
+		}
+		s.count(seatID, previous, -1)
+		s.count(seatID, status, 1)
+		s.statuses[seatID] = status
+	}
+}
+
+// count adds delta seats of the status to the total and the seat's type. Callers must hold mutex.
+func (s *showAvailability) count(seatID string, status models.SeatStatus, delta int) {
+	s.total.Add(status, delta)
+	s.typeCounts(seatID).Add(status, delta)
+}
+
+// typeCounts returns the counters of the seat's type. Callers must hold mutex.
+func (s *showAvailability) typeCounts(seatID string) *models.SeatCounts {
+	seatType := s.seatTypes[seatID]
+	counts, exists := s.byType[seatType]
+	if !exists {
+		counts = &models.SeatCounts{}
+		s.byType[seatType] = counts
+	}
+	return counts
+}
+
+// RegisterAvailabilitySubscriber feeds seat events to the tracker, the way the live seat stream is fed
+func RegisterAvailabilitySubscriber(bus events.EventBus, tracker *AvailabilityTracker) {
+	bus.Subscribe(events.EventSeatHoldCreated, func(ctx context.Context, event events.Event) error {
+		e := event.(events.SeatHoldCreated)
+		tracker.SetSeats(e.ShowID, e.SeatIDs, models.SeatStatusBlocked)
+		return nil
+	})
+
+	bus.Subscribe(events.EventSeatHoldReleased, func(ctx context.Context, event events.Event) error {
+		e := event.(events.SeatHoldReleased)
+		tracker.SetSeats(e.ShowID, e.SeatIDs, models.SeatStatusAvailable)
+		return nil
+	})
+
+	bus.Subscribe(events.EventBookingCreated, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingCreated)
+		tracker.SetSeats(e.ShowID, e.SeatIDs, models.SeatStatusBlocked)
+		return nil
+	})
+
+	bus.Subscribe(events.EventBookingConfirmed, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingConfirmed)
+		tracker.SetSeats(e.ShowID, e.SeatIDs, models.SeatStatusBooked)
+		return nil
+	})
+
+	bus.Subscribe(events.EventBookingCancelled, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingCancelled)
+		tracker.SetSeats(e.ShowID, e.SeatIDs, models.SeatStatusAvailable)
+		return nil
+	})
+
+	bus.Subscribe(events.EventBookingModified, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingModified)
+		status := models.SeatStatusBlocked
+		if e.Status == models.BookingStatusConfirmed {
+			status = models.SeatStatusBooked
+		}
+		tracker.SetSeats(e.ShowID, missingSeats(e.OldSeatIDs, e.NewSeatIDs), models.SeatStatusAvailable)
+		tracker.SetSeats(e.ShowID, missingSeats(e.NewSeatIDs, e.OldSeatIDs), status)
+		return nil
+	})
+
+	bus.Subscribe(events.EventHouseSeatsChanged, func(ctx context.Context, event events.Event) error {
+		e := event.(events.HouseSeatsChanged)
+		tracker.SetSeats(e.ShowID, e.SeatIDs, e.Status)
+		return nil
+	})
+
+	bus.Subscribe(events.EventShowCancelled, func(ctx context.Context, event events.Event) error {
+		tracker.Forget(event.(events.ShowCancelled).ShowID)
+		return nil
+	})
+}
@@ -1,183 +1,722 @@
 package services
 
 import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/logging"
 	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/pricing"
 	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 )
 
 // UserServiceImpl implements UserService - demonstrates Repository Pattern
 type UserServiceImpl struct {
 	userRepo repositories.UserRepository
+	clock    clock.Clock
 }
 
-func NewUserService(userRepo repositories.UserRepository) UserService {
+func NewUserService(userRepo repositories.UserRepository, clock clock.Clock) UserService {
 	return &UserServiceImpl{
 		userRepo: userRepo,
+		clock:    clock,
 	}
 }
 
-func (us *UserServiceImpl) CreateUser(name, email, phoneNumber string) (*models.User, error) {
-	user, err := models.NewUser(name, email, phoneNumber)
+func (us *UserServiceImpl) CreateUser(ctx context.Context, name, email, phoneNumber string) (*models.User, error) {
+	return us.CreateUserWithRole(ctx, name, email, phoneNumber, models.UserRoleCustomer)
+}
+
+func (us *UserServiceImpl) CreateUserWithRole(ctx context.Context, name, email, phoneNumber string, role models.UserRole) (*models.User, error) {
+	user, err := models.NewUser(name, email, phoneNumber, us.clock.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	if err := us.userRepo.Create(user); err != nil {
+	if err := user.SetRole(role, us.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := us.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
 	return user, nil
 }
 
-func (us *UserServiceImpl) GetUser(id string) (*models.User, error) {
-	return us.userRepo.GetByID(id)
+func (us *UserServiceImpl) GetUser(ctx context.Context, id string) (*models.User, error) {
+	return us.userRepo.GetByID(ctx, id)
+}
+
+func (us *UserServiceImpl) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	return us.userRepo.GetByEmail(ctx, email)
+}
+
+// SetDateOfBirth records when the user was born, which age-rated shows check at booking
+func (us *UserServiceImpl) SetDateOfBirth(ctx context.Context, userID string, dateOfBirth time.Time) (*models.User, error) {
+	user, err := us.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.SetDateOfBirth(dateOfBirth, us.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := us.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// SubmitDiscountProfile applies for a student, senior citizen or military discount; an admin verifies the
+// proof before bookings get it
+func (us *UserServiceImpl) SubmitDiscountProfile(ctx context.Context, userID string, category models.DiscountCategory, documentID string, validUntil *time.Time) (*models.User, error) {
+	user, err := us.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.SubmitDiscountProfile(category, documentID, validUntil, us.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := us.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// SetWhatsAppOptIn records the user opting in to or out of WhatsApp messages; WhatsApp sends nothing until
+// they opt in
+func (us *UserServiceImpl) SetWhatsAppOptIn(ctx context.Context, userID string, optedIn bool) (*models.User, error) {
+	user, err := us.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.SetWhatsAppOptIn(optedIn, us.clock.Now())
+
+	if err := us.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
 // MovieServiceImpl implements MovieService - demonstrates Repository Pattern
 type MovieServiceImpl struct {
-	movieRepo repositories.MovieRepository
+	movieRepo  repositories.MovieRepository
+	authorizer Authorizer
+	listings   *ListingsView // Trending is read from it
+	clock      clock.Clock
 }
 
-func NewMovieService(movieRepo repositories.MovieRepository) MovieService {
+func NewMovieService(movieRepo repositories.MovieRepository, authorizer Authorizer, listings *ListingsView, clock clock.Clock) MovieService {
 	return &MovieServiceImpl{
-		movieRepo: movieRepo,
+		movieRepo:  movieRepo,
+		authorizer: authorizer,
+		listings:   listings,
+		clock:      clock,
 	}
 }
 
-func (ms *MovieServiceImpl) CreateMovie(title, description string, duration time.Duration, genre models.Genre, language models.Language, rating float32, releaseDate time.Time) (*models.Movie, error) {
-	movie, err := models.NewMovie(title, description, duration, genre, language, rating, releaseDate)
+// CreateMovie adds a movie to the catalog - super admins only
+func (ms *MovieServiceImpl) CreateMovie(ctx context.Context, title, description string, duration time.Duration, genre models.Genre, language models.Language, rating float32, releaseDate time.Time, opts ...MovieOption) (*models.Movie, error) {
+	if _, err := ms.authorizer.AuthorizeCaller(ctx, models.PermissionManageCatalog, ""); err != nil {
+		return nil, err
+	}
+
+	var options MovieOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if !options.Certificate.Valid() {
+		return nil, models.ErrInvalidCertificate
+	}
+
+	movie, err := models.NewMovie(title, description, duration, genre, language, rating, releaseDate, ms.clock.Now())
 	if err != nil {
 		return nil, err
 	}
+	movie.Certificate = options.Certificate
+
+	if err := ms.movieRepo.Create(ctx, movie); err != nil {
+		return nil, err
+	}
+
+	return movie, nil
+}
+
+// SetSaleWindow changes when bookings open for the movie's shows created afterwards, overriding the theatres'
+// windows; the zero window defers to them again. Super admins only.
+func (ms *MovieServiceImpl) SetSaleWindow(ctx context.Context, movieID string, window models.SaleWindow) (*models.Movie, error) {
+	if _, err := ms.authorizer.AuthorizeCaller(ctx, models.PermissionManageCatalog, ""); err != nil {
+		return nil, err
+	}
 
-	if err := ms.movieRepo.Create(movie); err != nil {
+	movie, err := ms.movieRepo.GetByID(ctx, movieID)
+	if err != nil {
 		return nil, err
 	}
 
+	movie.SetSaleWindow(window, ms.clock.Now())
+	if err := ms.movieRepo.Update(ctx, movie); err != nil {
+		return nil, err
+	}
 	return movie, nil
 }
 
-func (ms *MovieServiceImpl) GetMovie(id string) (*models.Movie, error) {
-	return ms.movieRepo.GetByID(id)
+func (ms *MovieServiceImpl) GetMovie(ctx context.Context, id string) (*models.Movie, error) {
+	return ms.movieRepo.GetByID(ctx, id)
 }
 
-func (ms *MovieServiceImpl) GetReleasedMovies() ([]*models.Movie, error) {
-	return ms.movieRepo.GetReleased()
+func (ms *MovieServiceImpl) GetReleasedMovies(ctx context.Context) ([]*models.Movie, error) {
+	return ms.movieRepo.GetReleased(ctx, ms.clock.Now())
 }
 
+// GetTrending ranks the city's movies by seats confirmed within the trending window, from the listings view
+func (ms *MovieServiceImpl) GetTrending(ctx context.Context, city string) ([]*TrendingMovie, error) {
+	return ms.listings.Trending(ctx, city)
+}
+
+// EventServiceImpl implements EventService - demonstrates Repository Pattern
+type EventServiceImpl struct {
+	eventRepo  repositories.EventRepository
+	authorizer Authorizer
+	clock      clock.Clock
+}
+
+func NewEventService(eventRepo repositories.EventRepository, authorizer Authorizer, clock clock.Clock) EventService {
+	return &EventServiceImpl{
+		eventRepo:  eventRepo,
+		authorizer: authorizer,
+		clock:      clock,
+	}
+}
+
+// CreateEvent adds a live event to the catalog - super admins only
+func (es *EventServiceImpl) CreateEvent(ctx context.Context, title, description string, eventType models.EventType, duration time.Duration, language models.Language, performers []string) (*models.Event, error) {
+	if _, err := es.authorizer.AuthorizeCaller(ctx, models.PermissionManageCatalog, ""); err != nil {
+		return nil, err
+	}
+
+	event, err := models.NewEvent(title, description, eventType, duration, language, performers, es.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := es.eventRepo.Create(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (es *EventServiceImpl) GetEvent(ctx context.Context, id string) (*models.Event, error) {
+	return es.eventRepo.GetByID(ctx, id)
+}
+
+func (es *EventServiceImpl) GetEventsByType(ctx context.Context, eventType models.EventType) ([]*models.Event, error) {
+	if eventType != "" && !eventType.IsLive() {
+		return nil, models.ErrInvalidEventData
+	}
+	return es.eventRepo.GetByType(ctx, eventType)
+}
+
+// maxSearchRadiusKm bounds nearby theatre searches to roughly a metro area
+const maxSearchRadiusKm = 100
+
 // TheatreServiceImpl implements TheatreService - demonstrates Repository Pattern + Business Logic
 type TheatreServiceImpl struct {
 	theatreRepo repositories.TheatreRepository
 	screenRepo  repositories.ScreenRepository
+	cityRepo    repositories.CityRepository
+	authorizer  Authorizer
+	clock       clock.Clock
 }
 
-func NewTheatreService(theatreRepo repositories.TheatreRepository, screenRepo repositories.ScreenRepository) TheatreService {
+func NewTheatreService(theatreRepo repositories.TheatreRepository, screenRepo repositories.ScreenRepository, cityRepo repositories.CityRepository, authorizer Authorizer, clock clock.Clock) TheatreService {
 	return &TheatreServiceImpl{
 		theatreRepo: theatreRepo,
 		screenRepo:  screenRepo,
+		cityRepo:    cityRepo,
+		authorizer:  authorizer,
+		clock:       clock,
 	}
 }
 
-func (ts *TheatreServiceImpl) CreateTheatre(name, address, city string) (*models.Theatre, error) {
-	theatre, err := models.NewTheatre(name, address, city)
+// CreateTheatre onboards a partner theatre - super admins only
+func (ts *TheatreServiceImpl) CreateTheatre(ctx context.Context, name, address, city string, opts ...TheatreOption) (*models.Theatre, error) {
+	if _, err := ts.authorizer.AuthorizeCaller(ctx, models.PermissionOnboardTheatre, ""); err != nil {
+		return nil, err
+	}
+
+	var options TheatreOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	theatre, err := models.NewTheatre(name, address, city, ts.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if options.Location != nil {
+		location, err := models.NewGeoPoint(options.Location.Latitude, options.Location.Longitude)
+		if err != nil {
+			return nil, err
+		}
+		theatre.SetLocation(location, ts.clock.Now())
+	}
+
+	// Theatres use the catalog's spelling so city lookups group them together
+	catalogCity, err := ts.ensureCity(ctx, city)
 	if err != nil {
 		return nil, err
 	}
+	theatre.City = catalogCity.Name
 
-	if err := ts.theatreRepo.Create(theatre); err != nil {
+	if err := ts.theatreRepo.Create(ctx, theatre); err != nil {
 		return nil, err
 	}
 
 	return theatre, nil
 }
 
-func (ts *TheatreServiceImpl) GetTheatre(id string) (*models.Theatre, error) {
-	return ts.theatreRepo.GetByID(id)
+func (ts *TheatreServiceImpl) GetTheatre(ctx context.Context, id string) (*models.Theatre, error) {
+	return ts.theatreRepo.GetByID(ctx, id)
 }
 
-func (ts *TheatreServiceImpl) AddScreen(theatreID string, screen *models.Screen) error {
-	theatre, err := ts.theatreRepo.GetByID(theatreID)
+// AddScreen adds a screen to a theatre - its theatre admins and super admins only
+func (ts *TheatreServiceImpl) AddScreen(ctx context.Context, theatreID string, screen *models.Screen) error {
+	if _, err := ts.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, theatreID); err != nil {
+		return err
+	}
+
+	theatre, err := ts.theatreRepo.GetByID(ctx, theatreID)
 	if err != nil {
 		return err
 	}
 
-	theatre.AddScreen(screen)
+	theatre.AddScreen(screen, ts.clock.Now())
 
-	if err := ts.screenRepo.Create(screen); err != nil {
+	if err := ts.screenRepo.Create(ctx, screen); err != nil {
 		return err
 	}
 
-	return ts.theatreRepo.Update(theatre)
+	return ts.theatreRepo.Update(ctx, theatre)
+}
+
+// CloneScreen copies a screen's seat layout and pricing into a new screen of theatreID - its theatre admins and
+// super admins only. Layouts are public, so the source may belong to any theatre, e.g. another of the chain's.
+func (ts *TheatreServiceImpl) CloneScreen(ctx context.Context, theatreID, sourceScreenID, newName string) (*models.Screen, error) {
+	if newName == "" {
+		return nil, models.ErrInvalidTheatreData
+	}
+
+	source, err := ts.screenRepo.GetByID(ctx, sourceScreenID)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := source.Clone(newName)
+	if err := ts.AddScreen(ctx, theatreID, clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// AddCity adds a city to the catalog - super admins only
+func (ts *TheatreServiceImpl) AddCity(ctx context.Context, name, region string) (*models.City, error) {
+	if _, err := ts.authorizer.AuthorizeCaller(ctx, models.PermissionManageCatalog, ""); err != nil {
+		return nil, err
+	}
+	return ts.addCity(ctx, name, region)
+}
+
+// addCity stores a new city; onboarding a theatre in a new city is allowed to add it
+func (ts *TheatreServiceImpl) addCity(ctx context.Context, name, region string) (*models.City, error) {
+	city, err := models.NewCity(name, region, ts.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.cityRepo.Create(ctx, city); err != nil {
+		return nil, err
+	}
+
+	return city, nil
+}
+
+func (ts *TheatreServiceImpl) GetCities(ctx context.Context) ([]*models.City, error) {
+	return ts.cityRepo.GetAll(ctx)
+}
+
+func (ts *TheatreServiceImpl) GetTheatresByCity(ctx context.Context, cityID string) ([]*models.Theatre, error) {
+	city, err := ts.cityRepo.GetByID(ctx, cityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ts.theatreRepo.GetByCity(ctx, city.Name)
+}
+
+// GetTheatresNearLocation lists theatres with coordinates within radiusKm of the point, nearest first
+func (ts *TheatreServiceImpl) GetTheatresNearLocation(ctx context.Context, lat, lng, radiusKm float64) ([]*NearbyTheatre, error) {
+	origin, err := models.NewGeoPoint(lat, lng)
+	if err != nil {
+		return nil, err
+	}
+	if !(radiusKm > 0 && radiusKm <= maxSearchRadiusKm) {
+		return nil, fmt.Errorf("%w: radius must be between 0 and %d km", models.ErrInvalidLocation, maxSearchRadiusKm)
+	}
+
+	theatres, err := ts.theatreRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var nearby []*NearbyTheatre
+	for _, theatre := range theatres {
+		location, ok := theatre.GetLocation()
+		if !ok {
+			continue
+		}
+		if distance := origin.DistanceKm(location); distance <= radiusKm {
+			nearby = append(nearby, &NearbyTheatre{Theatre: theatre, DistanceKm: math.Round(distance*100) / 100})
+		}
+	}
+
+	sort.Slice(nearby, func(i, j int) bool { return nearby[i].DistanceKm < nearby[j].DistanceKm })
+	return nearby, nil
+}
+
+// ensureCity returns the catalog entry for name, adding it when a theatre opens in a new city
+func (ts *TheatreServiceImpl) ensureCity(ctx context.Context, name string) (*models.City, error) {
+	city, err := ts.cityRepo.GetByName(ctx, name)
+	if !errors.Is(err, models.ErrCityNotFound) {
+		return city, err
+	}
+
+	city, err = ts.addCity(ctx, name, "")
+	if errors.Is(err, models.ErrCityAlreadyExists) {
+		// Another theatre in the same new city won the race
+		return ts.cityRepo.GetByName(ctx, name)
+	}
+	return city, err
 }
 
 // ShowServiceImpl implements ShowService - demonstrates business rules and validation
 type ShowServiceImpl struct {
 	showRepo    repositories.ShowRepository
 	movieRepo   repositories.MovieRepository
+	eventRepo   repositories.EventRepository
 	theatreRepo repositories.TheatreRepository
 	screenRepo  repositories.ScreenRepository
+	bookingRepo repositories.BookingRepository
+
+	// Cancellation cascade
+	bookingService      BookingService
+	holdService         SeatHoldService
+	paymentService      PaymentService
+	notificationService NotificationService
+	surcharges          models.FormatSurcharges // Per-seat surcharge by format, fixed on each show when created
+	scheduling          models.SchedulingConfig // Turnaround and opening hours SuggestSlots works within
+	listings            *ListingsView           // Now-showing is read from it
+	availability        *AvailabilityTracker    // Seat counters behind GetAvailabilitySummary
+	pricer              pricing.Pricer          // Prices the seat map the way bookings are charged
+	authorizer          Authorizer              // Scheduling, cancelling and rescheduling are limited to the theatre's admins
+	audit               AuditRecorder           // Cancellations, reschedules and held back seats
+	lockManager         locks.LockManager       // Held back seats change under the show's booking and hold locks
+	eventBus            events.EventBus
+	logger              logging.Logger
+	clock               clock.Clock
 }
 
-func NewShowService(showRepo repositories.ShowRepository, movieRepo repositories.MovieRepository, theatreRepo repositories.TheatreRepository, screenRepo repositories.ScreenRepository) ShowService {
+func NewShowService(
+	showRepo repositories.ShowRepository,
+	movieRepo repositories.MovieRepository,
+	eventRepo repositories.EventRepository,
+	theatreRepo repositories.TheatreRepository,
+	screenRepo repositories.ScreenRepository,
+	bookingRepo repositories.BookingRepository,
+	bookingService BookingService,
+	holdService SeatHoldService,
+	paymentService PaymentService,
+	notificationService NotificationService,
+	surcharges models.FormatSurcharges,
+	scheduling models.SchedulingConfig,
+	listings *ListingsView,
+	availability *AvailabilityTracker,
+	pricer pricing.Pricer,
+	authorizer Authorizer,
+	audit AuditRecorder,
+	lockManager locks.LockManager,
+	eventBus events.EventBus,
+	logger logging.Logger,
+	clock clock.Clock,
+) ShowService {
+	if pricer == nil {
+		pricer = pricing.Base{}
+	}
+	if audit == nil {
+		audit = NopAuditRecorder()
+	}
+	if availability == nil {
+		availability = NewAvailabilityTracker(0, clock)
+	}
+	defaults := models.DefaultSchedulingConfig()
+	if scheduling.SlotInterval <= 0 {
+		scheduling.SlotInterval = defaults.SlotInterval
+	}
+	if scheduling.DayEnd <= scheduling.DayStart {
+		scheduling.DayStart, scheduling.DayEnd = defaults.DayStart, defaults.DayEnd
+	}
+	if scheduling.Turnaround < 0 {
+		scheduling.Turnaround = 0
+	}
 	return &ShowServiceImpl{
-		showRepo:    showRepo,
-		movieRepo:   movieRepo,
-		theatreRepo: theatreRepo,
-		screenRepo:  screenRepo,
+		showRepo:            showRepo,
+		movieRepo:           movieRepo,
+		eventRepo:           eventRepo,
+		theatreRepo:         theatreRepo,
+		screenRepo:          screenRepo,
+		bookingRepo:         bookingRepo,
+		bookingService:      bookingService,
+		holdService:         holdService,
+		paymentService:      paymentService,
+		notificationService: notificationService,
+		surcharges:          surcharges,
+		scheduling:          scheduling,
+		listings:            listings,
+		availability:        availability,
+		pricer:              pricer,
+		authorizer:          authorizer,
+		audit:               audit,
+		lockManager:         lockManager,
+		eventBus:            eventBus,
+		logger:              logger,
+		clock:               clock,
 	}
 }
 
-func (ss *ShowServiceImpl) CreateShow(movieID, theatreID, screenID string, startTime time.Time, basePrice float64) (*models.Show, error) {
+// CreateShow schedules a movie on a screen - the theatre's admins and super admins only
+func (ss *ShowServiceImpl) CreateShow(ctx context.Context, movieID, theatreID, screenID string, startTime time.Time, basePrice models.Money, opts ...ShowOption) (*models.Show, error) {
+	if _, err := ss.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, theatreID); err != nil {
+		return nil, err
+	}
+
+	options := ShowOptions{Format: models.ShowFormat2D}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// Validate movie exists
-	movie, err := ss.movieRepo.GetByID(movieID)
+	movie, err := ss.movieRepo.GetByID(ctx, movieID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Validate theatre exists
-	if _, err := ss.theatreRepo.GetByID(theatreID); err != nil {
+	show, err := models.NewShow(movieID, theatreID, screenID, startTime, basePrice, movie.Duration, ss.clock.Now())
+	if err != nil {
 		return nil, err
 	}
 
-	// Validate screen exists and belongs to theatre
-	screen, err := ss.screenRepo.GetByID(screenID)
+	// Dubbed screenings override the movie's language; the surcharge is fixed now so later rule changes don't reprice
+	if options.Language == "" {
+		options.Language = movie.Language
+	}
+	surcharge := ss.surcharges.For(options.Format, basePrice.Currency)
+	if err := show.SetFormat(options.Format, options.Language, surcharge, ss.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := show.SetBookingTimeout(options.BookingTimeout, ss.clock.Now()); err != nil {
+		return nil, err
+	}
+	show.SaleWindow = movie.SaleWindow
+
+	if err := ss.schedule(ctx, show); err != nil {
+		return nil, err
+	}
+	return show, nil
+}
+
+// CreateEventShow schedules a concert, play or stand-up set; it runs for the event's duration
+func (ss *ShowServiceImpl) CreateEventShow(ctx context.Context, eventID, theatreID, screenID string, startTime time.Time, basePrice models.Money) (*models.Show, error) {
+	if _, err := ss.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, theatreID); err != nil {
+		return nil, err
+	}
+
+	// Validate event exists
+	event, err := ss.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
 
-	if screen.TheatreID != theatreID {
-		return nil, models.ErrInvalidShowData
+	show, err := models.NewEventShow(event, theatreID, screenID, startTime, basePrice, ss.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ss.schedule(ctx, show); err != nil {
+		return nil, err
+	}
+	return show, nil
+}
+
+// schedule checks the show's theatre and screen and stores it if the screen is free - shared by movies and live events.
+// Shows without their own booking timeout or sale window take the theatre's.
+func (ss *ShowServiceImpl) schedule(ctx context.Context, show *models.Show) error {
+	// Validate theatre exists
+	theatre, err := ss.theatreRepo.GetByID(ctx, show.TheatreID)
+	if err != nil {
+		return err
+	}
+
+	if show.BookingTimeout == 0 {
+		show.BookingTimeout = theatre.GetBookingTimeout()
+	}
+	if show.SaleWindow.IsZero() {
+		show.SaleWindow = theatre.GetSaleWindow()
+	}
+
+	// Validate screen exists and belongs to theatre
+	screen, err := ss.screenRepo.GetByID(ctx, show.ScreenID)
+	if err != nil {
+		return err
+	}
+
+	if screen.TheatreID != show.TheatreID {
+		return models.ErrInvalidShowData
+	}
+
+	if !screen.IsOperational() {
+		return models.ErrScreenUnderMaintenance
 	}
 
 	// Check for scheduling conflicts - demonstrates business rules
-	endTime := startTime.Add(movie.Duration)
-	hasConflict, err := ss.showRepo.CheckConflict(screenID, startTime, endTime)
+	hasConflict, err := ss.showRepo.CheckConflict(ctx, show.ScreenID, show.StartTime, show.EndTime, "")
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if hasConflict {
-		return nil, models.ErrInvalidShowTime
+		return models.ErrInvalidShowTime
+	}
+
+	return ss.showRepo.Create(ctx, show)
+}
+
+func (ss *ShowServiceImpl) GetShow(ctx context.Context, id string) (*models.Show, error) {
+	return ss.showRepo.GetByID(ctx, id)
+}
+
+// GetNowShowing lists the movies bookable in the city on date's day, from the listings view
+func (ss *ShowServiceImpl) GetNowShowing(ctx context.Context, city string, date time.Time) ([]*NowShowingMovie, error) {
+	return ss.listings.NowShowing(ctx, city, date)
+}
+
+// SearchShows lists a movie's bookable shows in the requested format and language
+func (ss *ShowServiceImpl) SearchShows(ctx context.Context, filter ShowFilter) ([]*models.Show, error) {
+	if filter.MovieID == "" || (filter.Format != "" && !filter.Format.IsValid()) {
+		return nil, models.ErrInvalidShowData
 	}
 
-	// Create show
-	show, err := models.NewShow(movieID, theatreID, screenID, startTime, basePrice, movie.Duration)
+	shows, err := ss.showRepo.GetByMovieID(ctx, filter.MovieID)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := ss.showRepo.Create(show); err != nil {
-		return nil, err
+	var matches []*models.Show
+	for _, show := range shows {
+		if !show.CanBeBooked(ss.clock.Now()) {
+			continue
+		}
+		if filter.Format != "" && show.Format != filter.Format {
+			continue
+		}
+		if !filter.matchesLanguage(show.Language) {
+			continue
+		}
+		if filter.City != "" {
+			inCity, err := ss.inCity(ctx, show.TheatreID, filter.City)
+			if err != nil {
+				return nil, err
+			}
+			if !inCity {
+				continue
+			}
+		}
+		matches = append(matches, show)
 	}
 
-	return show, nil
+	sort.Slice(matches, func(i, j int) bool {
+		iFavorite := slices.Contains(filter.FavoriteTheatreIDs, matches[i].TheatreID)
+		jFavorite := slices.Contains(filter.FavoriteTheatreIDs, matches[j].TheatreID)
+		if iFavorite != jFavorite {
+			return iFavorite
+		}
+		return matches[i].StartTime.Before(matches[j].StartTime)
+	})
+	return matches, nil
 }
 
-func (ss *ShowServiceImpl) GetShow(id string) (*models.Show, error) {
-	return ss.showRepo.GetByID(id)
+// matchesLanguage applies Language, or failing that Languages, case-insensitively
+func (f ShowFilter) matchesLanguage(language models.Language) bool {
+	if f.Language != "" {
+		return strings.EqualFold(string(language), string(f.Language))
+	}
+	if len(f.Languages) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(f.Languages, func(wanted models.Language) bool {
+		return strings.EqualFold(string(language), string(wanted))
+	})
+}
+
+// inCity reports whether the theatre is in the named city
+func (ss *ShowServiceImpl) inCity(ctx context.Context, theatreID, city string) (bool, error) {
+	theatre, err := ss.theatreRepo.GetByID(ctx, theatreID)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(strings.TrimSpace(theatre.City), strings.TrimSpace(city)), nil
+}
+
+func (ss *ShowServiceImpl) GetShowsByMovie(ctx context.Context, movieID string) ([]*models.Show, error) {
+	return ss.showRepo.GetByMovieID(ctx, movieID)
+}
+
+func (ss *ShowServiceImpl) GetShowsByEvent(ctx context.Context, eventID string) ([]*models.Show, error) {
+	return ss.showRepo.GetByEventID(ctx, eventID)
+}
+
+// GetSeatAvailability lays out the show's screen row by row, with each seat's status for this show only:
+// seats booked for it are BOOKED, seats its holds and pending bookings have are BLOCKED
+func (ss *ShowServiceImpl) GetSeatAvailability(ctx context.Context, showID string) (*models.SeatMap, error) {
+	show, err := ss.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	screen, err := ss.screenRepo.GetByID(ctx, show.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+
+	seatMap := show.SeatMap(screen)
+	pricing.PriceSeatMap(ss.pricer, show, screen, seatMap)
+	return seatMap, nil
 }
 
-func (ss *ShowServiceImpl) GetShowsByMovie(movieID string) ([]*models.Show, error) {
-	return ss.showRepo.GetByMovieID(movieID)
+// GetAvailabilitySummary returns the show's seat counters - cheap enough for a badge on every listed show
+func (ss *ShowServiceImpl) GetAvailabilitySummary(ctx context.Context, showID string) (*AvailabilitySummary, error) {
+	return ss.availability.Summary(ctx, showID, ss.GetSeatAvailability)
 }
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/models"
+	"context"
+	"errors"
+	"time"
+)
+
+// ExtendHold gives the owner of a pending booking more time to pay, e.g. when their bank's OTP is slow. The
+// booking's seats stay blocked until the extended window closes. Each booking is extended at most once, and each
+// user only so many times a day; refusals for the daily limit are published so abuse can be watched.
+func (bs *BookingServiceImpl) ExtendHold(ctx context.Context, bookingID, userID string) (*models.Booking, error) {
+	booking, err := bs.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := bs.lockShow(ctx, booking.ShowID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Checked under the lock, as a transfer or resale changes the owner
+	if booking.UserID != userID {
+		return nil, models.ErrForbidden
+	}
+	if booking.GetStatus() != models.BookingStatusPending {
+		return nil, models.ErrBookingNotPending
+	}
+	if !booking.GetHoldExtendedAt().IsZero() {
+		return nil, models.ErrHoldAlreadyExtended
+	}
+
+	if bs.rules == nil {
+		return nil, models.ErrHoldExtensionUnavailable
+	}
+	extension, err := bs.rules.CheckHoldExtension(ctx, booking)
+	if err != nil {
+		if errors.Is(err, models.ErrHoldExtensionLimit) {
+			bs.publish(ctx, events.HoldExtensionRefused{
+				BookingID: booking.ID,
+				UserID:    booking.UserID,
+				ShowID:    booking.ShowID,
+				Reason:    err.Error(),
+				Timestamp: bs.clock.Now(),
+			})
+		}
+		return nil, err
+	}
+
+	if err := booking.ExtendHold(extension, bs.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := bs.bookingRepo.Update(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	expiresAt := booking.GetExpiryTime()
+	bs.recordChange(ctx, booking, models.AuditBookingHoldExtended, map[string]string{
+		"expires_at": expiresAt.Format(time.RFC3339),
+	})
+	bs.publish(ctx, events.BookingHoldExtended{
+		BookingID: booking.ID,
+		UserID:    booking.UserID,
+		ShowID:    booking.ShowID,
+		ExpiresAt: expiresAt,
+		Timestamp: bs.clock.Now(),
+	})
+	return booking, nil
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/ratelimit"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+)
+
+// openingRateLimitScope names the limit in the *models.RateLimitError of a refused booking
+const openingRateLimitScope = "bookings while sales open"
+
+// RateLimitedBookingService throttles CreateBooking per user and show while the show's sales have just opened, when
+// demand spikes and scripts hammer the seat map - demonstrates Decorator Pattern. The check runs before the
+// show lock is taken, so refused attempts never queue behind real bookings. Corporate blocks aren't limited.
+type RateLimitedBookingService struct {
+	BookingService
+	showRepo repositories.ShowRepository
+	limits   models.RateLimitConfig
+	limiter  *ratelimit.Limiter
+	clock    clock.Clock
+}
+
+// NewRateLimitedBookingService wraps bookingService; it is returned as is when limits.Opening is disabled
+func NewRateLimitedBookingService(bookingService BookingService, showRepo repositories.ShowRepository, limits models.RateLimitConfig, clock clock.Clock) BookingService {
+	if !limits.Opening.Enabled() || limits.OpeningWindow <= 0 {
+		return bookingService
+	}
+	return &RateLimitedBookingService{
+		BookingService: bookingService,
+		showRepo:       showRepo,
+		limits:         limits,
+		limiter:        ratelimit.New(limits.Opening, clock),
+		clock:          clock,
+	}
+}
+
+// CreateBooking refuses users over the opening limit for the show with a *models.RateLimitError, then books as usual
+func (s *RateLimitedBookingService) CreateBooking(ctx context.Context, userID, showID string, seatIDs []string, opts ...BookingOption) (*models.Booking, error) {
+	var options BookingOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.BulkBookingID == "" {
+		show, err := s.showRepo.GetByID(ctx, showID)
+		if err != nil {
+			return nil, err
+		}
+		if s.limits.InOpening(show, s.clock.Now()) {
+			if retryAfter, ok := s.limiter.Allow(userID + "/" + showID); !ok {
+				return nil, &models.RateLimitError{Scope: openingRateLimitScope, RetryAfter: retryAfter}
+			}
+		}
+	}
+	return s.BookingService.CreateBooking(ctx, userID, showID, seatIDs, opts...)
+}
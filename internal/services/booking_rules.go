@@ -0,0 +1,136 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"fmt"
+	"time"
+)
+
+// holdExtensionPeriod is the rolling period MaxHoldExtensionsPerDay counts over
+const holdExtensionPeriod = 24 * time.Hour
+
+// LimitBookingRules implements BookingRules with fixed per-booking, per-user and per-show limits
+type LimitBookingRules struct {
+	bookingRepo repositories.BookingRepository
+	limits      models.BookingLimits
+	clock       clock.Clock
+}
+
+// NewBookingRules creates anti-hoarding rules backed by the users' existing bookings
+func NewBookingRules(bookingRepo repositories.BookingRepository, limits models.BookingLimits, clock clock.Clock) BookingRules {
+	return &LimitBookingRules{
+		bookingRepo: bookingRepo,
+		limits:      limits,
+		clock:       clock,
+	}
+}
+
+// CheckNewBooking enforces every limit for a user booking seats at a show
+func (r *LimitBookingRules) CheckNewBooking(ctx context.Context, userID string, show *models.Show, seats int) error {
+	if err := r.checkSeatsPerBooking(seats); err != nil {
+		return err
+	}
+	if err := r.checkPendingBookings(ctx, userID); err != nil {
+		return err
+	}
+	return r.checkTicketsPerShow(ctx, userID, show.ID, "", seats)
+}
+
+// CheckSeatChange enforces the seat limits for moving a booking to a new set of seats
+func (r *LimitBookingRules) CheckSeatChange(ctx context.Context, booking *models.Booking, seats int) error {
+	if err := r.checkSeatsPerBooking(seats); err != nil {
+		return err
+	}
+	return r.checkTicketsPerShow(ctx, booking.UserID, booking.ShowID, booking.ID, seats)
+}
+
+// CheckHoldExtension allows extending the booking's payment window unless extensions are off or its owner has
+// used up their extensions for the day
+func (r *LimitBookingRules) CheckHoldExtension(ctx context.Context, booking *models.Booking) (time.Duration, error) {
+	if r.limits.HoldExtension <= 0 {
+		return 0, models.ErrHoldExtensionUnavailable
+	}
+	limit := r.limits.MaxHoldExtensionsPerDay
+	if limit <= 0 {
+		return r.limits.HoldExtension, nil
+	}
+
+	bookings, _, err := r.bookingRepo.GetByUserID(ctx, booking.UserID, repositories.Page{})
+	if err != nil {
+		return 0, err
+	}
+
+	since := r.clock.Now().Add(-holdExtensionPeriod)
+	extended := 0
+	for _, other := range bookings {
+		if at := other.GetHoldExtendedAt(); !at.IsZero() && at.After(since) {
+			extended++
+		}
+	}
+	if extended >= limit {
+		return 0, fmt.Errorf("%w: at most %d", models.ErrHoldExtensionLimit, limit)
+	}
+	return r.limits.HoldExtension, nil
+}
+
+func (r *LimitBookingRules) checkSeatsPerBooking(seats int) error {
+	if limit := r.limits.MaxSeatsPerBooking; limit > 0 && seats > limit {
+		return fmt.Errorf("%w: at most %d", models.ErrTooManySeatsPerBooking, limit)
+	}
+	return nil
+}
+
+// checkPendingBookings counts the user's unpaid bookings that haven't lapsed yet
+func (r *LimitBookingRules) checkPendingBookings(ctx context.Context, userID string) error {
+	limit := r.limits.MaxPendingBookingsPerUser
+	if limit <= 0 {
+		return nil
+	}
+
+	bookings, _, err := r.bookingRepo.GetByUserID(ctx, userID, repositories.Page{})
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, booking := range bookings {
+		if booking.GetStatus() == models.BookingStatusPending && !booking.IsExpired(r.clock.Now()) {
+			pending++
+		}
+	}
+	if pending >= limit {
+		return fmt.Errorf("%w: pay for or cancel one of %d", models.ErrTooManyPendingBookings, pending)
+	}
+	return nil
+}
+
+// checkTicketsPerShow adds seats to the user's other live bookings for the show; excludeBookingID is being replaced
+func (r *LimitBookingRules) checkTicketsPerShow(ctx context.Context, userID, showID, excludeBookingID string, seats int) error {
+	limit := r.limits.MaxTicketsPerUserPerShow
+	if limit <= 0 {
+		return nil
+	}
+
+	bookings, err := r.bookingRepo.GetByShowID(ctx, showID)
+	if err != nil {
+		return err
+	}
+
+	tickets := seats
+	for _, booking := range bookings {
+		if booking.UserID != userID || booking.ID == excludeBookingID {
+			continue
+		}
+		status := booking.GetStatus()
+		if status == models.BookingStatusConfirmed || (status == models.BookingStatusPending && !booking.IsExpired(r.clock.Now())) {
+			tickets += booking.GetSeatCount()
+		}
+	}
+	if tickets > limit {
+		return fmt.Errorf("%w: at most %d per user", models.ErrShowTicketLimitReached, limit)
+	}
+	return nil
+}
@@ -1,24 +1,61 @@
 package services
 
 import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/metrics"
 	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/pricing"
 	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
 	"fmt"
-	"sync"
+	"sort"
+	"strings"
+	"time"
 )
 
 // BookingServiceImpl implements BookingService - demonstrates Concurrency Control and Business Logic
 type BookingServiceImpl struct {
-	bookingRepo     repositories.BookingRepository
-	showRepo        repositories.ShowRepository
-	screenRepo      repositories.ScreenRepository
-	theatreRepo     repositories.TheatreRepository
-	movieRepo       repositories.MovieRepository
-	paymentRepo     repositories.PaymentRepository
-	notificationSvc NotificationService
-	mutex           sync.RWMutex // Demonstrates thread-safe operations
+	bookingRepo      repositories.BookingRepository
+	showRepo         repositories.ShowRepository
+	screenRepo       repositories.ScreenRepository
+	theatreRepo      repositories.TheatreRepository
+	movieRepo        repositories.MovieRepository
+	eventRepo        repositories.EventRepository
+	paymentRepo      repositories.PaymentRepository
+	userRepo         repositories.UserRepository   // Recipients of booking transfers
+	ticketRepo       repositories.TicketRepository // A scanned ticket can't be transferred
+	eventBus         events.EventBus               // Observer Pattern - subscribers react to booking events
+	refundService    RefundService
+	paymentService   PaymentService // Collects the difference when seats are upgraded
+	promotionService PromotionService
+	holdService      SeatHoldService
+	policyEngine     PolicyEngine            // Tiered refunds for user cancellations
+	validators       *BookingValidatorChain  // Rules a new booking must pass, in order
+	rules            BookingRules            // Anti-hoarding limits for seat changes and hold extensions; nil allows any number of seats but no extensions
+	profileDiscounts ProfileDiscountRules    // Student, senior citizen and military discounts; nil grants none
+	addOns           *AddOnCatalog           // Insurance, 3D glasses, parking and other extras bookings can buy
+	fees             models.FeeConfig        // Convenience fee and GST added to new bookings
+	timeouts         models.BookingTimeouts  // Payment window of shows and theatres that set none
+	pricer           pricing.Pricer          // Seat prices after format surcharge and pricing rules
+	lockManager      locks.LockManager       // Per-show locks - bookings on different shows don't contend
+	unitOfWork       repositories.UnitOfWork // Saves a booking and its seats together or not at all
+	logger           logging.Logger
+	metrics          metrics.Recorder // Booking funnel counters and latency
+	audit            AuditRecorder    // Who created, confirmed, cancelled or changed each booking
+	authorizer       Authorizer       // Lets callers at their own bookings and theatre admins at their theatres'
+	clock            clock.Clock
 }
 
+// bookingLockOwner namespaces booking locks apart from seat hold locks
+const bookingLockOwner = "booking"
+
+// maxReferenceAttempts bounds how many booking reference codes are drawn before giving up
+const maxReferenceAttempts = 3
+
 // NewBookingService creates a new booking service
 func NewBookingService(
 	bookingRepo repositories.BookingRepository,
@@ -26,163 +63,419 @@ func NewBookingService(
 	screenRepo repositories.ScreenRepository,
 	theatreRepo repositories.TheatreRepository,
 	movieRepo repositories.MovieRepository,
+	eventRepo repositories.EventRepository,
 	paymentRepo repositories.PaymentRepository,
-	notificationSvc NotificationService,
+	userRepo repositories.UserRepository,
+	ticketRepo repositories.TicketRepository,
+	eventBus events.EventBus,
+	refundService RefundService,
+	paymentService PaymentService,
+	promotionService PromotionService,
+	holdService SeatHoldService,
+	policyEngine PolicyEngine,
+	validators *BookingValidatorChain,
+	rules BookingRules,
+	profileDiscounts ProfileDiscountRules,
+	addOns *AddOnCatalog,
+	fees models.FeeConfig,
+	timeouts models.BookingTimeouts,
+	pricer pricing.Pricer,
+	lockManager locks.LockManager,
+	unitOfWork repositories.UnitOfWork,
+	logger logging.Logger,
+	metrics metrics.Recorder,
+	audit AuditRecorder,
+	authorizer Authorizer,
+	clock clock.Clock,
 ) BookingService {
+	if pricer == nil {
+		pricer = pricing.Base{}
+	}
+	if validators == nil {
+		validators = NewBookingValidatorChain(DefaultBookingValidators(nil, nil, rules)...)
+	}
+	if audit == nil {
+		audit = NopAuditRecorder()
+	}
+	if unitOfWork == nil {
+		unitOfWork = repositories.NewMemoryUnitOfWork()
+	}
+	if addOns == nil {
+		addOns = NewAddOnCatalog()
+	}
+	if authorizer == nil {
+		authorizer = NewAuthorizer(userRepo, showRepo)
+	}
 	return &BookingServiceImpl{
-		bookingRepo:     bookingRepo,
-		showRepo:        showRepo,
-		screenRepo:      screenRepo,
-		theatreRepo:     theatreRepo,
-		movieRepo:       movieRepo,
-		paymentRepo:     paymentRepo,
-		notificationSvc: notificationSvc,
+		bookingRepo:      bookingRepo,
+		showRepo:         showRepo,
+		screenRepo:       screenRepo,
+		theatreRepo:      theatreRepo,
+		movieRepo:        movieRepo,
+		eventRepo:        eventRepo,
+		paymentRepo:      paymentRepo,
+		userRepo:         userRepo,
+		ticketRepo:       ticketRepo,
+		eventBus:         eventBus,
+		refundService:    refundService,
+		paymentService:   paymentService,
+		promotionService: promotionService,
+		holdService:      holdService,
+		policyEngine:     policyEngine,
+		validators:       validators,
+		rules:            rules,
+		profileDiscounts: profileDiscounts,
+		addOns:           addOns,
+		fees:             fees,
+		timeouts:         timeouts,
+		pricer:           pricer,
+		lockManager:      lockManager,
+		unitOfWork:       unitOfWork,
+		logger:           logger,
+		metrics:          metrics,
+		audit:            audit,
+		authorizer:       authorizer,
+		clock:            clock,
 	}
 }
 
-// CreateBooking creates a new booking with atomic seat blocking - demonstrates Concurrency Control
-func (bs *BookingServiceImpl) CreateBooking(userID, showID string, seatIDs []string) (*models.Booking, error) {
-	bs.mutex.Lock()
-	defer bs.mutex.Unlock()
+// CreateBooking books seats held by the user - demonstrates Concurrency Control
+// Without WithHold, a hold is placed on the requested seats and consumed immediately
+func (bs *BookingServiceImpl) CreateBooking(ctx context.Context, userID, showID string, seatIDs []string, opts ...BookingOption) (*models.Booking, error) {
+	start := time.Now() // Wall time, not the injected clock - this measures real latency
+	booking, err := bs.createBooking(ctx, userID, showID, seatIDs, opts...)
+	bs.metrics.ObserveBookingLatency(time.Since(start), err == nil)
+	if err != nil {
+		return nil, err
+	}
+
+	bs.metrics.BookingCreated()
+	return booking, nil
+}
+
+// createBooking does the work of CreateBooking
+func (bs *BookingServiceImpl) createBooking(ctx context.Context, userID, showID string, seatIDs []string, opts ...BookingOption) (*models.Booking, error) {
+	var options BookingOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 
-	// Validate show
-	show, err := bs.showRepo.GetByID(showID)
+	// Only bookings for the same show are serialized; waiting respects ctx
+	unlock, err := bs.lockShow(ctx, showID)
 	if err != nil {
 		return nil, err
 	}
+	defer unlock()
 
-	if !show.CanBeBooked() {
-		return nil, models.ErrShowNotBookable
+	show, err := bs.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get screen and validate seats
-	screen, err := bs.screenRepo.GetByID(show.ScreenID)
+	screen, err := bs.screenRepo.GetByID(ctx, show.ScreenID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate total amount using Factory Pattern pricing
-	totalAmount := 0.0
-	for _, seatID := range seatIDs {
-		seat, err := screen.GetSeat(seatID)
-		if err != nil {
+	// An explicit hold fixes the seats being validated
+	var hold *models.SeatHold
+	if options.HoldID != "" {
+		if hold, err = bs.userHold(ctx, userID, showID, seatIDs, options.HoldID); err != nil {
 			return nil, err
 		}
-		if !seat.IsAvailable() {
-			return nil, models.ErrSeatNotAvailable
+		seatIDs = hold.SeatIDs
+	}
+
+	// Chain of Responsibility - every rule runs before a seat is blocked, so rejections leave nothing to undo
+	request := &BookingRequest{
+		UserID:        userID,
+		Show:          show,
+		Screen:        screen,
+		SeatIDs:       seatIDs,
+		HoldID:        options.HoldID,
+		Now:           bs.clock.Now(),
+		WithGuardian:  options.WithGuardian,
+		BulkBookingID: options.BulkBookingID,
+	}
+	if err := bs.validators.Validate(ctx, request); err != nil {
+		if errors.Is(err, models.ErrSeatNotAvailable) {
+			bs.metrics.SeatConflict()
 		}
-		totalAmount += seat.GetPrice()
+		return nil, err
 	}
 
-	// Block seats atomically - demonstrates atomic operations
-	if err := screen.BlockSeats(seatIDs); err != nil {
+	// Acquire seats through a user-owned hold - seats are never blocked anonymously
+	implicitHold := hold == nil
+	if implicitHold {
+		if hold, err = bs.holdService.CreateHold(ctx, userID, showID, seatIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	// Calculate the seat subtotal using Factory Pattern pricing
+	subtotal, err := bs.priceSeats(show, screen, seatIDs)
+	if err != nil {
+		bs.abandonHold(ctx, hold, implicitHold)
 		return nil, err
 	}
 
-	// Create booking
-	booking, err := models.NewBooking(userID, showID, seatIDs, totalAmount)
+	// Create booking - fees and tax are added on top of the subtotal
+	booking, err := models.NewBooking(userID, showID, seatIDs, subtotal, bs.fees, show.PaymentWindow(bs.timeouts), bs.clock.Now())
 	if err != nil {
-		// Rollback seat blocking on failure
-		bs.rollbackSeatBlocking(screen, seatIDs)
+		bs.abandonHold(ctx, hold, implicitHold)
 		return nil, err
 	}
+	booking.HoldID = hold.ID
+	booking.WithGuardian = options.WithGuardian
+	booking.BulkBookingID = options.BulkBookingID
 
-	// Save booking
-	if err := bs.bookingRepo.Create(booking); err != nil {
-		// Rollback seat blocking on failure
-		bs.rollbackSeatBlocking(screen, seatIDs)
+	// Verified students, senior citizens and military personnel save their category's share, within its caps
+	if err := bs.applyProfileDiscount(ctx, booking); err != nil {
+		bs.abandonHold(ctx, hold, implicitHold)
 		return nil, err
 	}
 
-	// Update screen in repository
-	if err := bs.screenRepo.Update(screen); err != nil {
-		// Log error but don't fail the booking
-		fmt.Printf("Warning: Failed to update screen after booking creation: %v\n", err)
+	// Redeem coupon and record the discount on the booking
+	if options.CouponCode != "" {
+		if err := bs.applyCoupon(ctx, booking, options.CouponCode); err != nil {
+			bs.abandonHold(ctx, hold, implicitHold)
+			return nil, err
+		}
+	}
+
+	// Add-ons are priced on the tickets after discounts and paid for with them
+	if len(options.AddOns) > 0 {
+		if err := bs.applyAddOns(ctx, show, booking, options.AddOns); err != nil {
+			bs.releaseCoupon(ctx, booking)
+			bs.abandonHold(ctx, hold, implicitHold)
+			return nil, err
+		}
 	}
 
+	// Hand the held seats over to the booking and save it, its blocked seats and its BookingCreated event in one
+	// transaction - a rollback makes the hold active again, and a reference collision just draws a new code
+	err = repositories.InTransaction(ctx, bs.unitOfWork, func(txCtx context.Context) error {
+		if _, err := bs.holdService.ConsumeHold(txCtx, hold.ID, userID, booking.ID); err != nil {
+			return err
+		}
+
+		err := bs.bookingRepo.Create(txCtx, booking)
+		for attempt := 1; errors.Is(err, models.ErrDuplicateBookingReference) && attempt < maxReferenceAttempts; attempt++ {
+			booking.Reference = models.NewBookingReference()
+			err = bs.bookingRepo.Create(txCtx, booking)
+		}
+		if err != nil {
+			return err
+		}
+		repositories.OnRollback(txCtx, func() { bs.bookingRepo.Delete(ctx, booking.ID) })
+		if err := bs.showRepo.Update(txCtx, show); err != nil {
+			return err
+		}
+
+		return bs.publishInTransaction(txCtx, events.BookingCreated{
+			BookingID:   booking.ID,
+			UserID:      booking.UserID,
+			ShowID:      booking.ShowID,
+			SeatIDs:     booking.SeatIDs,
+			TotalAmount: booking.TotalAmount,
+			Timestamp:   bs.clock.Now(),
+		})
+	})
+	if err != nil {
+		// Give back reserved add-ons, coupon usage and an implicit hold's seats; an explicit hold stays the user's
+		bs.unreserveAddOns(ctx, booking)
+		bs.releaseCoupon(ctx, booking)
+		bs.abandonHold(ctx, hold, implicitHold)
+		return nil, err
+	}
+
+	details := map[string]string{
+		"seats": strings.Join(booking.SeatIDs, ","),
+		"total": booking.TotalAmount.String(),
+	}
+	if booking.WithGuardian {
+		details["with_guardian"] = "true" // The age certificate was waived
+	}
+	if booking.BulkBookingID != "" {
+		details["bulk_booking_id"] = booking.BulkBookingID
+	}
+	if booking.ProfileDiscount != nil {
+		details["profile_discount"] = fmt.Sprintf("%s %s", booking.ProfileDiscount.Category, booking.ProfileDiscount.Amount)
+	}
+	if len(booking.AddOns) > 0 {
+		addOns := make([]string, 0, len(booking.AddOns))
+		for _, addOn := range booking.AddOns {
+			addOns = append(addOns, fmt.Sprintf("%s x%d", addOn.Type, addOn.Quantity))
+		}
+		details["add_ons"] = strings.Join(addOns, ",")
+	}
+	bs.recordChange(ctx, booking, models.AuditBookingCreated, details)
+
 	return booking, nil
 }
 
 // GetBooking retrieves a booking by ID
-func (bs *BookingServiceImpl) GetBooking(id string) (*models.Booking, error) {
-	return bs.bookingRepo.GetByID(id)
+func (bs *BookingServiceImpl) GetBooking(ctx context.Context, id string) (*models.Booking, error) {
+	return bs.bookingRepo.GetByID(ctx, id)
+}
+
+// GetBookingByReference finds a booking by the short code shown to its user
+func (bs *BookingServiceImpl) GetBookingByReference(ctx context.Context, reference string) (*models.Booking, error) {
+	return bs.bookingRepo.GetByReference(ctx, reference)
 }
 
 // ConfirmBooking confirms a booking after successful payment - demonstrates Observer Pattern
-func (bs *BookingServiceImpl) ConfirmBooking(bookingID, paymentID string) error {
-	booking, err := bs.bookingRepo.GetByID(bookingID)
+func (bs *BookingServiceImpl) ConfirmBooking(ctx context.Context, bookingID, paymentID string) error {
+	booking, err := bs.bookingRepo.GetByID(ctx, bookingID)
 	if err != nil {
 		return err
 	}
 
-	if err := booking.Confirm(paymentID); err != nil {
+	unlock, err := bs.lockShow(ctx, booking.ShowID)
+	if err != nil {
 		return err
 	}
+	defer unlock()
 
-	// Update booking in repository
-	if err := bs.bookingRepo.Update(booking); err != nil {
+	// Checked under the lock, as a transfer or resale changes the owner
+	if err := bs.authorizer.AuthorizeBooking(ctx, booking); err != nil {
 		return err
 	}
 
-	// Book the actual seats
-	show, err := bs.showRepo.GetByID(booking.ShowID)
+	show, err := bs.showRepo.GetByID(ctx, booking.ShowID)
 	if err != nil {
 		return err
 	}
 
-	screen, err := bs.screenRepo.GetByID(show.ScreenID)
+	screen, err := bs.screenRepo.GetByID(ctx, show.ScreenID)
 	if err != nil {
 		return err
 	}
 
-	// Book all seats
-	for _, seatID := range booking.SeatIDs {
-		seat, err := screen.GetSeat(seatID)
-		if err != nil {
-			continue // Log error but don't fail
-		}
-		if err := seat.Book(); err != nil {
-			// Log error but continue
-			fmt.Printf("Warning: Failed to book seat %s: %v\n", seatID, err)
+	// Only a successful payment of the booking's total confirms it
+	if err := bs.checkPayment(ctx, booking, paymentID); err != nil {
+		return err
+	}
+
+	previousPaymentID := booking.PaymentID
+	if err := booking.Confirm(paymentID, bs.clock.Now()); err != nil {
+		if errors.Is(err, models.ErrBookingExpired) {
+			bs.metrics.BookingExpired()
+			bs.publish(ctx, events.BookingExpired{
+				BookingID: booking.ID,
+				UserID:    booking.UserID,
+				ShowID:    booking.ShowID,
+				Timestamp: bs.clock.Now(),
+			})
 		}
+		return err
 	}
 
-	// Update screen
-	if err := bs.screenRepo.Update(screen); err != nil {
-		fmt.Printf("Warning: Failed to update screen after booking confirmation: %v\n", err)
+	// Save the confirmation, book the actual seats and write the events in one transaction; a failure puts
+	// them all back
+	err = repositories.InTransaction(ctx, bs.unitOfWork, func(txCtx context.Context) error {
+		repositories.OnRollback(txCtx, func() { booking.RevertConfirm(previousPaymentID) })
+		if err := bs.bookingRepo.Update(txCtx, booking); err != nil {
+			return err
+		}
+
+		for _, seatID := range booking.SeatIDs {
+			if err := show.BookSeat(seatID); err != nil {
+				// Log error but continue
+				bs.logger.Warn(ctx, "failed to book seat", "booking_id", booking.ID, "seat_id", seatID, "error", err)
+				continue
+			}
+			repositories.OnRollback(txCtx, func() { show.UnbookSeat(seatID) })
+		}
+		if err := bs.showRepo.Update(txCtx, show); err != nil {
+			return err
+		}
+
+		// Publish event - demonstrates Observer Pattern
+		if err := bs.publishInTransaction(txCtx, events.BookingConfirmed{
+			BookingID: booking.ID,
+			UserID:    booking.UserID,
+			ShowID:    booking.ShowID,
+			SeatIDs:   booking.SeatIDs,
+			Reference: booking.Reference,
+			PaymentID: paymentID,
+			Timestamp: bs.clock.Now(),
+		}); err != nil {
+			return err
+		}
+
+		// The show lock makes this the one confirmation that took the last seat
+		if !show.IsSoldOut(screen) {
+			return nil
+		}
+		return bs.publishInTransaction(txCtx, events.ShowSoldOut{
+			ShowID:    show.ID,
+			TheatreID: show.TheatreID,
+			Seats:     len(screen.Seats),
+			Timestamp: bs.clock.Now(),
+		})
+	})
+	if err != nil {
+		return err
 	}
+	bs.recordChange(ctx, booking, models.AuditBookingConfirmed, map[string]string{"payment_id": paymentID})
+
+	bs.metrics.BookingConfirmed()
+	return nil
+}
 
-	// Send notification - demonstrates Observer Pattern
-	if bs.notificationSvc != nil {
-		bs.notificationSvc.SendBookingConfirmation(booking.UserID, booking.ID)
+// checkPayment verifies that paymentID is a successful payment for booking that covers its total
+func (bs *BookingServiceImpl) checkPayment(ctx context.Context, booking *models.Booking, paymentID string) error {
+	payment, err := bs.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return err
 	}
 
+	if payment.BookingID != booking.ID {
+		return models.ErrPaymentBookingMismatch
+	}
+	if !payment.IsSuccessful() {
+		return models.ErrPaymentNotSuccessful
+	}
+	if !payment.Amount.SameCurrency(booking.TotalAmount) || payment.Amount.LessThan(booking.TotalAmount) {
+		return models.ErrPaymentShortOfTotal
+	}
 	return nil
 }
 
 // GetBookingDetails retrieves detailed booking information - demonstrates Aggregate Construction
-func (bs *BookingServiceImpl) GetBookingDetails(bookingID string) (*BookingDetails, error) {
-	booking, err := bs.bookingRepo.GetByID(bookingID)
+func (bs *BookingServiceImpl) GetBookingDetails(ctx context.Context, bookingID string) (*BookingDetails, error) {
+	booking, err := bs.bookingRepo.GetByID(ctx, bookingID)
 	if err != nil {
 		return nil, err
 	}
+	if err := bs.authorizer.AuthorizeBooking(ctx, booking); err != nil {
+		return nil, err
+	}
 
-	show, err := bs.showRepo.GetByID(booking.ShowID)
+	show, err := bs.showRepo.GetByID(ctx, booking.ShowID)
 	if err != nil {
 		return nil, err
 	}
 
-	movie, err := bs.movieRepo.GetByID(show.MovieID)
+	// A show screens either a movie or a live event
+	var movie *models.Movie
+	var event *models.Event
+	if show.IsMovie() {
+		movie, err = bs.movieRepo.GetByID(ctx, show.MovieID)
+	} else {
+		event, err = bs.eventRepo.GetByID(ctx, show.EventID)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	theatre, err := bs.theatreRepo.GetByID(show.TheatreID)
+	theatre, err := bs.theatreRepo.GetByID(ctx, show.TheatreID)
 	if err != nil {
 		return nil, err
 	}
 
-	screen, err := bs.screenRepo.GetByID(show.ScreenID)
+	screen, err := bs.screenRepo.GetByID(ctx, show.ScreenID)
 	if err != nil {
 		return nil, err
 	}
@@ -199,26 +492,943 @@ func (bs *BookingServiceImpl) GetBookingDetails(bookingID string) (*BookingDetai
 	// Get payment if exists
 	var payment *models.Payment
 	if booking.PaymentID != "" {
-		payment, _ = bs.paymentRepo.GetByID(booking.PaymentID)
+		payment, _ = bs.paymentRepo.GetByID(ctx, booking.PaymentID)
 	}
 
 	return &BookingDetails{
-		Booking: booking,
-		Show:    show,
-		Movie:   movie,
-		Theatre: theatre,
-		Screen:  screen,
-		Seats:   seats,
-		Payment: payment,
+		Booking:        booking,
+		Show:           show,
+		Movie:          movie,
+		Event:          event,
+		Theatre:        theatre,
+		Screen:         screen,
+		Seats:          seats,
+		LineItems:      bs.buildLineItems(booking, show, seats),
+		PriceBreakdown: booking.GetPriceBreakdown(),
+		Payment:        payment,
 	}, nil
 }
 
-// Helper method to rollback seat blocking - demonstrates Error Handling
-func (bs *BookingServiceImpl) rollbackSeatBlocking(screen *models.Screen, seatIDs []string) {
-	for _, seatID := range seatIDs {
+// GetUserBookings returns a page of the user's bookings, optionally limited to one category.
+// Upcoming bookings are ordered by show time; everything else is newest first.
+func (bs *BookingServiceImpl) GetUserBookings(ctx context.Context, userID string, filter BookingFilter) (*UserBookings, error) {
+	if userID == "" || filter.Offset < 0 || filter.Limit < 0 {
+		return nil, models.ErrInvalidBookingData
+	}
+
+	switch filter.Category {
+	case "", BookingCategoryUpcoming, BookingCategoryPast, BookingCategoryCancelled:
+	default:
+		return nil, fmt.Errorf("%w: unknown category %q", models.ErrInvalidBookingData, filter.Category)
+	}
+
+	page := repositories.Page{Offset: filter.Offset, Limit: filter.Limit}
+	if filter.Category != "" {
+		// Category depends on the show, so filter the full history before paginating
+		page = repositories.Page{}
+	}
+
+	bookings, total, err := bs.bookingRepo.GetByUserID(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	summarizer := newBookingSummarizer(bs, bs.clock.Now())
+	summaries := make([]*BookingSummary, 0, len(bookings))
+	for _, booking := range bookings {
+		summary, err := summarizer.summarize(ctx, booking)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Category == "" || summary.Category == filter.Category {
+			summaries = append(summaries, summary)
+		}
+	}
+
+	if filter.Category != "" {
+		if filter.Category == BookingCategoryUpcoming {
+			sort.SliceStable(summaries, func(i, j int) bool {
+				return summaries[i].ShowStart.Before(summaries[j].ShowStart)
+			})
+		}
+
+		total = len(summaries)
+		summaries = summaries[min(filter.Offset, total):]
+		if filter.Limit > 0 && filter.Limit < len(summaries) {
+			summaries = summaries[:filter.Limit]
+		}
+	}
+
+	return &UserBookings{
+		Bookings: summaries,
+		Total:    total,
+		Offset:   filter.Offset,
+		Limit:    filter.Limit,
+	}, nil
+}
+
+// bookingSummarizer builds list rows, caching shows, movies, events, theatres and screens shared by bookings
+type bookingSummarizer struct {
+	bs       *BookingServiceImpl
+	now      time.Time
+	shows    map[string]*models.Show
+	movies   map[string]*models.Movie
+	events   map[string]*models.Event
+	theatres map[string]*models.Theatre
+	screens  map[string]*models.Screen
+}
+
+func newBookingSummarizer(bs *BookingServiceImpl, now time.Time) *bookingSummarizer {
+	return &bookingSummarizer{
+		bs:       bs,
+		now:      now,
+		shows:    make(map[string]*models.Show),
+		movies:   make(map[string]*models.Movie),
+		events:   make(map[string]*models.Event),
+		theatres: make(map[string]*models.Theatre),
+		screens:  make(map[string]*models.Screen),
+	}
+}
+
+// summarize flattens a booking and its show into a BookingSummary
+func (s *bookingSummarizer) summarize(ctx context.Context, booking *models.Booking) (*BookingSummary, error) {
+	show, err := cached(ctx, s.shows, booking.ShowID, s.bs.showRepo.GetByID)
+	if err != nil {
+		return nil, err
+	}
+	title, err := s.title(ctx, show)
+	if err != nil {
+		return nil, err
+	}
+	theatre, err := cached(ctx, s.theatres, show.TheatreID, s.bs.theatreRepo.GetByID)
+	if err != nil {
+		return nil, err
+	}
+	screen, err := cached(ctx, s.screens, show.ScreenID, s.bs.screenRepo.GetByID)
+	if err != nil {
+		return nil, err
+	}
+
+	seats := make([]string, 0, len(booking.SeatIDs))
+	for _, seatID := range booking.SeatIDs {
+		if seat, err := screen.GetSeat(seatID); err == nil {
+			seats = append(seats, seat.GetSeatNumber())
+		}
+	}
+
+	return &BookingSummary{
+		BookingID:   booking.ID,
+		Reference:   booking.Reference,
+		Status:      booking.GetStatus(),
+		Category:    s.categorize(booking, show),
+		ShowID:      show.ID,
+		ShowStart:   show.StartTime,
+		EventType:   show.EventType,
+		MovieID:     show.MovieID,
+		EventID:     show.EventID,
+		Title:       title,
+		TheatreName: theatre.Name,
+		City:        theatre.City,
+		ScreenName:  screen.Name,
+		Seats:       seats,
+		TotalAmount: booking.TotalAmount,
+		BookedAt:    booking.BookingTime,
+	}, nil
+}
+
+// title names the movie or live event the show is for
+func (s *bookingSummarizer) title(ctx context.Context, show *models.Show) (string, error) {
+	if show.IsMovie() {
+		movie, err := cached(ctx, s.movies, show.MovieID, s.bs.movieRepo.GetByID)
+		if err != nil {
+			return "", err
+		}
+		return movie.Title, nil
+	}
+
+	event, err := cached(ctx, s.events, show.EventID, s.bs.eventRepo.GetByID)
+	if err != nil {
+		return "", err
+	}
+	return event.Title, nil
+}
+
+// categorize treats cancelled, expired and lapsed pending bookings as cancelled
+func (s *bookingSummarizer) categorize(booking *models.Booking, show *models.Show) BookingCategory {
+	switch booking.GetStatus() {
+	case models.BookingStatusCancelled, models.BookingStatusExpired:
+		return BookingCategoryCancelled
+	}
+	if booking.IsExpired(s.bs.clock.Now()) {
+		return BookingCategoryCancelled
+	}
+	if s.now.Before(show.StartTime) {
+		return BookingCategoryUpcoming
+	}
+	return BookingCategoryPast
+}
+
+// cached looks id up in cache, falling back to get and remembering the result
+func cached[T any](ctx context.Context, cache map[string]T, id string, get func(context.Context, string) (T, error)) (T, error) {
+	if v, ok := cache[id]; ok {
+		return v, nil
+	}
+	v, err := get(ctx, id)
+	if err != nil {
+		return v, err
+	}
+	cache[id] = v
+	return v, nil
+}
+
+// CancelBooking cancels a booking, releases its seats and refunds any captured payment
+func (bs *BookingServiceImpl) CancelBooking(ctx context.Context, bookingID string) error {
+	booking, err := bs.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := bs.lockShow(ctx, booking.ShowID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Checked under the lock, as a transfer or resale changes the owner
+	if err := bs.authorizer.AuthorizeBooking(ctx, booking); err != nil {
+		return err
+	}
+
+	show, err := bs.showRepo.GetByID(ctx, booking.ShowID)
+	if err != nil {
+		return err
+	}
+
+	// Save the cancellation, release the seats back to inventory and write the event in one transaction;
+	// a failure leaves the booking and its seats as they were
+	err = repositories.InTransaction(ctx, bs.unitOfWork, func(txCtx context.Context) error {
+		if err := bs.cancelBooking(txCtx, booking, show); err != nil {
+			return err
+		}
+		return bs.showRepo.Update(txCtx, show)
+	})
+	if err != nil {
+		return err
+	}
+	bs.recordChange(ctx, booking, models.AuditBookingCancelled, nil)
+
+	// Refund captured payments - cancellations produce money movement records
+	if bs.refundService == nil {
+		return nil
+	}
+
+	paid, err := bs.refundableTotal(ctx, booking)
+	if err != nil || !paid.IsPositive() {
+		return err
+	}
+
+	// The theatre's cancellation policy decides how much of it comes back, unless the booking is insured:
+	// then everything but the premium does
+	amount := paid
+	if insurance, insured := booking.AddOn(models.AddOnCancellationInsurance); insured {
+		if amount = paid.Sub(insurance.Amount); !amount.IsPositive() {
+			return nil
+		}
+	} else if bs.policyEngine != nil {
+		if amount, err = bs.policyEngine.RefundAmount(ctx, show, paid); err != nil {
+			return err
+		}
+	}
+
+	_, err = bs.refundAcross(ctx, booking, amount, "booking cancelled")
+	return err
+}
+
+// refundableTotal sums what can still be refunded across the booking's payments
+func (bs *BookingServiceImpl) refundableTotal(ctx context.Context, booking *models.Booking) (models.Money, error) {
+	total := models.ZeroMoney(booking.TotalAmount.Currency)
+	for _, paymentID := range booking.PaymentIDs() {
+		payment, err := bs.paymentRepo.GetByID(ctx, paymentID)
+		if err != nil {
+			return total, err
+		}
+
+		if payment.CanBeRefunded() {
+			total = total.Add(payment.RefundableAmount())
+		}
+	}
+	return total, nil
+}
+
+// CancelShowBookings cancels every pending or confirmed booking of a show and releases their seats.
+// Refunds are left to the caller, since a called-off show is always refunded in full.
+func (bs *BookingServiceImpl) CancelShowBookings(ctx context.Context, showID string) ([]*models.Booking, error) {
+	unlock, err := bs.lockShow(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	show, err := bs.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := bs.bookingRepo.GetByShowID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	// All of them or none: a failure leaves every booking and seat as it was, so the cancellation can be retried
+	var cancelled []*models.Booking
+	err = repositories.InTransaction(ctx, bs.unitOfWork, func(txCtx context.Context) error {
+		for _, booking := range bookings {
+			if !booking.CanBeCancelled() {
+				continue
+			}
+			if err := bs.cancelBooking(txCtx, booking, show); err != nil {
+				return err
+			}
+			cancelled = append(cancelled, booking)
+		}
+		return bs.showRepo.Update(txCtx, show)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, booking := range cancelled {
+		bs.recordChange(ctx, booking, models.AuditBookingCancelled, map[string]string{"show_id": showID})
+	}
+	return cancelled, nil
+}
+
+// ModifySeats moves a booking to different seats before the show starts.
+// New seats are blocked before old ones are released, so a failed swap leaves the booking untouched.
+// Confirmed bookings pay the difference through the original payment method or get it refunded;
+// if that refund fails the swap still stands and the error is returned alongside the modification.
+func (bs *BookingServiceImpl) ModifySeats(ctx context.Context, bookingID string, newSeatIDs []string) (*SeatModification, error) {
+	booking, err := bs.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := bs.lockShow(ctx, booking.ShowID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Checked under the lock, as a transfer or resale changes the owner
+	if err := bs.authorizer.AuthorizeBooking(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	status := booking.GetStatus()
+	if status != models.BookingStatusPending && status != models.BookingStatusConfirmed {
+		return nil, models.ErrBookingNotModifiable
+	}
+
+	if booking.IsExpired(bs.clock.Now()) {
+		return nil, models.ErrBookingExpired
+	}
+
+	if len(newSeatIDs) == 0 || sameSeats(booking.SeatIDs, newSeatIDs) {
+		return nil, models.ErrInvalidBookingData
+	}
+
+	show, err := bs.showRepo.GetByID(ctx, booking.ShowID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !show.IsUpcoming(bs.clock.Now()) {
+		return nil, models.ErrBookingNotModifiable
+	}
+
+	// Corporate blocks are outside the anti-hoarding limits, as when they were booked
+	if bs.rules != nil && booking.BulkBookingID == "" {
+		if err := bs.rules.CheckSeatChange(ctx, booking, len(newSeatIDs)); err != nil {
+			return nil, err
+		}
+	}
+
+	screen, err := bs.screenRepo.GetByID(ctx, show.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := screen.ValidateSeatGroups(newSeatIDs); err != nil {
+		return nil, err
+	}
+	if err := screen.ValidateCompanionSeats(newSeatIDs); err != nil {
+		return nil, err
+	}
+	if err := show.CheckSeatsOnSale(newSeatIDs); err != nil {
+		return nil, err
+	}
+
+	// Block incoming seats first - all or nothing
+	added := missingSeats(newSeatIDs, booking.SeatIDs)
+	removed := missingSeats(booking.SeatIDs, newSeatIDs)
+	if err := show.BlockSeats(screen, added); err != nil {
+		return nil, err
+	}
+
+	subtotal, err := bs.priceSeats(show, screen, newSeatIDs)
+	if err != nil {
+		bs.rollbackSeatBlocking(show, added)
+		return nil, err
+	}
+
+	profileDiscount, err := bs.recalculateProfileDiscount(ctx, booking, subtotal)
+	if err != nil {
+		bs.rollbackSeatBlocking(show, added)
+		return nil, err
+	}
+	discount := bs.recalculateDiscount(ctx, booking, subtotal)
+	if profileDiscount != nil {
+		discount = discount.Min(subtotal.Sub(profileDiscount.Amount))
+	}
+	oldSeatIDs := booking.SeatIDs
+	oldTotal := booking.TotalAmount
+	difference := booking.QuoteTotal(subtotal, discount, profileDiscount).Sub(booking.TotalAmount)
+	modification := &SeatModification{Booking: booking, PriceDifference: difference}
+
+	// Collect an upgrade before giving up the old seats
+	if status == models.BookingStatusConfirmed && difference.IsPositive() {
+		payment, err := bs.chargeDifference(ctx, booking, difference)
+		if err != nil {
+			bs.rollbackSeatBlocking(show, added)
+			return nil, err
+		}
+		modification.Payment = payment
+	}
+
+	// Move the booking to its new seats, release the old ones and write the event in one transaction;
+	// a failure leaves the booking on its old seats and gives back the new seats and any upgrade payment
+	selection := booking.SeatSelection()
+	err = repositories.InTransaction(ctx, bs.unitOfWork, func(txCtx context.Context) error {
+		repositories.OnRollback(txCtx, func() { booking.RestoreSeatSelection(selection, bs.clock.Now()) })
+		if modification.Payment != nil {
+			booking.AddExtraPayment(modification.Payment.ID, bs.clock.Now())
+		}
+		if err := booking.ChangeSeats(newSeatIDs, subtotal, discount, profileDiscount, bs.clock.Now()); err != nil {
+			return err
+		}
+		if err := bs.bookingRepo.Update(txCtx, booking); err != nil {
+			return err
+		}
+
+		for _, seatID := range removed {
+			bs.releaseSeat(txCtx, booking, show, seatID)
+		}
+		if status == models.BookingStatusConfirmed {
+			for _, seatID := range added {
+				if err := show.BookSeat(seatID); err != nil {
+					bs.logger.Warn(ctx, "failed to book seat", "booking_id", booking.ID, "seat_id", seatID, "error", err)
+					continue
+				}
+				repositories.OnRollback(txCtx, func() { show.UnbookSeat(seatID) })
+			}
+		}
+		if err := bs.showRepo.Update(txCtx, show); err != nil {
+			return err
+		}
+
+		return bs.publishInTransaction(txCtx, events.BookingModified{
+			BookingID:       booking.ID,
+			UserID:          booking.UserID,
+			ShowID:          booking.ShowID,
+			OldSeatIDs:      oldSeatIDs,
+			NewSeatIDs:      newSeatIDs,
+			Status:          status,
+			PriceDifference: difference,
+			Timestamp:       bs.clock.Now(),
+		})
+	})
+	if err != nil {
+		bs.rollbackSeatBlocking(show, added)
+		bs.refundUpgrade(ctx, booking, modification.Payment)
+		return nil, err
+	}
+	bs.recordChange(ctx, booking, models.AuditBookingSeatsChanged, map[string]string{
+		"old_seats": strings.Join(oldSeatIDs, ","),
+		"new_seats": strings.Join(newSeatIDs, ","),
+		"old_total": oldTotal.String(),
+		"new_total": booking.TotalAmount.String(),
+	})
+
+	// Downgrades give money back once the new seats are secured
+	if status == models.BookingStatusConfirmed && difference.IsNegative() {
+		refunds, err := bs.refundDifference(ctx, booking, difference.Mul(-1))
+		modification.Refunds = refunds
+		if err != nil {
+			return modification, err
+		}
+	}
+
+	return modification, nil
+}
+
+// priceSeats adds up the quoted price of the given seats at the show
+func (bs *BookingServiceImpl) priceSeats(show *models.Show, screen *models.Screen, seatIDs []string) (models.Money, error) {
+	var total models.Money
+	for i, seatID := range seatIDs {
 		seat, err := screen.GetSeat(seatID)
-		if err == nil {
-			seat.Unblock()
+		if err != nil {
+			return models.Money{}, err
+		}
+		if i == 0 {
+			total = models.ZeroMoney(seat.GetPrice().Currency)
+		}
+		total = total.Add(bs.pricer.Quote(show, seat).Price)
+	}
+	return total, nil
+}
+
+// recalculateDiscount reapplies the booking's coupon to a new subtotal without consuming another use
+func (bs *BookingServiceImpl) recalculateDiscount(ctx context.Context, booking *models.Booking, subtotal models.Money) models.Money {
+	if booking.CouponCode == "" {
+		return models.ZeroMoney(subtotal.Currency)
+	}
+
+	if bs.promotionService != nil {
+		if coupon, err := bs.promotionService.GetCoupon(ctx, booking.CouponCode); err == nil {
+			return coupon.CalculateDiscount(subtotal)
+		}
+	}
+
+	// Coupon no longer retrievable - keep what was granted, capped at the new subtotal
+	return booking.DiscountAmount.Min(subtotal)
+}
+
+// recalculateProfileDiscount works out the booking's profile discount for a new subtotal. The booking's own
+// discount doesn't count against the monthly cap, so moving seats can't use up the allowance twice.
+func (bs *BookingServiceImpl) recalculateProfileDiscount(ctx context.Context, booking *models.Booking, subtotal models.Money) (*models.AppliedProfileDiscount, error) {
+	if bs.profileDiscounts == nil {
+		return nil, nil
+	}
+	return bs.profileDiscounts.DiscountFor(ctx, booking.UserID, subtotal, booking.ID)
+}
+
+// chargeDifference collects an upgrade through the booking's original payment method
+func (bs *BookingServiceImpl) chargeDifference(ctx context.Context, booking *models.Booking, amount models.Money) (*models.Payment, error) {
+	if bs.paymentService == nil {
+		return nil, models.ErrServiceUnavailable
+	}
+
+	original, err := bs.paymentRepo.GetByID(ctx, booking.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := bs.paymentService.ChargeSupplement(ctx, booking.ID, amount, original.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	if !payment.IsSuccessful() {
+		return nil, models.ErrPaymentProcessingFail
+	}
+	return payment, nil
+}
+
+// refundDifference returns a downgrade, drawing on the booking's payments in order
+func (bs *BookingServiceImpl) refundDifference(ctx context.Context, booking *models.Booking, amount models.Money) ([]*models.Refund, error) {
+	if bs.refundService == nil {
+		return nil, models.ErrServiceUnavailable
+	}
+
+	return bs.refundAcross(ctx, booking, amount, "seat modification")
+}
+
+// refundAcross refunds amount from the booking's payments in order
+func (bs *BookingServiceImpl) refundAcross(ctx context.Context, booking *models.Booking, amount models.Money, reason string) ([]*models.Refund, error) {
+	var refunds []*models.Refund
+	remaining := amount
+	for _, paymentID := range booking.PaymentIDs() {
+		if !remaining.IsPositive() {
+			break
+		}
+
+		payment, err := bs.paymentRepo.GetByID(ctx, paymentID)
+		if err != nil {
+			return refunds, err
+		}
+
+		if !payment.CanBeRefunded() {
+			continue
+		}
+
+		share := remaining.Min(payment.RefundableAmount())
+		refund, err := bs.refundService.InitiateRefund(ctx, payment.ID, share, reason)
+		if err != nil {
+			return refunds, err
+		}
+		refunds = append(refunds, refund)
+		remaining = remaining.Sub(share)
+	}
+	return refunds, nil
+}
+
+// lockShow serializes seat changes for one show
+func (bs *BookingServiceImpl) lockShow(ctx context.Context, showID string) (locks.Unlock, error) {
+	return bs.lockManager.Lock(ctx, locks.ShowKey(bookingLockOwner, showID))
+}
+
+// applyAddOns prices the requested add-ons, adds them to a new booking and reserves those from limited stock;
+// when one can't be reserved, the others are given back
+func (bs *BookingServiceImpl) applyAddOns(ctx context.Context, show *models.Show, booking *models.Booking, requests []models.AddOnRequest) error {
+	addOns, err := bs.addOns.Price(ctx, show, booking, requests)
+	if err != nil {
+		return err
+	}
+	if err := booking.AddAddOns(bs.clock.Now(), addOns...); err != nil {
+		return err
+	}
+
+	for _, item := range addOns {
+		addOn, ok := bs.addOns.Lookup(item.Type)
+		if !ok {
+			continue
+		}
+		if reservable, ok := addOn.(ReservableAddOn); ok {
+			if err := reservable.Reserve(ctx, show, booking, item); err != nil {
+				bs.unreserveAddOns(ctx, booking)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unreserveAddOns gives back what a booking that fell through reserved
+func (bs *BookingServiceImpl) unreserveAddOns(ctx context.Context, booking *models.Booking) {
+	for _, item := range booking.GetAddOns() {
+		addOn, ok := bs.addOns.Lookup(item.Type)
+		if !ok {
+			continue
+		}
+		if reservable, ok := addOn.(ReservableAddOn); ok {
+			reservable.Unreserve(ctx, booking)
+		}
+	}
+}
+
+// GetAddOnOffers lists the add-ons a booking for the show can buy
+func (bs *BookingServiceImpl) GetAddOnOffers(ctx context.Context, showID string) ([]AddOnOffer, error) {
+	show, err := bs.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	return bs.addOns.Offers(ctx, show), nil
+}
+
+// FulfillAddOns runs the fulfilment hook of every add-on of a confirmed booking not yet fulfilled, recording
+// the reference each issues. A failed hook is recorded on its add-on and tried again on the next call.
+func (bs *BookingServiceImpl) FulfillAddOns(ctx context.Context, bookingID string) error {
+	booking, err := bs.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+	if booking.GetStatus() != models.BookingStatusConfirmed {
+		return nil
+	}
+
+	var errs []error
+	fulfilled := false
+	for _, item := range booking.GetAddOns() {
+		if item.Status == models.AddOnStatusFulfilled {
+			continue
+		}
+		addOn, ok := bs.addOns.Lookup(item.Type)
+		if !ok {
+			err := fmt.Errorf("%w: %s", models.ErrAddOnUnavailable, item.Type)
+			booking.FulfillAddOn(item.Type, "", err, bs.clock.Now())
+			errs = append(errs, err)
+			continue
+		}
+
+		reference, err := addOn.Fulfill(ctx, booking, item)
+		booking.FulfillAddOn(item.Type, reference, err, bs.clock.Now())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fulfilling %s: %w", item.Type, err))
+			continue
+		}
+		fulfilled = true
+	}
+	if fulfilled || len(errs) > 0 {
+		if err := bs.bookingRepo.Update(ctx, booking); err != nil {
+			return err
 		}
 	}
+	return errors.Join(errs...)
+}
+
+// applyProfileDiscount takes the user's verified profile discount off a new booking
+func (bs *BookingServiceImpl) applyProfileDiscount(ctx context.Context, booking *models.Booking) error {
+	if bs.profileDiscounts == nil {
+		return nil
+	}
+
+	discount, err := bs.profileDiscounts.DiscountFor(ctx, booking.UserID, booking.SubtotalAmount, "")
+	if err != nil || discount == nil {
+		return err
+	}
+	return booking.ApplyProfileDiscount(*discount, bs.clock.Now())
+}
+
+// applyCoupon redeems a coupon against the booking subtotal
+func (bs *BookingServiceImpl) applyCoupon(ctx context.Context, booking *models.Booking, couponCode string) error {
+	if bs.promotionService == nil {
+		return models.ErrCouponNotFound
+	}
+
+	code := models.NormalizeCouponCode(couponCode)
+	discount, err := bs.promotionService.RedeemCoupon(ctx, code, booking.SubtotalAmount)
+	if err != nil {
+		return err
+	}
+
+	if err := booking.ApplyDiscount(code, discount, bs.clock.Now()); err != nil {
+		bs.promotionService.ReleaseCoupon(ctx, code)
+		return err
+	}
+	return nil
+}
+
+// releaseCoupon gives back the coupon use redeemed by a booking
+func (bs *BookingServiceImpl) releaseCoupon(ctx context.Context, booking *models.Booking) {
+	if booking.CouponCode == "" || bs.promotionService == nil {
+		return
+	}
+	if err := bs.promotionService.ReleaseCoupon(ctx, booking.CouponCode); err != nil {
+		bs.logger.Warn(ctx, "failed to release coupon", "booking_id", booking.ID, "coupon", booking.CouponCode, "error", err)
+	}
+}
+
+// userHold loads the caller's hold, checking it covers the show and, if given, the requested seats
+func (bs *BookingServiceImpl) userHold(ctx context.Context, userID, showID string, seatIDs []string, holdID string) (*models.SeatHold, error) {
+	hold, err := bs.holdService.GetHold(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hold.UserID != userID {
+		return nil, models.ErrUnauthorized
+	}
+
+	if hold.ShowID != showID || (len(seatIDs) > 0 && !sameSeats(hold.SeatIDs, seatIDs)) {
+		return nil, models.ErrSeatHoldMismatch
+	}
+
+	return hold, nil
+}
+
+// abandonHold releases holds placed implicitly by CreateBooking; explicit holds stay with the user
+func (bs *BookingServiceImpl) abandonHold(ctx context.Context, hold *models.SeatHold, implicit bool) {
+	if !implicit {
+		return
+	}
+	if err := bs.holdService.ReleaseHold(ctx, hold.ID, hold.UserID); err != nil {
+		bs.logger.Warn(ctx, "failed to release hold", "hold_id", hold.ID, "error", err)
+	}
+}
+
+// missingSeats returns the seats in a that are not in b
+func missingSeats(a, b []string) []string {
+	present := make(map[string]bool, len(b))
+	for _, id := range b {
+		present[id] = true
+	}
+
+	var missing []string
+	for _, id := range a {
+		if !present[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// sameSeats checks whether two seat ID lists contain the same seats
+func sameSeats(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	seen := make(map[string]int, len(a))
+	for _, id := range a {
+		seen[id]++
+	}
+	for _, id := range b {
+		if seen[id] == 0 {
+			return false
+		}
+		seen[id]--
+	}
+	return true
+}
+
+// buildLineItems itemizes seats, format surcharge, pricing rules, discounts, fees, tax, add-ons and loyalty points for the booking summary
+func (bs *BookingServiceImpl) buildLineItems(booking *models.Booking, show *models.Show, seats []*models.Seat) []LineItem {
+	items := make([]LineItem, 0, len(seats)+5)
+	for _, seat := range seats {
+		items = append(items, LineItem{
+			Description: fmt.Sprintf("Seat %s%d (%s)", seat.RowName, seat.Number, seat.Type),
+			Amount:      seat.GetPrice(),
+		})
+	}
+
+	if show.FormatSurcharge.IsPositive() {
+		items = append(items, LineItem{
+			Description: fmt.Sprintf("%s surcharge (%d seats)", show.Format, len(seats)),
+			Amount:      show.FormatSurcharge.Times(len(seats)),
+		})
+	}
+
+	// One line per pricing rule, totalled over the seats it applied to
+	var ruleNames []string
+	ruleTotals := make(map[string]models.Money)
+	for _, seat := range seats {
+		for _, adjustment := range bs.pricer.Quote(show, seat).Adjustments {
+			total, seen := ruleTotals[adjustment.Name]
+			if !seen {
+				ruleNames = append(ruleNames, adjustment.Name)
+				total = models.ZeroMoney(adjustment.Amount.Currency)
+			}
+			ruleTotals[adjustment.Name] = total.Add(adjustment.Amount)
+		}
+	}
+	for _, name := range ruleNames {
+		items = append(items, LineItem{Description: name, Amount: ruleTotals[name]})
+	}
+
+	if discount := booking.GetProfileDiscount(); discount != nil {
+		items = append(items, LineItem{
+			Description: fmt.Sprintf("Verified %s discount", strings.ToLower(strings.ReplaceAll(string(discount.Category), "_", " "))),
+			Amount:      models.ZeroMoney(discount.Amount.Currency).Sub(discount.Amount),
+		})
+	}
+
+	if booking.CouponCode != "" {
+		items = append(items, LineItem{
+			Description: fmt.Sprintf("Coupon %s", booking.CouponCode),
+			Amount:      models.ZeroMoney(booking.DiscountAmount.Currency).Sub(booking.DiscountAmount),
+		})
+	}
+
+	breakdown := booking.GetPriceBreakdown()
+	charges := []LineItem{
+		{Description: fmt.Sprintf("Convenience fee (%g%%)", breakdown.Fees.ConvenienceFeePercent), Amount: breakdown.ConvenienceFee},
+		{Description: fmt.Sprintf("CGST (%g%%)", breakdown.Fees.GSTPercent/2), Amount: breakdown.CGST},
+		{Description: fmt.Sprintf("SGST (%g%%)", breakdown.Fees.GSTPercent/2), Amount: breakdown.SGST},
+	}
+	for _, charge := range charges {
+		if charge.Amount.IsPositive() {
+			items = append(items, charge)
+		}
+	}
+
+	for _, addOn := range booking.GetAddOns() {
+		description := addOn.Description
+		if addOn.Quantity > 1 {
+			description = fmt.Sprintf("%s (%d x %s)", addOn.Description, addOn.Quantity, addOn.UnitPrice)
+		}
+		items = append(items, LineItem{Description: description, Amount: addOn.Amount})
+	}
+
+	if breakdown.LoyaltyPoints > 0 {
+		items = append(items, LineItem{
+			Description: fmt.Sprintf("Loyalty points (%d)", breakdown.LoyaltyPoints),
+			Amount:      models.ZeroMoney(breakdown.LoyaltyValue.Currency).Sub(breakdown.LoyaltyValue),
+		})
+	}
+	return items
+}
+
+// Helper method to rollback seat blocking - demonstrates Error Handling
+func (bs *BookingServiceImpl) rollbackSeatBlocking(show *models.Show, seatIDs []string) {
+	for _, seatID := range seatIDs {
+		show.UnblockSeat(seatID)
+	}
+}
+
+// cancelBooking cancels a booking inside txCtx's transaction: it saves the cancellation, frees the seats in
+// show's inventory and writes BookingCancelled to the outbox, and gives the coupon use back once the
+// transaction commits. A rollback puts the booking and its seats back; the caller saves show.
+func (bs *BookingServiceImpl) cancelBooking(txCtx context.Context, booking *models.Booking, show *models.Show) error {
+	if err := booking.Cancel(bs.clock.Now()); err != nil {
+		return err
+	}
+	repositories.OnRollback(txCtx, booking.RevertCancel)
+	if err := bs.bookingRepo.Update(txCtx, booking); err != nil {
+		return err
+	}
+
+	for _, seatID := range booking.SeatIDs {
+		bs.releaseSeat(txCtx, booking, show, seatID)
+	}
+
+	// Give the coupon use back
+	repositories.OnCommit(txCtx, func() { bs.releaseCoupon(repositories.OutsideTransaction(txCtx), booking) })
+
+	return bs.publishInTransaction(txCtx, events.BookingCancelled{
+		BookingID: booking.ID,
+		UserID:    booking.UserID,
+		ShowID:    booking.ShowID,
+		SeatIDs:   booking.SeatIDs,
+		Timestamp: bs.clock.Now(),
+	})
+}
+
+// releaseSeat makes one of a booking's seats available inside txCtx's transaction; a rollback takes it back
+func (bs *BookingServiceImpl) releaseSeat(txCtx context.Context, booking *models.Booking, show *models.Show, seatID string) {
+	status := show.SeatStatus(seatID)
+	if err := show.ReleaseSeat(seatID); err != nil {
+		bs.logger.Warn(txCtx, "failed to release seat", "booking_id", booking.ID, "seat_id", seatID, "error", err)
+		return
+	}
+	repositories.OnRollback(txCtx, func() { show.RestoreSeat(seatID, status) })
+}
+
+// refundUpgrade gives back an upgrade payment taken for a seat change that couldn't be saved
+func (bs *BookingServiceImpl) refundUpgrade(ctx context.Context, booking *models.Booking, payment *models.Payment) {
+	if payment == nil || bs.refundService == nil {
+		return
+	}
+	if _, err := bs.refundService.InitiateRefund(ctx, payment.ID, payment.Amount, "seat modification failed"); err != nil {
+		bs.logger.Warn(ctx, "failed to refund upgrade payment", "booking_id", booking.ID, "payment_id", payment.ID, "error", err)
+	}
+}
+
+// recordChange adds a booking change to the audit trail; its user is the actor unless the request has a caller
+func (bs *BookingServiceImpl) recordChange(ctx context.Context, booking *models.Booking, action models.AuditAction, details map[string]string) {
+	bs.audit.Record(ctx, AuditChange{
+		EntityType: models.AuditEntityBooking,
+		EntityID:   booking.ID,
+		Action:     action,
+		ActorID:    booking.UserID,
+		Details:    details,
+	})
+}
+
+// publishInTransaction writes event to the outbox inside txCtx's transaction, so subscribers hear of it once the
+// transaction commits and never if it rolls back. Unlike publish, a failed write fails the transaction.
+func (bs *BookingServiceImpl) publishInTransaction(txCtx context.Context, event events.Event) error {
+	if bs.eventBus == nil {
+		return nil
+	}
+	return bs.eventBus.Publish(txCtx, event)
+}
+
+// publish sends an event to subscribers; subscriber failures never fail the booking
+func (bs *BookingServiceImpl) publish(ctx context.Context, event events.Event) {
+	if bs.eventBus == nil {
+		return
+	}
+	if err := bs.eventBus.Publish(ctx, event); err != nil {
+		bs.logger.Warn(ctx, "failed to publish event", "event", event.Type(), "error", err)
+	}
 }
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/models"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TransferBooking offers a confirmed booking to another registered user, found by email, so a friend can go
+// in the owner's place. The booking stays the owner's until the recipient accepts; offering it again withdraws
+// the earlier offer. Refunds still go back to the payments the booking was bought with.
+func (bs *BookingServiceImpl) TransferBooking(ctx context.Context, bookingID, fromUserID, toUserEmail string) (*models.BookingTransfer, error) {
+	if bs.userRepo == nil {
+		return nil, fmt.Errorf("%w: transfers are not enabled", models.ErrInvalidTransfer)
+	}
+
+	booking, err := bs.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient, err := bs.userRepo.GetByEmail(ctx, strings.TrimSpace(toUserEmail))
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := bs.lockShow(ctx, booking.ShowID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Checked under the lock, as an accepted offer changes the owner
+	if booking.UserID != fromUserID {
+		return nil, models.ErrForbidden
+	}
+
+	show, err := bs.transferableShow(ctx, booking)
+	if err != nil {
+		return nil, err
+	}
+	if err := bs.validateRecipient(ctx, booking, show, recipient.ID); err != nil {
+		return nil, err
+	}
+
+	transfer, err := booking.OfferTransfer(recipient.ID, bs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := bs.bookingRepo.Update(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	bs.publish(ctx, events.BookingTransferOffered{
+		BookingID:  booking.ID,
+		Reference:  booking.Reference,
+		ShowID:     booking.ShowID,
+		FromUserID: transfer.FromUserID,
+		ToUserID:   transfer.ToUserID,
+		Timestamp:  bs.clock.Now(),
+	})
+	return &transfer, nil
+}
+
+// AcceptTransfer makes a booking offered to the user theirs. The ticket is signed again for them, so the
+// previous owner's QR code stops working.
+func (bs *BookingServiceImpl) AcceptTransfer(ctx context.Context, bookingID, userID string) (*models.Booking, error) {
+	booking, err := bs.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := bs.lockShow(ctx, booking.ShowID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if transfer, pending := booking.PendingTransfer(); !pending || transfer.ToUserID != userID {
+		return nil, models.ErrTransferNotFound
+	}
+
+	// The booking may have been cancelled, the show may have started or the recipient been blocked since the offer
+	show, err := bs.transferableShow(ctx, booking)
+	if err != nil {
+		return nil, err
+	}
+	if err := bs.validateRecipient(ctx, booking, show, userID); err != nil {
+		return nil, err
+	}
+
+	transfer, err := booking.AcceptTransfer(userID, bs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := bs.bookingRepo.Update(ctx, booking); err != nil {
+		return nil, err
+	}
+	bs.recordChange(ctx, booking, models.AuditBookingTransferred, map[string]string{
+		"from_user_id": transfer.FromUserID,
+		"to_user_id":   transfer.ToUserID,
+	})
+
+	bs.publish(ctx, events.BookingTransferred{
+		BookingID:  booking.ID,
+		Reference:  booking.Reference,
+		ShowID:     booking.ShowID,
+		FromUserID: transfer.FromUserID,
+		ToUserID:   transfer.ToUserID,
+		Timestamp:  bs.clock.Now(),
+	})
+	return booking, nil
+}
+
+// DeclineTransfer turns down a booking offered to the user; it stays with its owner
+func (bs *BookingServiceImpl) DeclineTransfer(ctx context.Context, bookingID, userID string) (*models.Booking, error) {
+	booking, err := bs.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := bs.lockShow(ctx, booking.ShowID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	transfer, err := booking.DeclineTransfer(userID, bs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := bs.bookingRepo.Update(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	bs.publish(ctx, events.BookingTransferDeclined{
+		BookingID:  booking.ID,
+		Reference:  booking.Reference,
+		ShowID:     booking.ShowID,
+		FromUserID: transfer.FromUserID,
+		ToUserID:   transfer.ToUserID,
+		Timestamp:  bs.clock.Now(),
+	})
+	return booking, nil
+}
+
+// GetIncomingTransfers lists the bookings offered to the user that are waiting for an answer
+func (bs *BookingServiceImpl) GetIncomingTransfers(ctx context.Context, userID string) ([]*models.Booking, error) {
+	return bs.bookingRepo.GetPendingTransfersTo(ctx, userID)
+}
+
+// transferableShow returns the booking's show if the booking can still change hands: not a corporate block,
+// before the show starts and before its ticket is scanned
+func (bs *BookingServiceImpl) transferableShow(ctx context.Context, booking *models.Booking) (*models.Show, error) {
+	if booking.BulkBookingID != "" {
+		return nil, fmt.Errorf("%w: corporate seats are handed out with their codes", models.ErrInvalidTransfer)
+	}
+	if booking.GetStatus() != models.BookingStatusConfirmed {
+		return nil, models.ErrBookingNotConfirmed
+	}
+
+	show, err := bs.showRepo.GetByID(ctx, booking.ShowID)
+	if err != nil {
+		return nil, err
+	}
+	if !bs.clock.Now().Before(show.StartTime) {
+		return nil, fmt.Errorf("%w: the show has started", models.ErrTransferClosed)
+	}
+
+	if bs.ticketRepo != nil {
+		if ticket, err := bs.ticketRepo.GetByBookingID(ctx, booking.ID); err == nil && ticket.GetStatus() == models.TicketStatusUsed {
+			return nil, fmt.Errorf("%w: the ticket has been scanned", models.ErrTransferClosed)
+		}
+	}
+	return show, nil
+}
+
+// validateRecipient holds the recipient to the rules the owner was held to when booking
+func (bs *BookingServiceImpl) validateRecipient(ctx context.Context, booking *models.Booking, show *models.Show, userID string) error {
+	return validateNewOwner(ctx, bs.userRepo, bs.movieRepo, booking, show, userID)
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BookingRequest is what CreateBooking validates before any seat is held
+type BookingRequest struct {
+	UserID  string
+	Show    *models.Show
+	Screen  *models.Screen
+	SeatIDs []string
+	HoldID  string    // Set when the seats come from the user's own hold, which already blocks them
+	Now     time.Time // When the booking is made; sale windows and show times are checked against it
+
+	WithGuardian  bool   // The viewer comes with an adult guardian, which waives the age certificate
+	BulkBookingID string // Set for a corporate block, which the per-user limits and age certificate don't cover
+}
+
+// BookingValidator checks one rule a new booking must pass - demonstrates Chain of Responsibility Pattern:
+// each validator either rejects the request or passes it down the chain
+type BookingValidator interface {
+	Name() string
+	Validate(ctx context.Context, req *BookingRequest) error
+}
+
+// BookingValidationError names the validator that rejected a booking; errors.Is still sees the domain error
+type BookingValidationError struct {
+	Validator string
+	Err       error
+}
+
+func (e *BookingValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BookingValidationError) Unwrap() error {
+	return e.Err
+}
+
+// BookingValidatorChain runs validators in order and stops at the first rejection.
+// Validators can be added and removed while the service is running.
+type BookingValidatorChain struct {
+	validators []BookingValidator
+	mutex      sync.RWMutex
+}
+
+// NewBookingValidatorChain creates a chain of the given validators, in order
+func NewBookingValidatorChain(validators ...BookingValidator) *BookingValidatorChain {
+	chain := &BookingValidatorChain{}
+	for _, validator := range validators {
+		chain.Add(validator)
+	}
+	return chain
+}
+
+// DefaultBookingValidators is the standard chain. Validators whose dependencies are nil are left out,
+// so nil rules drop the booking limits.
+func DefaultBookingValidators(userRepo repositories.UserRepository, movieRepo repositories.MovieRepository, rules BookingRules) []BookingValidator {
+	validators := []BookingValidator{ShowBookableValidator{}}
+	if userRepo != nil {
+		validators = append(validators, UserNotBlockedValidator{userRepo: userRepo})
+		if movieRepo != nil {
+			validators = append(validators, AgeRatingValidator{userRepo: userRepo, movieRepo: movieRepo})
+		}
+	}
+	validators = append(validators, SeatsAvailableValidator{}, CompanionSeatsValidator{})
+	if rules != nil {
+		validators = append(validators, BookingLimitsValidator{rules: rules})
+	}
+	return validators
+}
+
+// validateNewOwner holds someone taking over a booking - by transfer or resale - to the rules its owner was held
+// to when booking: not blocked, and old enough for the movie unless the booking comes with a guardian
+func validateNewOwner(ctx context.Context, userRepo repositories.UserRepository, movieRepo repositories.MovieRepository, booking *models.Booking, show *models.Show, userID string) error {
+	validators := NewBookingValidatorChain(
+		UserNotBlockedValidator{userRepo: userRepo},
+		AgeRatingValidator{userRepo: userRepo, movieRepo: movieRepo},
+	)
+	return validators.Validate(ctx, &BookingRequest{UserID: userID, Show: show, WithGuardian: booking.WithGuardian})
+}
+
+// Add appends a validator to the end of the chain; a name can only be added once
+func (c *BookingValidatorChain) Add(validator BookingValidator) error {
+	if validator == nil || validator.Name() == "" {
+		return fmt.Errorf("a booking validator needs a name")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, existing := range c.validators {
+		if existing.Name() == validator.Name() {
+			return fmt.Errorf("booking validator %q is already in the chain", validator.Name())
+		}
+	}
+	c.validators = append(c.validators, validator)
+	return nil
+}
+
+// Remove takes a validator out of the chain, reporting whether it was there
+func (c *BookingValidatorChain) Remove(name string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, validator := range c.validators {
+		if validator.Name() == name {
+			c.validators = append(c.validators[:i:i], c.validators[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Names lists the validators in the order they run
+func (c *BookingValidatorChain) Names() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	names := make([]string, len(c.validators))
+	for i, validator := range c.validators {
+		names[i] = validator.Name()
+	}
+	return names
+}
+
+// Validate passes the request down the chain, returning the first rejection as a *BookingValidationError
+func (c *BookingValidatorChain) Validate(ctx context.Context, req *BookingRequest) error {
+	c.mutex.RLock()
+	validators := c.validators
+	c.mutex.RUnlock()
+
+	for _, validator := range validators {
+		if err := validator.Validate(ctx, req); err != nil {
+			return &BookingValidationError{Validator: validator.Name(), Err: err}
+		}
+	}
+	return nil
+}
+
+// ShowBookableValidator rejects shows not on sale yet, cancelled or started shows and screens under maintenance
+type ShowBookableValidator struct{}
+
+func (ShowBookableValidator) Name() string { return "show-bookable" }
+
+func (ShowBookableValidator) Validate(ctx context.Context, req *BookingRequest) error {
+	if !req.Show.IsOnSale(req.Now) {
+		return fmt.Errorf("%w: bookings open at %s", models.ErrShowNotOnSale, req.Show.SaleOpensAt().Format(time.RFC3339))
+	}
+	if !req.Show.CanBeBooked(req.Now) {
+		return models.ErrShowNotBookable
+	}
+	if !req.Screen.IsOperational() {
+		return models.ErrScreenUnderMaintenance
+	}
+	return nil
+}
+
+// UserNotBlockedValidator rejects users an admin has blocked
+type UserNotBlockedValidator struct {
+	userRepo repositories.UserRepository
+}
+
+func (UserNotBlockedValidator) Name() string { return "user-not-blocked" }
+
+func (v UserNotBlockedValidator) Validate(ctx context.Context, req *BookingRequest) error {
+	user, err := v.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if user.Blocked {
+		return models.ErrUserBlocked
+	}
+	return nil
+}
+
+// AgeRatingValidator rejects viewers too young for the movie's certificate, judged on the show's day, with
+// an *models.AgeRestrictionError. Users without a date of birth can't book age-rated movies unless a guardian
+// comes along; live events aren't rated.
+type AgeRatingValidator struct {
+	userRepo  repositories.UserRepository
+	movieRepo repositories.MovieRepository
+}
+
+func (AgeRatingValidator) Name() string { return "age-rating" }
+
+func (v AgeRatingValidator) Validate(ctx context.Context, req *BookingRequest) error {
+	if !req.Show.IsMovie() || req.WithGuardian || req.BulkBookingID != "" {
+		return nil
+	}
+
+	movie, err := v.movieRepo.GetByID(ctx, req.Show.MovieID)
+	if err != nil {
+		return err
+	}
+	minimumAge := movie.Certificate.MinimumAge()
+	if minimumAge == 0 {
+		return nil
+	}
+
+	user, err := v.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	age, known := user.AgeOn(req.Show.StartTime)
+	if !known || age < minimumAge {
+		return &models.AgeRestrictionError{Certificate: movie.Certificate, MinimumAge: minimumAge, Age: age, AgeKnown: known}
+	}
+	return nil
+}
+
+// SeatsAvailableValidator rejects unknown seats, seats the theatre holds back for the show and, unless they come
+// from the user's hold, seats that aren't free. It fails fast; blocking the seats under the show lock stays the
+// authoritative check.
+type SeatsAvailableValidator struct{}
+
+func (SeatsAvailableValidator) Name() string { return "seats-available" }
+
+func (SeatsAvailableValidator) Validate(ctx context.Context, req *BookingRequest) error {
+	for _, seatID := range req.SeatIDs {
+		if _, err := req.Screen.GetSeat(seatID); err != nil {
+			return err
+		}
+		if req.HoldID == "" && !req.Show.IsSeatAvailable(seatID) {
+			return models.ErrSeatNotAvailable
+		}
+	}
+	return req.Show.CheckSeatsOnSale(req.SeatIDs)
+}
+
+// CompanionSeatsValidator rejects companion seats booked without the wheelchair space beside them
+type CompanionSeatsValidator struct{}
+
+func (CompanionSeatsValidator) Name() string { return "companion-seats" }
+
+func (CompanionSeatsValidator) Validate(ctx context.Context, req *BookingRequest) error {
+	return req.Screen.ValidateCompanionSeats(req.SeatIDs)
+}
+
+// BookingLimitsValidator applies the anti-hoarding BookingRules; the show lock keeps per-show totals exact
+type BookingLimitsValidator struct {
+	rules BookingRules
+}
+
+func (BookingLimitsValidator) Name() string { return "booking-limits" }
+
+func (v BookingLimitsValidator) Validate(ctx context.Context, req *BookingRequest) error {
+	if req.BulkBookingID != "" {
+		return nil
+	}
+	return v.rules.CheckNewBooking(ctx, req.UserID, req.Show, len(req.SeatIDs))
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"strings"
+)
+
+// bulkLockOwner namespaces per-block locks, which keep a redemption and a release from picking the same code
+const bulkLockOwner = "bulk"
+
+// BulkBookingServiceImpl implements BulkBookingService - corporate blocks ride on an ordinary booking, so
+// payment, tickets, refunds and the seat map work for them unchanged; the block adds the codes on top
+type BulkBookingServiceImpl struct {
+	bulkRepo       repositories.BulkBookingRepository
+	userRepo       repositories.UserRepository
+	movieRepo      repositories.MovieRepository
+	showRepo       repositories.ShowRepository
+	screenRepo     repositories.ScreenRepository
+	bookingService BookingService
+	authorizer     Authorizer
+	lockManager    locks.LockManager
+	clock          clock.Clock
+}
+
+func NewBulkBookingService(
+	bulkRepo repositories.BulkBookingRepository,
+	userRepo repositories.UserRepository,
+	movieRepo repositories.MovieRepository,
+	showRepo repositories.ShowRepository,
+	screenRepo repositories.ScreenRepository,
+	bookingService BookingService,
+	authorizer Authorizer,
+	lockManager locks.LockManager,
+	clock clock.Clock,
+) BulkBookingService {
+	return &BulkBookingServiceImpl{
+		bulkRepo:       bulkRepo,
+		userRepo:       userRepo,
+		movieRepo:      movieRepo,
+		showRepo:       showRepo,
+		screenRepo:     screenRepo,
+		bookingService: bookingService,
+		authorizer:     authorizer,
+		lockManager:    lockManager,
+		clock:          clock,
+	}
+}
+
+// ReserveBlock books the requested seats for the account in one pending booking and issues a code per seat.
+// The codes can be redeemed once the account pays for the booking.
+func (bs *BulkBookingServiceImpl) ReserveBlock(ctx context.Context, accountID, showID string, request BulkBlockRequest) (*BulkBookingDetails, error) {
+	if _, err := bs.authorizer.Authorize(ctx, accountID, models.PermissionBulkBook, ""); err != nil {
+		return nil, err
+	}
+
+	show, err := bs.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	if !show.CanBeBooked(bs.clock.Now()) {
+		return nil, models.ErrShowNotBookable
+	}
+
+	deadline := request.ReleaseDeadline
+	if deadline.IsZero() {
+		deadline = show.StartTime.Add(-models.DefaultBulkReleaseNotice)
+	}
+	if !deadline.Before(show.StartTime) {
+		return nil, models.ErrInvalidBulkBooking
+	}
+
+	seats, err := bs.pickSeats(ctx, show, request)
+	if err != nil {
+		return nil, err
+	}
+
+	bulk, err := models.NewBulkBooking(accountID, request.Organization, showID, seats, deadline, bs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	seatIDs := make([]string, 0, len(seats))
+	for _, seat := range seats {
+		seatIDs = append(seatIDs, seat.ID)
+	}
+	booking, err := bs.bookingService.CreateBooking(ctx, accountID, showID, seatIDs, WithBulkBooking(bulk.ID))
+	if err != nil {
+		return nil, err
+	}
+	bulk.SetBooking(booking.ID, bs.clock.Now())
+
+	if err := bs.bulkRepo.Create(ctx, bulk); err != nil {
+		// Without the block nobody could redeem the seats, so give them back
+		bs.bookingService.CancelBooking(ctx, booking.ID)
+		return nil, err
+	}
+	return bs.details(ctx, bulk)
+}
+
+// GetBulkBooking returns one of the account's blocks with its codes and booking
+func (bs *BulkBookingServiceImpl) GetBulkBooking(ctx context.Context, accountID, bulkBookingID string) (*BulkBookingDetails, error) {
+	bulk, err := bs.getOwned(ctx, accountID, bulkBookingID)
+	if err != nil {
+		return nil, err
+	}
+	return bs.details(ctx, bulk)
+}
+
+// GetAccountBulkBookings lists the account's blocks, newest first
+func (bs *BulkBookingServiceImpl) GetAccountBulkBookings(ctx context.Context, accountID string) ([]*BulkBookingDetails, error) {
+	blocks, err := bs.bulkRepo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*BulkBookingDetails, 0, len(blocks))
+	for _, bulk := range blocks {
+		details, err := bs.details(ctx, bulk)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, details)
+	}
+	return list, nil
+}
+
+// RedeemCode gives an employee the code's seat. The block must be paid for and the show still ahead, and the
+// employee must be old enough for the movie - the block itself skipped that check.
+func (bs *BulkBookingServiceImpl) RedeemCode(ctx context.Context, userID, code string) (*BulkPass, error) {
+	bulk, err := bs.bulkRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := bs.lockManager.Lock(ctx, locks.BookingKey(bulkLockOwner, bulk.ID))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	booking, err := bs.bookingService.GetBooking(ctx, bulk.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.GetStatus() != models.BookingStatusConfirmed {
+		return nil, models.ErrBookingNotConfirmed
+	}
+
+	show, err := bs.showRepo.GetByID(ctx, bulk.ShowID)
+	if err != nil {
+		return nil, err
+	}
+	if !show.IsUpcoming(bs.clock.Now()) {
+		return nil, models.ErrShowNotBookable
+	}
+
+	user, err := bs.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Blocked {
+		return nil, models.ErrUserBlocked
+	}
+	if err := bs.checkAge(ctx, user, show); err != nil {
+		return nil, err
+	}
+
+	redeemed, err := bulk.Redeem(code, userID, bs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := bs.bulkRepo.Update(ctx, bulk); err != nil {
+		return nil, err
+	}
+
+	return &BulkPass{
+		Code:             redeemed,
+		Organization:     bulk.Organization,
+		BookingReference: booking.Reference,
+		Show:             show,
+	}, nil
+}
+
+// ReleaseSeats hands unredeemed seats back to the show before the block's deadline. The booking shrinks to the
+// seats kept and, once paid for, the difference is refunded. No codes releases every unredeemed seat.
+func (bs *BulkBookingServiceImpl) ReleaseSeats(ctx context.Context, accountID, bulkBookingID string, codes []string) (*BulkRelease, error) {
+	bulk, err := bs.getOwned(ctx, accountID, bulkBookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := bs.lockManager.Lock(ctx, locks.BookingKey(bulkLockOwner, bulk.ID))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	released, kept, err := bulk.PlanRelease(codes, bs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	modification, err := bs.bookingService.ModifySeats(ctx, bulk.BookingID, kept)
+	if err != nil && modification == nil {
+		return nil, err
+	}
+
+	// The seats are gone from the booking even when a refund failed, so the codes go with them
+	bulk.MarkReleased(released, bs.clock.Now())
+	if updateErr := bs.bulkRepo.Update(ctx, bulk); updateErr != nil {
+		return nil, updateErr
+	}
+
+	details, detailsErr := bs.details(ctx, bulk)
+	if detailsErr != nil {
+		return nil, detailsErr
+	}
+	return &BulkRelease{
+		BulkBooking:     details,
+		Released:        released,
+		PriceDifference: modification.PriceDifference,
+		Refunds:         modification.Refunds,
+	}, err
+}
+
+// pickSeats resolves the request to seats: the exact seats asked for, or the first free seats of the requested
+// rows in the order given. Like SuggestSeats it reads the show's seat status, which CreateBooking checks, skips
+// seats held back for the show and leaves accessible and grouped seats for those who need them.
+func (bs *BulkBookingServiceImpl) pickSeats(ctx context.Context, show *models.Show, request BulkBlockRequest) ([]models.BulkSeat, error) {
+	screen, err := bs.screenRepo.GetByID(ctx, show.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+	seatMap := show.SeatMap(screen)
+
+	if len(request.SeatIDs) > 0 {
+		labels := make(map[string]string)
+		for _, row := range seatMap.Rows {
+			for _, cell := range row.Cells {
+				labels[cell.SeatID] = cell.Label
+			}
+		}
+
+		seats := make([]models.BulkSeat, 0, len(request.SeatIDs))
+		seen := make(map[string]bool)
+		for _, seatID := range request.SeatIDs {
+			label, ok := labels[seatID]
+			if !ok || seatID == "" {
+				return nil, models.ErrSeatNotFound
+			}
+			if !seen[seatID] {
+				seen[seatID] = true
+				seats = append(seats, models.BulkSeat{ID: seatID, Label: label})
+			}
+		}
+		return seats, nil
+	}
+
+	if request.Seats <= 0 || request.Seats > models.MaxBulkBookingSeats {
+		return nil, models.ErrInvalidBulkBooking
+	}
+
+	rows := seatMap.Rows
+	if len(request.Rows) > 0 {
+		byName := make(map[string]models.SeatMapRow, len(seatMap.Rows))
+		for _, row := range seatMap.Rows {
+			byName[strings.ToUpper(row.Name)] = row
+		}
+		rows = make([]models.SeatMapRow, 0, len(request.Rows))
+		for _, name := range request.Rows {
+			row, ok := byName[strings.ToUpper(strings.TrimSpace(name))]
+			if !ok {
+				return nil, models.ErrSeatNotFound
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	seats := make([]models.BulkSeat, 0, request.Seats)
+	for _, row := range rows {
+		for _, cell := range row.Cells {
+			if !cell.IsSeat() || cell.Status != models.SeatStatusAvailable || cell.Group != "" || isAccessible(cell) {
+				continue
+			}
+			seats = append(seats, models.BulkSeat{ID: cell.SeatID, Label: cell.Label})
+			if len(seats) == request.Seats {
+				return seats, nil
+			}
+		}
+	}
+	return nil, models.ErrInsufficientSeats
+}
+
+// checkAge applies the movie's certificate to the employee, as AgeRatingValidator does for a booking
+func (bs *BulkBookingServiceImpl) checkAge(ctx context.Context, user *models.User, show *models.Show) error {
+	if !show.IsMovie() {
+		return nil
+	}
+
+	movie, err := bs.movieRepo.GetByID(ctx, show.MovieID)
+	if err != nil {
+		return err
+	}
+	minimumAge := movie.Certificate.MinimumAge()
+	if minimumAge == 0 {
+		return nil
+	}
+
+	age, known := user.AgeOn(show.StartTime)
+	if !known || age < minimumAge {
+		return &models.AgeRestrictionError{Certificate: movie.Certificate, MinimumAge: minimumAge, Age: age, AgeKnown: known}
+	}
+	return nil
+}
+
+func (bs *BulkBookingServiceImpl) getOwned(ctx context.Context, accountID, bulkBookingID string) (*models.BulkBooking, error) {
+	bulk, err := bs.bulkRepo.GetByID(ctx, bulkBookingID)
+	if err != nil {
+		return nil, err
+	}
+	if bulk.AccountID != accountID {
+		return nil, models.ErrUnauthorized
+	}
+	return bulk, nil
+}
+
+func (bs *BulkBookingServiceImpl) details(ctx context.Context, bulk *models.BulkBooking) (*BulkBookingDetails, error) {
+	booking, err := bs.bookingService.GetBooking(ctx, bulk.BookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	tally := bulk.Tally()
+	return &BulkBookingDetails{
+		BulkBooking: bulk,
+		Booking:     booking,
+		Issued:      tally[models.BulkCodeStatusIssued],
+		Redeemed:    tally[models.BulkCodeStatusRedeemed],
+		Released:    tally[models.BulkCodeStatusReleased],
+	}, nil
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// CatalogImporterImpl implements CatalogImporter over a MovieSource - demonstrates Adapter Pattern:
+// the importer only sees CatalogMovie, whichever API or file the listings came from
+type CatalogImporterImpl struct {
+	source     MovieSource
+	movieRepo  repositories.MovieRepository
+	authorizer Authorizer
+	mutex      sync.Mutex // One import at a time, so two runs can't both create the same movie
+	clock      clock.Clock
+}
+
+func NewCatalogImporter(source MovieSource, movieRepo repositories.MovieRepository, authorizer Authorizer, clock clock.Clock) CatalogImporter {
+	return &CatalogImporterImpl{
+		source:     source,
+		movieRepo:  movieRepo,
+		authorizer: authorizer,
+		clock:      clock,
+	}
+}
+
+// Import fetches every listing and upserts it by external ID - super admins only.
+// Listings that fail validation are skipped and reported rather than failing the whole run.
+func (ci *CatalogImporterImpl) Import(ctx context.Context) (*CatalogImport, error) {
+	if _, err := ci.authorizer.AuthorizeCaller(ctx, models.PermissionManageCatalog, ""); err != nil {
+		return nil, err
+	}
+
+	ci.mutex.Lock()
+	defer ci.mutex.Unlock()
+
+	listings, err := ci.source.FetchMovies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CatalogImport{Source: ci.source.Name()}
+	for _, listing := range listings {
+		created, err := ci.upsert(ctx, listing)
+		switch {
+		case errors.Is(err, models.ErrInvalidMovieData):
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s %q: %v", listing.ExternalID, listing.Title, err))
+		case err != nil:
+			return result, err
+		case created:
+			result.Created++
+		default:
+			result.Updated++
+		}
+	}
+	return result, nil
+}
+
+// upsert creates the listing's movie or refreshes the one imported earlier, reporting which it did
+func (ci *CatalogImporterImpl) upsert(ctx context.Context, listing CatalogMovie) (bool, error) {
+	if listing.ExternalID == "" || len(listing.Genres) == 0 {
+		return false, fmt.Errorf("%w: listing needs an external ID and a known genre", models.ErrInvalidMovieData)
+	}
+
+	imported, err := models.NewMovie(listing.Title, listing.Overview, listing.Runtime, listing.Genres[0], listing.Language, listing.Rating, listing.ReleaseDate, ci.clock.Now())
+	if err != nil {
+		return false, err
+	}
+	if !listing.Certificate.Valid() {
+		return false, fmt.Errorf("%w: %q", models.ErrInvalidCertificate, listing.Certificate)
+	}
+	imported.Genres = listing.Genres
+	imported.Certificate = listing.Certificate
+	imported.PosterURL = listing.PosterURL
+	imported.ExternalID = listing.ExternalID
+
+	existing, err := ci.movieRepo.GetByExternalID(ctx, listing.ExternalID)
+	if errors.Is(err, models.ErrMovieNotFound) {
+		return true, ci.movieRepo.Create(ctx, imported)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	existing.ApplyCatalog(imported, ci.clock.Now())
+	return false, ci.movieRepo.Update(ctx, existing)
+}
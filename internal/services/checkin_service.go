@@ -0,0 +1,178 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+)
+
+// CheckInServiceImpl implements CheckInService - theatres scan tickets at entry
+type CheckInServiceImpl struct {
+	ticketRepo  repositories.TicketRepository
+	bookingRepo repositories.BookingRepository
+	showRepo    repositories.ShowRepository
+	movieRepo   repositories.MovieRepository
+	eventRepo   repositories.EventRepository
+	screenRepo  repositories.ScreenRepository
+	authorizer  Authorizer // Only the theatre's own staff scan its tickets
+	signer      *ticketSigner
+	clock       clock.Clock
+}
+
+// NewCheckInService creates a check-in service; signingKey must match the TicketService's
+func NewCheckInService(
+	ticketRepo repositories.TicketRepository,
+	bookingRepo repositories.BookingRepository,
+	showRepo repositories.ShowRepository,
+	movieRepo repositories.MovieRepository,
+	eventRepo repositories.EventRepository,
+	screenRepo repositories.ScreenRepository,
+	authorizer Authorizer,
+	signingKey []byte,
+	clock clock.Clock,
+) CheckInService {
+	return &CheckInServiceImpl{
+		ticketRepo:  ticketRepo,
+		bookingRepo: bookingRepo,
+		showRepo:    showRepo,
+		movieRepo:   movieRepo,
+		eventRepo:   eventRepo,
+		screenRepo:  screenRepo,
+		authorizer:  authorizer,
+		signer:      newTicketSigner(signingKey),
+		clock:       clock,
+	}
+}
+
+// ValidateTicket checks a scanned payload without admitting the holder
+func (cs *CheckInServiceImpl) ValidateTicket(ctx context.Context, theatreID, payload string) (*TicketValidation, error) {
+	ticket, show, err := cs.resolve(ctx, theatreID, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ticket.GetStatus() {
+	case models.TicketStatusUsed:
+		return nil, models.ErrTicketAlreadyUsed
+	case models.TicketStatusVoid:
+		return nil, models.ErrTicketVoid
+	}
+
+	return cs.describe(ctx, ticket, show)
+}
+
+// CheckIn validates a scanned payload and marks the ticket used, preventing double entry
+func (cs *CheckInServiceImpl) CheckIn(ctx context.Context, theatreID, gate, payload string) (*TicketValidation, error) {
+	ticket, show, err := cs.resolve(ctx, theatreID, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Atomic on the ticket - of two simultaneous scans only one succeeds
+	if err := ticket.CheckIn(gate, cs.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := cs.ticketRepo.Update(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	return cs.describe(ctx, ticket, show)
+}
+
+// resolve verifies the signature and loads the ticket, rejecting other venues, cancelled bookings and finished shows.
+// The caller recorded by WithCaller must manage the theatre.
+func (cs *CheckInServiceImpl) resolve(ctx context.Context, theatreID, payload string) (*models.Ticket, *models.Show, error) {
+	if _, err := cs.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, theatreID); err != nil {
+		return nil, nil, err
+	}
+
+	claims, err := cs.signer.verify(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ticket, err := cs.ticketRepo.GetByID(ctx, claims.TicketID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The stored payload must match - a re-signed ticket invalidates old QR codes
+	if ticket.Payload != payload {
+		return nil, nil, models.ErrInvalidTicket
+	}
+
+	if ticket.TheatreID != theatreID {
+		return nil, nil, models.ErrTicketWrongVenue
+	}
+
+	booking, err := cs.bookingRepo.GetByID(ctx, ticket.BookingID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if booking.GetStatus() != models.BookingStatusConfirmed {
+		return nil, nil, models.ErrTicketVoid
+	}
+
+	show, err := cs.showRepo.GetByID(ctx, ticket.ShowID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if show.IsCompleted(cs.clock.Now()) {
+		return nil, nil, models.ErrTicketExpired
+	}
+
+	return ticket, show, nil
+}
+
+// describe builds what the gate staff sees after a scan
+func (cs *CheckInServiceImpl) describe(ctx context.Context, ticket *models.Ticket, show *models.Show) (*TicketValidation, error) {
+	booking, err := cs.bookingRepo.GetByID(ctx, ticket.BookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := cs.title(ctx, show)
+	if err != nil {
+		return nil, err
+	}
+
+	screen, err := cs.screenRepo.GetByID(ctx, show.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Seats come from the booking, so seat changes after issue are reflected
+	seats := make([]string, 0, len(booking.SeatIDs))
+	for _, seatID := range booking.SeatIDs {
+		if seat, err := screen.GetSeat(seatID); err == nil {
+			seats = append(seats, seat.GetSeatNumber())
+		}
+	}
+
+	return &TicketValidation{
+		Ticket:     ticket,
+		Title:      title,
+		ScreenName: screen.Name,
+		ShowStart:  show.StartTime,
+		Seats:      seats,
+	}, nil
+}
+
+// title names the movie or live event the show is for
+func (cs *CheckInServiceImpl) title(ctx context.Context, show *models.Show) (string, error) {
+	if show.IsMovie() {
+		movie, err := cs.movieRepo.GetByID(ctx, show.MovieID)
+		if err != nil {
+			return "", err
+		}
+		return movie.Title, nil
+	}
+
+	event, err := cs.eventRepo.GetByID(ctx, show.EventID)
+	if err != nil {
+		return "", err
+	}
+	return event.Title, nil
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DealsServiceImpl implements DealsService. A worker calls CreateDeals to look at the shows starting within the
+// deal window; every one still below the occupancy threshold gets a deal until it starts, and DealCreated is
+// published. Deals are kept in memory: after a restart the next run makes them again for shows still short of
+// seats.
+type DealsServiceImpl struct {
+	showRepo    repositories.ShowRepository
+	theatreRepo repositories.TheatreRepository
+	showService ShowService // Counts the seats taken
+	eventBus    events.EventBus
+	config      models.DealsConfig
+	logger      logging.Logger
+	clock       clock.Clock
+
+	creating sync.Mutex // One run at a time
+	mutex    sync.RWMutex
+	deals    map[string]*models.Deal // By show ID
+}
+
+func NewDealsService(showRepo repositories.ShowRepository, theatreRepo repositories.TheatreRepository, showService ShowService, eventBus events.EventBus, config models.DealsConfig, logger logging.Logger, clk clock.Clock) DealsService {
+	return &DealsServiceImpl{
+		showRepo:    showRepo,
+		theatreRepo: theatreRepo,
+		showService: showService,
+		eventBus:    eventBus,
+		config:      config,
+		logger:      logger,
+		clock:       clk,
+		deals:       make(map[string]*models.Deal),
+	}
+}
+
+// CreateDeals gives a deal to every bookable show starting within the window with fewer than MaxOccupancy
+// percent of its seats on sale taken. A show keeps its deal until it starts, even if it fills up, so the price
+// doesn't flip back and forth; deals of cancelled shows are dropped. Nothing happens while deals are off.
+func (ds *DealsServiceImpl) CreateDeals(ctx context.Context) ([]*models.Deal, error) {
+	if ds.config.Percent <= 0 || ds.config.Window <= 0 {
+		return nil, nil
+	}
+
+	ds.creating.Lock()
+	defer ds.creating.Unlock()
+
+	now := ds.clock.Now()
+	ds.dropEnded(now)
+
+	theatres, err := ds.theatreRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []*models.Deal
+	var errs []error
+	for _, theatre := range theatres {
+		shows, err := ds.showRepo.GetByTheatreBetween(ctx, theatre.ID, now, now.Add(ds.config.Window))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, show := range shows {
+			if !show.CanBeBooked(now) {
+				ds.drop(show.ID)
+				continue
+			}
+			if _, ok := ds.ActiveDeal(show.ID); ok {
+				continue
+			}
+
+			summary, err := ds.showService.GetAvailabilitySummary(ctx, show.ID)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			occupancy, ok := summary.Occupancy()
+			if !ok || occupancy >= ds.config.MaxOccupancy {
+				continue
+			}
+
+			deal := models.NewDeal(show, theatre.City, ds.config.Percent, occupancy, now)
+			ds.mutex.Lock()
+			ds.deals[show.ID] = deal
+			ds.mutex.Unlock()
+			created = append(created, deal)
+			ds.publish(ctx, deal)
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+// ActiveDeal returns the deal running on the show now, if any
+func (ds *DealsServiceImpl) ActiveDeal(showID string) (*models.Deal, bool) {
+	ds.mutex.RLock()
+	defer ds.mutex.RUnlock()
+
+	deal, ok := ds.deals[showID]
+	if !ok || !deal.IsActive(ds.clock.Now()) {
+		return nil, false
+	}
+	return deal, true
+}
+
+// GetActiveDeals lists the deals running now on shows in the city, case-insensitive, soonest show first
+func (ds *DealsServiceImpl) GetActiveDeals(ctx context.Context, city string) ([]*models.Deal, error) {
+	now := ds.clock.Now()
+
+	ds.mutex.RLock()
+	deals := make([]*models.Deal, 0, len(ds.deals))
+	for _, deal := range ds.deals {
+		if deal.IsActive(now) && (city == "" || strings.EqualFold(deal.City, city)) {
+			deals = append(deals, deal)
+		}
+	}
+	ds.mutex.RUnlock()
+
+	slices.SortFunc(deals, func(a, b *models.Deal) int {
+		if c := a.EndsAt.Compare(b.EndsAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ShowID, b.ShowID)
+	})
+	return deals, nil
+}
+
+// dropEnded forgets deals whose show has started
+func (ds *DealsServiceImpl) dropEnded(now time.Time) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	for showID, deal := range ds.deals {
+		if !now.Before(deal.EndsAt) {
+			delete(ds.deals, showID)
+		}
+	}
+}
+
+func (ds *DealsServiceImpl) drop(showID string) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	delete(ds.deals, showID)
+}
+
+func (ds *DealsServiceImpl) publish(ctx context.Context, deal *models.Deal) {
+	if ds.eventBus == nil {
+		return
+	}
+	event := events.DealCreated{
+		DealID:    deal.ID,
+		ShowID:    deal.ShowID,
+		TheatreID: deal.TheatreID,
+		City:      deal.City,
+		Percent:   deal.Percent,
+		Occupancy: deal.Occupancy,
+		EndsAt:    deal.EndsAt,
+		Timestamp: deal.StartsAt,
+	}
+	if err := ds.eventBus.Publish(ctx, event); err != nil {
+		ds.logger.Warn(ctx, "failed to publish event", "event", event.Type(), "error", err)
+	}
+}
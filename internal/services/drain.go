@@ -0,0 +1,152 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"sync"
+)
+
+// InFlight counts the bookings and payments under way so shutdown can wait for them. Once it starts draining it
+// turns new checkouts away with models.ErrShuttingDown, but still lets existing bookings be paid for, confirmed
+// or cancelled, so no customer is charged for a booking the process then refuses to confirm.
+type InFlight struct {
+	mutex    sync.Mutex
+	draining bool
+	calls    int
+	idle     chan struct{} // Closed once draining with no calls left
+	idled    bool
+}
+
+func NewInFlight() *InFlight {
+	return &InFlight{idle: make(chan struct{})}
+}
+
+// begin admits a call that starts new work, refusing it once draining; the returned func ends the call
+func (f *InFlight) begin() (func(), error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.draining {
+		return nil, models.ErrShuttingDown
+	}
+	f.calls++
+	return f.done, nil
+}
+
+// join admits a call that finishes work already started, even while draining; the returned func ends the call
+func (f *InFlight) join() func() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.calls++
+	return f.done
+}
+
+func (f *InFlight) done() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.calls--
+	f.signalIdle()
+}
+
+// signalIdle closes idle once draining leaves no calls; callers must hold the mutex
+func (f *InFlight) signalIdle() {
+	if f.draining && f.calls == 0 && !f.idled {
+		f.idled = true
+		close(f.idle)
+	}
+}
+
+// Drain stops admitting new work and waits for the calls under way to finish, or for ctx to be done
+func (f *InFlight) Drain(ctx context.Context) error {
+	f.mutex.Lock()
+	f.draining = true
+	f.signalIdle()
+	f.mutex.Unlock()
+
+	select {
+	case <-f.idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// DrainingBookingService counts the booking calls under way so shutdown doesn't cut one off halfway, and refuses
+// new bookings once shutdown has begun - demonstrates Decorator Pattern. Confirming, cancelling, extending and
+// modifying existing bookings is still allowed while draining.
+type DrainingBookingService struct {
+	BookingService
+	inFlight *InFlight
+}
+
+// NewDrainingBookingService wraps bookingService with inFlight's admission
+func NewDrainingBookingService(bookingService BookingService, inFlight *InFlight) BookingService {
+	return &DrainingBookingService{BookingService: bookingService, inFlight: inFlight}
+}
+
+func (s *DrainingBookingService) CreateBooking(ctx context.Context, userID, showID string, seatIDs []string, opts ...BookingOption) (*models.Booking, error) {
+	done, err := s.inFlight.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return s.BookingService.CreateBooking(ctx, userID, showID, seatIDs, opts...)
+}
+
+func (s *DrainingBookingService) ConfirmBooking(ctx context.Context, bookingID, paymentID string) error {
+	defer s.inFlight.join()()
+	return s.BookingService.ConfirmBooking(ctx, bookingID, paymentID)
+}
+
+func (s *DrainingBookingService) CancelBooking(ctx context.Context, bookingID string) error {
+	defer s.inFlight.join()()
+	return s.BookingService.CancelBooking(ctx, bookingID)
+}
+
+func (s *DrainingBookingService) ExtendHold(ctx context.Context, bookingID, userID string) (*models.Booking, error) {
+	defer s.inFlight.join()()
+	return s.BookingService.ExtendHold(ctx, bookingID, userID)
+}
+
+func (s *DrainingBookingService) ModifySeats(ctx context.Context, bookingID string, newSeatIDs []string) (*SeatModification, error) {
+	defer s.inFlight.join()()
+	return s.BookingService.ModifySeats(ctx, bookingID, newSeatIDs)
+}
+
+// DrainingPaymentService counts the charges under way, and refuses first charges once shutdown has begun.
+// Retries, supplements and OTP challenges of bookings already being paid for still go through.
+type DrainingPaymentService struct {
+	PaymentService
+	inFlight *InFlight
+}
+
+// NewDrainingPaymentService wraps paymentService with inFlight's admission
+func NewDrainingPaymentService(paymentService PaymentService, inFlight *InFlight) PaymentService {
+	return &DrainingPaymentService{PaymentService: paymentService, inFlight: inFlight}
+}
+
+func (s *DrainingPaymentService) ProcessPayment(ctx context.Context, bookingID string, paymentMethod models.PaymentMethod, opts ...PaymentOption) (*models.Payment, error) {
+	done, err := s.inFlight.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return s.PaymentService.ProcessPayment(ctx, bookingID, paymentMethod, opts...)
+}
+
+func (s *DrainingPaymentService) RetryPayment(ctx context.Context, bookingID string, paymentMethod models.PaymentMethod, opts ...PaymentOption) (*models.Payment, error) {
+	defer s.inFlight.join()()
+	return s.PaymentService.RetryPayment(ctx, bookingID, paymentMethod, opts...)
+}
+
+func (s *DrainingPaymentService) ChargeSupplement(ctx context.Context, bookingID string, amount models.Money, paymentMethod models.PaymentMethod, opts ...PaymentOption) (*models.Payment, error) {
+	defer s.inFlight.join()()
+	return s.PaymentService.ChargeSupplement(ctx, bookingID, amount, paymentMethod, opts...)
+}
+
+func (s *DrainingPaymentService) CompleteChallenge(ctx context.Context, paymentID, otp string) (*models.Payment, error) {
+	defer s.inFlight.join()()
+	return s.PaymentService.CompleteChallenge(ctx, paymentID, otp)
+}
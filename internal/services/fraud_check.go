@@ -0,0 +1,264 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FraudRequest is what fraud rules look at before a payment is charged
+type FraudRequest struct {
+	Payment *models.Payment
+	Booking *models.Booking
+	Payer   *models.User // Who pays, which for resales and supplements isn't always the booking's owner
+	Show    *models.Show
+	Theatre *models.Theatre
+}
+
+// FraudRule scores one risk a payment might carry; rules are plugged into a FraudPipeline
+type FraudRule interface {
+	Name() string
+	// Assess returns a signal when the rule flags the payment, nil when it doesn't
+	Assess(ctx context.Context, req *FraudRequest) (*models.RiskSignal, error)
+}
+
+// FraudCheck assesses a payment before the gateway is asked to charge it
+type FraudCheck interface {
+	Check(ctx context.Context, payment *models.Payment, booking *models.Booking) (*models.RiskAssessment, error)
+}
+
+// FraudPipeline implements FraudCheck by running every rule and adding up their scores - the total decides
+// whether the payment goes ahead, needs step-up verification or is rejected. Rules can be added and removed
+// while the service is running.
+type FraudPipeline struct {
+	rules  []FraudRule
+	config models.FraudConfig
+	mutex  sync.RWMutex
+
+	userRepo    repositories.UserRepository
+	showRepo    repositories.ShowRepository
+	theatreRepo repositories.TheatreRepository
+	clock       clock.Clock
+}
+
+// NewFraudPipeline creates a pipeline of the given rules, in order
+func NewFraudPipeline(
+	userRepo repositories.UserRepository,
+	showRepo repositories.ShowRepository,
+	theatreRepo repositories.TheatreRepository,
+	config models.FraudConfig,
+	clock clock.Clock,
+	rules ...FraudRule,
+) *FraudPipeline {
+	pipeline := &FraudPipeline{config: config, userRepo: userRepo, showRepo: showRepo, theatreRepo: theatreRepo, clock: clock}
+	for _, rule := range rules {
+		pipeline.Add(rule)
+	}
+	return pipeline
+}
+
+// DefaultFraudRules are the standard rules: payment velocity, booking away from home and disposable email
+func DefaultFraudRules(paymentRepo repositories.PaymentRepository, clock clock.Clock) []FraudRule {
+	return []FraudRule{
+		VelocityRule{paymentRepo: paymentRepo, clock: clock},
+		GeographyRule{},
+		DisposableEmailRule{},
+	}
+}
+
+// Add appends a rule to the pipeline; a name can only be added once
+func (p *FraudPipeline) Add(rule FraudRule) error {
+	if rule == nil || rule.Name() == "" {
+		return fmt.Errorf("a fraud rule needs a name")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, existing := range p.rules {
+		if existing.Name() == rule.Name() {
+			return fmt.Errorf("fraud rule %q is already in the pipeline", rule.Name())
+		}
+	}
+	p.rules = append(p.rules, rule)
+	return nil
+}
+
+// Remove takes a rule out of the pipeline, reporting whether it was there
+func (p *FraudPipeline) Remove(name string) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for i, rule := range p.rules {
+		if rule.Name() == name {
+			p.rules = append(p.rules[:i:i], p.rules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Names lists the rules in the order they run
+func (p *FraudPipeline) Names() []string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	names := make([]string, len(p.rules))
+	for i, rule := range p.rules {
+		names[i] = rule.Name()
+	}
+	return names
+}
+
+// Check runs every rule over the payment. A rule that can't decide fails the check, so the payment isn't
+// charged unassessed.
+func (p *FraudPipeline) Check(ctx context.Context, payment *models.Payment, booking *models.Booking) (*models.RiskAssessment, error) {
+	p.mutex.RLock()
+	rules := p.rules
+	p.mutex.RUnlock()
+
+	req, err := p.request(ctx, payment, booking)
+	if err != nil {
+		return nil, err
+	}
+
+	var signals []models.RiskSignal
+	for _, rule := range rules {
+		signal, err := rule.Assess(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("fraud rule %s: %w", rule.Name(), err)
+		}
+		if signal != nil && signal.Score > 0 {
+			signal.Rule = rule.Name()
+			signals = append(signals, *signal)
+		}
+	}
+	return models.NewRiskAssessment(signals, p.config, p.clock.Now()), nil
+}
+
+// request loads the payer, show and theatre the rules look at
+func (p *FraudPipeline) request(ctx context.Context, payment *models.Payment, booking *models.Booking) (*FraudRequest, error) {
+	payer, err := p.userRepo.GetByID(ctx, payment.UserID)
+	if err != nil {
+		return nil, err
+	}
+	show, err := p.showRepo.GetByID(ctx, booking.ShowID)
+	if err != nil {
+		return nil, err
+	}
+	theatre, err := p.theatreRepo.GetByID(ctx, show.TheatreID)
+	if err != nil {
+		return nil, err
+	}
+	return &FraudRequest{Payment: payment, Booking: booking, Payer: payer, Show: show, Theatre: theatre}, nil
+}
+
+// Velocity limits - card testing shows up as many payments, many of them failing, in a few minutes
+const (
+	velocityWindow       = 10 * time.Minute
+	velocityAttempts     = 5  // Payments in the window before the payer looks busy
+	velocityBurst        = 10 // Payments in the window before the payer looks automated
+	velocityFailures     = 3  // Failed payments in the window before it looks like card testing
+	velocityAttemptScore = 30
+	velocityFailureScore = 30
+)
+
+// VelocityRule flags payers making many payments, or failing many, within a few minutes
+type VelocityRule struct {
+	paymentRepo repositories.PaymentRepository
+	clock       clock.Clock
+}
+
+func (VelocityRule) Name() string { return "velocity" }
+
+func (r VelocityRule) Assess(ctx context.Context, req *FraudRequest) (*models.RiskSignal, error) {
+	recent, err := r.paymentRepo.GetByUserSince(ctx, req.Payer.ID, r.clock.Now().Add(-velocityWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, failures := 0, 0
+	for _, payment := range recent {
+		if payment.ID == req.Payment.ID {
+			continue
+		}
+		attempts++
+		if payment.Status == models.PaymentStatusFailed {
+			failures++
+		}
+	}
+
+	score := 0
+	var reasons []string
+	switch {
+	case attempts >= velocityBurst:
+		score += 2 * velocityAttemptScore
+		reasons = append(reasons, fmt.Sprintf("%d payments in the last %s", attempts, velocityWindow))
+	case attempts >= velocityAttempts:
+		score += velocityAttemptScore
+		reasons = append(reasons, fmt.Sprintf("%d payments in the last %s", attempts, velocityWindow))
+	}
+	if failures >= velocityFailures {
+		score += velocityFailureScore
+		reasons = append(reasons, fmt.Sprintf("%d failed payments in the last %s", failures, velocityWindow))
+	}
+	if score == 0 {
+		return nil, nil
+	}
+	return &models.RiskSignal{Score: score, Reason: strings.Join(reasons, ", ")}, nil
+}
+
+// geographyScore is what booking away from the payer's home city adds
+const geographyScore = 20
+
+// GeographyRule flags payers booking in a city other than the home city on their profile. Payers who never
+// gave one aren't flagged.
+type GeographyRule struct{}
+
+func (GeographyRule) Name() string { return "geography" }
+
+func (GeographyRule) Assess(ctx context.Context, req *FraudRequest) (*models.RiskSignal, error) {
+	home := req.Payer.Preferences.HomeCity
+	if home == "" || strings.EqualFold(home, req.Theatre.City) {
+		return nil, nil
+	}
+	return &models.RiskSignal{
+		Score:  geographyScore,
+		Reason: fmt.Sprintf("booking in %s, away from home city %s", req.Theatre.City, home),
+	}, nil
+}
+
+// disposableEmailScore is what a throwaway email address adds
+const disposableEmailScore = 30
+
+// disposableEmailDomains are throwaway inbox providers, a common sign of accounts made for one purchase
+var disposableEmailDomains = []string{
+	"10minutemail.com",
+	"guerrillamail.com",
+	"mailinator.com",
+	"sharklasers.com",
+	"temp-mail.org",
+	"tempmail.com",
+	"throwawaymail.com",
+	"trashmail.com",
+	"yopmail.com",
+}
+
+// DisposableEmailRule flags payers whose email address is at a throwaway inbox provider
+type DisposableEmailRule struct{}
+
+func (DisposableEmailRule) Name() string { return "disposable-email" }
+
+func (DisposableEmailRule) Assess(ctx context.Context, req *FraudRequest) (*models.RiskSignal, error) {
+	_, domain, found := strings.Cut(req.Payer.Email, "@")
+	if !found || !slices.Contains(disposableEmailDomains, strings.ToLower(strings.TrimSpace(domain))) {
+		return nil, nil
+	}
+	return &models.RiskSignal{Score: disposableEmailScore, Reason: "disposable email address at " + domain}, nil
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/models"
+	"context"
+)
+
+// RegisterFraudSubscriber cancels the pending booking a rejected payment was for, freeing its seats -
+// demonstrates Observer Pattern. Supplements leave their already confirmed booking alone.
+func RegisterFraudSubscriber(bus events.EventBus, bookingService BookingService) {
+	bus.Subscribe(events.EventPaymentRejected, func(ctx context.Context, event events.Event) error {
+		e := event.(events.PaymentRejected)
+		if e.Attempt == 0 {
+			return nil
+		}
+
+		booking, err := bookingService.GetBooking(ctx, e.BookingID)
+		if err != nil {
+			return err
+		}
+		if booking.GetStatus() != models.BookingStatusPending {
+			return nil
+		}
+		return bookingService.CancelBooking(ctx, booking.ID)
+	})
+}
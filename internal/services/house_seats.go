@@ -0,0 +1,178 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/models"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// WithholdSeats takes seats of one show off sale - broken seats as BLOCKED_ADMIN, management holds as HOUSE.
+// The seats must be free for the show: nobody holds or has booked them. The theatre's admins only.
+func (ss *ShowServiceImpl) WithholdSeats(ctx context.Context, showID string, seatIDs []string, status models.SeatStatus, reason string) ([]*models.HouseSeat, error) {
+	show, err := ss.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	caller, err := ss.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, show.TheatreID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !show.CanBeBooked(ss.clock.Now()) {
+		return nil, models.ErrShowNotBookable
+	}
+
+	unlock, err := ss.lockSeats(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	seatIDs = uniqueSeats(seatIDs)
+	if err := show.CheckSeatsOnSale(seatIDs); err != nil {
+		return nil, err
+	}
+
+	screen, err := ss.screenRepo.GetByID(ctx, show.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+	for _, seatID := range seatIDs {
+		if _, err := screen.GetSeat(seatID); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, seatID)
+		}
+		if status := show.SeatStatus(seatID); status != models.SeatStatusAvailable {
+			return nil, fmt.Errorf("%w: %s is %s", models.ErrSeatNotAvailable, seatID, strings.ToLower(string(status)))
+		}
+	}
+
+	withheld, err := show.WithholdSeats(seatIDs, status, reason, caller.ID, ss.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := ss.showRepo.Update(ctx, show); err != nil {
+		return nil, err
+	}
+
+	ss.audit.Record(ctx, AuditChange{
+		EntityType: models.AuditEntityShow,
+		EntityID:   show.ID,
+		Action:     models.AuditSeatsWithheld,
+		Details: map[string]string{
+			"seats":  strings.Join(seatIDs, ","),
+			"status": string(status),
+			"reason": withheld[0].Reason,
+		},
+	})
+	ss.publish(ctx, events.HouseSeatsChanged{
+		ShowID:    show.ID,
+		TheatreID: show.TheatreID,
+		SeatIDs:   seatIDs,
+		Status:    status,
+		Reason:    withheld[0].Reason,
+		Timestamp: ss.clock.Now(),
+	})
+	return withheld, nil
+}
+
+// ReleaseHouseSeats puts held back seats of a show back on sale; no seat IDs releases all of them.
+// The theatre's admins only.
+func (ss *ShowServiceImpl) ReleaseHouseSeats(ctx context.Context, showID string, seatIDs []string) ([]*models.HouseSeat, error) {
+	show, err := ss.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ss.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, show.TheatreID); err != nil {
+		return nil, err
+	}
+
+	unlock, err := ss.lockSeats(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	released, err := show.ReleaseHouseSeats(uniqueSeats(seatIDs), ss.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := ss.showRepo.Update(ctx, show); err != nil {
+		return nil, err
+	}
+
+	releasedIDs := make([]string, 0, len(released))
+	for _, seat := range released {
+		releasedIDs = append(releasedIDs, seat.SeatID)
+	}
+	ss.audit.Record(ctx, AuditChange{
+		EntityType: models.AuditEntityShow,
+		EntityID:   show.ID,
+		Action:     models.AuditSeatsReleasedToSale,
+		Details:    map[string]string{"seats": strings.Join(releasedIDs, ",")},
+	})
+	ss.publish(ctx, events.HouseSeatsChanged{
+		ShowID:    show.ID,
+		TheatreID: show.TheatreID,
+		SeatIDs:   releasedIDs,
+		Status:    models.SeatStatusAvailable,
+		Timestamp: ss.clock.Now(),
+	})
+	return released, nil
+}
+
+// GetHouseSeats lists the show's held back seats with who held each back and why, oldest first.
+// Released seats are gone from the list; the show's audit trail keeps them. The theatre's admins only.
+func (ss *ShowServiceImpl) GetHouseSeats(ctx context.Context, showID string) ([]*models.HouseSeat, error) {
+	show, err := ss.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ss.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, show.TheatreID); err != nil {
+		return nil, err
+	}
+
+	houseSeats := make([]*models.HouseSeat, 0, len(show.HouseSeats))
+	for _, seat := range show.HouseSeats {
+		houseSeats = append(houseSeats, seat)
+	}
+	slices.SortFunc(houseSeats, func(a, b *models.HouseSeat) int {
+		if c := a.BlockedAt.Compare(b.BlockedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.SeatID, b.SeatID)
+	})
+	return houseSeats, nil
+}
+
+// lockSeats takes the show's booking lock, then its hold lock - the order CreateBooking takes them in - so no
+// booking, seat change or hold runs while held back seats change
+func (ss *ShowServiceImpl) lockSeats(ctx context.Context, showID string) (locks.Unlock, error) {
+	unlockBookings, err := ss.lockManager.Lock(ctx, locks.ShowKey(bookingLockOwner, showID))
+	if err != nil {
+		return nil, err
+	}
+	unlockHolds, err := ss.lockManager.Lock(ctx, locks.ShowKey(holdLockOwner, showID))
+	if err != nil {
+		unlockBookings()
+		return nil, err
+	}
+	return func() {
+		unlockHolds()
+		unlockBookings()
+	}, nil
+}
+
+// uniqueSeats drops repeated seat IDs, keeping the first of each
+func uniqueSeats(seatIDs []string) []string {
+	unique := make([]string, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		if !slices.Contains(unique, seatID) {
+			unique = append(unique, seatID)
+		}
+	}
+	return unique
+}
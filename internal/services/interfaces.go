@@ -1,76 +1,1029 @@
 package services
 
 import (
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/factories"
 	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/pricing"
+	"context"
+	"net/http"
 	"time"
 )
 
 // UserService defines core user operations for LLD learning
 type UserService interface {
-	CreateUser(name, email, phoneNumber string) (*models.User, error)
-	GetUser(id string) (*models.User, error)
+	CreateUser(ctx context.Context, name, email, phoneNumber string) (*models.User, error)
+	CreateUserWithRole(ctx context.Context, name, email, phoneNumber string, role models.UserRole) (*models.User, error) // Seeds admins
+	GetUser(ctx context.Context, id string) (*models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)                                                                                     // Finds users kept from an earlier run
+	SetDateOfBirth(ctx context.Context, userID string, dateOfBirth time.Time) (*models.User, error)                                                             // Lets age-rated shows be booked
+	SubmitDiscountProfile(ctx context.Context, userID string, category models.DiscountCategory, documentID string, validUntil *time.Time) (*models.User, error) // Pending until an admin verifies it
+	SetWhatsAppOptIn(ctx context.Context, userID string, optedIn bool) (*models.User, error)                                                                    // WhatsApp messages need it
+}
+
+// PreferenceService keeps each user's personalization profile and fills in what a search leaves open from it
+type PreferenceService interface {
+	GetPreferences(ctx context.Context, userID string) (*models.UserPreferences, error)
+	UpdatePreferences(ctx context.Context, userID string, preferences models.UserPreferences) (*models.UserPreferences, error) // Replaces the whole profile
+	ApplyShowDefaults(ctx context.Context, userID string, filter ShowFilter) (ShowFilter, error)                               // Unset city and languages come from the profile
+}
+
+// RecommendationService suggests movies a user hasn't booked yet, best first
+type RecommendationService interface {
+	GetRecommendations(ctx context.Context, userID string, limit int) ([]*MovieRecommendation, error) // Zero limit returns DefaultRecommendationLimit
+}
+
+// MovieRecommendation is one suggested movie with how each scorer rated it
+type MovieRecommendation struct {
+	Movie   *models.Movie      `json:"movie"`
+	Score   float64            `json:"score"`             // 0-1, the weighted mean of Scores
+	Scores  map[string]float64 `json:"scores"`            // 0-1 per scorer name
+	Reasons []string           `json:"reasons,omitempty"` // Why, e.g. "matches your taste for action"
+}
+
+// WatchlistService keeps the movies each user wants to see and reminds them once one is showing in their home city
+type WatchlistService interface {
+	AddToWatchlist(ctx context.Context, userID, movieID string) (*models.WatchlistEntry, error) // Adding a movie twice returns the existing entry
+	RemoveFromWatchlist(ctx context.Context, userID, movieID string) error
+	GetWatchlist(ctx context.Context, userID string) ([]*WatchlistItem, error) // Newest first
+	SendReminders(ctx context.Context) (int, error)                            // Run periodically; returns the reminders sent
+}
+
+// WatchlistItem is a watchlist entry with its movie
+type WatchlistItem struct {
+	*models.WatchlistEntry
+	Movie *models.Movie `json:"movie"`
+}
+
+// DealsService discounts shows that are about to start with plenty of seats left. The pricing chain asks it
+// for a show's deal on every quote.
+type DealsService interface {
+	pricing.DealFinder
+	CreateDeals(ctx context.Context) ([]*models.Deal, error)                 // Run periodically; returns the deals made
+	GetActiveDeals(ctx context.Context, city string) ([]*models.Deal, error) // Soonest show first; "" lists every city
+}
+
+// ParkingService manages theatres' car parks and the slots reserved with bookings; a reservation is held,
+// confirmed, cancelled and expired along with its booking
+type ParkingService interface {
+	SetCapacity(ctx context.Context, theatreID string, slots int) (*models.Theatre, error) // Theatre admins only; zero stops new reservations
+	GetAvailability(ctx context.Context, showID string) (*ParkingAvailability, error)
+	Reserve(ctx context.Context, show *models.Show, booking *models.Booking, vehicles int) (*models.ParkingReservation, error) // Held until the booking's payment window closes
+	Confirm(ctx context.Context, bookingID string) (*models.ParkingReservation, error)
+	Cancel(ctx context.Context, bookingID string) error // No-op for bookings without parking
+	Expire(ctx context.Context, bookingID string) error
+	ExtendHold(ctx context.Context, bookingID string, expiresAt time.Time) error // Held slots follow the booking's payment window
+	GetReservation(ctx context.Context, bookingID string) (*models.ParkingReservation, error)
+	RescheduleShow(ctx context.Context, showID string) error // Moves the show's reservations to its current times
+}
+
+// ParkingAvailability counts a theatre's parking slots for the length of one show
+type ParkingAvailability struct {
+	ShowID    string `json:"show_id"`
+	TheatreID string `json:"theatre_id"`
+	Capacity  int    `json:"capacity"`
+	Reserved  int    `json:"reserved"` // Held or confirmed for a show overlapping this one
+	Available int    `json:"available"`
+}
+
+// ResaleService lets users sell confirmed bookings they can't use, at no more than face value; the first buyer
+// to pay gets the booking and the seller is refunded the price less the platform's fee
+type ResaleService interface {
+	ListBooking(ctx context.Context, bookingID, sellerID string, price models.Money) (*models.ResaleListing, error) // Owner only
+	WithdrawListing(ctx context.Context, listingID, sellerID string) (*models.ResaleListing, error)
+	WithdrawBookingListing(ctx context.Context, bookingID, reason string) error // No-op for bookings not on sale
+	GetListing(ctx context.Context, listingID string) (*models.ResaleListing, error)
+	GetShowListings(ctx context.Context, showID string) ([]*models.ResaleListing, error) // Open listings, cheapest first
+	GetSellerListings(ctx context.Context, sellerID string) ([]*models.ResaleListing, error)
+	BuyListing(ctx context.Context, listingID, buyerID string, method models.PaymentMethod, opts ...PaymentOption) (*ResalePurchase, error)
+}
+
+// ResalePurchase is the outcome of buying a listed booking
+type ResalePurchase struct {
+	Listing *models.ResaleListing `json:"listing"`
+	Booking *models.Booking       `json:"booking"` // Now the buyer's
+	Payment *models.Payment       `json:"payment"` // The buyer's
+	Refunds []*models.Refund      `json:"refunds"` // The seller's payout
+}
+
+// ShowReminderService reminds bookings' owners shortly before their show starts
+type ShowReminderService interface {
+	SendShowReminders(ctx context.Context) (int, error) // Run periodically; returns the reminders sent
+}
+
+// ShowCloseOutService closes out shows once they end: tickets never scanned become no-shows, and the show is
+// completed with a final summary of its attendance and takings
+type ShowCloseOutService interface {
+	CloseOutShow(ctx context.Context, showID string) (*models.Show, error) // Theatre admins; the show must have ended
+	CloseOutEndedShows(ctx context.Context) ([]*models.Show, error)        // Every scheduled show that has ended; run periodically
+}
+
+// AuthService signs users up and in, and turns bearer tokens back into users
+type AuthService interface {
+	Signup(ctx context.Context, name, email, phoneNumber, password string) (*AuthSession, error) // Creates a customer and signs them in
+	Login(ctx context.Context, email, password string) (*AuthSession, error)
+	Logout(ctx context.Context, token string) error
+	Authenticate(ctx context.Context, token string) (*models.User, error)
+	SetPassword(ctx context.Context, userID, password string) error // Gives seeded users a login
 }
 
 // MovieService defines core movie operations for LLD learning
 type MovieService interface {
-	CreateMovie(title, description string, duration time.Duration, genre models.Genre, language models.Language, rating float32, releaseDate time.Time) (*models.Movie, error)
-	GetMovie(id string) (*models.Movie, error)
-	GetReleasedMovies() ([]*models.Movie, error) // Needed for demo
+	CreateMovie(ctx context.Context, title, description string, duration time.Duration, genre models.Genre, language models.Language, rating float32, releaseDate time.Time, opts ...MovieOption) (*models.Movie, error)
+	SetSaleWindow(ctx context.Context, movieID string, window models.SaleWindow) (*models.Movie, error) // For shows created afterwards
+	GetMovie(ctx context.Context, id string) (*models.Movie, error)
+	GetReleasedMovies(ctx context.Context) ([]*models.Movie, error)         // Needed for demo
+	GetTrending(ctx context.Context, city string) ([]*TrendingMovie, error) // Most booked lately; an empty city ranks every city
+}
+
+// EventService defines live event (concert, play, stand-up) catalog operations
+type EventService interface {
+	CreateEvent(ctx context.Context, title, description string, eventType models.EventType, duration time.Duration, language models.Language, performers []string) (*models.Event, error)
+	GetEvent(ctx context.Context, id string) (*models.Event, error)
+	GetEventsByType(ctx context.Context, eventType models.EventType) ([]*models.Event, error) // Empty type lists every live event
+}
+
+// ReviewService defines movie reviews with moderation; approved reviews drive Movie.Rating
+type ReviewService interface {
+	SubmitReview(ctx context.Context, userID, movieID string, stars int, text string) (*models.Review, error)
+	EditReview(ctx context.Context, reviewID, userID string, stars int, text string) (*models.Review, error)
+	ModerateReview(ctx context.Context, moderatorID, reviewID string, approve bool, note string) (*models.Review, error) // Admin only
+	ListMovieReviews(ctx context.Context, movieID string, offset, limit int) (*MovieReviews, error)                      // Approved only
+	ListPendingReviews(ctx context.Context, moderatorID, movieID string, offset, limit int) (*MovieReviews, error)       // Admin only
+	GetRatingSummary(ctx context.Context, movieID string) (*RatingSummary, error)
 }
 
 // TheatreService defines core theatre operations for LLD learning
 type TheatreService interface {
-	CreateTheatre(name, address, city string) (*models.Theatre, error)
-	GetTheatre(id string) (*models.Theatre, error)
-	AddScreen(theatreID string, screen *models.Screen) error // Core to booking flow
+	CreateTheatre(ctx context.Context, name, address, city string, opts ...TheatreOption) (*models.Theatre, error) // Adds a new city to the catalog
+	GetTheatre(ctx context.Context, id string) (*models.Theatre, error)
+	AddScreen(ctx context.Context, theatreID string, screen *models.Screen) error                       // Core to booking flow
+	CloneScreen(ctx context.Context, theatreID, sourceScreenID, newName string) (*models.Screen, error) // Copies a layout and its pricing; the source may be any theatre's
+	AddCity(ctx context.Context, name, region string) (*models.City, error)
+	GetCities(ctx context.Context) ([]*models.City, error)
+	GetTheatresByCity(ctx context.Context, cityID string) ([]*models.Theatre, error)
+	GetTheatresNearLocation(ctx context.Context, lat, lng, radiusKm float64) ([]*NearbyTheatre, error) // Nearest first
 }
 
 // ShowService defines core show operations for LLD learning
 type ShowService interface {
-	CreateShow(movieID, theatreID, screenID string, startTime time.Time, basePrice float64) (*models.Show, error)
-	GetShow(id string) (*models.Show, error)
-	GetShowsByMovie(movieID string) ([]*models.Show, error) // Needed for demo
+	CreateShow(ctx context.Context, movieID, theatreID, screenID string, startTime time.Time, basePrice models.Money, opts ...ShowOption) (*models.Show, error)
+	GetShow(ctx context.Context, id string) (*models.Show, error)
+	SearchShows(ctx context.Context, filter ShowFilter) ([]*models.Show, error) // Bookable shows, soonest first
+	CreateEventShow(ctx context.Context, eventID, theatreID, screenID string, startTime time.Time, basePrice models.Money) (*models.Show, error)
+	GetShowsByMovie(ctx context.Context, movieID string) ([]*models.Show, error) // Needed for demo
+	GetShowsByEvent(ctx context.Context, eventID string) ([]*models.Show, error)
+	GetSeatAvailability(ctx context.Context, showID string) (*models.SeatMap, error)  // Seat picker layout with this show's seat status
+	CancelShow(ctx context.Context, showID, reason string) (*ShowCancellation, error) // Cancels bookings, refunds in full, notifies users
+	RescheduleShow(ctx context.Context, showID string, startTime time.Time) (*ShowReschedule, error)
+	SuggestSlots(ctx context.Context, screenID, movieID string, date time.Time) (*SlotSuggestion, error)                                      // Free start times that day, turnaround included
+	GetNowShowing(ctx context.Context, city string, date time.Time) ([]*NowShowingMovie, error)                                               // Movies bookable in the city that day; an empty city lists every city
+	SetBookingTimeout(ctx context.Context, showID string, timeout time.Duration) (*models.Show, error)                                        // Zero restores the theatre's or platform default
+	WithholdSeats(ctx context.Context, showID string, seatIDs []string, status models.SeatStatus, reason string) ([]*models.HouseSeat, error) // HOUSE or BLOCKED_ADMIN; the seats must be free
+	ReleaseHouseSeats(ctx context.Context, showID string, seatIDs []string) ([]*models.HouseSeat, error)                                      // No seat IDs releases them all
+	GetHouseSeats(ctx context.Context, showID string) ([]*models.HouseSeat, error)
+	GetAvailabilitySummary(ctx context.Context, showID string) (*AvailabilitySummary, error) // Seat counters by status and type, without reading the seat map
 }
 
 // BookingService defines core booking operations for LLD learning
 type BookingService interface {
-	CreateBooking(userID, showID string, seatIDs []string) (*models.Booking, error)
-	GetBooking(id string) (*models.Booking, error)
-	ConfirmBooking(bookingID, paymentID string) error
-	GetBookingDetails(bookingID string) (*BookingDetails, error)
+	CreateBooking(ctx context.Context, userID, showID string, seatIDs []string, opts ...BookingOption) (*models.Booking, error)
+	GetBooking(ctx context.Context, id string) (*models.Booking, error)
+	GetBookingByReference(ctx context.Context, reference string) (*models.Booking, error)
+	ConfirmBooking(ctx context.Context, bookingID, paymentID string) error
+	ExtendHold(ctx context.Context, bookingID, userID string) (*models.Booking, error) // Once per booking; more time to pay
+	GetBookingDetails(ctx context.Context, bookingID string) (*BookingDetails, error)
+	CancelBooking(ctx context.Context, bookingID string) error                        // Releases seats and refunds confirmed bookings
+	CancelShowBookings(ctx context.Context, showID string) ([]*models.Booking, error) // Releases seats only; the caller refunds
+	ModifySeats(ctx context.Context, bookingID string, newSeatIDs []string) (*SeatModification, error)
+	GetUserBookings(ctx context.Context, userID string, filter BookingFilter) (*UserBookings, error) // "My Bookings" view
+	SuggestSeats(ctx context.Context, showID string, count int, seatType models.SeatType) (*SeatSuggestion, error)
+	GetAddOnOffers(ctx context.Context, showID string) ([]AddOnOffer, error) // Add-ons a booking for the show can buy
+	FulfillAddOns(ctx context.Context, bookingID string) error               // Runs each paid add-on's fulfilment hook
+	TransferBooking(ctx context.Context, bookingID, fromUserID, toUserEmail string) (*models.BookingTransfer, error)
+	AcceptTransfer(ctx context.Context, bookingID, userID string) (*models.Booking, error) // The booking becomes the user's
+	DeclineTransfer(ctx context.Context, bookingID, userID string) (*models.Booking, error)
+	GetIncomingTransfers(ctx context.Context, userID string) ([]*models.Booking, error) // Offers waiting for the user, oldest first
+}
+
+// BulkBookingService defines corporate seat blocks: reserving them, redeeming their codes and handing back unused seats
+type BulkBookingService interface {
+	ReserveBlock(ctx context.Context, accountID, showID string, request BulkBlockRequest) (*BulkBookingDetails, error) // Codes work once the booking is paid for
+	GetBulkBooking(ctx context.Context, accountID, bulkBookingID string) (*BulkBookingDetails, error)
+	GetAccountBulkBookings(ctx context.Context, accountID string) ([]*BulkBookingDetails, error) // Newest first
+	RedeemCode(ctx context.Context, userID, code string) (*BulkPass, error)
+	ReleaseSeats(ctx context.Context, accountID, bulkBookingID string, codes []string) (*BulkRelease, error) // No codes releases every unredeemed seat
+}
+
+// AdminService defines theatre partner operations; every call is checked against the caller's role
+type AdminService interface {
+	GrantRole(ctx context.Context, adminID, userID string, role models.UserRole, theatreIDs ...string) (*models.User, error) // Theatre admins need the theatres they manage
+	BlockUser(ctx context.Context, adminID, userID, reason string) (*models.User, error)                                     // Stops the user holding seats or booking
+	UnblockUser(ctx context.Context, adminID, userID string) (*models.User, error)
+	GetPendingDiscountProfiles(ctx context.Context, adminID string) ([]*models.User, error) // Oldest submission first
+	VerifyDiscountProfile(ctx context.Context, adminID, userID string) (*models.User, error)
+	RejectDiscountProfile(ctx context.Context, adminID, userID, reason string) (*models.User, error)
+	OnboardTheatre(ctx context.Context, adminID, name, address, city string, opts ...TheatreOption) (*models.Theatre, error)
+	AddCity(ctx context.Context, adminID, name, region string) (*models.City, error)
+	RegisterSeatType(ctx context.Context, adminID string, seatType models.SeatType, info factories.SeatTypeInfo) (factories.SeatTypeInfo, error) // New seat types for screen layouts, e.g. SOFA
+	AddScreen(ctx context.Context, adminID, theatreID, name string, layout factories.ScreenConfig, basePrice models.Money) (*models.Screen, error)
+	CloneScreen(ctx context.Context, adminID, theatreID, screenID, name string) (*models.Screen, error)                                                // An empty theatre ID copies within the source's theatre
+	AddScreensFromTemplate(ctx context.Context, adminID, theatreID, template string, names []string, basePrice models.Money) ([]*models.Screen, error) // One screen per name, all laid out alike
+	RegisterScreenTemplate(ctx context.Context, adminID string, template factories.ScreenTemplate) (factories.ScreenTemplate, error)
+	GetPricingCalendar(ctx context.Context, adminID string) ([]pricing.CalendarDay, error)
+	SetPricingDay(ctx context.Context, adminID string, day pricing.CalendarDay) (pricing.CalendarDay, error) // Replaces what the date had
+	ClearPricingDay(ctx context.Context, adminID string, date time.Time) error
+	CreateShowsFromTemplate(ctx context.Context, adminID string, template WeeklyShowTemplate) ([]*models.Show, error)
+	SetScreenMaintenance(ctx context.Context, adminID, screenID string, offline bool) (*models.Screen, error)
+	CancelShow(ctx context.Context, adminID, showID, reason string) (*ShowCancellation, error)
+	RescheduleShow(ctx context.Context, adminID, showID string, startTime time.Time) (*ShowReschedule, error)
+	SetCancellationPolicy(ctx context.Context, adminID, theatreID string, tiers []models.CancellationTier) (*models.Theatre, error) // Empty tiers restore the default
+	SetTheatreBookingTimeout(ctx context.Context, adminID, theatreID string, timeout time.Duration) (*models.Theatre, error)        // For shows created afterwards; zero restores the default
+	SetTheatreSaleWindow(ctx context.Context, adminID, theatreID string, window models.SaleWindow) (*models.Theatre, error)         // For shows created afterwards
+	SetShowBookingTimeout(ctx context.Context, adminID, showID string, timeout time.Duration) (*models.Show, error)
+	WithholdSeats(ctx context.Context, adminID, showID string, seatIDs []string, status models.SeatStatus, reason string) ([]*models.HouseSeat, error) // Off sale for this show only
+	ReleaseHouseSeats(ctx context.Context, adminID, showID string, seatIDs []string) ([]*models.HouseSeat, error)
+	GetHouseSeats(ctx context.Context, adminID, showID string) ([]*models.HouseSeat, error)
+	GetDeadLetters(ctx context.Context, adminID string) ([]*models.OutboxMessage, error)
+	RedeliverEvent(ctx context.Context, adminID, messageID string) (*models.OutboxMessage, error)
+	GetNotificationDeadLetters(ctx context.Context, adminID string) ([]*models.Notification, error)
+	ReplayNotification(ctx context.Context, adminID, notificationID string) (*models.Notification, error)
+	GetWhatsAppTemplates(ctx context.Context, adminID string) ([]models.WhatsAppTemplate, error)
+	SetWhatsAppTemplate(ctx context.Context, adminID string, template models.WhatsAppTemplate) (models.WhatsAppTemplate, error) // Replaces the kind's template
+	RemoveWhatsAppTemplate(ctx context.Context, adminID string, kind models.NotificationKind) error
+	GetAuditTrail(ctx context.Context, adminID, entityID string) ([]*models.AuditEntry, error) // Oldest first
+}
+
+// Authorizer decides whether a user may take an action - the role check guarded service methods consult.
+// Unknown or missing users get ErrUnauthorized; the wrong role or someone else's theatre gets ErrForbidden, which wraps it.
+type Authorizer interface {
+	Authorize(ctx context.Context, userID string, permission models.Permission, theatreID string) (*models.User, error) // theatreID only matters for theatre-scoped permissions
+	AuthorizeCaller(ctx context.Context, permission models.Permission, theatreID string) (*models.User, error)          // The user recorded by WithCaller
+	AuthorizeBooking(ctx context.Context, booking *models.Booking) error                                                // The caller's own booking, or one at a theatre they manage
+}
+
+// TicketService defines e-ticket issuance for confirmed bookings
+type TicketService interface {
+	IssueTicket(ctx context.Context, bookingID string) (*models.Ticket, error)   // Idempotent per booking
+	ReissueTicket(ctx context.Context, bookingID string) (*models.Ticket, error) // Signs it for the booking's new owner
+	GetTicket(ctx context.Context, ticketID string) (*models.Ticket, error)
+	GetTicketForBooking(ctx context.Context, bookingID string) (*models.Ticket, error)
+	RenderQRCode(ctx context.Context, ticketID string, size int) ([]byte, error) // PNG
+	VoidTicket(ctx context.Context, bookingID string) error
+	ExportICS(ctx context.Context, bookingID string) (*Attachment, error)    // iCalendar event for a confirmed booking
+	ExportQRCode(ctx context.Context, bookingID string) (*Attachment, error) // PNG of the entry QR code, e.g. for WhatsApp
+}
+
+// TicketRenderer turns a confirmed booking into a printable ticket and invoice
+type TicketRenderer interface {
+	RenderTicket(ctx context.Context, bookingID string) (*Attachment, error)
+}
+
+// CheckInService defines entry validation used by theatres
+type CheckInService interface {
+	ValidateTicket(ctx context.Context, theatreID, payload string) (*TicketValidation, error)
+	CheckIn(ctx context.Context, theatreID, gate, payload string) (*TicketValidation, error) // Marks the ticket used
+}
+
+// SeatHoldService defines per-user seat hold operations with TTL
+type SeatHoldService interface {
+	CreateHold(ctx context.Context, userID, showID string, seatIDs []string) (*models.SeatHold, error)
+	GetHold(ctx context.Context, holdID string) (*models.SeatHold, error)
+	ExtendHold(ctx context.Context, holdID, userID string) (*models.SeatHold, error)
+	ReleaseHold(ctx context.Context, holdID, userID string) error
+	ConsumeHold(ctx context.Context, holdID, userID, bookingID string) (*models.SeatHold, error) // Hands seats to a booking
+	ReleaseExpiredHolds(ctx context.Context) (int, error)
+	ReleaseShowHolds(ctx context.Context, showID string) (int, error) // Frees every active hold on a cancelled show
 }
 
 // PaymentService defines core payment operations for LLD learning (Strategy Pattern)
 type PaymentService interface {
-	ProcessPayment(bookingID string, paymentMethod models.PaymentMethod) (*models.Payment, error)
-	GetPayment(id string) (*models.Payment, error)
+	ProcessPayment(ctx context.Context, bookingID string, paymentMethod models.PaymentMethod, opts ...PaymentOption) (*models.Payment, error)
+	RetryPayment(ctx context.Context, bookingID string, paymentMethod models.PaymentMethod, opts ...PaymentOption) (*models.Payment, error) // After a failed attempt
+	GetPaymentAttempts(ctx context.Context, bookingID string) ([]*models.Payment, error)
+	GetPayment(ctx context.Context, id string) (*models.Payment, error)
+	RefundPayment(ctx context.Context, paymentID string, amount models.Money, reason string, opts ...RefundOption) (*models.Refund, error)
+	ChargeSupplement(ctx context.Context, bookingID string, amount models.Money, paymentMethod models.PaymentMethod, opts ...PaymentOption) (*models.Payment, error)
+	CompleteChallenge(ctx context.Context, paymentID, otp string) (*models.Payment, error) // Enters the OTP for a payment that requires action
+}
+
+// PaymentInstrumentService keeps the cards, UPI handles and wallet links users save to pay with again. Cards go
+// through a Tokenizer first; instruments are returned without their vault token.
+type PaymentInstrumentService interface {
+	AddCard(ctx context.Context, userID string, card models.CardDetails) (*models.PaymentInstrument, error)
+	AddUPI(ctx context.Context, userID, handle string) (*models.PaymentInstrument, error)   // Adding a handle twice returns the existing instrument
+	LinkWallet(ctx context.Context, userID string) (*models.PaymentInstrument, error)       // Linking twice returns the existing link
+	GetInstruments(ctx context.Context, userID string) ([]*models.PaymentInstrument, error) // Oldest first
+	RemoveInstrument(ctx context.Context, userID, instrumentID string) error
+}
+
+// Tokenizer swaps card details for an opaque token and back, so only the vault ever holds a card number
+type Tokenizer interface {
+	Tokenize(ctx context.Context, card models.CardDetails) (string, error)
+	Detokenize(ctx context.Context, token string) (models.CardDetails, error) // An unknown token is models.ErrInvalidInstrumentToken
+}
+
+// RefundService defines refund operations - supports full and partial refunds
+type RefundService interface {
+	InitiateRefund(ctx context.Context, paymentID string, amount models.Money, reason string, opts ...RefundOption) (*models.Refund, error)
+	GetRefund(ctx context.Context, id string) (*models.Refund, error)
+	GetRefundsByPayment(ctx context.Context, paymentID string) ([]*models.Refund, error)
+	// A refund the gateway made on its own, e.g. from the provider's dashboard; nothing is sent back to it
+	RecordGatewayRefund(ctx context.Context, paymentID string, amount models.Money, reference, reason string) (*models.Refund, error)
+}
+
+// PaymentReconciliationService settles payments the gateway never answered for, e.g. after a timeout, by asking
+// it again and then confirming or releasing their bookings
+type PaymentReconciliationService interface {
+	ReconcilePayments(ctx context.Context) ([]*models.Payment, error) // Every payment awaiting confirmation; run periodically
+	// A verified gateway callback about one payment: captured, failed or refunded. Repeats change nothing.
+	HandleCallback(ctx context.Context, callback *models.GatewayCallback) (*models.Payment, error)
+}
+
+// CallbackVerifier checks a payment gateway callback's signature and reads it into a provider-neutral form,
+// returning nil for verified callbacks about things bookings don't track
+type CallbackVerifier interface {
+	VerifyCallback(header http.Header, body []byte) (*models.GatewayCallback, error)
+}
+
+// WalletService defines wallet balance operations - a stored-value payment method
+type WalletService interface {
+	GetWallet(ctx context.Context, userID string) (*models.Wallet, error) // Opens an empty wallet on first use
+	TopUp(ctx context.Context, userID string, amount models.Money) (*models.WalletTransaction, error)
+	Debit(ctx context.Context, userID string, amount models.Money, reference, description string) (*models.WalletTransaction, error)
+	Credit(ctx context.Context, userID string, amount models.Money, reference, description string) (*models.WalletTransaction, error)
+	RefundTransaction(ctx context.Context, transactionID string, amount models.Money, description string) (*models.WalletTransaction, error) // Credits back an earlier debit
+	GetStatement(ctx context.Context, userID string, offset, limit int) (*WalletStatement, error)
+}
+
+// LoyaltyService defines loyalty points earned on bookings and spent towards them
+type LoyaltyService interface {
+	GetAccount(ctx context.Context, userID string) (*models.LoyaltyAccount, error) // Opens an empty account on first use
+	AwardPoints(ctx context.Context, bookingID string) (int64, error)
+	RedeemPoints(ctx context.Context, userID, bookingID string, points int64) (*models.Booking, error) // Pays part of a pending booking
+	ReverseBooking(ctx context.Context, bookingID string) error                                        // Returns spent points and takes back earned ones
+}
+
+// ReportingService defines occupancy and revenue reports for theatre managers
+type ReportingService interface {
+	GetShowReport(ctx context.Context, adminID, showID string) (*ShowReport, error)                                           // Admin only
+	GetTheatreDailyRevenue(ctx context.Context, adminID, theatreID string, from, to time.Time) (*TheatreRevenueReport, error) // Admin only; from and to are inclusive days
+	GetTheatreSettlements(ctx context.Context, adminID, theatreID string) (*SettlementStatement, error)                       // Admin only
+}
+
+// SettlementService pays theatre partners for their ticket sales, less the platform commission
+type SettlementService interface {
+	GenerateSettlement(ctx context.Context, adminID, theatreID string, from, to time.Time) (*models.Settlement, error) // Super admins only; from and to are inclusive days, already over
+	MarkSettlementPaid(ctx context.Context, adminID, settlementID, reference string) (*models.Settlement, error)
+}
+
+// PolicyEngine decides how much of a booking is refunded when its user cancels
+type PolicyEngine interface {
+	RefundAmount(ctx context.Context, show *models.Show, paid models.Money) (models.Money, error)
+}
+
+// BookingRules stops one user from hoarding seats; each violated limit has its own error
+type BookingRules interface {
+	CheckNewBooking(ctx context.Context, userID string, show *models.Show, seats int) error
+	CheckSeatChange(ctx context.Context, booking *models.Booking, seats int) error          // Booking's current seats don't count against it
+	CheckHoldExtension(ctx context.Context, booking *models.Booking) (time.Duration, error) // How much longer its payment window gets
+}
+
+// ProfileDiscountRules works out what verified students, senior citizens and military personnel save on a booking
+type ProfileDiscountRules interface {
+	DiscountFor(ctx context.Context, userID string, subtotal models.Money, excludeBookingID string) (*models.AppliedProfileDiscount, error) // nil when there is nothing to claim; excludeBookingID is being repriced
+}
+
+// PromotionService defines coupon and promo-code operations
+type PromotionService interface {
+	CreateCoupon(ctx context.Context, code string, discountType models.DiscountType, value float64, minAmount models.Money, expiresAt time.Time, usageLimit int) (*models.Coupon, error)
+	GetCoupon(ctx context.Context, code string) (*models.Coupon, error)
+	PreviewDiscount(ctx context.Context, code string, amount models.Money) (models.Money, error)
+	RedeemCoupon(ctx context.Context, code string, amount models.Money) (models.Money, error)
+	ReleaseCoupon(ctx context.Context, code string) error
 }
 
 // PaymentGateway defines payment gateway operations (Strategy Pattern)
 type PaymentGateway interface {
-	ProcessPayment(amount float64, method models.PaymentMethod, metadata map[string]string) (*PaymentResult, error)
+	ProcessPayment(ctx context.Context, amount models.Money, method models.PaymentMethod, metadata map[string]string) (*PaymentResult, error)
+	RefundPayment(ctx context.Context, transactionID string, amount models.Money, method models.PaymentMethod) (*PaymentResult, error)
+	QueryTransaction(ctx context.Context, paymentID string, method models.PaymentMethod) (*PaymentResult, error)   // How a charge went, by our payment ID; a decline isn't an error
+	CompleteChallenge(ctx context.Context, token, otp string, method models.PaymentMethod) (*PaymentResult, error) // Finishes a charge the issuer challenged; a wrong OTP is models.ErrIncorrectOTP
+}
+
+// MovieSource is an external movie catalog, e.g. TMDB or a JSON fixture - demonstrates Adapter Pattern
+type MovieSource interface {
+	Name() string
+	FetchMovies(ctx context.Context) ([]CatalogMovie, error)
+}
+
+// CatalogImporter upserts a movie source's listings into the catalog; re-importing updates rather than duplicates
+type CatalogImporter interface {
+	Import(ctx context.Context) (*CatalogImport, error) // Needs catalog management
+}
+
+// OutboxService is an event bus that records events before delivering them and retries failed deliveries (Outbox Pattern)
+type OutboxService interface {
+	events.EventBus
+	DispatchPending(ctx context.Context) (int, error) // Retries due messages; run periodically
+	GetDeadLetters(ctx context.Context) ([]*models.OutboxMessage, error)
+	Redeliver(ctx context.Context, messageID string) (*models.OutboxMessage, error) // Dead-lettered messages only
+	PurgeDelivered(ctx context.Context, retention time.Duration) (int, error)
+}
+
+// WebhookService delivers booking and show events to theatre partners as signed HTTP POSTs, retried with
+// backoff until the partner answers 2xx. Managing a theatre's webhooks needs MANAGE_THEATRE for it.
+type WebhookService interface {
+	RegisterWebhook(ctx context.Context, theatreID, url string, eventTypes []events.EventType) (*models.Webhook, error) // No types means all; the only time the secret is returned
+	GetWebhooks(ctx context.Context, theatreID string) ([]*models.Webhook, error)                                       // Secrets left out
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	GetDeliveries(ctx context.Context, webhookID string) ([]*models.WebhookDelivery, error) // Newest first
+	Enqueue(ctx context.Context, event events.Event) error                                  // Queues the event for every webhook of its theatre that wants it
+	DispatchPending(ctx context.Context) (int, error)                                       // Sends deliveries whose retry is due; run periodically
+}
+
+// WebhookClient sends webhook POSTs - the subset of *http.Client the service needs, so tests can fake partners
+type WebhookClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HealthChecker probes the application's dependencies for the health endpoint; the AppController is one
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) *models.HealthReport
+}
+
+// AuditRecorder is how services write the audit trail - demonstrates Dependency Inversion:
+// services say what changed and never where it's kept
+type AuditRecorder interface {
+	Record(ctx context.Context, change AuditChange)
+}
+
+// AuditLog is the audit trail itself: recorded into by services and read back by entity
+type AuditLog interface {
+	AuditRecorder
+	GetEntityHistory(ctx context.Context, entityID string) ([]*models.AuditEntry, error) // Oldest first
 }
 
 // NotificationService defines notification operations (Observer Pattern)
 type NotificationService interface {
-	SendBookingConfirmation(userID, bookingID string) error
+	SendBookingConfirmation(ctx context.Context, userID, bookingID, reference string, parking *models.ParkingReservation, attachments ...Attachment) error // parking is nil when the booking has none
+	SendRefundNotification(ctx context.Context, userID, refundID string, amount models.Money) error
+	SendPaymentFailure(ctx context.Context, userID, bookingID, reference, reason string) error
+	SendShowCancellation(ctx context.Context, userID, bookingID, reference, reason string) error
+	SendShowRescheduled(ctx context.Context, userID, bookingID, reference string, startTime time.Time) error
+	SendWatchlistReminder(ctx context.Context, userID, movieID, title, city string, firstShow time.Time) error
+	SendShowReminder(ctx context.Context, userID, bookingID, reference, title, theatre string, startTime time.Time) error
+	SendTransferOffer(ctx context.Context, userID, fromUserID, bookingID, reference string) error
+	SendTransferAccepted(ctx context.Context, fromUserID, toUserID, bookingID, reference string, attachments ...Attachment) error // Tells both users; the attachments go to the new owner
+	SendTransferDeclined(ctx context.Context, userID, toUserID, bookingID, reference string) error
+	SendBookingResold(ctx context.Context, sellerID, buyerID, bookingID, reference string, payout models.Money, attachments ...Attachment) error // Tells both users; the attachments go to the buyer
+}
+
+// Attachment is a file sent along with a notification, such as a printable ticket
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+}
+
+// NotificationMessage is what a notification says, before the queue addresses it to each channel
+type NotificationMessage struct {
+	Kind        models.NotificationKind
+	UserID      string
+	Subject     string
+	Fields      map[string]string // e.g. reference and booking_id
+	Attachments []Attachment
+}
+
+// NotificationQueue stores notifications and delivers them in the background on every configured channel,
+// retrying failed deliveries with backoff and dead-lettering those that keep failing
+type NotificationQueue interface {
+	Enqueue(ctx context.Context, message NotificationMessage) error // Queues one notification per channel
+	DispatchPending(ctx context.Context) (int, error)               // Retries due notifications; run periodically
+	GetDeadLetters(ctx context.Context) ([]*models.Notification, error)
+	Replay(ctx context.Context, notificationID string) (*models.Notification, error) // Dead-lettered notifications only
+	PurgeSent(ctx context.Context, retention time.Duration) (int, error)
+	Wait() // Blocks until background first attempts finish; called on shutdown
+}
+
+// NotificationChannel delivers notifications one way, e.g. by email - channels are plugged into the NotificationQueue
+type NotificationChannel interface {
+	Channel() models.NotificationChannel
+	// Deliver sends the notification to the user. Errors wrapping models.ErrNotificationUndeliverable aren't retried;
+	// ones wrapping models.ErrNotificationSkipped mean the notification isn't for this user on this channel.
+	Deliver(ctx context.Context, user *models.User, notification *models.Notification) error
+}
+
+// AuditChange is what a service tells its AuditRecorder; the recorder stamps who and when
+type AuditChange struct {
+	EntityType models.AuditEntityType
+	EntityID   string
+	Action     models.AuditAction
+	ActorID    string            // Used when the context has no caller, e.g. the booking's user; empty is the system
+	Details    map[string]string // What changed, e.g. "old_total" and "new_total"
+}
+
+// AuthSession is what a successful signup or login returns; the token goes in the Authorization header
+type AuthSession struct {
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	User      *models.User `json:"user"`
 }
 
 // BookingDetails represents detailed booking information
 type BookingDetails struct {
-	Booking *models.Booking `json:"booking"`
-	Show    *models.Show    `json:"show"`
-	Movie   *models.Movie   `json:"movie"`
-	Theatre *models.Theatre `json:"theatre"`
-	Screen  *models.Screen  `json:"screen"`
-	Seats   []*models.Seat  `json:"seats"`
-	Payment *models.Payment `json:"payment,omitempty"`
+	Booking        *models.Booking       `json:"booking"`
+	Show           *models.Show          `json:"show"`
+	Movie          *models.Movie         `json:"movie,omitempty"` // Set for movie shows
+	Event          *models.Event         `json:"event,omitempty"` // Set for live events
+	Theatre        *models.Theatre       `json:"theatre"`
+	Screen         *models.Screen        `json:"screen"`
+	Seats          []*models.Seat        `json:"seats"`
+	LineItems      []LineItem            `json:"line_items"`
+	PriceBreakdown models.PriceBreakdown `json:"price_breakdown"` // Subtotal, discount, fee and GST making up the total
+	Payment        *models.Payment       `json:"payment,omitempty"`
+}
+
+// WeeklyShowTemplate describes a recurring weekly schedule for one movie on one screen
+type WeeklyShowTemplate struct {
+	MovieID   string            `json:"movie_id"`
+	TheatreID string            `json:"theatre_id"`
+	ScreenID  string            `json:"screen_id"`
+	WeekStart time.Time         `json:"week_start"` // Shows are generated from this date onwards
+	Weeks     int               `json:"weeks"`      // Defaults to one week
+	Slots     []ShowSlot        `json:"slots"`
+	BasePrice models.Money      `json:"base_price"`
+	Format    models.ShowFormat `json:"format,omitempty"`   // 2D when empty
+	Language  models.Language   `json:"language,omitempty"` // The movie's language when empty
+}
+
+// ShowSlot is a weekday and a start time measured from midnight
+type ShowSlot struct {
+	Weekday   time.Weekday  `json:"weekday"`
+	StartTime time.Duration `json:"start_time"`
+}
+
+// SeatModification is the outcome of moving a booking to different seats
+type SeatModification struct {
+	Booking         *models.Booking  `json:"booking"`
+	PriceDifference models.Money     `json:"price_difference"` // Positive means the user paid more
+	Payment         *models.Payment  `json:"payment,omitempty"`
+	Refunds         []*models.Refund `json:"refunds,omitempty"`
+}
+
+// BulkBlockRequest says which seats a corporate block takes: exact seats, or a number of seats from the given rows
+type BulkBlockRequest struct {
+	Organization    string    `json:"organization"`
+	SeatIDs         []string  `json:"seat_ids,omitempty"`        // Exact seats; Rows and Seats are ignored when set
+	Rows            []string  `json:"rows,omitempty"`            // Row names in order of preference; empty means every row, front to back
+	Seats           int       `json:"seats,omitempty"`           // How many seats to take from Rows
+	ReleaseDeadline time.Time `json:"release_deadline,omitzero"` // Zero is models.DefaultBulkReleaseNotice before the show
+}
+
+// BulkBookingDetails is a block with the booking holding its seats and its codes counted by status
+type BulkBookingDetails struct {
+	*models.BulkBooking
+	Booking  *models.Booking `json:"booking"` // Pay for it as for any booking to activate the codes
+	Issued   int             `json:"issued"`
+	Redeemed int             `json:"redeemed"`
+	Released int             `json:"released"`
+}
+
+// BulkPass is what an employee gets for a redeemed code
+type BulkPass struct {
+	Code             *models.BulkCode `json:"code"`
+	Organization     string           `json:"organization"`
+	BookingReference string           `json:"booking_reference"`
+	Show             *models.Show     `json:"show"`
+}
+
+// BulkRelease is the outcome of handing seats back
+type BulkRelease struct {
+	BulkBooking     *BulkBookingDetails `json:"bulk_booking"`
+	Released        []*models.BulkCode  `json:"released"`
+	PriceDifference models.Money        `json:"price_difference"` // Negative: what the smaller booking costs less
+	Refunds         []*models.Refund    `json:"refunds,omitempty"`
+}
+
+// SeatSuggestion is a block of adjacent available seats in one row, ready to pass to CreateBooking
+type SeatSuggestion struct {
+	ShowID  string          `json:"show_id"`
+	Row     string          `json:"row"`
+	SeatIDs []string        `json:"seat_ids"`
+	Labels  []string        `json:"labels"` // e.g. "F7", "F8"
+	Type    models.SeatType `json:"type"`
+	Total   models.Money    `json:"total"`
+}
+
+// SlotSuggestion lists start times that fit a movie into a screen's day without clashing
+type SlotSuggestion struct {
+	ScreenID   string        `json:"screen_id"`
+	MovieID    string        `json:"movie_id"`
+	Duration   time.Duration `json:"duration"`    // The movie's running time
+	Turnaround time.Duration `json:"turnaround"`  // Kept free before and after every show
+	StartTimes []time.Time   `json:"start_times"` // Earliest first; all of them can be scheduled together
+}
+
+// ShowCancellation is the outcome of calling off a show
+type ShowCancellation struct {
+	Show     *models.Show           `json:"show"`
+	Bookings []CancelledShowBooking `json:"bookings"`
+	Failures []string               `json:"failures,omitempty"` // Refunds or notifications that need a retry
+}
+
+// CancelledShowBooking is one booking cancelled because its show was called off
+type CancelledShowBooking struct {
+	BookingID string           `json:"booking_id"`
+	UserID    string           `json:"user_id"`
+	Refunds   []*models.Refund `json:"refunds,omitempty"` // Empty for bookings that were never paid
+}
+
+// ShowReschedule is the outcome of moving a show to a new time
+type ShowReschedule struct {
+	Show          *models.Show `json:"show"`
+	PreviousStart time.Time    `json:"previous_start"`
+	NotifiedUsers int          `json:"notified_users"`
+	Failures      []string     `json:"failures,omitempty"`
+}
+
+// BookingCategory groups a user's bookings for the "My Bookings" view
+type BookingCategory string
+
+const (
+	BookingCategoryUpcoming  BookingCategory = "UPCOMING"  // Show not started yet
+	BookingCategoryPast      BookingCategory = "PAST"      // Show has started or finished
+	BookingCategoryCancelled BookingCategory = "CANCELLED" // Cancelled or expired before payment
+)
+
+// ShowFilter narrows a movie's shows; empty fields match any
+type ShowFilter struct {
+	MovieID   string            `json:"movie_id"`
+	Format    models.ShowFormat `json:"format,omitempty"`
+	Language  models.Language   `json:"language,omitempty"`
+	Languages []models.Language `json:"languages,omitempty"` // Any of these; ignored when Language is set
+	City      string            `json:"city,omitempty"`      // The theatre's city, case-insensitive
+
+	FavoriteTheatreIDs []string `json:"favorite_theatre_ids,omitempty"` // Shows at these theatres are listed first
+}
+
+// AvailabilitySummary counts a show's seats by status, overall and per seat type, for listing badges
+type AvailabilitySummary struct {
+	ShowID string `json:"show_id"`
+	models.SeatCounts
+	Badge     models.AvailabilityBadge              `json:"badge"`
+	ByType    map[models.SeatType]models.SeatCounts `json:"by_type"`
+	CountedAt time.Time                             `json:"counted_at"` // Last full count; seat events keep it current in between
+}
+
+// TrendingMovie is a movie ranked by the seats confirmed for it within the trending window
+type TrendingMovie struct {
+	Rank        int           `json:"rank"`
+	Movie       *models.Movie `json:"movie"`
+	SeatsBooked int           `json:"seats_booked"`
+	Bookings    int           `json:"bookings"`
+}
+
+// NowShowingMovie is a movie with bookable shows in a city on one day
+type NowShowingMovie struct {
+	Movie     *models.Movie       `json:"movie"`
+	Shows     int                 `json:"shows"`
+	Theatres  int                 `json:"theatres"`
+	FirstShow time.Time           `json:"first_show"`
+	Formats   []models.ShowFormat `json:"formats"`
+	Languages []models.Language   `json:"languages"`
+
+	theatres map[string]bool // Counted into Theatres while the listing is built
+}
+
+// BookingFilter selects which of a user's bookings to return; an empty Category returns all
+type BookingFilter struct {
+	Category BookingCategory `json:"category,omitempty"`
+	Offset   int             `json:"offset"`
+	Limit    int             `json:"limit"` // Zero returns every match
+}
+
+// BookingSummary is a booking with just enough show/movie or event info to render a list row
+type BookingSummary struct {
+	BookingID   string               `json:"booking_id"`
+	Reference   string               `json:"reference"`
+	Status      models.BookingStatus `json:"status"`
+	Category    BookingCategory      `json:"category"`
+	ShowID      string               `json:"show_id"`
+	ShowStart   time.Time            `json:"show_start"`
+	EventType   models.EventType     `json:"event_type"`
+	MovieID     string               `json:"movie_id,omitempty"`
+	EventID     string               `json:"event_id,omitempty"`
+	Title       string               `json:"title"` // Movie or live event
+	TheatreName string               `json:"theatre_name"`
+	City        string               `json:"city"`
+	ScreenName  string               `json:"screen_name"`
+	Seats       []string             `json:"seats"` // Seat labels, e.g. "A10"
+	TotalAmount models.Money         `json:"total_amount"`
+	BookedAt    time.Time            `json:"booked_at"`
+}
+
+// UserBookings is one page of a user's booking history
+type UserBookings struct {
+	Bookings []*BookingSummary `json:"bookings"`
+	Total    int               `json:"total"` // Matches before pagination
+	Offset   int               `json:"offset"`
+	Limit    int               `json:"limit"`
+}
+
+// WalletStatement is a wallet with one page of its transactions
+type WalletStatement struct {
+	Wallet       *models.Wallet              `json:"wallet"`
+	Transactions []*models.WalletTransaction `json:"transactions"` // Newest first
+	Total        int                         `json:"total"`
+	Offset       int                         `json:"offset"`
+	Limit        int                         `json:"limit"`
+}
+
+// RevenueTotals sums settled payments; refunds are netted against the payment they return
+type RevenueTotals struct {
+	Payments int          `json:"payments"`
+	Gross    models.Money `json:"gross"`
+	Refunded models.Money `json:"refunded"`
+	Net      models.Money `json:"net"`
+}
+
+// SeatTypeSales is one seat type's share of a show
+type SeatTypeSales struct {
+	Type             models.SeatType `json:"type"`
+	Capacity         int             `json:"capacity"`
+	SeatsSold        int             `json:"seats_sold"`
+	OccupancyPercent float64         `json:"occupancy_percent"`
+	TicketSales      models.Money    `json:"ticket_sales"` // Seat prices before discounts, fees and tax
+}
+
+// ShowReport is a show's occupancy and takings
+type ShowReport struct {
+	ShowID           string          `json:"show_id"`
+	TheatreID        string          `json:"theatre_id"`
+	ScreenID         string          `json:"screen_id"`
+	StartTime        time.Time       `json:"start_time"`
+	Capacity         int             `json:"capacity"`
+	SeatsSold        int             `json:"seats_sold"` // Seats of confirmed bookings
+	OccupancyPercent float64         `json:"occupancy_percent"`
+	SeatTypes        []SeatTypeSales `json:"seat_types"` // Cheapest tier first
+	Revenue          RevenueTotals   `json:"revenue"`
+	Attended         int             `json:"attended,omitempty"` // Seats whose ticket was scanned; counted when the show is closed out
+	NoShows          int             `json:"no_shows,omitempty"`
+	Final            bool            `json:"final,omitempty"` // The show is closed out, so its figures won't change
+}
+
+// DailyRevenue is one day of a theatre's takings
+type DailyRevenue struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	RevenueTotals
+}
+
+// TheatreRevenueReport is a theatre's takings per day, by the day each payment was taken
+type TheatreRevenueReport struct {
+	TheatreID string         `json:"theatre_id"`
+	Days      []DailyRevenue `json:"days"` // Every day in the range, oldest first
+	Total     RevenueTotals  `json:"total"`
+}
+
+// SettlementStatement is every settlement of a theatre, with what is still owed and what has been paid
+type SettlementStatement struct {
+	TheatreID   string               `json:"theatre_id"`
+	Settlements []*models.Settlement `json:"settlements"` // Oldest period first
+	Outstanding models.Money         `json:"outstanding"` // Payable on pending settlements
+	Paid        models.Money         `json:"paid"`
+}
+
+// MovieReviews is one page of a movie's reviews
+type MovieReviews struct {
+	Reviews []*models.Review `json:"reviews"`
+	Total   int              `json:"total"`
+	Offset  int              `json:"offset"`
+	Limit   int              `json:"limit"`
+}
+
+// RatingSummary aggregates a movie's approved reviews
+type RatingSummary struct {
+	MovieID      string      `json:"movie_id"`
+	Rating       float32     `json:"rating"` // Out of 10, as shown on the movie
+	ReviewCount  int         `json:"review_count"`
+	AverageStars float64     `json:"average_stars"`
+	Distribution map[int]int `json:"distribution"` // Stars -> number of reviews
+}
+
+// TicketValidation is what gate staff see after scanning a ticket
+type TicketValidation struct {
+	Ticket     *models.Ticket `json:"ticket"`
+	Title      string         `json:"title"` // Movie or live event
+	ScreenName string         `json:"screen_name"`
+	ShowStart  time.Time      `json:"show_start"`
+	Seats      []string       `json:"seats"`
+}
+
+// LineItem is one priced entry on a booking; discounts have negative amounts
+type LineItem struct {
+	Description string       `json:"description"`
+	Amount      models.Money `json:"amount"`
+}
+
+// NearbyTheatre is a theatre found by location search with its distance from the search point
+type NearbyTheatre struct {
+	Theatre    *models.Theatre `json:"theatre"`
+	DistanceKm float64         `json:"distance_km"`
+}
+
+// TheatreOptions holds optional inputs for CreateTheatre
+type TheatreOptions struct {
+	Location *models.GeoPoint
+}
+
+// TheatreOption configures optional CreateTheatre behaviour
+type TheatreOption func(*TheatreOptions)
+
+// WithLocation stores the theatre's coordinates so it shows up in nearby search
+func WithLocation(location models.GeoPoint) TheatreOption {
+	return func(o *TheatreOptions) {
+		o.Location = &location
+	}
+}
+
+// ShowOptions holds optional inputs for CreateShow
+type ShowOptions struct {
+	Format         models.ShowFormat
+	Language       models.Language
+	BookingTimeout time.Duration
+}
+
+// ShowOption configures optional CreateShow behaviour
+type ShowOption func(*ShowOptions)
+
+// WithFormat screens the show in 3D, IMAX or 4DX instead of 2D, adding the format's surcharge to every seat
+func WithFormat(format models.ShowFormat) ShowOption {
+	return func(o *ShowOptions) {
+		o.Format = format
+	}
+}
+
+// WithLanguage screens a dubbed version instead of the movie's original language
+func WithLanguage(language models.Language) ShowOption {
+	return func(o *ShowOptions) {
+		o.Language = language
+	}
+}
+
+// WithBookingTimeout gives the show its own payment window instead of the theatre's, e.g. 5 minutes for a blockbuster opening
+func WithBookingTimeout(timeout time.Duration) ShowOption {
+	return func(o *ShowOptions) {
+		o.BookingTimeout = timeout
+	}
+}
+
+// MovieOptions holds optional inputs for CreateMovie
+type MovieOptions struct {
+	Certificate models.Certificate
+}
+
+// MovieOption configures optional CreateMovie behaviour
+type MovieOption func(*MovieOptions)
+
+// WithCertificate rates the movie, e.g. A for adults only; unrated movies can be booked by anyone
+func WithCertificate(certificate models.Certificate) MovieOption {
+	return func(o *MovieOptions) {
+		o.Certificate = certificate
+	}
+}
+
+// BookingOptions holds optional inputs for CreateBooking
+type BookingOptions struct {
+	CouponCode    string
+	HoldID        string
+	WithGuardian  bool
+	BulkBookingID string
+	AddOns        []models.AddOnRequest
+}
+
+// BookingOption configures optional CreateBooking behaviour
+type BookingOption func(*BookingOptions)
+
+// WithGuardian books an age-rated show for a viewer accompanied by an adult guardian, waiving the certificate check.
+// The booking is flagged so staff can check the guardian at the door.
+func WithGuardian() BookingOption {
+	return func(o *BookingOptions) {
+		o.WithGuardian = true
+	}
+}
+
+// WithBulkBooking books a corporate block. The account isn't the audience, so its anti-hoarding limits and
+// the age certificate don't apply; each employee's age is checked when they redeem a code.
+func WithBulkBooking(bulkBookingID string) BookingOption {
+	return func(o *BookingOptions) {
+		o.BulkBookingID = bulkBookingID
+	}
+}
+
+// WithHold books the seats of an existing hold instead of placing a new one
+func WithHold(holdID string) BookingOption {
+	return func(o *BookingOptions) {
+		o.HoldID = holdID
+	}
+}
+
+// WithCoupon applies a promo code to the booking
+func WithCoupon(code string) BookingOption {
+	return func(o *BookingOptions) {
+		o.CouponCode = code
+	}
+}
+
+// WithAddOns buys add-ons such as cancellation insurance with the booking; they are paid for with it
+func WithAddOns(addOns ...models.AddOnRequest) BookingOption {
+	return func(o *BookingOptions) {
+		o.AddOns = append(o.AddOns, addOns...)
+	}
+}
+
+// PaymentOptions holds optional inputs for ProcessPayment, RetryPayment and ChargeSupplement
+type PaymentOptions struct {
+	TenureMonths int
+	PayerID      string // Who pays, when it isn't the booking's owner
+	InstrumentID string // A saved instrument of the payer's to pay with
+}
+
+// PaymentOption configures optional payment behaviour
+type PaymentOption func(*PaymentOptions)
+
+// WithTenure picks the EMI tenure in months; EMI payments require one
+func WithTenure(months int) PaymentOption {
+	return func(o *PaymentOptions) {
+		o.TenureMonths = months
+	}
+}
+
+// WithPayer charges someone other than the booking's owner, e.g. the buyer of a resold booking
+func WithPayer(userID string) PaymentOption {
+	return func(o *PaymentOptions) {
+		o.PayerID = userID
+	}
+}
+
+// WithInstrument pays with one of the payer's saved instruments instead of details typed in for this payment
+func WithInstrument(instrumentID string) PaymentOption {
+	return func(o *PaymentOptions) {
+		o.InstrumentID = instrumentID
+	}
+}
+
+// payer returns who is paying for the booking
+func (o PaymentOptions) payer(booking *models.Booking) string {
+	if o.PayerID != "" {
+		return o.PayerID
+	}
+	return booking.UserID
+}
+
+// RefundOptions holds optional inputs for InitiateRefund
+type RefundOptions struct {
+	ToWallet bool
+}
+
+// RefundOption configures optional InitiateRefund behaviour
+type RefundOption func(*RefundOptions)
+
+// ToWallet credits the refund to the user's wallet instantly instead of returning it to the original payment method
+func ToWallet() RefundOption {
+	return func(o *RefundOptions) {
+		o.ToWallet = true
+	}
 }
 
 // PaymentResult represents payment processing result (Strategy Pattern)
 type PaymentResult struct {
-	Success       bool   `json:"success"`
-	TransactionID string `json:"transaction_id"`
-	Response      string `json:"response"`
-	ErrorMessage  string `json:"error_message,omitempty"`
+	Success       bool                    `json:"success"`
+	TransactionID string                  `json:"transaction_id"`
+	Response      string                  `json:"response"`
+	ErrorMessage  string                  `json:"error_message,omitempty"`
+	Installments  *models.InstallmentPlan `json:"installments,omitempty"` // Set by EMI and pay-later strategies
+	Pending       bool                    `json:"pending,omitempty"`      // QueryTransaction: the charge is still being processed
+	Challenge     *models.OTPChallenge    `json:"challenge,omitempty"`    // Requires action: nothing is charged until the OTP is entered
+}
+
+// CatalogMovie is one listing from a movie source, already mapped onto our genres and languages
+type CatalogMovie struct {
+	ExternalID  string // Source-prefixed key such as tmdb:27205
+	Title       string
+	Overview    string
+	Runtime     time.Duration
+	Genres      []models.Genre // Primary first; genres we don't list are dropped
+	Language    models.Language
+	Certificate models.Certificate // Empty when the source doesn't rate
+	PosterURL   string
+	ReleaseDate time.Time
+	Rating      float32 // Out of 10
+}
+
+// CatalogImport summarises one import run
+type CatalogImport struct {
+	Source  string   `json:"source"`
+	Created int      `json:"created"`
+	Updated int      `json:"updated"`
+	Skipped []string `json:"skipped,omitempty"` // Why each rejected listing was left out
 }
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ListingsView keeps the trending and now-showing listings of every city precomputed - demonstrates
+// Materialized View Pattern: Refresh rebuilds them from the repositories off the request path, so a listing
+// call is a map lookup. Listings lag bookings and show changes by up to the refresh interval.
+type ListingsView struct {
+	movieRepo   repositories.MovieRepository
+	showRepo    repositories.ShowRepository
+	theatreRepo repositories.TheatreRepository
+	bookingRepo repositories.BookingRepository
+	config      models.ListingsConfig
+	clock       clock.Clock
+
+	refreshing  sync.Mutex // One rebuild at a time
+	mutex       sync.RWMutex
+	trending    map[string][]*TrendingMovie // By lower-case city; "" ranks every city together
+	nowShowing  map[listingKey][]*NowShowingMovie
+	refreshedAt time.Time
+}
+
+// listingKey is a city's now-showing list for one day
+type listingKey struct {
+	city string // Lower-case; "" is every city
+	day  string // time.DateOnly in the server's zone
+}
+
+// NewListingsView creates an empty view; the first listing call or Refresh fills it.
+// Zero config fields use models.DefaultListingsConfig.
+func NewListingsView(movieRepo repositories.MovieRepository, showRepo repositories.ShowRepository, theatreRepo repositories.TheatreRepository, bookingRepo repositories.BookingRepository, config models.ListingsConfig, clk clock.Clock) *ListingsView {
+	defaults := models.DefaultListingsConfig()
+	if config.TrendingWindow <= 0 {
+		config.TrendingWindow = defaults.TrendingWindow
+	}
+	if config.TrendingLimit <= 0 {
+		config.TrendingLimit = defaults.TrendingLimit
+	}
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = defaults.RefreshInterval
+	}
+	if config.Days <= 0 {
+		config.Days = defaults.Days
+	}
+	return &ListingsView{
+		movieRepo:   movieRepo,
+		showRepo:    showRepo,
+		theatreRepo: theatreRepo,
+		bookingRepo: bookingRepo,
+		config:      config,
+		clock:       clk,
+	}
+}
+
+// RefreshInterval is how often the owner should call Refresh
+func (v *ListingsView) RefreshInterval() time.Duration {
+	return v.config.RefreshInterval
+}
+
+// Refresh rebuilds every listing and swaps them in at once; readers keep the old ones until then
+func (v *ListingsView) Refresh(ctx context.Context) error {
+	v.refreshing.Lock()
+	defer v.refreshing.Unlock()
+
+	now := v.clock.Now()
+	today := startOfDay(now)
+	trending, nowShowing, err := v.build(ctx, now, today, today.AddDate(0, 0, v.config.Days), true)
+	if err != nil {
+		return err
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.trending, v.nowShowing, v.refreshedAt = trending, nowShowing, now
+	return nil
+}
+
+// Trending returns the city's most booked movies over the trending window, most seats first
+func (v *ListingsView) Trending(ctx context.Context, city string) ([]*TrendingMovie, error) {
+	if err := v.ensureFresh(ctx); err != nil {
+		return nil, err
+	}
+
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return append([]*TrendingMovie{}, v.trending[strings.ToLower(city)]...), nil
+}
+
+// NowShowing returns the movies with bookable shows in the city on date's day, most shows first.
+// Days past the cached range are computed on the spot.
+func (v *ListingsView) NowShowing(ctx context.Context, city string, date time.Time) ([]*NowShowingMovie, error) {
+	if err := v.ensureFresh(ctx); err != nil {
+		return nil, err
+	}
+
+	day := startOfDay(date)
+	key := listingKey{city: strings.ToLower(city), day: day.Format(time.DateOnly)}
+	v.mutex.RLock()
+	today := startOfDay(v.refreshedAt)
+	cached := !day.Before(today) && day.Before(today.AddDate(0, 0, v.config.Days))
+	listing := append([]*NowShowingMovie{}, v.nowShowing[key]...)
+	v.mutex.RUnlock()
+	if cached {
+		return listing, nil
+	}
+
+	_, nowShowing, err := v.build(ctx, v.clock.Now(), day, day.AddDate(0, 0, 1), false)
+	if err != nil {
+		return nil, err
+	}
+	return append([]*NowShowingMovie{}, nowShowing[key]...), nil
+}
+
+// ensureFresh rebuilds the listings when nothing has refreshed them for two intervals, e.g. on first use
+// or when no background worker runs
+func (v *ListingsView) ensureFresh(ctx context.Context) error {
+	v.mutex.RLock()
+	refreshedAt := v.refreshedAt
+	v.mutex.RUnlock()
+
+	if !refreshedAt.IsZero() && v.clock.Now().Sub(refreshedAt) < 2*v.config.RefreshInterval {
+		return nil
+	}
+	return v.Refresh(ctx)
+}
+
+// build reads the movie shows starting in [from, to) of every theatre - and, for trending, those since the
+// window opened - and aggregates them per city and for all cities together
+func (v *ListingsView) build(ctx context.Context, now, from, to time.Time, withTrending bool) (map[string][]*TrendingMovie, map[listingKey][]*NowShowingMovie, error) {
+	theatres, err := v.theatreRepo.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	windowStart := now.Add(-v.config.TrendingWindow)
+	scanFrom := from
+	if withTrending {
+		// A booking made in the window is for a show starting after it was made
+		scanFrom = minTime(from, windowStart)
+	}
+
+	movies := make(map[string]*models.Movie)
+	trending := make(map[string]map[string]*TrendingMovie)
+	nowShowing := make(map[listingKey]map[string]*NowShowingMovie)
+	for _, theatre := range theatres {
+		shows, err := v.showRepo.GetByTheatreBetween(ctx, theatre.ID, scanFrom, to)
+		if err != nil {
+			return nil, nil, err
+		}
+		cities := []string{strings.ToLower(theatre.City), ""}
+
+		for _, show := range shows {
+			if !show.IsMovie() {
+				continue
+			}
+			movie, ok := movies[show.MovieID]
+			if !ok {
+				if movie, err = v.movieRepo.GetByID(ctx, show.MovieID); err != nil {
+					return nil, nil, err
+				}
+				movies[show.MovieID] = movie
+			}
+
+			if withTrending {
+				seats, bookings, err := v.recentBookings(ctx, show.ID, windowStart)
+				if err != nil {
+					return nil, nil, err
+				}
+				if bookings > 0 {
+					for _, city := range cities {
+						entry := trendingEntry(trending, city, movie)
+						entry.SeatsBooked += seats
+						entry.Bookings += bookings
+					}
+				}
+			}
+
+			if show.StartTime.Before(from) || !show.CanBeBooked(v.clock.Now()) {
+				continue
+			}
+			day := startOfDay(show.StartTime).Format(time.DateOnly)
+			for _, city := range cities {
+				nowShowingEntry(nowShowing, listingKey{city: city, day: day}, movie).add(show)
+			}
+		}
+	}
+	return rankTrending(trending, v.config.TrendingLimit), rankNowShowing(nowShowing), nil
+}
+
+// recentBookings counts the show's confirmed bookings, and their seats, made since windowStart
+func (v *ListingsView) recentBookings(ctx context.Context, showID string, windowStart time.Time) (seats, bookings int, err error) {
+	showBookings, err := v.bookingRepo.GetByShowID(ctx, showID)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, booking := range showBookings {
+		if booking.GetStatus() == models.BookingStatusConfirmed && !booking.BookingTime.Before(windowStart) {
+			seats += booking.GetSeatCount()
+			bookings++
+		}
+	}
+	return seats, bookings, nil
+}
+
+func trendingEntry(trending map[string]map[string]*TrendingMovie, city string, movie *models.Movie) *TrendingMovie {
+	if trending[city] == nil {
+		trending[city] = make(map[string]*TrendingMovie)
+	}
+	entry, ok := trending[city][movie.ID]
+	if !ok {
+		entry = &TrendingMovie{Movie: movie}
+		trending[city][movie.ID] = entry
+	}
+	return entry
+}
+
+func nowShowingEntry(nowShowing map[listingKey]map[string]*NowShowingMovie, key listingKey, movie *models.Movie) *NowShowingMovie {
+	if nowShowing[key] == nil {
+		nowShowing[key] = make(map[string]*NowShowingMovie)
+	}
+	entry, ok := nowShowing[key][movie.ID]
+	if !ok {
+		entry = &NowShowingMovie{Movie: movie, theatres: make(map[string]bool)}
+		nowShowing[key][movie.ID] = entry
+	}
+	return entry
+}
+
+// add counts the show towards the movie's listing
+func (m *NowShowingMovie) add(show *models.Show) {
+	m.Shows++
+	m.theatres[show.TheatreID] = true
+	m.Theatres = len(m.theatres)
+	if m.FirstShow.IsZero() || show.StartTime.Before(m.FirstShow) {
+		m.FirstShow = show.StartTime
+	}
+	if !slices.Contains(m.Formats, show.Format) {
+		m.Formats = append(m.Formats, show.Format)
+	}
+	if !slices.Contains(m.Languages, show.Language) {
+		m.Languages = append(m.Languages, show.Language)
+	}
+}
+
+// rankTrending orders each city's movies by seats booked, then bookings, then title, and keeps the top limit
+func rankTrending(trending map[string]map[string]*TrendingMovie, limit int) map[string][]*TrendingMovie {
+	ranked := make(map[string][]*TrendingMovie, len(trending))
+	for city, byMovie := range trending {
+		list := make([]*TrendingMovie, 0, len(byMovie))
+		for _, entry := range byMovie {
+			list = append(list, entry)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			a, b := list[i], list[j]
+			if a.SeatsBooked != b.SeatsBooked {
+				return a.SeatsBooked > b.SeatsBooked
+			}
+			if a.Bookings != b.Bookings {
+				return a.Bookings > b.Bookings
+			}
+			return a.Movie.Title < b.Movie.Title
+		})
+		for i, entry := range list {
+			entry.Rank = i + 1
+		}
+		ranked[city] = list[:min(limit, len(list))]
+	}
+	return ranked
+}
+
+// rankNowShowing orders each day's movies by show count, then title
+func rankNowShowing(nowShowing map[listingKey]map[string]*NowShowingMovie) map[listingKey][]*NowShowingMovie {
+	ranked := make(map[listingKey][]*NowShowingMovie, len(nowShowing))
+	for key, byMovie := range nowShowing {
+		list := make([]*NowShowingMovie, 0, len(byMovie))
+		for _, entry := range byMovie {
+			list = append(list, entry)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Shows != list[j].Shows {
+				return list[i].Shows > list[j].Shows
+			}
+			return list[i].Movie.Title < list[j].Movie.Title
+		})
+		ranked[key] = list
+	}
+	return ranked
+}
+
+// startOfDay is midnight at the start of t's day in the server's zone
+func startOfDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
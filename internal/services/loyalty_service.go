@@ -0,0 +1,169 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"sync"
+)
+
+// LoyaltyServiceImpl implements LoyaltyService - points earned on confirmed bookings, spent towards new ones
+type LoyaltyServiceImpl struct {
+	loyaltyRepo repositories.LoyaltyRepository
+	userRepo    repositories.UserRepository
+	bookingRepo repositories.BookingRepository
+	config      models.LoyaltyConfig
+	lockManager locks.LockManager // Shared with payments so points never change a booking mid-charge
+	mutex       sync.Mutex        // Serializes opening accounts so a user never gets two
+	clock       clock.Clock
+}
+
+// NewLoyaltyService creates a new loyalty service
+func NewLoyaltyService(
+	loyaltyRepo repositories.LoyaltyRepository,
+	userRepo repositories.UserRepository,
+	bookingRepo repositories.BookingRepository,
+	config models.LoyaltyConfig,
+	lockManager locks.LockManager,
+	clock clock.Clock,
+) LoyaltyService {
+	return &LoyaltyServiceImpl{
+		loyaltyRepo: loyaltyRepo,
+		userRepo:    userRepo,
+		bookingRepo: bookingRepo,
+		config:      config,
+		lockManager: lockManager,
+		clock:       clock,
+	}
+}
+
+// GetAccount returns the user's loyalty account, opening an empty one on first use
+func (ls *LoyaltyServiceImpl) GetAccount(ctx context.Context, userID string) (*models.LoyaltyAccount, error) {
+	account, err := ls.loyaltyRepo.GetByUserID(ctx, userID)
+	if !errors.Is(err, models.ErrLoyaltyAccountNotFound) {
+		return account, err
+	}
+
+	ls.mutex.Lock()
+	defer ls.mutex.Unlock()
+
+	// Another caller may have opened it while we waited
+	if account, err := ls.loyaltyRepo.GetByUserID(ctx, userID); !errors.Is(err, models.ErrLoyaltyAccountNotFound) {
+		return account, err
+	}
+
+	if _, err := ls.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	account, err = models.NewLoyaltyAccount(userID, ls.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ls.loyaltyRepo.Create(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// AwardPoints credits the points a confirmed booking earned on what was actually paid; awarding twice is a no-op
+func (ls *LoyaltyServiceImpl) AwardPoints(ctx context.Context, bookingID string) (int64, error) {
+	booking, err := ls.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return 0, err
+	}
+
+	if booking.GetStatus() != models.BookingStatusConfirmed {
+		return 0, models.ErrBookingNotConfirmed
+	}
+
+	points := ls.config.PointsFor(booking.TotalAmount)
+	if points == 0 {
+		return 0, nil
+	}
+
+	account, err := ls.GetAccount(ctx, booking.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	if !account.Earn(booking.ID, points, ls.clock.Now()) {
+		return 0, nil
+	}
+	return points, ls.loyaltyRepo.Update(ctx, account)
+}
+
+// RedeemPoints spends points towards a pending booking, lowering what is left to pay.
+// The points leave the account straight away and come back if the booking is cancelled or expires.
+func (ls *LoyaltyServiceImpl) RedeemPoints(ctx context.Context, userID, bookingID string, points int64) (*models.Booking, error) {
+	if ls.config.PointValue <= 0 || points <= 0 {
+		return nil, models.ErrInvalidLoyaltyData
+	}
+
+	unlock, err := ls.lockManager.Lock(ctx, locks.BookingKey(paymentLockOwner, bookingID))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	booking, err := ls.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.UserID != userID {
+		return nil, models.ErrForbidden
+	}
+
+	if booking.IsExpired(ls.clock.Now()) {
+		return nil, models.ErrBookingExpired
+	}
+
+	account, err := ls.GetAccount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := account.Redeem(booking.ID, points, ls.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := booking.ApplyLoyaltyPoints(points, ls.config.ValueOf(points, booking.TotalAmount.Currency), ls.clock.Now()); err != nil {
+		account.Reverse(booking.ID, ls.clock.Now())
+		return nil, err
+	}
+
+	if err := ls.bookingRepo.Update(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	if err := ls.loyaltyRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// ReverseBooking undoes a cancelled or expired booking's points
+func (ls *LoyaltyServiceImpl) ReverseBooking(ctx context.Context, bookingID string) error {
+	booking, err := ls.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+
+	account, err := ls.loyaltyRepo.GetByUserID(ctx, booking.UserID)
+	if errors.Is(err, models.ErrLoyaltyAccountNotFound) {
+		return nil // Never earned or spent anything
+	}
+	if err != nil {
+		return err
+	}
+
+	if account.Reverse(booking.ID, ls.clock.Now()) == 0 {
+		return nil
+	}
+	return ls.loyaltyRepo.Update(ctx, account)
+}
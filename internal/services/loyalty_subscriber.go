@@ -0,0 +1,25 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/events"
+	"context"
+)
+
+// RegisterLoyaltySubscriber awards points on confirmation and reverses them when a booking falls through - demonstrates Observer Pattern
+func RegisterLoyaltySubscriber(bus events.EventBus, loyaltyService LoyaltyService) {
+	bus.Subscribe(events.EventBookingConfirmed, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingConfirmed)
+		_, err := loyaltyService.AwardPoints(ctx, e.BookingID)
+		return err
+	})
+
+	bus.Subscribe(events.EventBookingCancelled, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingCancelled)
+		return loyaltyService.ReverseBooking(ctx, e.BookingID)
+	})
+
+	bus.Subscribe(events.EventBookingExpired, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingExpired)
+		return loyaltyService.ReverseBooking(ctx, e.BookingID)
+	})
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// LogChannel is a notification channel that records what it would send instead of sending it. In a real
+// deployment each would wrap an email, SMS or push provider's client.
+type LogChannel struct {
+	channel models.NotificationChannel
+	icon    string
+	address func(user *models.User) string // Where the user is reached on this channel; empty when they can't be
+	logger  logging.Logger
+}
+
+// NewEmailChannel sends to the user's email address
+func NewEmailChannel(logger logging.Logger) *LogChannel {
+	return &LogChannel{channel: models.NotificationChannelEmail, icon: "📧", logger: logger, address: func(user *models.User) string {
+		return user.Email
+	}}
+}
+
+// NewSMSChannel texts the user's phone number
+func NewSMSChannel(logger logging.Logger) *LogChannel {
+	return &LogChannel{channel: models.NotificationChannelSMS, icon: "📱", logger: logger, address: func(user *models.User) string {
+		return user.PhoneNumber
+	}}
+}
+
+// NewPushChannel pushes to the user's devices, which are reached through their user ID
+func NewPushChannel(logger logging.Logger) *LogChannel {
+	return &LogChannel{channel: models.NotificationChannelPush, icon: "🔔", logger: logger, address: func(user *models.User) string {
+		return user.ID
+	}}
+}
+
+// DefaultNotificationChannels are the email, SMS, push and WhatsApp channels; which of them are used is up to
+// the queue's config
+func DefaultNotificationChannels(whatsAppTemplates *WhatsAppTemplates, logger logging.Logger) []NotificationChannel {
+	return []NotificationChannel{
+		NewEmailChannel(logger),
+		NewSMSChannel(logger),
+		NewPushChannel(logger),
+		NewWhatsAppChannel(whatsAppTemplates, logger),
+	}
+}
+
+func (c *LogChannel) Channel() models.NotificationChannel { return c.channel }
+
+func (c *LogChannel) Deliver(ctx context.Context, user *models.User, notification *models.Notification) error {
+	to := c.address(user)
+	if to == "" {
+		return fmt.Errorf("%w: user %s has no %s address", models.ErrNotificationUndeliverable, user.ID, c.channel)
+	}
+
+	keys := make([]string, 0, len(notification.Fields))
+	for key := range notification.Fields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	args := []any{"channel", c.channel, "to", to, "user_id", user.ID}
+	for _, key := range keys {
+		args = append(args, key, notification.Fields[key])
+	}
+	for _, attachment := range notification.Attachments {
+		args = append(args, "attachment", fmt.Sprintf("%s (%d bytes)", attachment.Filename, len(attachment.Content)))
+	}
+	c.logger.Info(ctx, c.icon+" NOTIFICATION: "+notification.Subject, args...)
+	return nil
+}
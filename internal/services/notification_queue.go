@@ -0,0 +1,270 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// NotificationQueueImpl implements NotificationQueue - demonstrates Strategy Pattern for channels: each channel
+// is a plugin the queue hands notifications to. Every notification is stored before it is sent, and sent in the
+// background, so a slow or failing channel never holds up the booking that raised it. Failed deliveries are
+// retried with backoff by DispatchPending until they succeed or are dead-lettered.
+type NotificationQueueImpl struct {
+	notificationRepo repositories.NotificationRepository
+	userRepo         repositories.UserRepository
+	plugins          map[models.NotificationChannel]NotificationChannel
+	config           models.NotificationConfig
+	logger           logging.Logger
+	clock            clock.Clock
+	inFlight         map[string]bool // Notifications being sent, so the first attempt and the dispatcher never send one twice at once
+	mutex            sync.Mutex
+	sending          sync.WaitGroup // First attempts running in the background
+}
+
+// NewNotificationQueue creates a queue sending on config's channels with the given plugins; zero config fields
+// fall back to models.DefaultNotificationConfig
+func NewNotificationQueue(
+	notificationRepo repositories.NotificationRepository,
+	userRepo repositories.UserRepository,
+	channels []NotificationChannel,
+	config models.NotificationConfig,
+	logger logging.Logger,
+	clk clock.Clock,
+) NotificationQueue {
+	defaults := models.DefaultNotificationConfig()
+	if len(config.Channels) == 0 {
+		config.Channels = defaults.Channels
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaults.RetryBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaults.MaxBackoff
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+
+	plugins := make(map[models.NotificationChannel]NotificationChannel, len(channels))
+	for _, channel := range channels {
+		plugins[channel.Channel()] = channel
+	}
+
+	return &NotificationQueueImpl{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		plugins:          plugins,
+		config:           config,
+		logger:           logger,
+		clock:            clk,
+		inFlight:         make(map[string]bool),
+	}
+}
+
+// Enqueue stores one notification per configured channel the user prefers, then sends them in the background
+func (nq *NotificationQueueImpl) Enqueue(ctx context.Context, message NotificationMessage) error {
+	attachments := make([]models.NotificationAttachment, len(message.Attachments))
+	for i, attachment := range message.Attachments {
+		attachments[i] = models.NotificationAttachment(attachment)
+	}
+
+	var queued []*models.Notification
+	var errs []error
+	for _, channel := range nq.channelsFor(ctx, message.UserID) {
+		notification, err := models.NewNotification(message.Kind, channel, message.UserID, message.Subject, message.Fields, attachments, nq.clock.Now())
+		if err != nil {
+			return err
+		}
+		if err := nq.notificationRepo.Create(ctx, notification); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		queued = append(queued, notification)
+	}
+
+	for _, notification := range queued {
+		nq.sending.Add(1)
+		go func() {
+			defer nq.sending.Done()
+			nq.send(context.WithoutCancel(ctx), notification)
+		}()
+	}
+	return errors.Join(errs...)
+}
+
+// channelsFor picks the configured channels the user prefers. A user whose preferred channels are all switched
+// off still hears on every configured one rather than not at all.
+func (nq *NotificationQueueImpl) channelsFor(ctx context.Context, userID string) []models.NotificationChannel {
+	user, err := nq.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		// Delivery finds out the user is missing and dead-letters the notifications
+		return nq.config.Channels
+	}
+
+	var channels []models.NotificationChannel
+	for _, channel := range nq.config.Channels {
+		if user.Preferences.PrefersChannel(channel) {
+			channels = append(channels, channel)
+		}
+	}
+	if len(channels) == 0 {
+		return nq.config.Channels
+	}
+	return channels
+}
+
+// DispatchPending sends notifications whose retry is due, returning how many the channels took
+func (nq *NotificationQueueImpl) DispatchPending(ctx context.Context) (int, error) {
+	due, err := nq.notificationRepo.GetDue(ctx, nq.clock.Now(), nq.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, notification := range due {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+		if nq.send(ctx, notification) {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// GetDeadLetters returns the notifications that were given up on, oldest first
+func (nq *NotificationQueueImpl) GetDeadLetters(ctx context.Context) ([]*models.Notification, error) {
+	return nq.notificationRepo.GetByStatus(ctx, models.NotificationStatusDeadLettered)
+}
+
+// Replay requeues a dead-lettered notification and tries it again straight away
+func (nq *NotificationQueueImpl) Replay(ctx context.Context, notificationID string) (*models.Notification, error) {
+	notification, err := nq.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := notification.Requeue(nq.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := nq.notificationRepo.Update(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	nq.send(ctx, notification)
+	return notification, nil
+}
+
+// Wait blocks until the first attempts started by Enqueue have finished
+func (nq *NotificationQueueImpl) Wait() {
+	nq.sending.Wait()
+}
+
+// PurgeSent drops sent notifications older than the retention period
+func (nq *NotificationQueueImpl) PurgeSent(ctx context.Context, retention time.Duration) (int, error) {
+	return nq.notificationRepo.DeleteSentBefore(ctx, nq.clock.Now().Add(-retention))
+}
+
+// send makes one attempt at a due notification and records the outcome; it reports whether the channel took it
+func (nq *NotificationQueueImpl) send(ctx context.Context, notification *models.Notification) bool {
+	if !nq.claim(notification.ID) {
+		return false
+	}
+	defer nq.release(notification.ID)
+
+	// The other path may have sent it between being picked up and being claimed
+	if !notification.IsDue(nq.clock.Now()) {
+		return false
+	}
+
+	err := nq.deliver(ctx, notification)
+	if errors.Is(err, models.ErrNotificationSkipped) {
+		notification.MarkSkipped(err.Error())
+		nq.save(ctx, notification)
+		nq.logger.Debug(ctx, "notification skipped",
+			"notification_id", notification.ID, "kind", notification.Kind, "channel", notification.Channel, "reason", err)
+		return false
+	}
+	if err != nil {
+		maxAttempts := nq.config.MaxAttempts
+		if errors.Is(err, models.ErrNotificationUndeliverable) {
+			// Retrying can't fix a missing address or channel
+			maxAttempts = 0
+		}
+		nq.fail(ctx, notification, err, maxAttempts)
+		return false
+	}
+
+	notification.MarkSent(nq.clock.Now())
+	nq.save(ctx, notification)
+	return true
+}
+
+// deliver hands the notification to its channel's plugin
+func (nq *NotificationQueueImpl) deliver(ctx context.Context, notification *models.Notification) error {
+	plugin, ok := nq.plugins[notification.Channel]
+	if !ok {
+		return fmt.Errorf("%w: no %s channel is plugged in", models.ErrNotificationUndeliverable, notification.Channel)
+	}
+
+	user, err := nq.userRepo.GetByID(ctx, notification.UserID)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return fmt.Errorf("%w: %v", models.ErrNotificationUndeliverable, err)
+	}
+	if err != nil {
+		return err
+	}
+	return plugin.Deliver(ctx, user, notification)
+}
+
+// fail schedules the next attempt, or dead-letters the notification when it has none left
+func (nq *NotificationQueueImpl) fail(ctx context.Context, notification *models.Notification, cause error, maxAttempts int) {
+	retryAt := nq.clock.Now().Add(nq.config.Backoff(notification.Attempts + 1))
+	deadLettered := notification.MarkFailed(cause.Error(), retryAt, maxAttempts)
+	nq.save(ctx, notification)
+
+	if deadLettered {
+		nq.logger.Error(ctx, "notification dead-lettered",
+			"notification_id", notification.ID, "kind", notification.Kind, "channel", notification.Channel, "attempts", notification.Attempts, "error", cause)
+		return
+	}
+	nq.logger.Warn(ctx, "notification delivery failed, will retry",
+		"notification_id", notification.ID, "kind", notification.Kind, "channel", notification.Channel, "attempts", notification.Attempts, "retry_at", retryAt, "error", cause)
+}
+
+// save records a delivery attempt; a failed write only costs a duplicate send later
+func (nq *NotificationQueueImpl) save(ctx context.Context, notification *models.Notification) {
+	if err := nq.notificationRepo.Update(ctx, notification); err != nil {
+		nq.logger.Warn(ctx, "failed to record notification delivery", "notification_id", notification.ID, "error", err)
+	}
+}
+
+// claim marks a notification as being sent; false if someone else already is
+func (nq *NotificationQueueImpl) claim(notificationID string) bool {
+	nq.mutex.Lock()
+	defer nq.mutex.Unlock()
+
+	if nq.inFlight[notificationID] {
+		return false
+	}
+	nq.inFlight[notificationID] = true
+	return true
+}
+
+// release ends a send started by claim
+func (nq *NotificationQueueImpl) release(notificationID string) {
+	nq.mutex.Lock()
+	defer nq.mutex.Unlock()
+
+	delete(nq.inFlight, notificationID)
+}
@@ -1,30 +1,161 @@
 package services
 
 import (
-	"fmt"
-	"log"
+	"bookmyshow-lld/internal/models"
+	"context"
+	"errors"
+	"strings"
+	"time"
 )
 
-// NotificationServiceImpl implements NotificationService - demonstrates Observer Pattern
+// NotificationServiceImpl implements NotificationService - demonstrates Observer Pattern. It says what each
+// notification is about and leaves delivery to the queue, so sending returns as soon as the notification is stored.
 type NotificationServiceImpl struct {
-	// In a real implementation, this would have email/SMS service clients
+	queue NotificationQueue
 }
 
-// NewNotificationService creates a new notification service
-func NewNotificationService() NotificationService {
-	return &NotificationServiceImpl{}
+// NewNotificationService creates a notification service that queues everything it sends
+func NewNotificationService(queue NotificationQueue) NotificationService {
+	return &NotificationServiceImpl{queue: queue}
 }
 
 // SendBookingConfirmation sends booking confirmation notification - demonstrates Observer Pattern
-func (ns *NotificationServiceImpl) SendBookingConfirmation(userID, bookingID string) error {
-	message := fmt.Sprintf("Booking confirmed! Booking ID: %s for User: %s", bookingID, userID)
-	log.Printf("📧 NOTIFICATION: %s", message)
+func (ns *NotificationServiceImpl) SendBookingConfirmation(ctx context.Context, userID, bookingID, reference string, parking *models.ParkingReservation, attachments ...Attachment) error {
+	fields := map[string]string{"reference": reference, "booking_id": bookingID}
+	if parking != nil {
+		fields["parking_slots"] = strings.Join(parking.GetSlots(), ",")
+	}
+	return ns.queue.Enqueue(ctx, NotificationMessage{
+		Kind:        models.NotificationBookingConfirmed,
+		UserID:      userID,
+		Subject:     "booking confirmed",
+		Fields:      fields,
+		Attachments: attachments,
+	})
+}
+
+// SendRefundNotification notifies the user that money is on its way back
+func (ns *NotificationServiceImpl) SendRefundNotification(ctx context.Context, userID, refundID string, amount models.Money) error {
+	return ns.queue.Enqueue(ctx, NotificationMessage{
+		Kind:    models.NotificationRefundProcessed,
+		UserID:  userID,
+		Subject: "refund processed",
+		Fields:  map[string]string{"refund_id": refundID, "amount": amount.String()},
+	})
+}
+
+// SendPaymentFailure tells the user their payment didn't go through
+func (ns *NotificationServiceImpl) SendPaymentFailure(ctx context.Context, userID, bookingID, reference, reason string) error {
+	return ns.queue.Enqueue(ctx, NotificationMessage{
+		Kind:    models.NotificationPaymentFailed,
+		UserID:  userID,
+		Subject: "payment failed",
+		Fields:  map[string]string{"reference": reference, "booking_id": bookingID, "reason": reason},
+	})
+}
+
+// SendShowCancellation tells the user their show was called off and their money is coming back
+func (ns *NotificationServiceImpl) SendShowCancellation(ctx context.Context, userID, bookingID, reference, reason string) error {
+	return ns.queue.Enqueue(ctx, NotificationMessage{
+		Kind:    models.NotificationShowCancelled,
+		UserID:  userID,
+		Subject: "show cancelled, booking cancelled and any payment refunded in full",
+		Fields:  map[string]string{"reference": reference, "booking_id": bookingID, "reason": reason},
+	})
+}
+
+// SendShowRescheduled tells the user their show has moved to a new time
+func (ns *NotificationServiceImpl) SendShowRescheduled(ctx context.Context, userID, bookingID, reference string, startTime time.Time) error {
+	return ns.queue.Enqueue(ctx, NotificationMessage{
+		Kind:    models.NotificationShowRescheduled,
+		UserID:  userID,
+		Subject: "show rescheduled",
+		Fields:  map[string]string{"reference": reference, "booking_id": bookingID, "start_time": startTime.Format("Mon 02 Jan 15:04")},
+	})
+}
 
-	// In real implementation:
-	// - Send email confirmation
-	// - Send SMS notification
-	// - Push notification to mobile app
-	// - Update user's notification preferences
+// SendShowReminder reminds the user when and where their show is
+func (ns *NotificationServiceImpl) SendShowReminder(ctx context.Context, userID, bookingID, reference, title, theatre string, startTime time.Time) error {
+	return ns.queue.Enqueue(ctx, NotificationMessage{
+		Kind:    models.NotificationShowReminder,
+		UserID:  userID,
+		Subject: "upcoming show reminder",
+		Fields: map[string]string{
+			"reference":  reference,
+			"booking_id": bookingID,
+			"title":      title,
+			"theatre":    theatre,
+			"start_time": startTime.Format("Mon 02 Jan 15:04"),
+		},
+	})
+}
+
+// SendWatchlistReminder tells the user bookings have opened for a movie on their watchlist in their city
+func (ns *NotificationServiceImpl) SendWatchlistReminder(ctx context.Context, userID, movieID, title, city string, firstShow time.Time) error {
+	return ns.queue.Enqueue(ctx, NotificationMessage{
+		Kind:    models.NotificationWatchlistReminder,
+		UserID:  userID,
+		Subject: "bookings open for watchlisted movie",
+		Fields:  map[string]string{"title": title, "movie_id": movieID, "city": city, "first_show": firstShow.Format("Mon 02 Jan 15:04")},
+	})
+}
+
+// SendTransferOffer tells the user someone wants to hand them a booking, which they can accept or decline
+func (ns *NotificationServiceImpl) SendTransferOffer(ctx context.Context, userID, fromUserID, bookingID, reference string) error {
+	return ns.queue.Enqueue(ctx, NotificationMessage{
+		Kind:    models.NotificationTransferOffered,
+		UserID:  userID,
+		Subject: "booking offered to you",
+		Fields:  map[string]string{"reference": reference, "booking_id": bookingID, "from_user_id": fromUserID},
+	})
+}
+
+// SendTransferAccepted tells the previous owner their booking was handed over, and the new owner it is theirs,
+// with the ticket signed for them
+func (ns *NotificationServiceImpl) SendTransferAccepted(ctx context.Context, fromUserID, toUserID, bookingID, reference string, attachments ...Attachment) error {
+	return errors.Join(
+		ns.queue.Enqueue(ctx, NotificationMessage{
+			Kind:    models.NotificationTransferred,
+			UserID:  fromUserID,
+			Subject: "your booking now belongs to someone else, and your ticket no longer works",
+			Fields:  map[string]string{"reference": reference, "booking_id": bookingID, "to_user_id": toUserID},
+		}),
+		ns.queue.Enqueue(ctx, NotificationMessage{
+			Kind:        models.NotificationTransferred,
+			UserID:      toUserID,
+			Subject:     "booking transferred to you",
+			Fields:      map[string]string{"reference": reference, "booking_id": bookingID, "from_user_id": fromUserID},
+			Attachments: attachments,
+		}),
+	)
+}
+
+// SendTransferDeclined tells the owner the user they offered their booking to turned it down
+func (ns *NotificationServiceImpl) SendTransferDeclined(ctx context.Context, userID, toUserID, bookingID, reference string) error {
+	return ns.queue.Enqueue(ctx, NotificationMessage{
+		Kind:    models.NotificationTransferDeclined,
+		UserID:  userID,
+		Subject: "booking transfer declined, the booking is still yours",
+		Fields:  map[string]string{"reference": reference, "booking_id": bookingID, "to_user_id": toUserID},
+	})
+}
 
-	return nil
+// SendBookingResold tells the seller their listed booking sold and what they are refunded, and the buyer it is
+// theirs, with the ticket signed for them
+func (ns *NotificationServiceImpl) SendBookingResold(ctx context.Context, sellerID, buyerID, bookingID, reference string, payout models.Money, attachments ...Attachment) error {
+	return errors.Join(
+		ns.queue.Enqueue(ctx, NotificationMessage{
+			Kind:    models.NotificationBookingResold,
+			UserID:  sellerID,
+			Subject: "your booking was resold and your ticket no longer works",
+			Fields:  map[string]string{"reference": reference, "booking_id": bookingID, "refund": payout.String()},
+		}),
+		ns.queue.Enqueue(ctx, NotificationMessage{
+			Kind:        models.NotificationBookingResold,
+			UserID:      buyerID,
+			Subject:     "resale booking is yours",
+			Fields:      map[string]string{"reference": reference, "booking_id": bookingID},
+			Attachments: attachments,
+		}),
+	)
 }
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/models"
+	"context"
+)
+
+// RegisterNotificationSubscriber subscribes the notification service to domain events - demonstrates Observer Pattern.
+// Booking confirmations carry the printable ticket when a renderer is given, a calendar invite and the entry QR code
+// when a ticket service is, and the booking's parking slots when a parking service is. Both users hear about a booking transfer.
+func RegisterNotificationSubscriber(bus events.EventBus, notificationSvc NotificationService, renderer TicketRenderer, ticketService TicketService, parkingService ParkingService) {
+	bus.Subscribe(events.EventBookingConfirmed, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingConfirmed)
+
+		// A ticket or invite that fails to render shouldn't hold back the confirmation; both can still be fetched later
+		var attachments []Attachment
+		if renderer != nil {
+			if ticket, err := renderer.RenderTicket(ctx, e.BookingID); err == nil {
+				attachments = append(attachments, *ticket)
+			}
+		}
+		if ticketService != nil {
+			if invite, err := ticketService.ExportICS(ctx, e.BookingID); err == nil {
+				attachments = append(attachments, *invite)
+			}
+			if qrCode, err := ticketService.ExportQRCode(ctx, e.BookingID); err == nil {
+				attachments = append(attachments, *qrCode)
+			}
+		}
+		var parking *models.ParkingReservation
+		if parkingService != nil {
+			if reservation, err := parkingService.GetReservation(ctx, e.BookingID); err == nil {
+				parking = reservation
+			}
+		}
+		return notificationSvc.SendBookingConfirmation(ctx, e.UserID, e.BookingID, e.Reference, parking, attachments...)
+	})
+
+	bus.Subscribe(events.EventBookingTransferOffered, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingTransferOffered)
+		return notificationSvc.SendTransferOffer(ctx, e.ToUserID, e.FromUserID, e.BookingID, e.Reference)
+	})
+
+	// The new owner gets the ticket signed for them; the ticket subscriber has reissued it by now
+	bus.Subscribe(events.EventBookingTransferred, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingTransferred)
+
+		var attachments []Attachment
+		if renderer != nil {
+			if ticket, err := renderer.RenderTicket(ctx, e.BookingID); err == nil {
+				attachments = append(attachments, *ticket)
+			}
+		}
+		return notificationSvc.SendTransferAccepted(ctx, e.FromUserID, e.ToUserID, e.BookingID, e.Reference, attachments...)
+	})
+
+	bus.Subscribe(events.EventBookingTransferDeclined, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingTransferDeclined)
+		return notificationSvc.SendTransferDeclined(ctx, e.FromUserID, e.ToUserID, e.BookingID, e.Reference)
+	})
+
+	// The buyer gets the ticket signed for them, as with a transfer
+	bus.Subscribe(events.EventBookingResold, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingResold)
+
+		var attachments []Attachment
+		if renderer != nil {
+			if ticket, err := renderer.RenderTicket(ctx, e.BookingID); err == nil {
+				attachments = append(attachments, *ticket)
+			}
+		}
+		return notificationSvc.SendBookingResold(ctx, e.SellerID, e.BuyerID, e.BookingID, e.Reference, e.SellerPayout, attachments...)
+	})
+
+	bus.Subscribe(events.EventPaymentFailed, func(ctx context.Context, event events.Event) error {
+		e := event.(events.PaymentFailed)
+		return notificationSvc.SendPaymentFailure(ctx, e.UserID, e.BookingID, e.Reference, e.Reason)
+	})
+
+	bus.Subscribe(events.EventRefundProcessed, func(ctx context.Context, event events.Event) error {
+		e := event.(events.RefundProcessed)
+		return notificationSvc.SendRefundNotification(ctx, e.UserID, e.RefundID, e.Amount)
+	})
+}
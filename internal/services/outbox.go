@@ -0,0 +1,255 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// OutboxEventBus records every published event in the outbox before handing it to the subscribers - demonstrates
+// Outbox Pattern. Publish delivers straight away, so the happy path is as synchronous as the plain bus; a failed
+// delivery stays pending and DispatchPending retries it with backoff until it succeeds or is dead-lettered.
+// Published inside a repositories.UnitOfWork transaction, the message is written with the transaction's other
+// writes and delivered once it commits, so an event is neither lost nor announced for a change rolled back.
+// Delivery is at least once: a retry reaches every subscriber again, so subscribers must be idempotent.
+type OutboxEventBus struct {
+	bus        events.EventBus
+	outboxRepo repositories.OutboxRepository
+	config     models.OutboxConfig
+	logger     logging.Logger
+	clock      clock.Clock
+	inFlight   map[string]bool // Messages being delivered, so Publish and the dispatcher never deliver one twice at once
+	mutex      sync.Mutex
+}
+
+// NewOutboxEventBus wraps bus with an outbox; zero config fields fall back to models.DefaultOutboxConfig
+func NewOutboxEventBus(
+	bus events.EventBus,
+	outboxRepo repositories.OutboxRepository,
+	config models.OutboxConfig,
+	logger logging.Logger,
+	clk clock.Clock,
+) OutboxService {
+	defaults := models.DefaultOutboxConfig()
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaults.RetryBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaults.MaxBackoff
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+
+	return &OutboxEventBus{
+		bus:        bus,
+		outboxRepo: outboxRepo,
+		config:     config,
+		logger:     logger,
+		clock:      clk,
+		inFlight:   make(map[string]bool),
+	}
+}
+
+// Subscribe registers a handler on the wrapped bus
+func (ob *OutboxEventBus) Subscribe(eventType events.EventType, handler events.Handler) {
+	ob.bus.Subscribe(eventType, handler)
+}
+
+// SubscribeAll registers a catch-all handler on the wrapped bus
+func (ob *OutboxEventBus) SubscribeAll(handler events.Handler) {
+	ob.bus.SubscribeAll(handler)
+}
+
+// Publish writes the event to the outbox, then tries to deliver it. Only a failed outbox write is returned;
+// the event is then delivered directly so subscribers still hear about it, just without a retry. Inside a
+// transaction, see publishOnCommit.
+func (ob *OutboxEventBus) Publish(ctx context.Context, event events.Event) error {
+	if repositories.TransactionFrom(ctx) != nil {
+		return ob.publishOnCommit(ctx, event)
+	}
+
+	message, err := ob.record(ctx, event)
+	if err != nil {
+		return errors.Join(fmt.Errorf("writing %s to outbox: %w", event.Type(), err), ob.bus.Publish(ctx, event))
+	}
+
+	ob.deliver(ctx, message, event)
+	return nil
+}
+
+// publishOnCommit writes the event to the outbox in ctx's transaction and delivers it once that commits. A failed
+// write is returned for the caller to roll back with; a rollback drops the message. The message stays claimed
+// until the commit, so the dispatcher can't deliver it first.
+func (ob *OutboxEventBus) publishOnCommit(ctx context.Context, event events.Event) error {
+	message, err := ob.newMessage(event)
+	if err != nil {
+		return fmt.Errorf("writing %s to outbox: %w", event.Type(), err)
+	}
+
+	ob.claim(message.ID)
+	if err := ob.outboxRepo.Create(ctx, message); err != nil {
+		ob.release(message.ID)
+		return fmt.Errorf("writing %s to outbox: %w", event.Type(), err)
+	}
+
+	outside := repositories.OutsideTransaction(ctx)
+	repositories.OnRollback(ctx, func() {
+		ob.release(message.ID)
+		if err := ob.outboxRepo.Delete(outside, message.ID); err != nil {
+			ob.logger.Warn(outside, "failed to drop rolled back outbox message", "message_id", message.ID, "error", err)
+		}
+	})
+	repositories.OnCommit(ctx, func() {
+		ob.release(message.ID)
+		ob.deliver(outside, message, event)
+	})
+	return nil
+}
+
+// DispatchPending delivers messages whose retry is due, returning how many were delivered
+func (ob *OutboxEventBus) DispatchPending(ctx context.Context) (int, error) {
+	due, err := ob.outboxRepo.GetDue(ctx, ob.clock.Now(), ob.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, message := range due {
+		if err := ctx.Err(); err != nil {
+			return delivered, err
+		}
+
+		event, err := events.Decode(events.EventType(message.EventType), message.Payload)
+		if err != nil {
+			// Retrying can't fix a payload we can't read
+			ob.fail(ctx, message, err, ob.config.MaxAttempts)
+			continue
+		}
+		if ob.deliver(ctx, message, event) {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// GetDeadLetters returns the messages that ran out of attempts, oldest first
+func (ob *OutboxEventBus) GetDeadLetters(ctx context.Context) ([]*models.OutboxMessage, error) {
+	return ob.outboxRepo.GetByStatus(ctx, models.OutboxStatusDeadLettered)
+}
+
+// Redeliver requeues a dead-lettered message and tries it again straight away
+func (ob *OutboxEventBus) Redeliver(ctx context.Context, messageID string) (*models.OutboxMessage, error) {
+	message, err := ob.outboxRepo.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := events.Decode(events.EventType(message.EventType), message.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidOutboxMessage, err)
+	}
+
+	if err := message.Requeue(ob.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := ob.outboxRepo.Update(ctx, message); err != nil {
+		return nil, err
+	}
+
+	ob.deliver(ctx, message, event)
+	return message, nil
+}
+
+// PurgeDelivered drops delivered messages older than the retention period
+func (ob *OutboxEventBus) PurgeDelivered(ctx context.Context, retention time.Duration) (int, error) {
+	return ob.outboxRepo.DeleteDeliveredBefore(ctx, ob.clock.Now().Add(-retention))
+}
+
+// record serializes the event into a new pending message and writes it to the outbox
+func (ob *OutboxEventBus) record(ctx context.Context, event events.Event) (*models.OutboxMessage, error) {
+	message, err := ob.newMessage(event)
+	if err != nil {
+		return nil, err
+	}
+	if err := ob.outboxRepo.Create(ctx, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// newMessage serializes the event into a new pending message
+func (ob *OutboxEventBus) newMessage(event events.Event) (*models.OutboxMessage, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewOutboxMessage(string(event.Type()), payload, ob.clock.Now())
+}
+
+// deliver publishes one message to the subscribers and records the outcome; it reports whether delivery succeeded
+func (ob *OutboxEventBus) deliver(ctx context.Context, message *models.OutboxMessage, event events.Event) bool {
+	if !ob.claim(message.ID) {
+		return false
+	}
+	defer ob.release(message.ID)
+
+	if err := ob.bus.Publish(ctx, event); err != nil {
+		ob.fail(ctx, message, err, ob.config.MaxAttempts)
+		return false
+	}
+
+	message.MarkDelivered(ob.clock.Now())
+	if err := ob.outboxRepo.Update(ctx, message); err != nil {
+		ob.logger.Warn(ctx, "failed to mark outbox message delivered", "message_id", message.ID, "error", err)
+	}
+	return true
+}
+
+// fail schedules the next attempt, or dead-letters the message when it has none left
+func (ob *OutboxEventBus) fail(ctx context.Context, message *models.OutboxMessage, cause error, maxAttempts int) {
+	retryAt := ob.clock.Now().Add(ob.config.Backoff(message.Attempts + 1))
+	deadLettered := message.MarkFailed(cause.Error(), retryAt, maxAttempts)
+	if err := ob.outboxRepo.Update(ctx, message); err != nil {
+		ob.logger.Warn(ctx, "failed to record outbox delivery failure", "message_id", message.ID, "error", err)
+	}
+
+	if deadLettered {
+		ob.logger.Error(ctx, "outbox message dead-lettered",
+			"message_id", message.ID, "event", message.EventType, "attempts", message.Attempts, "error", cause)
+		return
+	}
+	ob.logger.Warn(ctx, "outbox delivery failed, will retry",
+		"message_id", message.ID, "event", message.EventType, "attempts", message.Attempts, "retry_at", retryAt, "error", cause)
+}
+
+// claim marks a message as being delivered; false if someone else already is
+func (ob *OutboxEventBus) claim(messageID string) bool {
+	ob.mutex.Lock()
+	defer ob.mutex.Unlock()
+
+	if ob.inFlight[messageID] {
+		return false
+	}
+	ob.inFlight[messageID] = true
+	return true
+}
+
+// release ends a delivery started by claim
+func (ob *OutboxEventBus) release(messageID string) {
+	ob.mutex.Lock()
+	defer ob.mutex.Unlock()
+
+	delete(ob.inFlight, messageID)
+}
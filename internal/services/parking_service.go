@@ -0,0 +1,279 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ParkingServiceImpl implements ParkingService. A theatre's car park is shared by all its shows: a slot reserved
+// for one show is taken from its start to its end, so overlapping shows compete for the same slots while later
+// shows can reuse them. Reservations follow their booking - held while it waits for payment, confirmed when it
+// is paid, freed when it is cancelled or its payment window closes.
+type ParkingServiceImpl struct {
+	reservationRepo repositories.ParkingReservationRepository
+	theatreRepo     repositories.TheatreRepository
+	showRepo        repositories.ShowRepository
+	authorizer      Authorizer        // Car parks are sized by the theatre's admins
+	audit           AuditRecorder     // Capacity changes
+	lockManager     locks.LockManager // Serializes reservations per theatre, across its shows
+	clock           clock.Clock
+}
+
+// parkingLockOwner namespaces parking locks apart from booking and hold locks
+const parkingLockOwner = "parking"
+
+func NewParkingService(
+	reservationRepo repositories.ParkingReservationRepository,
+	theatreRepo repositories.TheatreRepository,
+	showRepo repositories.ShowRepository,
+	authorizer Authorizer,
+	audit AuditRecorder,
+	lockManager locks.LockManager,
+	clk clock.Clock,
+) ParkingService {
+	return &ParkingServiceImpl{
+		reservationRepo: reservationRepo,
+		theatreRepo:     theatreRepo,
+		showRepo:        showRepo,
+		authorizer:      authorizer,
+		audit:           audit,
+		lockManager:     lockManager,
+		clock:           clk,
+	}
+}
+
+// SetCapacity sizes a theatre's car park. Existing reservations keep their slots even when the car park
+// shrinks below them; only new reservations see the new size.
+func (ps *ParkingServiceImpl) SetCapacity(ctx context.Context, theatreID string, slots int) (*models.Theatre, error) {
+	if _, err := ps.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, theatreID); err != nil {
+		return nil, err
+	}
+
+	theatre, err := ps.theatreRepo.GetByID(ctx, theatreID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := theatre.GetParkingSlots()
+	if err := theatre.SetParkingSlots(slots, ps.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := ps.theatreRepo.Update(ctx, theatre); err != nil {
+		return nil, err
+	}
+
+	ps.audit.Record(ctx, AuditChange{
+		EntityType: models.AuditEntityTheatre,
+		EntityID:   theatre.ID,
+		Action:     models.AuditParkingCapacityChange,
+		Details: map[string]string{
+			"old_slots": strconv.Itoa(previous),
+			"new_slots": strconv.Itoa(slots),
+		},
+	})
+	return theatre, nil
+}
+
+// GetAvailability counts the slots free for the whole of a show
+func (ps *ParkingServiceImpl) GetAvailability(ctx context.Context, showID string) (*ParkingAvailability, error) {
+	show, err := ps.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	theatre, err := ps.theatreRepo.GetByID(ctx, show.TheatreID)
+	if err != nil {
+		return nil, err
+	}
+
+	taken, err := ps.takenSlots(ctx, show)
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := theatre.GetParkingSlots()
+	return &ParkingAvailability{
+		ShowID:    show.ID,
+		TheatreID: theatre.ID,
+		Capacity:  capacity,
+		Reserved:  len(taken),
+		Available: len(freeSlots(capacity, taken, capacity)),
+	}, nil
+}
+
+// Reserve holds slots for a pending booking until its payment window closes, lowest free slot codes first
+func (ps *ParkingServiceImpl) Reserve(ctx context.Context, show *models.Show, booking *models.Booking, vehicles int) (*models.ParkingReservation, error) {
+	if vehicles <= 0 {
+		return nil, models.ErrInvalidParkingReservation
+	}
+
+	unlock, err := ps.lockManager.Lock(ctx, locks.TheatreKey(parkingLockOwner, show.TheatreID))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	theatre, err := ps.theatreRepo.GetByID(ctx, show.TheatreID)
+	if err != nil {
+		return nil, err
+	}
+	capacity := theatre.GetParkingSlots()
+	if capacity == 0 {
+		return nil, models.ErrNoParking
+	}
+
+	taken, err := ps.takenSlots(ctx, show)
+	if err != nil {
+		return nil, err
+	}
+	slots := freeSlots(capacity, taken, vehicles)
+	if len(slots) < vehicles {
+		return nil, fmt.Errorf("%w: %d left, %d asked for", models.ErrParkingFull, len(slots), vehicles)
+	}
+
+	reservation, err := models.NewParkingReservation(booking, show, slots, ps.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := ps.reservationRepo.Create(ctx, reservation); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// Confirm keeps a booking's held slots once it is paid; confirming again is a no-op
+func (ps *ParkingServiceImpl) Confirm(ctx context.Context, bookingID string) (*models.ParkingReservation, error) {
+	reservation, err := ps.reservationRepo.GetByBookingID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.GetStatus(ps.clock.Now()) == models.ParkingReservationConfirmed {
+		return reservation, nil
+	}
+
+	if err := reservation.Confirm(ps.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := ps.reservationRepo.Update(ctx, reservation); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// Cancel frees a booking's slots; a booking without parking, or whose slots are already free, is left alone
+func (ps *ParkingServiceImpl) Cancel(ctx context.Context, bookingID string) error {
+	return ps.change(ctx, bookingID, func(reservation *models.ParkingReservation) error {
+		return reservation.Cancel(ps.clock.Now())
+	})
+}
+
+// Expire frees the slots of a booking that wasn't paid in time
+func (ps *ParkingServiceImpl) Expire(ctx context.Context, bookingID string) error {
+	return ps.change(ctx, bookingID, func(reservation *models.ParkingReservation) error {
+		return reservation.Expire(ps.clock.Now())
+	})
+}
+
+// ExtendHold keeps a booking's held slots until its extended payment window closes; bookings without parking,
+// or whose slots are confirmed or free, are left alone
+func (ps *ParkingServiceImpl) ExtendHold(ctx context.Context, bookingID string, expiresAt time.Time) error {
+	return ps.change(ctx, bookingID, func(reservation *models.ParkingReservation) error {
+		return reservation.ExtendHold(expiresAt, ps.clock.Now())
+	})
+}
+
+// GetReservation returns a booking's parking reservation, recording it as expired if its booking's payment
+// window closed without an expiry event
+func (ps *ParkingServiceImpl) GetReservation(ctx context.Context, bookingID string) (*models.ParkingReservation, error) {
+	reservation, err := ps.reservationRepo.GetByBookingID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	now := ps.clock.Now()
+	if reservation.GetStatus(now) == models.ParkingReservationExpired && reservation.Expire(now) == nil {
+		if err := ps.reservationRepo.Update(ctx, reservation); err != nil {
+			return nil, err
+		}
+	}
+	return reservation, nil
+}
+
+// RescheduleShow moves the show's reservations to its new times. The slots stay with their bookings even if
+// they are now taken by an overlapping show, as a moved show's seats do.
+func (ps *ParkingServiceImpl) RescheduleShow(ctx context.Context, showID string) error {
+	show, err := ps.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return err
+	}
+
+	reservations, err := ps.reservationRepo.GetByShowID(ctx, showID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, reservation := range reservations {
+		reservation.Reschedule(show.StartTime, show.EndTime, ps.clock.Now())
+		if err := ps.reservationRepo.Update(ctx, reservation); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// change applies a change to a booking's reservation, if it has one that is still held or confirmed
+func (ps *ParkingServiceImpl) change(ctx context.Context, bookingID string, change func(*models.ParkingReservation) error) error {
+	reservation, err := ps.reservationRepo.GetByBookingID(ctx, bookingID)
+	if errors.Is(err, models.ErrParkingReservationNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := change(reservation); err != nil {
+		if errors.Is(err, models.ErrParkingReservationNotHeld) {
+			return nil
+		}
+		return err
+	}
+	return ps.reservationRepo.Update(ctx, reservation)
+}
+
+// takenSlots collects the slots reserved for any part of the show
+func (ps *ParkingServiceImpl) takenSlots(ctx context.Context, show *models.Show) (map[string]bool, error) {
+	reservations, err := ps.reservationRepo.GetActiveByTheatre(ctx, show.TheatreID, ps.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	now := ps.clock.Now()
+	taken := make(map[string]bool)
+	for _, reservation := range reservations {
+		if !reservation.Occupies(show.StartTime, show.EndTime, now) {
+			continue
+		}
+		for _, slot := range reservation.GetSlots() {
+			taken[slot] = true
+		}
+	}
+	return taken, nil
+}
+
+// freeSlots lists up to n slot codes of a car park of the given capacity that aren't taken, lowest first
+func freeSlots(capacity int, taken map[string]bool, n int) []string {
+	var slots []string
+	for i := 1; i <= capacity && len(slots) < n; i++ {
+		if code := models.ParkingSlotCode(i); !taken[code] {
+			slots = append(slots, code)
+		}
+	}
+	return slots
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/events"
+	"context"
+)
+
+// RegisterParkingSubscriber frees the parking slots of bookings that are cancelled or expire, holds them longer
+// when a payment window is extended, and moves them with rescheduled shows - demonstrates Observer Pattern.
+// Slots are confirmed when the parking add-on is fulfilled.
+func RegisterParkingSubscriber(bus events.EventBus, parkingService ParkingService) {
+	bus.Subscribe(events.EventBookingCancelled, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingCancelled)
+		return parkingService.Cancel(ctx, e.BookingID)
+	})
+
+	bus.Subscribe(events.EventBookingExpired, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingExpired)
+		return parkingService.Expire(ctx, e.BookingID)
+	})
+
+	bus.Subscribe(events.EventBookingHoldExtended, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingHoldExtended)
+		return parkingService.ExtendHold(ctx, e.BookingID, e.ExpiresAt)
+	})
+
+	bus.Subscribe(events.EventShowRescheduled, func(ctx context.Context, event events.Event) error {
+		e := event.(events.ShowRescheduled)
+		return parkingService.RescheduleShow(ctx, e.ShowID)
+	})
+}
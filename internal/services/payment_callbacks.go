@@ -0,0 +1,164 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/models"
+	"cmp"
+	"context"
+)
+
+// HandleCallback applies a gateway's asynchronous callback to the payment it is about, under the same payment
+// lock as payment attempts, so bookings move on even when the synchronous charge never answered:
+//   - captured: a payment not settled yet succeeds and its booking is confirmed, or, as in reconciliation, the
+//     charge is refunded when the booking has moved on. A successful payment whose booking was never confirmed
+//     gets it confirmed.
+//   - failed: a payment still waiting for its outcome fails. Settled payments are left alone, as callbacks can
+//     arrive out of order.
+//   - refund processed: a refund made at the gateway is recorded, and once it refunds the payment in full the
+//     confirmed booking is cancelled.
+//
+// Whatever it does is published as a PaymentReconciled event.
+func (rs *PaymentReconciliationServiceImpl) HandleCallback(ctx context.Context, callback *models.GatewayCallback) (*models.Payment, error) {
+	if err := callback.Validate(); err != nil {
+		return nil, err
+	}
+
+	payment, err := rs.callbackPayment(ctx, callback)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := rs.lockManager.Lock(ctx, locks.BookingKey(paymentLockOwner, payment.BookingID))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	payment, err = rs.paymentRepo.GetByID(ctx, payment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolution string
+	switch callback.Type {
+	case models.GatewayCallbackPaymentCaptured:
+		resolution, err = rs.captured(ctx, payment, callback)
+	case models.GatewayCallbackPaymentFailed:
+		resolution, err = rs.failed(ctx, payment, callback)
+	case models.GatewayCallbackRefundProcessed:
+		resolution, err = rs.refunded(ctx, payment, callback)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The refund service and bookings may have changed the payment since it was read
+	if payment, err = rs.paymentRepo.GetByID(ctx, payment.ID); err != nil {
+		return nil, err
+	}
+	if resolution != "" {
+		rs.publish(ctx, payment, resolution)
+	}
+	return payment, nil
+}
+
+// callbackPayment finds the payment a callback is about: by our own payment ID when the gateway echoes it back,
+// otherwise by the gateway's reference
+func (rs *PaymentReconciliationServiceImpl) callbackPayment(ctx context.Context, callback *models.GatewayCallback) (*models.Payment, error) {
+	if callback.PaymentID == "" {
+		return rs.paymentRepo.GetByTransactionID(ctx, callback.TransactionID)
+	}
+
+	payment, err := rs.paymentRepo.GetByID(ctx, callback.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	// A reference that belongs to another charge means the callback isn't about this payment
+	if callback.TransactionID != "" && payment.TransactionID != "" && payment.TransactionID != callback.TransactionID {
+		return nil, models.ErrPaymentNotFound
+	}
+	return payment, nil
+}
+
+// captured settles a payment the gateway says it charged
+func (rs *PaymentReconciliationServiceImpl) captured(ctx context.Context, payment *models.Payment, callback *models.GatewayCallback) (string, error) {
+	switch {
+	case payment.IsSuccessful():
+		// The charge answered in time, but the booking may never have been confirmed
+		if payment.Attempt == 0 {
+			return "", nil
+		}
+		booking, err := rs.bookingRepo.GetByID(ctx, payment.BookingID)
+		if err != nil {
+			return "", err
+		}
+		if booking.GetStatus() != models.BookingStatusPending {
+			return "", nil
+		}
+		return rs.settleCharge(ctx, payment, nil)
+	case payment.CanBeRefunded() || payment.IsRefunded():
+		return "", nil
+	default:
+		// Still waiting, or given up on although the money was taken
+		return rs.settleCharge(ctx, payment, &PaymentResult{
+			Success:       true,
+			TransactionID: cmp.Or(callback.TransactionID, payment.TransactionID),
+			Response:      "payment captured, as the gateway's callback reported",
+		})
+	}
+}
+
+// failed fails a payment still waiting for its outcome. One the gateway timed out on has its booking released,
+// as reconciliation would; after a declined OTP step the booking stays pending and can be paid for again.
+func (rs *PaymentReconciliationServiceImpl) failed(ctx context.Context, payment *models.Payment, callback *models.GatewayCallback) (string, error) {
+	outcomeUnknown := payment.IsAwaitingConfirmation()
+	if !outcomeUnknown && !payment.IsAwaitingAction() && !payment.IsPending() {
+		return "", nil
+	}
+
+	payment.MarkFailed(cmp.Or(callback.Reason, "payment failed, as the gateway's callback reported"), rs.clock.Now())
+	if err := rs.paymentRepo.Update(ctx, payment); err != nil {
+		return "", err
+	}
+	if !outcomeUnknown {
+		return ResolutionFailed, nil
+	}
+	return rs.release(ctx, payment)
+}
+
+// refunded records a refund the gateway made on its own. Refunds the app asked for are already recorded under
+// the gateway's reference and change nothing.
+func (rs *PaymentReconciliationServiceImpl) refunded(ctx context.Context, payment *models.Payment, callback *models.GatewayCallback) (string, error) {
+	refunds, err := rs.refundService.GetRefundsByPayment(ctx, payment.ID)
+	if err != nil {
+		return "", err
+	}
+	for _, refund := range refunds {
+		if refund.GatewayReference == callback.RefundID {
+			return "", nil
+		}
+	}
+
+	if _, err := rs.refundService.RecordGatewayRefund(ctx, payment.ID, callback.Amount, callback.RefundID, "refunded at the payment gateway"); err != nil {
+		return "", err
+	}
+	if payment, err = rs.paymentRepo.GetByID(ctx, payment.ID); err != nil {
+		return "", err
+	}
+	if !payment.IsRefunded() || payment.Attempt == 0 {
+		return ResolutionRefunded, nil
+	}
+
+	// The money for the tickets is back with the payer, so the seats go back on sale
+	booking, err := rs.bookingRepo.GetByID(ctx, payment.BookingID)
+	if err != nil {
+		return "", err
+	}
+	if booking.GetStatus() != models.BookingStatusConfirmed {
+		return ResolutionRefunded, nil
+	}
+	if err := rs.bookingService.CancelBooking(ctx, booking.ID); err != nil {
+		return "", err
+	}
+	return ResolutionCancelled, nil
+}
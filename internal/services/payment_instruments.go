@@ -0,0 +1,132 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"strings"
+)
+
+// PaymentInstrumentServiceImpl implements PaymentInstrumentService. Card numbers go straight to the tokenizer;
+// what is saved and shown back is the vault token, the brand and the last four digits.
+type PaymentInstrumentServiceImpl struct {
+	instrumentRepo repositories.PaymentInstrumentRepository
+	userRepo       repositories.UserRepository
+	tokenizer      Tokenizer
+	clock          clock.Clock
+}
+
+func NewPaymentInstrumentService(instrumentRepo repositories.PaymentInstrumentRepository, userRepo repositories.UserRepository, tokenizer Tokenizer, clock clock.Clock) PaymentInstrumentService {
+	return &PaymentInstrumentServiceImpl{
+		instrumentRepo: instrumentRepo,
+		userRepo:       userRepo,
+		tokenizer:      tokenizer,
+		clock:          clock,
+	}
+}
+
+// AddCard validates and tokenizes a card, then saves it for the user
+func (is *PaymentInstrumentServiceImpl) AddCard(ctx context.Context, userID string, card models.CardDetails) (*models.PaymentInstrument, error) {
+	if _, err := is.saved(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := card.Validate(is.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	token, err := is.tokenizer.Tokenize(ctx, card)
+	if err != nil {
+		return nil, err
+	}
+	instrument, err := models.NewCardInstrument(userID, token, card, is.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	return is.save(ctx, instrument)
+}
+
+func (is *PaymentInstrumentServiceImpl) AddUPI(ctx context.Context, userID, handle string) (*models.PaymentInstrument, error) {
+	instruments, err := is.saved(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, instrument := range instruments {
+		if instrument.Type == models.InstrumentTypeUPI && strings.EqualFold(instrument.Handle, strings.TrimSpace(handle)) {
+			return instrument.Redacted(), nil
+		}
+	}
+
+	instrument, err := models.NewUPIInstrument(userID, handle, is.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	return is.save(ctx, instrument)
+}
+
+func (is *PaymentInstrumentServiceImpl) LinkWallet(ctx context.Context, userID string) (*models.PaymentInstrument, error) {
+	instruments, err := is.saved(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, instrument := range instruments {
+		if instrument.Type == models.InstrumentTypeWallet {
+			return instrument.Redacted(), nil
+		}
+	}
+
+	instrument, err := models.NewWalletInstrument(userID, is.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	return is.save(ctx, instrument)
+}
+
+func (is *PaymentInstrumentServiceImpl) GetInstruments(ctx context.Context, userID string) ([]*models.PaymentInstrument, error) {
+	instruments, err := is.saved(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := make([]*models.PaymentInstrument, len(instruments))
+	for i, instrument := range instruments {
+		redacted[i] = instrument.Redacted()
+	}
+	return redacted, nil
+}
+
+// RemoveInstrument deletes one of the user's instruments; payments already made with it are unaffected
+func (is *PaymentInstrumentServiceImpl) RemoveInstrument(ctx context.Context, userID, instrumentID string) error {
+	instrument, err := is.instrumentRepo.GetByID(ctx, instrumentID)
+	if err != nil {
+		return err
+	}
+	// Someone else's instrument is reported missing rather than confirming it exists
+	if instrument.UserID != userID {
+		return models.ErrPaymentInstrumentNotFound
+	}
+	return is.instrumentRepo.Delete(ctx, instrument.ID)
+}
+
+// saved returns the user's instruments, checking the user exists
+func (is *PaymentInstrumentServiceImpl) saved(ctx context.Context, userID string) ([]*models.PaymentInstrument, error) {
+	if _, err := is.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return is.instrumentRepo.GetByUserID(ctx, userID)
+}
+
+// save stores a new instrument unless the user already has the maximum
+func (is *PaymentInstrumentServiceImpl) save(ctx context.Context, instrument *models.PaymentInstrument) (*models.PaymentInstrument, error) {
+	instruments, err := is.instrumentRepo.GetByUserID(ctx, instrument.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(instruments) >= models.MaxPaymentInstruments {
+		return nil, models.ErrPaymentInstrumentLimit
+	}
+	if err := is.instrumentRepo.Create(ctx, instrument); err != nil {
+		return nil, err
+	}
+	return instrument.Redacted(), nil
+}
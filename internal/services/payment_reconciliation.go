@@ -0,0 +1,238 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"time"
+)
+
+// paymentConfirmationTimeout is how long the gateway may keep saying a charge is still processing before the
+// payment is given up as failed and its booking released
+const paymentConfirmationTimeout = time.Hour
+
+// Reconciliation resolutions, as published in PaymentReconciled events
+const (
+	ResolutionConfirmed = "BOOKING_CONFIRMED" // The charge went through and the booking was confirmed
+	ResolutionRefunded  = "REFUNDED"          // The charge went through too late for its booking, or for an abandoned supplement
+	ResolutionReleased  = "BOOKING_RELEASED"  // The charge failed; the booking was cancelled and its seats freed
+	ResolutionFailed    = "FAILED"            // The charge failed and held nothing that needed releasing
+	ResolutionCancelled = "BOOKING_CANCELLED" // The gateway refunded the charge in full on its own; the booking was cancelled
+)
+
+// PaymentReconciliationServiceImpl implements PaymentReconciliationService. A charge the gateway timed out on
+// leaves its payment PENDING_CONFIRMATION and its booking pending; reconciliation asks the gateway how the charge
+// went and then confirms the booking, refunds a charge that came too late, or releases the booking's seats.
+type PaymentReconciliationServiceImpl struct {
+	paymentRepo    repositories.PaymentRepository
+	bookingRepo    repositories.BookingRepository
+	paymentGateway PaymentGateway
+	bookingService BookingService // Confirms and releases bookings, under their show lock
+	refundService  RefundService
+	lockManager    locks.LockManager // Same per-booking lock as payment attempts
+	eventBus       events.EventBus
+	logger         logging.Logger
+	clock          clock.Clock
+}
+
+func NewPaymentReconciliationService(
+	paymentRepo repositories.PaymentRepository,
+	bookingRepo repositories.BookingRepository,
+	paymentGateway PaymentGateway,
+	bookingService BookingService,
+	refundService RefundService,
+	lockManager locks.LockManager,
+	eventBus events.EventBus,
+	logger logging.Logger,
+	clk clock.Clock,
+) PaymentReconciliationService {
+	return &PaymentReconciliationServiceImpl{
+		paymentRepo:    paymentRepo,
+		bookingRepo:    bookingRepo,
+		paymentGateway: paymentGateway,
+		bookingService: bookingService,
+		refundService:  refundService,
+		lockManager:    lockManager,
+		eventBus:       eventBus,
+		logger:         logger,
+		clock:          clk,
+	}
+}
+
+// ReconcilePayments settles every payment awaiting confirmation that the gateway now has an answer for, oldest
+// first. Payments it still can't answer for, or that fail, are left for the next run; the others are settled.
+func (rs *PaymentReconciliationServiceImpl) ReconcilePayments(ctx context.Context) ([]*models.Payment, error) {
+	payments, err := rs.paymentRepo.GetAwaitingConfirmation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var settled []*models.Payment
+	var errs []error
+	for _, payment := range payments {
+		reconciled, err := rs.reconcile(ctx, payment)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if reconciled {
+			settled = append(settled, payment)
+		}
+	}
+	return settled, errors.Join(errs...)
+}
+
+// reconcile asks the gateway about one payment under its booking's payment lock and settles it if it can,
+// reporting whether it did
+func (rs *PaymentReconciliationServiceImpl) reconcile(ctx context.Context, payment *models.Payment) (bool, error) {
+	unlock, err := rs.lockManager.Lock(ctx, locks.BookingKey(paymentLockOwner, payment.BookingID))
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	payment, err = rs.paymentRepo.GetByID(ctx, payment.ID)
+	if err != nil {
+		return false, err
+	}
+	if !payment.IsAwaitingConfirmation() {
+		return false, nil
+	}
+
+	result, err := rs.paymentGateway.QueryTransaction(ctx, payment.ID, payment.Method)
+	if err != nil {
+		return false, err
+	}
+	if !result.Success && result.Pending {
+		if rs.clock.Now().Sub(payment.CreatedAt) < paymentConfirmationTimeout {
+			return false, nil
+		}
+		result = &PaymentResult{ErrorMessage: "payment gateway didn't confirm the payment within " + paymentConfirmationTimeout.String()}
+	}
+
+	var resolution string
+	if result.Success {
+		resolution, err = rs.settleCharge(ctx, payment, result)
+	} else {
+		payment.MarkFailed(result.ErrorMessage, rs.clock.Now())
+		if err := rs.paymentRepo.Update(ctx, payment); err != nil {
+			return false, err
+		}
+		resolution, err = rs.release(ctx, payment)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	rs.publish(ctx, payment, resolution)
+	return true, nil
+}
+
+// publish emits a PaymentReconciled event with what was done about the payment's booking
+func (rs *PaymentReconciliationServiceImpl) publish(ctx context.Context, payment *models.Payment, resolution string) {
+	if rs.eventBus == nil {
+		return
+	}
+	err := rs.eventBus.Publish(ctx, events.PaymentReconciled{
+		PaymentID:  payment.ID,
+		BookingID:  payment.BookingID,
+		UserID:     payment.UserID,
+		Status:     string(payment.Status),
+		Resolution: resolution,
+		Timestamp:  rs.clock.Now(),
+	})
+	if err != nil {
+		rs.logger.Warn(ctx, "failed to publish event", "event", events.EventPaymentReconciled, "error", err)
+	}
+}
+
+// settleCharge records a late charge as successful and confirms the booking it paid for. The payment is saved first,
+// since a booking is only confirmed by a successful payment; a failed confirmation puts it back to awaiting
+// confirmation for the next run. A charge for a booking that has meanwhile expired or been cancelled is refunded,
+// as is a supplement: the change it paid for was abandoned when the gateway timed out. A failed refund puts the
+// payment back to awaiting confirmation too. A nil result means payment is already saved as successful.
+func (rs *PaymentReconciliationServiceImpl) settleCharge(ctx context.Context, payment *models.Payment, result *PaymentResult) (string, error) {
+	if err := rs.markSucceeded(ctx, payment, result); err != nil {
+		return "", err
+	}
+
+	reason := "supplementary charge confirmed after it was abandoned"
+	if payment.Attempt > 0 {
+		err := rs.confirm(ctx, payment)
+		if err == nil {
+			return ResolutionConfirmed, nil
+		}
+		if !errors.Is(err, models.ErrBookingExpired) && !errors.Is(err, models.ErrBookingNotPending) {
+			return "", rs.awaitNextRun(ctx, payment, result, "confirming its booking failed: ", err)
+		}
+		reason = "payment confirmed after the booking was no longer pending"
+	}
+
+	if _, err := rs.refundService.InitiateRefund(ctx, payment.ID, payment.Amount, reason); err != nil {
+		return "", rs.awaitNextRun(ctx, payment, result, "refund of late charge failed: ", err)
+	}
+	return ResolutionRefunded, nil
+}
+
+// awaitNextRun puts a charge settleCharge saved as successful back to awaiting confirmation, so the next run settles
+// it again, and returns err. A payment that was already successful stays so.
+func (rs *PaymentReconciliationServiceImpl) awaitNextRun(ctx context.Context, payment *models.Payment, result *PaymentResult, reason string, err error) error {
+	if result == nil {
+		return err
+	}
+	payment.MarkPendingConfirmation(reason+err.Error(), rs.clock.Now())
+	if updateErr := rs.paymentRepo.Update(ctx, payment); updateErr != nil {
+		return errors.Join(err, updateErr)
+	}
+	return err
+}
+
+// confirm confirms the booking payment paid for. A booking this payment already confirmed counts as confirmed, e.g.
+// after a gateway callback repeats the capture.
+func (rs *PaymentReconciliationServiceImpl) confirm(ctx context.Context, payment *models.Payment) error {
+	booking, err := rs.bookingRepo.GetByID(ctx, payment.BookingID)
+	if err != nil {
+		return err
+	}
+	if booking.GetStatus() == models.BookingStatusConfirmed && booking.PaymentID == payment.ID {
+		return nil
+	}
+	return rs.bookingService.ConfirmBooking(ctx, payment.BookingID, payment.ID)
+}
+
+// markSucceeded saves payment as charged with what the gateway answered, unless there is no answer to record
+func (rs *PaymentReconciliationServiceImpl) markSucceeded(ctx context.Context, payment *models.Payment, result *PaymentResult) error {
+	if result == nil {
+		return nil
+	}
+	payment.MarkSuccess(result.TransactionID, result.Response, rs.clock.Now())
+	if result.Installments != nil {
+		payment.AttachInstallments(result.Installments, rs.clock.Now())
+	}
+	return rs.paymentRepo.Update(ctx, payment)
+}
+
+// release cancels the pending booking a failed charge was for, freeing its seats
+func (rs *PaymentReconciliationServiceImpl) release(ctx context.Context, payment *models.Payment) (string, error) {
+	if payment.Attempt == 0 {
+		return ResolutionFailed, nil
+	}
+
+	booking, err := rs.bookingRepo.GetByID(ctx, payment.BookingID)
+	if err != nil {
+		return "", err
+	}
+	if booking.GetStatus() != models.BookingStatusPending {
+		return ResolutionFailed, nil
+	}
+
+	if err := rs.bookingService.CancelBooking(ctx, booking.ID); err != nil {
+		return "", err
+	}
+	return ResolutionReleased, nil
+}
@@ -1,77 +1,360 @@
 package services
 
 import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/metrics"
 	"bookmyshow-lld/internal/models"
 	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
 )
 
 // PaymentServiceImpl implements PaymentService - demonstrates Strategy Pattern
 type PaymentServiceImpl struct {
-	paymentRepo     repositories.PaymentRepository
-	bookingRepo     repositories.BookingRepository
-	paymentGateway  PaymentGateway // Strategy Pattern - different payment methods
-	notificationSvc NotificationService
+	paymentRepo    repositories.PaymentRepository
+	bookingRepo    repositories.BookingRepository
+	paymentGateway PaymentGateway  // Strategy Pattern - different payment methods
+	eventBus       events.EventBus // Observer Pattern - subscribers react to payment events
+	refundService  RefundService
+	lockManager    locks.LockManager // Per-booking locks - one payment attempt in flight at a time
+	metrics        metrics.Recorder  // Success rate per payment method
+	logger         logging.Logger
+	clock          clock.Clock
+
+	instrumentRepo repositories.PaymentInstrumentRepository // Saved instruments payers can pick instead of typing details
+	tokenizer      Tokenizer                                // Turns saved cards' tokens back into card details
+	fraud          FraudCheck                               // Scores each payment before it is charged; nil skips the checks
+	authorizer     Authorizer                               // Lets callers pay for and refund their own bookings, and theatre admins their theatres'
 }
 
+// paymentLockOwner namespaces payment locks apart from booking and hold locks
+const paymentLockOwner = "payment"
+
 // NewPaymentService creates a new payment service
 func NewPaymentService(
 	paymentRepo repositories.PaymentRepository,
 	bookingRepo repositories.BookingRepository,
 	paymentGateway PaymentGateway,
-	notificationSvc NotificationService,
+	eventBus events.EventBus,
+	refundService RefundService,
+	lockManager locks.LockManager,
+	logger logging.Logger,
+	metrics metrics.Recorder,
+	clock clock.Clock,
+	instrumentRepo repositories.PaymentInstrumentRepository,
+	tokenizer Tokenizer,
+	fraud FraudCheck,
+	authorizer Authorizer,
 ) PaymentService {
 	return &PaymentServiceImpl{
-		paymentRepo:     paymentRepo,
-		bookingRepo:     bookingRepo,
-		paymentGateway:  paymentGateway,
-		notificationSvc: notificationSvc,
+		paymentRepo:    paymentRepo,
+		bookingRepo:    bookingRepo,
+		paymentGateway: paymentGateway,
+		eventBus:       eventBus,
+		refundService:  refundService,
+		lockManager:    lockManager,
+		logger:         logger,
+		metrics:        metrics,
+		clock:          clock,
+		instrumentRepo: instrumentRepo,
+		tokenizer:      tokenizer,
+		fraud:          fraud,
+		authorizer:     authorizer,
 	}
 }
 
 // ProcessPayment processes a payment for a booking - demonstrates Strategy Pattern
-func (ps *PaymentServiceImpl) ProcessPayment(bookingID string, paymentMethod models.PaymentMethod) (*models.Payment, error) {
-	// Get booking
-	booking, err := ps.bookingRepo.GetByID(bookingID)
+func (ps *PaymentServiceImpl) ProcessPayment(ctx context.Context, bookingID string, paymentMethod models.PaymentMethod, opts ...PaymentOption) (*models.Payment, error) {
+	return ps.attemptPayment(ctx, bookingID, paymentMethod, false, opts...)
+}
+
+// RetryPayment charges a pending booking again after a failed attempt, optionally with a different method.
+// The booking keeps its seats and expiry; retries are capped at models.MaxPaymentRetries.
+func (ps *PaymentServiceImpl) RetryPayment(ctx context.Context, bookingID string, paymentMethod models.PaymentMethod, opts ...PaymentOption) (*models.Payment, error) {
+	return ps.attemptPayment(ctx, bookingID, paymentMethod, true, opts...)
+}
+
+// GetPaymentAttempts returns every payment tried for a booking's total, oldest first
+func (ps *PaymentServiceImpl) GetPaymentAttempts(ctx context.Context, bookingID string) ([]*models.Payment, error) {
+	booking, err := ps.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ps.authorizer.AuthorizeBooking(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	attemptIDs := booking.GetPaymentAttempts()
+	attempts := make([]*models.Payment, 0, len(attemptIDs))
+	for _, paymentID := range attemptIDs {
+		payment, err := ps.paymentRepo.GetByID(ctx, paymentID)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, payment)
+	}
+	return attempts, nil
+}
+
+// attemptPayment records and runs one payment attempt for the booking total.
+// A retry requires the previous attempt to have failed; neither is allowed once an attempt succeeded, or while
+// one is awaiting confirmation from the gateway.
+func (ps *PaymentServiceImpl) attemptPayment(ctx context.Context, bookingID string, paymentMethod models.PaymentMethod, retry bool, opts ...PaymentOption) (*models.Payment, error) {
+	var options PaymentOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// Concurrent attempts for the same booking would double charge
+	unlock, err := ps.lockManager.Lock(ctx, locks.BookingKey(paymentLockOwner, bookingID))
 	if err != nil {
 		return nil, err
 	}
+	defer unlock()
+
+	booking, err := ps.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ps.authorizer.AuthorizeBooking(ctx, booking); err != nil {
+		return nil, err
+	}
 
 	if booking.GetStatus() != models.BookingStatusPending {
 		return nil, models.ErrBookingNotPending
 	}
 
-	if booking.IsExpired() {
+	if booking.IsExpired(ps.clock.Now()) {
 		return nil, models.ErrBookingExpired
 	}
 
+	saved, err := ps.instrumentMetadata(ctx, options.InstrumentID, options.payer(booking), paymentMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := ps.GetPaymentAttempts(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	for _, attempt := range previous {
+		if attempt.IsSuccessful() {
+			return nil, models.ErrPaymentAlreadySucceeded
+		}
+		// Nothing was charged without the OTP, so an unfinished challenge is given up
+		if attempt.IsAwaitingAction() {
+			attempt.MarkFailed("OTP challenge abandoned for a new attempt", ps.clock.Now())
+			if err := ps.paymentRepo.Update(ctx, attempt); err != nil {
+				return nil, err
+			}
+		}
+		// It may yet go through; charging again could take the money twice
+		if attempt.IsAwaitingConfirmation() {
+			return nil, models.ErrPaymentAwaitingConfirmation
+		}
+	}
+	if retry && (len(previous) == 0 || !previous[len(previous)-1].IsFailed()) {
+		return nil, models.ErrNoFailedPayment
+	}
+
+	payment, err := models.NewPayment(booking.ID, booking.UserID, booking.TotalAmount, paymentMethod, ps.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	payment.Attempt, err = booking.RecordPaymentAttempt(payment.ID, ps.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.bookingRepo.Update(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	return ps.execute(ctx, booking, payment, options, saved)
+}
+
+// ChargeSupplement collects an extra amount for a confirmed booking, e.g. after a seat upgrade or when a buyer
+// pays for a resold booking
+func (ps *PaymentServiceImpl) ChargeSupplement(ctx context.Context, bookingID string, amount models.Money, paymentMethod models.PaymentMethod, opts ...PaymentOption) (*models.Payment, error) {
+	var options PaymentOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	booking, err := ps.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.GetStatus() != models.BookingStatusConfirmed {
+		return nil, models.ErrBookingNotModifiable
+	}
+
+	return ps.charge(ctx, booking, amount, paymentMethod, options)
+}
+
+// charge records a one-off payment for a booking and runs it through the gateway
+func (ps *PaymentServiceImpl) charge(ctx context.Context, booking *models.Booking, amount models.Money, paymentMethod models.PaymentMethod, options PaymentOptions) (*models.Payment, error) {
+	saved, err := ps.instrumentMetadata(ctx, options.InstrumentID, options.payer(booking), paymentMethod)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create payment record
-	payment, err := models.NewPayment(bookingID, booking.UserID, booking.TotalAmount, paymentMethod)
+	payment, err := models.NewPayment(booking.ID, options.payer(booking), amount, paymentMethod, ps.clock.Now())
 	if err != nil {
 		return nil, err
 	}
 
+	return ps.execute(ctx, booking, payment, options, saved)
+}
+
+// execute saves a new payment and runs it through the gateway, with the saved instrument's details if one was picked
+func (ps *PaymentServiceImpl) execute(ctx context.Context, booking *models.Booking, payment *models.Payment, options PaymentOptions, saved map[string]string) (*models.Payment, error) {
+	amount, paymentMethod := payment.Amount, payment.Method
+
 	// Save payment
-	if err := ps.paymentRepo.Create(payment); err != nil {
+	if err := ps.paymentRepo.Create(ctx, payment); err != nil {
 		return nil, err
 	}
 
 	// Process payment through gateway using Strategy Pattern
-	metadata := ps.buildPaymentMetadata(paymentMethod, booking)
-	result, err := ps.paymentGateway.ProcessPayment(booking.TotalAmount, paymentMethod, metadata)
+	metadata := ps.buildPaymentMetadata(paymentMethod, booking, amount, options, saved)
+	metadata["payment_id"] = payment.ID // Idempotency key for real providers
+	if payment.Attempt == 0 {
+		// The upgrades and resales supplements pay for can't wait for an OTP, so they are charged off-session
+		metadata["off_session"] = "true"
+	}
+	if err := ps.checkFraud(ctx, booking, payment, metadata); err != nil {
+		payment, settleErr := ps.settle(ctx, booking, payment, nil, err)
+		if errors.Is(err, models.ErrPaymentRejected) {
+			ps.publishRejection(ctx, payment)
+		}
+		return payment, settleErr
+	}
+	result, err := ps.paymentGateway.ProcessPayment(ctx, amount, paymentMethod, metadata)
+	return ps.settle(ctx, booking, payment, result, err)
+}
+
+// checkFraud assesses a payment before it is charged - demonstrates Chain of Responsibility Pattern via the
+// FraudCheck's rules. Risky card payments are marked for the issuer's OTP and UPI's PIN already verifies the
+// payer; other methods, and supplements charged off-session, can't be verified, so they are refused with
+// models.ErrPaymentVerificationRequired.
+func (ps *PaymentServiceImpl) checkFraud(ctx context.Context, booking *models.Booking, payment *models.Payment, metadata map[string]string) error {
+	if ps.fraud == nil {
+		return nil
+	}
+
+	assessment, err := ps.fraud.Check(ctx, payment, booking)
+	if err != nil {
+		return err
+	}
+	payment.AssessRisk(assessment, ps.clock.Now())
+
+	switch assessment.Decision {
+	case models.RiskDecisionReject:
+		return fmt.Errorf("%w: %s", models.ErrPaymentRejected, assessment.Reasons())
+	case models.RiskDecisionStepUp:
+		if metadata["off_session"] == "true" {
+			return models.ErrPaymentVerificationRequired
+		}
+		switch payment.Method {
+		case models.PaymentMethodCreditCard, models.PaymentMethodDebitCard, models.PaymentMethodEMI:
+			metadata["step_up"] = "true"
+		case models.PaymentMethodUPI:
+			// The UPI PIN already verifies the payer
+		default:
+			return models.ErrPaymentVerificationRequired
+		}
+	}
+	return nil
+}
+
+// CompleteChallenge enters the cardholder's OTP for a payment the issuer challenged. A wrong OTP can be tried
+// again until models.MaxOTPAttempts are used up; then, or once the OTP expires, the payment fails and the booking
+// can be paid for again with RetryPayment.
+func (ps *PaymentServiceImpl) CompleteChallenge(ctx context.Context, paymentID, otp string) (*models.Payment, error) {
+	payment, err := ps.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := ps.lockManager.Lock(ctx, locks.BookingKey(paymentLockOwner, payment.BookingID))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	booking, err := ps.bookingRepo.GetByID(ctx, payment.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ps.authorizer.AuthorizeBooking(ctx, booking); err != nil {
+		return nil, err
+	}
+	if !payment.IsAwaitingAction() {
+		return nil, models.ErrNoPaymentChallenge
+	}
+	if payment.Challenge.IsExpired(ps.clock.Now()) {
+		return ps.settle(ctx, booking, payment, nil, models.ErrOTPChallengeExpired)
+	}
+
+	result, err := ps.paymentGateway.CompleteChallenge(ctx, payment.Challenge.Token, otp, payment.Method)
+	if errors.Is(err, models.ErrIncorrectOTP) && !payment.RecordWrongOTP(ps.clock.Now()) {
+		if updateErr := ps.paymentRepo.Update(ctx, payment); updateErr != nil {
+			return nil, updateErr
+		}
+		return payment, err
+	}
+	return ps.settle(ctx, booking, payment, result, err)
+}
+
+// settle records the gateway's answer to a charge on its payment
+func (ps *PaymentServiceImpl) settle(ctx context.Context, booking *models.Booking, payment *models.Payment, result *PaymentResult, err error) (*models.Payment, error) {
+	paymentMethod := payment.Method
+	if errors.Is(err, models.ErrPaymentOutcomeUnknown) {
+		// Neither taken nor failed until the gateway is asked again - see PaymentReconciliationService
+		payment.MarkPendingConfirmation(err.Error(), ps.clock.Now())
+		ps.paymentRepo.Update(ctx, payment)
+		return payment, err
+	}
 	if err != nil {
-		payment.MarkFailed(err.Error())
-		ps.paymentRepo.Update(payment)
+		payment.MarkFailed(err.Error(), ps.clock.Now())
+		ps.paymentRepo.Update(ctx, payment)
+		ps.metrics.PaymentAttempt(paymentMethod, false)
+		ps.publishFailure(ctx, booking, payment)
 		return payment, err
 	}
 
+	// Nothing is charged yet: the issuer wants the cardholder's OTP first
+	if result.Challenge != nil {
+		payment.RequireAction(result.Challenge, ps.clock.Now())
+		if err := ps.paymentRepo.Update(ctx, payment); err != nil {
+			return payment, err
+		}
+		return payment, nil
+	}
+
+	ps.metrics.PaymentAttempt(paymentMethod, result.Success)
 	if result.Success {
-		payment.MarkSuccess(result.TransactionID, result.Response)
+		payment.MarkSuccess(result.TransactionID, result.Response, ps.clock.Now())
+		if result.Installments != nil {
+			payment.AttachInstallments(result.Installments, ps.clock.Now())
+		}
 	} else {
-		payment.MarkFailed(result.ErrorMessage)
+		payment.MarkFailed(result.ErrorMessage, ps.clock.Now())
+		ps.publishFailure(ctx, booking, payment)
 	}
 
 	// Update payment
-	if err := ps.paymentRepo.Update(payment); err != nil {
+	if err := ps.paymentRepo.Update(ctx, payment); err != nil {
 		return payment, err
 	}
 
@@ -79,16 +362,120 @@ func (ps *PaymentServiceImpl) ProcessPayment(bookingID string, paymentMethod mod
 }
 
 // GetPayment retrieves a payment by ID
-func (ps *PaymentServiceImpl) GetPayment(id string) (*models.Payment, error) {
-	return ps.paymentRepo.GetByID(id)
+func (ps *PaymentServiceImpl) GetPayment(ctx context.Context, id string) (*models.Payment, error) {
+	return ps.paymentRepo.GetByID(ctx, id)
+}
+
+// RefundPayment refunds all or part of a successful payment via the refund service
+func (ps *PaymentServiceImpl) RefundPayment(ctx context.Context, paymentID string, amount models.Money, reason string, opts ...RefundOption) (*models.Refund, error) {
+	payment, err := ps.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	booking, err := ps.bookingRepo.GetByID(ctx, payment.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ps.authorizer.AuthorizeBooking(ctx, booking); err != nil {
+		return nil, err
+	}
+	return ps.refundService.InitiateRefund(ctx, paymentID, amount, reason, opts...)
+}
+
+// publishFailure emits a PaymentFailed event - demonstrates Observer Pattern
+func (ps *PaymentServiceImpl) publishFailure(ctx context.Context, booking *models.Booking, payment *models.Payment) {
+	if ps.eventBus == nil {
+		return
+	}
+	err := ps.eventBus.Publish(ctx, events.PaymentFailed{
+		PaymentID: payment.ID,
+		BookingID: payment.BookingID,
+		Reference: booking.Reference,
+		UserID:    payment.UserID,
+		Method:    string(payment.Method),
+		Reason:    payment.FailureReason,
+		Attempt:   payment.Attempt,
+		Timestamp: ps.clock.Now(),
+	})
+	if err != nil {
+		ps.logger.Warn(ctx, "failed to publish event", "event", events.EventPaymentFailed, "error", err)
+	}
+}
+
+// publishRejection emits a PaymentRejected event so the booking can be cancelled - demonstrates Observer Pattern
+func (ps *PaymentServiceImpl) publishRejection(ctx context.Context, payment *models.Payment) {
+	if ps.eventBus == nil {
+		return
+	}
+	err := ps.eventBus.Publish(ctx, events.PaymentRejected{
+		PaymentID: payment.ID,
+		BookingID: payment.BookingID,
+		UserID:    payment.UserID,
+		Score:     payment.Risk.Score,
+		Reasons:   payment.Risk.Reasons(),
+		Attempt:   payment.Attempt,
+		Timestamp: ps.clock.Now(),
+	})
+	if err != nil {
+		ps.logger.Warn(ctx, "failed to publish event", "event", events.EventPaymentRejected, "error", err)
+	}
 }
 
-// buildPaymentMetadata builds metadata for payment processing - demonstrates Strategy Pattern setup
-func (ps *PaymentServiceImpl) buildPaymentMetadata(method models.PaymentMethod, booking *models.Booking) map[string]string {
+// instrumentMetadata returns the payment details kept in one of the payer's saved instruments, or nil when
+// none was picked. Cards are detokenized here, just before the charge; the number is never saved with the payment.
+func (ps *PaymentServiceImpl) instrumentMetadata(ctx context.Context, instrumentID, payerID string, method models.PaymentMethod) (map[string]string, error) {
+	if instrumentID == "" {
+		return nil, nil
+	}
+	if ps.instrumentRepo == nil {
+		return nil, models.ErrPaymentInstrumentNotFound
+	}
+
+	instrument, err := ps.instrumentRepo.GetByID(ctx, instrumentID)
+	if err != nil {
+		return nil, err
+	}
+	// Another user's instrument is reported missing rather than confirming it exists
+	if instrument.UserID != payerID {
+		return nil, models.ErrPaymentInstrumentNotFound
+	}
+	if !instrument.Supports(method) {
+		return nil, fmt.Errorf("%w: %s instrument, %s payment", models.ErrInstrumentMethodMismatch, instrument.Type, method)
+	}
+
+	saved := map[string]string{"instrument_id": instrument.ID}
+	switch instrument.Type {
+	case models.InstrumentTypeCard:
+		if instrument.IsExpired(ps.clock.Now()) {
+			return nil, models.ErrCardExpired
+		}
+		card, err := ps.tokenizer.Detokenize(ctx, instrument.Token)
+		if err != nil {
+			return nil, err
+		}
+		saved["card_number"] = card.Number
+		saved["expiry"] = card.Expiry
+	case models.InstrumentTypeUPI:
+		saved["upi_id"] = instrument.Handle
+	}
+	return saved, nil
+}
+
+// buildPaymentMetadata builds metadata for payment processing - demonstrates Strategy Pattern setup. Details
+// from a saved instrument are used when given; otherwise the mock's sample details stand in for user input.
+func (ps *PaymentServiceImpl) buildPaymentMetadata(method models.PaymentMethod, booking *models.Booking, amount models.Money, options PaymentOptions, saved map[string]string) map[string]string {
 	metadata := map[string]string{
 		"booking_id": booking.ID,
-		"user_id":    booking.UserID,
-		"amount":     string(rune(booking.TotalAmount)),
+		"user_id":    options.payer(booking),
+		"amount":     strconv.FormatInt(amount.Minor, 10),
+		"currency":   amount.Currency,
+	}
+	if method == models.PaymentMethodEMI && options.TenureMonths > 0 {
+		metadata["tenure_months"] = strconv.Itoa(options.TenureMonths)
+	}
+	if saved != nil {
+		maps.Copy(metadata, saved)
+		return metadata
 	}
 
 	// Add method-specific metadata - in real implementation, this would come from user input
@@ -105,8 +492,10 @@ func (ps *PaymentServiceImpl) buildPaymentMetadata(method models.PaymentMethod,
 	case models.PaymentMethodNetBanking:
 		metadata["bank_code"] = "HDFC"
 		metadata["account_number"] = "1234567890"
-	case models.PaymentMethodWallet:
-		metadata["wallet_id"] = "wallet123"
+	case models.PaymentMethodEMI:
+		metadata["card_number"] = "1234-5678-9012-3456"
+		metadata["cvv"] = "123"
+		metadata["expiry"] = "12/25"
 	}
 
 	return metadata
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+)
+
+// CancellationPolicyEngine implements PolicyEngine using each theatre's tiered cancellation policy
+type CancellationPolicyEngine struct {
+	theatreRepo repositories.TheatreRepository
+	clock       clock.Clock
+}
+
+// NewPolicyEngine creates a policy engine backed by the theatres' cancellation policies
+func NewPolicyEngine(theatreRepo repositories.TheatreRepository, clock clock.Clock) PolicyEngine {
+	return &CancellationPolicyEngine{
+		theatreRepo: theatreRepo,
+		clock:       clock,
+	}
+}
+
+// RefundAmount returns the share of paid refundable for cancelling the show now
+func (pe *CancellationPolicyEngine) RefundAmount(ctx context.Context, show *models.Show, paid models.Money) (models.Money, error) {
+	theatre, err := pe.theatreRepo.GetByID(ctx, show.TheatreID)
+	if err != nil {
+		return models.Money{}, err
+	}
+
+	notice := show.StartTime.Sub(pe.clock.Now())
+	percent := theatre.GetCancellationPolicy().RefundPercent(notice)
+	return paid.Percent(percent), nil
+}
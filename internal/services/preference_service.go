@@ -0,0 +1,94 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"fmt"
+)
+
+// PreferenceServiceImpl implements PreferenceService - demonstrates Repository Pattern.
+// Preferences live on the user, so they are saved and restored with it.
+type PreferenceServiceImpl struct {
+	userRepo    repositories.UserRepository
+	cityRepo    repositories.CityRepository
+	theatreRepo repositories.TheatreRepository
+	clock       clock.Clock
+}
+
+func NewPreferenceService(userRepo repositories.UserRepository, cityRepo repositories.CityRepository, theatreRepo repositories.TheatreRepository, clock clock.Clock) PreferenceService {
+	return &PreferenceServiceImpl{
+		userRepo:    userRepo,
+		cityRepo:    cityRepo,
+		theatreRepo: theatreRepo,
+		clock:       clock,
+	}
+}
+
+// GetPreferences returns the user's profile; it is empty until they fill it in
+func (ps *PreferenceServiceImpl) GetPreferences(ctx context.Context, userID string) (*models.UserPreferences, error) {
+	user, err := ps.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	preferences := user.Preferences
+	return &preferences, nil
+}
+
+// UpdatePreferences replaces the profile. The home city must be in the city catalog and is stored under its
+// catalog name; every favourite theatre must exist.
+func (ps *PreferenceServiceImpl) UpdatePreferences(ctx context.Context, userID string, preferences models.UserPreferences) (*models.UserPreferences, error) {
+	user, err := ps.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences, err = preferences.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if preferences.HomeCity != "" {
+		city, err := ps.cityRepo.GetByName(ctx, preferences.HomeCity)
+		if err != nil {
+			return nil, err
+		}
+		preferences.HomeCity = city.Name
+	}
+	for _, theatreID := range preferences.FavoriteTheatreIDs {
+		if _, err := ps.theatreRepo.GetByID(ctx, theatreID); err != nil {
+			return nil, fmt.Errorf("favorite theatre %s: %w", theatreID, err)
+		}
+	}
+
+	if err := user.SetPreferences(preferences, ps.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := ps.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return ps.GetPreferences(ctx, userID)
+}
+
+// ApplyShowDefaults fills in what the filter leaves open: the home city, the preferred languages unless a
+// language was asked for, and favourite theatres first. Anonymous searches are returned unchanged.
+func (ps *PreferenceServiceImpl) ApplyShowDefaults(ctx context.Context, userID string, filter ShowFilter) (ShowFilter, error) {
+	if userID == "" {
+		return filter, nil
+	}
+	preferences, err := ps.GetPreferences(ctx, userID)
+	if err != nil {
+		return filter, err
+	}
+
+	if filter.City == "" {
+		filter.City = preferences.HomeCity
+	}
+	if filter.Language == "" && len(filter.Languages) == 0 {
+		filter.Languages = preferences.PreferredLanguages
+	}
+	if len(filter.FavoriteTheatreIDs) == 0 {
+		filter.FavoriteTheatreIDs = preferences.FavoriteTheatreIDs
+	}
+	return filter, nil
+}
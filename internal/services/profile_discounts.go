@@ -0,0 +1,79 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/pricing"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"time"
+)
+
+// CappedProfileDiscounts implements ProfileDiscountRules with the pricing discount rule, counting the monthly
+// cap over the user's live bookings made this calendar month (UTC). Cancelled and lapsed bookings give their
+// discount back to the allowance.
+type CappedProfileDiscounts struct {
+	userRepo    repositories.UserRepository
+	bookingRepo repositories.BookingRepository
+	discounts   pricing.ProfileDiscounts
+	clock       clock.Clock
+}
+
+// NewProfileDiscountRules creates profile discount rules backed by the users' profiles and existing bookings
+func NewProfileDiscountRules(userRepo repositories.UserRepository, bookingRepo repositories.BookingRepository, discounts pricing.ProfileDiscounts, clock clock.Clock) ProfileDiscountRules {
+	return &CappedProfileDiscounts{
+		userRepo:    userRepo,
+		bookingRepo: bookingRepo,
+		discounts:   discounts,
+		clock:       clock,
+	}
+}
+
+// DiscountFor returns the discount userID's verified profile earns on subtotal, after both caps
+func (r *CappedProfileDiscounts) DiscountFor(ctx context.Context, userID string, subtotal models.Money, excludeBookingID string) (*models.AppliedProfileDiscount, error) {
+	user, err := r.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := r.clock.Now()
+	category, ok := user.VerifiedDiscount(now)
+	if !ok {
+		return nil, nil
+	}
+
+	used, err := r.usedThisMonth(ctx, userID, subtotal.Currency, excludeBookingID, now)
+	if err != nil {
+		return nil, err
+	}
+	discount := r.discounts.Discount(category, subtotal, used)
+	if !discount.IsPositive() {
+		return nil, nil
+	}
+	return &models.AppliedProfileDiscount{Category: category, Amount: discount}, nil
+}
+
+// usedThisMonth adds up the profile discounts of the user's confirmed and unlapsed pending bookings made in
+// now's month
+func (r *CappedProfileDiscounts) usedThisMonth(ctx context.Context, userID, currency, excludeBookingID string, now time.Time) (models.Money, error) {
+	used := models.ZeroMoney(currency)
+	bookings, _, err := r.bookingRepo.GetByUserID(ctx, userID, repositories.Page{})
+	if err != nil {
+		return used, err
+	}
+
+	year, month, _ := now.UTC().Date()
+	for _, booking := range bookings {
+		discount := booking.GetProfileDiscount()
+		if discount == nil || booking.ID == excludeBookingID || !discount.Amount.SameCurrency(used) {
+			continue
+		}
+		if bookedYear, bookedMonth, _ := booking.BookingTime.UTC().Date(); bookedYear != year || bookedMonth != month {
+			continue
+		}
+		status := booking.GetStatus()
+		if status == models.BookingStatusConfirmed || (status == models.BookingStatusPending && !booking.IsExpired(now)) {
+			used = used.Add(discount.Amount)
+		}
+	}
+	return used, nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"time"
+)
+
+// PromotionServiceImpl implements PromotionService - demonstrates business rules for discounts
+type PromotionServiceImpl struct {
+	couponRepo repositories.CouponRepository
+	authorizer Authorizer
+	clock      clock.Clock
+}
+
+// NewPromotionService creates a new promotion service
+func NewPromotionService(couponRepo repositories.CouponRepository, authorizer Authorizer, clock clock.Clock) PromotionService {
+	return &PromotionServiceImpl{
+		couponRepo: couponRepo,
+		authorizer: authorizer,
+		clock:      clock,
+	}
+}
+
+// CreateCoupon creates a new promo code - super admins only
+func (ps *PromotionServiceImpl) CreateCoupon(ctx context.Context, code string, discountType models.DiscountType, value float64, minAmount models.Money, expiresAt time.Time, usageLimit int) (*models.Coupon, error) {
+	if _, err := ps.authorizer.AuthorizeCaller(ctx, models.PermissionManagePromotions, ""); err != nil {
+		return nil, err
+	}
+
+	coupon, err := models.NewCoupon(code, discountType, value, minAmount, expiresAt, usageLimit, ps.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.couponRepo.Create(ctx, coupon); err != nil {
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+// GetCoupon retrieves a coupon by code
+func (ps *PromotionServiceImpl) GetCoupon(ctx context.Context, code string) (*models.Coupon, error) {
+	return ps.couponRepo.GetByCode(ctx, code)
+}
+
+// PreviewDiscount returns the discount a coupon would give without consuming it
+func (ps *PromotionServiceImpl) PreviewDiscount(ctx context.Context, code string, amount models.Money) (models.Money, error) {
+	coupon, err := ps.couponRepo.GetByCode(ctx, code)
+	if err != nil {
+		return models.ZeroMoney(amount.Currency), err
+	}
+
+	if err := coupon.Validate(amount, ps.clock.Now()); err != nil {
+		return models.ZeroMoney(amount.Currency), err
+	}
+
+	return coupon.CalculateDiscount(amount), nil
+}
+
+// RedeemCoupon consumes one use of the coupon and returns the discount
+func (ps *PromotionServiceImpl) RedeemCoupon(ctx context.Context, code string, amount models.Money) (models.Money, error) {
+	coupon, err := ps.couponRepo.GetByCode(ctx, code)
+	if err != nil {
+		return models.ZeroMoney(amount.Currency), err
+	}
+
+	discount, err := coupon.Redeem(amount, ps.clock.Now())
+	if err != nil {
+		return models.ZeroMoney(amount.Currency), err
+	}
+
+	if err := ps.couponRepo.Update(ctx, coupon); err != nil {
+		coupon.Release(ps.clock.Now())
+		return models.ZeroMoney(amount.Currency), err
+	}
+
+	return discount, nil
+}
+
+// ReleaseCoupon returns a previously redeemed use
+func (ps *PromotionServiceImpl) ReleaseCoupon(ctx context.Context, code string) error {
+	coupon, err := ps.couponRepo.GetByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	coupon.Release(ps.clock.Now())
+	return ps.couponRepo.Update(ctx, coupon)
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/models"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// RecommendationProfile is what scorers know about the user and the catalog, gathered once per request
+type RecommendationProfile struct {
+	UserID        string
+	Preferences   models.UserPreferences
+	Watched       map[string]bool          // Movies the user has a confirmed booking for
+	GenreAffinity map[models.Genre]float64 // 0-1 from booked (secondary genres by half) and favourite genres; the strongest is 1
+	Languages     []models.Language        // Preferred languages, or failing that the ones the user has booked
+	CoBooked      map[string]int           // Movie ID -> users who share a booked movie with this user and booked it too
+	Bookers       map[string]int           // Movie ID -> users with a confirmed booking, for popularity
+}
+
+// MovieScorer rates how well a movie suits the profile - demonstrates Strategy Pattern: the service ranks
+// with any mix of scorers. Score returns 0-1 and, when the score is worth telling the user about, a reason.
+type MovieScorer interface {
+	Name() string
+	Score(profile *RecommendationProfile, movie *models.Movie) (score float64, reason string)
+}
+
+// WeightedScorer is a scorer with its share of the final score
+type WeightedScorer struct {
+	Scorer MovieScorer
+	Weight float64
+}
+
+// DefaultMovieScorers leans on the user's own taste first, then on what similar users booked, and falls back
+// to rating and popularity - all a new user has
+func DefaultMovieScorers() []WeightedScorer {
+	return []WeightedScorer{
+		{Scorer: GenreAffinityScorer{}, Weight: 0.35},
+		{Scorer: CoBookingScorer{}, Weight: 0.25},
+		{Scorer: RatingScorer{}, Weight: 0.2},
+		{Scorer: PopularityScorer{}, Weight: 0.15},
+		{Scorer: LanguageScorer{}, Weight: 0.05},
+	}
+}
+
+// GenreAffinityScorer scores the movie by the genre of it the user likes most; like the profile, it counts
+// secondary genres by half
+type GenreAffinityScorer struct{}
+
+func (GenreAffinityScorer) Name() string { return "genre-affinity" }
+
+func (GenreAffinityScorer) Score(profile *RecommendationProfile, movie *models.Movie) (float64, string) {
+	best, bestGenre := 0.0, models.Genre("")
+	for i, genre := range movie.AllGenres() {
+		if affinity := profile.GenreAffinity[genre] * genreWeight(i); affinity > best {
+			best, bestGenre = affinity, genre
+		}
+	}
+	if best < 0.75 {
+		return best, ""
+	}
+	return best, fmt.Sprintf("matches your taste for %s", strings.ToLower(strings.ReplaceAll(string(bestGenre), "_", "-")))
+}
+
+// CoBookingScorer is the collaborative signal: how many users who booked what this user booked also booked
+// the movie, relative to the most co-booked one
+type CoBookingScorer struct{}
+
+func (CoBookingScorer) Name() string { return "co-booking" }
+
+func (CoBookingScorer) Score(profile *RecommendationProfile, movie *models.Movie) (float64, string) {
+	count := profile.CoBooked[movie.ID]
+	if count == 0 {
+		return 0, ""
+	}
+	reason := fmt.Sprintf("booked by %d people who booked what you did", count)
+	if count == 1 {
+		reason = "booked by someone who booked what you did"
+	}
+	return float64(count) / float64(maxCount(profile.CoBooked)), reason
+}
+
+// RatingScorer scores the movie by its rating out of 10
+type RatingScorer struct{}
+
+func (RatingScorer) Name() string { return "rating" }
+
+func (RatingScorer) Score(profile *RecommendationProfile, movie *models.Movie) (float64, string) {
+	// Ratings carry one decimal; rounding drops float32 noise such as 8.399999
+	score := math.Round(float64(movie.Rating)*10) / 100
+	if score < 0.8 {
+		return score, ""
+	}
+	return score, fmt.Sprintf("rated %.1f/10", movie.Rating)
+}
+
+// PopularityScorer scores the movie by how many users booked it, relative to the most booked one
+type PopularityScorer struct{}
+
+func (PopularityScorer) Name() string { return "popularity" }
+
+func (PopularityScorer) Score(profile *RecommendationProfile, movie *models.Movie) (float64, string) {
+	count := profile.Bookers[movie.ID]
+	if count == 0 {
+		return 0, ""
+	}
+	score := float64(count) / float64(maxCount(profile.Bookers))
+	if score < 0.75 {
+		return score, ""
+	}
+	return score, "one of the most booked movies"
+}
+
+// LanguageScorer gives full marks to movies in a language the user prefers or has booked
+type LanguageScorer struct{}
+
+func (LanguageScorer) Name() string { return "language" }
+
+func (LanguageScorer) Score(profile *RecommendationProfile, movie *models.Movie) (float64, string) {
+	if slices.Contains(profile.Languages, movie.Language) {
+		return 1, ""
+	}
+	return 0, ""
+}
+
+func maxCount(counts map[string]int) int {
+	highest := 0
+	for _, count := range counts {
+		highest = max(highest, count)
+	}
+	return highest
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Bounds on how many recommendations one request returns
+const (
+	DefaultRecommendationLimit = 10
+	MaxRecommendationLimit     = 50
+)
+
+// RecommendationServiceImpl implements RecommendationService - demonstrates Strategy Pattern:
+// it gathers one RecommendationProfile per request and leaves the ranking to weighted MovieScorers
+type RecommendationServiceImpl struct {
+	userRepo    repositories.UserRepository
+	movieRepo   repositories.MovieRepository
+	showRepo    repositories.ShowRepository
+	bookingRepo repositories.BookingRepository
+	scorers     []WeightedScorer
+	clock       clock.Clock // Users' ages are counted on today's date
+}
+
+// NewRecommendationService ranks with scorers, or DefaultMovieScorers when none are given
+func NewRecommendationService(userRepo repositories.UserRepository, movieRepo repositories.MovieRepository, showRepo repositories.ShowRepository, bookingRepo repositories.BookingRepository, clock clock.Clock, scorers ...WeightedScorer) RecommendationService {
+	if len(scorers) == 0 {
+		scorers = DefaultMovieScorers()
+	}
+	return &RecommendationServiceImpl{
+		userRepo:    userRepo,
+		movieRepo:   movieRepo,
+		showRepo:    showRepo,
+		bookingRepo: bookingRepo,
+		scorers:     scorers,
+		clock:       clock,
+	}
+}
+
+// GetRecommendations scores every released movie the user hasn't booked and returns the best, highest score
+// first. Movies the user is known to be too young for are left out.
+func (rs *RecommendationServiceImpl) GetRecommendations(ctx context.Context, userID string, limit int) ([]*MovieRecommendation, error) {
+	if limit <= 0 {
+		limit = DefaultRecommendationLimit
+	}
+	limit = min(limit, MaxRecommendationLimit)
+
+	user, err := rs.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	movies, err := rs.movieRepo.GetReleased(ctx, rs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	profile, err := rs.buildProfile(ctx, user, movies)
+	if err != nil {
+		return nil, err
+	}
+
+	today := rs.clock.Now()
+	recommendations := make([]*MovieRecommendation, 0, len(movies))
+	for _, movie := range movies {
+		if profile.Watched[movie.ID] || !oldEnoughFor(user, movie, today) {
+			continue
+		}
+		recommendations = append(recommendations, rs.score(profile, movie))
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Movie.Rating != b.Movie.Rating {
+			return a.Movie.Rating > b.Movie.Rating
+		}
+		return a.Movie.Title < b.Movie.Title
+	})
+	return recommendations[:min(limit, len(recommendations))], nil
+}
+
+// buildProfile reads every confirmed booking of the movies once: who booked what drives the user's history,
+// genre affinity, co-booking counts and popularity alike
+func (rs *RecommendationServiceImpl) buildProfile(ctx context.Context, user *models.User, movies []*models.Movie) (*RecommendationProfile, error) {
+	bookers := make(map[string]map[string]bool, len(movies)) // Movie ID -> users with a confirmed booking
+	for _, movie := range movies {
+		shows, err := rs.showRepo.GetByMovieID(ctx, movie.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, show := range shows {
+			bookings, err := rs.bookingRepo.GetByShowID(ctx, show.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, booking := range bookings {
+				if booking.GetStatus() != models.BookingStatusConfirmed {
+					continue
+				}
+				if bookers[movie.ID] == nil {
+					bookers[movie.ID] = make(map[string]bool)
+				}
+				bookers[movie.ID][booking.UserID] = true
+			}
+		}
+	}
+
+	profile := &RecommendationProfile{
+		UserID:        user.ID,
+		Preferences:   user.Preferences,
+		Watched:       make(map[string]bool),
+		GenreAffinity: make(map[models.Genre]float64),
+		CoBooked:      make(map[string]int),
+		Bookers:       make(map[string]int, len(bookers)),
+	}
+
+	neighbours := make(map[string]bool) // Users who booked a movie this user booked
+	for _, movie := range movies {
+		profile.Bookers[movie.ID] = len(bookers[movie.ID])
+		if !bookers[movie.ID][user.ID] {
+			continue
+		}
+		profile.Watched[movie.ID] = true
+		for i, genre := range movie.AllGenres() {
+			profile.GenreAffinity[genre] += genreWeight(i)
+		}
+		if !slices.Contains(profile.Languages, movie.Language) {
+			profile.Languages = append(profile.Languages, movie.Language)
+		}
+		for bookerID := range bookers[movie.ID] {
+			if bookerID != user.ID {
+				neighbours[bookerID] = true
+			}
+		}
+	}
+	for movieID, users := range bookers {
+		if profile.Watched[movieID] {
+			continue
+		}
+		for bookerID := range users {
+			if neighbours[bookerID] {
+				profile.CoBooked[movieID]++
+			}
+		}
+	}
+
+	// Favourite genres count like a booking; then the strongest genre scales to 1
+	for _, genre := range user.Preferences.FavoriteGenres {
+		profile.GenreAffinity[genre]++
+	}
+	strongest := 0.0
+	for _, affinity := range profile.GenreAffinity {
+		strongest = max(strongest, affinity)
+	}
+	for genre, affinity := range profile.GenreAffinity {
+		profile.GenreAffinity[genre] = affinity / strongest
+	}
+
+	// Stated languages win over the ones the history shows
+	if len(user.Preferences.PreferredLanguages) > 0 {
+		profile.Languages = user.Preferences.PreferredLanguages
+	}
+	return profile, nil
+}
+
+// score is the weighted mean of the scorers' 0-1 scores, with the reasons of those that gave one
+func (rs *RecommendationServiceImpl) score(profile *RecommendationProfile, movie *models.Movie) *MovieRecommendation {
+	recommendation := &MovieRecommendation{Movie: movie, Scores: make(map[string]float64, len(rs.scorers))}
+	total, weights := 0.0, 0.0
+	for _, weighted := range rs.scorers {
+		score, reason := weighted.Scorer.Score(profile, movie)
+		score = min(max(score, 0), 1)
+		recommendation.Scores[weighted.Scorer.Name()] = score
+		total += score * weighted.Weight
+		weights += weighted.Weight
+		if reason != "" {
+			recommendation.Reasons = append(recommendation.Reasons, reason)
+		}
+	}
+	if weights > 0 {
+		recommendation.Score = total / weights
+	}
+	return recommendation
+}
+
+// genreWeight counts a movie's primary genre fully and its other genres by half
+func genreWeight(position int) float64 {
+	if position == 0 {
+		return 1
+	}
+	return 0.5
+}
+
+// oldEnoughFor leaves out age-rated movies for users whose date of birth says they are too young on today's date
+func oldEnoughFor(user *models.User, movie *models.Movie, today time.Time) bool {
+	age, known := user.AgeOn(today)
+	return !known || age >= movie.Certificate.MinimumAge()
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// RefundServiceImpl implements RefundService - demonstrates money movement tracking
+type RefundServiceImpl struct {
+	refundRepo     repositories.RefundRepository
+	paymentRepo    repositories.PaymentRepository
+	paymentGateway PaymentGateway
+	walletService  WalletService // Instant refunds to the user's wallet
+	eventBus       events.EventBus
+	audit          AuditRecorder // Refunds are recorded against the booking they pay back
+	logger         logging.Logger
+	clock          clock.Clock
+	mutex          sync.Mutex // Serializes refunds so a payment is never over-refunded
+}
+
+// NewRefundService creates a new refund service
+func NewRefundService(
+	refundRepo repositories.RefundRepository,
+	paymentRepo repositories.PaymentRepository,
+	paymentGateway PaymentGateway,
+	walletService WalletService,
+	eventBus events.EventBus,
+	audit AuditRecorder,
+	logger logging.Logger,
+	clock clock.Clock,
+) RefundService {
+	if audit == nil {
+		audit = NopAuditRecorder()
+	}
+	return &RefundServiceImpl{
+		refundRepo:     refundRepo,
+		paymentRepo:    paymentRepo,
+		paymentGateway: paymentGateway,
+		walletService:  walletService,
+		eventBus:       eventBus,
+		audit:          audit,
+		logger:         logger,
+		clock:          clock,
+	}
+}
+
+// InitiateRefund refunds all or part of a successful payment, to its payment method or with ToWallet to the user's wallet
+func (rs *RefundServiceImpl) InitiateRefund(ctx context.Context, paymentID string, amount models.Money, reason string, opts ...RefundOption) (*models.Refund, error) {
+	var options RefundOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.ToWallet && rs.walletService == nil {
+		return nil, models.ErrServiceUnavailable
+	}
+
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	payment, err := rs.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !payment.CanBeRefunded() {
+		return nil, models.ErrPaymentNotSuccessful
+	}
+
+	if !amount.SameCurrency(payment.Amount) {
+		return nil, models.ErrCurrencyMismatch
+	}
+
+	if amount.GreaterThan(payment.RefundableAmount()) {
+		return nil, models.ErrInvalidRefundAmount
+	}
+
+	// Record the refund before money moves so every attempt is traceable
+	refund, err := models.NewRefund(payment.ID, payment.BookingID, payment.UserID, amount, reason, rs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if options.ToWallet {
+		refund.Destination = models.RefundDestinationWallet
+	}
+
+	if err := rs.refundRepo.Create(ctx, refund); err != nil {
+		return nil, err
+	}
+
+	result, err := rs.sendRefund(ctx, payment, refund)
+	if err != nil || !result.Success {
+		failureReason := models.ErrRefundFailed.Error()
+		if err != nil {
+			failureReason = err.Error()
+		} else if result.ErrorMessage != "" {
+			failureReason = result.ErrorMessage
+		}
+		refund.MarkFailed(failureReason, rs.clock.Now())
+		rs.refundRepo.Update(ctx, refund)
+		return refund, models.ErrRefundFailed
+	}
+
+	return refund, rs.complete(ctx, payment, refund, result.TransactionID)
+}
+
+// RecordGatewayRefund records a refund the gateway made without InitiateRefund asking for it, e.g. one issued from
+// the provider's dashboard, as its callback reports it. No money moves here; a refund already recorded under the
+// gateway's reference is returned as it is, so repeated callbacks change nothing.
+func (rs *RefundServiceImpl) RecordGatewayRefund(ctx context.Context, paymentID string, amount models.Money, reference, reason string) (*models.Refund, error) {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	payment, err := rs.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := rs.refundRepo.GetByPaymentID(ctx, payment.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, refund := range refunds {
+		if refund.GatewayReference == reference {
+			return refund, nil
+		}
+	}
+
+	if !payment.CanBeRefunded() {
+		return nil, models.ErrPaymentNotSuccessful
+	}
+	if !amount.SameCurrency(payment.Amount) {
+		return nil, models.ErrCurrencyMismatch
+	}
+	if amount.GreaterThan(payment.RefundableAmount()) {
+		return nil, models.ErrInvalidRefundAmount
+	}
+
+	refund, err := models.NewRefund(payment.ID, payment.BookingID, payment.UserID, amount, reason, rs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.refundRepo.Create(ctx, refund); err != nil {
+		return nil, err
+	}
+	return refund, rs.complete(ctx, payment, refund, reference)
+}
+
+// complete takes a refund the money has moved for off its payment, then records and announces it
+func (rs *RefundServiceImpl) complete(ctx context.Context, payment *models.Payment, refund *models.Refund, reference string) error {
+	if err := payment.ProcessRefund(refund.Amount, refund.Reason, rs.clock.Now()); err != nil {
+		refund.MarkFailed(err.Error(), rs.clock.Now())
+		rs.refundRepo.Update(ctx, refund)
+		return err
+	}
+
+	if err := rs.paymentRepo.Update(ctx, payment); err != nil {
+		return err
+	}
+
+	refund.MarkProcessed(reference, rs.clock.Now())
+	if err := rs.refundRepo.Update(ctx, refund); err != nil {
+		return err
+	}
+
+	rs.audit.Record(ctx, AuditChange{
+		EntityType: models.AuditEntityBooking,
+		EntityID:   refund.BookingID,
+		Action:     models.AuditRefundIssued,
+		Details: map[string]string{
+			"refund_id":   refund.ID,
+			"payment_id":  refund.PaymentID,
+			"amount":      refund.Amount.String(),
+			"destination": string(refund.Destination),
+			"reason":      refund.Reason,
+		},
+	})
+
+	// Publish event so subscribers (e.g. notifications) learn about the refund
+	if rs.eventBus != nil {
+		err := rs.eventBus.Publish(ctx, events.RefundProcessed{
+			RefundID:  refund.ID,
+			PaymentID: refund.PaymentID,
+			BookingID: refund.BookingID,
+			UserID:    refund.UserID,
+			Amount:    refund.Amount,
+			Timestamp: rs.clock.Now(),
+		})
+		if err != nil {
+			rs.logger.Warn(ctx, "failed to publish event", "event", events.EventRefundProcessed, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// sendRefund moves the money through the gateway or into the user's wallet
+func (rs *RefundServiceImpl) sendRefund(ctx context.Context, payment *models.Payment, refund *models.Refund) (*PaymentResult, error) {
+	if refund.Destination != models.RefundDestinationWallet {
+		return rs.paymentGateway.RefundPayment(ctx, payment.TransactionID, refund.Amount, payment.Method)
+	}
+
+	tx, err := rs.walletService.Credit(ctx, refund.UserID, refund.Amount, refund.ID, "Refund: "+refund.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentResult{
+		Success:       true,
+		TransactionID: tx.ID,
+		Response:      fmt.Sprintf("Refunded %s to wallet", refund.Amount),
+	}, nil
+}
+
+// GetRefund retrieves a refund by ID
+func (rs *RefundServiceImpl) GetRefund(ctx context.Context, id string) (*models.Refund, error) {
+	return rs.refundRepo.GetByID(ctx, id)
+}
+
+// GetRefundsByPayment retrieves every refund issued against a payment
+func (rs *RefundServiceImpl) GetRefundsByPayment(ctx context.Context, paymentID string) ([]*models.Refund, error) {
+	return rs.refundRepo.GetByPaymentID(ctx, paymentID)
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+)
+
+// maxReportDays caps the daily revenue range at a year
+const maxReportDays = 366
+
+// ReportingServiceImpl implements ReportingService - read-only aggregates over bookings and payments
+type ReportingServiceImpl struct {
+	authorizer  Authorizer // Theatre admins see their own theatres' reports
+	theatreRepo repositories.TheatreRepository
+	screenRepo  repositories.ScreenRepository
+	showRepo    repositories.ShowRepository
+	bookingRepo repositories.BookingRepository
+	paymentRepo repositories.PaymentRepository
+	settlements repositories.SettlementRepository
+	seatTypes   *factories.SeatTypeRegistry // Orders seat types from cheapest to most premium
+}
+
+// NewReportingService creates a new reporting service
+func NewReportingService(
+	authorizer Authorizer,
+	theatreRepo repositories.TheatreRepository,
+	screenRepo repositories.ScreenRepository,
+	showRepo repositories.ShowRepository,
+	bookingRepo repositories.BookingRepository,
+	paymentRepo repositories.PaymentRepository,
+	settlements repositories.SettlementRepository,
+	seatTypes *factories.SeatTypeRegistry,
+) ReportingService {
+	if seatTypes == nil {
+		seatTypes = factories.DefaultSeatTypeRegistry()
+	}
+	return &ReportingServiceImpl{
+		authorizer:  authorizer,
+		theatreRepo: theatreRepo,
+		screenRepo:  screenRepo,
+		showRepo:    showRepo,
+		bookingRepo: bookingRepo,
+		paymentRepo: paymentRepo,
+		settlements: settlements,
+		seatTypes:   seatTypes,
+	}
+}
+
+// GetShowReport computes a show's occupancy, ticket sales by seat type and money collected
+func (rs *ReportingServiceImpl) GetShowReport(ctx context.Context, adminID, showID string) (*ShowReport, error) {
+	show, err := rs.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rs.authorizer.Authorize(ctx, adminID, models.PermissionViewReports, show.TheatreID); err != nil {
+		return nil, err
+	}
+
+	screen, err := rs.screenRepo.GetByID(ctx, show.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+
+	soldSeatIDs, err := rs.bookingRepo.GetSeatIDsByStatus(ctx, show.ID, models.BookingStatusConfirmed)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ShowReport{
+		ShowID:    show.ID,
+		TheatreID: show.TheatreID,
+		ScreenID:  show.ScreenID,
+		StartTime: show.StartTime,
+		Capacity:  screen.GetCapacity(),
+		SeatsSold: len(soldSeatIDs),
+		SeatTypes: rs.seatTypeSales(show, screen, soldSeatIDs),
+	}
+	report.OccupancyPercent = occupancy(report.SeatsSold, report.Capacity)
+
+	// A closed-out show reports the figures it was closed with
+	if summary := show.Summary; summary != nil {
+		report.SeatsSold, report.OccupancyPercent = summary.SeatsSold, summary.OccupancyPercent
+		report.Attended, report.NoShows, report.Final = summary.Attended, summary.NoShows, true
+		report.Revenue = RevenueTotals{Payments: summary.Payments, Gross: summary.Gross, Refunded: summary.Refunded, Net: summary.Net}
+		return report, nil
+	}
+
+	report.Revenue, err = showTakings(ctx, rs.bookingRepo, rs.paymentRepo, show)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// showTakings sums the settled payments of every booking of the show
+func showTakings(ctx context.Context, bookingRepo repositories.BookingRepository, paymentRepo repositories.PaymentRepository, show *models.Show) (RevenueTotals, error) {
+	totals := newRevenueTotals(show.BasePrice.Currency)
+
+	bookings, err := bookingRepo.GetByShowID(ctx, show.ID)
+	if err != nil {
+		return totals, err
+	}
+	bookingIDs := make([]string, 0, len(bookings))
+	for _, booking := range bookings {
+		bookingIDs = append(bookingIDs, booking.ID)
+	}
+
+	payments, err := paymentRepo.GetSettledByBookingIDs(ctx, bookingIDs)
+	if err != nil {
+		return totals, err
+	}
+	for _, payment := range payments {
+		totals.add(payment)
+	}
+	return totals, nil
+}
+
+// GetTheatreDailyRevenue sums what a theatre's shows took each day from `from` to `to`, in from's time zone
+func (rs *ReportingServiceImpl) GetTheatreDailyRevenue(ctx context.Context, adminID, theatreID string, from, to time.Time) (*TheatreRevenueReport, error) {
+	if _, err := rs.authorizer.Authorize(ctx, adminID, models.PermissionViewReports, theatreID); err != nil {
+		return nil, err
+	}
+
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, from.Location())
+	if last.Before(start) || last.Sub(start) >= maxReportDays*24*time.Hour {
+		return nil, models.ErrInvalidReportRange
+	}
+
+	if _, err := rs.theatreRepo.GetByID(ctx, theatreID); err != nil {
+		return nil, err
+	}
+
+	payments, err := rs.paymentRepo.GetSettledBetween(ctx, start, last.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	shows := make(map[string]*models.Show)
+	var matched []*models.Payment
+	for _, payment := range payments {
+		show, err := rs.showForPayment(ctx, payment, shows)
+		if err != nil {
+			return nil, err
+		}
+		if show != nil && show.TheatreID == theatreID {
+			matched = append(matched, payment)
+		}
+	}
+
+	// Totals are kept in the currency of the first payment
+	currency := models.DefaultCurrency
+	if len(matched) > 0 {
+		currency = matched[0].Amount.Currency
+	}
+
+	report := &TheatreRevenueReport{TheatreID: theatreID, Total: newRevenueTotals(currency)}
+	days := make(map[string]*RevenueTotals)
+	for day := start; !day.After(last); day = day.AddDate(0, 0, 1) {
+		totals := newRevenueTotals(currency)
+		days[day.Format(time.DateOnly)] = &totals
+	}
+
+	for _, payment := range matched {
+		days[payment.ProcessedAt.In(start.Location()).Format(time.DateOnly)].add(payment)
+		report.Total.add(payment)
+	}
+
+	for day := start; !day.After(last); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		report.Days = append(report.Days, DailyRevenue{Date: date, RevenueTotals: *days[date]})
+	}
+	return report, nil
+}
+
+// GetTheatreSettlements lists a theatre's settlements and totals what is outstanding and paid
+func (rs *ReportingServiceImpl) GetTheatreSettlements(ctx context.Context, adminID, theatreID string) (*SettlementStatement, error) {
+	if _, err := rs.authorizer.Authorize(ctx, adminID, models.PermissionViewReports, theatreID); err != nil {
+		return nil, err
+	}
+	if _, err := rs.theatreRepo.GetByID(ctx, theatreID); err != nil {
+		return nil, err
+	}
+
+	settlements, err := rs.settlements.GetByTheatreID(ctx, theatreID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Totals are kept in the currency of the first settlement
+	currency := models.DefaultCurrency
+	if len(settlements) > 0 {
+		currency = settlements[0].Payable.Currency
+	}
+
+	statement := &SettlementStatement{
+		TheatreID:   theatreID,
+		Settlements: settlements,
+		Outstanding: models.ZeroMoney(currency),
+		Paid:        models.ZeroMoney(currency),
+	}
+	for _, settlement := range settlements {
+		switch {
+		case !settlement.Payable.SameCurrency(statement.Paid):
+		case settlement.Status == models.SettlementStatusPaid:
+			statement.Paid = statement.Paid.Add(settlement.Payable)
+		default:
+			statement.Outstanding = statement.Outstanding.Add(settlement.Payable)
+		}
+	}
+	return statement, nil
+}
+
+// showForPayment finds the show a payment was for; payments whose booking is gone yield nil
+func (rs *ReportingServiceImpl) showForPayment(ctx context.Context, payment *models.Payment, shows map[string]*models.Show) (*models.Show, error) {
+	booking, err := rs.bookingRepo.GetByID(ctx, payment.BookingID)
+	if err != nil {
+		if errors.Is(err, models.ErrBookingNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return cached(ctx, shows, booking.ShowID, rs.showRepo.GetByID)
+}
+
+// seatTypeTiers orders the screen's seat types from cheapest to most premium, as registered.
+// Types no longer registered (runtime types after a restart) come last.
+func (rs *ReportingServiceImpl) seatTypeTiers(screen *models.Screen) []models.SeatType {
+	tiers := rs.seatTypes.Types()
+	for _, seatType := range screen.SeatTypes() {
+		if !slices.Contains(tiers, seatType) {
+			tiers = append(tiers, seatType)
+		}
+	}
+	return tiers
+}
+
+// seatTypeSales groups a screen's capacity and the show's sold seats by seat type
+func (rs *ReportingServiceImpl) seatTypeSales(show *models.Show, screen *models.Screen, soldSeatIDs []string) []SeatTypeSales {
+	currency := show.BasePrice.Currency
+	sales := make([]SeatTypeSales, 0)
+	for _, seatType := range rs.seatTypeTiers(screen) {
+		seats := screen.GetSeatsByType(seatType)
+		if len(seats) == 0 {
+			continue
+		}
+		sales = append(sales, SeatTypeSales{Type: seatType, Capacity: len(seats), TicketSales: models.ZeroMoney(currency)})
+	}
+
+	for _, seatID := range soldSeatIDs {
+		seat, err := screen.GetSeat(seatID)
+		if err != nil {
+			continue // Seat removed from the layout since
+		}
+		for i := range sales {
+			if price := show.PriceFor(seat); sales[i].Type == seat.Type && price.SameCurrency(sales[i].TicketSales) {
+				sales[i].SeatsSold++
+				sales[i].TicketSales = sales[i].TicketSales.Add(price)
+			}
+		}
+	}
+
+	for i := range sales {
+		sales[i].OccupancyPercent = occupancy(sales[i].SeatsSold, sales[i].Capacity)
+	}
+	return sales
+}
+
+// newRevenueTotals starts empty totals in a currency
+func newRevenueTotals(currency string) RevenueTotals {
+	return RevenueTotals{
+		Gross:    models.ZeroMoney(currency),
+		Refunded: models.ZeroMoney(currency),
+		Net:      models.ZeroMoney(currency),
+	}
+}
+
+// add counts a settled payment; payments in another currency are left out
+func (t *RevenueTotals) add(payment *models.Payment) {
+	if !payment.Amount.SameCurrency(t.Gross) {
+		return
+	}
+	t.Payments++
+	t.Gross = t.Gross.Add(payment.Amount)
+	t.Refunded = t.Refunded.Add(payment.RefundAmount)
+	t.Net = t.Gross.Sub(t.Refunded)
+}
+
+// occupancy returns sold as a percentage of capacity, to two decimals
+func occupancy(sold, capacity int) float64 {
+	if capacity == 0 {
+		return 0
+	}
+	return math.Round(float64(sold)*10000/float64(capacity)) / 100
+}
@@ -0,0 +1,394 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ResaleServiceImpl implements ResaleService. A user who can't make a show lists their confirmed booking at no
+// more than they paid; the first buyer to pay gets it. The buyer is charged the listing price, the booking and
+// its ticket become theirs, and the seller is refunded the price less the platform's fee. Sales take the same
+// show lock as booking changes, so a listing can't be bought while its booking is cancelled or modified.
+type ResaleServiceImpl struct {
+	listingRepo    repositories.ResaleListingRepository
+	bookingRepo    repositories.BookingRepository
+	showRepo       repositories.ShowRepository
+	userRepo       repositories.UserRepository
+	movieRepo      repositories.MovieRepository
+	ticketRepo     repositories.TicketRepository
+	paymentService PaymentService
+	refundService  RefundService
+	eventBus       events.EventBus
+	lockManager    locks.LockManager
+	audit          AuditRecorder
+	config         models.ResaleConfig
+	logger         logging.Logger
+	clock          clock.Clock
+}
+
+func NewResaleService(
+	listingRepo repositories.ResaleListingRepository,
+	bookingRepo repositories.BookingRepository,
+	showRepo repositories.ShowRepository,
+	userRepo repositories.UserRepository,
+	movieRepo repositories.MovieRepository,
+	ticketRepo repositories.TicketRepository,
+	paymentService PaymentService,
+	refundService RefundService,
+	eventBus events.EventBus,
+	lockManager locks.LockManager,
+	audit AuditRecorder,
+	config models.ResaleConfig,
+	logger logging.Logger,
+	clk clock.Clock,
+) ResaleService {
+	if audit == nil {
+		audit = NopAuditRecorder()
+	}
+	return &ResaleServiceImpl{
+		listingRepo:    listingRepo,
+		bookingRepo:    bookingRepo,
+		showRepo:       showRepo,
+		userRepo:       userRepo,
+		movieRepo:      movieRepo,
+		ticketRepo:     ticketRepo,
+		paymentService: paymentService,
+		refundService:  refundService,
+		eventBus:       eventBus,
+		lockManager:    lockManager,
+		audit:          audit,
+		config:         config,
+		logger:         logger,
+		clock:          clk,
+	}
+}
+
+// ListBooking puts the seller's confirmed booking up for resale at price, which can't exceed what they paid
+func (rs *ResaleServiceImpl) ListBooking(ctx context.Context, bookingID, sellerID string, price models.Money) (*models.ResaleListing, error) {
+	booking, err := rs.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := rs.lockShow(ctx, booking.ShowID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if booking.UserID != sellerID {
+		return nil, models.ErrForbidden
+	}
+	if _, err := rs.resellableShow(ctx, booking); err != nil {
+		return nil, err
+	}
+	if _, err := rs.listingRepo.GetOpenByBookingID(ctx, booking.ID); err == nil {
+		return nil, models.ErrBookingAlreadyListed
+	}
+
+	listing, err := models.NewResaleListing(booking, price, rs.config, rs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.listingRepo.Create(ctx, listing); err != nil {
+		return nil, err
+	}
+	return listing, nil
+}
+
+// WithdrawListing takes the seller's listing off sale; the booking stays theirs
+func (rs *ResaleServiceImpl) WithdrawListing(ctx context.Context, listingID, sellerID string) (*models.ResaleListing, error) {
+	listing, err := rs.listingRepo.GetByID(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.SellerID != sellerID {
+		return nil, models.ErrForbidden
+	}
+
+	unlock, err := rs.lockShow(ctx, listing.ShowID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := listing.Withdraw("withdrawn by seller", rs.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := rs.listingRepo.Update(ctx, listing); err != nil {
+		return nil, err
+	}
+	return listing, nil
+}
+
+// WithdrawBookingListing takes a booking's open listing off sale once the booking is cancelled, changed or
+// handed to someone else. It doesn't take the show lock, as it runs from events published under it; a sale
+// checks the booking against its listing anyway.
+func (rs *ResaleServiceImpl) WithdrawBookingListing(ctx context.Context, bookingID, reason string) error {
+	listing, err := rs.listingRepo.GetOpenByBookingID(ctx, bookingID)
+	if errors.Is(err, models.ErrResaleListingNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := listing.Withdraw(reason, rs.clock.Now()); err != nil {
+		if errors.Is(err, models.ErrResaleListingClosed) {
+			return nil
+		}
+		return err
+	}
+	return rs.listingRepo.Update(ctx, listing)
+}
+
+// GetListing returns a listing, recording it as expired if its show has started
+func (rs *ResaleServiceImpl) GetListing(ctx context.Context, listingID string) (*models.ResaleListing, error) {
+	listing, err := rs.listingRepo.GetByID(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.GetStatus() == models.ResaleListingListed {
+		show, err := rs.showRepo.GetByID(ctx, listing.ShowID)
+		if err != nil {
+			return nil, err
+		}
+		if err := rs.expireIfStarted(ctx, listing, show); err != nil {
+			return nil, err
+		}
+	}
+	return listing, nil
+}
+
+// GetShowListings lists the show's bookings on sale, cheapest first; none once the show has started
+func (rs *ResaleServiceImpl) GetShowListings(ctx context.Context, showID string) ([]*models.ResaleListing, error) {
+	show, err := rs.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	if !rs.clock.Now().Before(show.StartTime) {
+		return []*models.ResaleListing{}, nil
+	}
+	return rs.listingRepo.GetOpenByShowID(ctx, showID)
+}
+
+// GetSellerListings lists everything the user has put up for resale, newest first
+func (rs *ResaleServiceImpl) GetSellerListings(ctx context.Context, sellerID string) ([]*models.ResaleListing, error) {
+	return rs.listingRepo.GetBySellerID(ctx, sellerID)
+}
+
+// BuyListing charges the buyer the listing price and hands them the booking; the seller is refunded the price
+// less the fee across the payments they bought it with. Only the first buyer gets it: later ones find the
+// listing sold. A failed payment leaves the listing on sale.
+func (rs *ResaleServiceImpl) BuyListing(ctx context.Context, listingID, buyerID string, method models.PaymentMethod, opts ...PaymentOption) (*ResalePurchase, error) {
+	listing, err := rs.listingRepo.GetByID(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.SellerID == buyerID {
+		return nil, fmt.Errorf("%w: you can't buy your own booking", models.ErrInvalidResaleListing)
+	}
+
+	unlock, err := rs.lockShow(ctx, listing.ShowID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if listing.GetStatus() != models.ResaleListingListed {
+		return nil, models.ErrResaleListingClosed
+	}
+
+	booking, err := rs.bookingRepo.GetByID(ctx, listing.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	show, err := rs.resellableShow(ctx, booking)
+	if err != nil {
+		if errors.Is(err, models.ErrResaleListingClosed) {
+			rs.expireIfStarted(ctx, listing, show)
+		}
+		return nil, err
+	}
+	if stale := rs.staleReason(booking, listing); stale != "" {
+		// The booking changed before its listing was withdrawn
+		listing.Withdraw(stale, rs.clock.Now())
+		rs.listingRepo.Update(ctx, listing)
+		return nil, fmt.Errorf("%w: %s", models.ErrResaleListingClosed, stale)
+	}
+	if err := validateNewOwner(ctx, rs.userRepo, rs.movieRepo, booking, show, buyerID); err != nil {
+		return nil, err
+	}
+
+	payment, err := rs.paymentService.ChargeSupplement(ctx, booking.ID, listing.Price, method, append(opts, WithPayer(buyerID))...)
+	if err != nil {
+		return nil, err
+	}
+	if !payment.IsSuccessful() {
+		return nil, models.ErrPaymentProcessingFail
+	}
+
+	// The seller's payments stop counting as the booking's once it is resold, so they are gathered first
+	sellerPayments := booking.PaymentIDs()
+	if err := booking.Resell(buyerID, payment.ID, rs.clock.Now()); err != nil {
+		rs.refundBuyer(ctx, payment)
+		return nil, err
+	}
+	if err := rs.bookingRepo.Update(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	refunds := rs.paySeller(ctx, listing, sellerPayments)
+	refundIDs := make([]string, 0, len(refunds))
+	for _, refund := range refunds {
+		refundIDs = append(refundIDs, refund.ID)
+	}
+	if err := listing.MarkSold(buyerID, payment.ID, refundIDs, rs.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := rs.listingRepo.Update(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	rs.audit.Record(ctx, AuditChange{
+		EntityType: models.AuditEntityBooking,
+		EntityID:   booking.ID,
+		Action:     models.AuditBookingResold,
+		ActorID:    buyerID,
+		Details: map[string]string{
+			"listing_id":    listing.ID,
+			"seller_id":     listing.SellerID,
+			"buyer_id":      buyerID,
+			"price":         listing.Price.String(),
+			"fee":           listing.Fee.String(),
+			"seller_payout": listing.SellerPayout.String(),
+		},
+	})
+
+	rs.publish(ctx, events.BookingResold{
+		BookingID:    booking.ID,
+		Reference:    booking.Reference,
+		ShowID:       booking.ShowID,
+		ListingID:    listing.ID,
+		SellerID:     listing.SellerID,
+		BuyerID:      buyerID,
+		Price:        listing.Price,
+		SellerPayout: listing.SellerPayout,
+		Timestamp:    rs.clock.Now(),
+	})
+
+	return &ResalePurchase{Listing: listing, Booking: booking, Payment: payment, Refunds: refunds}, nil
+}
+
+// resellableShow returns the booking's show if the booking can still be resold: not a corporate block,
+// confirmed, before the show starts and before its ticket is scanned. The show is returned with
+// ErrResaleListingClosed once it has started.
+func (rs *ResaleServiceImpl) resellableShow(ctx context.Context, booking *models.Booking) (*models.Show, error) {
+	if booking.BulkBookingID != "" {
+		return nil, fmt.Errorf("%w: corporate seats are handed out with their codes", models.ErrInvalidResaleListing)
+	}
+	if booking.GetStatus() != models.BookingStatusConfirmed {
+		return nil, models.ErrBookingNotConfirmed
+	}
+
+	show, err := rs.showRepo.GetByID(ctx, booking.ShowID)
+	if err != nil {
+		return nil, err
+	}
+	if !rs.clock.Now().Before(show.StartTime) {
+		return show, fmt.Errorf("%w: the show has started", models.ErrResaleListingClosed)
+	}
+
+	if rs.ticketRepo != nil {
+		if ticket, err := rs.ticketRepo.GetByBookingID(ctx, booking.ID); err == nil && ticket.GetStatus() == models.TicketStatusUsed {
+			return nil, fmt.Errorf("%w: the ticket has been scanned", models.ErrResaleListingClosed)
+		}
+	}
+	return show, nil
+}
+
+// staleReason explains why a listing no longer describes its booking, or returns "" if it still does
+func (rs *ResaleServiceImpl) staleReason(booking *models.Booking, listing *models.ResaleListing) string {
+	switch {
+	case booking.UserID != listing.SellerID:
+		return "the booking has changed hands"
+	case booking.GetSeatCount() != listing.Seats || booking.TotalAmount != listing.FaceValue:
+		return "the booking has changed"
+	}
+	return ""
+}
+
+// expireIfStarted closes a listing whose show has started
+func (rs *ResaleServiceImpl) expireIfStarted(ctx context.Context, listing *models.ResaleListing, show *models.Show) error {
+	if show == nil || rs.clock.Now().Before(show.StartTime) {
+		return nil
+	}
+	if err := listing.Expire(rs.clock.Now()); err != nil {
+		return nil
+	}
+	return rs.listingRepo.Update(ctx, listing)
+}
+
+// paySeller refunds the seller's payout across the payments they bought the booking with, in order. A refund
+// that fails is logged rather than undoing the sale, which the buyer has already paid for.
+func (rs *ResaleServiceImpl) paySeller(ctx context.Context, listing *models.ResaleListing, paymentIDs []string) []*models.Refund {
+	var refunds []*models.Refund
+	remaining := listing.SellerPayout
+	for _, paymentID := range paymentIDs {
+		if !remaining.IsPositive() {
+			break
+		}
+
+		payment, err := rs.paymentService.GetPayment(ctx, paymentID)
+		if err != nil {
+			rs.logger.Error(ctx, "resale payout failed", "listing_id", listing.ID, "payment_id", paymentID, "error", err)
+			continue
+		}
+		if !payment.CanBeRefunded() {
+			continue
+		}
+
+		share := remaining.Min(payment.RefundableAmount())
+		refund, err := rs.refundService.InitiateRefund(ctx, payment.ID, share, "booking resold")
+		if err != nil {
+			rs.logger.Error(ctx, "resale payout failed", "listing_id", listing.ID, "payment_id", paymentID, "error", err)
+			continue
+		}
+		refunds = append(refunds, refund)
+		remaining = remaining.Sub(share)
+	}
+
+	if remaining.IsPositive() {
+		rs.logger.Error(ctx, "resale payout incomplete", "listing_id", listing.ID, "seller_id", listing.SellerID, "unpaid", remaining.String())
+	}
+	return refunds
+}
+
+// refundBuyer gives a buyer their money back when the booking couldn't be handed to them
+func (rs *ResaleServiceImpl) refundBuyer(ctx context.Context, payment *models.Payment) {
+	if _, err := rs.refundService.InitiateRefund(ctx, payment.ID, payment.Amount, "resale failed"); err != nil {
+		rs.logger.Error(ctx, "resale buyer refund failed", "payment_id", payment.ID, "error", err)
+	}
+}
+
+// lockShow takes the show's booking lock, so sales can't interleave with cancellations, changes and transfers
+func (rs *ResaleServiceImpl) lockShow(ctx context.Context, showID string) (locks.Unlock, error) {
+	return rs.lockManager.Lock(ctx, locks.ShowKey(bookingLockOwner, showID))
+}
+
+// publish sends an event to subscribers; subscriber failures never fail the sale
+func (rs *ResaleServiceImpl) publish(ctx context.Context, event events.Event) {
+	if rs.eventBus == nil {
+		return
+	}
+	if err := rs.eventBus.Publish(ctx, event); err != nil {
+		rs.logger.Warn(ctx, "failed to publish event", "event", event.Type(), "error", err)
+	}
+}
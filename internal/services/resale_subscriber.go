@@ -0,0 +1,25 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/events"
+	"context"
+)
+
+// RegisterResaleSubscriber takes bookings off sale once they are cancelled, changed or handed to someone else -
+// demonstrates Observer Pattern
+func RegisterResaleSubscriber(bus events.EventBus, resaleService ResaleService) {
+	bus.Subscribe(events.EventBookingCancelled, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingCancelled)
+		return resaleService.WithdrawBookingListing(ctx, e.BookingID, "the booking was cancelled")
+	})
+
+	bus.Subscribe(events.EventBookingModified, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingModified)
+		return resaleService.WithdrawBookingListing(ctx, e.BookingID, "the booking was changed")
+	})
+
+	bus.Subscribe(events.EventBookingTransferred, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingTransferred)
+		return resaleService.WithdrawBookingListing(ctx, e.BookingID, "the booking was transferred")
+	})
+}
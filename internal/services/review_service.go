@@ -0,0 +1,221 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"sync"
+)
+
+// ReviewServiceImpl implements ReviewService - user reviews with moderation and rating aggregation
+type ReviewServiceImpl struct {
+	reviewRepo repositories.ReviewRepository
+	movieRepo  repositories.MovieRepository
+	userRepo   repositories.UserRepository
+	authorizer Authorizer
+	mutex      sync.Mutex // Serializes duplicate checks and rating recomputation
+	clock      clock.Clock
+}
+
+// NewReviewService creates a new review service
+func NewReviewService(
+	reviewRepo repositories.ReviewRepository,
+	movieRepo repositories.MovieRepository,
+	userRepo repositories.UserRepository,
+	authorizer Authorizer,
+	clock clock.Clock,
+) ReviewService {
+	return &ReviewServiceImpl{
+		reviewRepo: reviewRepo,
+		movieRepo:  movieRepo,
+		userRepo:   userRepo,
+		authorizer: authorizer,
+		clock:      clock,
+	}
+}
+
+// SubmitReview creates a pending review; each user may review a released movie once
+func (rs *ReviewServiceImpl) SubmitReview(ctx context.Context, userID, movieID string, stars int, text string) (*models.Review, error) {
+	if _, err := rs.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	movie, err := rs.movieRepo.GetByID(ctx, movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !movie.IsReleased(rs.clock.Now()) {
+		return nil, models.ErrMovieNotReleased
+	}
+
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	if _, err := rs.reviewRepo.GetByUserAndMovie(ctx, userID, movieID); err == nil {
+		return nil, models.ErrDuplicateReview
+	} else if !errors.Is(err, models.ErrReviewNotFound) {
+		return nil, err
+	}
+
+	review, err := models.NewReview(userID, movieID, stars, text, rs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.reviewRepo.Create(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// EditReview lets the author change their review; it goes back to moderation
+func (rs *ReviewServiceImpl) EditReview(ctx context.Context, reviewID, userID string, stars int, text string) (*models.Review, error) {
+	review, err := rs.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.UserID != userID {
+		return nil, models.ErrForbidden
+	}
+
+	wasApproved := review.GetStatus() == models.ReviewStatusApproved
+	if err := review.Edit(stars, text, rs.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := rs.reviewRepo.Update(ctx, review); err != nil {
+		return nil, err
+	}
+
+	// The old stars no longer count until the edit is approved
+	if wasApproved {
+		if err := rs.recomputeRating(ctx, review.MovieID); err != nil {
+			return nil, err
+		}
+	}
+
+	return review, nil
+}
+
+// ModerateReview approves or rejects a review and refreshes the movie rating - super admins only
+func (rs *ReviewServiceImpl) ModerateReview(ctx context.Context, moderatorID, reviewID string, approve bool, note string) (*models.Review, error) {
+	if _, err := rs.authorizer.Authorize(ctx, moderatorID, models.PermissionModerateReviews, ""); err != nil {
+		return nil, err
+	}
+
+	review, err := rs.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	review.Moderate(moderatorID, approve, note, rs.clock.Now())
+	if err := rs.reviewRepo.Update(ctx, review); err != nil {
+		return nil, err
+	}
+
+	if err := rs.recomputeRating(ctx, review.MovieID); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// ListMovieReviews returns a page of a movie's approved reviews, newest first
+func (rs *ReviewServiceImpl) ListMovieReviews(ctx context.Context, movieID string, offset, limit int) (*MovieReviews, error) {
+	return rs.listReviews(ctx, movieID, models.ReviewStatusApproved, offset, limit)
+}
+
+// ListPendingReviews returns the moderation queue for a movie - super admins only
+func (rs *ReviewServiceImpl) ListPendingReviews(ctx context.Context, moderatorID, movieID string, offset, limit int) (*MovieReviews, error) {
+	if _, err := rs.authorizer.Authorize(ctx, moderatorID, models.PermissionModerateReviews, ""); err != nil {
+		return nil, err
+	}
+	return rs.listReviews(ctx, movieID, models.ReviewStatusPending, offset, limit)
+}
+
+// GetRatingSummary returns the movie's aggregate rating and star distribution of approved reviews
+func (rs *ReviewServiceImpl) GetRatingSummary(ctx context.Context, movieID string) (*RatingSummary, error) {
+	movie, err := rs.movieRepo.GetByID(ctx, movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, _, err := rs.reviewRepo.GetByMovieID(ctx, movieID, models.ReviewStatusApproved, repositories.Page{})
+	if err != nil {
+		return nil, err
+	}
+
+	average, distribution := aggregateStars(reviews)
+	return &RatingSummary{
+		MovieID:      movie.ID,
+		Rating:       movie.Rating,
+		ReviewCount:  len(reviews),
+		AverageStars: average,
+		Distribution: distribution,
+	}, nil
+}
+
+// listReviews validates paging and fetches reviews in one moderation state
+func (rs *ReviewServiceImpl) listReviews(ctx context.Context, movieID string, status models.ReviewStatus, offset, limit int) (*MovieReviews, error) {
+	if offset < 0 || limit < 0 {
+		return nil, models.ErrInvalidReviewData
+	}
+
+	if _, err := rs.movieRepo.GetByID(ctx, movieID); err != nil {
+		return nil, err
+	}
+
+	reviews, total, err := rs.reviewRepo.GetByMovieID(ctx, movieID, status, repositories.Page{Offset: offset, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MovieReviews{
+		Reviews: reviews,
+		Total:   total,
+		Offset:  offset,
+		Limit:   limit,
+	}, nil
+}
+
+// recomputeRating rebuilds Movie.Rating from every approved review
+func (rs *ReviewServiceImpl) recomputeRating(ctx context.Context, movieID string) error {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	movie, err := rs.movieRepo.GetByID(ctx, movieID)
+	if err != nil {
+		return err
+	}
+
+	reviews, _, err := rs.reviewRepo.GetByMovieID(ctx, movieID, models.ReviewStatusApproved, repositories.Page{})
+	if err != nil {
+		return err
+	}
+
+	average, _ := aggregateStars(reviews)
+	movie.ApplyReviewAggregate(average, len(reviews), rs.clock.Now())
+	return rs.movieRepo.Update(ctx, movie)
+}
+
+// aggregateStars returns the mean star count and how many reviews gave each star value
+func aggregateStars(reviews []*models.Review) (float64, map[int]int) {
+	distribution := make(map[int]int, models.MaxReviewStars)
+	for stars := models.MinReviewStars; stars <= models.MaxReviewStars; stars++ {
+		distribution[stars] = 0
+	}
+
+	if len(reviews) == 0 {
+		return 0, distribution
+	}
+
+	total := 0
+	for _, review := range reviews {
+		total += review.Stars
+		distribution[review.Stars]++
+	}
+	return float64(total) / float64(len(reviews)), distribution
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/metrics"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"time"
+)
+
+// SeatHoldServiceImpl implements SeatHoldService - ties blocked seats to a user with a TTL
+type SeatHoldServiceImpl struct {
+	holdRepo    repositories.SeatHoldRepository
+	userRepo    repositories.UserRepository
+	showRepo    repositories.ShowRepository
+	screenRepo  repositories.ScreenRepository
+	eventBus    events.EventBus        // Announces seats blocked and released by holds; may be nil
+	lockManager locks.LockManager      // Serializes hold changes with seat changes, per show
+	timeouts    models.BookingTimeouts // How long holds last when their show's payment window is longer
+	metrics     metrics.Recorder       // Counts seat conflicts
+	logger      logging.Logger
+	clock       clock.Clock
+}
+
+// holdLockOwner namespaces hold locks apart from booking locks
+const holdLockOwner = "hold"
+
+// sweepLockLease caps how long an expiry sweep keeps a show locked, should its instance die mid-sweep
+const sweepLockLease = 10 * time.Second
+
+// NewSeatHoldService creates a new seat hold service
+func NewSeatHoldService(
+	holdRepo repositories.SeatHoldRepository,
+	userRepo repositories.UserRepository,
+	showRepo repositories.ShowRepository,
+	screenRepo repositories.ScreenRepository,
+	eventBus events.EventBus,
+	lockManager locks.LockManager,
+	timeouts models.BookingTimeouts,
+	logger logging.Logger,
+	metrics metrics.Recorder,
+	clock clock.Clock,
+) SeatHoldService {
+	return &SeatHoldServiceImpl{
+		holdRepo:    holdRepo,
+		userRepo:    userRepo,
+		showRepo:    showRepo,
+		screenRepo:  screenRepo,
+		eventBus:    eventBus,
+		lockManager: lockManager,
+		timeouts:    timeouts,
+		logger:      logger,
+		metrics:     metrics,
+		clock:       clock,
+	}
+}
+
+// CreateHold blocks seats atomically on behalf of a user
+func (hs *SeatHoldServiceImpl) CreateHold(ctx context.Context, userID, showID string, seatIDs []string) (*models.SeatHold, error) {
+	unlock, err := hs.lockShow(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	user, err := hs.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Blocked {
+		return nil, models.ErrUserBlocked
+	}
+
+	show, err := hs.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !show.CanBeBooked(hs.clock.Now()) {
+		return nil, models.ErrShowNotBookable
+	}
+
+	// Held back seats change under this lock too, so the check stands until the seats are blocked
+	if err := show.CheckSeatsOnSale(seatIDs); err != nil {
+		hs.metrics.SeatConflict()
+		return nil, err
+	}
+
+	hold, err := models.NewSeatHold(userID, showID, seatIDs, show.SeatHoldWindow(hs.timeouts), hs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	screen, err := hs.screenRepo.GetByID(ctx, show.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !screen.IsOperational() {
+		return nil, models.ErrScreenUnderMaintenance
+	}
+
+	// Block seats atomically - all or nothing
+	if err := show.BlockSeats(screen, seatIDs); err != nil {
+		if errors.Is(err, models.ErrSeatNotAvailable) {
+			hs.metrics.SeatConflict()
+		}
+		return nil, err
+	}
+
+	// Saved before the hold that owns them, as releaseSeats saves their release
+	if err := hs.showRepo.Update(ctx, show); err != nil {
+		hs.unblockSeats(show, seatIDs)
+		return nil, err
+	}
+
+	if err := hs.holdRepo.Create(ctx, hold); err != nil {
+		hs.unblockSeats(show, seatIDs)
+		if err := hs.showRepo.Update(ctx, show); err != nil {
+			hs.logger.Warn(ctx, "failed to save seats of hold that wasn't created", "show_id", showID, "error", err)
+		}
+		return nil, err
+	}
+
+	hs.publish(ctx, events.SeatHoldCreated{
+		HoldID:    hold.ID,
+		UserID:    hold.UserID,
+		ShowID:    hold.ShowID,
+		SeatIDs:   hold.SeatIDs,
+		Timestamp: hs.clock.Now(),
+	})
+	return hold, nil
+}
+
+// GetHold retrieves a hold by ID
+func (hs *SeatHoldServiceImpl) GetHold(ctx context.Context, holdID string) (*models.SeatHold, error) {
+	return hs.holdRepo.GetByID(ctx, holdID)
+}
+
+// ExtendHold gives the owner more time to complete the booking
+func (hs *SeatHoldServiceImpl) ExtendHold(ctx context.Context, holdID, userID string) (*models.SeatHold, error) {
+	hold, err := hs.getOwnedHold(ctx, holdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := hold.Extend(hs.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := hs.holdRepo.Update(ctx, hold); err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// ReleaseHold gives the seats back to inventory
+func (hs *SeatHoldServiceImpl) ReleaseHold(ctx context.Context, holdID, userID string) error {
+	hold, err := hs.getOwnedHold(ctx, holdID, userID)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := hs.lockShow(ctx, hold.ShowID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := hold.Release(hs.clock.Now()); err != nil {
+		return err
+	}
+
+	return hs.releaseSeats(ctx, hold, "released")
+}
+
+// ConsumeHold hands the held seats over to a booking; seats stay blocked
+// The hold's own status check makes consume vs. release/expire races safe without a show lock.
+// Inside a transaction, the hold is active again should it roll back, since the booking then doesn't exist.
+func (hs *SeatHoldServiceImpl) ConsumeHold(ctx context.Context, holdID, userID, bookingID string) (*models.SeatHold, error) {
+	hold, err := hs.getOwnedHold(ctx, holdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := hold.Consume(bookingID, hs.clock.Now()); err != nil {
+		return nil, err
+	}
+	outside := repositories.OutsideTransaction(ctx)
+	repositories.OnRollback(ctx, func() {
+		hold.RevertConsume(bookingID, hs.clock.Now())
+		if err := hs.holdRepo.Update(outside, hold); err != nil {
+			hs.logger.Warn(outside, "failed to restore hold of rolled back booking", "hold_id", hold.ID, "error", err)
+		}
+	})
+
+	if err := hs.holdRepo.Update(ctx, hold); err != nil {
+		hold.RevertConsume(bookingID, hs.clock.Now())
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// ReleaseExpiredHolds expires stale holds and unblocks their seats
+func (hs *SeatHoldServiceImpl) ReleaseExpiredHolds(ctx context.Context) (int, error) {
+	holds, err := hs.holdRepo.GetActive(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, hold := range holds {
+		if !hold.IsExpired(hs.clock.Now()) {
+			continue
+		}
+
+		ok, err := hs.expireHold(ctx, hold)
+		if err != nil {
+			if ctx.Err() != nil {
+				return released, err
+			}
+			hs.logger.Warn(ctx, "failed to release seats of expired hold", "hold_id", hold.ID, "error", err)
+			continue
+		}
+		if ok {
+			released++
+		}
+	}
+
+	return released, nil
+}
+
+// ReleaseShowHolds releases every active hold on a show, regardless of owner
+func (hs *SeatHoldServiceImpl) ReleaseShowHolds(ctx context.Context, showID string) (int, error) {
+	holds, err := hs.holdRepo.GetActive(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	unlock, err := hs.lockShow(ctx, showID)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	released := 0
+	for _, hold := range holds {
+		if hold.ShowID != showID || hold.Release(hs.clock.Now()) != nil {
+			continue
+		}
+		if err := hs.releaseSeats(ctx, hold, "show cancelled"); err != nil {
+			return released, err
+		}
+		released++
+	}
+
+	return released, nil
+}
+
+// expireHold expires one hold under its show lock; false means someone else got there first.
+// A show that is locked - seats being blocked, or another instance sweeping it - is left for the next sweep
+// rather than stalling this one.
+func (hs *SeatHoldServiceImpl) expireHold(ctx context.Context, hold *models.SeatHold) (bool, error) {
+	unlock, ok, err := hs.lockManager.TryLock(ctx, locks.ShowKey(holdLockOwner, hold.ShowID), sweepLockLease)
+	if err != nil || !ok {
+		return false, err
+	}
+	defer unlock()
+
+	if err := hold.Expire(hs.clock.Now()); err != nil {
+		return false, nil
+	}
+
+	return true, hs.releaseSeats(ctx, hold, "expired")
+}
+
+// lockShow serializes seat changes for one show
+func (hs *SeatHoldServiceImpl) lockShow(ctx context.Context, showID string) (locks.Unlock, error) {
+	return hs.lockManager.Lock(ctx, locks.ShowKey(holdLockOwner, showID))
+}
+
+// getOwnedHold loads a hold and verifies the caller owns it
+func (hs *SeatHoldServiceImpl) getOwnedHold(ctx context.Context, holdID, userID string) (*models.SeatHold, error) {
+	hold, err := hs.holdRepo.GetByID(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hold.UserID != userID {
+		return nil, models.ErrUnauthorized
+	}
+
+	return hold, nil
+}
+
+// releaseSeats persists the hold, unblocks its seats and announces why
+func (hs *SeatHoldServiceImpl) releaseSeats(ctx context.Context, hold *models.SeatHold, reason string) error {
+	if err := hs.holdRepo.Update(ctx, hold); err != nil {
+		return err
+	}
+
+	show, err := hs.showRepo.GetByID(ctx, hold.ShowID)
+	if err != nil {
+		return err
+	}
+
+	hs.unblockSeats(show, hold.SeatIDs)
+	if err := hs.showRepo.Update(ctx, show); err != nil {
+		return err
+	}
+
+	hs.publish(ctx, events.SeatHoldReleased{
+		HoldID:    hold.ID,
+		UserID:    hold.UserID,
+		ShowID:    hold.ShowID,
+		SeatIDs:   hold.SeatIDs,
+		Reason:    reason,
+		Timestamp: hs.clock.Now(),
+	})
+	return nil
+}
+
+// publish sends an event to subscribers; subscriber failures never fail the hold
+func (hs *SeatHoldServiceImpl) publish(ctx context.Context, event events.Event) {
+	if hs.eventBus == nil {
+		return
+	}
+	if err := hs.eventBus.Publish(ctx, event); err != nil {
+		hs.logger.Warn(ctx, "failed to publish event", "event", event.Type(), "error", err)
+	}
+}
+
+// unblockSeats returns blocked seats to the show's inventory
+func (hs *SeatHoldServiceImpl) unblockSeats(show *models.Show, seatIDs []string) {
+	for _, seatID := range seatIDs {
+		show.UnblockSeat(seatID)
+	}
+}
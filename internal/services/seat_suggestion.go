@@ -0,0 +1,157 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/pricing"
+	"context"
+	"slices"
+	"sort"
+)
+
+// idealRowFraction is where the best rows sit, measured from the screen (0) to the back wall (1)
+const idealRowFraction = 2.0 / 3.0
+
+// SuggestSeats finds count adjacent available seats of seatType (any type if empty) in one row.
+// Rows about two-thirds of the way back are tried first; within a row the block nearest the centre wins.
+// Aisles break adjacency. The suggestion is not a hold - book or hold it promptly.
+func (bs *BookingServiceImpl) SuggestSeats(ctx context.Context, showID string, count int, seatType models.SeatType) (*SeatSuggestion, error) {
+	if count <= 0 {
+		return nil, models.ErrInvalidBookingData
+	}
+
+	show, err := bs.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !show.CanBeBooked(bs.clock.Now()) {
+		return nil, models.ErrShowNotBookable
+	}
+
+	screen, err := bs.screenRepo.GetByID(ctx, show.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The show's seat status is what CreateBooking checks, so only suggest seats it will accept
+	seatMap := show.SeatMap(screen)
+	pricing.PriceSeatMap(bs.pricer, show, screen, seatMap)
+	for _, r := range rankRows(len(seatMap.Rows)) {
+		row := seatMap.Rows[r]
+		block := bestBlockInRow(row, count, seatType)
+		if block == nil {
+			continue
+		}
+
+		suggestion := &SeatSuggestion{
+			ShowID: showID,
+			Row:    row.Name,
+			Type:   block[0].Type,
+			Total:  models.ZeroMoney(block[0].Price.Currency),
+		}
+		for _, cell := range block {
+			suggestion.SeatIDs = append(suggestion.SeatIDs, cell.SeatID)
+			suggestion.Labels = append(suggestion.Labels, cell.Label)
+			suggestion.Total = suggestion.Total.Add(cell.Price)
+		}
+		return suggestion, nil
+	}
+
+	return nil, models.ErrInsufficientSeats
+}
+
+// rankRows orders row indexes from the ideal viewing row outwards; on a tie the row further back wins
+func rankRows(rows int) []int {
+	ideal := float64(rows-1) * idealRowFraction
+	order := make([]int, rows)
+	for i := range order {
+		order[i] = i
+	}
+
+	distance := func(i int) float64 {
+		d := float64(i) - ideal
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		da, db := distance(order[a]), distance(order[b])
+		if da != db {
+			return da < db
+		}
+		return order[a] > order[b]
+	})
+	return order
+}
+
+// bestBlockInRow returns the count-seat window closest to the row's centre, or nil if none fits.
+// Windows that would split a seat group are skipped.
+func bestBlockInRow(row models.SeatMapRow, count int, seatType models.SeatType) []models.SeatMapCell {
+	var seats []models.SeatMapCell
+	groupSizes := make(map[string]int)
+	for _, cell := range row.Cells {
+		if cell.IsSeat() {
+			seats = append(seats, cell)
+			if cell.Group != "" {
+				groupSizes[cell.Group]++
+			}
+		}
+	}
+	if len(seats) < count {
+		return nil
+	}
+	centre := float64(seats[0].Number+seats[len(seats)-1].Number) / 2
+
+	var best []models.SeatMapCell
+	bestDistance := 0.0
+	var run []models.SeatMapCell
+	for _, cell := range row.Cells {
+		usable := cell.IsSeat() && cell.Status == models.SeatStatusAvailable && (seatType == "" || cell.Type == seatType) && !isAccessible(cell)
+		if !usable || (len(run) > 0 && cell.Number != run[len(run)-1].Number+1) {
+			run = nil
+		}
+		if !usable {
+			continue
+		}
+
+		run = append(run, cell)
+		if len(run) < count {
+			continue
+		}
+
+		window := run[len(run)-count:]
+		if splitsGroup(window, groupSizes) {
+			continue
+		}
+		distance := float64(window[0].Number+window[count-1].Number)/2 - centre
+		if distance < 0 {
+			distance = -distance
+		}
+		if best == nil || distance < bestDistance {
+			best, bestDistance = window, distance
+		}
+	}
+	return best
+}
+
+// splitsGroup reports whether the window holds only part of some seat group
+func splitsGroup(window []models.SeatMapCell, groupSizes map[string]int) bool {
+	inWindow := make(map[string]int)
+	for _, cell := range window {
+		if cell.Group != "" {
+			inWindow[cell.Group]++
+		}
+	}
+	for group, seats := range inWindow {
+		if seats != groupSizes[group] {
+			return true
+		}
+	}
+	return false
+}
+
+// isAccessible reports wheelchair spaces and companion seats, which suggestions leave for those who need them
+func isAccessible(cell models.SeatMapCell) bool {
+	return slices.Contains(cell.Attributes, models.SeatAttributeWheelchair) || slices.Contains(cell.Attributes, models.SeatAttributeCompanion)
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SettlementServiceImpl implements SettlementService - aggregates a theatre's confirmed bookings per period
+// and keeps the platform's commission before paying the rest out
+type SettlementServiceImpl struct {
+	authorizer     Authorizer
+	theatreRepo    repositories.TheatreRepository
+	showRepo       repositories.ShowRepository
+	bookingRepo    repositories.BookingRepository
+	settlementRepo repositories.SettlementRepository
+	config         models.SettlementConfig
+	clock          clock.Clock
+	mutex          sync.Mutex // Serializes generation, so two overlapping periods can't both pass the overlap check
+}
+
+// NewSettlementService creates a settlement service charging config's commission
+func NewSettlementService(
+	authorizer Authorizer,
+	theatreRepo repositories.TheatreRepository,
+	showRepo repositories.ShowRepository,
+	bookingRepo repositories.BookingRepository,
+	settlementRepo repositories.SettlementRepository,
+	config models.SettlementConfig,
+	clock clock.Clock,
+) SettlementService {
+	return &SettlementServiceImpl{
+		authorizer:     authorizer,
+		theatreRepo:    theatreRepo,
+		showRepo:       showRepo,
+		bookingRepo:    bookingRepo,
+		settlementRepo: settlementRepo,
+		config:         config,
+		clock:          clock,
+	}
+}
+
+// GenerateSettlement settles the confirmed bookings of a theatre's shows that started from `from` to `to`, in from's time zone.
+// The period must be over and must not overlap an earlier settlement, so no booking is paid out twice.
+func (ss *SettlementServiceImpl) GenerateSettlement(ctx context.Context, adminID, theatreID string, from, to time.Time) (*models.Settlement, error) {
+	if _, err := ss.authorizer.Authorize(ctx, adminID, models.PermissionSettlePayouts, theatreID); err != nil {
+		return nil, err
+	}
+
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, from.Location()).AddDate(0, 0, 1)
+	if !end.After(start) || end.Sub(start) > maxReportDays*24*time.Hour || end.After(ss.clock.Now()) {
+		return nil, models.ErrInvalidSettlementData
+	}
+
+	if _, err := ss.theatreRepo.GetByID(ctx, theatreID); err != nil {
+		return nil, err
+	}
+
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	earlier, err := ss.settlementRepo.GetByTheatreID(ctx, theatreID)
+	if err != nil {
+		return nil, err
+	}
+	for _, settlement := range earlier {
+		if settlement.Overlaps(start, end) {
+			return nil, models.ErrSettlementOverlap
+		}
+	}
+
+	shows, err := ss.showRepo.GetByTheatreBetween(ctx, theatreID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(shows, func(i, j int) bool { return shows[i].StartTime.Before(shows[j].StartTime) })
+
+	// Totals are kept in the currency of the first show
+	currency := models.DefaultCurrency
+	if len(shows) > 0 {
+		currency = shows[0].BasePrice.Currency
+	}
+	settlement, err := models.NewSettlement(theatreID, start, end, ss.config, currency, ss.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, show := range shows {
+		bookings, err := ss.bookingRepo.GetByShowID(ctx, show.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, booking := range bookings {
+			settlement.AddBooking(booking)
+		}
+	}
+
+	if err := ss.settlementRepo.Create(ctx, settlement); err != nil {
+		return nil, err
+	}
+	return settlement, nil
+}
+
+// MarkSettlementPaid records the payout reference once the money has been sent to the theatre
+func (ss *SettlementServiceImpl) MarkSettlementPaid(ctx context.Context, adminID, settlementID, reference string) (*models.Settlement, error) {
+	settlement, err := ss.settlementRepo.GetByID(ctx, settlementID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ss.authorizer.Authorize(ctx, adminID, models.PermissionSettlePayouts, settlement.TheatreID); err != nil {
+		return nil, err
+	}
+
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	if err := settlement.MarkPaid(reference, ss.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := ss.settlementRepo.Update(ctx, settlement); err != nil {
+		return nil, err
+	}
+	return settlement, nil
+}
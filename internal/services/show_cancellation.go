@@ -0,0 +1,189 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/models"
+	"context"
+	"fmt"
+	"time"
+)
+
+// CancelShow calls off a show and cascades to everyone who booked it:
+// bookings are cancelled, seats and holds released, payments refunded in full and users notified. The theatre's admins only.
+// The show stays cancelled even if a refund or notification fails; those are listed in Failures.
+func (ss *ShowServiceImpl) CancelShow(ctx context.Context, showID, reason string) (*ShowCancellation, error) {
+	if reason == "" {
+		return nil, models.ErrInvalidShowData
+	}
+
+	show, err := ss.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ss.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, show.TheatreID); err != nil {
+		return nil, err
+	}
+
+	// Cancel first so no new holds or bookings slip in while the cascade runs
+	if err := show.Cancel(reason, ss.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := ss.showRepo.Update(ctx, show); err != nil {
+		return nil, err
+	}
+	ss.audit.Record(ctx, AuditChange{
+		EntityType: models.AuditEntityShow,
+		EntityID:   show.ID,
+		Action:     models.AuditShowCancelled,
+		Details:    map[string]string{"reason": reason},
+	})
+
+	if _, err := ss.holdService.ReleaseShowHolds(ctx, showID); err != nil {
+		ss.logger.Warn(ctx, "failed to release holds of cancelled show", "show_id", showID, "error", err)
+	}
+
+	bookings, err := ss.bookingService.CancelShowBookings(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	cancellation := &ShowCancellation{Show: show, Bookings: make([]CancelledShowBooking, 0, len(bookings))}
+	bookingIDs := make([]string, 0, len(bookings))
+	for _, booking := range bookings {
+		cancelled := CancelledShowBooking{BookingID: booking.ID, UserID: booking.UserID}
+		bookingIDs = append(bookingIDs, booking.ID)
+
+		refunds, err := ss.refundInFull(ctx, booking, reason)
+		cancelled.Refunds = refunds
+		if err != nil {
+			cancellation.Failures = append(cancellation.Failures, fmt.Sprintf("refund for booking %s: %v", booking.ID, err))
+		}
+
+		if err := ss.notificationService.SendShowCancellation(ctx, booking.UserID, booking.ID, booking.Reference, reason); err != nil {
+			cancellation.Failures = append(cancellation.Failures, fmt.Sprintf("notify user %s: %v", booking.UserID, err))
+		}
+
+		cancellation.Bookings = append(cancellation.Bookings, cancelled)
+	}
+
+	ss.publish(ctx, events.ShowCancelled{
+		ShowID:     show.ID,
+		MovieID:    show.MovieID,
+		EventID:    show.EventID,
+		TheatreID:  show.TheatreID,
+		Reason:     reason,
+		BookingIDs: bookingIDs,
+		Timestamp:  ss.clock.Now(),
+	})
+
+	return cancellation, nil
+}
+
+// RescheduleShow moves an upcoming show to a new start time on the same screen and notifies its bookers.
+// Bookings, seats and tickets carry over unchanged.
+func (ss *ShowServiceImpl) RescheduleShow(ctx context.Context, showID string, startTime time.Time) (*ShowReschedule, error) {
+	show, err := ss.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ss.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, show.TheatreID); err != nil {
+		return nil, err
+	}
+
+	screen, err := ss.screenRepo.GetByID(ctx, show.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !screen.IsOperational() {
+		return nil, models.ErrScreenUnderMaintenance
+	}
+
+	hasConflict, err := ss.showRepo.CheckConflict(ctx, show.ScreenID, startTime, startTime.Add(show.GetDuration()), show.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasConflict {
+		return nil, models.ErrInvalidShowTime
+	}
+
+	previousStart := show.StartTime
+	if err := show.Reschedule(startTime, ss.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := ss.showRepo.Update(ctx, show); err != nil {
+		return nil, err
+	}
+	ss.audit.Record(ctx, AuditChange{
+		EntityType: models.AuditEntityShow,
+		EntityID:   show.ID,
+		Action:     models.AuditShowRescheduled,
+		Details: map[string]string{
+			"old_start_time": previousStart.Format(time.RFC3339),
+			"new_start_time": show.StartTime.Format(time.RFC3339),
+		},
+	})
+
+	reschedule := &ShowReschedule{Show: show, PreviousStart: previousStart}
+
+	bookings, err := ss.bookingRepo.GetByShowID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, booking := range bookings {
+		if !booking.CanBeCancelled() {
+			continue // Only live bookings care about the new time
+		}
+
+		if err := ss.notificationService.SendShowRescheduled(ctx, booking.UserID, booking.ID, booking.Reference, show.StartTime); err != nil {
+			reschedule.Failures = append(reschedule.Failures, fmt.Sprintf("notify user %s: %v", booking.UserID, err))
+			continue
+		}
+		reschedule.NotifiedUsers++
+	}
+
+	ss.publish(ctx, events.ShowRescheduled{
+		ShowID:        show.ID,
+		PreviousStart: previousStart,
+		StartTime:     show.StartTime,
+		Timestamp:     ss.clock.Now(),
+	})
+
+	return reschedule, nil
+}
+
+// refundInFull refunds whatever is left on every successful payment of a booking
+func (ss *ShowServiceImpl) refundInFull(ctx context.Context, booking *models.Booking, reason string) ([]*models.Refund, error) {
+	var refunds []*models.Refund
+	for _, paymentID := range booking.PaymentIDs() {
+		payment, err := ss.paymentService.GetPayment(ctx, paymentID)
+		if err != nil {
+			return refunds, err
+		}
+
+		if !payment.CanBeRefunded() {
+			continue
+		}
+
+		refund, err := ss.paymentService.RefundPayment(ctx, payment.ID, payment.RefundableAmount(), "show cancelled: "+reason)
+		if err != nil {
+			return refunds, err
+		}
+		refunds = append(refunds, refund)
+	}
+	return refunds, nil
+}
+
+// publish sends an event to subscribers; subscriber failures never undo the show change
+func (ss *ShowServiceImpl) publish(ctx context.Context, event events.Event) {
+	if ss.eventBus == nil {
+		return
+	}
+	if err := ss.eventBus.Publish(ctx, event); err != nil {
+		ss.logger.Warn(ctx, "failed to publish event", "event", event.Type(), "error", err)
+	}
+}
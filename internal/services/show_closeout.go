@@ -0,0 +1,220 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"strconv"
+)
+
+// ShowCloseOutServiceImpl implements ShowCloseOutService. Closing out a show marks the tickets of confirmed
+// bookings that were never scanned as no-shows, then completes the show with a summary of its attendance and
+// takings. The summary is final: reports use it from then on instead of recomputing the figures.
+type ShowCloseOutServiceImpl struct {
+	showRepo    repositories.ShowRepository
+	screenRepo  repositories.ScreenRepository
+	bookingRepo repositories.BookingRepository
+	ticketRepo  repositories.TicketRepository
+	paymentRepo repositories.PaymentRepository
+	authorizer  Authorizer // Theatre admins can close out their own shows early
+	audit       AuditRecorder
+	lockManager locks.LockManager // Same show lock as bookings, so none change while the figures are taken
+	eventBus    events.EventBus
+	logger      logging.Logger
+	clock       clock.Clock
+}
+
+func NewShowCloseOutService(
+	showRepo repositories.ShowRepository,
+	screenRepo repositories.ScreenRepository,
+	bookingRepo repositories.BookingRepository,
+	ticketRepo repositories.TicketRepository,
+	paymentRepo repositories.PaymentRepository,
+	authorizer Authorizer,
+	audit AuditRecorder,
+	lockManager locks.LockManager,
+	eventBus events.EventBus,
+	logger logging.Logger,
+	clk clock.Clock,
+) ShowCloseOutService {
+	if audit == nil {
+		audit = NopAuditRecorder()
+	}
+	return &ShowCloseOutServiceImpl{
+		showRepo:    showRepo,
+		screenRepo:  screenRepo,
+		bookingRepo: bookingRepo,
+		ticketRepo:  ticketRepo,
+		paymentRepo: paymentRepo,
+		authorizer:  authorizer,
+		audit:       audit,
+		lockManager: lockManager,
+		eventBus:    eventBus,
+		logger:      logger,
+		clock:       clk,
+	}
+}
+
+// CloseOutShow closes out one show that has ended, without waiting for the scheduled job
+func (cs *ShowCloseOutServiceImpl) CloseOutShow(ctx context.Context, showID string) (*models.Show, error) {
+	show, err := cs.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := cs.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, show.TheatreID); err != nil {
+		return nil, err
+	}
+	return cs.closeOut(ctx, show.ID)
+}
+
+// CloseOutEndedShows closes out every scheduled show that has ended, earliest first. A show that fails is
+// left for the next run; the others are still closed out.
+func (cs *ShowCloseOutServiceImpl) CloseOutEndedShows(ctx context.Context) ([]*models.Show, error) {
+	shows, err := cs.showRepo.GetAwaitingCloseOut(ctx, cs.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var closed []*models.Show
+	var errs []error
+	for _, show := range shows {
+		completed, err := cs.closeOut(ctx, show.ID)
+		if errors.Is(err, models.ErrShowClosed) || errors.Is(err, models.ErrShowCancelled) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		closed = append(closed, completed)
+	}
+	return closed, errors.Join(errs...)
+}
+
+// closeOut takes the show's final figures under its booking lock and completes it
+func (cs *ShowCloseOutServiceImpl) closeOut(ctx context.Context, showID string) (*models.Show, error) {
+	unlock, err := cs.lockManager.Lock(ctx, locks.ShowKey(bookingLockOwner, showID))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	show, err := cs.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case show.IsCancelled():
+		return nil, models.ErrShowCancelled
+	case show.IsClosed():
+		return nil, models.ErrShowClosed
+	case cs.clock.Now().Before(show.EndTime):
+		return nil, models.ErrShowNotEnded
+	}
+
+	summary, err := cs.summarize(ctx, show)
+	if err != nil {
+		return nil, err
+	}
+	if err := show.Complete(summary, cs.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := cs.showRepo.Update(ctx, show); err != nil {
+		return nil, err
+	}
+
+	cs.audit.Record(ctx, AuditChange{
+		EntityType: models.AuditEntityShow,
+		EntityID:   show.ID,
+		Action:     models.AuditShowClosedOut,
+		Details: map[string]string{
+			"seats_sold": strconv.Itoa(summary.SeatsSold),
+			"attended":   strconv.Itoa(summary.Attended),
+			"no_shows":   strconv.Itoa(summary.NoShows),
+		},
+	})
+
+	if cs.eventBus != nil {
+		err := cs.eventBus.Publish(ctx, events.ShowCompleted{
+			ShowID:    show.ID,
+			TheatreID: show.TheatreID,
+			Summary:   *show.Summary,
+			Timestamp: cs.clock.Now(),
+		})
+		if err != nil {
+			cs.logger.Warn(ctx, "failed to publish event", "event", events.EventShowCompleted, "error", err)
+		}
+	}
+	return show, nil
+}
+
+// summarize counts the show's attendance, marking unscanned tickets as no-shows, and sums its takings
+func (cs *ShowCloseOutServiceImpl) summarize(ctx context.Context, show *models.Show) (models.ShowSummary, error) {
+	screen, err := cs.screenRepo.GetByID(ctx, show.ScreenID)
+	if err != nil {
+		return models.ShowSummary{}, err
+	}
+	summary := models.ShowSummary{Capacity: screen.GetCapacity()}
+
+	bookings, err := cs.bookingRepo.GetByShowID(ctx, show.ID)
+	if err != nil {
+		return summary, err
+	}
+	for _, booking := range bookings {
+		if booking.GetStatus() != models.BookingStatusConfirmed {
+			continue
+		}
+
+		seats := booking.GetSeatCount()
+		summary.SeatsSold += seats
+		attended, err := cs.markAttendance(ctx, booking)
+		if err != nil {
+			return summary, err
+		}
+		if attended {
+			summary.Attended += seats
+		} else {
+			summary.NoShows += seats
+			summary.NoShowBookings++
+		}
+	}
+	summary.OccupancyPercent = occupancy(summary.SeatsSold, summary.Capacity)
+	summary.AttendancePercent = occupancy(summary.Attended, summary.SeatsSold)
+
+	takings, err := showTakings(ctx, cs.bookingRepo, cs.paymentRepo, show)
+	if err != nil {
+		return summary, err
+	}
+	summary.Payments, summary.Gross, summary.Refunded, summary.Net = takings.Payments, takings.Gross, takings.Refunded, takings.Net
+	return summary, nil
+}
+
+// markAttendance reports whether the booking's ticket was scanned, marking it a no-show if it wasn't. A booking
+// that never got a ticket counts as a no-show.
+func (cs *ShowCloseOutServiceImpl) markAttendance(ctx context.Context, booking *models.Booking) (bool, error) {
+	ticket, err := cs.ticketRepo.GetByBookingID(ctx, booking.ID)
+	if errors.Is(err, models.ErrTicketNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch ticket.GetStatus() {
+	case models.TicketStatusUsed:
+		return true, nil
+	case models.TicketStatusIssued:
+		if err := ticket.MarkNoShow(cs.clock.Now()); err != nil {
+			return false, err
+		}
+		if err := cs.ticketRepo.Update(ctx, ticket); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/locks"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"time"
+)
+
+// ShowReminderServiceImpl implements ShowReminderService. A confirmed booking is reminded once per configured
+// offset before its show starts, e.g. a day and two hours before, on whichever channels its owner prefers.
+// Bookings made after a reminder's window opened skip that reminder, and a show rescheduled later gets fresh
+// ones. Cancelled bookings and cancelled shows are never reminded.
+type ShowReminderServiceImpl struct {
+	showRepo      repositories.ShowRepository
+	bookingRepo   repositories.BookingRepository
+	theatreRepo   repositories.TheatreRepository
+	movieRepo     repositories.MovieRepository
+	eventRepo     repositories.EventRepository
+	notifications NotificationService
+	config        models.ShowReminderConfig
+	lockManager   locks.LockManager // Same show lock as bookings, so a booking isn't cancelled or resold while it is reminded
+	clock         clock.Clock
+}
+
+func NewShowReminderService(
+	showRepo repositories.ShowRepository,
+	bookingRepo repositories.BookingRepository,
+	theatreRepo repositories.TheatreRepository,
+	movieRepo repositories.MovieRepository,
+	eventRepo repositories.EventRepository,
+	notifications NotificationService,
+	config models.ShowReminderConfig,
+	lockManager locks.LockManager,
+	clk clock.Clock,
+) ShowReminderService {
+	return &ShowReminderServiceImpl{
+		showRepo:      showRepo,
+		bookingRepo:   bookingRepo,
+		theatreRepo:   theatreRepo,
+		movieRepo:     movieRepo,
+		eventRepo:     eventRepo,
+		notifications: notifications,
+		config:        config,
+		lockManager:   lockManager,
+		clock:         clk,
+	}
+}
+
+// SendShowReminders reminds the owners of confirmed bookings whose show's latest reminder window has opened.
+// A show that fails is retried on the next run; the others are still reminded.
+func (rs *ShowReminderServiceImpl) SendShowReminders(ctx context.Context) (int, error) {
+	if len(rs.config.Offsets) == 0 {
+		return 0, nil
+	}
+
+	now := rs.clock.Now()
+	shows, err := rs.showRepo.GetStartingBetween(ctx, now, now.Add(rs.config.Lookahead()))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, show := range shows {
+		reminded, err := rs.remindShow(ctx, show.ID, now)
+		sent += reminded
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+// remindShow reminds the show's confirmed bookings that haven't been since its latest reminder window opened
+func (rs *ShowReminderServiceImpl) remindShow(ctx context.Context, showID string, now time.Time) (int, error) {
+	unlock, err := rs.lockManager.Lock(ctx, locks.ShowKey(bookingLockOwner, showID))
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	// Read again under the lock: the show may have been cancelled or moved since it was listed
+	show, err := rs.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return 0, err
+	}
+	if show.IsCancelled() {
+		return 0, nil
+	}
+	windowOpens, ok := rs.config.LatestWindow(show.StartTime, now)
+	if !ok || !now.Before(show.StartTime) {
+		return 0, nil
+	}
+
+	bookings, err := rs.bookingRepo.GetByShowID(ctx, show.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	var due []*models.Booking
+	for _, booking := range bookings {
+		// Someone who booked after the window opened has just seen the show's time
+		if booking.GetStatus() == models.BookingStatusConfirmed && booking.BookingTime.Before(windowOpens) && booking.NeedsReminder(windowOpens) {
+			due = append(due, booking)
+		}
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	title, err := rs.title(ctx, show)
+	if err != nil {
+		return 0, err
+	}
+	theatre, err := rs.theatreRepo.GetByID(ctx, show.TheatreID)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, booking := range due {
+		if err := rs.notifications.SendShowReminder(ctx, booking.UserID, booking.ID, booking.Reference, title, theatre.Name, show.StartTime); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		booking.MarkReminded(now)
+		if err := rs.bookingRepo.Update(ctx, booking); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent++
+	}
+	return sent, errors.Join(errs...)
+}
+
+// title names the movie or live event the show is for
+func (rs *ShowReminderServiceImpl) title(ctx context.Context, show *models.Show) (string, error) {
+	if show.IsMovie() {
+		movie, err := rs.movieRepo.GetByID(ctx, show.MovieID)
+		if err != nil {
+			return "", err
+		}
+		return movie.Title, nil
+	}
+
+	event, err := rs.eventRepo.GetByID(ctx, show.EventID)
+	if err != nil {
+		return "", err
+	}
+	return event.Title, nil
+}
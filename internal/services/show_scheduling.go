@@ -0,0 +1,115 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"time"
+)
+
+// SuggestSlots proposes start times for a movie on a screen on the given day, in date's location.
+// Every suggestion keeps the configured turnaround clear of the screen's shows - checked with CheckConflict -
+// and of the suggestions before it, so all of them can be scheduled together. The theatre's admins only.
+func (ss *ShowServiceImpl) SuggestSlots(ctx context.Context, screenID, movieID string, date time.Time) (*SlotSuggestion, error) {
+	screen, err := ss.screenRepo.GetByID(ctx, screenID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ss.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, screen.TheatreID); err != nil {
+		return nil, err
+	}
+
+	if !screen.IsOperational() {
+		return nil, models.ErrScreenUnderMaintenance
+	}
+
+	movie, err := ss.movieRepo.GetByID(ctx, movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	config := ss.scheduling
+	midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	closing := midnight.Add(config.DayEnd)
+
+	start := midnight.Add(config.DayStart)
+	if now := ss.clock.Now(); start.Before(now) {
+		start = now
+	}
+	start = alignToSlot(start, midnight, config.SlotInterval)
+
+	suggestion := &SlotSuggestion{
+		ScreenID:   screen.ID,
+		MovieID:    movie.ID,
+		Duration:   movie.Duration,
+		Turnaround: config.Turnaround,
+		StartTimes: []time.Time{},
+	}
+	for end := start.Add(movie.Duration); !end.After(closing); end = start.Add(movie.Duration) {
+		conflict, err := ss.showRepo.CheckConflict(ctx, screen.ID, start.Add(-config.Turnaround), end.Add(config.Turnaround), "")
+		if err != nil {
+			return nil, err
+		}
+
+		if conflict {
+			start = start.Add(config.SlotInterval)
+			continue
+		}
+
+		suggestion.StartTimes = append(suggestion.StartTimes, start)
+		start = alignToSlot(end.Add(config.Turnaround), midnight, config.SlotInterval)
+	}
+
+	return suggestion, nil
+}
+
+// SetBookingTimeout changes how long new bookings for a show wait for payment; seat holds follow it when
+// it is shorter than the platform's hold duration. Zero restores the theatre's timeout. The theatre's admins only.
+func (ss *ShowServiceImpl) SetBookingTimeout(ctx context.Context, showID string, timeout time.Duration) (*models.Show, error) {
+	show, err := ss.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ss.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, show.TheatreID); err != nil {
+		return nil, err
+	}
+
+	if show.IsCancelled() {
+		return nil, models.ErrShowCancelled
+	}
+
+	if timeout == 0 {
+		theatre, err := ss.theatreRepo.GetByID(ctx, show.TheatreID)
+		if err != nil {
+			return nil, err
+		}
+		timeout = theatre.GetBookingTimeout()
+	}
+
+	previous := show.BookingTimeout
+	if err := show.SetBookingTimeout(timeout, ss.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := ss.showRepo.Update(ctx, show); err != nil {
+		return nil, err
+	}
+
+	ss.audit.Record(ctx, AuditChange{
+		EntityType: models.AuditEntityShow,
+		EntityID:   show.ID,
+		Action:     models.AuditBookingTimeoutChange,
+		Details: map[string]string{
+			"old_timeout": previous.String(),
+			"new_timeout": show.BookingTimeout.String(),
+		},
+	})
+	return show, nil
+}
+
+// alignToSlot rounds t up to the next multiple of interval after origin
+func alignToSlot(t, origin time.Time, interval time.Duration) time.Time {
+	if past := t.Sub(origin) % interval; past > 0 {
+		return t.Add(interval - past)
+	}
+	return t
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// icsTimeFormat is an iCalendar UTC date-time, e.g. 20261017T153000Z; calendars show it in the viewer's zone
+const icsTimeFormat = "20060102T150405Z"
+
+// ExportICS builds an iCalendar event for a confirmed booking, so the show can be added to any calendar app.
+// The event's UID is stable per booking: importing it again after a reschedule updates the entry.
+func (ts *TicketServiceImpl) ExportICS(ctx context.Context, bookingID string) (*Attachment, error) {
+	details, err := ts.bookingService.GetBookingDetails(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if details.Booking.GetStatus() != models.BookingStatusConfirmed {
+		return nil, models.ErrBookingNotConfirmed
+	}
+
+	seats := make([]string, 0, len(details.Seats))
+	for _, seat := range details.Seats {
+		seats = append(seats, seat.GetSeatNumber())
+	}
+	description := []string{
+		"Booking reference: " + details.Booking.Reference,
+		fmt.Sprintf("%s, %s", details.Theatre.Name, details.Screen.Name),
+		"Seats: " + strings.Join(seats, ", "),
+		"Show the QR code on your ticket at the gate.",
+	}
+
+	var ics icsWriter
+	ics.line("BEGIN", "VCALENDAR")
+	ics.line("VERSION", "2.0")
+	ics.line("PRODID", "-//bookmyshow-lld//Tickets//EN")
+	ics.line("CALSCALE", "GREGORIAN")
+	ics.line("METHOD", "PUBLISH")
+	ics.line("BEGIN", "VEVENT")
+	ics.line("UID", details.Booking.ID+"@bookmyshow-lld")
+	ics.line("DTSTAMP", ts.clock.Now().UTC().Format(icsTimeFormat))
+	ics.line("DTSTART", details.Show.StartTime.UTC().Format(icsTimeFormat))
+	ics.line("DTEND", details.Show.EndTime.UTC().Format(icsTimeFormat))
+	ics.line("SUMMARY", icsEscape(listingTitle(details)))
+	ics.line("LOCATION", icsEscape(fmt.Sprintf("%s, %s, %s", details.Theatre.Name, details.Theatre.Address, details.Theatre.City)))
+	ics.line("DESCRIPTION", icsEscape(strings.Join(description, "\n")))
+	ics.line("STATUS", "CONFIRMED")
+	ics.line("END", "VEVENT")
+	ics.line("END", "VCALENDAR")
+
+	return &Attachment{
+		Filename:    "show-" + strings.ToLower(details.Booking.Reference) + ".ics",
+		ContentType: "text/calendar; charset=utf-8; method=PUBLISH",
+		Content:     []byte(ics.String()),
+	}, nil
+}
+
+// icsWriter writes content lines the way RFC 5545 wants them: CRLF-terminated and folded at 75 octets
+type icsWriter struct {
+	strings.Builder
+}
+
+func (w *icsWriter) line(name, value string) {
+	line, limit := name+":"+value, 75
+	for len(line) > limit {
+		// Fold on a rune boundary so multi-byte characters stay whole; the leading space of a continuation
+		// line counts towards its 75
+		cut := limit
+		for !isRuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line, limit = line[cut:], 74
+	}
+	w.WriteString(line + "\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// icsEscape escapes a TEXT value's backslashes, commas, semicolons and line breaks
+func icsEscape(text string) string {
+	return icsEscaper.Replace(text)
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// printQRCodeSize is large enough for gate scanners to read a printout
+const printQRCodeSize = 320
+
+// HTMLTicketRenderer implements TicketRenderer - renders a booking as a self-contained, printable HTML page.
+// The QR code is embedded as a data URI, so the page works as an email attachment and offline.
+type HTMLTicketRenderer struct {
+	bookingService BookingService
+	ticketService  TicketService
+	userRepo       repositories.UserRepository
+}
+
+// NewTicketRenderer creates a renderer that builds tickets from booking details
+func NewTicketRenderer(bookingService BookingService, ticketService TicketService, userRepo repositories.UserRepository) TicketRenderer {
+	return &HTMLTicketRenderer{
+		bookingService: bookingService,
+		ticketService:  ticketService,
+		userRepo:       userRepo,
+	}
+}
+
+// RenderTicket renders the ticket and invoice for a confirmed booking, issuing the e-ticket if it doesn't exist yet
+func (tr *HTMLTicketRenderer) RenderTicket(ctx context.Context, bookingID string) (*Attachment, error) {
+	details, err := tr.bookingService.GetBookingDetails(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if details.Booking.GetStatus() != models.BookingStatusConfirmed {
+		return nil, models.ErrBookingNotConfirmed
+	}
+
+	// Idempotent, so rendering before the ticket subscriber has run is fine
+	ticket, err := tr.ticketService.IssueTicket(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := tr.ticketService.RenderQRCode(ctx, ticket.ID, printQRCodeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	page := printableTicket{
+		Reference: details.Booking.Reference,
+		Title:     listingTitle(details),
+		Format:    details.Show.Format,
+		Language:  details.Show.Language,
+		StartTime: details.Show.StartTime.Format("Mon, 02 Jan 2006 · 15:04"),
+		Theatre:   details.Theatre,
+		Screen:    details.Screen.Name,
+		LineItems: details.LineItems,
+		Total:     details.PriceBreakdown.Total,
+		Payment:   details.Payment,
+		TicketID:  ticket.ID,
+		QRCode:    template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(qr)),
+		IssuedAt:  ticket.IssuedAt.Format(time.RFC1123),
+		Attendee:  details.Booking.UserID,
+	}
+	for _, seat := range details.Seats {
+		page.Seats = append(page.Seats, seat.GetSeatNumber())
+	}
+	if user, err := tr.userRepo.GetByID(ctx, details.Booking.UserID); err == nil {
+		page.Attendee = user.Name
+	}
+
+	var body bytes.Buffer
+	if err := ticketTemplate.Execute(&body, page); err != nil {
+		return nil, err
+	}
+
+	return &Attachment{
+		Filename:    "ticket-" + strings.ToLower(details.Booking.Reference) + ".html",
+		ContentType: "text/html; charset=utf-8",
+		Content:     body.Bytes(),
+	}, nil
+}
+
+// listingTitle names what the booking is for - a movie or a live event
+func listingTitle(details *BookingDetails) string {
+	if details.Movie != nil {
+		return details.Movie.Title
+	}
+	if details.Event != nil {
+		return details.Event.Title
+	}
+	return ""
+}
+
+// printableTicket is everything the template shows
+type printableTicket struct {
+	Reference string
+	Title     string
+	Format    models.ShowFormat
+	Language  models.Language
+	StartTime string
+	Theatre   *models.Theatre
+	Screen    string
+	Seats     []string
+	LineItems []LineItem
+	Total     models.Money
+	Payment   *models.Payment
+	TicketID  string
+	QRCode    template.URL
+	IssuedAt  string
+	Attendee  string
+}
+
+var ticketTemplate = template.Must(template.New("ticket").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Ticket {{.Reference}} - {{.Title}}</title>
+<style>
+  body { font-family: Helvetica, Arial, sans-serif; color: #222; max-width: 720px; margin: 24px auto; }
+  .ticket { border: 2px dashed #c4242b; border-radius: 12px; padding: 24px; display: flex; gap: 24px; }
+  .details { flex: 1; }
+  h1 { margin: 0 0 4px; font-size: 24px; }
+  .muted { color: #666; font-size: 13px; }
+  .reference { font-size: 20px; font-weight: bold; letter-spacing: 2px; color: #c4242b; }
+  .qr img { width: 200px; height: 200px; }
+  table { width: 100%; border-collapse: collapse; margin-top: 24px; }
+  td { padding: 6px 0; border-bottom: 1px solid #eee; }
+  td.amount { text-align: right; }
+  tr.total td { font-weight: bold; border-bottom: none; border-top: 2px solid #222; }
+  @media print { body { margin: 0; } .ticket { break-inside: avoid; } }
+</style>
+</head>
+<body>
+<div class="ticket">
+  <div class="details">
+    <div class="reference">{{.Reference}}</div>
+    <h1>{{.Title}}</h1>
+    <div class="muted">{{if .Format}}{{.Format}} · {{end}}{{.Language}}</div>
+    <p><strong>{{.StartTime}}</strong></p>
+    <p>{{.Theatre.Name}}, {{.Screen}}<br><span class="muted">{{.Theatre.Address}}, {{.Theatre.City}}</span></p>
+    <p>Seats: <strong>{{range $i, $seat := .Seats}}{{if $i}}, {{end}}{{$seat}}{{end}}</strong></p>
+    <p class="muted">Issued to {{.Attendee}}</p>
+  </div>
+  <div class="qr">
+    <img src="{{.QRCode}}" alt="Entry QR code">
+    <div class="muted">Ticket {{.TicketID}}</div>
+  </div>
+</div>
+
+<table>
+  {{range .LineItems}}<tr><td>{{.Description}}</td><td class="amount">{{.Amount}}</td></tr>
+  {{end}}<tr class="total"><td>Total paid</td><td class="amount">{{.Total}}</td></tr>
+</table>
+{{with .Payment}}<p class="muted">Paid by {{.Method}}{{if .TransactionID}} · transaction {{.TransactionID}}{{end}}</p>{{end}}
+<p class="muted">Issued {{.IssuedAt}}. Show the QR code at the gate; it is valid once.</p>
+</body>
+</html>
+`))
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// DefaultQRCodeSize is the PNG edge length in pixels when the caller doesn't pick one
+const DefaultQRCodeSize = 256
+
+// TicketServiceImpl implements TicketService - issues signed e-tickets for confirmed bookings
+type TicketServiceImpl struct {
+	ticketRepo     repositories.TicketRepository
+	bookingRepo    repositories.BookingRepository
+	showRepo       repositories.ShowRepository
+	bookingService BookingService // Show, theatre and seat details for calendar events
+	signer         *ticketSigner
+	clock          clock.Clock
+}
+
+// NewTicketService creates a ticket service that signs payloads with signingKey
+func NewTicketService(
+	ticketRepo repositories.TicketRepository,
+	bookingRepo repositories.BookingRepository,
+	showRepo repositories.ShowRepository,
+	bookingService BookingService,
+	signingKey []byte,
+	clock clock.Clock,
+) TicketService {
+	return &TicketServiceImpl{
+		ticketRepo:     ticketRepo,
+		bookingRepo:    bookingRepo,
+		showRepo:       showRepo,
+		bookingService: bookingService,
+		signer:         newTicketSigner(signingKey),
+		clock:          clock,
+	}
+}
+
+// IssueTicket creates the e-ticket for a confirmed booking; issuing twice returns the same ticket
+func (ts *TicketServiceImpl) IssueTicket(ctx context.Context, bookingID string) (*models.Ticket, error) {
+	if existing, err := ts.ticketRepo.GetByBookingID(ctx, bookingID); err == nil {
+		return existing, nil
+	} else if !errors.Is(err, models.ErrTicketNotFound) {
+		return nil, err
+	}
+
+	booking, err := ts.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.GetStatus() != models.BookingStatusConfirmed {
+		return nil, models.ErrBookingNotConfirmed
+	}
+
+	show, err := ts.showRepo.GetByID(ctx, booking.ShowID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := models.NewTicket(booking.ID, booking.UserID, show.ID, show.TheatreID, ts.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket.Payload, err = ts.sign(ticket); err != nil {
+		return nil, err
+	}
+
+	if err := ts.ticketRepo.Create(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// ReissueTicket signs a booking's unused ticket again for the booking's current owner, after a transfer.
+// The old QR code stops working; a booking that had no ticket yet gets its first one.
+func (ts *TicketServiceImpl) ReissueTicket(ctx context.Context, bookingID string) (*models.Ticket, error) {
+	ticket, err := ts.ticketRepo.GetByBookingID(ctx, bookingID)
+	if errors.Is(err, models.ErrTicketNotFound) {
+		return ts.IssueTicket(ctx, bookingID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := ts.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ticket.Reissue(booking.UserID, ts.clock.Now()); err != nil {
+		return nil, err
+	}
+	if ticket.Payload, err = ts.sign(ticket); err != nil {
+		return nil, err
+	}
+	if err := ts.ticketRepo.Update(ctx, ticket); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// sign produces the ticket's QR payload; the reissue count tells payloads signed within the same second apart
+func (ts *TicketServiceImpl) sign(ticket *models.Ticket) (string, error) {
+	return ts.signer.sign(ticketClaims{
+		TicketID:  ticket.ID,
+		BookingID: ticket.BookingID,
+		ShowID:    ticket.ShowID,
+		TheatreID: ticket.TheatreID,
+		IssuedAt:  ticket.IssuedAt.Unix(),
+		Reissue:   ticket.Reissues,
+	})
+}
+
+// GetTicket retrieves a ticket by ID
+func (ts *TicketServiceImpl) GetTicket(ctx context.Context, ticketID string) (*models.Ticket, error) {
+	return ts.ticketRepo.GetByID(ctx, ticketID)
+}
+
+// GetTicketForBooking retrieves the ticket issued for a booking
+func (ts *TicketServiceImpl) GetTicketForBooking(ctx context.Context, bookingID string) (*models.Ticket, error) {
+	return ts.ticketRepo.GetByBookingID(ctx, bookingID)
+}
+
+// RenderQRCode encodes the ticket's signed payload as a PNG QR code
+func (ts *TicketServiceImpl) RenderQRCode(ctx context.Context, ticketID string, size int) ([]byte, error) {
+	ticket, err := ts.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if size <= 0 {
+		size = DefaultQRCodeSize
+	}
+	return qrcode.Encode(ticket.Payload, qrcode.Medium, size)
+}
+
+// ExportQRCode returns a booking's entry QR code as a PNG attachment, for messages that show it inline
+func (ts *TicketServiceImpl) ExportQRCode(ctx context.Context, bookingID string) (*Attachment, error) {
+	ticket, err := ts.ticketRepo.GetByBookingID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	booking, err := ts.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	png, err := qrcode.Encode(ticket.Payload, qrcode.Medium, DefaultQRCodeSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Attachment{
+		Filename:    "qr-" + strings.ToLower(booking.Reference) + ".png",
+		ContentType: "image/png",
+		Content:     png,
+	}, nil
+}
+
+// VoidTicket invalidates a booking's ticket; bookings without a ticket are ignored
+func (ts *TicketServiceImpl) VoidTicket(ctx context.Context, bookingID string) error {
+	ticket, err := ts.ticketRepo.GetByBookingID(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, models.ErrTicketNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if err := ticket.Void(ts.clock.Now()); err != nil {
+		return err
+	}
+	return ts.ticketRepo.Update(ctx, ticket)
+}
+
+// ticketPayloadVersion prefixes every payload so the format can evolve
+const ticketPayloadVersion = "BMS1"
+
+// ticketClaims is what the QR code carries - enough to find the ticket offline-verifiably
+type ticketClaims struct {
+	TicketID  string `json:"tid"`
+	BookingID string `json:"bid"`
+	ShowID    string `json:"sid"`
+	TheatreID string `json:"thid"`
+	IssuedAt  int64  `json:"iat"`
+	Reissue   int    `json:"rev,omitempty"`
+}
+
+// ticketSigner produces and checks "BMS1.<claims>.<hmac>" payloads with HMAC-SHA256
+type ticketSigner struct {
+	key []byte
+}
+
+func newTicketSigner(key []byte) *ticketSigner {
+	return &ticketSigner{key: key}
+}
+
+func (s *ticketSigner) sign(claims ticketClaims) (string, error) {
+	body, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(body)
+	signed := ticketPayloadVersion + "." + encoded
+	return signed + "." + base64.RawURLEncoding.EncodeToString(s.mac(signed)), nil
+}
+
+func (s *ticketSigner) verify(payload string) (*ticketClaims, error) {
+	parts := strings.Split(payload, ".")
+	if len(parts) != 3 || parts[0] != ticketPayloadVersion {
+		return nil, models.ErrInvalidTicket
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, models.ErrInvalidTicket
+	}
+	if !hmac.Equal(signature, s.mac(parts[0]+"."+parts[1])) {
+		return nil, models.ErrInvalidTicket
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, models.ErrInvalidTicket
+	}
+
+	var claims ticketClaims
+	if err := json.Unmarshal(body, &claims); err != nil || claims.TicketID == "" {
+		return nil, models.ErrInvalidTicket
+	}
+	return &claims, nil
+}
+
+func (s *ticketSigner) mac(message string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(message))
+	return h.Sum(nil)
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/events"
+	"context"
+)
+
+// RegisterTicketSubscriber issues tickets for confirmed bookings, signs them again for a new owner when a booking is
+// transferred or resold and voids them on cancellation - demonstrates Observer Pattern
+func RegisterTicketSubscriber(bus events.EventBus, ticketService TicketService) {
+	bus.Subscribe(events.EventBookingConfirmed, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingConfirmed)
+		_, err := ticketService.IssueTicket(ctx, e.BookingID)
+		return err
+	})
+
+	bus.Subscribe(events.EventBookingTransferred, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingTransferred)
+		_, err := ticketService.ReissueTicket(ctx, e.BookingID)
+		return err
+	})
+
+	bus.Subscribe(events.EventBookingResold, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingResold)
+		_, err := ticketService.ReissueTicket(ctx, e.BookingID)
+		return err
+	})
+
+	bus.Subscribe(events.EventBookingCancelled, func(ctx context.Context, event events.Event) error {
+		e := event.(events.BookingCancelled)
+		return ticketService.VoidTicket(ctx, e.BookingID)
+	})
+}
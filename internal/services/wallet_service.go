@@ -0,0 +1,137 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"sync"
+)
+
+// WalletServiceImpl implements WalletService - a stored balance users can top up and pay from
+type WalletServiceImpl struct {
+	walletRepo repositories.WalletRepository
+	userRepo   repositories.UserRepository
+	mutex      sync.Mutex // Serializes opening wallets so a user never gets two
+	clock      clock.Clock
+}
+
+// NewWalletService creates a new wallet service
+func NewWalletService(walletRepo repositories.WalletRepository, userRepo repositories.UserRepository, clock clock.Clock) WalletService {
+	return &WalletServiceImpl{
+		walletRepo: walletRepo,
+		userRepo:   userRepo,
+		clock:      clock,
+	}
+}
+
+// GetWallet returns the user's wallet, opening an empty one on first use
+func (ws *WalletServiceImpl) GetWallet(ctx context.Context, userID string) (*models.Wallet, error) {
+	wallet, err := ws.walletRepo.GetByUserID(ctx, userID)
+	if !errors.Is(err, models.ErrWalletNotFound) {
+		return wallet, err
+	}
+
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+
+	// Another caller may have opened it while we waited
+	if wallet, err := ws.walletRepo.GetByUserID(ctx, userID); !errors.Is(err, models.ErrWalletNotFound) {
+		return wallet, err
+	}
+
+	if _, err := ws.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	wallet, err = models.NewWallet(userID, models.DefaultCurrency, ws.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ws.walletRepo.Create(ctx, wallet); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// TopUp adds money to the user's wallet
+func (ws *WalletServiceImpl) TopUp(ctx context.Context, userID string, amount models.Money) (*models.WalletTransaction, error) {
+	return ws.Credit(ctx, userID, amount, "", "Wallet top-up")
+}
+
+// Debit takes money from the user's wallet, failing with ErrInsufficientWalletBalance rather than overdrawing
+func (ws *WalletServiceImpl) Debit(ctx context.Context, userID string, amount models.Money, reference, description string) (*models.WalletTransaction, error) {
+	wallet, err := ws.GetWallet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := wallet.Debit(amount, reference, description, ws.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	return tx, ws.save(ctx, wallet, tx)
+}
+
+// Credit adds money to the user's wallet, e.g. a refund
+func (ws *WalletServiceImpl) Credit(ctx context.Context, userID string, amount models.Money, reference, description string) (*models.WalletTransaction, error) {
+	wallet, err := ws.GetWallet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := wallet.Credit(amount, reference, description, ws.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	return tx, ws.save(ctx, wallet, tx)
+}
+
+// RefundTransaction credits back all or part of an earlier debit to the same wallet
+func (ws *WalletServiceImpl) RefundTransaction(ctx context.Context, transactionID string, amount models.Money, description string) (*models.WalletTransaction, error) {
+	debit, err := ws.walletRepo.GetTransaction(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if debit.Type != models.WalletTransactionDebit || amount.GreaterThan(debit.Amount) {
+		return nil, models.ErrInvalidRefundAmount
+	}
+
+	return ws.Credit(ctx, debit.UserID, amount, debit.ID, description)
+}
+
+// GetStatement returns the wallet with a page of its transactions, newest first
+func (ws *WalletServiceImpl) GetStatement(ctx context.Context, userID string, offset, limit int) (*WalletStatement, error) {
+	if offset < 0 || limit < 0 {
+		return nil, models.ErrInvalidWalletData
+	}
+
+	wallet, err := ws.GetWallet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, total, err := ws.walletRepo.GetTransactions(ctx, wallet.ID, repositories.Page{Offset: offset, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	return &WalletStatement{
+		Wallet:       wallet,
+		Transactions: transactions,
+		Total:        total,
+		Offset:       offset,
+		Limit:        limit,
+	}, nil
+}
+
+// save persists a balance change and its ledger entry
+func (ws *WalletServiceImpl) save(ctx context.Context, wallet *models.Wallet, tx *models.WalletTransaction) error {
+	if err := ws.walletRepo.Update(ctx, wallet); err != nil {
+		return err
+	}
+	return ws.walletRepo.AddTransaction(ctx, tx)
+}
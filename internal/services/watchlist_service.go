@@ -0,0 +1,192 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"errors"
+	"strings"
+)
+
+// WatchlistServiceImpl implements WatchlistService - demonstrates Observer Pattern: the reminder worker
+// watches the show schedule on the users' behalf
+type WatchlistServiceImpl struct {
+	watchlistRepo repositories.WatchlistRepository
+	userRepo      repositories.UserRepository
+	movieRepo     repositories.MovieRepository
+	showRepo      repositories.ShowRepository
+	theatreRepo   repositories.TheatreRepository
+	notifications NotificationService
+	clock         clock.Clock
+}
+
+func NewWatchlistService(watchlistRepo repositories.WatchlistRepository, userRepo repositories.UserRepository, movieRepo repositories.MovieRepository, showRepo repositories.ShowRepository, theatreRepo repositories.TheatreRepository, notifications NotificationService, clock clock.Clock) WatchlistService {
+	return &WatchlistServiceImpl{
+		watchlistRepo: watchlistRepo,
+		userRepo:      userRepo,
+		movieRepo:     movieRepo,
+		showRepo:      showRepo,
+		theatreRepo:   theatreRepo,
+		notifications: notifications,
+		clock:         clock,
+	}
+}
+
+// AddToWatchlist puts a movie, released or not, on the user's watchlist
+func (ws *WatchlistServiceImpl) AddToWatchlist(ctx context.Context, userID, movieID string) (*models.WatchlistEntry, error) {
+	if _, err := ws.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	if _, err := ws.movieRepo.GetByID(ctx, movieID); err != nil {
+		return nil, err
+	}
+
+	if existing, err := ws.watchlistRepo.GetByUserAndMovie(ctx, userID, movieID); err == nil {
+		return existing, nil
+	} else if !errors.Is(err, models.ErrWatchlistEntryNotFound) {
+		return nil, err
+	}
+
+	entries, err := ws.watchlistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) >= models.MaxWatchlistSize {
+		return nil, models.ErrWatchlistFull
+	}
+
+	entry, err := models.NewWatchlistEntry(userID, movieID, ws.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := ws.watchlistRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (ws *WatchlistServiceImpl) RemoveFromWatchlist(ctx context.Context, userID, movieID string) error {
+	entry, err := ws.watchlistRepo.GetByUserAndMovie(ctx, userID, movieID)
+	if err != nil {
+		return err
+	}
+	return ws.watchlistRepo.Delete(ctx, entry.ID)
+}
+
+func (ws *WatchlistServiceImpl) GetWatchlist(ctx context.Context, userID string) ([]*WatchlistItem, error) {
+	if _, err := ws.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	entries, err := ws.watchlistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*WatchlistItem, 0, len(entries))
+	for _, entry := range entries {
+		movie, err := ws.movieRepo.GetByID(ctx, entry.MovieID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &WatchlistItem{WatchlistEntry: entry, Movie: movie})
+	}
+	return items, nil
+}
+
+// SendReminders tells users whose watchlisted movie now has a bookable show in their home city, pointing them
+// to the earliest one; shows with a sale window count once bookings for them open. Each entry is reminded
+// once; users without a home city wait until they set one.
+// A failed reminder is retried on the next run.
+func (ws *WatchlistServiceImpl) SendReminders(ctx context.Context) (int, error) {
+	entries, err := ws.watchlistRepo.GetUnreminded(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	scan := reminderScan{ws: ws, showings: make(map[string]map[string]*models.Show), cities: make(map[string]string)}
+	sent := 0
+	var errs []error
+	for _, entry := range entries {
+		user, err := ws.userRepo.GetByID(ctx, entry.UserID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		city := user.Preferences.HomeCity
+		if city == "" {
+			continue
+		}
+
+		show, err := scan.firstShow(ctx, entry.MovieID, city)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if show == nil {
+			continue
+		}
+		movie, err := ws.movieRepo.GetByID(ctx, entry.MovieID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if err := ws.notifications.SendWatchlistReminder(ctx, user.ID, movie.ID, movie.Title, city, show.StartTime); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		entry.MarkReminded(city, show.ID, ws.clock.Now())
+		if err := ws.watchlistRepo.Update(ctx, entry); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent++
+	}
+	return sent, errors.Join(errs...)
+}
+
+// reminderScan looks up each movie's shows and each theatre's city once per SendReminders run
+type reminderScan struct {
+	ws       *WatchlistServiceImpl
+	showings map[string]map[string]*models.Show // Movie ID -> lower-case city -> earliest bookable show
+	cities   map[string]string                  // Theatre ID -> lower-case city
+}
+
+func (s *reminderScan) firstShow(ctx context.Context, movieID, city string) (*models.Show, error) {
+	byCity, ok := s.showings[movieID]
+	if !ok {
+		shows, err := s.ws.showRepo.GetByMovieID(ctx, movieID)
+		if err != nil {
+			return nil, err
+		}
+
+		byCity = make(map[string]*models.Show)
+		for _, show := range shows {
+			if !show.CanBeBooked(s.ws.clock.Now()) {
+				continue
+			}
+			theatreCity, err := s.theatreCity(ctx, show.TheatreID)
+			if err != nil {
+				return nil, err
+			}
+			if earliest := byCity[theatreCity]; earliest == nil || show.StartTime.Before(earliest.StartTime) {
+				byCity[theatreCity] = show
+			}
+		}
+		s.showings[movieID] = byCity
+	}
+	return byCity[strings.ToLower(city)], nil
+}
+
+func (s *reminderScan) theatreCity(ctx context.Context, theatreID string) (string, error) {
+	if city, ok := s.cities[theatreID]; ok {
+		return city, nil
+	}
+	theatre, err := s.ws.theatreRepo.GetByID(ctx, theatreID)
+	if err != nil {
+		return "", err
+	}
+	s.cities[theatreID] = strings.ToLower(theatre.City)
+	return s.cities[theatreID], nil
+}
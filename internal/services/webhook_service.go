@@ -0,0 +1,403 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/events"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// WebhookEventTypes are the events partners can subscribe to
+var WebhookEventTypes = []events.EventType{
+	events.EventBookingConfirmed,
+	events.EventBookingCancelled,
+	events.EventShowSoldOut,
+	events.EventDealCreated,
+	events.EventShowCompleted,
+}
+
+// Headers sent with every webhook POST
+const (
+	WebhookEventHeader     = "X-BMS-Event"
+	WebhookDeliveryHeader  = "X-BMS-Delivery"  // Same on every retry, so partners can drop duplicates
+	WebhookSignatureHeader = "X-BMS-Signature" // "t=<unix>,v1=<hex HMAC-SHA256 of "<unix>.<body>">"
+)
+
+// webhookPayload is the JSON body partners receive
+type webhookPayload struct {
+	ID         string           `json:"id"` // The delivery ID
+	Type       events.EventType `json:"type"`
+	TheatreID  string           `json:"theatre_id"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       json.RawMessage  `json:"data"` // The event as published on the bus
+}
+
+// WebhookServiceImpl implements WebhookService - demonstrates Observer Pattern across process boundaries:
+// partners subscribe to domain events like in-process handlers do, but over HTTP. Every delivery is stored
+// before it is sent, so a partner that is down gets the event once it is back, with the same ID.
+type WebhookServiceImpl struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+	theatreRepo  repositories.TheatreRepository
+	showRepo     repositories.ShowRepository
+	authorizer   Authorizer
+	client       WebhookClient
+	config       models.WebhookConfig
+	logger       logging.Logger
+	clock        clock.Clock
+	inFlight     map[string]bool // Deliveries being sent, so the first attempt and the dispatcher never send one twice at once
+	mutex        sync.Mutex
+}
+
+// NewWebhookService creates the service; a nil client uses http.DefaultClient and zero config fields fall back
+// to models.DefaultWebhookConfig
+func NewWebhookService(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	theatreRepo repositories.TheatreRepository,
+	showRepo repositories.ShowRepository,
+	authorizer Authorizer,
+	client WebhookClient,
+	config models.WebhookConfig,
+	logger logging.Logger,
+	clk clock.Clock,
+) WebhookService {
+	defaults := models.DefaultWebhookConfig()
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaults.RetryBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaults.MaxBackoff
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &WebhookServiceImpl{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		theatreRepo:  theatreRepo,
+		showRepo:     showRepo,
+		authorizer:   authorizer,
+		client:       client,
+		config:       config,
+		logger:       logger,
+		clock:        clk,
+		inFlight:     make(map[string]bool),
+	}
+}
+
+// RegisterWebhook adds a callback URL for the theatre's events
+func (ws *WebhookServiceImpl) RegisterWebhook(ctx context.Context, theatreID, url string, eventTypes []events.EventType) (*models.Webhook, error) {
+	caller, err := ws.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, theatreID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ws.theatreRepo.GetByID(ctx, theatreID); err != nil {
+		return nil, err
+	}
+
+	if len(eventTypes) == 0 {
+		eventTypes = WebhookEventTypes
+	}
+	var types []string
+	for _, eventType := range eventTypes {
+		if !slices.Contains(WebhookEventTypes, eventType) {
+			return nil, fmt.Errorf("%w: %s is not a webhook event", models.ErrInvalidWebhook, eventType)
+		}
+		if !slices.Contains(types, string(eventType)) {
+			types = append(types, string(eventType))
+		}
+	}
+
+	webhook, err := models.NewWebhook(theatreID, url, types, ws.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	webhook.CreatedBy = caller.ID
+
+	if err := ws.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// GetWebhooks lists the theatre's webhooks, oldest first, without their secrets
+func (ws *WebhookServiceImpl) GetWebhooks(ctx context.Context, theatreID string) ([]*models.Webhook, error) {
+	if _, err := ws.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, theatreID); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := ws.webhookRepo.GetByTheatreID(ctx, theatreID)
+	if err != nil {
+		return nil, err
+	}
+	redacted := make([]*models.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		redacted[i] = webhook.Redacted()
+	}
+	return redacted, nil
+}
+
+// DeleteWebhook stops deliveries to the webhook; ones still pending are given up on
+func (ws *WebhookServiceImpl) DeleteWebhook(ctx context.Context, webhookID string) error {
+	webhook, err := ws.authorizedWebhook(ctx, webhookID)
+	if err != nil {
+		return err
+	}
+	return ws.webhookRepo.Delete(ctx, webhook.ID)
+}
+
+// GetDeliveries returns every delivery to the webhook with the outcome of its latest attempt
+func (ws *WebhookServiceImpl) GetDeliveries(ctx context.Context, webhookID string) ([]*models.WebhookDelivery, error) {
+	webhook, err := ws.authorizedWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return ws.deliveryRepo.GetByWebhookID(ctx, webhook.ID)
+}
+
+// Enqueue stores a delivery for every webhook of the event's theatre that wants it, then sends them in the
+// background. Queuing the same event again - e.g. when the outbox retries its subscribers - is a no-op.
+func (ws *WebhookServiceImpl) Enqueue(ctx context.Context, event events.Event) error {
+	if !slices.Contains(WebhookEventTypes, event.Type()) {
+		return nil
+	}
+	theatreID, err := ws.theatreOf(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := ws.webhookRepo.GetByTheatreID(ctx, theatreID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, webhook := range webhooks {
+		if !webhook.Wants(string(event.Type())) {
+			continue
+		}
+		delivery, err := ws.queue(ctx, webhook, event, theatreID, data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if delivery != nil {
+			go ws.send(context.WithoutCancel(ctx), webhook, delivery)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DispatchPending sends deliveries whose retry is due, returning how many the partners accepted
+func (ws *WebhookServiceImpl) DispatchPending(ctx context.Context) (int, error) {
+	due, err := ws.deliveryRepo.GetDue(ctx, ws.clock.Now(), ws.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range due {
+		if err := ctx.Err(); err != nil {
+			return delivered, err
+		}
+
+		webhook, err := ws.webhookRepo.GetByID(ctx, delivery.WebhookID)
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			// Deleted since the event was queued; there is nobody left to send it to
+			now := ws.clock.Now()
+			delivery.MarkFailed(0, err.Error(), now, 0, now)
+			ws.save(ctx, delivery)
+			continue
+		}
+		if err != nil {
+			return delivered, err
+		}
+		if ws.send(ctx, webhook, delivery) {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// authorizedWebhook loads a webhook the caller may manage
+func (ws *WebhookServiceImpl) authorizedWebhook(ctx context.Context, webhookID string) (*models.Webhook, error) {
+	webhook, err := ws.webhookRepo.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ws.authorizer.AuthorizeCaller(ctx, models.PermissionManageTheatre, webhook.TheatreID); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// theatreOf finds the theatre whose partners hear about the event
+func (ws *WebhookServiceImpl) theatreOf(ctx context.Context, event events.Event) (string, error) {
+	var showID string
+	switch e := event.(type) {
+	case events.ShowSoldOut:
+		return e.TheatreID, nil
+	case events.DealCreated:
+		return e.TheatreID, nil
+	case events.ShowCompleted:
+		return e.TheatreID, nil
+	case events.BookingConfirmed:
+		showID = e.ShowID
+	case events.BookingCancelled:
+		showID = e.ShowID
+	default:
+		return "", fmt.Errorf("no theatre for %s events", event.Type())
+	}
+
+	show, err := ws.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		return "", err
+	}
+	return show.TheatreID, nil
+}
+
+// queue stores the delivery of one event to one webhook; it returns nil if that delivery already exists.
+// The ID is derived from the webhook and the event, which carries its own timestamp, so it is stable.
+func (ws *WebhookServiceImpl) queue(ctx context.Context, webhook *models.Webhook, event events.Event, theatreID string, data []byte) (*models.WebhookDelivery, error) {
+	sum := sha256.Sum256(append([]byte(webhook.ID+"|"+string(event.Type())+"|"), data...))
+	id := "whd_" + hex.EncodeToString(sum[:16])
+
+	if _, err := ws.deliveryRepo.GetByID(ctx, id); err == nil {
+		return nil, nil
+	} else if !errors.Is(err, models.ErrWebhookDeliveryNotFound) {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(webhookPayload{
+		ID:         id,
+		Type:       event.Type(),
+		TheatreID:  theatreID,
+		OccurredAt: event.OccurredAt(),
+		Data:       data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := models.NewWebhookDelivery(id, webhook.ID, string(event.Type()), payload, ws.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := ws.deliveryRepo.Create(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// send makes one attempt at a due delivery and records the outcome; it reports whether the partner accepted it
+func (ws *WebhookServiceImpl) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) bool {
+	if !ws.claim(delivery.ID) {
+		return false
+	}
+	defer ws.release(delivery.ID)
+
+	// The other path may have sent it between being picked up and being claimed
+	if !delivery.IsDue(ws.clock.Now()) {
+		return false
+	}
+
+	statusCode, err := ws.post(ctx, webhook, delivery)
+	if err != nil {
+		retryAt := ws.clock.Now().Add(ws.config.Backoff(delivery.Attempts + 1))
+		if delivery.MarkFailed(statusCode, err.Error(), retryAt, ws.config.MaxAttempts, ws.clock.Now()) {
+			ws.logger.Error(ctx, "webhook delivery failed for good",
+				"delivery_id", delivery.ID, "webhook_id", webhook.ID, "event", delivery.EventType, "attempts", delivery.Attempts, "error", err)
+		} else {
+			ws.logger.Warn(ctx, "webhook delivery failed, will retry",
+				"delivery_id", delivery.ID, "webhook_id", webhook.ID, "event", delivery.EventType, "retry_at", retryAt, "error", err)
+		}
+		ws.save(ctx, delivery)
+		return false
+	}
+
+	delivery.MarkDelivered(statusCode, ws.clock.Now())
+	ws.save(ctx, delivery)
+	return true
+}
+
+// post sends the signed payload, treating anything but a 2xx answer as a failure
+func (ws *WebhookServiceImpl) post(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, ws.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID)
+	req.Header.Set(WebhookSignatureHeader, webhook.Sign(ws.clock.Now(), delivery.Payload))
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("partner answered %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// save records a delivery attempt; a failed write only costs a duplicate send later
+func (ws *WebhookServiceImpl) save(ctx context.Context, delivery *models.WebhookDelivery) {
+	if err := ws.deliveryRepo.Update(ctx, delivery); err != nil {
+		ws.logger.Warn(ctx, "failed to record webhook delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// claim marks a delivery as being sent; false if someone else already is
+func (ws *WebhookServiceImpl) claim(deliveryID string) bool {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+
+	if ws.inFlight[deliveryID] {
+		return false
+	}
+	ws.inFlight[deliveryID] = true
+	return true
+}
+
+// release ends a send started by claim
+func (ws *WebhookServiceImpl) release(deliveryID string) {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+
+	delete(ws.inFlight, deliveryID)
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/events"
+	"context"
+)
+
+// RegisterWebhookSubscriber queues every event partners can subscribe to for delivery - demonstrates Observer Pattern
+func RegisterWebhookSubscriber(bus events.EventBus, webhookSvc WebhookService) {
+	for _, eventType := range WebhookEventTypes {
+		bus.Subscribe(eventType, func(ctx context.Context, event events.Event) error {
+			return webhookSvc.Enqueue(ctx, event)
+		})
+	}
+}
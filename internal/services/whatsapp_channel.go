@@ -0,0 +1,132 @@
+package services
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// WhatsAppTemplates holds the template each kind of notification is sent with on WhatsApp. Admins manage it at
+// runtime; the WhatsApp channel reads it on every delivery, so a changed template applies from the next message.
+type WhatsAppTemplates struct {
+	templates map[models.NotificationKind]models.WhatsAppTemplate
+	clock     clock.Clock // Stamps each template when it is set
+	mutex     sync.RWMutex
+}
+
+// NewWhatsAppTemplates creates a template set holding templates; invalid ones are skipped
+func NewWhatsAppTemplates(clock clock.Clock, templates ...models.WhatsAppTemplate) *WhatsAppTemplates {
+	set := &WhatsAppTemplates{templates: make(map[models.NotificationKind]models.WhatsAppTemplate), clock: clock}
+	for _, template := range templates {
+		set.Set(template)
+	}
+	return set
+}
+
+// Get returns the template for a kind of notification
+func (t *WhatsAppTemplates) Get(kind models.NotificationKind) (models.WhatsAppTemplate, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	template, ok := t.templates[kind]
+	return template, ok
+}
+
+// List returns every template, ordered by kind
+func (t *WhatsAppTemplates) List() []models.WhatsAppTemplate {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	templates := make([]models.WhatsAppTemplate, 0, len(t.templates))
+	for _, template := range t.templates {
+		templates = append(templates, template)
+	}
+	slices.SortFunc(templates, func(a, b models.WhatsAppTemplate) int { return strings.Compare(string(a.Kind), string(b.Kind)) })
+	return templates
+}
+
+// Set adds a template, replacing the one its kind had
+func (t *WhatsAppTemplates) Set(template models.WhatsAppTemplate) (models.WhatsAppTemplate, error) {
+	template.Kind = models.NotificationKind(strings.ToUpper(strings.TrimSpace(string(template.Kind))))
+	template.Name = strings.TrimSpace(template.Name)
+	template.Language = strings.TrimSpace(template.Language)
+	template.Header = models.WhatsAppHeader(strings.ToUpper(strings.TrimSpace(string(template.Header))))
+	if err := template.Validate(); err != nil {
+		return models.WhatsAppTemplate{}, err
+	}
+	template.UpdatedAt = t.clock.Now()
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.templates[template.Kind] = template
+	return template, nil
+}
+
+// Remove drops a kind's template, so that kind is no longer sent on WhatsApp; false if it had none
+func (t *WhatsAppTemplates) Remove(kind models.NotificationKind) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.templates[kind]; !ok {
+		return false
+	}
+	delete(t.templates, kind)
+	return true
+}
+
+// WhatsAppChannel sends notifications as WhatsApp template messages. Like the other channels it records what
+// it would send; a real deployment would call the WhatsApp Business API here. Users who haven't opted in and
+// kinds without a template are skipped rather than failed.
+type WhatsAppChannel struct {
+	templates *WhatsAppTemplates
+	logger    logging.Logger
+}
+
+// NewWhatsAppChannel sends to users' phone numbers with the given templates
+func NewWhatsAppChannel(templates *WhatsAppTemplates, logger logging.Logger) *WhatsAppChannel {
+	return &WhatsAppChannel{templates: templates, logger: logger}
+}
+
+func (c *WhatsAppChannel) Channel() models.NotificationChannel {
+	return models.NotificationChannelWhatsApp
+}
+
+func (c *WhatsAppChannel) Deliver(ctx context.Context, user *models.User, notification *models.Notification) error {
+	if !user.IsWhatsAppOptedIn() {
+		return fmt.Errorf("%w: user %s has not opted in to WhatsApp", models.ErrNotificationSkipped, user.ID)
+	}
+	template, ok := c.templates.Get(notification.Kind)
+	if !ok {
+		return fmt.Errorf("%w: no WhatsApp template for %s", models.ErrNotificationSkipped, notification.Kind)
+	}
+	if user.PhoneNumber == "" {
+		return fmt.Errorf("%w: user %s has no phone number", models.ErrNotificationUndeliverable, user.ID)
+	}
+
+	text, err := template.Render(notification.Fields)
+	if err != nil {
+		return err
+	}
+
+	args := []any{"channel", models.NotificationChannelWhatsApp, "to", user.PhoneNumber, "user_id", user.ID,
+		"template", template.Name, "language", template.Language, "text", text}
+	if template.Header == models.WhatsAppHeaderQRCode {
+		// The QR code comes with the confirmation; without it the approved template can't be sent
+		i := slices.IndexFunc(notification.Attachments, func(attachment models.NotificationAttachment) bool {
+			return attachment.ContentType == "image/png"
+		})
+		if i < 0 {
+			return fmt.Errorf("%w: template %s needs the entry QR code", models.ErrNotificationUndeliverable, template.Name)
+		}
+		qrCode := notification.Attachments[i]
+		args = append(args, "header", fmt.Sprintf("%s (%d bytes)", qrCode.Filename, len(qrCode.Content)))
+	}
+	c.logger.Info(ctx, "💬 NOTIFICATION: "+notification.Subject, args...)
+	return nil
+}
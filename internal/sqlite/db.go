@@ -0,0 +1,140 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	_ "modernc.org/sqlite" // Pure-Go driver, so the binary still builds without cgo
+)
+
+// DefaultPath is where main keeps the database when -store=sqlite is given without -db
+const DefaultPath = "bookmyshow.db"
+
+// schemaVersion is recorded in PRAGMA user_version; bump it when tables are added.
+// Older files are brought up to date by creating whichever tables they are missing.
+const schemaVersion = 10
+
+// tables holds one JSON document per entity, keyed by its ID
+var tables = []string{
+	"users",
+	"movies",
+	"events",
+	"theatres",
+	"cities",
+	"screens",
+	"shows",
+	"bookings",
+	"payments",
+	"refunds",
+	"coupons",
+	"seat_holds",
+	"tickets",
+	"reviews",
+	"wallets",
+	"wallet_transactions",
+	"loyalty_accounts",
+	"outbox_messages",
+	"notifications",
+	"credentials",
+	"sessions",
+	"settlements",
+	"audit_entries",
+	"webhooks",
+	"webhook_deliveries",
+	"watchlist_entries",
+	"bulk_bookings",
+	"parking_reservations",
+	"resale_listings",
+	"payment_instruments",
+	"secrets",
+}
+
+// Config selects the database file; an empty Path keeps everything in memory
+type Config struct {
+	Path string
+}
+
+// ConfigFromEnv reads SQLITE_PATH
+func ConfigFromEnv() Config {
+	return Config{Path: os.Getenv("SQLITE_PATH")}
+}
+
+// Enabled reports whether a database file is configured
+func (c Config) Enabled() bool {
+	return c.Path != ""
+}
+
+// Open opens (or creates) the database file and bootstraps the schema on first run
+func Open(ctx context.Context, config Config) (*sql.DB, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", config.Path)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite %s: %w", config.Path, err)
+	}
+
+	// One connection serializes writes, which SQLite does anyway, and avoids SQLITE_BUSY between our own goroutines
+	db.SetMaxOpenConns(1)
+
+	if err := bootstrap(ctx, db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sqlite %s: %w", config.Path, err)
+	}
+	return db, nil
+}
+
+// bootstrap creates the tables of a new database and refuses one written by a newer schema
+func bootstrap(ctx context.Context, db *sql.DB) error {
+	var version int
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+	if version > schemaVersion {
+		return fmt.Errorf("schema version %d is newer than this build supports (%d)", version, schemaVersion)
+	}
+	if version == schemaVersion {
+		return nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range tables {
+		stmt := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id TEXT PRIMARY KEY, data TEXT NOT NULL)", table)
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("creating %s: %w", table, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", schemaVersion)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Secret returns the named random key, generating and storing it on first use so it outlives the process
+func Secret(ctx context.Context, db *sql.DB, name string, size int) ([]byte, error) {
+	var encoded string
+	err := db.QueryRowContext(ctx, "SELECT data FROM secrets WHERE id = ?", name).Scan(&encoded)
+	if err == nil {
+		return hex.DecodeString(encoded)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("sqlite: loading secret %s: %w", name, err)
+	}
+
+	key := make([]byte, size)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if _, err := db.ExecContext(ctx, "INSERT INTO secrets (id, data) VALUES (?, ?)", name, hex.EncodeToString(key)); err != nil {
+		return nil, fmt.Errorf("sqlite: saving secret %s: %w", name, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,650 @@
+package sqlite
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Store is the full repository set backed by one database file. Each repository decorates its in-memory
+// counterpart - demonstrates Decorator Pattern: reads and queries are served from memory, every write is
+// saved to SQLite, and Restore replays the saved rows into memory on startup.
+type Store struct {
+	Users        repositories.UserRepository
+	Movies       repositories.MovieRepository
+	Events       repositories.EventRepository
+	Theatres     repositories.TheatreRepository
+	Cities       repositories.CityRepository
+	Screens      repositories.ScreenRepository
+	Shows        repositories.ShowRepository
+	Bookings     repositories.BookingRepository
+	Payments     repositories.PaymentRepository
+	Refunds      repositories.RefundRepository
+	Coupons      repositories.CouponRepository
+	SeatHolds    repositories.SeatHoldRepository
+	Tickets      repositories.TicketRepository
+	Reviews      repositories.ReviewRepository
+	Wallets      repositories.WalletRepository
+	Loyalty      repositories.LoyaltyRepository
+	Outbox       repositories.OutboxRepository
+	Notices      repositories.NotificationRepository
+	Credentials  repositories.CredentialRepository
+	Sessions     repositories.SessionRepository
+	Settlements  repositories.SettlementRepository
+	Audit        repositories.AuditRepository
+	Webhooks     repositories.WebhookRepository
+	Deliveries   repositories.WebhookDeliveryRepository
+	Watchlist    repositories.WatchlistRepository
+	BulkBookings repositories.BulkBookingRepository
+	Parking      repositories.ParkingReservationRepository
+	Resale       repositories.ResaleListingRepository
+	Instruments  repositories.PaymentInstrumentRepository
+	Restored     int // Rows loaded from the file; zero on first run
+}
+
+// Restore builds the repository set and loads everything saved by earlier runs
+func Restore(ctx context.Context, db *sql.DB) (*Store, error) {
+	users := &UserRepository{repositories.NewMemoryUserRepository(), table[models.User]{db, "users"}}
+	movies := &MovieRepository{repositories.NewMemoryMovieRepository(), table[models.Movie]{db, "movies"}}
+	events := &EventRepository{repositories.NewMemoryEventRepository(), table[models.Event]{db, "events"}}
+	theatres := &TheatreRepository{repositories.NewMemoryTheatreRepository(), table[models.Theatre]{db, "theatres"}}
+	cities := &CityRepository{repositories.NewMemoryCityRepository(), table[models.City]{db, "cities"}}
+	screens := &ScreenRepository{repositories.NewMemoryScreenRepository(), table[models.Screen]{db, "screens"}}
+	shows := &ShowRepository{repositories.NewMemoryShowRepository(), table[models.Show]{db, "shows"}}
+	bookings := &BookingRepository{repositories.NewMemoryBookingRepository(), table[models.Booking]{db, "bookings"}}
+	payments := &PaymentRepository{repositories.NewMemoryPaymentRepository(), table[models.Payment]{db, "payments"}}
+	refunds := &RefundRepository{repositories.NewMemoryRefundRepository(), table[models.Refund]{db, "refunds"}}
+	coupons := &CouponRepository{repositories.NewMemoryCouponRepository(), table[models.Coupon]{db, "coupons"}}
+	holds := &SeatHoldRepository{repositories.NewMemorySeatHoldRepository(), table[models.SeatHold]{db, "seat_holds"}}
+	tickets := &TicketRepository{repositories.NewMemoryTicketRepository(), table[models.Ticket]{db, "tickets"}}
+	reviews := &ReviewRepository{repositories.NewMemoryReviewRepository(), table[models.Review]{db, "reviews"}}
+	wallets := &WalletRepository{
+		WalletRepository: repositories.NewMemoryWalletRepository(),
+		table:            table[models.Wallet]{db, "wallets"},
+		transactions:     table[models.WalletTransaction]{db, "wallet_transactions"},
+	}
+	loyalty := &LoyaltyRepository{repositories.NewMemoryLoyaltyRepository(), table[loyaltyRecord]{db, "loyalty_accounts"}}
+	outbox := &OutboxRepository{repositories.NewMemoryOutboxRepository(), table[models.OutboxMessage]{db, "outbox_messages"}}
+	notifications := &NotificationRepository{repositories.NewMemoryNotificationRepository(), table[models.Notification]{db, "notifications"}}
+	credentials := &CredentialRepository{repositories.NewMemoryCredentialRepository(), table[models.Credential]{db, "credentials"}}
+	sessions := &SessionRepository{repositories.NewMemorySessionRepository(), table[models.Session]{db, "sessions"}}
+	settlements := &SettlementRepository{repositories.NewMemorySettlementRepository(), table[models.Settlement]{db, "settlements"}}
+	audit := &AuditRepository{repositories.NewMemoryAuditRepository(), table[models.AuditEntry]{db, "audit_entries"}}
+	webhooks := &WebhookRepository{repositories.NewMemoryWebhookRepository(), table[models.Webhook]{db, "webhooks"}}
+	deliveries := &WebhookDeliveryRepository{repositories.NewMemoryWebhookDeliveryRepository(), table[models.WebhookDelivery]{db, "webhook_deliveries"}}
+	watchlist := &WatchlistRepository{repositories.NewMemoryWatchlistRepository(), table[models.WatchlistEntry]{db, "watchlist_entries"}}
+	bulkBookings := &BulkBookingRepository{repositories.NewMemoryBulkBookingRepository(), table[models.BulkBooking]{db, "bulk_bookings"}}
+	parking := &ParkingReservationRepository{repositories.NewMemoryParkingReservationRepository(), table[models.ParkingReservation]{db, "parking_reservations"}}
+	resale := &ResaleListingRepository{repositories.NewMemoryResaleListingRepository(), table[models.ResaleListing]{db, "resale_listings"}}
+	instruments := &PaymentInstrumentRepository{repositories.NewMemoryPaymentInstrumentRepository(), table[models.PaymentInstrument]{db, "payment_instruments"}}
+
+	// Wallets before their transactions; everything else is independent in memory
+	restores := []func() (int, error){
+		func() (int, error) { return users.table.restore(ctx, users.UserRepository.Create) },
+		func() (int, error) { return movies.table.restore(ctx, movies.MovieRepository.Create) },
+		func() (int, error) { return events.table.restore(ctx, events.EventRepository.Create) },
+		func() (int, error) { return theatres.table.restore(ctx, theatres.TheatreRepository.Create) },
+		func() (int, error) { return cities.table.restore(ctx, cities.CityRepository.Create) },
+		func() (int, error) { return screens.table.restore(ctx, screens.ScreenRepository.Create) },
+		func() (int, error) { return shows.table.restore(ctx, shows.ShowRepository.Create) },
+		func() (int, error) { return bookings.table.restore(ctx, bookings.BookingRepository.Create) },
+		func() (int, error) { return payments.table.restore(ctx, payments.PaymentRepository.Create) },
+		func() (int, error) { return refunds.table.restore(ctx, refunds.RefundRepository.Create) },
+		func() (int, error) { return coupons.table.restore(ctx, coupons.CouponRepository.Create) },
+		func() (int, error) { return holds.table.restore(ctx, holds.SeatHoldRepository.Create) },
+		func() (int, error) { return tickets.table.restore(ctx, tickets.TicketRepository.Create) },
+		func() (int, error) { return reviews.table.restore(ctx, reviews.ReviewRepository.Create) },
+		func() (int, error) { return wallets.table.restore(ctx, wallets.WalletRepository.Create) },
+		func() (int, error) { return wallets.transactions.restore(ctx, wallets.WalletRepository.AddTransaction) },
+		func() (int, error) { return loyalty.table.restore(ctx, loyalty.restoreAccount) },
+		func() (int, error) { return outbox.table.restore(ctx, outbox.OutboxRepository.Create) },
+		func() (int, error) {
+			return notifications.table.restore(ctx, notifications.NotificationRepository.Create)
+		},
+		func() (int, error) { return credentials.table.restore(ctx, credentials.CredentialRepository.Save) },
+		func() (int, error) { return sessions.table.restore(ctx, sessions.SessionRepository.Create) },
+		func() (int, error) { return settlements.table.restore(ctx, settlements.SettlementRepository.Create) },
+		func() (int, error) { return audit.table.restore(ctx, audit.AuditRepository.Create) },
+		func() (int, error) { return webhooks.table.restore(ctx, webhooks.WebhookRepository.Create) },
+		func() (int, error) { return deliveries.table.restore(ctx, deliveries.WebhookDeliveryRepository.Create) },
+		func() (int, error) { return watchlist.table.restore(ctx, watchlist.WatchlistRepository.Create) },
+		func() (int, error) { return bulkBookings.table.restore(ctx, bulkBookings.BulkBookingRepository.Create) },
+		func() (int, error) { return parking.table.restore(ctx, parking.ParkingReservationRepository.Create) },
+		func() (int, error) { return resale.table.restore(ctx, resale.ResaleListingRepository.Create) },
+		func() (int, error) {
+			return instruments.table.restore(ctx, instruments.PaymentInstrumentRepository.Create)
+		},
+	}
+
+	store := &Store{
+		Users:        users,
+		Movies:       movies,
+		Events:       events,
+		Theatres:     theatres,
+		Cities:       cities,
+		Screens:      screens,
+		Shows:        shows,
+		Bookings:     bookings,
+		Payments:     payments,
+		Refunds:      refunds,
+		Coupons:      coupons,
+		SeatHolds:    holds,
+		Tickets:      tickets,
+		Reviews:      reviews,
+		Wallets:      wallets,
+		Loyalty:      loyalty,
+		Outbox:       outbox,
+		Notices:      notifications,
+		Credentials:  credentials,
+		Sessions:     sessions,
+		Settlements:  settlements,
+		Audit:        audit,
+		Webhooks:     webhooks,
+		Deliveries:   deliveries,
+		Watchlist:    watchlist,
+		BulkBookings: bulkBookings,
+		Parking:      parking,
+		Resale:       resale,
+		Instruments:  instruments,
+	}
+	for _, restore := range restores {
+		restored, err := restore()
+		if err != nil {
+			return nil, err
+		}
+		store.Restored += restored
+	}
+	return store, nil
+}
+
+// UserRepository saves users on every write
+type UserRepository struct {
+	repositories.UserRepository
+	table table[models.User]
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	return write(ctx, r.table, user.ID, user, r.UserRepository.Create)
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+	return write(ctx, r.table, user.ID, user, r.UserRepository.Update)
+}
+
+// MovieRepository saves movies on every write
+type MovieRepository struct {
+	repositories.MovieRepository
+	table table[models.Movie]
+}
+
+func (r *MovieRepository) Create(ctx context.Context, movie *models.Movie) error {
+	return write(ctx, r.table, movie.ID, movie, r.MovieRepository.Create)
+}
+
+func (r *MovieRepository) Update(ctx context.Context, movie *models.Movie) error {
+	return write(ctx, r.table, movie.ID, movie, r.MovieRepository.Update)
+}
+
+// EventRepository saves live events on every write
+type EventRepository struct {
+	repositories.EventRepository
+	table table[models.Event]
+}
+
+func (r *EventRepository) Create(ctx context.Context, event *models.Event) error {
+	return write(ctx, r.table, event.ID, event, r.EventRepository.Create)
+}
+
+// TheatreRepository saves theatres on every write
+type TheatreRepository struct {
+	repositories.TheatreRepository
+	table table[models.Theatre]
+}
+
+func (r *TheatreRepository) Create(ctx context.Context, theatre *models.Theatre) error {
+	return write(ctx, r.table, theatre.ID, theatre, r.TheatreRepository.Create)
+}
+
+func (r *TheatreRepository) Update(ctx context.Context, theatre *models.Theatre) error {
+	return write(ctx, r.table, theatre.ID, theatre, r.TheatreRepository.Update)
+}
+
+// CityRepository saves cities on every write
+type CityRepository struct {
+	repositories.CityRepository
+	table table[models.City]
+}
+
+func (r *CityRepository) Create(ctx context.Context, city *models.City) error {
+	return write(ctx, r.table, city.ID, city, r.CityRepository.Create)
+}
+
+// ScreenRepository saves screens, seats included, on every write
+type ScreenRepository struct {
+	repositories.ScreenRepository
+	table table[models.Screen]
+}
+
+func (r *ScreenRepository) Create(ctx context.Context, screen *models.Screen) error {
+	return write(ctx, r.table, screen.ID, screen, r.ScreenRepository.Create)
+}
+
+func (r *ScreenRepository) Update(ctx context.Context, screen *models.Screen) error {
+	return write(ctx, r.table, screen.ID, screen, r.ScreenRepository.Update)
+}
+
+// ShowRepository saves shows on every write
+type ShowRepository struct {
+	repositories.ShowRepository
+	table table[models.Show]
+}
+
+func (r *ShowRepository) Create(ctx context.Context, show *models.Show) error {
+	return write(ctx, r.table, show.ID, show, r.ShowRepository.Create)
+}
+
+func (r *ShowRepository) Update(ctx context.Context, show *models.Show) error {
+	return write(ctx, r.table, show.ID, show, r.ShowRepository.Update)
+}
+
+// BookingRepository saves bookings on every write
+type BookingRepository struct {
+	repositories.BookingRepository
+	table table[models.Booking]
+}
+
+func (r *BookingRepository) Create(ctx context.Context, booking *models.Booking) error {
+	return write(ctx, r.table, booking.ID, booking, r.BookingRepository.Create)
+}
+
+func (r *BookingRepository) Update(ctx context.Context, booking *models.Booking) error {
+	return write(ctx, r.table, booking.ID, booking, r.BookingRepository.Update)
+}
+
+func (r *BookingRepository) Delete(ctx context.Context, id string) error {
+	if err := r.BookingRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.table.delete(ctx, []string{id})
+}
+
+// PaymentRepository saves payments on every write
+type PaymentRepository struct {
+	repositories.PaymentRepository
+	table table[models.Payment]
+}
+
+func (r *PaymentRepository) Create(ctx context.Context, payment *models.Payment) error {
+	return write(ctx, r.table, payment.ID, payment, r.PaymentRepository.Create)
+}
+
+func (r *PaymentRepository) Update(ctx context.Context, payment *models.Payment) error {
+	return write(ctx, r.table, payment.ID, payment, r.PaymentRepository.Update)
+}
+
+// RefundRepository saves refunds on every write
+type RefundRepository struct {
+	repositories.RefundRepository
+	table table[models.Refund]
+}
+
+func (r *RefundRepository) Create(ctx context.Context, refund *models.Refund) error {
+	return write(ctx, r.table, refund.ID, refund, r.RefundRepository.Create)
+}
+
+func (r *RefundRepository) Update(ctx context.Context, refund *models.Refund) error {
+	return write(ctx, r.table, refund.ID, refund, r.RefundRepository.Update)
+}
+
+// CouponRepository saves coupons, usage counts included, on every write
+type CouponRepository struct {
+	repositories.CouponRepository
+	table table[models.Coupon]
+}
+
+func (r *CouponRepository) Create(ctx context.Context, coupon *models.Coupon) error {
+	return write(ctx, r.table, coupon.ID, coupon, r.CouponRepository.Create)
+}
+
+func (r *CouponRepository) Update(ctx context.Context, coupon *models.Coupon) error {
+	return write(ctx, r.table, coupon.ID, coupon, r.CouponRepository.Update)
+}
+
+// SeatHoldRepository saves seat holds on every write; holds that lapsed while stopped are released by the expiry worker
+type SeatHoldRepository struct {
+	repositories.SeatHoldRepository
+	table table[models.SeatHold]
+}
+
+func (r *SeatHoldRepository) Create(ctx context.Context, hold *models.SeatHold) error {
+	return write(ctx, r.table, hold.ID, hold, r.SeatHoldRepository.Create)
+}
+
+func (r *SeatHoldRepository) Update(ctx context.Context, hold *models.SeatHold) error {
+	return write(ctx, r.table, hold.ID, hold, r.SeatHoldRepository.Update)
+}
+
+// TicketRepository saves e-tickets on every write
+type TicketRepository struct {
+	repositories.TicketRepository
+	table table[models.Ticket]
+}
+
+func (r *TicketRepository) Create(ctx context.Context, ticket *models.Ticket) error {
+	return write(ctx, r.table, ticket.ID, ticket, r.TicketRepository.Create)
+}
+
+func (r *TicketRepository) Update(ctx context.Context, ticket *models.Ticket) error {
+	return write(ctx, r.table, ticket.ID, ticket, r.TicketRepository.Update)
+}
+
+// ReviewRepository saves reviews on every write
+type ReviewRepository struct {
+	repositories.ReviewRepository
+	table table[models.Review]
+}
+
+func (r *ReviewRepository) Create(ctx context.Context, review *models.Review) error {
+	return write(ctx, r.table, review.ID, review, r.ReviewRepository.Create)
+}
+
+func (r *ReviewRepository) Update(ctx context.Context, review *models.Review) error {
+	return write(ctx, r.table, review.ID, review, r.ReviewRepository.Update)
+}
+
+// WalletRepository saves wallets and their transactions on every write
+type WalletRepository struct {
+	repositories.WalletRepository
+	table        table[models.Wallet]
+	transactions table[models.WalletTransaction]
+}
+
+func (r *WalletRepository) Create(ctx context.Context, wallet *models.Wallet) error {
+	return write(ctx, r.table, wallet.ID, wallet, r.WalletRepository.Create)
+}
+
+func (r *WalletRepository) Update(ctx context.Context, wallet *models.Wallet) error {
+	return write(ctx, r.table, wallet.ID, wallet, r.WalletRepository.Update)
+}
+
+func (r *WalletRepository) AddTransaction(ctx context.Context, tx *models.WalletTransaction) error {
+	return write(ctx, r.transactions, tx.ID, tx, r.WalletRepository.AddTransaction)
+}
+
+// loyaltyRecord is a loyalty account plus its per-booking ledger, which the account keeps unexported
+type loyaltyRecord struct {
+	Account  *models.LoyaltyAccount `json:"account"`
+	Earned   map[string]int64       `json:"earned"`
+	Redeemed map[string]int64       `json:"redeemed"`
+}
+
+// LoyaltyRepository saves loyalty accounts, ledger included, on every write
+type LoyaltyRepository struct {
+	repositories.LoyaltyRepository
+	table table[loyaltyRecord]
+}
+
+func (r *LoyaltyRepository) Create(ctx context.Context, account *models.LoyaltyAccount) error {
+	if err := r.LoyaltyRepository.Create(ctx, account); err != nil {
+		return err
+	}
+	return r.save(ctx, account)
+}
+
+func (r *LoyaltyRepository) Update(ctx context.Context, account *models.LoyaltyAccount) error {
+	if err := r.LoyaltyRepository.Update(ctx, account); err != nil {
+		return err
+	}
+	return r.save(ctx, account)
+}
+
+// save stores the account under its user, as there is one account per user
+func (r *LoyaltyRepository) save(ctx context.Context, account *models.LoyaltyAccount) error {
+	earned, redeemed := account.Ledger()
+	return r.table.save(ctx, account.UserID, &loyaltyRecord{Account: account, Earned: earned, Redeemed: redeemed})
+}
+
+// restoreAccount puts a saved account and its ledger back into memory
+func (r *LoyaltyRepository) restoreAccount(ctx context.Context, record *loyaltyRecord) error {
+	record.Account.RestoreLedger(record.Earned, record.Redeemed)
+	return r.LoyaltyRepository.Create(ctx, record.Account)
+}
+
+// OutboxRepository saves outbox messages on every write
+type OutboxRepository struct {
+	repositories.OutboxRepository
+	table table[models.OutboxMessage]
+}
+
+func (r *OutboxRepository) Create(ctx context.Context, message *models.OutboxMessage) error {
+	return write(ctx, r.table, message.ID, message, r.OutboxRepository.Create)
+}
+
+func (r *OutboxRepository) Update(ctx context.Context, message *models.OutboxMessage) error {
+	return write(ctx, r.table, message.ID, message, r.OutboxRepository.Update)
+}
+
+func (r *OutboxRepository) Delete(ctx context.Context, id string) error {
+	if err := r.OutboxRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.table.delete(ctx, []string{id})
+}
+
+func (r *OutboxRepository) DeleteDeliveredBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	delivered, err := r.OutboxRepository.GetByStatus(ctx, models.OutboxStatusDelivered)
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []string
+	for _, message := range delivered {
+		if message.DeliveredAt != nil && message.DeliveredAt.Before(cutoff) {
+			ids = append(ids, message.ID)
+		}
+	}
+
+	deleted, err := r.OutboxRepository.DeleteDeliveredBefore(ctx, cutoff)
+	if err != nil {
+		return deleted, err
+	}
+	return deleted, r.table.delete(ctx, ids)
+}
+
+// NotificationRepository saves every notification, so pending retries and dead letters survive a restart
+type NotificationRepository struct {
+	repositories.NotificationRepository
+	table table[models.Notification]
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	return write(ctx, r.table, notification.ID, notification, r.NotificationRepository.Create)
+}
+
+func (r *NotificationRepository) Update(ctx context.Context, notification *models.Notification) error {
+	return write(ctx, r.table, notification.ID, notification, r.NotificationRepository.Update)
+}
+
+func (r *NotificationRepository) DeleteSentBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	sent, err := r.NotificationRepository.GetByStatus(ctx, models.NotificationStatusSent)
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []string
+	for _, notification := range sent {
+		if notification.SentAt != nil && notification.SentAt.Before(cutoff) {
+			ids = append(ids, notification.ID)
+		}
+	}
+
+	deleted, err := r.NotificationRepository.DeleteSentBefore(ctx, cutoff)
+	if err != nil {
+		return deleted, err
+	}
+	return deleted, r.table.delete(ctx, ids)
+}
+
+// CredentialRepository saves password hashes on every write, keyed by user
+type CredentialRepository struct {
+	repositories.CredentialRepository
+	table table[models.Credential]
+}
+
+func (r *CredentialRepository) Save(ctx context.Context, credential *models.Credential) error {
+	return write(ctx, r.table, credential.UserID, credential, r.CredentialRepository.Save)
+}
+
+// SessionRepository saves sessions so logins survive a restart
+type SessionRepository struct {
+	repositories.SessionRepository
+	table table[models.Session]
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *models.Session) error {
+	return write(ctx, r.table, session.ID, session, r.SessionRepository.Create)
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, id string) error {
+	if err := r.SessionRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.table.delete(ctx, []string{id})
+}
+
+// SettlementRepository saves theatre settlements on every write
+type SettlementRepository struct {
+	repositories.SettlementRepository
+	table table[models.Settlement]
+}
+
+func (r *SettlementRepository) Create(ctx context.Context, settlement *models.Settlement) error {
+	return write(ctx, r.table, settlement.ID, settlement, r.SettlementRepository.Create)
+}
+
+func (r *SettlementRepository) Update(ctx context.Context, settlement *models.Settlement) error {
+	return write(ctx, r.table, settlement.ID, settlement, r.SettlementRepository.Update)
+}
+
+// AuditRepository saves every audit entry as it is recorded
+type AuditRepository struct {
+	repositories.AuditRepository
+	table table[models.AuditEntry]
+}
+
+func (r *AuditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	return write(ctx, r.table, entry.ID, entry, r.AuditRepository.Create)
+}
+
+// WebhookRepository saves partner webhooks, secrets included, so deliveries keep their signatures across restarts
+type WebhookRepository struct {
+	repositories.WebhookRepository
+	table table[models.Webhook]
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	return write(ctx, r.table, webhook.ID, webhook, r.WebhookRepository.Create)
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	if err := r.WebhookRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.table.delete(ctx, []string{id})
+}
+
+// WebhookDeliveryRepository saves every delivery attempt, so pending retries survive a restart
+type WebhookDeliveryRepository struct {
+	repositories.WebhookDeliveryRepository
+	table table[models.WebhookDelivery]
+}
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return write(ctx, r.table, delivery.ID, delivery, r.WebhookDeliveryRepository.Create)
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return write(ctx, r.table, delivery.ID, delivery, r.WebhookDeliveryRepository.Update)
+}
+
+// WatchlistRepository saves watchlists, reminders included, so nobody is reminded twice after a restart
+type WatchlistRepository struct {
+	repositories.WatchlistRepository
+	table table[models.WatchlistEntry]
+}
+
+func (r *WatchlistRepository) Create(ctx context.Context, entry *models.WatchlistEntry) error {
+	return write(ctx, r.table, entry.ID, entry, r.WatchlistRepository.Create)
+}
+
+func (r *WatchlistRepository) Update(ctx context.Context, entry *models.WatchlistEntry) error {
+	return write(ctx, r.table, entry.ID, entry, r.WatchlistRepository.Update)
+}
+
+func (r *WatchlistRepository) Delete(ctx context.Context, id string) error {
+	if err := r.WatchlistRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.table.delete(ctx, []string{id})
+}
+
+// BulkBookingRepository saves corporate blocks with their codes, so redemptions survive a restart
+type BulkBookingRepository struct {
+	repositories.BulkBookingRepository
+	table table[models.BulkBooking]
+}
+
+func (r *BulkBookingRepository) Create(ctx context.Context, bulk *models.BulkBooking) error {
+	return write(ctx, r.table, bulk.ID, bulk, r.BulkBookingRepository.Create)
+}
+
+func (r *BulkBookingRepository) Update(ctx context.Context, bulk *models.BulkBooking) error {
+	return write(ctx, r.table, bulk.ID, bulk, r.BulkBookingRepository.Update)
+}
+
+// ParkingReservationRepository saves parking reservations, so slots stay taken after a restart
+type ParkingReservationRepository struct {
+	repositories.ParkingReservationRepository
+	table table[models.ParkingReservation]
+}
+
+func (r *ParkingReservationRepository) Create(ctx context.Context, reservation *models.ParkingReservation) error {
+	return write(ctx, r.table, reservation.ID, reservation, r.ParkingReservationRepository.Create)
+}
+
+func (r *ParkingReservationRepository) Update(ctx context.Context, reservation *models.ParkingReservation) error {
+	return write(ctx, r.table, reservation.ID, reservation, r.ParkingReservationRepository.Update)
+}
+
+// ResaleListingRepository saves resale listings, so bookings stay on sale after a restart
+type ResaleListingRepository struct {
+	repositories.ResaleListingRepository
+	table table[models.ResaleListing]
+}
+
+func (r *ResaleListingRepository) Create(ctx context.Context, listing *models.ResaleListing) error {
+	return write(ctx, r.table, listing.ID, listing, r.ResaleListingRepository.Create)
+}
+
+func (r *ResaleListingRepository) Update(ctx context.Context, listing *models.ResaleListing) error {
+	return write(ctx, r.table, listing.ID, listing, r.ResaleListingRepository.Update)
+}
+
+// PaymentInstrumentRepository saves users' payment instruments. Cards are saved as vault tokens, never numbers.
+type PaymentInstrumentRepository struct {
+	repositories.PaymentInstrumentRepository
+	table table[models.PaymentInstrument]
+}
+
+func (r *PaymentInstrumentRepository) Create(ctx context.Context, instrument *models.PaymentInstrument) error {
+	return write(ctx, r.table, instrument.ID, instrument, r.PaymentInstrumentRepository.Create)
+}
+
+func (r *PaymentInstrumentRepository) Delete(ctx context.Context, id string) error {
+	if err := r.PaymentInstrumentRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.table.delete(ctx, []string{id})
+}
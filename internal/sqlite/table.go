@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// table stores one entity type as JSON documents keyed by ID.
+// Queries stay with the in-memory repository; the table only has to survive a restart.
+type table[T any] struct {
+	db   *sql.DB
+	name string
+}
+
+// save inserts or replaces the entity's document; replacing keeps the row's original insertion order
+func (t table[T]) save(ctx context.Context, id string, entity *T) error {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return fmt.Errorf("sqlite: encoding %s %s: %w", t.name, id, err)
+	}
+
+	stmt := fmt.Sprintf("INSERT INTO %s (id, data) VALUES (?, ?) ON CONFLICT(id) DO UPDATE SET data = excluded.data", t.name)
+	if _, err := t.conn(ctx).ExecContext(ctx, stmt, id, string(data)); err != nil {
+		return fmt.Errorf("sqlite: saving %s %s: %w", t.name, id, err)
+	}
+	return nil
+}
+
+// delete removes the given documents
+func (t table[T]) delete(ctx context.Context, ids []string) error {
+	stmt := fmt.Sprintf("DELETE FROM %s WHERE id = ?", t.name)
+	for _, id := range ids {
+		if _, err := t.conn(ctx).ExecContext(ctx, stmt, id); err != nil {
+			return fmt.Errorf("sqlite: deleting %s %s: %w", t.name, id, err)
+		}
+	}
+	return nil
+}
+
+// conn is the transaction ctx carries, or the database outside one
+func (t table[T]) conn(ctx context.Context) execer {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return t.db
+}
+
+// restore replays every stored document, oldest first, into create
+func (t table[T]) restore(ctx context.Context, create func(context.Context, *T) error) (int, error) {
+	rows, err := t.db.QueryContext(ctx, fmt.Sprintf("SELECT id, data FROM %s ORDER BY rowid", t.name))
+	if err != nil {
+		return 0, fmt.Errorf("sqlite: loading %s: %w", t.name, err)
+	}
+	defer rows.Close()
+
+	restored := 0
+	for rows.Next() {
+		var id, data string
+		if err := rows.Scan(&id, &data); err != nil {
+			return restored, fmt.Errorf("sqlite: loading %s: %w", t.name, err)
+		}
+
+		entity := new(T)
+		if err := json.Unmarshal([]byte(data), entity); err != nil {
+			return restored, fmt.Errorf("sqlite: decoding %s %s: %w", t.name, id, err)
+		}
+		if err := create(ctx, entity); err != nil {
+			return restored, fmt.Errorf("sqlite: restoring %s %s: %w", t.name, id, err)
+		}
+		restored++
+	}
+	return restored, rows.Err()
+}
+
+// write applies a change to the in-memory repository, then saves the entity
+func write[T any](ctx context.Context, t table[T], id string, entity *T, apply func(context.Context, *T) error) error {
+	if err := apply(ctx, entity); err != nil {
+		return err
+	}
+	return t.save(ctx, id, entity)
+}
@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// UnitOfWork runs repository transactions on the database: the rows saved with a transaction's context are
+// committed together, and a rollback also runs the undo steps that put the in-memory side back
+type UnitOfWork struct {
+	db *sql.DB
+}
+
+// NewUnitOfWork creates the unit of work of the repositories Restore builds on db
+func NewUnitOfWork(db *sql.DB) repositories.UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+type txKey struct{}
+
+// Begin starts a database transaction. The database has a single connection, so other writers wait for
+// it to end; everything inside must use the returned context, or it waits on itself.
+func (u *UnitOfWork) Begin(ctx context.Context) (context.Context, error) {
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: beginning transaction: %w", err)
+	}
+	ctx, _ = repositories.BeginTransaction(ctx)
+	return context.WithValue(ctx, txKey{}, tx), nil
+}
+
+// Commit commits the rows; if that fails the transaction is rolled back, undo steps included
+func (u *UnitOfWork) Commit(ctx context.Context) error {
+	tx, transaction, err := transactionOf(ctx)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		transaction.Finish(true)
+		return fmt.Errorf("sqlite: committing transaction: %w", err)
+	}
+	return transaction.Finish(false)
+}
+
+// Rollback discards the rows, then runs the undo steps, which may write again now the connection is free
+func (u *UnitOfWork) Rollback(ctx context.Context) error {
+	tx, transaction, err := transactionOf(ctx)
+	if err != nil {
+		return err
+	}
+	rollbackErr := tx.Rollback()
+	if err := transaction.Finish(true); err != nil {
+		return err
+	}
+	if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+		return fmt.Errorf("sqlite: rolling back transaction: %w", rollbackErr)
+	}
+	return nil
+}
+
+// transactionOf returns the database and repository transactions ctx carries
+func transactionOf(ctx context.Context) (*sql.Tx, *repositories.Transaction, error) {
+	tx, _ := ctx.Value(txKey{}).(*sql.Tx)
+	transaction := repositories.TransactionFrom(ctx)
+	if tx == nil || transaction == nil {
+		return nil, nil, models.ErrNoTransaction
+	}
+	return tx, transaction, nil
+}
+
+// execer is what table writes run on: the database, or the transaction in progress
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
@@ -0,0 +1,133 @@
+package strategies
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MockOTP is the one-time password the mock issuer accepts, like a provider sandbox's test OTP
+const MockOTP = "123456"
+
+// challengedMethods are the card methods whose mock issuer can ask for an OTP
+var challengedMethods = []models.PaymentMethod{models.PaymentMethodCreditCard, models.PaymentMethodDebitCard, models.PaymentMethodEMI}
+
+// ChallengeConfig tunes the mock issuer's 3-D Secure step; the zero value never challenges a charge
+type ChallengeConfig struct {
+	Threshold int64         // Card charges of at least this many minor units need an OTP; 0 challenges none
+	TTL       time.Duration // How long the OTP can be entered
+}
+
+// DefaultChallengeConfig challenges no charges, with OTPs valid for 5 minutes once a threshold is set
+func DefaultChallengeConfig() ChallengeConfig {
+	return ChallengeConfig{TTL: 5 * time.Minute}
+}
+
+// ChallengeConfigFromEnv reads PAYMENT_OTP_THRESHOLD (minor units) and PAYMENT_OTP_TTL, defaulting to
+// DefaultChallengeConfig
+func ChallengeConfigFromEnv() ChallengeConfig {
+	cfg := DefaultChallengeConfig()
+	if threshold, err := strconv.ParseInt(os.Getenv("PAYMENT_OTP_THRESHOLD"), 10, 64); err == nil && threshold >= 0 {
+		cfg.Threshold = threshold
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("PAYMENT_OTP_TTL")); err == nil && ttl > 0 {
+		cfg.TTL = ttl
+	}
+	return cfg
+}
+
+// challengedCharge is a validated charge held back until its OTP is entered
+type challengedCharge struct {
+	strategy  PaymentStrategy
+	amount    models.Money
+	metadata  map[string]string
+	expiresAt time.Time
+}
+
+// WithChallenges makes the mock issuer ask for an OTP on card charges at or above the config's threshold
+func (pg *PaymentGatewayImpl) WithChallenges(config ChallengeConfig) *PaymentGatewayImpl {
+	pg.issuer = config
+	return pg
+}
+
+// challenges reports whether the mock issuer wants an OTP before the strategy charges amount: at or above the
+// threshold, or whatever the amount when fraud checks asked for step-up verification. Off-session charges,
+// which the cardholder isn't there to authorize, are exempt.
+func (pg *PaymentGatewayImpl) challenges(strategy PaymentStrategy, amount models.Money, metadata map[string]string) bool {
+	if metadata["off_session"] == "true" {
+		return false
+	}
+	stepUp := metadata["step_up"] == "true"
+	if !stepUp && (pg.issuer.Threshold <= 0 || amount.Minor < pg.issuer.Threshold) {
+		return false
+	}
+	if !slices.Contains(challengedMethods, strategy.GetPaymentMethod()) {
+		return false
+	}
+	// Real providers run 3-D Secure themselves
+	backed, ok := strategy.(ProviderBacked)
+	return !ok || backed.Provider() == nil
+}
+
+// challenge holds back a card charge until its OTP is entered, returning the challenge instead of an answer.
+// Invalid card details fail straight away, as the issuer never gets to send an OTP.
+func (pg *PaymentGatewayImpl) challenge(strategy PaymentStrategy, amount models.Money, metadata map[string]string) (*services.PaymentResult, error) {
+	if err := strategy.ValidatePayment(metadata); err != nil {
+		return &services.PaymentResult{Success: false, ErrorMessage: err.Error()}, err
+	}
+
+	// Step-up challenges can come without a threshold ever being configured
+	ttl := cmp.Or(pg.issuer.TTL, DefaultChallengeConfig().TTL)
+	challenge := &models.OTPChallenge{Token: "3DS_" + uuid.New().String(), ExpiresAt: pg.clock.Now().Add(ttl)}
+
+	pg.mutex.Lock()
+	defer pg.mutex.Unlock()
+	now := pg.clock.Now()
+	for token, held := range pg.held {
+		if !now.Before(held.expiresAt) {
+			delete(pg.held, token)
+		}
+	}
+	pg.held[challenge.Token] = &challengedCharge{strategy: strategy, amount: amount, metadata: metadata, expiresAt: challenge.ExpiresAt}
+
+	return &services.PaymentResult{
+		Success:   false,
+		Response:  fmt.Sprintf("OTP sent by the %s issuer", strategy.GetPaymentMethod()),
+		Challenge: challenge,
+	}, nil
+}
+
+// CompleteChallenge charges a held-back payment once its OTP is right. A wrong OTP leaves the challenge open
+// for another try; the payment service decides when the tries are used up.
+func (pg *PaymentGatewayImpl) CompleteChallenge(ctx context.Context, token, otp string, method models.PaymentMethod) (*services.PaymentResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	pg.mutex.Lock()
+	held, exists := pg.held[token]
+	switch {
+	case !exists || held.strategy.GetPaymentMethod() != method:
+		pg.mutex.Unlock()
+		return nil, models.ErrNoPaymentChallenge
+	case !pg.clock.Now().Before(held.expiresAt):
+		delete(pg.held, token)
+		pg.mutex.Unlock()
+		return nil, models.ErrOTPChallengeExpired
+	case otp != MockOTP:
+		pg.mutex.Unlock()
+		return nil, models.ErrIncorrectOTP
+	}
+	delete(pg.held, token)
+	pg.mutex.Unlock()
+
+	return pg.charge(ctx, held.strategy, held.amount, held.metadata)
+}
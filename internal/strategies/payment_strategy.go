@@ -1,29 +1,55 @@
 package strategies
 
 import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/gateways"
 	"bookmyshow-lld/internal/models"
 	"bookmyshow-lld/internal/services"
+	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
 )
 
 // PaymentStrategy defines the strategy interface for payment processing - demonstrates Strategy Pattern
 type PaymentStrategy interface {
-	ProcessPayment(amount float64, metadata map[string]string) (*services.PaymentResult, error)
+	ProcessPayment(ctx context.Context, amount models.Money, metadata map[string]string) (*services.PaymentResult, error)
 	ValidatePayment(metadata map[string]string) error
 	GetPaymentMethod() models.PaymentMethod
 }
 
+// Refunder is implemented by strategies that return money themselves instead of through the mock or a provider
+type Refunder interface {
+	RefundPayment(ctx context.Context, transactionID string, amount models.Money) (*services.PaymentResult, error)
+}
+
+// ProviderBacked is implemented by strategies that can delegate to a real payment provider;
+// refunds for their payments go back through the same provider
+type ProviderBacked interface {
+	Provider() gateways.Provider
+}
+
 // PaymentGatewayImpl implements the PaymentGateway interface using strategies
 type PaymentGatewayImpl struct {
 	strategies map[models.PaymentMethod]PaymentStrategy
+	charges    map[string]*services.PaymentResult // What the mock answered, by payment ID, for QueryTransaction
+	issuer     ChallengeConfig                    // When the mock card issuer asks for an OTP
+	held       map[string]*challengedCharge       // Charges waiting for their OTP, by challenge token
+	clock      clock.Clock                        // When held charges' OTPs expire
+	mutex      sync.Mutex
 }
 
 // NewPaymentGateway creates a new payment gateway with all strategies - demonstrates Strategy Pattern
-func NewPaymentGateway() *PaymentGatewayImpl {
+func NewPaymentGateway(clock clock.Clock) *PaymentGatewayImpl {
 	gateway := &PaymentGatewayImpl{
 		strategies: make(map[models.PaymentMethod]PaymentStrategy),
+		charges:    make(map[string]*services.PaymentResult),
+		held:       make(map[string]*challengedCharge),
+		clock:      clock,
 	}
 
 	// Register all payment strategies - demonstrates Strategy Pattern
@@ -32,29 +58,206 @@ func NewPaymentGateway() *PaymentGatewayImpl {
 	gateway.RegisterStrategy(&UPIStrategy{})
 	gateway.RegisterStrategy(&NetBankingStrategy{})
 	gateway.RegisterStrategy(&WalletStrategy{})
+	gateway.RegisterStrategy(&EMIStrategy{clock: clock})
+	gateway.RegisterStrategy(&PayLaterStrategy{clock: clock})
 
 	return gateway
 }
 
+// NewPaymentGatewayWithProvider creates a gateway whose credit card and UPI strategies charge through
+// a real provider when it supports the method - demonstrates Adapter Pattern. A nil provider keeps the mock.
+func NewPaymentGatewayWithProvider(provider gateways.Provider, clock clock.Clock) *PaymentGatewayImpl {
+	gateway := NewPaymentGateway(clock)
+	if provider == nil {
+		return gateway
+	}
+
+	if provider.Supports(models.PaymentMethodCreditCard) {
+		gateway.RegisterStrategy(&CreditCardStrategy{provider: provider})
+	}
+	if provider.Supports(models.PaymentMethodUPI) {
+		gateway.RegisterStrategy(&UPIStrategy{provider: provider})
+	}
+	return gateway
+}
+
+// NewPaymentGatewayWithWallet registers a wallet strategy that pays from, and refunds to, real wallet balances
+func NewPaymentGatewayWithWallet(provider gateways.Provider, wallets services.WalletService, clock clock.Clock) *PaymentGatewayImpl {
+	gateway := NewPaymentGatewayWithProvider(provider, clock)
+	gateway.RegisterStrategy(&WalletStrategy{wallets: wallets})
+	return gateway
+}
+
 // RegisterStrategy registers a payment strategy
 func (pg *PaymentGatewayImpl) RegisterStrategy(strategy PaymentStrategy) {
 	pg.strategies[strategy.GetPaymentMethod()] = strategy
 }
 
 // ProcessPayment processes payment using the appropriate strategy - demonstrates Strategy Pattern
-func (pg *PaymentGatewayImpl) ProcessPayment(amount float64, method models.PaymentMethod, metadata map[string]string) (*services.PaymentResult, error) {
+func (pg *PaymentGatewayImpl) ProcessPayment(ctx context.Context, amount models.Money, method models.PaymentMethod, metadata map[string]string) (*services.PaymentResult, error) {
 	strategy, exists := pg.strategies[method]
 	if !exists {
 		return nil, fmt.Errorf("payment method %s not supported", method)
 	}
 
-	return strategy.ProcessPayment(amount, metadata)
+	// Respect caller cancellation before touching the provider
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if pg.challenges(strategy, amount, metadata) {
+		return pg.challenge(strategy, amount, metadata)
+	}
+	return pg.charge(ctx, strategy, amount, metadata)
+}
+
+// charge runs the strategy, remembering the mock's answer for QueryTransaction
+func (pg *PaymentGatewayImpl) charge(ctx context.Context, strategy PaymentStrategy, amount models.Money, metadata map[string]string) (*services.PaymentResult, error) {
+	result, err := strategy.ProcessPayment(ctx, amount, metadata)
+	if backed, ok := strategy.(ProviderBacked); (!ok || backed.Provider() == nil) && result != nil && metadata["payment_id"] != "" {
+		pg.mutex.Lock()
+		pg.charges[metadata["payment_id"]] = result
+		pg.mutex.Unlock()
+	}
+	return result, err
+}
+
+// QueryTransaction asks how the charge for a payment went. Provider-backed methods ask the provider; the mock
+// repeats what it answered, and reports a charge it never made as failed.
+func (pg *PaymentGatewayImpl) QueryTransaction(ctx context.Context, paymentID string, method models.PaymentMethod) (*services.PaymentResult, error) {
+	strategy, exists := pg.strategies[method]
+	if !exists {
+		return nil, fmt.Errorf("payment method %s not supported", method)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if backed, ok := strategy.(ProviderBacked); ok && backed.Provider() != nil {
+		return queryViaProvider(ctx, backed.Provider(), paymentID)
+	}
+
+	pg.mutex.Lock()
+	defer pg.mutex.Unlock()
+	if result, charged := pg.charges[paymentID]; charged {
+		return result, nil
+	}
+	return &services.PaymentResult{Success: false, ErrorMessage: "no charge was made for payment " + paymentID}, nil
+}
+
+// RefundPayment returns money for a previously captured transaction
+func (pg *PaymentGatewayImpl) RefundPayment(ctx context.Context, transactionID string, amount models.Money, method models.PaymentMethod) (*services.PaymentResult, error) {
+	strategy, exists := pg.strategies[method]
+	if !exists {
+		return nil, fmt.Errorf("payment method %s not supported", method)
+	}
+
+	if transactionID == "" || !amount.IsPositive() {
+		return nil, models.ErrInvalidRefundAmount
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Provider-charged payments must be refunded by the same provider
+	if backed, ok := strategy.(ProviderBacked); ok && backed.Provider() != nil {
+		return refundViaProvider(ctx, backed.Provider(), transactionID, amount)
+	}
+
+	if refunder, ok := strategy.(Refunder); ok {
+		return refunder.RefundPayment(ctx, transactionID, amount)
+	}
+
+	return mockRefund(transactionID, amount, method), nil
+}
+
+// mockRefund fakes a refund - refunds against captured transactions always succeed
+func mockRefund(transactionID string, amount models.Money, method models.PaymentMethod) *services.PaymentResult {
+	return &services.PaymentResult{
+		Success:       true,
+		TransactionID: fmt.Sprintf("REF_%s_%d", transactionID, time.Now().UnixNano()),
+		Response:      fmt.Sprintf("Refund of %s processed via %s", amount, method),
+	}
+}
+
+// chargeViaProvider sends a validated payment to a real provider and maps its answer to a PaymentResult.
+// Declines fail like the mock does; transport and configuration problems are gateway errors.
+func chargeViaProvider(ctx context.Context, provider gateways.Provider, amount models.Money, method models.PaymentMethod, metadata map[string]string) (*services.PaymentResult, error) {
+	result, err := provider.Charge(ctx, gateways.ChargeRequest{
+		Amount:         amount,
+		Method:         method,
+		IdempotencyKey: metadata["payment_id"],
+		Metadata:       metadata,
+	})
+	if errors.Is(err, gateways.ErrOutcomeUnknown) {
+		return nil, fmt.Errorf("%w: %s: %v", models.ErrPaymentOutcomeUnknown, provider.Name(), err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", models.ErrPaymentGatewayError, provider.Name(), err)
+	}
+
+	if !result.Success {
+		return &services.PaymentResult{
+			Success:      false,
+			ErrorMessage: result.Message,
+		}, models.ErrPaymentProcessingFail
+	}
+
+	return &services.PaymentResult{
+		Success:       true,
+		TransactionID: result.Reference,
+		Response:      result.Message,
+	}, nil
+}
+
+// queryViaProvider asks a provider how the charge made with the payment ID as idempotency key went
+func queryViaProvider(ctx context.Context, provider gateways.Provider, paymentID string) (*services.PaymentResult, error) {
+	result, err := provider.Query(ctx, paymentID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", models.ErrPaymentGatewayError, provider.Name(), err)
+	}
+
+	if !result.Success {
+		return &services.PaymentResult{
+			Success:      false,
+			Pending:      result.Pending,
+			ErrorMessage: result.Message,
+		}, nil
+	}
+
+	return &services.PaymentResult{
+		Success:       true,
+		TransactionID: result.Reference,
+		Response:      result.Message,
+	}, nil
+}
+
+// refundViaProvider refunds a provider reference; a refused refund is a gateway error
+func refundViaProvider(ctx context.Context, provider gateways.Provider, reference string, amount models.Money) (*services.PaymentResult, error) {
+	result, err := provider.Refund(ctx, reference, amount)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", models.ErrPaymentGatewayError, provider.Name(), err)
+	}
+
+	if !result.Success {
+		return nil, fmt.Errorf("%w: %s", models.ErrPaymentGatewayError, result.Message)
+	}
+
+	return &services.PaymentResult{
+		Success:       true,
+		TransactionID: result.Reference,
+		Response:      result.Message,
+	}, nil
 }
 
 // CreditCardStrategy implements payment processing for credit cards - demonstrates Concrete Strategy
-type CreditCardStrategy struct{}
+type CreditCardStrategy struct {
+	provider gateways.Provider // nil uses the built-in mock
+}
 
-func (ccs *CreditCardStrategy) ProcessPayment(amount float64, metadata map[string]string) (*services.PaymentResult, error) {
+func (ccs *CreditCardStrategy) ProcessPayment(ctx context.Context, amount models.Money, metadata map[string]string) (*services.PaymentResult, error) {
 	if err := ccs.ValidatePayment(metadata); err != nil {
 		return &services.PaymentResult{
 			Success:      false,
@@ -62,6 +265,14 @@ func (ccs *CreditCardStrategy) ProcessPayment(amount float64, metadata map[strin
 		}, err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if ccs.provider != nil {
+		return chargeViaProvider(ctx, ccs.provider, amount, ccs.GetPaymentMethod(), metadata)
+	}
+
 	// Mock payment processing - 90% success rate
 	success := rand.Float32() > 0.1
 
@@ -80,7 +291,7 @@ func (ccs *CreditCardStrategy) ProcessPayment(amount float64, metadata map[strin
 }
 
 func (ccs *CreditCardStrategy) ValidatePayment(metadata map[string]string) error {
-	if metadata["card_number"] == "" || metadata["cvv"] == "" || metadata["expiry"] == "" {
+	if metadata["card_number"] == "" || (metadata["cvv"] == "" && !savedCard(metadata)) || metadata["expiry"] == "" {
 		return fmt.Errorf("missing required credit card details")
 	}
 	return nil
@@ -90,10 +301,20 @@ func (ccs *CreditCardStrategy) GetPaymentMethod() models.PaymentMethod {
 	return models.PaymentMethodCreditCard
 }
 
+func (ccs *CreditCardStrategy) Provider() gateways.Provider {
+	return ccs.provider
+}
+
+// savedCard reports whether the card details came from a saved instrument. Saved cards are charged without the
+// CVV or PIN, which are never stored; the issuer's OTP is the second factor instead.
+func savedCard(metadata map[string]string) bool {
+	return metadata["instrument_id"] != ""
+}
+
 // DebitCardStrategy implements payment processing for debit cards - demonstrates Concrete Strategy
 type DebitCardStrategy struct{}
 
-func (dcs *DebitCardStrategy) ProcessPayment(amount float64, metadata map[string]string) (*services.PaymentResult, error) {
+func (dcs *DebitCardStrategy) ProcessPayment(ctx context.Context, amount models.Money, metadata map[string]string) (*services.PaymentResult, error) {
 	if err := dcs.ValidatePayment(metadata); err != nil {
 		return &services.PaymentResult{
 			Success:      false,
@@ -101,6 +322,10 @@ func (dcs *DebitCardStrategy) ProcessPayment(amount float64, metadata map[string
 		}, err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Mock payment processing - 85% success rate
 	success := rand.Float32() > 0.15
 
@@ -119,7 +344,7 @@ func (dcs *DebitCardStrategy) ProcessPayment(amount float64, metadata map[string
 }
 
 func (dcs *DebitCardStrategy) ValidatePayment(metadata map[string]string) error {
-	if metadata["card_number"] == "" || metadata["pin"] == "" {
+	if metadata["card_number"] == "" || (metadata["pin"] == "" && !savedCard(metadata)) {
 		return fmt.Errorf("missing required debit card details")
 	}
 	return nil
@@ -130,9 +355,11 @@ func (dcs *DebitCardStrategy) GetPaymentMethod() models.PaymentMethod {
 }
 
 // UPIStrategy implements payment processing for UPI - demonstrates Concrete Strategy
-type UPIStrategy struct{}
+type UPIStrategy struct {
+	provider gateways.Provider // nil uses the built-in mock
+}
 
-func (upi *UPIStrategy) ProcessPayment(amount float64, metadata map[string]string) (*services.PaymentResult, error) {
+func (upi *UPIStrategy) ProcessPayment(ctx context.Context, amount models.Money, metadata map[string]string) (*services.PaymentResult, error) {
 	if err := upi.ValidatePayment(metadata); err != nil {
 		return &services.PaymentResult{
 			Success:      false,
@@ -140,6 +367,14 @@ func (upi *UPIStrategy) ProcessPayment(amount float64, metadata map[string]strin
 		}, err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if upi.provider != nil {
+		return chargeViaProvider(ctx, upi.provider, amount, upi.GetPaymentMethod(), metadata)
+	}
+
 	// Mock payment processing - 95% success rate (UPI is most reliable)
 	success := rand.Float32() > 0.05
 
@@ -168,10 +403,14 @@ func (upi *UPIStrategy) GetPaymentMethod() models.PaymentMethod {
 	return models.PaymentMethodUPI
 }
 
+func (upi *UPIStrategy) Provider() gateways.Provider {
+	return upi.provider
+}
+
 // NetBankingStrategy implements payment processing for net banking - demonstrates Concrete Strategy
 type NetBankingStrategy struct{}
 
-func (nb *NetBankingStrategy) ProcessPayment(amount float64, metadata map[string]string) (*services.PaymentResult, error) {
+func (nb *NetBankingStrategy) ProcessPayment(ctx context.Context, amount models.Money, metadata map[string]string) (*services.PaymentResult, error) {
 	if err := nb.ValidatePayment(metadata); err != nil {
 		return &services.PaymentResult{
 			Success:      false,
@@ -179,6 +418,10 @@ func (nb *NetBankingStrategy) ProcessPayment(amount float64, metadata map[string
 		}, err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Mock payment processing - 92% success rate
 	success := rand.Float32() > 0.08
 
@@ -207,10 +450,12 @@ func (nb *NetBankingStrategy) GetPaymentMethod() models.PaymentMethod {
 	return models.PaymentMethodNetBanking
 }
 
-// WalletStrategy implements payment processing for digital wallets - demonstrates Concrete Strategy
-type WalletStrategy struct{}
+// WalletStrategy implements payment processing from the user's wallet balance - demonstrates Concrete Strategy
+type WalletStrategy struct {
+	wallets services.WalletService // nil uses the built-in mock
+}
 
-func (ws *WalletStrategy) ProcessPayment(amount float64, metadata map[string]string) (*services.PaymentResult, error) {
+func (ws *WalletStrategy) ProcessPayment(ctx context.Context, amount models.Money, metadata map[string]string) (*services.PaymentResult, error) {
 	if err := ws.ValidatePayment(metadata); err != nil {
 		return &services.PaymentResult{
 			Success:      false,
@@ -218,6 +463,26 @@ func (ws *WalletStrategy) ProcessPayment(amount float64, metadata map[string]str
 		}, err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if ws.wallets != nil {
+		// The ledger entry ID is the transaction ID, so refunds can find the wallet that paid
+		tx, err := ws.wallets.Debit(ctx, metadata["user_id"], amount, metadata["payment_id"], "Payment for booking "+metadata["booking_id"])
+		if err != nil {
+			return &services.PaymentResult{
+				Success:      false,
+				ErrorMessage: err.Error(),
+			}, err
+		}
+		return &services.PaymentResult{
+			Success:       true,
+			TransactionID: tx.ID,
+			Response:      fmt.Sprintf("Paid from wallet, balance %s", tx.BalanceAfter),
+		}, nil
+	}
+
 	// Mock payment processing - 97% success rate (wallets are very reliable)
 	success := rand.Float32() > 0.03
 
@@ -236,12 +501,183 @@ func (ws *WalletStrategy) ProcessPayment(amount float64, metadata map[string]str
 }
 
 func (ws *WalletStrategy) ValidatePayment(metadata map[string]string) error {
-	if metadata["wallet_id"] == "" {
-		return fmt.Errorf("missing wallet ID")
+	if metadata["user_id"] == "" {
+		return fmt.Errorf("missing wallet owner")
 	}
 	return nil
 }
 
+// RefundPayment credits the wallet that paid
+func (ws *WalletStrategy) RefundPayment(ctx context.Context, transactionID string, amount models.Money) (*services.PaymentResult, error) {
+	if ws.wallets == nil {
+		return mockRefund(transactionID, amount, ws.GetPaymentMethod()), nil
+	}
+
+	tx, err := ws.wallets.RefundTransaction(ctx, transactionID, amount, "Refund to wallet")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrPaymentGatewayError, err)
+	}
+	return &services.PaymentResult{
+		Success:       true,
+		TransactionID: tx.ID,
+		Response:      fmt.Sprintf("Refunded %s to wallet", amount),
+	}, nil
+}
+
 func (ws *WalletStrategy) GetPaymentMethod() models.PaymentMethod {
 	return models.PaymentMethodWallet
 }
+
+// emiPlans maps each EMI tenure the issuer offers, in months, to its annual interest rate; 3 months is no-cost EMI
+var emiPlans = map[int]float64{3: 0, 6: 13, 9: 14, 12: 15}
+
+// maxEMIInterestRate caps a caller-supplied annual rate, in percent
+const maxEMIInterestRate = 36
+
+// EMIStrategy implements card EMI payments: the theatre is paid in full and the user repays monthly - demonstrates Concrete Strategy
+type EMIStrategy struct {
+	clock clock.Clock // Installments fall due monthly from today
+}
+
+func (emi *EMIStrategy) ProcessPayment(ctx context.Context, amount models.Money, metadata map[string]string) (*services.PaymentResult, error) {
+	if err := emi.ValidatePayment(metadata); err != nil {
+		return &services.PaymentResult{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tenure, rate, _ := emiTerms(metadata)
+	plan, err := models.NewEMIPlan(amount, tenure, rate, emi.clock.Now())
+	if err != nil {
+		return &services.PaymentResult{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, err
+	}
+
+	// Mock payment processing - 88% success rate (the issuer also checks the card's EMI eligibility)
+	success := rand.Float32() > 0.12
+
+	if success {
+		return &services.PaymentResult{
+			Success:       true,
+			TransactionID: fmt.Sprintf("EMI_%d", time.Now().Unix()),
+			Response:      fmt.Sprintf("Payment converted to %d-month EMI at %g%% p.a.", tenure, rate),
+			Installments:  plan,
+		}, nil
+	}
+
+	return &services.PaymentResult{
+		Success:      false,
+		ErrorMessage: "EMI payment failed",
+	}, models.ErrPaymentProcessingFail
+}
+
+func (emi *EMIStrategy) ValidatePayment(metadata map[string]string) error {
+	if metadata["card_number"] == "" || (metadata["cvv"] == "" && !savedCard(metadata)) || metadata["expiry"] == "" {
+		return fmt.Errorf("missing required credit card details")
+	}
+	_, _, err := emiTerms(metadata)
+	return err
+}
+
+func (emi *EMIStrategy) GetPaymentMethod() models.PaymentMethod {
+	return models.PaymentMethodEMI
+}
+
+// emiTerms reads tenure_months and an optional interest_rate override (annual percent) from the metadata
+func emiTerms(metadata map[string]string) (int, float64, error) {
+	tenure, err := strconv.Atoi(metadata["tenure_months"])
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: missing or invalid EMI tenure", models.ErrInvalidPaymentData)
+	}
+	rate, offered := emiPlans[tenure]
+	if !offered {
+		return 0, 0, fmt.Errorf("%w: EMI tenure must be 3, 6, 9 or 12 months", models.ErrInvalidPaymentData)
+	}
+
+	if raw := metadata["interest_rate"]; raw != "" {
+		if rate, err = strconv.ParseFloat(raw, 64); err != nil || rate < 0 || rate > maxEMIInterestRate {
+			return 0, 0, fmt.Errorf("%w: EMI interest rate must be between 0 and %d%%", models.ErrInvalidPaymentData, maxEMIInterestRate)
+		}
+	}
+	return tenure, rate, nil
+}
+
+// payLaterDueDays are the repayment windows offered; 15 days when the metadata doesn't choose
+var payLaterDueDays = []int{15, 30}
+
+// PayLaterStrategy implements buy now, pay later against the user's credit line - demonstrates Concrete Strategy
+type PayLaterStrategy struct {
+	clock clock.Clock // Repayment falls due days from today
+}
+
+func (pl *PayLaterStrategy) ProcessPayment(ctx context.Context, amount models.Money, metadata map[string]string) (*services.PaymentResult, error) {
+	if err := pl.ValidatePayment(metadata); err != nil {
+		return &services.PaymentResult{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	days, _ := payLaterDays(metadata)
+	plan, err := models.NewPayLaterPlan(amount, pl.clock.Now().AddDate(0, 0, days))
+	if err != nil {
+		return &services.PaymentResult{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, err
+	}
+
+	// Mock payment processing - 90% success rate (the rest fail the credit check)
+	success := rand.Float32() > 0.1
+
+	if success {
+		return &services.PaymentResult{
+			Success:       true,
+			TransactionID: fmt.Sprintf("PAYLATER_%d", time.Now().Unix()),
+			Response:      fmt.Sprintf("Approved on pay later, due in %d days", days),
+			Installments:  plan,
+		}, nil
+	}
+
+	return &services.PaymentResult{
+		Success:      false,
+		ErrorMessage: "Pay later credit check failed",
+	}, models.ErrPaymentProcessingFail
+}
+
+func (pl *PayLaterStrategy) ValidatePayment(metadata map[string]string) error {
+	if metadata["user_id"] == "" {
+		return fmt.Errorf("missing pay later account holder")
+	}
+	_, err := payLaterDays(metadata)
+	return err
+}
+
+func (pl *PayLaterStrategy) GetPaymentMethod() models.PaymentMethod {
+	return models.PaymentMethodPayLater
+}
+
+// payLaterDays reads the optional due_in_days repayment window
+func payLaterDays(metadata map[string]string) (int, error) {
+	raw := metadata["due_in_days"]
+	if raw == "" {
+		return payLaterDueDays[0], nil
+	}
+
+	days, err := strconv.Atoi(raw)
+	if err != nil || !slices.Contains(payLaterDueDays, days) {
+		return 0, fmt.Errorf("%w: pay later is due in 15 or 30 days", models.ErrInvalidPaymentData)
+	}
+	return days, nil
+}
@@ -0,0 +1,73 @@
+package strategies
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// vaultTokenPrefix marks tokens issued by SealedVault
+const vaultTokenPrefix = "tok_"
+
+// SealedVault implements services.Tokenizer without storing anything: each token is the card details sealed
+// with AES-GCM under the vault key. Tokens stay valid for as long as the key does, so the app keeps it with
+// its other secrets when the data is persisted. A real deployment swaps this for the payment provider's vault.
+type SealedVault struct {
+	aead cipher.AEAD
+}
+
+// NewSealedVault creates a vault sealing with a 16, 24 or 32 byte AES key
+func NewSealedVault(key []byte) (*SealedVault, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("payment vault: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("payment vault: %w", err)
+	}
+	return &SealedVault{aead: aead}, nil
+}
+
+// Tokenize seals the card details into a new token; tokenizing the same card twice gives different tokens
+func (v *SealedVault) Tokenize(ctx context.Context, card models.CardDetails) (string, error) {
+	plaintext, err := json.Marshal(card)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, v.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := v.aead.Seal(nonce, nonce, plaintext, nil)
+	return vaultTokenPrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Detokenize opens a token this vault sealed
+func (v *SealedVault) Detokenize(ctx context.Context, token string) (models.CardDetails, error) {
+	encoded, ok := strings.CutPrefix(token, vaultTokenPrefix)
+	if !ok {
+		return models.CardDetails{}, models.ErrInvalidInstrumentToken
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < v.aead.NonceSize() {
+		return models.CardDetails{}, models.ErrInvalidInstrumentToken
+	}
+
+	nonce, ciphertext := sealed[:v.aead.NonceSize()], sealed[v.aead.NonceSize():]
+	plaintext, err := v.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return models.CardDetails{}, models.ErrInvalidInstrumentToken
+	}
+	var card models.CardDetails
+	if err := json.Unmarshal(plaintext, &card); err != nil {
+		return models.CardDetails{}, models.ErrInvalidInstrumentToken
+	}
+	return card, nil
+}
@@ -0,0 +1,358 @@
+package strategies
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/metrics"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ResilienceConfig tunes the resilient gateway; the zero value of a field disables that mechanism
+type ResilienceConfig struct {
+	Timeout      time.Duration // Bounds each gateway call, retries included one by one
+	MaxRetries   int           // Extra charge attempts after a transient failure
+	RetryBackoff time.Duration // Delay before the first retry, doubled for each one after
+	FailureRate  float64       // Share (0-1] of recent calls failing transiently that opens a method's circuit
+	MinRequests  int           // Calls a method needs in the window before its failure rate is judged
+	Window       int           // How many recent calls per method the failure rate is computed over
+	OpenDuration time.Duration // How long an open circuit fails fast before letting a probe through
+}
+
+// DefaultResilienceConfig retries twice and opens a method's circuit when half of its last 20 calls fail
+func DefaultResilienceConfig() ResilienceConfig {
+	return ResilienceConfig{
+		Timeout:      15 * time.Second,
+		MaxRetries:   2,
+		RetryBackoff: 200 * time.Millisecond,
+		FailureRate:  0.5,
+		MinRequests:  10,
+		Window:       20,
+		OpenDuration: 30 * time.Second,
+	}
+}
+
+// ResilienceConfigFromEnv reads PAYMENT_TIMEOUT, PAYMENT_MAX_RETRIES, PAYMENT_RETRY_BACKOFF,
+// PAYMENT_CIRCUIT_FAILURE_RATE and PAYMENT_CIRCUIT_OPEN_DURATION, defaulting to DefaultResilienceConfig
+func ResilienceConfigFromEnv() ResilienceConfig {
+	cfg := DefaultResilienceConfig()
+	if timeout, err := time.ParseDuration(os.Getenv("PAYMENT_TIMEOUT")); err == nil && timeout >= 0 {
+		cfg.Timeout = timeout
+	}
+	if retries, err := strconv.Atoi(os.Getenv("PAYMENT_MAX_RETRIES")); err == nil && retries >= 0 {
+		cfg.MaxRetries = retries
+	}
+	if backoff, err := time.ParseDuration(os.Getenv("PAYMENT_RETRY_BACKOFF")); err == nil && backoff >= 0 {
+		cfg.RetryBackoff = backoff
+	}
+	if rate, err := strconv.ParseFloat(os.Getenv("PAYMENT_CIRCUIT_FAILURE_RATE"), 64); err == nil && rate >= 0 && rate <= 1 {
+		cfg.FailureRate = rate
+	}
+	if open, err := time.ParseDuration(os.Getenv("PAYMENT_CIRCUIT_OPEN_DURATION")); err == nil && open >= 0 {
+		cfg.OpenDuration = open
+	}
+	return cfg
+}
+
+// ResilientPaymentGateway wraps a PaymentGateway with timeouts, retries and a circuit breaker per method
+// - demonstrates Decorator Pattern: the payment and refund services can't tell it from the gateway it wraps.
+// Only charges are retried: they carry the payment ID as an idempotency key, refunds don't.
+type ResilientPaymentGateway struct {
+	gateway  services.PaymentGateway
+	config   ResilienceConfig
+	clock    clock.Clock
+	metrics  metrics.Recorder
+	circuits map[models.PaymentMethod]*circuitBreaker
+	mutex    sync.Mutex
+}
+
+// NewResilientPaymentGateway decorates gateway with the given timeouts, retries and circuit breakers
+func NewResilientPaymentGateway(gateway services.PaymentGateway, config ResilienceConfig, clk clock.Clock, recorder metrics.Recorder) *ResilientPaymentGateway {
+	return &ResilientPaymentGateway{
+		gateway:  gateway,
+		config:   config,
+		clock:    clk,
+		metrics:  recorder,
+		circuits: make(map[models.PaymentMethod]*circuitBreaker),
+	}
+}
+
+// ProcessPayment charges through the wrapped gateway, retrying outages and timeouts with exponential backoff.
+// Once an attempt has timed out, the charge may have gone through: unless a later attempt gets an answer, the
+// result is models.ErrPaymentOutcomeUnknown rather than whatever stopped the retries.
+func (rg *ResilientPaymentGateway) ProcessPayment(ctx context.Context, amount models.Money, method models.PaymentMethod, metadata map[string]string) (*services.PaymentResult, error) {
+	var result *services.PaymentResult
+	var err, uncertain error
+	for attempt := 0; attempt <= rg.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			rg.metrics.PaymentRetry(method)
+			if waitErr := rg.backoff(ctx, attempt); waitErr != nil {
+				return nil, cmp.Or(uncertain, waitErr)
+			}
+		}
+
+		result, err = rg.call(ctx, method, func(ctx context.Context) (*services.PaymentResult, error) {
+			return rg.gateway.ProcessPayment(ctx, amount, method, metadata)
+		})
+		if errors.Is(err, models.ErrPaymentOutcomeUnknown) {
+			uncertain = err
+		}
+		if !isTransient(err) {
+			break
+		}
+	}
+	if err != nil && result == nil && uncertain != nil {
+		return nil, uncertain
+	}
+	return result, err
+}
+
+// RefundPayment refunds through the wrapped gateway once, within the timeout and circuit breaker
+func (rg *ResilientPaymentGateway) RefundPayment(ctx context.Context, transactionID string, amount models.Money, method models.PaymentMethod) (*services.PaymentResult, error) {
+	return rg.call(ctx, method, func(ctx context.Context) (*services.PaymentResult, error) {
+		return rg.gateway.RefundPayment(ctx, transactionID, amount, method)
+	})
+}
+
+// QueryTransaction asks the wrapped gateway once, within the timeout and circuit breaker; the reconciliation
+// worker asks again on its next run
+func (rg *ResilientPaymentGateway) QueryTransaction(ctx context.Context, paymentID string, method models.PaymentMethod) (*services.PaymentResult, error) {
+	return rg.call(ctx, method, func(ctx context.Context) (*services.PaymentResult, error) {
+		return rg.gateway.QueryTransaction(ctx, paymentID, method)
+	})
+}
+
+// CompleteChallenge finishes a challenged charge once, within the timeout and circuit breaker. It isn't retried:
+// the issuer forgets the challenge once it is answered, so a retry couldn't tell how the charge went.
+func (rg *ResilientPaymentGateway) CompleteChallenge(ctx context.Context, token, otp string, method models.PaymentMethod) (*services.PaymentResult, error) {
+	return rg.call(ctx, method, func(ctx context.Context) (*services.PaymentResult, error) {
+		return rg.gateway.CompleteChallenge(ctx, token, otp, method)
+	})
+}
+
+// OpenCircuits lists the methods currently failing fast, for health checks
+func (rg *ResilientPaymentGateway) OpenCircuits() []models.PaymentMethod {
+	rg.mutex.Lock()
+	defer rg.mutex.Unlock()
+
+	var open []models.PaymentMethod
+	for method, circuit := range rg.circuits {
+		if circuit.isOpen() {
+			open = append(open, method)
+		}
+	}
+	return open
+}
+
+// call runs one gateway call through the method's circuit and the per-call timeout
+func (rg *ResilientPaymentGateway) call(ctx context.Context, method models.PaymentMethod, fn func(context.Context) (*services.PaymentResult, error)) (*services.PaymentResult, error) {
+	circuit := rg.circuit(method)
+	if !circuit.allow(rg.clock.Now()) {
+		rg.metrics.PaymentRejected(method)
+		return nil, fmt.Errorf("%w: %s", models.ErrPaymentCircuitOpen, method)
+	}
+
+	callCtx := ctx
+	if rg.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, rg.config.Timeout)
+		defer cancel()
+	}
+
+	result, err := fn(callCtx)
+
+	// The caller giving up is not the gateway's fault; our own deadline is, and leaves the outcome unknown
+	if err != nil && ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		rg.metrics.PaymentTimeout(method)
+		err = fmt.Errorf("%w: %s timed out after %s", models.ErrPaymentOutcomeUnknown, method, rg.config.Timeout)
+	}
+
+	outcome := outcomeSuccess
+	if isTransient(err) {
+		outcome = outcomeFailure
+	} else if err != nil && ctx.Err() != nil {
+		outcome = outcomeIgnored
+	}
+	if circuit.record(outcome, rg.clock.Now()) {
+		rg.metrics.PaymentCircuitChanged(method, circuit.isOpen())
+	}
+
+	return result, err
+}
+
+// circuit returns the method's breaker, creating it on first use
+func (rg *ResilientPaymentGateway) circuit(method models.PaymentMethod) *circuitBreaker {
+	rg.mutex.Lock()
+	defer rg.mutex.Unlock()
+
+	circuit, exists := rg.circuits[method]
+	if !exists {
+		circuit = newCircuitBreaker(rg.config)
+		rg.circuits[method] = circuit
+	}
+	return circuit
+}
+
+// backoff waits RetryBackoff doubled per attempt, plus up to half again as jitter so retries don't stampede
+func (rg *ResilientPaymentGateway) backoff(ctx context.Context, attempt int) error {
+	delay := rg.config.RetryBackoff << (attempt - 1)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isTransient reports failures worth retrying: outages and timeouts, never declines, bad payment data or an open circuit
+func isTransient(err error) bool {
+	return errors.Is(err, models.ErrPaymentGatewayError)
+}
+
+// CircuitState is where a method's circuit breaker stands
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "CLOSED"    // Calls go through; failures are counted
+	CircuitOpen     CircuitState = "OPEN"      // Calls fail fast until OpenDuration passes
+	CircuitHalfOpen CircuitState = "HALF_OPEN" // One probe decides whether to close or reopen
+)
+
+// callOutcome is what a finished call tells the breaker
+type callOutcome int
+
+const (
+	outcomeSuccess callOutcome = iota // The gateway answered, even with a decline
+	outcomeFailure                    // Outage or timeout
+	outcomeIgnored                    // The caller cancelled - says nothing about the gateway
+)
+
+// circuitBreaker tracks one method's recent outcomes in a fixed-size ring - demonstrates State Pattern
+type circuitBreaker struct {
+	failureRate  float64
+	minRequests  int
+	openDuration time.Duration
+
+	state    CircuitState
+	outcomes []bool // Ring of recent calls; true is a failure
+	next     int
+	count    int
+	failures int
+	openedAt time.Time
+	probing  bool // A half-open probe is in flight
+	mutex    sync.Mutex
+}
+
+// newCircuitBreaker creates a closed breaker; without a window or failure rate it never opens
+func newCircuitBreaker(config ResilienceConfig) *circuitBreaker {
+	window := max(config.Window, 0)
+	return &circuitBreaker{
+		failureRate:  config.FailureRate,
+		minRequests:  max(config.MinRequests, 1),
+		openDuration: config.OpenDuration,
+		state:        CircuitClosed,
+		outcomes:     make([]bool, window),
+	}
+}
+
+// allow decides whether a call may go ahead, moving an open circuit to half-open once it has cooled down
+func (cb *circuitBreaker) allow(now time.Time) bool {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if now.Sub(cb.openedAt) < cb.openDuration {
+			return false
+		}
+		cb.state = CircuitHalfOpen
+		cb.probing = true
+		return true
+	case CircuitHalfOpen:
+		if cb.probing {
+			return false
+		}
+		cb.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record counts a finished call and reports whether it opened or closed the circuit
+func (cb *circuitBreaker) record(outcome callOutcome, now time.Time) bool {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.state == CircuitHalfOpen {
+		cb.probing = false
+		switch outcome {
+		case outcomeFailure:
+			cb.trip(now)
+			return true
+		case outcomeSuccess:
+			cb.reset()
+			return true
+		}
+		return false
+	}
+
+	if outcome == outcomeIgnored || len(cb.outcomes) == 0 || cb.failureRate <= 0 {
+		return false
+	}
+
+	failed := outcome == outcomeFailure
+	if cb.count == len(cb.outcomes) {
+		if cb.outcomes[cb.next] {
+			cb.failures--
+		}
+	} else {
+		cb.count++
+	}
+	cb.outcomes[cb.next] = failed
+	cb.next = (cb.next + 1) % len(cb.outcomes)
+	if failed {
+		cb.failures++
+	}
+
+	if cb.count >= cb.minRequests && float64(cb.failures)/float64(cb.count) >= cb.failureRate {
+		cb.trip(now)
+		return true
+	}
+	return false
+}
+
+// isOpen reports whether calls are being refused; half-open counts until its probe succeeds
+func (cb *circuitBreaker) isOpen() bool {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	return cb.state != CircuitClosed
+}
+
+// trip opens the circuit
+func (cb *circuitBreaker) trip(now time.Time) {
+	cb.state = CircuitOpen
+	cb.openedAt = now
+}
+
+// reset closes the circuit with a clean window
+func (cb *circuitBreaker) reset() {
+	cb.state = CircuitClosed
+	cb.next, cb.count, cb.failures = 0, 0, 0
+	clear(cb.outcomes)
+}
@@ -0,0 +1,87 @@
+package testkit
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ledgerGateway approves every charge and refund and books each against the booking it was for, so the
+// invariant checker can compare the money that moved with the bookings left behind - demonstrates
+// Strategy Pattern (a test double)
+type ledgerGateway struct {
+	mutex     sync.Mutex
+	next      int
+	bookingOf map[string]string       // Transaction ID to booking ID
+	charged   map[string]string       // Payment ID to transaction ID
+	net       map[string]models.Money // Charged minus refunded, by booking ID
+}
+
+func newLedgerGateway() *ledgerGateway {
+	return &ledgerGateway{bookingOf: make(map[string]string), charged: make(map[string]string), net: make(map[string]models.Money)}
+}
+
+func (g *ledgerGateway) ProcessPayment(ctx context.Context, amount models.Money, method models.PaymentMethod, metadata map[string]string) (*services.PaymentResult, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.next++
+	transactionID := fmt.Sprintf("TESTKIT_%d", g.next)
+	bookingID := metadata["booking_id"]
+	g.bookingOf[transactionID] = bookingID
+	g.charged[metadata["payment_id"]] = transactionID
+	g.net[bookingID] = g.balance(bookingID, amount).Add(amount)
+	return &services.PaymentResult{
+		Success:       true,
+		TransactionID: transactionID,
+		Response:      fmt.Sprintf("Paid %s via %s", amount, method),
+	}, nil
+}
+
+func (g *ledgerGateway) RefundPayment(ctx context.Context, transactionID string, amount models.Money, method models.PaymentMethod) (*services.PaymentResult, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	bookingID, ok := g.bookingOf[transactionID]
+	if !ok {
+		return &services.PaymentResult{Success: false, ErrorMessage: "unknown transaction " + transactionID}, nil
+	}
+	g.net[bookingID] = g.balance(bookingID, amount).Sub(amount)
+	return &services.PaymentResult{
+		Success:       true,
+		TransactionID: "REFUND_" + transactionID,
+		Response:      fmt.Sprintf("Refunded %s", amount),
+	}, nil
+}
+
+func (g *ledgerGateway) QueryTransaction(ctx context.Context, paymentID string, method models.PaymentMethod) (*services.PaymentResult, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	transactionID, ok := g.charged[paymentID]
+	if !ok {
+		return &services.PaymentResult{Success: false, ErrorMessage: "no charge for payment " + paymentID}, nil
+	}
+	return &services.PaymentResult{Success: true, TransactionID: transactionID, Response: "Charge found"}, nil
+}
+
+func (g *ledgerGateway) CompleteChallenge(ctx context.Context, token, otp string, method models.PaymentMethod) (*services.PaymentResult, error) {
+	return nil, models.ErrNoPaymentChallenge
+}
+
+// netFor returns what the gateway kept for a booking: every charge less every refund
+func (g *ledgerGateway) netFor(bookingID string, currency string) models.Money {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.balance(bookingID, models.ZeroMoney(currency))
+}
+
+// balance is the booking's net so far, zero in like's currency before its first charge. Callers must hold mutex.
+func (g *ledgerGateway) balance(bookingID string, like models.Money) models.Money {
+	if net, ok := g.net[bookingID]; ok {
+		return net
+	}
+	return models.ZeroMoney(like.Currency)
+}
@@ -0,0 +1,427 @@
+package testkit
+
+import (
+	"bookmyshow-lld/internal/controllers"
+	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/logging"
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Config sizes a race check
+type Config struct {
+	Workers     int   // Goroutines released at once, each a different user working its own holds and bookings
+	Operations  int   // Random operations per worker
+	MaxSeats    int   // Each hold or booking takes 1..MaxSeats adjacent seats
+	ContestRows int   // Workers only pick from the first seats of this many rows, so their picks collide
+	Seed        int64 // Worker i draws its operations from Seed+i; the interleaving still differs run to run
+}
+
+// DefaultConfig has 32 workers run 100 operations each against the four front rows of one show
+func DefaultConfig() Config {
+	return Config{Workers: 32, Operations: 100, MaxSeats: 4, ContestRows: 4, Seed: 1}
+}
+
+// Operation is one kind of step a worker takes
+type Operation string
+
+const (
+	OpHold        Operation = "hold"         // Block seats with a seat hold
+	OpReleaseHold Operation = "release-hold" // Hand one of the worker's holds back
+	OpBook        Operation = "book"         // Book seats directly
+	OpBookHold    Operation = "book-hold"    // Book the seats of one of the worker's holds
+	OpPay         Operation = "pay"          // Pay for and confirm a pending booking
+	OpCancel      Operation = "cancel"       // Cancel a pending or confirmed booking
+)
+
+// Operations lists every operation in report order
+var Operations = []Operation{OpHold, OpReleaseHold, OpBook, OpBookHold, OpPay, OpCancel}
+
+// OperationCounts is how the attempts of one operation ended
+type OperationCounts struct {
+	Succeeded int
+	Conflicts int // Another worker got a seat first
+	Refused   int // A booking limit said no, e.g. too many unpaid bookings
+	Failed    int // Anything else - should be zero
+}
+
+// Result is what a race check did and what the invariant checker found afterwards
+type Result struct {
+	Config     Config
+	Counts     map[Operation]*OperationCounts
+	Failures   []string // The first few unexpected errors
+	Contested  int      // Seats the workers picked from
+	Confirmed  int      // Bookings confirmed at the end
+	SeatsSold  int
+	Revenue    models.Money // Kept by the gateway, cancellation fees included
+	Violations []Violation
+	Elapsed    time.Duration
+}
+
+// OK reports whether every invariant held and no operation failed unexpectedly
+func (r *Result) OK() bool {
+	return len(r.Violations) == 0 && r.failed() == 0
+}
+
+func (r *Result) failed() int {
+	failed := 0
+	for _, counts := range r.Counts {
+		failed += counts.Failed
+	}
+	return failed
+}
+
+// maxFailures caps the unexpected errors a result keeps
+const maxFailures = 5
+
+// Harness drives randomized concurrent holds, bookings, payments and cancellations against one show of a
+// fully wired in-memory app, remembering every hold and booking it makes so Check can audit them.
+// Tests build one with NewHarness, call Run and assert on Result.Violations; RunRaceCheck prints a report.
+type Harness struct {
+	cfg     Config
+	app     *controllers.AppController
+	gateway *ledgerGateway
+	show    *models.Show
+	contest [][]string
+	userIDs []string
+
+	mutex      sync.Mutex
+	holdIDs    []string
+	bookingIDs []string
+}
+
+// NewHarness wires an app whose gateway approves every payment and creates a show on the default screen.
+// Options are applied after the harness's own, e.g. WithSQLite to race against the SQLite store.
+func NewHarness(ctx context.Context, cfg Config, opts ...controllers.Option) (*Harness, error) {
+	defaults := DefaultConfig()
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaults.Workers
+	}
+	if cfg.Operations <= 0 {
+		cfg.Operations = defaults.Operations
+	}
+	cfg.MaxSeats = max(cfg.MaxSeats, 1)
+	cfg.ContestRows = max(cfg.ContestRows, 1)
+
+	gateway := newLedgerGateway()
+	app := controllers.NewAppController(append([]controllers.Option{
+		controllers.WithPaymentGateway(gateway),
+		controllers.WithLogger(logging.Nop()),
+	}, opts...)...)
+
+	h := &Harness{cfg: cfg, app: app, gateway: gateway}
+	if err := h.setUp(ctx); err != nil {
+		app.Shutdown()
+		return nil, err
+	}
+	return h, nil
+}
+
+// App returns the app under test, for checks of a test's own
+func (h *Harness) App() *controllers.AppController {
+	return h.app
+}
+
+// Show returns the show the workers race over
+func (h *Harness) Show() *models.Show {
+	return h.show
+}
+
+// Close shuts the app down
+func (h *Harness) Close() {
+	h.app.Shutdown()
+}
+
+// setUp creates the show and one user per worker, and starts the show's availability counters so the
+// workers' events keep them up to date rather than a single count at the end
+func (h *Harness) setUp(ctx context.Context) error {
+	admin, err := h.app.GetUserService().CreateUserWithRole(ctx, "Testkit Admin", "admin@testkit.test", "+17770000000", models.UserRoleSuperAdmin)
+	if err != nil {
+		return err
+	}
+	adminCtx := services.WithCaller(ctx, admin.ID)
+
+	movie, err := h.app.GetMovieService().CreateMovie(adminCtx, "Testkit Movie", "Race check fixture", 2*time.Hour, models.GenreAction, models.LanguageEnglish, 8.0, h.app.GetClock().Now())
+	if err != nil {
+		return err
+	}
+	theatre, err := h.app.GetAdminService().OnboardTheatre(ctx, admin.ID, "Testkit Theatre", "1 Race Street", "Mumbai")
+	if err != nil {
+		return err
+	}
+	basePrice := models.NewMoney(10000, models.DefaultCurrency)
+	screen, err := h.app.GetAdminService().AddScreen(ctx, admin.ID, theatre.ID, "Screen 1", factories.DefaultScreenConfig(), basePrice)
+	if err != nil {
+		return err
+	}
+	h.show, err = h.app.GetShowService().CreateShow(adminCtx, movie.ID, theatre.ID, screen.ID, h.app.GetClock().Now().Add(7*24*time.Hour), basePrice)
+	if err != nil {
+		return err
+	}
+
+	seatMap, err := h.app.GetShowService().GetSeatAvailability(ctx, h.show.ID)
+	if err != nil {
+		return err
+	}
+	for _, row := range seatMap.Rows[:min(h.cfg.ContestRows, len(seatMap.Rows))] {
+		var seatIDs []string
+		for _, cell := range row.Cells {
+			if cell.IsSeat() {
+				seatIDs = append(seatIDs, cell.SeatID)
+			}
+		}
+		h.contest = append(h.contest, seatIDs)
+	}
+	if _, err := h.app.GetShowService().GetAvailabilitySummary(ctx, h.show.ID); err != nil {
+		return err
+	}
+
+	h.userIDs = make([]string, h.cfg.Workers)
+	for i := range h.userIDs {
+		user, err := h.app.GetUserService().CreateUser(ctx, fmt.Sprintf("Worker %d", i+1), fmt.Sprintf("worker%d@testkit.test", i+1), fmt.Sprintf("+1777%07d", i+1))
+		if err != nil {
+			return err
+		}
+		h.userIDs[i] = user.ID
+	}
+	return nil
+}
+
+// Run releases every worker at the same instant, waits for them, then checks the invariants
+func (h *Harness) Run(ctx context.Context) (*Result, error) {
+	result := &Result{Config: h.cfg, Counts: make(map[Operation]*OperationCounts)}
+	for _, op := range Operations {
+		result.Counts[op] = &OperationCounts{}
+	}
+	for _, row := range h.contest {
+		result.Contested += len(row)
+	}
+
+	start := make(chan struct{})
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+	)
+	for i, userID := range h.userIDs {
+		wg.Add(1)
+		go func(i int, userID string) {
+			defer wg.Done()
+			w := &worker{h: h, userID: userID, rng: rand.New(rand.NewSource(h.cfg.Seed + int64(i))), counts: make(map[Operation]*OperationCounts)}
+			<-start
+			for range h.cfg.Operations {
+				w.step(ctx)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			for op, counts := range w.counts {
+				total := result.Counts[op]
+				total.Succeeded += counts.Succeeded
+				total.Conflicts += counts.Conflicts
+				total.Refused += counts.Refused
+				total.Failed += counts.Failed
+			}
+			for _, failure := range w.failures {
+				if len(result.Failures) < maxFailures {
+					result.Failures = append(result.Failures, failure)
+				}
+			}
+		}(i, userID)
+	}
+
+	began := time.Now()
+	close(start)
+	wg.Wait()
+	result.Elapsed = time.Since(began)
+
+	audit, err := h.audit(ctx)
+	if err != nil {
+		return result, err
+	}
+	result.Violations = audit.violations
+	result.Confirmed, result.SeatsSold, result.Revenue = audit.confirmed, audit.seatsSold, audit.revenue
+	return result, nil
+}
+
+// Check audits the show as it stands, e.g. after a test's own extra steps
+func (h *Harness) Check(ctx context.Context) ([]Violation, error) {
+	audit, err := h.audit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return audit.violations, nil
+}
+
+func (h *Harness) trackHold(holdID string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.holdIDs = append(h.holdIDs, holdID)
+}
+
+func (h *Harness) trackBooking(bookingID string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.bookingIDs = append(h.bookingIDs, bookingID)
+}
+
+func (h *Harness) tracked() (holdIDs, bookingIDs []string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return slices.Clone(h.holdIDs), slices.Clone(h.bookingIDs)
+}
+
+// worker is one user picking random operations on their own holds and bookings; only the seats are shared
+type worker struct {
+	h        *Harness
+	userID   string
+	rng      *rand.Rand
+	holds    []*models.SeatHold
+	pending  []*models.Booking
+	booked   []*models.Booking
+	counts   map[Operation]*OperationCounts
+	failures []string
+}
+
+// operationWeights makes new holds and bookings, which fight over seats, the most common steps
+var operationWeights = map[Operation]int{OpHold: 3, OpReleaseHold: 1, OpBook: 3, OpBookHold: 2, OpPay: 3, OpCancel: 2}
+
+// step runs one operation chosen at random from those the worker's state allows
+func (w *worker) step(ctx context.Context) {
+	var choices []Operation
+	for _, op := range Operations {
+		switch {
+		case (op == OpReleaseHold || op == OpBookHold) && len(w.holds) == 0,
+			op == OpPay && len(w.pending) == 0,
+			op == OpCancel && len(w.pending)+len(w.booked) == 0:
+			continue
+		}
+		for range operationWeights[op] {
+			choices = append(choices, op)
+		}
+	}
+	op := choices[w.rng.Intn(len(choices))]
+
+	var err error
+	switch op {
+	case OpHold:
+		err = w.hold(ctx)
+	case OpReleaseHold:
+		err = w.releaseHold(ctx)
+	case OpBook:
+		err = w.book(ctx, w.pick(), "")
+	case OpBookHold:
+		hold := w.takeHold()
+		err = w.book(ctx, hold.SeatIDs, hold.ID)
+	case OpPay:
+		err = w.pay(ctx)
+	case OpCancel:
+		err = w.cancel(ctx)
+	}
+	w.record(op, err)
+}
+
+func (w *worker) hold(ctx context.Context) error {
+	hold, err := w.h.app.GetSeatHoldService().CreateHold(ctx, w.userID, w.h.show.ID, w.pick())
+	if err != nil {
+		return err
+	}
+	w.h.trackHold(hold.ID)
+	w.holds = append(w.holds, hold)
+	return nil
+}
+
+func (w *worker) releaseHold(ctx context.Context) error {
+	hold := w.takeHold()
+	return w.h.app.GetSeatHoldService().ReleaseHold(ctx, hold.ID, w.userID)
+}
+
+// book creates a pending booking, from a hold when holdID is set
+func (w *worker) book(ctx context.Context, seatIDs []string, holdID string) error {
+	var opts []services.BookingOption
+	if holdID != "" {
+		opts = append(opts, services.WithHold(holdID))
+	}
+	booking, err := w.h.app.GetBookingService().CreateBooking(ctx, w.userID, w.h.show.ID, seatIDs, opts...)
+	if err != nil {
+		return err
+	}
+	w.h.trackBooking(booking.ID)
+	w.pending = append(w.pending, booking)
+	return nil
+}
+
+// pay charges a pending booking and confirms it, as the payment webhook would
+func (w *worker) pay(ctx context.Context) error {
+	booking := take(w.rng, &w.pending)
+	payment, err := w.h.app.GetPaymentService().ProcessPayment(ctx, booking.ID, models.PaymentMethodUPI)
+	if err != nil {
+		return err
+	}
+	if !payment.IsSuccessful() {
+		return fmt.Errorf("%w: %s", models.ErrPaymentProcessingFail, payment.FailureReason)
+	}
+	if err := w.h.app.GetBookingService().ConfirmBooking(ctx, booking.ID, payment.ID); err != nil {
+		return err
+	}
+	w.booked = append(w.booked, booking)
+	return nil
+}
+
+// cancel cancels a pending or confirmed booking, chosen evenly over both
+func (w *worker) cancel(ctx context.Context) error {
+	var booking *models.Booking
+	if w.rng.Intn(len(w.pending)+len(w.booked)) < len(w.pending) {
+		booking = take(w.rng, &w.pending)
+	} else {
+		booking = take(w.rng, &w.booked)
+	}
+	return w.h.app.GetBookingService().CancelBooking(ctx, booking.ID)
+}
+
+// takeHold removes a random hold from the worker's list; it is used or released either way
+func (w *worker) takeHold() *models.SeatHold {
+	return take(w.rng, &w.holds)
+}
+
+// pick chooses 1..MaxSeats neighbouring seats from a random contested row
+func (w *worker) pick() []string {
+	row := w.h.contest[w.rng.Intn(len(w.h.contest))]
+	n := min(1+w.rng.Intn(w.h.cfg.MaxSeats), len(row))
+	first := w.rng.Intn(len(row) - n + 1)
+	return slices.Clone(row[first : first+n])
+}
+
+// record sorts an operation's outcome into succeeded, conflict, refused or failed
+func (w *worker) record(op Operation, err error) {
+	counts, ok := w.counts[op]
+	if !ok {
+		counts = &OperationCounts{}
+		w.counts[op] = counts
+	}
+	switch {
+	case err == nil:
+		counts.Succeeded++
+	case errors.Is(err, models.ErrSeatNotAvailable), errors.Is(err, models.ErrSeatAlreadyBooked):
+		counts.Conflicts++
+	case errors.Is(err, models.ErrTooManyPendingBookings), errors.Is(err, models.ErrShowTicketLimitReached):
+		counts.Refused++
+	default:
+		counts.Failed++
+		w.failures = append(w.failures, fmt.Sprintf("%s: %v", op, err))
+	}
+}
+
+// take removes and returns a random element of the list
+func take[T any](rng *rand.Rand, list *[]T) T {
+	i := rng.Intn(len(*list))
+	item := (*list)[i]
+	*list = slices.Delete(*list, i, i+1)
+	return item
+}
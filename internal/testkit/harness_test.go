@@ -0,0 +1,179 @@
+package testkit
+
+import (
+	"bookmyshow-lld/internal/clock"
+	"bookmyshow-lld/internal/controllers"
+	"bookmyshow-lld/internal/models"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard) // Every booking logs its events
+	os.Exit(m.Run())
+}
+
+// newTestHarness is a smaller race than the -racecheck default, still enough workers to collide on the front rows
+func newTestHarness(t *testing.T, ctx context.Context) *Harness {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.Workers, cfg.Operations = 16, 40
+
+	h, err := NewHarness(ctx, cfg)
+	if err != nil {
+		t.Fatalf("NewHarness: %v", err)
+	}
+	t.Cleanup(h.Close)
+	return h
+}
+
+func TestRunKeepsInvariants(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	h := newTestHarness(t, ctx)
+
+	result, err := h.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	for _, violation := range result.Violations {
+		t.Errorf("violation %s", violation)
+	}
+	for _, failure := range result.Failures {
+		t.Errorf("unexpected error: %s", failure)
+	}
+	if !result.OK() {
+		t.Fatalf("result not OK after %d violations", len(result.Violations))
+	}
+
+	// A race where nobody got a seat proves nothing
+	booked := result.Counts[OpBook].Succeeded + result.Counts[OpBookHold].Succeeded
+	if booked == 0 || result.Counts[OpPay].Succeeded == 0 {
+		t.Fatalf("workers booked %d and paid %d times, want both above zero", booked, result.Counts[OpPay].Succeeded)
+	}
+	if result.Confirmed == 0 || result.SeatsSold < result.Confirmed {
+		t.Errorf("%d bookings confirmed for %d seats sold", result.Confirmed, result.SeatsSold)
+	}
+	if result.Revenue.IsZero() {
+		t.Errorf("gateway kept nothing for %d confirmed bookings", result.Confirmed)
+	}
+}
+
+func TestCheckReportsDoubleBooking(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	h := newTestHarness(t, ctx)
+	if _, err := h.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	seatMap, err := h.App().GetShowService().GetSeatAvailability(ctx, h.Show().ID)
+	if err != nil {
+		t.Fatalf("GetSeatAvailability: %v", err)
+	}
+	var seatID string
+	for _, cell := range seats(seatMap) {
+		if cell.Status == models.SeatStatusAvailable {
+			seatID = cell.SeatID
+			break
+		}
+	}
+	if seatID == "" {
+		t.Fatal("no seat left available")
+	}
+
+	user, err := h.App().GetUserService().CreateUser(ctx, "Late User", "late@testkit.test", "+17779999999")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	booking, err := h.App().GetBookingService().CreateBooking(ctx, user.ID, h.Show().ID, []string{seatID})
+	if err != nil {
+		t.Fatalf("CreateBooking: %v", err)
+	}
+	// Tracking the booking twice is how the checker sees two live bookings of one seat
+	h.trackBooking(booking.ID)
+	h.trackBooking(booking.ID)
+
+	violations, err := h.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	found := false
+	for _, violation := range violations {
+		if violation.Invariant == InvariantNoDoubleBooking {
+			found = true
+		} else {
+			t.Errorf("unexpected violation %s", violation)
+		}
+	}
+	if !found {
+		t.Errorf("Check missed the double booking of %s, found %v", seatID, violations)
+	}
+}
+
+func TestFakeClockExpiresHoldsAndBookings(t *testing.T) {
+	t.Parallel() // Each controller keeps its own clock, so advancing this one leaves the other harnesses alone
+	ctx := context.Background()
+	fake := clock.NewFake(time.Now())
+	h, err := NewHarness(ctx, Config{Workers: 1, Operations: 1}, controllers.WithClock(fake))
+	if err != nil {
+		t.Fatalf("NewHarness: %v", err)
+	}
+	t.Cleanup(h.Close)
+	app := h.App()
+
+	seatMap, err := app.GetShowService().GetSeatAvailability(ctx, h.Show().ID)
+	if err != nil {
+		t.Fatalf("GetSeatAvailability: %v", err)
+	}
+	cells := seats(seatMap)
+	user, err := app.GetUserService().CreateUser(ctx, "Slow User", "slow@testkit.test", "+17778888888")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	hold, err := app.GetSeatHoldService().CreateHold(ctx, user.ID, h.Show().ID, []string{cells[0].SeatID})
+	if err != nil {
+		t.Fatalf("CreateHold: %v", err)
+	}
+	booking, err := app.GetBookingService().CreateBooking(ctx, user.ID, h.Show().ID, []string{cells[1].SeatID})
+	if err != nil {
+		t.Fatalf("CreateBooking: %v", err)
+	}
+
+	if released, err := app.GetSeatHoldService().ReleaseExpiredHolds(ctx); err != nil || released != 0 {
+		t.Fatalf("ReleaseExpiredHolds before the hold lapsed released %d, %v", released, err)
+	}
+
+	// Past the longest hold and payment window the platform can be configured with
+	fake.Advance(models.MaxBookingTimeout + time.Minute)
+
+	if released, err := app.GetSeatHoldService().ReleaseExpiredHolds(ctx); err != nil || released != 1 {
+		t.Fatalf("ReleaseExpiredHolds released %d, %v; want the one lapsed hold", released, err)
+	}
+	hold, err = app.GetSeatHoldService().GetHold(ctx, hold.ID)
+	if err != nil {
+		t.Fatalf("GetHold: %v", err)
+	}
+	if hold.GetStatus() != models.SeatHoldStatusExpired {
+		t.Errorf("hold is %s, want %s", hold.GetStatus(), models.SeatHoldStatusExpired)
+	}
+
+	if _, err := app.GetPaymentService().ProcessPayment(ctx, booking.ID, models.PaymentMethodUPI); !errors.Is(err, models.ErrBookingExpired) {
+		t.Errorf("ProcessPayment of a lapsed booking: %v, want %v", err, models.ErrBookingExpired)
+	}
+
+	seatMap, err = app.GetShowService().GetSeatAvailability(ctx, h.Show().ID)
+	if err != nil {
+		t.Fatalf("GetSeatAvailability: %v", err)
+	}
+	for _, cell := range seats(seatMap) {
+		if cell.SeatID == cells[0].SeatID && cell.Status != models.SeatStatusAvailable {
+			t.Errorf("seat %s of the expired hold is %s, want it back on sale", cell.SeatID, cell.Status)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package testkit
+
+import (
+	"bookmyshow-lld/internal/models"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Invariants the checker asserts
+const (
+	InvariantNoDoubleBooking = "no-double-booking" // A seat belongs to at most one active hold or live booking
+	InvariantSeatMap         = "seat-map"          // The show's seat map shows exactly what the holds and bookings claim
+	InvariantCounters        = "counters"          // The event-maintained availability counters match a fresh count
+	InvariantRevenue         = "revenue"           // Money kept matches the bookings, and confirmed bookings the seats sold
+)
+
+// Invariants lists every invariant in report order
+var Invariants = []string{InvariantNoDoubleBooking, InvariantSeatMap, InvariantCounters, InvariantRevenue}
+
+// Violation is one broken invariant
+type Violation struct {
+	Invariant string
+	Detail    string
+}
+
+func (v Violation) String() string {
+	return v.Invariant + ": " + v.Detail
+}
+
+// audit is what the checker found
+type audit struct {
+	violations []Violation
+	confirmed  int
+	seatsSold  int
+	revenue    models.Money
+}
+
+func (a *audit) violate(invariant, format string, args ...any) {
+	a.violations = append(a.violations, Violation{Invariant: invariant, Detail: fmt.Sprintf(format, args...)})
+}
+
+// audit reloads every hold and booking the workers made and checks each invariant against the show
+func (h *Harness) audit(ctx context.Context) (*audit, error) {
+	holdIDs, bookingIDs := h.tracked()
+	a := &audit{revenue: models.ZeroMoney(h.show.BasePrice.Currency)}
+	now := h.app.GetClock().Now()
+
+	// Who claims each seat: active holds block it, pending bookings block it, confirmed bookings sell it
+	claims := make(map[string][]string)
+	want := make(map[string]models.SeatStatus)
+	lapsed := make(map[string]bool) // Seats of lapsed holds and bookings stay blocked until the expiry sweep frees them
+	for _, holdID := range holdIDs {
+		hold, err := h.app.GetSeatHoldService().GetHold(ctx, holdID)
+		if err != nil {
+			return nil, err
+		}
+		if hold.GetStatus() != models.SeatHoldStatusActive {
+			continue
+		}
+		if hold.IsExpired(now) {
+			markSeats(lapsed, hold.SeatIDs)
+			continue
+		}
+		for _, seatID := range hold.SeatIDs {
+			claims[seatID] = append(claims[seatID], "hold "+hold.ID)
+			want[seatID] = models.SeatStatusBlocked
+		}
+	}
+
+	bookings := make([]*models.Booking, 0, len(bookingIDs))
+	for _, bookingID := range bookingIDs {
+		booking, err := h.app.GetBookingService().GetBooking(ctx, bookingID)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+
+		status := booking.GetStatus()
+		if status == models.BookingStatusPending && booking.IsExpired(now) {
+			markSeats(lapsed, booking.SeatIDs) // The expiry sweep just hasn't reached it
+			continue
+		}
+		if status != models.BookingStatusPending && status != models.BookingStatusConfirmed {
+			continue
+		}
+		for _, seatID := range booking.SeatIDs {
+			claims[seatID] = append(claims[seatID], fmt.Sprintf("%s booking %s", strings.ToLower(string(status)), booking.Reference))
+			if status == models.BookingStatusConfirmed {
+				want[seatID] = models.SeatStatusBooked
+			} else {
+				want[seatID] = models.SeatStatusBlocked
+			}
+		}
+	}
+
+	seatMap, err := h.app.GetShowService().GetSeatAvailability(ctx, h.show.ID)
+	if err != nil {
+		return nil, err
+	}
+	h.checkClaims(a, seatMap, claims)
+	h.checkSeatMap(a, seatMap, want, lapsed)
+	if err := h.checkCounters(ctx, a, seatMap); err != nil {
+		return nil, err
+	}
+	h.checkRevenue(a, seatMap, bookings)
+	return a, nil
+}
+
+// checkClaims flags every seat claimed more than once
+func (h *Harness) checkClaims(a *audit, seatMap *models.SeatMap, claims map[string][]string) {
+	for _, cell := range seats(seatMap) {
+		if owners := claims[cell.SeatID]; len(owners) > 1 {
+			a.violate(InvariantNoDoubleBooking, "%s is claimed by %s", cell.Label, strings.Join(owners, ", "))
+		}
+	}
+}
+
+// checkSeatMap compares each seat's status, and the map's available count, with what the claims imply.
+// An unclaimed seat of a lapsed hold or booking may still be blocked.
+func (h *Harness) checkSeatMap(a *audit, seatMap *models.SeatMap, want map[string]models.SeatStatus, lapsed map[string]bool) {
+	available := 0
+	for _, cell := range seats(seatMap) {
+		expected, claimed := want[cell.SeatID]
+		if !claimed {
+			expected = models.SeatStatusAvailable
+			if lapsed[cell.SeatID] && cell.Status == models.SeatStatusBlocked {
+				expected = models.SeatStatusBlocked
+			}
+		}
+		if cell.Status != expected {
+			a.violate(InvariantSeatMap, "%s shows %s, want %s", cell.Label, cell.Status, expected)
+		}
+		if cell.Status == models.SeatStatusAvailable {
+			available++
+		}
+	}
+	if seatMap.Available != available {
+		a.violate(InvariantSeatMap, "map says %d seats available, its cells %d", seatMap.Available, available)
+	}
+}
+
+// checkCounters compares the availability summary, kept up to date by events since setUp, with the seat map
+func (h *Harness) checkCounters(ctx context.Context, a *audit, seatMap *models.SeatMap) error {
+	summary, err := h.app.GetShowService().GetAvailabilitySummary(ctx, h.show.ID)
+	if err != nil {
+		return err
+	}
+
+	var total models.SeatCounts
+	byType := make(map[models.SeatType]models.SeatCounts)
+	for _, cell := range seats(seatMap) {
+		total.Total++
+		total.Add(cell.Status, 1)
+		counts := byType[cell.Type]
+		counts.Total++
+		counts.Add(cell.Status, 1)
+		byType[cell.Type] = counts
+	}
+
+	if summary.SeatCounts != total {
+		a.violate(InvariantCounters, "summary counts %+v, seat map %+v", summary.SeatCounts, total)
+	}
+	for seatType, counts := range byType {
+		if summary.ByType[seatType] != counts {
+			a.violate(InvariantCounters, "%s counts %+v, seat map %+v", seatType, summary.ByType[seatType], counts)
+		}
+	}
+	if summary.Badge != total.Badge() {
+		a.violate(InvariantCounters, "badge %s, seat map implies %s", summary.Badge, total.Badge())
+	}
+	return nil
+}
+
+// checkRevenue holds the gateway's ledger against each booking's status - confirmed bookings kept their
+// total, unpaid ones were never charged, cancelled ones kept no more than their total - and the confirmed
+// subtotals against the seat map's prices of the seats sold
+func (h *Harness) checkRevenue(a *audit, seatMap *models.SeatMap, bookings []*models.Booking) {
+	currency := h.show.BasePrice.Currency
+	subtotals := models.ZeroMoney(currency)
+	for _, booking := range bookings {
+		net := h.gateway.netFor(booking.ID, currency)
+		a.revenue = a.revenue.Add(net)
+
+		switch booking.GetStatus() {
+		case models.BookingStatusConfirmed:
+			a.confirmed++
+			subtotals = subtotals.Add(booking.SubtotalAmount)
+			if net != booking.TotalAmount {
+				a.violate(InvariantRevenue, "confirmed booking %s kept %s, total is %s", booking.Reference, net, booking.TotalAmount)
+			}
+		case models.BookingStatusCancelled:
+			if net.IsNegative() || net.GreaterThan(booking.TotalAmount) {
+				a.violate(InvariantRevenue, "cancelled booking %s kept %s of %s", booking.Reference, net, booking.TotalAmount)
+			}
+		default:
+			if !net.IsZero() {
+				a.violate(InvariantRevenue, "%s booking %s was charged %s", strings.ToLower(string(booking.GetStatus())), booking.Reference, net)
+			}
+		}
+	}
+
+	sold := models.ZeroMoney(currency)
+	for _, cell := range seats(seatMap) {
+		if cell.Status == models.SeatStatusBooked {
+			a.seatsSold++
+			sold = sold.Add(cell.Price)
+		}
+	}
+	if subtotals != sold {
+		a.violate(InvariantRevenue, "confirmed bookings charge %s for tickets, the %d seats sold are priced %s", subtotals, a.seatsSold, sold)
+	}
+}
+
+// seats returns the map's seats without its aisle gaps, in row then seat order
+func seats(seatMap *models.SeatMap) []models.SeatMapCell {
+	var cells []models.SeatMapCell
+	for _, row := range seatMap.Rows {
+		for _, cell := range row.Cells {
+			if cell.IsSeat() {
+				cells = append(cells, cell)
+			}
+		}
+	}
+	return cells
+}
+
+// markSeats adds the seats to a set
+func markSeats(set map[string]bool, seatIDs []string) {
+	for _, seatID := range seatIDs {
+		set[seatID] = true
+	}
+}
@@ -0,0 +1,56 @@
+package testkit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// RunRaceCheck runs a harness on a fresh in-memory app and prints what it did and which invariants held
+func RunRaceCheck(w io.Writer, cfg Config) (*Result, error) {
+	ctx := context.Background()
+	h, err := NewHarness(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer h.Close()
+
+	result, err := h.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	printResult(w, result)
+	return result, nil
+}
+
+// printResult writes the report; the invariant lines are the ones that matter
+func printResult(w io.Writer, r *Result) {
+	cfg := r.Config
+	fmt.Fprintf(w, "Race check: %d workers x %d random operations on %d contested seats of one show (seed %d)\n",
+		cfg.Workers, cfg.Operations, r.Contested, cfg.Seed)
+	for _, op := range Operations {
+		c := r.Counts[op]
+		fmt.Fprintf(w, "  %-13s %5d ok, %4d conflicts, %4d refused, %d failed\n", op, c.Succeeded, c.Conflicts, c.Refused, c.Failed)
+	}
+	for _, failure := range r.Failures {
+		fmt.Fprintf(w, "  ✗ %s\n", failure)
+	}
+	fmt.Fprintf(w, "  sold:          %d seats in %d confirmed bookings, %s kept by the gateway\n", r.SeatsSold, r.Confirmed, r.Revenue)
+
+	broken := make(map[string]int)
+	for _, violation := range r.Violations {
+		broken[violation.Invariant]++
+	}
+	for _, invariant := range Invariants {
+		verdict := "✓"
+		if broken[invariant] > 0 {
+			verdict = fmt.Sprintf("✗ %d violations", broken[invariant])
+		}
+		fmt.Fprintf(w, "  %-18s %s\n", invariant, verdict)
+	}
+	for _, violation := range r.Violations {
+		fmt.Fprintf(w, "    %s\n", violation)
+	}
+	fmt.Fprintf(w, "  elapsed:       %s\n", r.Elapsed.Round(time.Microsecond))
+}
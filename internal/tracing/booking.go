@@ -0,0 +1,141 @@
+package tracing
+
+import (
+	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/services"
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// BookingService wraps a booking service with a span around each step of the booking flow - demonstrates
+// Decorator Pattern. CreateBooking's span is the parent of the seat blocking span when the hold service it
+// books through is traced too.
+type BookingService struct {
+	services.BookingService
+	tracer trace.Tracer
+}
+
+// NewBookingService traces bookingService's CreateBooking and ConfirmBooking
+func NewBookingService(bookingService services.BookingService, tracer trace.Tracer) services.BookingService {
+	return &BookingService{BookingService: bookingService, tracer: tracer}
+}
+
+func (s *BookingService) CreateBooking(ctx context.Context, userID, showID string, seatIDs []string, opts ...services.BookingOption) (*models.Booking, error) {
+	ctx, span := s.tracer.Start(ctx, "booking.create", trace.WithAttributes(ids("user.id", userID, "show.id", showID)...))
+	span.SetAttributes(attribute.Int("booking.seats", len(seatIDs)))
+
+	booking, err := s.BookingService.CreateBooking(ctx, userID, showID, seatIDs, opts...)
+	if err == nil {
+		span.SetAttributes(ids("booking.id", booking.ID, "booking.reference", booking.Reference)...)
+		span.SetAttributes(attribute.String("booking.total", booking.TotalAmount.String()))
+	}
+	end(span, err)
+	return booking, err
+}
+
+func (s *BookingService) ConfirmBooking(ctx context.Context, bookingID, paymentID string) error {
+	ctx, span := s.tracer.Start(ctx, "booking.confirm", trace.WithAttributes(ids("booking.id", bookingID, "payment.id", paymentID)...))
+	err := s.BookingService.ConfirmBooking(ctx, bookingID, paymentID)
+	end(span, err)
+	return err
+}
+
+// SeatHoldService wraps a hold service so blocking seats shows up as its own span
+type SeatHoldService struct {
+	services.SeatHoldService
+	tracer trace.Tracer
+}
+
+// NewSeatHoldService traces holdService's CreateHold, which blocks the requested seats
+func NewSeatHoldService(holdService services.SeatHoldService, tracer trace.Tracer) services.SeatHoldService {
+	return &SeatHoldService{SeatHoldService: holdService, tracer: tracer}
+}
+
+func (s *SeatHoldService) CreateHold(ctx context.Context, userID, showID string, seatIDs []string) (*models.SeatHold, error) {
+	ctx, span := s.tracer.Start(ctx, "seats.block", trace.WithAttributes(ids("user.id", userID, "show.id", showID)...))
+	span.SetAttributes(attribute.StringSlice("seat.ids", seatIDs))
+
+	hold, err := s.SeatHoldService.CreateHold(ctx, userID, showID, seatIDs)
+	if err == nil {
+		span.SetAttributes(ids("hold.id", hold.ID)...)
+	}
+	if errors.Is(err, models.ErrSeatNotAvailable) {
+		span.SetAttributes(attribute.Bool("seat.conflict", true))
+	}
+	end(span, err)
+	return hold, err
+}
+
+// PaymentService wraps a payment service with a span per charge attempt. A declined charge isn't an error to
+// the caller, but its span is still marked failed so declines can be told apart from successes.
+type PaymentService struct {
+	services.PaymentService
+	tracer trace.Tracer
+}
+
+// NewPaymentService traces paymentService's ProcessPayment and RetryPayment
+func NewPaymentService(paymentService services.PaymentService, tracer trace.Tracer) services.PaymentService {
+	return &PaymentService{PaymentService: paymentService, tracer: tracer}
+}
+
+func (s *PaymentService) ProcessPayment(ctx context.Context, bookingID string, paymentMethod models.PaymentMethod, opts ...services.PaymentOption) (*models.Payment, error) {
+	ctx, span := s.startPayment(ctx, "payment.process", bookingID, paymentMethod)
+	payment, err := s.PaymentService.ProcessPayment(ctx, bookingID, paymentMethod, opts...)
+	endPayment(span, payment, err)
+	return payment, err
+}
+
+func (s *PaymentService) RetryPayment(ctx context.Context, bookingID string, paymentMethod models.PaymentMethod, opts ...services.PaymentOption) (*models.Payment, error) {
+	ctx, span := s.startPayment(ctx, "payment.retry", bookingID, paymentMethod)
+	payment, err := s.PaymentService.RetryPayment(ctx, bookingID, paymentMethod, opts...)
+	endPayment(span, payment, err)
+	return payment, err
+}
+
+func (s *PaymentService) startPayment(ctx context.Context, name, bookingID string, paymentMethod models.PaymentMethod) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, name, trace.WithAttributes(ids("booking.id", bookingID, "payment.method", string(paymentMethod))...))
+}
+
+// endPayment finishes a charge attempt's span with how the payment ended up
+func endPayment(span trace.Span, payment *models.Payment, err error) {
+	if payment != nil {
+		span.SetAttributes(ids("payment.id", payment.ID, "payment.status", string(payment.Status))...)
+		if err == nil && payment.Status == models.PaymentStatusFailed {
+			span.SetStatus(codes.Error, "payment declined: "+payment.FailureReason)
+		}
+	}
+	end(span, err)
+}
+
+// PaymentGateway wraps a gateway so the time spent with the payment provider shows up inside the charge
+type PaymentGateway struct {
+	services.PaymentGateway
+	tracer trace.Tracer
+}
+
+// NewPaymentGateway traces gateway's charges; wrap the resilient gateway so retries fall inside the span
+func NewPaymentGateway(gateway services.PaymentGateway, tracer trace.Tracer) services.PaymentGateway {
+	return &PaymentGateway{PaymentGateway: gateway, tracer: tracer}
+}
+
+func (g *PaymentGateway) ProcessPayment(ctx context.Context, amount models.Money, method models.PaymentMethod, metadata map[string]string) (*services.PaymentResult, error) {
+	ctx, span := g.tracer.Start(ctx, "payment.gateway.charge", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("payment.method", string(method)),
+		attribute.String("payment.amount", amount.String()),
+	))
+
+	result, err := g.PaymentGateway.ProcessPayment(ctx, amount, method, metadata)
+	if result != nil {
+		span.SetAttributes(attribute.Bool("payment.success", result.Success), attribute.Bool("payment.challenged", result.Challenge != nil))
+		if err == nil && !result.Success && result.Challenge == nil {
+			span.SetStatus(codes.Error, strings.TrimSpace("declined "+result.ErrorMessage))
+		}
+	}
+	end(span, err)
+	return result, err
+}
@@ -0,0 +1,108 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// InstrumentationName names the tracer every span of the booking flow comes from
+const InstrumentationName = "bookmyshow-lld"
+
+// Exporter selects where finished spans go
+type Exporter string
+
+const (
+	ExporterNone    Exporter = "none"    // Spans are not recorded at all
+	ExporterConsole Exporter = "console" // One JSON object per span, for local debugging
+)
+
+// Config controls whether spans are recorded and where they are exported
+type Config struct {
+	Exporter    Exporter
+	ServiceName string
+	Output      io.Writer // Where the console exporter writes; defaults to stderr
+}
+
+// ConfigFromEnv reads OTEL_TRACES_EXPORTER (none, console) and OTEL_SERVICE_NAME; tracing is off by default
+func ConfigFromEnv() Config {
+	config := Config{Exporter: ExporterNone, ServiceName: "bookmyshow"}
+	if exporter := Exporter(strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER")))); exporter != "" {
+		config.Exporter = exporter
+	}
+	if name := strings.TrimSpace(os.Getenv("OTEL_SERVICE_NAME")); name != "" {
+		config.ServiceName = name
+	}
+	return config
+}
+
+// New builds the tracer provider config asks for; without an exporter it is a no-op, so instrumented code
+// costs next to nothing. An unknown exporter is an error and returns the no-op provider.
+func New(config Config) (trace.TracerProvider, error) {
+	var exporter sdktrace.SpanExporter
+	switch config.Exporter {
+	case "", ExporterNone:
+		return noop.NewTracerProvider(), nil
+	case ExporterConsole:
+		output := config.Output
+		if output == nil {
+			output = os.Stderr
+		}
+		console, err := stdouttrace.New(stdouttrace.WithWriter(output))
+		if err != nil {
+			return noop.NewTracerProvider(), err
+		}
+		exporter = console
+	default:
+		return noop.NewTracerProvider(), fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q", config.Exporter)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(config.ServiceName))),
+	), nil
+}
+
+// Shutdown exports spans the provider still buffers; providers that buffer nothing are left alone
+func Shutdown(ctx context.Context, provider trace.TracerProvider) error {
+	if p, ok := provider.(interface{ Shutdown(context.Context) error }); ok {
+		return p.Shutdown(ctx)
+	}
+	return nil
+}
+
+// Nop returns a tracer that records nothing, for benchmarks and fixtures
+func Nop() trace.Tracer {
+	return noop.NewTracerProvider().Tracer(InstrumentationName)
+}
+
+// end finishes span, marking it failed when err is set so the failing step stands out in the trace
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ids records the IDs a span is about, skipping blanks
+func ids(pairs ...string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			attrs = append(attrs, attribute.String(pairs[i], pairs[i+1]))
+		}
+	}
+	return attrs
+}
@@ -1,55 +1,444 @@
 package main
 
 import (
+	"bookmyshow-lld/internal/api"
+	"bookmyshow-lld/internal/benchmarks"
+	"bookmyshow-lld/internal/cli"
+	"bookmyshow-lld/internal/config"
 	"bookmyshow-lld/internal/controllers"
 	"bookmyshow-lld/internal/factories"
+	"bookmyshow-lld/internal/filestore"
 	"bookmyshow-lld/internal/models"
+	"bookmyshow-lld/internal/scenario"
 	"bookmyshow-lld/internal/services"
+	"bookmyshow-lld/internal/sqlite"
+	"bookmyshow-lld/internal/testkit"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 )
 
 func main() {
+	serveAddr := flag.String("serve", "", "start the REST API on this address (e.g. :8080) instead of the interactive CLI (default: server.addr, else SERVER_ADDR)")
+	demo := flag.Bool("demo", false, "run the scripted design-pattern walkthrough instead of the interactive CLI")
+	cpuProfile := flag.String("cpuprofile", "", "write a CPU profile to this file, e.g. for go tool pprof")
+	memProfile := flag.String("memprofile", "", "write a heap profile to this file on exit")
+	simulate := flag.Bool("simulate", false, "release hundreds of goroutines booking overlapping seats of one show, audit for double bookings and exit")
+	bookers := flag.Int("bookers", benchmarks.DefaultSimulationConfig().Bookers, "concurrent bookers for -simulate")
+	raceCheck := flag.Bool("racecheck", false, "run randomized concurrent hold/book/pay/cancel workers against one show, check the seat invariants and exit")
+	workers := flag.Int("workers", testkit.DefaultConfig().Workers, "concurrent workers for -racecheck")
+	ops := flag.Int("ops", testkit.DefaultConfig().Operations, "random operations per worker for -racecheck")
+	seed := flag.Int64("seed", testkit.DefaultConfig().Seed, "random seed for -racecheck")
+	scenarioPath := flag.String("scenario", "", "run the steps of a YAML or JSON scenario (see scenarios/) against a fresh in-memory app and exit")
+	store := flag.String("store", "", "where state lives: memory, or sqlite or file to keep it across runs (default: sqlite when SQLITE_PATH is set, else file when FILESTORE_DIR is)")
+	importCatalog := flag.Bool("import-catalog", false, "with -serve, import movies from CATALOG_SOURCE (default: the bundled fixture) on start")
+	loadState := flag.String("load-state", "", "import a state file written by -save-state into the empty app on start")
+	saveState := flag.String("save-state", "", "export the whole app state to this JSON file once the CLI or -demo finishes")
+	dbPath := flag.String("db", "", "database file for -store=sqlite (default: SQLITE_PATH, else "+sqlite.DefaultPath+")")
+	dataDir := flag.String("dir", "", "data directory for -store=file (default: FILESTORE_DIR, else "+filestore.DefaultDir+")")
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML file of settings (see config.example.yaml); environment variables and flags override it")
+	flag.Parse()
+
+	stopProfiling, err := startProfiling(*cpuProfile, *memProfile)
+	if err != nil {
+		log.Fatal("Failed to start profiling:", err)
+	}
+	defer stopProfiling()
+
+	if *simulate {
+		cfg := benchmarks.DefaultSimulationConfig()
+		cfg.Bookers = *bookers
+		log.SetOutput(io.Discard) // Hundreds of booking events would bury the report
+		result, err := benchmarks.RunSimulation(os.Stdout, cfg)
+		if err != nil {
+			log.SetOutput(os.Stderr)
+			log.Fatal("Simulation failed:", err)
+		}
+		if !result.OK() {
+			stopProfiling()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *raceCheck {
+		cfg := testkit.DefaultConfig()
+		cfg.Workers, cfg.Operations, cfg.Seed = *workers, *ops, *seed
+		log.SetOutput(io.Discard)
+		result, err := testkit.RunRaceCheck(os.Stdout, cfg)
+		if err != nil {
+			log.SetOutput(os.Stderr)
+			log.Fatal("Race check failed:", err)
+		}
+		if !result.OK() {
+			stopProfiling()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *scenarioPath != "" {
+		code := runScenario(*scenarioPath)
+		stopProfiling()
+		os.Exit(code)
+	}
+
 	fmt.Println("🎬 BookMyShow Low Level Design Learning Prototype")
 	fmt.Println("==================================================")
 	fmt.Println("🎯 Focus: Core Design Patterns & SOLID Principles")
 
+	// Defaults, then the config file, then the environment, then flags
+	settings, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load configuration:\n%v", err)
+	}
+	if *store != "" {
+		settings.Storage.Backend = config.Backend(*store)
+	}
+	if *dbPath != "" {
+		settings.Storage.SQLitePath = *dbPath
+	}
+	if *dataDir != "" {
+		settings.Storage.FileDir = *dataDir
+	}
+	if *serveAddr != "" {
+		settings.Server.Addr = *serveAddr
+	}
+	if err := settings.Validate(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+	opts := []controllers.Option{controllers.WithSettings(settings)}
+
 	// Get application controller - demonstrates Singleton + Dependency Injection
-	appController := controllers.GetAppController()
+	appController := controllers.GetAppController(opts...)
 	defer appController.Shutdown()
 
+	// Replay a saved session, and save this one when it ends; deferred after Shutdown so it runs first
+	if *loadState != "" {
+		if err := importState(appController, *loadState); err != nil {
+			log.Fatal("Failed to load state:", err)
+		}
+		fmt.Printf("📂 Loaded state from %s\n", *loadState)
+	}
+	if *saveState != "" {
+		defer func() {
+			if err := exportState(appController, *saveState); err != nil {
+				log.Fatal("Failed to save state:", err)
+			}
+			fmt.Printf("💾 Saved state to %s\n", *saveState)
+		}()
+	}
+
 	// Get services through controller - demonstrates clean architecture
 	userService := appController.GetUserService()
+	authService := appController.GetAuthService()
 	movieService := appController.GetMovieService()
+	catalogImporter := appController.GetCatalogImporter()
+	eventService := appController.GetEventService()
 	theatreService := appController.GetTheatreService()
 	showService := appController.GetShowService()
 	bookingService := appController.GetBookingService()
 	paymentService := appController.GetPaymentService()
+	promotionService := appController.GetPromotionService()
+	seatHoldService := appController.GetSeatHoldService()
+	adminService := appController.GetAdminService()
+	ticketService := appController.GetTicketService()
+	checkInService := appController.GetCheckInService()
+	walletService := appController.GetWalletService()
+	loyaltyService := appController.GetLoyaltyService()
+	reportingService := appController.GetReportingService()
+	reviewService := appController.GetReviewService()
+
+	if settings.Server.Addr != "" {
+		// Expose services over HTTP so the flow can be driven from curl/Postman
+		server := api.NewServer(
+			userService,
+			appController.GetPreferenceService(),
+			appController.GetRecommendationService(),
+			appController.GetWatchlistService(),
+			appController.GetDealsService(),
+			appController.GetParkingService(),
+			appController.GetResaleService(),
+			appController.GetPaymentInstrumentService(),
+			appController.GetShowCloseOutService(),
+			authService,
+			movieService,
+			catalogImporter,
+			eventService,
+			theatreService,
+			showService,
+			bookingService,
+			appController.GetBulkBookingService(),
+			paymentService,
+			appController.GetPaymentReconciliationService(),
+			appController.GetCallbackVerifier(),
+			promotionService,
+			seatHoldService,
+			adminService,
+			appController.GetAuthorizer(),
+			ticketService,
+			appController.GetTicketRenderer(),
+			checkInService,
+			reviewService,
+			walletService,
+			loyaltyService,
+			reportingService,
+			appController.GetSettlementService(),
+			appController.GetWebhookService(),
+			appController.GetSeatFactory(),
+			appController.GetSeatHub(),
+			appController.GetMetricsHandler(),
+			appController,
+			appController.GetTracerProvider(),
+			appController.GetRateLimits(),
+			appController.GetClock(),
+		)
+
+		// Bootstrap admin so theatre partners can be onboarded via /admin routes
+		admin, err := findOrCreateUser(context.Background(), userService, "Admin", "admin@bookmyshow.local", "+10000000000", models.UserRoleSuperAdmin)
+		if err != nil {
+			log.Fatal("Failed to create admin user:", err)
+		}
+		password, err := adminPassword()
+		if err != nil {
+			log.Fatal("Failed to generate admin password:", err)
+		}
+		if err := authService.SetPassword(context.Background(), admin.ID, password); err != nil {
+			log.Fatal("Failed to set admin password:", err)
+		}
+		fmt.Printf("🔑 Admin login (POST /auth/login): %s / %s\n", admin.Email, password)
+		if *importCatalog {
+			result, err := catalogImporter.Import(services.WithCaller(context.Background(), admin.ID))
+			if err != nil {
+				log.Fatal("Failed to import catalog:", err)
+			}
+			fmt.Printf("🎞️ Imported catalog from %s: %d new, %d updated, %d skipped\n", result.Source, result.Created, result.Updated, len(result.Skipped))
+		}
+		fmt.Printf("🌐 REST API listening on %s\n", settings.Server.Addr)
+		if err := serve(settings.Server.Addr, server.Handler(), appController.GetSeatHub().Close, time.Duration(settings.Server.ShutdownTimeout)); err != nil {
+			log.Fatal("REST API failed:", err)
+		}
+		return
+	}
+
+	if !*demo {
+		// Drive the booking flow by hand; an empty store gets a catalog, a theatre and shows to book
+		shell := cli.New(appController, os.Stdin, os.Stdout)
+		admin, err := findOrCreateUser(context.Background(), userService, "Admin", "admin@bookmyshow.local", "+10000000000", models.UserRoleSuperAdmin)
+		if err != nil {
+			log.Fatal("Failed to create admin user:", err)
+		}
+		if err := shell.SeedIfEmpty(context.Background(), admin.ID); err != nil {
+			fmt.Printf("⚠️ Could not seed demo data: %v\n", err)
+		}
+		if err := shell.Run(context.Background()); err != nil {
+			log.Fatal("CLI failed:", err)
+		}
+		return
+	}
 
 	// Run focused demo showcasing design patterns
-	runApi(userService, movieService, theatreService, showService, bookingService, paymentService)
+	runApi(context.Background(), userService, authService, movieService, catalogImporter, eventService, theatreService, showService, bookingService, paymentService, promotionService, seatHoldService, adminService, ticketService, checkInService, walletService, loyaltyService, appController.GetSeatFactory())
+}
+
+// importState loads a state file into the app, which must hold no data yet
+func importState(app *controllers.AppController, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return app.ImportState(file)
+}
+
+// exportState writes the app's whole state to path, replacing the file
+func exportState(app *controllers.AppController, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := app.ExportState(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// findOrCreateUser reuses a user kept by -store=sqlite from an earlier run, creating it otherwise
+// runScenario runs a scenario file and returns the exit code: 1 when it broke or an assertion failed
+// startProfiling starts a CPU profile when cpuPath is set; the returned stop ends it and writes a heap profile
+// when memPath is set. Stop is safe to call more than once, so paths that exit early can call it first.
+func startProfiling(cpuPath, memPath string) (stop func(), err error) {
+	var cpuFile *os.File
+	if cpuPath != "" {
+		if cpuFile, err = os.Create(cpuPath); err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(cpuFile); err != nil {
+			cpuFile.Close()
+			return nil, err
+		}
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if cpuFile != nil {
+				pprof.StopCPUProfile()
+				cpuFile.Close()
+			}
+			if memPath == "" {
+				return
+			}
+			memFile, err := os.Create(memPath)
+			if err != nil {
+				log.Println("Failed to write heap profile:", err)
+				return
+			}
+			defer memFile.Close()
+			runtime.GC() // Up-to-date allocation statistics
+			if err := pprof.WriteHeapProfile(memFile); err != nil {
+				log.Println("Failed to write heap profile:", err)
+			}
+		})
+	}, nil
+}
+
+func runScenario(path string) int {
+	script, err := scenario.Load(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	log.SetOutput(io.Discard) // Keep event bus chatter out of the assertions
+	report, err := scenario.Run(context.Background(), script, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if !report.OK() {
+		return 1
+	}
+	return 0
+}
+
+func findOrCreateUser(ctx context.Context, userService services.UserService, name, email, phoneNumber string, role models.UserRole) (*models.User, error) {
+	if user, err := userService.GetUserByEmail(ctx, email); err == nil {
+		return user, nil
+	}
+	return userService.CreateUserWithRole(ctx, name, email, phoneNumber, role)
+}
+
+// adminPassword reads ADMIN_PASSWORD, generating a fresh one per start when it is unset
+func adminPassword() (string, error) {
+	if password := os.Getenv("ADMIN_PASSWORD"); password != "" {
+		return password, nil
+	}
+	raw := make([]byte, 12)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// serve runs the REST API until SIGINT or SIGTERM, then stops accepting connections and gives requests under
+// way up to timeout to finish; closeStreams ends the seat streams that would otherwise never go idle. The
+// deferred AppController.Shutdown drains the rest once serve returns.
+func serve(addr string, handler http.Handler, closeStreams func(), timeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	httpServer := &http.Server{Addr: addr, Handler: handler}
+	httpServer.RegisterOnShutdown(closeStreams)
+	failed := make(chan error, 1)
+	go func() {
+		failed <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-failed:
+		return err
+	case <-ctx.Done():
+	}
+
+	fmt.Println("🛑 Shutting down: finishing requests under way")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return httpServer.Shutdown(ctx)
 }
 
 func runApi(
+	ctx context.Context,
 	userService services.UserService,
+	authService services.AuthService,
 	movieService services.MovieService,
+	catalogImporter services.CatalogImporter,
+	eventService services.EventService,
 	theatreService services.TheatreService,
 	showService services.ShowService,
 	bookingService services.BookingService,
 	paymentService services.PaymentService,
+	promotionService services.PromotionService,
+	seatHoldService services.SeatHoldService,
+	adminService services.AdminService,
+	ticketService services.TicketService,
+	checkInService services.CheckInService,
+	walletService services.WalletService,
+	loyaltyService services.LoyaltyService,
+	seatFactory *factories.SeatFactory,
 ) {
 	fmt.Println("\n📚 1. Repository Pattern - Creating Core Entities")
 
 	// Create users - demonstrates Repository Pattern
-	user1, err := userService.CreateUser("John Doe", "john@example.com", "+1234567890")
+	user1, err := findOrCreateUser(ctx, userService, "John Doe", "john@example.com", "+1234567890", models.UserRoleCustomer)
 	if err != nil {
 		log.Fatal("Failed to create user:", err)
 	}
 	fmt.Printf("✅ Created user: %s (Repository Pattern)\n", user1.Name)
 
+	// Sign John in - the session token, not a user ID in the request, says who is calling
+	if err := authService.SetPassword(ctx, user1.ID, "john-secret"); err != nil {
+		log.Fatal("Failed to set password:", err)
+	}
+	if _, err := authService.Login(ctx, user1.Email, "wrong-password"); err != nil {
+		fmt.Printf("🔒 Wrong password refused: %v\n", err)
+	}
+	session, err := authService.Login(ctx, user1.Email, "john-secret")
+	if err != nil {
+		log.Fatal("Failed to log in:", err)
+	}
+	if user1, err = authService.Authenticate(ctx, session.Token); err != nil {
+		log.Fatal("Failed to authenticate:", err)
+	}
+	fmt.Printf("🔑 %s signed in until %s (Session Authentication)\n", user1.Name, session.ExpiresAt.Format("Jan 2 15:04"))
+
+	// The catalog is run by a super admin; services read the caller from the context
+	admin, err := findOrCreateUser(ctx, userService, "Admin", "admin@bookmyshow.local", "+10000000000", models.UserRoleSuperAdmin)
+	if err != nil {
+		log.Fatal("Failed to create admin user:", err)
+	}
+	platformCtx := services.WithCaller(ctx, admin.ID)
+	if _, err := movieService.CreateMovie(services.WithCaller(ctx, user1.ID), "Bootleg", "Not on the catalog", time.Hour, models.GenreDrama, models.LanguageEnglish, 1, time.Now()); err != nil {
+		fmt.Printf("🛡️ %s can't add movies: %v (Role-Based Access Control)\n", user1.Name, err)
+	}
+
 	// Create movie - demonstrates Repository Pattern
 	movie1, err := movieService.CreateMovie(
+		platformCtx,
 		"Avengers: Endgame",
 		"Epic superhero finale",
 		3*time.Hour,
@@ -63,29 +452,67 @@ func runApi(
 	}
 	fmt.Printf("✅ Created movie: %s (Repository Pattern)\n", movie1.Title)
 
+	// Bulk-load real listings from a movie source; a second run updates them instead of duplicating
+	for run := 1; run <= 2; run++ {
+		imported, err := catalogImporter.Import(platformCtx)
+		if err != nil {
+			fmt.Printf("⚠️ Catalog import skipped: %v\n", err)
+			break
+		}
+		fmt.Printf("🎞️ Import #%d from %s: %d new, %d updated (Adapter Pattern)\n", run, imported.Source, imported.Created, imported.Updated)
+	}
+
 	// Create theatre - demonstrates Repository Pattern
-	theatre1, err := theatreService.CreateTheatre("PVR Cinemas", "Phoenix Mall", "Mumbai")
+	theatre1, err := theatreService.CreateTheatre(platformCtx, "PVR Cinemas", "Phoenix Mall", "Mumbai",
+		services.WithLocation(models.GeoPoint{Latitude: 18.9947, Longitude: 72.8258}))
 	if err != nil {
 		log.Fatal("Failed to create theatre:", err)
 	}
 	fmt.Printf("✅ Created theatre: %s (Repository Pattern)\n", theatre1.Name)
 
+	// Screens and shows are run by the theatre's own admin, who can't touch other theatres or the catalog
+	manager, err := findOrCreateUser(ctx, userService, "PVR Manager", "manager@pvr.example.com", "+1987654321", models.UserRoleCustomer)
+	if err != nil {
+		log.Fatal("Failed to create theatre manager:", err)
+	}
+	if manager, err = adminService.GrantRole(ctx, admin.ID, manager.ID, models.UserRoleTheatreAdmin, theatre1.ID); err != nil {
+		log.Fatal("Failed to grant theatre admin role:", err)
+	}
+	theatreCtx := services.WithCaller(ctx, manager.ID)
+	fmt.Printf("🛡️ %s is %s of %s\n", manager.Name, manager.Role, theatre1.Name)
+
+	// Pick city, then pick theatre - the city catalog grows as theatres open
+	cities, _ := theatreService.GetCities(ctx)
+	for _, city := range cities {
+		cityTheatres, _ := theatreService.GetTheatresByCity(ctx, city.ID)
+		fmt.Printf("🏙️ %s: %d theatre(s)\n", city.Name, len(cityTheatres))
+	}
+	if nearby, err := theatreService.GetTheatresNearLocation(ctx, 19.0176, 72.8562, 10); err == nil {
+		for _, n := range nearby {
+			fmt.Printf("📍 %s is %.1f km from Dadar\n", n.Theatre.Name, n.DistanceKm)
+		}
+	}
+
 	fmt.Println("\n🏭 2. Factory Pattern - Creating Complex Objects")
 
 	// Create screen with seats using Factory Pattern
 	screen1 := models.NewScreen("Screen 1", theatre1.ID)
 
 	// Use SeatFactory to create seats - demonstrates Factory Pattern
-	seatFactory := factories.NewSeatFactory()
-	seats := seatFactory.CreateDefaultScreenSeats(100.0) // Base price: $100
+	basePrice := models.NewMoney(10000, models.DefaultCurrency) // Base price: $100
+	seats := seatFactory.CreateDefaultScreenSeats(basePrice)
 	fmt.Printf("🏭 Factory Pattern: Created %d seats with different types and pricing\n", len(seats))
 
 	for _, seat := range seats {
 		screen1.AddSeat(seat)
 	}
+	seatFactory.ApplyAisles(screen1, factories.DefaultScreenConfig())
+	if err := seatFactory.ApplySeatGroups(screen1, factories.DefaultScreenConfig()); err != nil {
+		log.Fatal("Failed to group seats:", err)
+	}
 
 	// Add screen to theatre
-	err = theatreService.AddScreen(theatre1.ID, screen1)
+	err = theatreService.AddScreen(theatreCtx, theatre1.ID, screen1)
 	if err != nil {
 		log.Fatal("Failed to add screen:", err)
 	}
@@ -95,7 +522,7 @@ func runApi(
 
 	// Create show - demonstrates business rules and validation
 	showTime1 := time.Now().Add(2 * time.Hour)
-	show1, err := showService.CreateShow(movie1.ID, theatre1.ID, screen1.ID, showTime1, 100.0)
+	show1, err := showService.CreateShow(theatreCtx, movie1.ID, theatre1.ID, screen1.ID, showTime1, basePrice)
 	if err != nil {
 		log.Fatal("Failed to create show:", err)
 	}
@@ -103,49 +530,125 @@ func runApi(
 
 	fmt.Println("\n🔒 4. Concurrency Control - Thread-Safe Booking")
 
-	// Get available seats for booking
-	availableSeats := screen1.GetAvailableSeats()
-	if len(availableSeats) < 3 {
+	// Pick seats from the ordered seat map, as a seat-picker UI would
+	seatMap, err := showService.GetSeatAvailability(ctx, show1.ID)
+	if err != nil {
+		log.Fatal("Failed to load seat map:", err)
+	}
+	fmt.Printf("🗺️ Seat map: %d rows, %d/%d seats available\n", len(seatMap.Rows), seatMap.Available, seatMap.Capacity)
+	fmt.Printf("   %s\n", cli.RenderSeatRow(seatMap.Rows[0]))
+
+	// Or let the service pick the best block of adjacent seats
+	suggestion, err := bookingService.SuggestSeats(ctx, show1.ID, 4, models.SeatTypeRegular)
+	if err != nil {
+		log.Fatal("Failed to suggest seats:", err)
+	}
+	fmt.Printf("💺 Suggested %d adjacent %s seats in row %s: %s (%s)\n",
+		len(suggestion.SeatIDs), suggestion.Type, suggestion.Row, strings.Join(suggestion.Labels, ", "), suggestion.Total)
+
+	// Couple recliners are one group - half of one can't be held
+	backRow := seatMap.Rows[len(seatMap.Rows)-1]
+	if coupleSeat := backRow.Cells[0]; coupleSeat.Group != "" {
+		if _, err := seatHoldService.CreateHold(ctx, user1.ID, show1.ID, []string{coupleSeat.SeatID}); err != nil {
+			fmt.Printf("💑 %s alone refused: %v\n", coupleSeat.Label, err)
+		}
+	}
+
+	// Companion seats go only with the wheelchair space beside them
+	for _, row := range seatMap.Rows {
+		for _, cell := range row.Cells {
+			if !slices.Contains(cell.Attributes, models.SeatAttributeCompanion) {
+				continue
+			}
+			if _, err := bookingService.CreateBooking(ctx, user1.ID, show1.ID, []string{cell.SeatID}); err != nil {
+				fmt.Printf("♿ %s alone refused: %v\n", cell.Label, err)
+			}
+			break
+		}
+	}
+
+	var seatIDs []string
+	for _, cell := range seatMap.Rows[0].Cells {
+		if cell.IsSeat() && cell.Status == models.SeatStatusAvailable && len(seatIDs) < 3 {
+			seatIDs = append(seatIDs, cell.SeatID)
+		}
+	}
+	if len(seatIDs) < 3 {
 		log.Fatal("Not enough seats available")
 	}
-	seatIDs := []string{availableSeats[0].ID, availableSeats[1].ID, availableSeats[2].ID}
 
-	// Book seats - demonstrates concurrency control
-	booking1, err := bookingService.CreateBooking(user1.ID, show1.ID, seatIDs)
+	// Create a promo code - demonstrates business rules for discounts; -store=sqlite keeps it from an earlier run
+	if _, err = promotionService.GetCoupon(ctx, "FIRST10"); err != nil {
+		_, err = promotionService.CreateCoupon(platformCtx, "FIRST10", models.DiscountTypePercentage, 10, basePrice, time.Now().AddDate(0, 1, 0), 100)
+	}
+	if err != nil {
+		log.Fatal("Failed to create coupon:", err)
+	}
+
+	// Hold seats for the user - blocked seats are tied to a user with a TTL
+	hold1, err := seatHoldService.CreateHold(ctx, user1.ID, show1.ID, seatIDs)
+	if err != nil {
+		log.Fatal("Failed to hold seats:", err)
+	}
+	fmt.Printf("⏳ Held %d seats until %s\n", len(hold1.SeatIDs), hold1.ExpiresAt.Format("15:04"))
+
+	// Book held seats - demonstrates concurrency control
+	booking1, err := bookingService.CreateBooking(ctx, user1.ID, show1.ID, seatIDs, services.WithHold(hold1.ID), services.WithCoupon("FIRST10"))
 	if err != nil {
 		log.Fatal("Failed to create booking:", err)
 	}
-	fmt.Printf("🔒 Thread-safe booking created: $%.2f (Concurrency Control)\n", booking1.TotalAmount)
+	fmt.Printf("🔒 Thread-safe booking %s created: %s (Concurrency Control)\n", booking1.Reference, booking1.TotalAmount)
+	fmt.Printf("🎟️ Coupon %s applied: -%s off %s\n", booking1.CouponCode, booking1.DiscountAmount, booking1.SubtotalAmount)
 
 	fmt.Println("\n🔄 5. Strategy Pattern - Payment Processing")
 
 	// Process payment using Strategy Pattern - different payment methods
-	payment1, err := paymentService.ProcessPayment(booking1.ID, models.PaymentMethodUPI)
+	payment1, err := paymentService.ProcessPayment(ctx, booking1.ID, models.PaymentMethodUPI)
+
+	// A failed attempt keeps the booking pending - retry with alternate methods up to the cap
+	for _, method := range []models.PaymentMethod{models.PaymentMethodCreditCard, models.PaymentMethodNetBanking} {
+		if payment1 == nil || payment1.IsSuccessful() {
+			break
+		}
+		fmt.Printf("🔁 %s attempt %d failed (%s), retrying with %s\n", payment1.Method, payment1.Attempt, payment1.FailureReason, method)
+		payment1, err = paymentService.RetryPayment(ctx, booking1.ID, method)
+	}
+
 	if err != nil {
 		log.Printf("❌ Payment failed: %v", err)
 	} else {
-		fmt.Printf("🔄 Strategy Pattern: %s payment processed ($%.2f)\n", payment1.Method, payment1.Amount)
+		fmt.Printf("🔄 Strategy Pattern: %s payment processed (%s)\n", payment1.Method, payment1.Amount)
 
 		if payment1.IsSuccessful() {
 			// Confirm booking
-			err = bookingService.ConfirmBooking(booking1.ID, payment1.ID)
+			err = bookingService.ConfirmBooking(ctx, booking1.ID, payment1.ID)
 			if err != nil {
 				log.Printf("❌ Failed to confirm booking: %v", err)
 			} else {
 				fmt.Printf("✅ Booking confirmed! (Transaction: %s)\n", payment1.TransactionID)
+				if account, err := loyaltyService.GetAccount(ctx, user1.ID); err == nil {
+					fmt.Printf("⭐ Loyalty points earned: %d\n", account.GetPoints())
+				}
 			}
 		}
 	}
 
 	fmt.Println("\n📢 6. Observer Pattern - Notifications")
-	fmt.Println("📧 Notification sent via Observer Pattern (check logs above)")
+	fmt.Println("📧 EventBus published booking events to notification subscribers (check logs above)")
 
 	fmt.Println("\n🎯 7. Demonstrating Seat Pricing (Factory Pattern)")
 
+	// New seat types are registered at runtime rather than added to the factory's code
+	sofa := factories.SeatTypeInfo{Name: "Sofa", Description: "Two-seater sofa with a side table", Multiplier: 3.0}
+	if _, err := adminService.RegisterSeatType(ctx, admin.ID, "SOFA", sofa); err != nil {
+		log.Printf("Failed to register seat type: %v", err)
+	}
+
 	// Show seat pricing using Factory pattern
 	seatInfo := seatFactory.GetSeatTypeInfo()
 	fmt.Println("💺 Factory Pattern - Different seat types and pricing:")
-	for seatType, info := range seatInfo {
+	for _, seatType := range seatFactory.Registry().Types() {
+		info := seatInfo[seatType]
 		fmt.Printf("   %s: %s (%.1fx base price)\n",
 			seatType, info.Description, info.Multiplier)
 	}
@@ -153,11 +656,12 @@ func runApi(
 	fmt.Println("\n🏗️ 8. Getting Aggregate Data")
 
 	// Get detailed booking information - demonstrates aggregate construction
-	bookingDetails, err := bookingService.GetBookingDetails(booking1.ID)
+	bookingDetails, err := bookingService.GetBookingDetails(ctx, booking1.ID)
 	if err != nil {
 		log.Printf("Failed to get booking details: %v", err)
 	} else {
 		fmt.Printf("📋 Aggregate Construction:\n")
+		fmt.Printf("   Reference: %s\n", bookingDetails.Booking.Reference)
 		fmt.Printf("   Movie: %s (%s)\n", bookingDetails.Movie.Title, bookingDetails.Movie.Language)
 		fmt.Printf("   Theatre: %s, %s\n", bookingDetails.Theatre.Name, bookingDetails.Theatre.City)
 		fmt.Printf("   Seats: ")
@@ -165,9 +669,131 @@ func runApi(
 			if i > 0 {
 				fmt.Print(", ")
 			}
-			fmt.Printf("%s%d (%s-$%.0f)", seat.RowName, seat.Number, seat.Type, seat.Price)
+			fmt.Printf("%s%d (%s-%s)", seat.RowName, seat.Number, seat.Type, seat.Price)
 		}
-		fmt.Printf("\n   Total: $%.2f | Status: %s\n", bookingDetails.Booking.TotalAmount, bookingDetails.Booking.GetStatus())
+		breakdown := bookingDetails.PriceBreakdown
+		fmt.Printf("\n   Fee: %s | CGST: %s | SGST: %s\n", breakdown.ConvenienceFee, breakdown.CGST, breakdown.SGST)
+		fmt.Printf("   Total: %s | Status: %s\n", bookingDetails.Booking.TotalAmount, bookingDetails.Booking.GetStatus())
+	}
+
+	// "My Bookings" view - summaries grouped into upcoming, past and cancelled
+	history, err := bookingService.GetUserBookings(ctx, user1.ID, services.BookingFilter{})
+	if err != nil {
+		log.Printf("Failed to get booking history: %v", err)
+	} else {
+		fmt.Printf("🗂️ My Bookings for %s (%d total):\n", user1.Name, history.Total)
+		for _, summary := range history.Bookings {
+			fmt.Printf("   [%s] %s @ %s - seats %v - %s\n", summary.Category, summary.Title, summary.TheatreName, summary.Seats, summary.TotalAmount)
+		}
+	}
+
+	// E-ticket - issued by an event subscriber on confirmation, scanned once at entry
+	ticket, err := ticketService.GetTicketForBooking(ctx, booking1.ID)
+	if err != nil {
+		log.Printf("No ticket issued: %v", err)
+	} else {
+		fmt.Printf("🎫 E-ticket %s issued (QR payload %d chars)\n", ticket.ID, len(ticket.Payload))
+		if _, err := checkInService.CheckIn(theatreCtx, ticket.TheatreID, "Gate 1", ticket.Payload); err != nil {
+			log.Printf("Check-in failed: %v", err)
+		} else {
+			fmt.Printf("🚪 Checked in at Gate 1\n")
+		}
+		if _, err := checkInService.CheckIn(theatreCtx, ticket.TheatreID, "Gate 2", ticket.Payload); err != nil {
+			fmt.Printf("🚫 Second scan at Gate 2 rejected: %v\n", err)
+		}
+	}
+
+	fmt.Println("\n🗓️ 9. Show Rescheduling & Cancellation Cascade")
+
+	// A late IMAX screening of the Hindi dub gets booked, moved by an hour, then called off
+	show2, err := showService.CreateShow(theatreCtx, movie1.ID, theatre1.ID, screen1.ID, showTime1.Add(4*time.Hour), basePrice,
+		services.WithFormat(models.ShowFormatIMAX), services.WithLanguage(models.LanguageHindi))
+	if err != nil {
+		log.Fatal("Failed to create late show:", err)
+	}
+	if hindiShows, err := showService.SearchShows(ctx, services.ShowFilter{MovieID: movie1.ID, Language: models.LanguageHindi}); err == nil {
+		for _, s := range hindiShows {
+			fmt.Printf("🎞️ %s %s show at %s (+%s per seat)\n", s.Language, s.Format, s.StartTime.Format("15:04"), s.FormatSurcharge)
+		}
+	}
+	lateSeats, err := bookingService.SuggestSeats(ctx, show2.ID, 2, "")
+	if err != nil {
+		log.Fatal("Failed to suggest seats:", err)
+	}
+	booking2, err := bookingService.CreateBooking(ctx, user1.ID, show2.ID, lateSeats.SeatIDs)
+	if err != nil {
+		log.Fatal("Failed to book late show:", err)
+	}
+	// Part of it is paid with the points from the first booking
+	if account, err := loyaltyService.GetAccount(ctx, user1.ID); err == nil && account.GetPoints() > 0 {
+		if _, err := loyaltyService.RedeemPoints(ctx, user1.ID, booking2.ID, account.GetPoints()); err != nil {
+			log.Printf("Failed to redeem points: %v", err)
+		} else {
+			breakdown := booking2.GetPriceBreakdown()
+			fmt.Printf("⭐ Redeemed %d points for %s, %s left to pay\n", breakdown.LoyaltyPoints, breakdown.LoyaltyValue, booking2.TotalAmount)
+		}
+	}
+
+	// Paid from the wallet, so the cancellation refund lands back in it
+	if _, err := walletService.TopUp(ctx, user1.ID, models.MoneyFromMajor(1000, models.DefaultCurrency)); err != nil {
+		log.Printf("Failed to top up wallet: %v", err)
+	}
+	if payment2, err := paymentService.ProcessPayment(ctx, booking2.ID, models.PaymentMethodWallet); err == nil && payment2.IsSuccessful() {
+		bookingService.ConfirmBooking(ctx, booking2.ID, payment2.ID)
+	}
+	fmt.Printf("🎟️ Booked %v for the late show (%s)\n", lateSeats.Labels, booking2.GetStatus())
+	if wallet, err := walletService.GetWallet(ctx, user1.ID); err == nil {
+		fmt.Printf("👛 Wallet balance after paying: %s\n", wallet.GetBalance())
+	}
+
+	if reschedule, err := showService.RescheduleShow(theatreCtx, show2.ID, show2.StartTime.Add(time.Hour)); err != nil {
+		log.Printf("Failed to reschedule show: %v", err)
+	} else {
+		fmt.Printf("🕒 Show moved %s → %s (%d user(s) notified)\n",
+			reschedule.PreviousStart.Format("15:04"), reschedule.Show.StartTime.Format("15:04"), reschedule.NotifiedUsers)
+	}
+
+	if cancellation, err := showService.CancelShow(theatreCtx, show2.ID, "projector failure"); err != nil {
+		log.Printf("Failed to cancel show: %v", err)
+	} else {
+		for _, cancelled := range cancellation.Bookings {
+			fmt.Printf("🚫 Booking %s cancelled with %d refund(s)\n", cancelled.BookingID, len(cancelled.Refunds))
+		}
+	}
+	if wallet, err := walletService.GetWallet(ctx, user1.ID); err == nil {
+		fmt.Printf("👛 Wallet balance after the refund: %s\n", wallet.GetBalance())
+	}
+	if account, err := loyaltyService.GetAccount(ctx, user1.ID); err == nil {
+		fmt.Printf("⭐ Loyalty points after the cancellation: %d\n", account.GetPoints())
+	}
+
+	fmt.Println("\n🎤 10. Live Events - Same Shows, Seats and Bookings")
+
+	// A stand-up set is scheduled and booked exactly like a movie show
+	standup, err := eventService.CreateEvent(
+		platformCtx,
+		"Comedy Night Live",
+		"Ninety minutes of stand-up",
+		models.EventTypeStandup,
+		90*time.Minute,
+		models.LanguageEnglish,
+		[]string{"Zakir Khan"},
+	)
+	if err != nil {
+		log.Fatal("Failed to create event:", err)
+	}
+	standupShow, err := showService.CreateEventShow(theatreCtx, standup.ID, theatre1.ID, screen1.ID, showTime1.Add(8*time.Hour), basePrice)
+	if err != nil {
+		log.Fatal("Failed to create event show:", err)
+	}
+	fmt.Printf("✅ Scheduled %s %q at %s\n", standupShow.EventType, standup.Title, standupShow.StartTime.Format("15:04"))
+
+	if suggestion, err := bookingService.SuggestSeats(ctx, standupShow.ID, 2, models.SeatTypePremium); err != nil {
+		log.Printf("Failed to suggest seats: %v", err)
+	} else if booking3, err := bookingService.CreateBooking(ctx, user1.ID, standupShow.ID, suggestion.SeatIDs); err != nil {
+		log.Printf("Failed to book event: %v", err)
+	} else if details, err := bookingService.GetBookingDetails(ctx, booking3.ID); err == nil {
+		fmt.Printf("🎟️ Booked %v for %s - %s\n", suggestion.Labels, details.Event.Title, booking3.TotalAmount)
 	}
 
 	fmt.Println("\n✨ Learning Demo Completed Successfully!")
@@ -176,7 +802,7 @@ func runApi(
 	fmt.Println("   🔄 Strategy Pattern: Multiple payment methods (UPI, Credit Card, etc.)")
 	fmt.Println("   🔒 Singleton Pattern: AppController manages application lifecycle")
 	fmt.Println("   📦 Repository Pattern: Clean data access abstraction")
-	fmt.Println("   📢 Observer Pattern: Notification system for booking events")
+	fmt.Println("   📢 Observer Pattern: EventBus delivers booking/payment events to subscribers")
 	fmt.Println("   🔐 Concurrency Control: Thread-safe seat booking with atomic operations")
 
 	fmt.Println("\n⚡ SOLID Principles Applied:")